@@ -0,0 +1,79 @@
+package baseapp
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// archiveHeightKeyFmt indexes the multistore version committed at a block height.
+// It lives in the app DB beside the multistore commit info.
+const archiveHeightKeyFmt = "archive/h/%d" // archive/h/<height>
+
+// historical queries hit cold data, so keep the per-query account cache small
+const archiveAccountCacheCap = 100
+
+func archiveHeightKey(height int64) []byte {
+	return []byte(fmt.Sprintf(archiveHeightKeyFmt, height))
+}
+
+// SetHistoricalAccountStore tells an archive node where accounts are stored, so
+// that historical custom queries can build an account cache over the account
+// store as of the queried height.
+func (app *BaseApp) SetHistoricalAccountStore(cdc *codec.Codec, key sdk.StoreKey) {
+	app.accountCdc = cdc
+	app.accountStoreKey = key
+}
+
+// IsArchive returns whether the app retains and serves all historical versions.
+func (app *BaseApp) IsArchive() bool {
+	return app.archive
+}
+
+// indexArchiveVersion records the multistore version committed at height.
+func (app *BaseApp) indexArchiveVersion(height int64, version int64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(version))
+	app.db.Set(archiveHeightKey(height), bz)
+}
+
+// ArchiveVersion returns the multistore version committed at height. Heights
+// committed before the index existed fall back to version == height, which is
+// how the multistore numbers its versions.
+func (app *BaseApp) ArchiveVersion(height int64) int64 {
+	bz := app.db.Get(archiveHeightKey(height))
+	if bz == nil {
+		return height
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// isHistoricalQuery returns whether req should be served from a past version.
+func (app *BaseApp) isHistoricalQuery(req abci.RequestQuery) bool {
+	return app.archive && req.Height > 0 && req.Height < app.LastBlockHeight()
+}
+
+// historicalQueryContext builds a read-only query context over the state as of
+// req.Height.
+func (app *BaseApp) historicalQueryContext(req abci.RequestQuery) (sdk.Context, sdk.Error) {
+	version := app.ArchiveVersion(req.Height)
+	cms, err := app.cms.CacheMultiStoreWithVersion(version)
+	if err != nil {
+		return sdk.Context{}, sdk.ErrUnknownRequest(
+			fmt.Sprintf("state at height %d is not available: %s", req.Height, err.Error()))
+	}
+
+	header := app.CheckState.Ctx.BlockHeader()
+	header.Height = req.Height
+	ctx := sdk.NewContext(cms, header, sdk.RunTxModeCheck, app.Logger)
+	if app.accountStoreKey != nil {
+		accountStoreCache := auth.NewAccountStoreCache(app.accountCdc, cms.GetKVStore(app.accountStoreKey), archiveAccountCacheCap)
+		ctx = ctx.WithAccountCache(auth.NewAccountCache(accountStoreCache))
+	}
+	return ctx, nil
+}
@@ -3,9 +3,12 @@ package baseapp
 import (
 	"fmt"
 	"io"
+	"os"
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
@@ -22,6 +25,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/types/fees"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -58,13 +62,20 @@ type BaseApp struct {
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
 	preChecker  sdk.PreChecker
+	// evicts the txs of the mempool which can no longer be executed on ReCheckTx
+	reCheckFilter sdk.ReCheckFilter
 
 	// may be nil
-	initChainer      sdk.InitChainer  // initialize state with validators and state blob
-	beginBlocker     sdk.BeginBlocker // logic to run before any txs
-	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
-	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
-	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	initChainer      sdk.InitChainer   // initialize state with validators and state blob
+	beginBlocker     sdk.BeginBlocker  // logic to run before any txs
+	endBlocker       sdk.EndBlocker    // logic to run after all txs, and to determine valset changes
+	preEndBlocker    sdk.PreEndBlocker // logic to prepare the endBlocker concurrently with the txs
+	addrPeerFilter   sdk.PeerFilter    // filter peers by address and port
+	pubkeyPeerFilter sdk.PeerFilter    // filter peers by public key
+
+	// run after beginBlocker and endBlocker, sorted by priority
+	beginBlockRoutines []beginBlockRoutine
+	endBlockRoutines   []endBlockRoutine
 
 	//--------------------
 	// Volatile
@@ -78,6 +89,37 @@ type BaseApp struct {
 	txMsgCache        *lru.Cache
 	Pool              *sdk.Pool
 
+	// archive mode keeps every version and serves custom queries at any height
+	archive         bool
+	accountCdc      *codec.Codec
+	accountStoreKey sdk.StoreKey
+
+	// cache warm up saves the hot keys on stop and pre-loads them on start
+	warmUp   bool
+	warmedUp bool
+
+	// override the capacity and the eviction policy of the account store cache if set
+	accountCacheSize   int
+	accountCachePolicy auth.CacheEvictionPolicy
+
+	// report the metrics of the caches to Prometheus
+	telemetry bool
+
+	// the txs paying less are not accepted into the mempool of this node, see SetMinimumFees
+	minimumFees sdk.Coins
+
+	// txs of these routes can be checked concurrently when their accounts are disjoint
+	parallelCheckTxRoutes map[string]bool
+	// guards the router call record of the check state written by concurrent CheckTx
+	routerCallRecordMtx sync.Mutex
+
+	// the node stops after committing the block at haltHeight, or the first block at or after haltTime
+	haltHeight int64
+	haltTime   int64 // unix seconds
+
+	// the height of the last block whose EndBlock was prepared by the preEndBlocker
+	preparedHeight int64
+
 	// Snapshot for state sync related fields
 	StateSyncHelper *store.StateSyncHelper // manage state sync related status
 
@@ -195,6 +237,8 @@ func (app *BaseApp) LoadVersion(version int64, mainKey sdk.StoreKey) error {
 	if err != nil {
 		return err
 	}
+	// the cached accounts may be of a later version
+	app.InvalidateAccountCache()
 	return app.initFromStore(mainKey)
 }
 
@@ -314,8 +358,41 @@ func (app *BaseApp) SetDeliverState(header abci.Header) {
 	}
 }
 
+// SetAccountStoreCache caches up to cap accounts of accountStore, unless another size
+// is set with the SetAccountCacheSize option.
 func (app *BaseApp) SetAccountStoreCache(cdc *codec.Codec, accountStore sdk.KVStore, cap int) {
-	app.AccountStoreCache = auth.NewAccountStoreCache(cdc, accountStore, cap)
+	if app.accountCacheSize > 0 {
+		cap = app.accountCacheSize
+	}
+	app.AccountStoreCache = auth.NewAccountStoreCacheWithPolicy(cdc, accountStore, cap, app.accountCachePolicy,
+		app.Logger.With("module", "accountCache"))
+	if metered, ok := app.AccountStoreCache.(auth.MeteredAccountStoreCache); ok && app.telemetry {
+		metered.EnablePrometheusMetrics()
+	}
+}
+
+// TelemetryEnabled returns whether the app reports the metrics of its caches, see SetTelemetry
+func (app *BaseApp) TelemetryEnabled() bool {
+	return app.telemetry
+}
+
+// CacheSizes returns the number of the entries in the caches of the app, by cache
+func (app *BaseApp) CacheSizes() map[string]int {
+	sizes := map[string]int{"tx_msg": app.txMsgCache.Len()}
+	if metered, ok := app.AccountStoreCache.(auth.MeteredAccountStoreCache); ok {
+		sizes["account"] = metered.Stats().Len
+	}
+	return sizes
+}
+
+// InvalidateAccountCache drops all the cached accounts, they are loaded from the store again.
+// It must be called when the state is rolled back.
+func (app *BaseApp) InvalidateAccountCache() {
+	if app.AccountStoreCache == nil {
+		return
+	}
+	app.Logger.Info("Invalidating account cache", "height", app.LastBlockHeight())
+	app.AccountStoreCache.ClearCache()
 }
 
 //______________________________________________________________________________
@@ -486,8 +563,17 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		return sdk.ErrUnknownRequest("no custom querier found for route " + path[1]).QueryResult()
 	}
 
-	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
-	ctx = ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache))
+	var ctx sdk.Context
+	if app.isHistoricalQuery(req) {
+		var err sdk.Error
+		ctx, err = app.historicalQueryContext(req)
+		if err != nil {
+			return err.QueryResult()
+		}
+	} else {
+		ctx = sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
+		ctx = ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache))
+	}
 
 	// Passes the rest of the path as an argument to the querier.
 	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
@@ -530,6 +616,7 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.DeliverState.Ctx, req)
 	}
+	app.runBeginBlockRoutines(app.DeliverState.Ctx, req, &res)
 
 	return
 }
@@ -717,6 +804,24 @@ func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
 	return nil
 }
 
+// checkMinimumFees rejects the msgs whose fees, as set by the fee calculators, do not cover the
+// minimum fees of the node
+func (app *BaseApp) checkMinimumFees(msgs []sdk.Msg) sdk.Error {
+	if len(app.minimumFees) == 0 {
+		return nil
+	}
+	var fee sdk.Fee
+	for _, msg := range msgs {
+		if calculator := fees.GetCalculator(msg.Type()); calculator != nil {
+			fee.AddFee(calculator(msg))
+		}
+	}
+	if !fee.Tokens.IsGTE(app.minimumFees) {
+		return sdk.ErrInsufficientFee(fmt.Sprintf("fees %s do not cover the minimum fees %s of the node", fee.Tokens, app.minimumFees))
+	}
+	return nil
+}
+
 // retrieve the context with cache and store the tx bytes and tx hash
 func (app *BaseApp) getContextWithCache(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (sdk.Context,
 	sdk.CacheMultiStore, sdk.AccountCache) {
@@ -734,7 +839,7 @@ func (app *BaseApp) getContextWithCache(mode sdk.RunTxMode, tx sdk.Tx, txHash st
 			map[string]interface{}{"txHash": txHash},
 		)).(sdk.CacheMultiStore)
 	}
-	accountCache := getAccountCache(app, mode).Cache()
+	accountCache := getAccountCache(app, mode).CacheWrap()
 
 	return ctx.WithMultiStore(msCache).WithAccountCache(accountCache), msCache, accountCache
 }
@@ -765,7 +870,10 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode)
 
 		// Stop execution and return on first failed message.
 		if !msgResult.IsOK() {
-			logs = append(logs, msgResult.Log)
+			logs = append(logs, sdk.AnnotateABCILog(msgResult.Log, msgRoute, map[string]string{
+				"msg_index": strconv.Itoa(msgIdx),
+				"msg_type":  msg.Type(),
+			}))
 			code = msgResult.Code
 			break
 		}
@@ -773,10 +881,13 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode)
 		// Construct usable logs in multi-message transactions.
 		logs = append(logs, "Msg "+strconv.Itoa(msgIdx)+": "+msgResult.Log)
 	}
-	// A tx must only contain one msg. If the msg execution is success, record it
+	// A tx must only contain one msg. If the msg execution is success, record it.
+	// The record is locked as CheckTx may run concurrently.
 	if code == sdk.ABCICodeOK {
 		routerName := msgs[0].Route()
+		app.routerCallRecordMtx.Lock()
 		ctx.RouterCallRecord()[routerName] = true
+		app.routerCallRecordMtx.Unlock()
 	}
 	result = sdk.Result{
 		Code: code,
@@ -834,6 +945,12 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 	if err := validateBasicTxMsgs(msgs); err != nil {
 		return err.Result()
 	}
+	// the minimum fees only filter the new txs of the mempool, they are not part of the consensus
+	if mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeCheckAfterPre {
+		if err := app.checkMinimumFees(msgs); err != nil {
+			return err.Result()
+		}
+	}
 
 	// run the ante handler
 	ctx = ctx.WithValue(TxHashKey, txHash)
@@ -844,6 +961,8 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 		}
 
 		if abort {
+			// roll back the account changes of the ante handler, e.g. the signers verified before a failing one
+			accountCache.Discard()
 			return result
 		}
 	}
@@ -874,6 +993,8 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 		}
 		accountCache.Write()
 		msCache.Write()
+	} else {
+		accountCache.Discard()
 	}
 
 	return
@@ -896,6 +1017,13 @@ func (app *BaseApp) ReRunTx(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 
 	}()
 
+	if app.reCheckFilter != nil {
+		if result := app.reCheckFilter(ctx, tx); !result.IsOK() {
+			app.RemoveTxFromCache(txBytes)
+			return result
+		}
+	}
+
 	// run the ante handler
 	if app.anteHandler != nil {
 		newCtx, result, abort := app.anteHandler(ctx.WithValue(TxHashKey, txHash), tx, mode)
@@ -904,6 +1032,8 @@ func (app *BaseApp) ReRunTx(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 		}
 
 		if abort {
+			// roll back the account changes of the ante handler, e.g. the signers verified before a failing one
+			accountCache.Discard()
 			return result
 		}
 	}
@@ -919,20 +1049,42 @@ func (app *BaseApp) ReRunTx(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	if result.IsOK() {
 		accountCache.Write()
 		msCache.Write()
+	} else {
+		accountCache.Discard()
 	}
 
 	return
 }
 
+// PreEndBlock implements extended ABCI for concurrency.
+// It runs the preEndBlocker on a read-only context of the last committed state, it may be called
+// while the last DeliverTx of the block are running but not concurrently with EndBlock.
+func (app *BaseApp) PreEndBlock(req abci.RequestEndBlock) {
+	if app.preEndBlocker == nil {
+		return
+	}
+	// the check mode keeps the modules from collecting events of the block
+	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.DeliverState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
+	ctx = ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache))
+	app.preEndBlocker(ctx, req)
+	app.preparedHeight = req.Height
+}
+
 // EndBlock implements the ABCI application interface.
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
 	if app.DeliverState.ms.TracingEnabled() {
 		app.DeliverState.ms = app.DeliverState.ms.ResetTraceContext().(sdk.CacheMultiStore)
 	}
 
+	// the clients without concurrency do not call PreEndBlock
+	if app.preEndBlocker != nil && app.preparedHeight != req.Height {
+		app.PreEndBlock(req)
+	}
+
 	if app.endBlocker != nil {
 		res = app.endBlocker(app.DeliverState.Ctx, req)
 	}
+	app.runEndBlockRoutines(app.DeliverState.Ctx, req, &res)
 
 	return
 }
@@ -953,6 +1105,9 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.DeliverState.WriteAccountCache()
 	app.DeliverState.ms.Write()
 	commitID := app.cms.Commit()
+	if app.archive {
+		app.indexArchiveVersion(header.Height, commitID.Version)
+	}
 	// TODO: this is missing a module identifier and dumps byte array
 	app.Logger.Debug("Commit synced",
 		"commit", commitID,
@@ -967,11 +1122,36 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.DeliverState = nil
 	app.Pool.Clear()
 
+	if app.shouldHalt(header) {
+		app.halt(header)
+	}
+
 	return abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
 }
 
+func (app *BaseApp) shouldHalt(header abci.Header) bool {
+	return (app.haltHeight > 0 && header.Height >= app.haltHeight) ||
+		(app.haltTime > 0 && header.Time.Unix() >= app.haltTime)
+}
+
+// halt asks the node to stop once the block is committed, the signal goes through the same
+// graceful stop as the one sent by an operator
+func (app *BaseApp) halt(header abci.Header) {
+	app.Logger.Info("Halting node per configuration", "height", header.Height, "time", header.Time,
+		"haltHeight", app.haltHeight, "haltTime", app.haltTime)
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		// try SIGTERM if SIGINT fails, it depends on the OS
+		if p.Signal(syscall.SIGINT) == nil || p.Signal(syscall.SIGTERM) == nil {
+			return
+		}
+	}
+	app.Logger.Error("Failed to signal the node to halt, exiting")
+	os.Exit(0)
+}
+
 func (app *BaseApp) StartRecovery(manifest *abci.Manifest) error {
 	return app.StateSyncHelper.StartRecovery(manifest)
 }
@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +20,8 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
 var (
@@ -558,6 +563,32 @@ func TestDeliverTx(t *testing.T) {
 	}
 }
 
+// The log of a failed msg tells the module and the msg raising the error
+func TestDeliverTxErrorLog(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, []byte("ante-key"))) }
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return sdk.ErrInternal("Must Fail").Result()
+		})
+	}
+	app := setupBaseApp(t, anteOpt, routerOpt)
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	app.BeginBlock(abci.RequestBeginBlock{})
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.False(t, res.IsOK())
+
+	errLog, ok := sdk.ParseABCILog(res.Log)
+	require.True(t, ok, res.Log)
+	require.Equal(t, routeMsgCounter, errLog.Module)
+	require.Equal(t, map[string]string{"msg_index": "0", "msg_type": "counter1"}, errLog.Metadata)
+	require.Equal(t, "Must Fail", errLog.Message)
+}
+
 // Number of messages doesn't matter to CheckTx.
 func TestMultiMsgCheckTx(t *testing.T) {
 	// TODO: ensure we get the same results
@@ -708,3 +739,185 @@ func TestSimulateTx(t *testing.T) {
 		app.Commit()
 	}
 }
+
+type hotKeysAccountStoreCache struct {
+	sdk.AccountStoreCache
+	addrs  []sdk.AccAddress
+	loaded []sdk.AccAddress
+}
+
+func (c *hotKeysAccountStoreCache) CachedAddrs() []sdk.AccAddress {
+	return c.addrs
+}
+
+func (c *hotKeysAccountStoreCache) GetAccount(addr sdk.AccAddress) sdk.Account {
+	c.loaded = append(c.loaded, addr)
+	return nil
+}
+
+func TestCacheWarmUp(t *testing.T) {
+	db := dbm.NewMemDB()
+	addrs := []sdk.AccAddress{sdk.AccAddress("addr1"), sdk.AccAddress("addr2")}
+
+	app := NewBaseApp(t.Name(), defaultLogger(), db, nil, sdk.CollectConfig{}, SetCacheWarmUp(true))
+	require.True(t, app.IsCacheWarmUp())
+	app.AccountStoreCache = &hotKeysAccountStoreCache{addrs: addrs}
+	app.SaveHotKeys()
+
+	// a restarted app pre-loads the saved accounts once, in the same order
+	app = NewBaseApp(t.Name(), defaultLogger(), db, nil, sdk.CollectConfig{}, SetCacheWarmUp(true))
+	cache := &hotKeysAccountStoreCache{}
+	app.AccountStoreCache = cache
+	app.WarmUpCache()
+	require.Equal(t, addrs, cache.loaded)
+	app.WarmUpCache()
+	require.Len(t, cache.loaded, len(addrs))
+
+	// nothing is loaded when warm up is off
+	app = NewBaseApp(t.Name(), defaultLogger(), db, nil, sdk.CollectConfig{})
+	cache = &hotKeysAccountStoreCache{}
+	app.AccountStoreCache = cache
+	app.WarmUpCache()
+	require.Empty(t, cache.loaded)
+}
+
+func TestAccountCacheOptions(t *testing.T) {
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	app := setupBaseApp(t, SetAccountCacheSize(3), SetAccountCacheEviction("2q"))
+	accountStore := app.cms.GetKVStore(capKey2)
+	app.SetAccountStoreCache(cdc, accountStore, 100)
+	cache := app.AccountStoreCache.(auth.MeteredAccountStoreCache)
+	require.Equal(t, 3, cache.Stats().Cap)
+
+	addr := sdk.AccAddress("addr1")
+	app.AccountStoreCache.SetAccount(addr, &auth.BaseAccount{Address: addr})
+	require.Equal(t, 1, cache.Stats().Len)
+
+	// rolling back drops the cached accounts
+	require.NoError(t, app.LoadVersion(0, capKey1))
+	require.Equal(t, 0, cache.Stats().Len)
+
+	require.Panics(t, func() { SetAccountCacheEviction("fifo") })
+}
+
+func TestMinimumFees(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+	app := setupBaseApp(t, routerOpt, SetMinimumFees("100:BNB"))
+	app.InitChain(abci.RequestInitChain{})
+	codec := codec.New()
+	registerTestCodec(codec)
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+
+	// a msg without fee calculator pays nothing
+	res := app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.Equal(t, sdk.CodeInsufficientFee, sdk.CodeType(res.Code), res.Log)
+
+	defer fees.UnsetAllCalculators()
+	fees.RegisterCalculator(msgCounter{}.Type(), fees.FixedFeeCalculator(50, sdk.FeeForProposer))
+	res = app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.Equal(t, sdk.CodeInsufficientFee, sdk.CodeType(res.Code), res.Log)
+	fees.RegisterCalculator(msgCounter{}.Type(), fees.FixedFeeCalculator(100, sdk.FeeForProposer))
+	res = app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.True(t, res.IsOK(), res.Log)
+
+	// the minimum fees do not apply to the txs of the blocks
+	fees.UnsetAllCalculators()
+	app.BeginBlock(abci.RequestBeginBlock{})
+	resDeliver := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.True(t, resDeliver.IsOK(), resDeliver.Log)
+
+	require.Panics(t, func() { SetMinimumFees("100") })
+}
+
+func TestCheckTxAccounts(t *testing.T) {
+	codec := codec.New()
+	registerTestCodec(codec)
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	req := abci.RequestCheckTx{Tx: txBytes}
+
+	// no parallel routes, every tx is checked exclusively
+	app := setupBaseApp(t)
+	require.False(t, app.IsParallelCheckTx())
+	_, ok := app.CheckTxAccounts(req)
+	require.False(t, ok)
+
+	app = setupBaseApp(t, SetParallelCheckTxRoutes(routeMsgCounter))
+	require.True(t, app.IsParallelCheckTx())
+	accounts, ok := app.CheckTxAccounts(req)
+	require.True(t, ok)
+	require.Empty(t, accounts)
+
+	// a tx of another route or that cannot be decoded is exclusive
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(&txTest{[]sdk.Msg{msgCounter2{}}, 0})
+	require.NoError(t, err)
+	_, ok = app.CheckTxAccounts(abci.RequestCheckTx{Tx: txBytes})
+	require.False(t, ok)
+	_, ok = app.CheckTxAccounts(abci.RequestCheckTx{Tx: []byte("invalid")})
+	require.False(t, ok)
+}
+
+func TestHalt(t *testing.T) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT)
+	defer signal.Stop(sigs)
+
+	commit := func(app *BaseApp, header abci.Header) {
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.Commit()
+	}
+	requireHalted := func(halted bool) {
+		select {
+		case <-sigs:
+			require.True(t, halted, "the node is halted")
+		case <-time.After(100 * time.Millisecond):
+			require.False(t, halted, "the node is not halted")
+		}
+	}
+
+	// halt height
+	app := setupBaseApp(t, SetHaltHeight(2))
+	commit(app, abci.Header{Height: 1})
+	requireHalted(false)
+	commit(app, abci.Header{Height: 2})
+	requireHalted(true)
+
+	// halt time
+	haltTime := time.Unix(1600000000, 0)
+	app = setupBaseApp(t, SetHaltTime(haltTime.Unix()))
+	commit(app, abci.Header{Height: 1, Time: haltTime.Add(-time.Second)})
+	requireHalted(false)
+	commit(app, abci.Header{Height: 2, Time: haltTime.Add(time.Second)})
+	requireHalted(true)
+}
+
+func TestPreEndBlock(t *testing.T) {
+	key := func(height int64) []byte { return []byte(fmt.Sprintf("key%d", height)) }
+	var prepared []int64
+	app := setupBaseApp(t, func(bap *BaseApp) {
+		bap.SetPreEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) {
+			// the writes of the block are not visible
+			require.Nil(t, ctx.KVStore(capKey1).Get(key(req.Height)))
+			prepared = append(prepared, req.Height)
+		})
+	})
+
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.DeliverState.Ctx.KVStore(capKey1).Set(key(1), []byte("value"))
+	app.PreEndBlock(abci.RequestEndBlock{Height: 1})
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+	require.Equal(t, []int64{1}, prepared, "EndBlock prepares the block again")
+
+	// EndBlock prepares the block if PreEndBlock is not called
+	header = abci.Header{Height: 2}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.DeliverState.Ctx.KVStore(capKey1).Set(key(2), []byte("value"))
+	app.EndBlock(abci.RequestEndBlock{Height: 2})
+	require.Equal(t, []int64{1, 2}, prepared)
+}
@@ -0,0 +1,120 @@
+package baseapp
+
+import (
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BlockerPredicate decides whether a registered blocker runs in the current block.
+type BlockerPredicate func(ctx sdk.Context) bool
+
+// EveryNBlocks runs a blocker only at the heights that are multiples of n.
+func EveryNBlocks(n int64) BlockerPredicate {
+	if n <= 0 {
+		panic("n should be positive")
+	}
+	return func(ctx sdk.Context) bool {
+		return ctx.BlockHeight()%n == 0
+	}
+}
+
+type blockerRoutine struct {
+	name       string
+	priority   int
+	predicates []BlockerPredicate
+}
+
+func (r blockerRoutine) shouldRun(ctx sdk.Context) bool {
+	for _, predicate := range r.predicates {
+		if !predicate(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+type beginBlockRoutine struct {
+	blockerRoutine
+	blocker sdk.BeginBlocker
+}
+
+type endBlockRoutine struct {
+	blockerRoutine
+	blocker sdk.EndBlocker
+}
+
+// RegisterBeginBlocker adds a routine that runs in BeginBlock after the blocker set by SetBeginBlocker.
+// Routines run in ascending priority, those with the same priority in the order they are registered.
+// A routine is skipped in the blocks where any of its predicates returns false.
+func (app *BaseApp) RegisterBeginBlocker(name string, priority int, blocker sdk.BeginBlocker, predicates ...BlockerPredicate) {
+	if app.sealed {
+		panic("RegisterBeginBlocker() on sealed BaseApp")
+	}
+	app.beginBlockRoutines = append(app.beginBlockRoutines, beginBlockRoutine{
+		blockerRoutine: blockerRoutine{name: name, priority: priority, predicates: predicates},
+		blocker:        blocker,
+	})
+	sort.SliceStable(app.beginBlockRoutines, func(i, j int) bool {
+		return app.beginBlockRoutines[i].priority < app.beginBlockRoutines[j].priority
+	})
+}
+
+// RegisterEndBlocker adds a routine that runs in EndBlock after the blocker set by SetEndBlocker,
+// with the same ordering and predicates as RegisterBeginBlocker.
+func (app *BaseApp) RegisterEndBlocker(name string, priority int, blocker sdk.EndBlocker, predicates ...BlockerPredicate) {
+	if app.sealed {
+		panic("RegisterEndBlocker() on sealed BaseApp")
+	}
+	app.endBlockRoutines = append(app.endBlockRoutines, endBlockRoutine{
+		blockerRoutine: blockerRoutine{name: name, priority: priority, predicates: predicates},
+		blocker:        blocker,
+	})
+	sort.SliceStable(app.endBlockRoutines, func(i, j int) bool {
+		return app.endBlockRoutines[i].priority < app.endBlockRoutines[j].priority
+	})
+}
+
+// BeginBlockerNames returns the names of the registered begin blockers in execution order.
+func (app *BaseApp) BeginBlockerNames() []string {
+	names := make([]string, 0, len(app.beginBlockRoutines))
+	for _, routine := range app.beginBlockRoutines {
+		names = append(names, routine.name)
+	}
+	return names
+}
+
+// EndBlockerNames returns the names of the registered end blockers in execution order.
+func (app *BaseApp) EndBlockerNames() []string {
+	names := make([]string, 0, len(app.endBlockRoutines))
+	for _, routine := range app.endBlockRoutines {
+		names = append(names, routine.name)
+	}
+	return names
+}
+
+func (app *BaseApp) runBeginBlockRoutines(ctx sdk.Context, req abci.RequestBeginBlock, res *abci.ResponseBeginBlock) {
+	for _, routine := range app.beginBlockRoutines {
+		if !routine.shouldRun(ctx) {
+			continue
+		}
+		routineRes := routine.blocker(ctx, req)
+		res.Events = append(res.Events, routineRes.Events...)
+	}
+}
+
+func (app *BaseApp) runEndBlockRoutines(ctx sdk.Context, req abci.RequestEndBlock, res *abci.ResponseEndBlock) {
+	for _, routine := range app.endBlockRoutines {
+		if !routine.shouldRun(ctx) {
+			continue
+		}
+		routineRes := routine.blocker(ctx, req)
+		res.ValidatorUpdates = append(res.ValidatorUpdates, routineRes.ValidatorUpdates...)
+		if routineRes.ConsensusParamUpdates != nil {
+			res.ConsensusParamUpdates = routineRes.ConsensusParamUpdates
+		}
+		res.Events = append(res.Events, routineRes.Events...)
+	}
+}
@@ -0,0 +1,53 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestRegisteredBlockers(t *testing.T) {
+	var calls []string
+	beginBlocker := func(name string) sdk.BeginBlocker {
+		return func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			calls = append(calls, name)
+			return abci.ResponseBeginBlock{Events: []abci.Event{{Type: name}}}
+		}
+	}
+	endBlocker := func(name string, updates ...abci.ValidatorUpdate) sdk.EndBlocker {
+		return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+			calls = append(calls, name)
+			return abci.ResponseEndBlock{ValidatorUpdates: updates}
+		}
+	}
+
+	app := setupBaseApp(t, func(app *BaseApp) {
+		app.SetBeginBlocker(beginBlocker("app"))
+		app.RegisterBeginBlocker("late", 10, beginBlocker("late"))
+		app.RegisterBeginBlocker("early", -1, beginBlocker("early"))
+		app.RegisterBeginBlocker("sweep", 10, beginBlocker("sweep"), EveryNBlocks(2))
+		app.RegisterEndBlocker("stake", 0, endBlocker("stake", abci.ValidatorUpdate{Power: 1}))
+		app.RegisterEndBlocker("gov", 0, endBlocker("gov"), func(ctx sdk.Context) bool { return false })
+	})
+	require.Equal(t, []string{"early", "late", "sweep"}, app.BeginBlockerNames())
+	require.Equal(t, []string{"stake", "gov"}, app.EndBlockerNames())
+
+	// the sweep is skipped in odd blocks
+	beginRes := app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	endRes := app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+	require.Equal(t, []string{"app", "early", "late", "stake"}, calls)
+	require.Equal(t, []abci.Event{{Type: "app"}, {Type: "early"}, {Type: "late"}}, beginRes.Events)
+	require.Equal(t, []abci.ValidatorUpdate{{Power: 1}}, endRes.ValidatorUpdates)
+
+	calls = nil
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	app.EndBlock(abci.RequestEndBlock{Height: 2})
+	app.Commit()
+	require.Equal(t, []string{"app", "early", "late", "sweep", "stake"}, calls)
+
+	require.Panics(t, func() { EveryNBlocks(0) })
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	dbm "github.com/tendermint/tendermint/libs/db"
 )
 
@@ -30,6 +31,97 @@ func SetPruning(pruning string) func(*BaseApp) {
 	}
 }
 
+// SetArchiveMode turns the app into an archive node: every historical version
+// is retained and custom queries are served at the requested height.
+// It overrides any pruning option given before it.
+func SetArchiveMode(archive bool) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if !archive {
+			return
+		}
+		bap.archive = true
+		bap.cms.SetPruning(sdk.PruneNothing)
+	}
+}
+
+// SetCacheWarmUp makes the app save the keys in its inter-block caches on stop
+// and pre-load them on the next start.
+func SetCacheWarmUp(warmUp bool) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.warmUp = warmUp
+	}
+}
+
+// SetAccountCacheSize sets how many accounts the account store cache keeps, overriding the
+// capacity given to SetAccountStoreCache. Zero keeps it.
+func SetAccountCacheSize(size int) func(*BaseApp) {
+	if size < 0 {
+		panic(fmt.Sprintf("invalid account cache size: %d", size))
+	}
+	return func(bap *BaseApp) {
+		bap.accountCacheSize = size
+	}
+}
+
+// SetAccountCacheEviction sets the eviction policy of the account store cache: lru, arc or 2q.
+// The empty string keeps lru.
+func SetAccountCacheEviction(policy string) func(*BaseApp) {
+	evictionPolicy, err := auth.ParseCacheEvictionPolicy(policy)
+	if err != nil {
+		panic(err)
+	}
+	return func(bap *BaseApp) {
+		bap.accountCachePolicy = evictionPolicy
+	}
+}
+
+// SetMinimumFees sets the fees a tx must pay for this node to accept it into its mempool, e.g. "100:BNB".
+// The fees of a tx are the ones of its msgs given by the fee calculators. The empty string accepts any.
+func SetMinimumFees(minFees string) func(*BaseApp) {
+	fees, err := sdk.ParseCoins(minFees)
+	if err != nil {
+		panic(fmt.Sprintf("invalid minimum fees %q: %v", minFees, err))
+	}
+	return func(bap *BaseApp) {
+		bap.minimumFees = fees
+	}
+}
+
+// SetTelemetry makes the app report the metrics of its caches to the default Prometheus registry
+func SetTelemetry(enabled bool) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.telemetry = enabled
+	}
+}
+
+// SetHaltHeight makes the node stop cleanly after committing the block at the given height,
+// e.g. to export the state at that exact height. Zero disables it.
+func SetHaltHeight(height int64) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.haltHeight = height
+	}
+}
+
+// SetHaltTime makes the node stop cleanly after committing the first block whose time is at
+// or after the given unix time in seconds. Zero disables it.
+func SetHaltTime(haltTime int64) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.haltTime = haltTime
+	}
+}
+
+// SetParallelCheckTxRoutes lets the txs of the given msg routes be checked concurrently
+// when they touch disjoint accounts. The handlers of these routes must only change
+// accounts in CheckTx.
+func SetParallelCheckTxRoutes(routes ...string) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.parallelCheckTxRoutes = make(map[string]bool, len(routes))
+		for _, route := range routes {
+			bap.parallelCheckTxRoutes[route] = true
+		}
+	}
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -72,6 +164,15 @@ func (app *BaseApp) SetEndBlocker(endBlocker sdk.EndBlocker) {
 	app.endBlocker = endBlocker
 }
 
+// SetPreEndBlocker sets the routine preparing the EndBlocker, it reads the state of the last block
+// and keeps its results for the EndBlocker. EndBlock runs it first if the ABCI client did not.
+func (app *BaseApp) SetPreEndBlocker(preEndBlocker sdk.PreEndBlocker) {
+	if app.sealed {
+		panic("SetPreEndBlocker() on sealed BaseApp")
+	}
+	app.preEndBlocker = preEndBlocker
+}
+
 func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	if app.sealed {
 		panic("SetAnteHandler() on sealed BaseApp")
@@ -86,6 +187,13 @@ func (app *BaseApp) SetPreChecker(pc sdk.PreChecker) {
 	app.preChecker = pc
 }
 
+func (app *BaseApp) SetReCheckFilter(rf sdk.ReCheckFilter) {
+	if app.sealed {
+		panic("SetReCheckFilter() on sealed BaseApp")
+	}
+	app.reCheckFilter = rf
+}
+
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	if app.sealed {
 		panic("SetAddrPeerFilter() on sealed BaseApp")
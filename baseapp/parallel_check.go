@@ -0,0 +1,46 @@
+package baseapp
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// IsParallelCheckTx returns whether some txs can be checked concurrently.
+func (app *BaseApp) IsParallelCheckTx() bool {
+	return len(app.parallelCheckTxRoutes) != 0
+}
+
+// CheckTxAccounts returns the accounts a tx reads or writes in CheckTx, so that the
+// txs touching disjoint accounts can be checked concurrently. It returns false if the
+// tx has to be checked exclusively: a msg out of the parallel routes, a tx that cannot
+// be decoded or an account that does not exist yet, since creating an account takes
+// the next global account number.
+func (app *BaseApp) CheckTxAccounts(req abci.RequestCheckTx) ([][]byte, bool) {
+	if !app.IsParallelCheckTx() {
+		return nil, false
+	}
+	// the tx is not added to the cache here, a cached tx skips the signature check
+	tx, ok := app.GetTxFromCache(req.Tx)
+	if !ok {
+		var err error
+		if tx, err = app.TxDecoder(req.Tx); err != nil {
+			return nil, false
+		}
+	}
+
+	var accounts [][]byte
+	for _, msg := range tx.GetMsgs() {
+		if !app.parallelCheckTxRoutes[msg.Route()] {
+			return nil, false
+		}
+		for _, addr := range msg.GetSigners() {
+			accounts = append(accounts, addr)
+		}
+		for _, addr := range msg.GetInvolvedAddresses() {
+			if app.CheckState.AccountCache.GetAccount(addr) == nil {
+				return nil, false
+			}
+			accounts = append(accounts, addr)
+		}
+	}
+	return accounts, true
+}
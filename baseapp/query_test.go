@@ -94,3 +94,58 @@ func TestP2PQuery(t *testing.T) {
 	res = app.Query(pubkeyQuery)
 	require.Equal(t, uint32(4), res.Code)
 }
+
+// Test that an archive node serves custom queries at past heights.
+func TestArchiveQuery(t *testing.T) {
+	key := []byte("height")
+	queryRouterOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("test", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			return ctx.KVStore(capKey1).Get(key), nil
+		})
+	}
+
+	app := setupBaseApp(t, SetArchiveMode(true), queryRouterOpt)
+	require.True(t, app.IsArchive())
+	app.InitChain(abci.RequestInitChain{})
+
+	for height := int64(1); height <= 3; height++ {
+		header := abci.Header{Height: height}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.DeliverState.Ctx.KVStore(capKey1).Set(key, []byte{byte(height)})
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+		require.Equal(t, height, app.ArchiveVersion(height))
+	}
+
+	// latest state by default
+	res := app.Query(abci.RequestQuery{Path: "/custom/test"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []byte{3}, res.Value)
+
+	// historical state at earlier heights
+	for height := int64(1); height <= 3; height++ {
+		res = app.Query(abci.RequestQuery{Path: "/custom/test", Height: height})
+		require.True(t, res.IsOK(), res.Log)
+		require.Equal(t, []byte{byte(height)}, res.Value)
+	}
+
+	// without archive mode the requested height is ignored
+	app.archive = false
+	res = app.Query(abci.RequestQuery{Path: "/custom/test", Height: 1})
+	require.Equal(t, []byte{3}, res.Value)
+}
+
+func TestCacheMultiStoreWithVersion(t *testing.T) {
+	app := setupBaseApp(t, SetArchiveMode(true))
+	app.InitChain(abci.RequestInitChain{})
+
+	_, err := app.GetCommitMultiStore().CacheMultiStoreWithVersion(1)
+	require.Error(t, err)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.Commit()
+
+	cms, err := app.GetCommitMultiStore().CacheMultiStoreWithVersion(1)
+	require.NoError(t, err)
+	require.Nil(t, cms.GetKVStore(capKey1).Get([]byte("missing")))
+}
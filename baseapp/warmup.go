@@ -0,0 +1,73 @@
+package baseapp
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// hotAccountsKey stores the addresses in the account cache at shutdown.
+// It lives in the app DB beside the multistore commit info.
+var hotAccountsKey = []byte("warmup/accounts")
+
+// hotKeysCache is implemented by account store caches that can list what they hold
+type hotKeysCache interface {
+	CachedAddrs() []sdk.AccAddress
+}
+
+// IsCacheWarmUp returns whether the app saves its hot keys on stop and pre-loads them on start.
+func (app *BaseApp) IsCacheWarmUp() bool {
+	return app.warmUp
+}
+
+// SaveHotKeys records the accounts in the account cache so that the next start can
+// pre-load them. The list also warms the bech32 strings of their addresses up. The other
+// inter-block caches are left out: the signature and tx msg caches are keyed by txs which
+// were already delivered and are not seen again, and the validator and consensus address
+// strings are few and encoded again by the first block.
+func (app *BaseApp) SaveHotKeys() {
+	if !app.warmUp {
+		return
+	}
+	cache, ok := app.AccountStoreCache.(hotKeysCache)
+	if !ok {
+		return
+	}
+	addrs := cache.CachedAddrs()
+	bz, err := json.Marshal(addrs)
+	if err != nil {
+		app.Logger.Error("failed to encode hot keys", "err", err)
+		return
+	}
+	app.db.SetSync(hotAccountsKey, bz)
+	app.Logger.Info("saved hot keys", "accounts", len(addrs))
+}
+
+// WarmUpCache pre-loads the account cache with the accounts saved by SaveHotKeys,
+// the least recently used first so that the cache ends up in the same order, and
+// the bech32 string cache with their addresses. It runs once, later calls are no-ops.
+func (app *BaseApp) WarmUpCache() {
+	if !app.warmUp || app.warmedUp || app.AccountStoreCache == nil {
+		return
+	}
+	app.warmedUp = true
+
+	bz := app.db.Get(hotAccountsKey)
+	if bz == nil {
+		return
+	}
+	var addrs []sdk.AccAddress
+	if err := json.Unmarshal(bz, &addrs); err != nil {
+		app.Logger.Error("failed to decode hot keys, skip cache warm up", "err", err)
+		return
+	}
+	loaded := 0
+	for _, addr := range addrs {
+		if app.AccountStoreCache.GetAccount(addr) != nil {
+			loaded++
+		}
+		// the address strings are cached by String, they are logged and used in the events of the txs
+		_ = addr.String()
+	}
+	app.Logger.Info("warmed up account cache", "accounts", loaded)
+}
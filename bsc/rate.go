@@ -16,3 +16,10 @@ func ConvertBCAmountToBSCAmount(bcAmount int64) *big.Int {
 	bscAmount := sdk.NewInt(bcAmount).Mul(decimals)
 	return bscAmount.BigInt()
 }
+
+// ConvertBSCAmountToBCAmount truncates the part of the amount below the precision of the beacon chain
+func ConvertBSCAmountToBCAmount(bscAmount *big.Int) int64 {
+	decimals := sdk.NewIntWithDecimal(1, int(BNBDecimalOnBSC-BNBDecimalOnBC))
+	bcAmount := sdk.NewIntFromBigInt(bscAmount).Div(decimals)
+	return bcAmount.Int64()
+}
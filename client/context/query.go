@@ -112,6 +112,19 @@ func (ctx CLIContext) GetAccountSequence(address []byte) (int64, error) {
 	return account.GetSequence(), nil
 }
 
+// GetLaneSequence returns the sequence number of a lane of the given account
+// address, lane 0 is the sequence of the account.
+func (ctx CLIContext) GetLaneSequence(address []byte, lane int64) (int64, error) {
+	if lane == 0 {
+		return ctx.GetAccountSequence(address)
+	}
+	res, err := ctx.QueryStore(auth.LaneSequenceStoreKey(address, lane), ctx.AccountStore)
+	if err != nil {
+		return 0, err
+	}
+	return auth.DecodeLaneSequence(res), nil
+}
+
 // EnsureAccountExists ensures that an account exists for a given context. An
 // error is returned if it does not.
 func (ctx CLIContext) EnsureAccountExists() error {
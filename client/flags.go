@@ -17,6 +17,8 @@ const (
 	FlagName           = "name"
 	FlagAccountNumber  = "account-number"
 	FlagSequence       = "sequence"
+	FlagLane           = "lane"
+	FlagValidUntil     = "valid-until-height"
 	FlagMemo           = "memo"
 	FlagSource         = "source"
 	FlagAsync          = "async"
@@ -59,6 +61,8 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagFrom, "", "Name or address of private key with which to sign")
 		c.Flags().Int64(FlagAccountNumber, 0, "AccountNumber number to sign the tx")
 		c.Flags().Int64(FlagSequence, 0, "Sequence number to sign the tx")
+		c.Flags().Int64(FlagLane, 0, "Lane of the account ordering the tx, each lane has its own sequence")
+		c.Flags().Int64(FlagValidUntil, 0, "Height of the last block the tx may be included in, 0 for no limit")
 		c.Flags().String(FlagMemo, "", "Memo to send along with transaction")
 		c.Flags().Int64(FlagSource, 0, "Source of tx")
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
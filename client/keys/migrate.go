@@ -0,0 +1,172 @@
+package keys
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// migration status of a key
+const (
+	migrateStatusMigrated = "migrated"
+	migrateStatusVerified = "verified"
+	migrateStatusSkipped  = "skipped"
+	migrateStatusFailed   = "failed"
+)
+
+type migrateResult struct {
+	Name    string
+	Type    keys.KeyType
+	Address sdk.AccAddress
+	Status  string
+	Reason  string
+}
+
+func migrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate <legacy home>",
+		Short: "Migrate the keys of a legacy keybase into the keybase under --home",
+		Long: `Read every key of the legacy keybase under <legacy home>/keys and write it into the keybase
+under --home. Local private keys are decrypted with their passphrase and re-encrypted with the current
+parameters, the address of every migrated key is verified against the legacy one. Keys whose name is
+already taken are skipped. The legacy keybase is never modified.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMigrateCmd,
+	}
+	cmd.Flags().Bool(flagDryRun, false, "Verify the passphrases and addresses without writing any key")
+	return cmd
+}
+
+func runMigrateCmd(cmd *cobra.Command, args []string) error {
+	legacyDB, err := dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(args[0], "keys"), &opt.Options{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	legacy := client.GetKeyBase(legacyDB)
+	defer legacy.CloseDB()
+
+	dryRun := viper.GetBool(flagDryRun)
+	var target keys.Keybase
+	if dryRun {
+		target = client.MockKeyBase()
+	} else {
+		target, err = GetKeyBaseWithWritePerm()
+		if err != nil {
+			return err
+		}
+	}
+
+	buf := client.BufferStdin()
+	results, err := migrateKeys(legacy, target, func(name string) (string, error) {
+		return client.GetPassword(fmt.Sprintf("Enter the passphrase of key %s:", name), buf)
+	}, dryRun)
+	if err != nil {
+		return err
+	}
+	return printMigrateReport(bufio.NewWriter(cmd.OutOrStdout()), results)
+}
+
+// migrateKeys copies all the keys of the legacy keybase into the target one. Local keys are
+// re-encrypted with the same passphrase, a key that fails is reported without stopping the others.
+func migrateKeys(legacy, target keys.Keybase, getPassphrase func(name string) (string, error), dryRun bool) ([]migrateResult, error) {
+	infos, err := legacy.List()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]migrateResult, 0, len(infos))
+	for _, info := range infos {
+		res := migrateResult{Name: info.GetName(), Type: info.GetType(), Address: info.GetAddress()}
+		if existing, err := target.Get(info.GetName()); err == nil {
+			res.Status = migrateStatusSkipped
+			if existing.GetAddress().Equals(info.GetAddress()) {
+				res.Reason = "already migrated"
+			} else {
+				res.Reason = fmt.Sprintf("name taken by %s", existing.GetAddress())
+			}
+			results = append(results, res)
+			continue
+		}
+		if err := migrateKey(legacy, target, info, getPassphrase); err != nil {
+			res.Status, res.Reason = migrateStatusFailed, err.Error()
+		} else if dryRun {
+			res.Status = migrateStatusVerified
+		} else {
+			res.Status = migrateStatusMigrated
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func migrateKey(legacy, target keys.Keybase, info keys.Info, getPassphrase func(name string) (string, error)) error {
+	name := info.GetName()
+	var passphrase string
+	if info.GetType() == keys.TypeLocal {
+		var err error
+		if passphrase, err = getPassphrase(name); err != nil {
+			return err
+		}
+		priv, err := legacy.ExportPrivateKeyObject(name, passphrase)
+		if err != nil {
+			return err
+		}
+		if !sdk.AccAddress(priv.PubKey().Address()).Equals(info.GetAddress()) {
+			return fmt.Errorf("private key does not match address %s", info.GetAddress())
+		}
+	}
+
+	armor, err := legacy.Export(name)
+	if err != nil {
+		return err
+	}
+	if err = target.Import(name, armor); err != nil {
+		return err
+	}
+	if info.GetType() == keys.TypeLocal {
+		// re-encrypt the private key with the current parameters
+		err = target.Update(name, passphrase, func() (string, error) { return passphrase, nil })
+		if err != nil {
+			return err
+		}
+	}
+
+	migrated, err := target.GetByAddress(info.GetAddress())
+	if err != nil {
+		return err
+	}
+	if migrated.GetName() != name || !migrated.GetPubKey().Equals(info.GetPubKey()) {
+		return fmt.Errorf("migrated key does not match address %s", info.GetAddress())
+	}
+	return nil
+}
+
+func printMigrateReport(w *bufio.Writer, results []migrateResult) error {
+	var failed int
+	for _, res := range results {
+		line := fmt.Sprintf("%s\t%s\t%s\t%s", res.Name, res.Type, res.Address, res.Status)
+		if res.Reason != "" {
+			line += fmt.Sprintf("\t%s", res.Reason)
+		}
+		fmt.Fprintln(w, line)
+		if res.Status == migrateStatusFailed {
+			failed++
+		}
+	}
+	fmt.Fprintf(w, "%d keys processed, %d failed\n", len(results), failed)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to migrate %d keys", failed)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package keys
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+)
+
+func TestMigrateKeys(t *testing.T) {
+	legacy, target := client.MockKeyBase(), client.MockKeyBase()
+	alice, _, err := legacy.CreateMnemonic("alice", keys.English, "alicepass", keys.Secp256k1)
+	require.NoError(t, err)
+	_, _, err = legacy.CreateMnemonic("bob", keys.English, "bobpass", keys.Secp256k1)
+	require.NoError(t, err)
+	_, _, err = legacy.CreateMnemonic("carol", keys.English, "carolpass", keys.Secp256k1)
+	require.NoError(t, err)
+	offline, err := legacy.CreateOffline("dave", secp256k1.GenPrivKey().PubKey())
+	require.NoError(t, err)
+	_, _, err = target.CreateMnemonic("carol", keys.English, "otherpass", keys.Secp256k1)
+	require.NoError(t, err)
+
+	passphrases := map[string]string{"alice": "alicepass", "bob": "wrongpass"}
+	getPassphrase := func(name string) (string, error) {
+		if pass, ok := passphrases[name]; ok {
+			return pass, nil
+		}
+		return "", errors.New("no passphrase")
+	}
+
+	// a dry run writes nothing
+	results, err := migrateKeys(legacy, client.MockKeyBase(), getPassphrase, true)
+	require.NoError(t, err)
+	require.Equal(t, migrateStatusVerified, results[0].Status)
+
+	results, err = migrateKeys(legacy, target, getPassphrase, false)
+	require.NoError(t, err)
+	statuses := make(map[string]string)
+	for _, res := range results {
+		statuses[res.Name] = res.Status
+	}
+	require.Equal(t, map[string]string{
+		"alice": migrateStatusMigrated,
+		"bob":   migrateStatusFailed,
+		"carol": migrateStatusSkipped,
+		"dave":  migrateStatusMigrated,
+	}, statuses)
+
+	// the migrated keys keep their addresses and passphrases
+	info, err := target.GetByAddress(alice.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, "alice", info.GetName())
+	_, err = target.ExportPrivateKeyObject("alice", "alicepass")
+	require.NoError(t, err)
+	info, err = target.GetByAddress(offline.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, keys.TypeOffline, info.GetType())
+	_, err = target.Get("bob")
+	require.Error(t, err)
+
+	var out bytes.Buffer
+	err = printMigrateReport(bufio.NewWriter(&out), results)
+	require.Error(t, err)
+	require.Contains(t, out.String(), "4 keys processed, 1 failed")
+
+	// migrating again skips everything that has been migrated
+	passphrases["bob"] = "bobpass"
+	results, err = migrateKeys(legacy, target, getPassphrase, false)
+	require.NoError(t, err)
+	for _, res := range results {
+		if res.Name == "bob" {
+			require.Equal(t, migrateStatusMigrated, res.Status)
+		} else {
+			require.Equal(t, migrateStatusSkipped, res.Status)
+		}
+	}
+}
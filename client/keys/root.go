@@ -26,6 +26,7 @@ func Commands() *cobra.Command {
 		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
+		migrateCommand(),
 	)
 	return cmd
 }
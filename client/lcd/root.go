@@ -15,7 +15,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 	auth "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/client/rest"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution/client/rest"
 	gov "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	oracle "github.com/cosmos/cosmos-sdk/x/oracle/client/rest"
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/client/rest"
 	stake "github.com/cosmos/cosmos-sdk/x/stake/client/rest"
 	"github.com/gorilla/mux"
@@ -157,6 +159,8 @@ func createHandler(cdc *codec.Codec) *mux.Router {
 	stake.RegisterRoutes(cliCtx, r, cdc, kb)
 	slashing.RegisterRoutes(cliCtx, r, cdc, kb)
 	gov.RegisterRoutes(cliCtx, r, cdc)
+	distr.RegisterRoutes(cliCtx, r, cdc)
+	oracle.RegisterRoutes(cliCtx, r, cdc)
 
 	return r
 }
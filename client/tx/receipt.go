@@ -0,0 +1,260 @@
+package tx
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	lru "github.com/hashicorp/golang-lru"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+const (
+	// maxReceipts bounds the receipts kept, the oldest ones are dropped first
+	maxReceipts = 100000
+	// receiptWorkers is the number of goroutines broadcasting the txs
+	receiptWorkers = 16
+	// maxQueuedBroadcasts bounds the txs waiting for a worker, more are refused
+	maxQueuedBroadcasts = 1000
+	// receiptPollInterval is the interval to look for the checked txs in the blocks
+	receiptPollInterval = time.Second
+	// receiptPollTimeout is how long to wait for the tx to be included before giving up
+	receiptPollTimeout = 2 * time.Minute
+)
+
+// the status of a tx broadcast with a receipt
+const (
+	ReceiptPending   = "pending"   // the tx is being checked
+	ReceiptChecked   = "checked"   // the tx passed CheckTx and waits to be included in a block
+	ReceiptRejected  = "rejected"  // the tx failed CheckTx or could not be broadcast
+	ReceiptCommitted = "committed" // the tx is included in a block, see the DeliverTx result
+	ReceiptUnknown   = "unknown"   // the tx was not found in a block in time
+)
+
+// Receipt reports the progress of a tx broadcast asynchronously
+type Receipt struct {
+	ID        string                  `json:"id"`
+	Hash      common.HexBytes         `json:"hash"`
+	Status    string                  `json:"status"`
+	Error     string                  `json:"error,omitempty"`
+	CheckTx   *abci.ResponseCheckTx   `json:"check_tx,omitempty"`
+	Height    int64                   `json:"height,omitempty"`
+	DeliverTx *abci.ResponseDeliverTx `json:"deliver_tx,omitempty"`
+}
+
+// receiptStore keeps the receipts of the txs broadcast by this REST server. The txs are broadcast
+// by a fixed pool of workers, then a single poller looks for the checked ones in the blocks.
+type receiptStore struct {
+	cliCtx   context.CLIContext
+	mtx      sync.RWMutex // the receipts are updated by the workers and the poller
+	receipts *lru.Cache
+
+	broadcasts chan broadcastJob
+	pollMtx    sync.Mutex
+	polling    map[string]*Receipt // the checked receipts by id, waiting to be committed
+}
+
+type broadcastJob struct {
+	receipt *Receipt
+	txBytes []byte
+}
+
+func newReceiptStore(cliCtx context.CLIContext) *receiptStore {
+	receipts, err := lru.New(maxReceipts)
+	if err != nil {
+		panic(err)
+	}
+	s := &receiptStore{
+		cliCtx:     cliCtx,
+		receipts:   receipts,
+		broadcasts: make(chan broadcastJob, maxQueuedBroadcasts),
+		polling:    make(map[string]*Receipt),
+	}
+	for i := 0; i < receiptWorkers; i++ {
+		go s.broadcastWorker()
+	}
+	go s.poll()
+	return s
+}
+
+func (s *receiptStore) get(id string) (Receipt, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	receipt, ok := s.receipts.Get(id)
+	if !ok {
+		return Receipt{}, false
+	}
+	return *receipt.(*Receipt), true
+}
+
+func (s *receiptStore) add(receipt *Receipt) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.receipts.Add(receipt.ID, receipt)
+}
+
+func (s *receiptStore) update(receipt *Receipt, update func(*Receipt)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	update(receipt)
+}
+
+func newReceiptID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// enqueue hands the tx to the broadcast workers, it returns false if too many txs are queued
+func (s *receiptStore) enqueue(receipt *Receipt, txBytes []byte) bool {
+	select {
+	case s.broadcasts <- broadcastJob{receipt: receipt, txBytes: txBytes}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *receiptStore) broadcastWorker() {
+	for job := range s.broadcasts {
+		s.broadcast(job.receipt, job.txBytes)
+	}
+}
+
+// broadcast broadcasts the tx and hands it to the poller once it is checked
+func (s *receiptStore) broadcast(receipt *Receipt, txBytes []byte) {
+	res, err := s.cliCtx.BroadcastTxSync(txBytes)
+	if err != nil {
+		s.update(receipt, func(r *Receipt) {
+			r.Status = ReceiptRejected
+			r.Error = err.Error()
+		})
+		return
+	}
+	checkTx := abci.ResponseCheckTx{Code: res.Code, Data: res.Data, Log: res.Log}
+	if !checkTx.IsOK() {
+		s.update(receipt, func(r *Receipt) {
+			r.Status = ReceiptRejected
+			r.CheckTx = &checkTx
+		})
+		return
+	}
+	s.update(receipt, func(r *Receipt) {
+		r.Status = ReceiptChecked
+		r.CheckTx = &checkTx
+	})
+	s.pollMtx.Lock()
+	s.polling[receipt.ID] = receipt
+	s.pollMtx.Unlock()
+}
+
+// poll looks for the checked txs in the blocks until they are committed or given up
+func (s *receiptStore) poll() {
+	deadlines := make(map[string]time.Time)
+	for range time.Tick(receiptPollInterval) {
+		s.pollMtx.Lock()
+		pending := make([]*Receipt, 0, len(s.polling))
+		for _, receipt := range s.polling {
+			pending = append(pending, receipt)
+		}
+		s.pollMtx.Unlock()
+		if len(pending) == 0 {
+			continue
+		}
+
+		node, err := s.cliCtx.GetNode()
+		for _, receipt := range pending {
+			if _, ok := deadlines[receipt.ID]; !ok {
+				deadlines[receipt.ID] = time.Now().Add(receiptPollTimeout)
+			}
+			if err == nil {
+				if resTx, err := node.Tx(receipt.Hash, false); err == nil {
+					s.update(receipt, func(r *Receipt) {
+						r.Status = ReceiptCommitted
+						r.Height = resTx.Height
+						r.DeliverTx = &resTx.TxResult
+					})
+					s.stopPolling(receipt, deadlines)
+					continue
+				}
+			}
+			// not included yet
+			if time.Now().After(deadlines[receipt.ID]) {
+				s.update(receipt, func(r *Receipt) {
+					r.Status = ReceiptUnknown
+					r.Error = "tx not found in a block in time"
+				})
+				s.stopPolling(receipt, deadlines)
+			}
+		}
+	}
+}
+
+func (s *receiptStore) stopPolling(receipt *Receipt, deadlines map[string]time.Time) {
+	s.pollMtx.Lock()
+	delete(s.polling, receipt.ID)
+	s.pollMtx.Unlock()
+	delete(deadlines, receipt.ID)
+}
+
+// broadcastTxWithReceiptHandlerFn REST Handler, it broadcasts the tx in the background and returns
+// a receipt at once, whose status is then given by queryReceiptHandlerFn
+func broadcastTxWithReceiptHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, store *receiptStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m BroadcastBody
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		err = cdc.UnmarshalJSON(body, &m)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if len(m.TxBytes) == 0 {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "tx is empty")
+			return
+		}
+
+		receipt := &Receipt{
+			ID:     newReceiptID(),
+			Hash:   tmtypes.Tx(m.TxBytes).Hash(),
+			Status: ReceiptPending,
+		}
+		if !store.enqueue(receipt, m.TxBytes) {
+			utils.WriteErrorResponse(w, http.StatusServiceUnavailable, "too many txs being broadcast, retry later")
+			return
+		}
+		store.add(receipt)
+
+		res, _ := store.get(receipt.ID)
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// queryReceiptHandlerFn REST Handler, it returns the receipt of a tx broadcast by
+// broadcastTxWithReceiptHandlerFn
+func queryReceiptHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, store *receiptStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+		receipt, ok := store.get(id)
+		if !ok {
+			utils.WriteErrorResponse(w, http.StatusNotFound, "receipt not found: "+id)
+			return
+		}
+		utils.PostProcessResponse(w, cdc, receipt, cliCtx.Indent)
+	}
+}
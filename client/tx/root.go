@@ -21,4 +21,8 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 	r.HandleFunc("/txs/{hash}", QueryTxRequestHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/txs", SearchTxRequestHandlerFn(cliCtx, cdc)).Methods("GET")
 	r.HandleFunc("/txs", BroadcastTxRequest(cliCtx, cdc)).Methods("POST")
+
+	receipts := newReceiptStore(cliCtx)
+	r.HandleFunc("/txs/async", broadcastTxWithReceiptHandlerFn(cliCtx, cdc, receipts)).Methods("POST")
+	r.HandleFunc("/txs/receipts/{id}", queryReceiptHandlerFn(cliCtx, cdc, receipts)).Methods("GET")
 }
@@ -164,7 +164,7 @@ func SignStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string,
 	}
 
 	if !offline && txBldr.Sequence == 0 {
-		accSeq, err := cliCtx.GetAccountSequence(addr)
+		accSeq, err := cliCtx.GetLaneSequence(addr, txBldr.Lane)
 		if err != nil {
 			return signedStdTx, err
 		}
@@ -209,7 +209,7 @@ func prepareTxBuilder(txBldr authtxb.TxBuilder, cliCtx context.CLIContext) (auth
 	// TODO: (ref #1903) Allow for user supplied account sequence without
 	// automatically doing a manual lookup.
 	if txBldr.Sequence == 0 && !viper.GetBool(client.FlagOffline) {
-		accSeq, err := cliCtx.GetAccountSequence(from)
+		accSeq, err := cliCtx.GetLaneSequence(from, txBldr.Lane)
 		if err != nil {
 			return txBldr, err
 		}
@@ -233,7 +233,8 @@ func buildUnsignedStdTxOffline(txBldr authtxb.TxBuilder, msgs []sdk.Msg) (stdTx
 	if err != nil {
 		return
 	}
-	return auth.NewStdTx(stdSignMsg.Msgs, nil, stdSignMsg.Memo, stdSignMsg.Source, nil), nil
+	return auth.NewStdTx(stdSignMsg.Msgs, nil, stdSignMsg.Memo, stdSignMsg.Source, nil).
+		WithValidUntilHeight(stdSignMsg.ValidUntil), nil
 }
 
 func isTxSigner(user sdk.AccAddress, signers []sdk.AccAddress) bool {
@@ -16,12 +16,14 @@ import (
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/account"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/oracle"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
@@ -63,11 +65,13 @@ type GaiaApp struct {
 	tkeyParams       *sdk.TransientStoreKey
 	keyIbc           *sdk.KVStoreKey
 	keySide          *sdk.KVStoreKey
+	keyOracle        *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountKeeper       auth.AccountKeeper
 	feeCollectionKeeper auth.FeeCollectionKeeper
 	bankKeeper          bank.Keeper
+	supplyKeeper        bank.SupplyKeeper
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
 	mintKeeper          mint.Keeper
@@ -75,6 +79,7 @@ type GaiaApp struct {
 	govKeeper           gov.Keeper
 	paramsKeeper        params.Keeper
 	ibcKeeper           ibc.Keeper
+	oracleKeeper        oracle.Keeper
 }
 
 // NewGaiaApp returns a reference to an initialized GaiaApp.
@@ -102,6 +107,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		tkeyParams:       sdk.NewTransientStoreKey("transient_params"),
 		keyIbc:           sdk.NewKVStoreKey("ibc"),
 		keySide:          sdk.NewKVStoreKey("sc"),
+		keyOracle:        sdk.NewKVStoreKey("oracle"),
 	}
 
 	// define the accountKeeper
@@ -121,14 +127,16 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.cdc,
 		app.keyParams, app.tkeyParams,
 	)
-	app.ibcKeeper = ibc.NewKeeper(app.keyIbc, app.paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace,
-		sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc))
+	scKeeper := sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc)
+	app.ibcKeeper = ibc.NewKeeper(app.keyIbc, app.paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scKeeper)
+	app.ibcKeeper.SetupForRefund(app.bankKeeper, app.Pool)
 	app.stakeKeeper = stake.NewKeeper(
 		app.cdc,
 		app.keyStake, app.keyStakeReward, app.tkeyStake,
 		app.bankKeeper, app.Pool, app.paramsKeeper.Subspace(stake.DefaultParamspace),
 		app.RegisterCodespace(stake.DefaultCodespace),
 	)
+	app.stakeKeeper.SetSupplyKeeper(&app.supplyKeeper)
 	app.mintKeeper = mint.NewKeeper(app.cdc, app.keyMint,
 		app.paramsKeeper.Subspace(mint.DefaultParamspace),
 		app.stakeKeeper,
@@ -154,29 +162,71 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.RegisterCodespace(gov.DefaultCodespace),
 		app.Pool,
 	)
+	app.supplyKeeper = bank.NewSupplyKeeper(app.accountKeeper)
+	app.supplyKeeper.AddEscrowAccount("gov", gov.DepositedCoinsAccAddr, gov.DepositedCoinsModuleAddr)
+	app.supplyKeeper.AddEscrowAccount("bridge", sdk.PegAccount, sdk.PegModuleAccount)
+	app.supplyKeeper.SetStakingAccount(stake.DelegationAccAddr, app.stakeKeeper)
+	app.supplyKeeper.AddEscrowAccount("mint", mint.ProvisionsAccAddr)
+	app.supplyKeeper.AddModuleAccount("mint", mint.ProvisionsAccAddr, bank.PermMinter)
+	app.supplyKeeper.AddModuleAccount("stake", stake.DelegationAccAddr, bank.PermBurner)
+	app.mintKeeper.SetSupplyKeeper(&app.supplyKeeper)
+	app.slashingKeeper.SetGovKeeper(&app.govKeeper)
+	app.govKeeper.AddHooks(gov.ProposalTypeUntombstoneValidator, slashing.NewUntombstoneHooks(app.slashingKeeper))
+	app.ibcKeeper.SetGovKeeper(&app.govKeeper)
+	app.govKeeper.AddHooks(gov.ProposalTypeManageChanSenders, ibc.NewChanSendersHooks())
+	app.distrKeeper.SetGovKeeper(&app.govKeeper)
+	app.govKeeper.AddHooks(gov.ProposalTypeWithdrawAddrBans, distr.NewWithdrawAddrBansHooks())
+	app.govKeeper.AddHooks(gov.ProposalTypeDistrParamsChange, distr.NewDistrParamsChangeHooks())
 
 	// register the staking hooks
 	app.stakeKeeper = app.stakeKeeper.WithHooks(
 		NewHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
 
+	app.oracleKeeper = oracle.NewKeeper(app.cdc, app.keyOracle, app.paramsKeeper.Subspace(oracle.DefaultParamSpace),
+		app.stakeKeeper, scKeeper, app.ibcKeeper, app.bankKeeper, app.Pool)
+	app.oracleKeeper.SetGovKeeper(&app.govKeeper)
+	app.oracleKeeper.SetSlashingKeeper(app.slashingKeeper)
+	app.oracleKeeper.SetDistributionKeeper(app.distrKeeper)
+	app.govKeeper.AddHooks(gov.ProposalTypeRelayerAllowList, oracle.NewRelayerAllowListHooks(app.oracleKeeper))
+	app.govKeeper.AddHooks(gov.ProposalTypeSkipSequence, oracle.NewSkipSequenceHooks(app.oracleKeeper))
+
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.bankKeeper)).
+		AddRoute(auth.RouteKey, auth.NewHandler(app.accountKeeper)).
+		AddRoute(account.RouteKey, account.NewHandler(app.accountKeeper)).
 		AddRoute("stake", stake.NewStakeHandler(app.stakeKeeper)).
 		AddRoute("distr", distr.NewHandler(app.distrKeeper)).
 		AddRoute("slashing", slashing.NewSlashingHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute(oracle.RouteOracle, oracle.NewHandler(app.oracleKeeper))
 
+	committed, _ := app.GetCommitMultiStore().(sdk.Queryable)
 	app.QueryRouter().
+		AddRoute("acc", auth.NewQuerierWithProofs(app.accountKeeper, app.cdc, committed)).
+		AddRoute("bank", bank.NewQuerier(app.supplyKeeper, app.cdc)).
+		AddRoute("distr", distr.NewQuerier(app.distrKeeper, app.cdc)).
 		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
+		AddRoute("ibc", ibc.NewQuerier(app.ibcKeeper, app.cdc)).
+		AddRoute("oracle", oracle.NewQuerier(app.oracleKeeper, app.cdc)).
+		AddRoute("params", params.NewQuerier(app.paramsKeeper)).
+		AddRoute("slashing", slashing.NewQuerier(app.slashingKeeper, app.cdc)).
 		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
 
 	// initialize BaseApp
 	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyStakeReward, app.keyMint, app.keyDistr,
-		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc)
+		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc, app.keySide, app.keyOracle)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper))
+	// the signatures verified in PreCheckTx are not verified again in CheckTx and DeliverTx
+	sigCache := auth.NewSigCache(auth.DefaultSigCacheSize)
+	if app.TelemetryEnabled() {
+		sigCache.EnablePrometheusMetrics()
+	}
+	app.SetPreChecker(auth.NewSigVerifyPreChecker(app.accountKeeper, sigCache))
+	app.SetReCheckFilter(auth.NewReCheckFilter(app.accountKeeper))
+	app.SetAnteHandler(account.NewAnteHandler(app.accountKeeper,
+		distr.NewAnteHandler(app.accountKeeper, auth.NewAnteHandlerWithSigCache(app.accountKeeper, sigCache))))
 	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr)
 	app.SetEndBlocker(app.EndBlocker)
 
@@ -187,6 +237,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 
 	accountStore := app.BaseApp.GetCommitMultiStore().GetKVStore(app.keyAccount)
 	app.SetAccountStoreCache(cdc, accountStore, accountCacheCap)
+	app.SetHistoricalAccountStore(cdc, app.keyAccount)
 
 	err = app.InitFromStore(app.keyMain)
 	if err != nil {
@@ -204,7 +255,9 @@ func MakeCodec() *codec.Codec {
 	distr.RegisterCodec(cdc)
 	slashing.RegisterCodec(cdc)
 	gov.RegisterCodec(cdc)
+	oracle.RegisterWire(cdc)
 	auth.RegisterCodec(cdc)
+	account.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
 	return cdc
@@ -230,8 +283,11 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	ctx = ctx.WithEventManager(sdk.NewEventManager())
 	gov.EndBlocker(ctx, app.govKeeper)
+	app.oracleKeeper.EndBlocker(ctx)
+	slashing.EndBlocker(ctx, app.slashingKeeper)
 	validatorUpdates, _ := stake.EndBlocker(ctx, app.stakeKeeper)
 	ibc.EndBlocker(ctx, app.ibcKeeper)
+	distr.EndBlocker(ctx, app.distrKeeper)
 
 	// Add these new validators to the addr -> pubkey map.
 	app.slashingKeeper.AddValidators(ctx, validatorUpdates)
@@ -255,11 +311,11 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	}
 
 	// load the accounts
+	accs := make([]sdk.Account, 0, len(genesisState.Accounts))
 	for _, gacc := range genesisState.Accounts {
-		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountKeeper.GetNextAccountNumber(ctx)
-		app.accountKeeper.SetAccount(ctx, acc)
+		accs = append(accs, gacc.ToAccount())
 	}
+	app.accountKeeper.LoadGenesisAccounts(ctx, accs)
 
 	// load the initial stake information
 	validators, err := stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
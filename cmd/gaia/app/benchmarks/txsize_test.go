@@ -26,7 +26,7 @@ func ExampleTxSendSize() {
 		Outputs: []bank.Output{bank.NewOutput(addr2, coins)},
 	}
 	sig, _ := priv1.Sign(msg1.GetSignBytes())
-	sigs := []auth.StdSignature{{nil, sig, 0, 0}}
+	sigs := []auth.StdSignature{{PubKey: nil, Signature: sig, AccountNumber: 0, Sequence: 0}}
 	tx := auth.NewStdTx([]sdk.Msg{msg1}, sigs, "", 0, nil)
 	fmt.Println(len(cdc.MustMarshalBinaryBare([]sdk.Msg{msg1})))
 	fmt.Println(len(cdc.MustMarshalBinaryBare(tx)))
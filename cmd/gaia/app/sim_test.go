@@ -124,6 +124,7 @@ func testAndRunTxs(app *GaiaApp) []simulation.WeightedOperation {
 func invariants(app *GaiaApp) []simulation.Invariant {
 	return []simulation.Invariant{
 		banksim.NonnegativeBalanceInvariant(app.accountKeeper),
+		banksim.SupplyInvariant(app.supplyKeeper, app.accountKeeper),
 		govsim.AllInvariants(),
 		stakesim.AllInvariants(app.bankKeeper, app.stakeKeeper, app.distrKeeper, app.accountKeeper),
 		slashingsim.AllInvariants(),
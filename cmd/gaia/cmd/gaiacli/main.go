@@ -20,6 +20,7 @@ import (
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
 	distrcmd "github.com/cosmos/cosmos-sdk/x/distribution/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	oraclecmd "github.com/cosmos/cosmos-sdk/x/oracle/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
 )
@@ -65,6 +66,9 @@ func main() {
 	queryCmd.AddCommand(client.LineBreak)
 	queryCmd.AddCommand(client.GetCommands(
 		authcmd.GetAccountCmd(storeAcc, cdc, authcmd.GetAccountDecoder(cdc)),
+		authcmd.GetInspectAccountCmd(storeAcc, cdc),
+		bankcmd.GetCmdQuerySupply(cdc),
+		bankcmd.GetCmdQueryFeesSpent(cdc),
 		stakecmd.GetCmdQueryDelegation(storeStake, cdc),
 		stakecmd.GetCmdQueryDelegations(storeStake, cdc),
 		stakecmd.GetCmdQueryParams(storeStake, cdc),
@@ -76,12 +80,17 @@ func main() {
 		stakecmd.GetCmdQueryRedelegation(storeStake, cdc),
 		stakecmd.GetCmdQueryRedelegations(storeStake, cdc),
 		slashingcmd.GetCmdQuerySigningInfo(storeSlashing, cdc),
+		slashingcmd.GetCmdQuerySigningPerformance(cdc),
 		stakecmd.GetCmdQueryUnbondingDelegation(storeStake, cdc),
 		stakecmd.GetCmdQueryUnbondingDelegations(storeStake, cdc),
 		stakecmd.GetCmdQueryValidator(storeStake, cdc),
 		stakecmd.GetCmdQueryValidators(storeStake, cdc),
+		stakecmd.GetCmdQueryValidatorStatus(storeStake, cdc),
+		stakecmd.GetCmdQueryExchangeRate(cdc),
+		distrcmd.GetCmdQueryWithdrawAddrs(cdc),
 		govcmd.GetCmdQueryVote(storeGov, cdc),
 		govcmd.GetCmdQueryVotes(storeGov, cdc),
+		govcmd.GetCmdQueryTallyBreakdown(storeGov, cdc),
 	)...)
 
 	//Add query commands
@@ -101,19 +110,28 @@ func main() {
 	txCmd.AddCommand(
 		client.PostCommands(
 			stakecmd.GetCmdCreateValidator(cdc),
+			stakecmd.GetCmdCreateValidatorFromFile(cdc),
 			stakecmd.GetCmdEditValidator(cdc),
 			stakecmd.GetCmdDelegate(cdc),
 			stakecmd.GetCmdRedelegate(storeStake, cdc),
 			stakecmd.GetCmdUnbond(storeStake, cdc),
 			distrcmd.GetCmdWithdrawRewards(cdc),
+			stakecmd.GetCmdWithdrawCommissionAndRestake(cdc),
 			distrcmd.GetCmdSetWithdrawAddr(cdc),
+			distrcmd.GetCmdSetCommissionAgreement(cdc),
+			distrcmd.GetCmdRemoveCommissionAgreement(cdc),
 			govcmd.GetCmdDeposit(cdc),
 			bankcmd.SendTxCmd(cdc),
 			govcmd.GetCmdSubmitProposal(cdc),
 			govcmd.GetCmdSubmitListProposal(cdc),
 			slashingcmd.GetCmdUnjail(cdc),
 			govcmd.GetCmdVote(cdc),
+			govcmd.GetCmdVoteWeighted(cdc),
 		)...)
+
+	// add the oracle commands of relayers
+	oraclecmd.AddCommands(rootCmd, cdc)
+
 	rootCmd.AddCommand(
 		queryCmd,
 		txCmd,
@@ -48,6 +48,15 @@ func main() {
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
 	return app.NewGaiaApp(logger, db, traceStore,
 		baseapp.SetPruning(viper.GetString("pruning")),
+		baseapp.SetArchiveMode(viper.GetBool("archive")),
+		baseapp.SetCacheWarmUp(viper.GetBool("warm-up-cache")),
+		baseapp.SetHaltHeight(viper.GetInt64("halt-height")),
+		baseapp.SetHaltTime(viper.GetInt64("halt-time")),
+		baseapp.SetAccountCacheSize(viper.GetInt("account_cache_size")),
+		baseapp.SetAccountCacheEviction(viper.GetString("account_cache_eviction")),
+		baseapp.SetTelemetry(viper.GetBool("telemetry")),
+		baseapp.SetMinimumFees(viper.GetString("minimum_fees")),
+		baseapp.SetParallelCheckTxRoutes("bank"),
 	)
 }
 
@@ -354,7 +354,12 @@ func (kb dbKeybase) Import(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	kb.db.Set(infoKey(name), infoBytes)
+	info, err := readInfo(infoBytes)
+	if err != nil {
+		return
+	}
+	// write through writeInfo so that the key can be found by address
+	kb.writeInfo(info, name)
 	return nil
 }
 
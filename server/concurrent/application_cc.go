@@ -7,3 +7,26 @@ type ApplicationCC interface {
 	PreCheckTx(req types.RequestCheckTx) types.ResponseCheckTx
 	PreDeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx
 }
+
+// CacheWarmer is implemented by applications that save the hot keys of their
+// inter-block caches on stop and pre-load them on start.
+type CacheWarmer interface {
+	WarmUpCache()
+	SaveHotKeys()
+}
+
+// PreEndBlockApp is implemented by applications that prepare EndBlock, e.g. compute the validator
+// set changes, while the last DeliverTx of the block are still running.
+type PreEndBlockApp interface {
+	// PreEndBlock must only read the state of the last block, it is not called concurrently
+	// with EndBlock but may not be called at all.
+	PreEndBlock(req types.RequestEndBlock)
+}
+
+// ParallelCheckTxApp is implemented by applications whose CheckTx can run concurrently
+// for txs touching disjoint accounts.
+type ParallelCheckTxApp interface {
+	// CheckTxAccounts returns the accounts a tx reads or writes in CheckTx,
+	// or false if the tx has to be checked exclusively.
+	CheckTxAccounts(req types.RequestCheckTx) ([][]byte, bool)
+}
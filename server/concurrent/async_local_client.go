@@ -1,7 +1,9 @@
 package concurrent
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/server/concurrent/pool"
 
@@ -23,22 +25,40 @@ const (
 	WorkerPoolSize  = 16
 	WorkerPoolSpawn = 4
 	WorkerPoolQueue = 16
+
+	checkTxPoolSize   = WorkerPoolSize / 2
+	deliverTxPoolSize = WorkerPoolSize
+
+	// DefaultDrainTimeout bounds the wait for the queued CheckTx/DeliverTx on stop
+	DefaultDrainTimeout = 10 * time.Second
 )
 
 type WorkItem struct {
 	reqRes *abcicli.ReqRes
 	mtx    *sync.Mutex // make sure the eventual execution sequence
+	trace  *txTrace    // nil unless the tracing is enabled
 }
 
 type localAsyncClientCreator struct {
 	app types.Application
 	log log.Logger
 
-	commitLock     *sync.Mutex
-	checkTxLowLock *sync.Mutex
-	checkTxMidLock *sync.Mutex
-	wgCommit       *sync.WaitGroup
-	rwLock         *sync.RWMutex
+	commitLock       *sync.Mutex
+	checkTxLowLock   *sync.Mutex
+	checkTxMidLock   *sync.Mutex
+	wgCommit         *sync.WaitGroup
+	rwLock           *sync.RWMutex
+	guard            *blockGuard
+	checkTxWorkers   int
+	drainTimeout     time.Duration
+	metrics          *Metrics
+	traceTxs         bool
+	checkLockOrder   bool
+	backPressure     BackPressurePolicy
+	orderedCallbacks bool
+
+	clientsMtx sync.Mutex
+	clients    []*asyncLocalClient // the clients created, to report the stats of their queues
 }
 
 type asyncLocalClient struct {
@@ -48,16 +68,39 @@ type asyncLocalClient struct {
 
 	checkTxPool   *pool.Pool
 	deliverTxPool *pool.Pool
+	checkTxCtx    context.Context // canceled on stop, the queued PreCheckTx are skipped
+	cancelCheckTx context.CancelFunc
 
-	commitLock     *sync.Mutex
-	checkTxLowLock *sync.Mutex
-	checkTxMidLock *sync.Mutex
+	// the locks are acquired in this order: checkTxLowLock, checkTxMidLock, commitLock, rwLock
+	commitLock     sync.Locker
+	checkTxLowLock sync.Locker
+	checkTxMidLock sync.Locker
 	wgCommit       *sync.WaitGroup
-	rwLock         *sync.RWMutex
+	rwLock         rwLocker
+	guard          *blockGuard
 
 	checkTxQueue   chan WorkItem
 	deliverTxQueue chan WorkItem
+	queueLock      sync.RWMutex   // held to send to the queues, exclusively to close them
+	stopped        bool           // the queues are closed, guarded by queueLock
+	wgWorkers      sync.WaitGroup // the workers draining the queues
 	log            log.Logger
+
+	checkTxWorkers int           // more than one runs the real CheckTx in parallel if the app supports it
+	drainTimeout   time.Duration // how long OnStop waits for the queues to be drained
+	metrics        *Metrics
+	traceTxs       bool // log the time spent in every stage by each CheckTx/DeliverTx
+
+	// the CheckTx callbacks fire in the order of the requests, the ones of the txs checked in
+	// parallel are buffered until the previous ones are responded
+	orderedCallbacks bool
+
+	backPressure   BackPressurePolicy // what DeliverTxAsync does when deliverTxQueue is full
+	overflowMtx    sync.Mutex
+	overflow       []WorkItem     // the DeliverTx beyond deliverTxQueue with BackPressureGrow
+	pumping        bool           // pumpOverflow is running, guarded by overflowMtx
+	wgPump         sync.WaitGroup // pumpOverflow, deliverTxQueue is closed once it is done
+	aboveWatermark int32          // 1 once the DeliverTx queue is above the high watermark
 }
 
 func NewAsyncLocalClient(app types.Application, log log.Logger,
@@ -67,10 +110,13 @@ func NewAsyncLocalClient(app types.Application, log log.Logger,
 	if !ok {
 		return nil
 	}
+	checkTxCtx, cancelCheckTx := context.WithCancel(context.Background())
 	cli := &asyncLocalClient{
 		Application:    appcc,
-		checkTxPool:    pool.NewPool(WorkerPoolSize/2, WorkerPoolQueue/2, WorkerPoolSpawn/2),
-		deliverTxPool:  pool.NewPool(WorkerPoolSize, WorkerPoolQueue, WorkerPoolSpawn),
+		checkTxCtx:     checkTxCtx,
+		cancelCheckTx:  cancelCheckTx,
+		checkTxPool:    pool.NewPool(checkTxPoolSize, WorkerPoolQueue/2, WorkerPoolSpawn/2),
+		deliverTxPool:  pool.NewPool(deliverTxPoolSize, WorkerPoolQueue, WorkerPoolSpawn),
 		checkTxQueue:   make(chan WorkItem, WorkerPoolQueue*2),
 		deliverTxQueue: make(chan WorkItem, WorkerPoolQueue*2),
 		log:            log,
@@ -79,6 +125,10 @@ func NewAsyncLocalClient(app types.Application, log log.Logger,
 		checkTxMidLock: checkTxMidLock,
 		wgCommit:       wgCommit,
 		rwLock:         rwLock,
+		guard:          new(blockGuard),
+		drainTimeout:   DefaultDrainTimeout,
+		metrics:        NopMetrics(),
+		backPressure:   BackPressureBlock,
 	}
 	cli.BaseService = *cmn.NewBaseService(nil, "asyncLocalClient", cli)
 	return cli
@@ -88,17 +138,58 @@ func (app *asyncLocalClient) OnStart() error {
 	if err := app.BaseService.OnStart(); err != nil {
 		return err
 	}
-	go app.checkTxWorker()
+	if warmer, ok := app.Application.(CacheWarmer); ok {
+		app.rwLock.Lock()
+		warmer.WarmUpCache()
+		app.rwLock.Unlock()
+	}
+	app.wgWorkers.Add(2)
+	if checker, ok := app.Application.(ParallelCheckTxApp); ok && app.checkTxWorkers > 1 {
+		go app.parallelCheckTxWorker(checker)
+	} else {
+		go app.checkTxWorker()
+	}
 	go app.deliverTxWorker()
 	return nil
 }
 
+// OnStop stops accepting CheckTx/DeliverTx, then waits up to drainTimeout for the workers to
+// respond to the queued ones before saving the caches. The queued CheckTx skip PreCheckTx and
+// respond with ErrNodeStopping.
 func (app *asyncLocalClient) OnStop() {
 	app.BaseService.OnStop()
-	app.commitLock.Lock()
+	app.cancelCheckTx()
+	app.queueLock.Lock()
+	app.stopped = true
 	close(app.checkTxQueue)
+	app.wgPump.Wait() // the worker keeps draining the queue meanwhile
 	close(app.deliverTxQueue)
-	app.commitLock.Unlock()
+	app.queueLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.drainTimeout)
+	defer cancel()
+	drained := make(chan struct{})
+	go func() {
+		app.wgWorkers.Wait()
+		close(drained)
+	}()
+	// the workers wait for the queued PreCheckTx/PreDeliverTx run by the pools
+	_ = app.checkTxPool.Shutdown(ctx)
+	_ = app.deliverTxPool.Shutdown(ctx)
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		// a worker may still hold the app lock, saving the caches could block forever
+		app.log.Error("Timed out draining the ABCI queues", "timeout", app.drainTimeout,
+			"checkTxQueue", len(app.checkTxQueue), "deliverTxQueue", len(app.deliverTxQueue))
+		return
+	}
+
+	if warmer, ok := app.Application.(CacheWarmer); ok {
+		app.rwLock.Lock()
+		warmer.SaveHotKeys()
+		app.rwLock.Unlock()
+	}
 }
 
 func (app *asyncLocalClient) SetResponseCallback(cb abcicli.Callback) {
@@ -108,45 +199,45 @@ func (app *asyncLocalClient) SetResponseCallback(cb abcicli.Callback) {
 }
 
 func (app *asyncLocalClient) checkTxWorker() {
+	defer app.wgWorkers.Done()
 	for i := range app.checkTxQueue {
+		app.metrics.QueueDepth.With("queue", "check_tx").Set(float64(len(app.checkTxQueue)))
+		i.trace.mark(stagePickup)
 		i.mtx.Lock() // wait the PreCheckTx finish
 		i.mtx.Unlock()
 		func() {
 			app.rwLock.Lock()         // make sure not other non-CheckTx/non-DeliverTx ABCI is called
 			defer app.rwLock.Unlock() // this unlock is put after wgCommit.Done() to give commit priority
-			if i.reqRes.Response == nil {
-				tx := types.RequestCheckTx{Tx: i.reqRes.Request.GetCheckTx().GetTx()}
-				res := app.Application.CheckTx(tx)
-				i.reqRes.Response = types.ToResponseCheckTx(res) // Set response
-			}
-			i.reqRes.Done()
-			app.wgCommit.Done() // enable Commit to start
-			if cb := i.reqRes.GetCallback(); cb != nil {
-				cb(i.reqRes.Response)
-			}
-			app.Callback(i.reqRes.Request, i.reqRes.Response)
+			app.runCheckTx(i, func(cb func()) { cb() })
 		}()
 	}
 }
 
 func (app *asyncLocalClient) deliverTxWorker() {
+	defer app.wgWorkers.Done()
 	for i := range app.deliverTxQueue {
+		app.metrics.QueueDepth.With("queue", "deliver_tx").Set(float64(app.deliverTxQueueDepth()))
+		app.checkLowWatermark()
+		i.trace.mark(stagePickup)
 		i.mtx.Lock() // wait the PreDeliverTx finish
 		i.mtx.Unlock()
 		func() {
 			app.rwLock.Lock()         // make sure not other non-CheckTx/non-DeliverTx ABCI is called
 			defer app.rwLock.Unlock() // this unlock is put after wgCommit.Done() to give commit priority
+			i.trace.mark(stageExecStart)
 			if i.reqRes.Response == nil {
 				tx := types.RequestDeliverTx{Tx: i.reqRes.Request.GetDeliverTx().GetTx()}
 				res := app.Application.DeliverTx(tx)
 				i.reqRes.Response = types.ToResponseDeliverTx(res) // Set response
 			}
+			i.trace.mark(stageExecEnd)
 			i.reqRes.Done()
 			app.wgCommit.Done() // enable Commit to start
 			if cb := i.reqRes.GetCallback(); cb != nil {
 				cb(i.reqRes.Response)
 			}
 			app.Callback(i.reqRes.Request, i.reqRes.Response)
+			i.trace.emit(app.log)
 		}()
 	}
 }
@@ -190,45 +281,102 @@ func (app *asyncLocalClient) SetOptionAsync(req types.RequestSetOption) *abcicli
 
 func (app *asyncLocalClient) DeliverTxAsync(req types.RequestDeliverTx) *abcicli.ReqRes {
 	// no app level lock because the real DeliverTx would be called in the worker routine
+	trace := newTxTrace(app.traceTxs, "deliver_tx", req.Tx)
 	reqp := types.ToRequestDeliverTx(req)
 	reqres := abcicli.NewReqRes(reqp)
 	mtx := new(sync.Mutex)
 	mtx.Lock()
-	app.deliverTxQueue <- WorkItem{reqRes: reqres, mtx: mtx}
+	app.queueLock.RLock()
+	if app.stopped {
+		app.queueLock.RUnlock()
+		return app.callback(reqp, types.ToResponseDeliverTx(stoppingDeliverTxResponse()))
+	}
+	trace.mark(stageEnqueue) // before the worker may read it
+	app.enqueueDeliverTx(WorkItem{reqRes: reqres, mtx: mtx, trace: trace})
+	app.metrics.QueueDepth.With("queue", "deliver_tx").Set(float64(app.deliverTxQueueDepth()))
 	//no need to lock commitLock because Commit and DeliverTx will not be called concurrently
 	app.wgCommit.Add(1)
-	app.deliverTxPool.Schedule(func() {
+	app.queueLock.RUnlock()
+	err := app.deliverTxPool.ScheduleCtx(context.Background(), func(context.Context) {
 		defer mtx.Unlock()
+		busy := app.metrics.PoolBusyWorkers.With("pool", "deliver_tx")
+		busy.Add(1)
+		defer busy.Add(-1)
+		trace.mark(stagePreStart)
+		start := time.Now()
 		res := app.Application.PreDeliverTx(req)
+		app.metrics.PreDeliverTxLatency.Observe(time.Since(start).Seconds())
+		trace.mark(stagePreEnd)
 		if !res.IsOK() { // no need to call the real DeliverTx
 			reqres.Response = types.ToResponseDeliverTx(res)
 		}
 	})
+	if err != nil { // the pool is shut down, the worker delivers the tx without PreDeliverTx
+		mtx.Unlock()
+	}
 
 	return reqres
 }
 
 func (app *asyncLocalClient) CheckTxAsync(req types.RequestCheckTx) *abcicli.ReqRes {
+	if app.guard.isStopping() {
+		return app.callback(
+			types.ToRequestCheckTx(req),
+			types.ToResponseCheckTx(stoppingCheckTxResponse()),
+		)
+	}
+	if app.shedCheckTx() {
+		return app.callback(
+			types.ToRequestCheckTx(req),
+			types.ToResponseCheckTx(queueFullCheckTxResponse()),
+		)
+	}
 	// no app level lock because the real CheckTx would be called in the worker routine
+	trace := newTxTrace(app.traceTxs, "check_tx", req.Tx)
 	reqp := types.ToRequestCheckTx(req)
 	reqres := abcicli.NewReqRes(reqp)
 	mtx := new(sync.Mutex)
 	mtx.Lock()
 	app.checkTxLowLock.Lock()
 	app.checkTxMidLock.Lock()
-	app.commitLock.Lock() // here would block further queue if commit is ready to go
+	app.lockCommit("check_tx") // here would block further queue if commit is ready to go
 	app.checkTxMidLock.Unlock()
-	app.checkTxQueue <- WorkItem{reqRes: reqres, mtx: mtx}
+	app.queueLock.RLock()
+	if app.stopped {
+		app.queueLock.RUnlock()
+		app.commitLock.Unlock()
+		app.checkTxLowLock.Unlock()
+		return app.callback(reqp, types.ToResponseCheckTx(stoppingCheckTxResponse()))
+	}
+	trace.mark(stageEnqueue) // before the worker may read it
+	app.checkTxQueue <- WorkItem{reqRes: reqres, mtx: mtx, trace: trace}
+	app.metrics.QueueDepth.With("queue", "check_tx").Set(float64(len(app.checkTxQueue)))
 	app.wgCommit.Add(1)
+	app.queueLock.RUnlock()
 	app.commitLock.Unlock()
 	app.checkTxLowLock.Unlock()
-	app.checkTxPool.Schedule(func() {
+	err := app.checkTxPool.ScheduleCtx(app.checkTxCtx, func(ctx context.Context) {
 		defer mtx.Unlock()
+		if ctx.Err() != nil { // the client is stopping
+			reqres.Response = types.ToResponseCheckTx(stoppingCheckTxResponse())
+			return
+		}
+		busy := app.metrics.PoolBusyWorkers.With("pool", "check_tx")
+		busy.Add(1)
+		defer busy.Add(-1)
+		trace.mark(stagePreStart)
+		start := time.Now()
 		res := app.Application.PreCheckTx(req)
+		app.metrics.PreCheckTxLatency.Observe(time.Since(start).Seconds())
+		trace.mark(stagePreEnd)
 		if !res.IsOK() { // no need to call the real CheckTx
 			reqres.Response = types.ToResponseCheckTx(res)
 		}
 	})
+	if err != nil { // the pool is shut down
+		reqres.Response = types.ToResponseCheckTx(stoppingCheckTxResponse())
+		mtx.Unlock()
+	}
 	return reqres
 }
 
@@ -255,9 +403,10 @@ func (app *asyncLocalClient) QueryAsync(req types.RequestQuery) *abcicli.ReqRes
 }
 
 func (app *asyncLocalClient) CommitAsync() *abcicli.ReqRes {
+	defer app.guard.exitBlock()
 	app.log.Debug("Trying to get CommitAsync lock")
 	app.checkTxMidLock.Lock()
-	app.commitLock.Lock() // this must come before the wgCommit.Wait()
+	app.lockCommit("commit") // this must come before the wgCommit.Wait()
 	defer app.commitLock.Unlock()
 	app.checkTxMidLock.Unlock()
 	app.wgCommit.Wait() // wait for all the submitted CheckTx/DeliverTx/Query finish
@@ -286,6 +435,7 @@ func (app *asyncLocalClient) InitChainAsync(req types.RequestInitChain) *abcicli
 }
 
 func (app *asyncLocalClient) BeginBlockAsync(req types.RequestBeginBlock) *abcicli.ReqRes {
+	app.guard.enterBlock() // released when the block is committed
 	app.rwLock.Lock()
 	defer app.rwLock.Unlock()
 	res := app.Application.BeginBlock(req)
@@ -298,10 +448,12 @@ func (app *asyncLocalClient) BeginBlockAsync(req types.RequestBeginBlock) *abcic
 func (app *asyncLocalClient) EndBlockAsync(req types.RequestEndBlock) *abcicli.ReqRes {
 	app.log.Debug("Trying to get EndBlockAsync lock")
 	app.checkTxMidLock.Lock()
-	app.commitLock.Lock() // this must come before the wgCommit.Wait()
+	app.lockCommit("end_block") // this must come before the wgCommit.Wait()
 	defer app.commitLock.Unlock()
 	app.checkTxMidLock.Unlock()
+	waitPreEndBlock := app.startPreEndBlock(req)
 	app.wgCommit.Wait() // wait for all the submitted CheckTx/DeliverTx/Query finish
+	waitPreEndBlock()
 	app.rwLock.Lock()
 	defer app.rwLock.Unlock()
 	// only checkTxLock is locked here
@@ -315,6 +467,21 @@ func (app *asyncLocalClient) EndBlockAsync(req types.RequestEndBlock) *abcicli.R
 	)
 }
 
+// startPreEndBlock runs PreEndBlock in the background if the app implements PreEndBlockApp,
+// the returned func waits for it to finish
+func (app *asyncLocalClient) startPreEndBlock(req types.RequestEndBlock) func() {
+	preparer, ok := app.Application.(PreEndBlockApp)
+	if !ok {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		preparer.PreEndBlock(req)
+	}()
+	return func() { <-done }
+}
+
 //-------------------------------------------------------
 
 func (app *asyncLocalClient) FlushSync() error {
@@ -348,6 +515,10 @@ func (app *asyncLocalClient) DeliverTxSync(req types.RequestDeliverTx) (*types.R
 }
 
 func (app *asyncLocalClient) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	if app.guard.isStopping() {
+		res := stoppingCheckTxResponse()
+		return &res, nil
+	}
 	app.rwLock.Lock()
 	defer app.rwLock.Unlock()
 	app.log.Debug("Start CheckTxSync")
@@ -363,9 +534,10 @@ func (app *asyncLocalClient) QuerySync(req types.RequestQuery) (*types.ResponseQ
 }
 
 func (app *asyncLocalClient) CommitSync() (*types.ResponseCommit, error) {
+	defer app.guard.exitBlock()
 	app.log.Debug("Trying to get CommitSync Lock")
 	app.checkTxMidLock.Lock()
-	app.commitLock.Lock() // this must come before the wgCommit.Wait()
+	app.lockCommit("commit") // this must come before the wgCommit.Wait()
 	defer app.commitLock.Unlock()
 	app.checkTxMidLock.Unlock()
 	app.wgCommit.Wait() // wait for all the submitted CheckTx/DeliverTx/Query finish
@@ -387,6 +559,7 @@ func (app *asyncLocalClient) InitChainSync(req types.RequestInitChain) (*types.R
 }
 
 func (app *asyncLocalClient) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
+	app.guard.enterBlock() // released when the block is committed
 	app.rwLock.Lock()
 	defer app.rwLock.Unlock()
 	res := app.Application.BeginBlock(req)
@@ -396,10 +569,12 @@ func (app *asyncLocalClient) BeginBlockSync(req types.RequestBeginBlock) (*types
 func (app *asyncLocalClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
 	app.log.Debug("Trying to get EndBlockSync lock")
 	app.checkTxMidLock.Lock()
-	app.commitLock.Lock() // this must come before the wgCommit.Wait()
+	app.lockCommit("end_block") // this must come before the wgCommit.Wait()
 	defer app.commitLock.Unlock()
 	app.checkTxMidLock.Unlock()
+	waitPreEndBlock := app.startPreEndBlock(req)
 	app.wgCommit.Wait() // wait for all the submitted CheckTx/DeliverTx/Query finish
+	waitPreEndBlock()
 	app.rwLock.Lock()
 	defer app.rwLock.Unlock()
 	app.log.Debug("Start EndBlockSync")
@@ -428,6 +603,13 @@ func (app *asyncLocalClient) WriteRecoveryChunk(hash types.SHA256Sum, chunk *typ
 
 //-------------------------------------------------------
 
+// lockCommit locks commitLock and records how long the caller waited for it
+func (app *asyncLocalClient) lockCommit(caller string) {
+	start := time.Now()
+	app.commitLock.Lock()
+	app.metrics.CommitLockWait.With("caller", caller).Observe(time.Since(start).Seconds())
+}
+
 func (app *asyncLocalClient) callback(req *types.Request, res *types.Response) *abcicli.ReqRes {
 	app.Callback(req, res)
 	return newLocalReqRes(req, res)
@@ -441,6 +623,13 @@ func newLocalReqRes(req *types.Request, res *types.Response) *abcicli.ReqRes {
 }
 
 func NewAsyncLocalClientCreator(app types.Application, log log.Logger) proxy.ClientCreator {
+	return NewParallelCheckTxClientCreator(app, log, 1, DefaultDrainTimeout)
+}
+
+// NewParallelCheckTxClientCreator runs the real CheckTx in up to checkTxWorkers goroutines
+// if the app implements ParallelCheckTxApp, the txs of the same account are still checked in order.
+// On stop, the clients wait up to drainTimeout for the queued txs to be responded.
+func NewParallelCheckTxClientCreator(app types.Application, log log.Logger, checkTxWorkers int, drainTimeout time.Duration) proxy.ClientCreator {
 	return &localAsyncClientCreator{
 		app:            app,
 		log:            log,
@@ -449,10 +638,51 @@ func NewAsyncLocalClientCreator(app types.Application, log log.Logger) proxy.Cli
 		commitLock:     new(sync.Mutex),
 		checkTxLowLock: new(sync.Mutex),
 		checkTxMidLock: new(sync.Mutex),
+		guard:          new(blockGuard),
+		checkTxWorkers: checkTxWorkers,
+		drainTimeout:   drainTimeout,
+		metrics:        NopMetrics(),
 	}
 }
 
+// EnableLockOrderCheck makes the clients panic with the stacks of all the goroutines when
+// their locks are not acquired in order. It is slow and only meant for debugging, it must be
+// called before creating the clients.
+func (l *localAsyncClientCreator) EnableLockOrderCheck() {
+	l.checkLockOrder = true
+}
+
+// EnableTxTracing makes the clients log the time spent in every stage by each CheckTx/DeliverTx,
+// it must be called before creating the clients.
+func (l *localAsyncClientCreator) EnableTxTracing() {
+	l.traceTxs = true
+}
+
+// EnablePrometheusMetrics registers the metrics of the clients to the default Prometheus registry,
+// it must be called before creating the clients.
+func (l *localAsyncClientCreator) EnablePrometheusMetrics(namespace string) {
+	l.metrics = PrometheusMetrics(namespace)
+	l.metrics.PoolSize.With("pool", "check_tx").Set(checkTxPoolSize)
+	l.metrics.PoolSize.With("pool", "deliver_tx").Set(deliverTxPoolSize)
+}
+
 func (l *localAsyncClientCreator) NewABCIClient() (abcicli.Client, error) {
-	return NewAsyncLocalClient(l.app, l.log, l.rwLock, l.wgCommit,
-		l.commitLock, l.checkTxLowLock, l.checkTxMidLock), nil
+	cli := NewAsyncLocalClient(l.app, l.log, l.rwLock, l.wgCommit,
+		l.commitLock, l.checkTxLowLock, l.checkTxMidLock)
+	cli.guard = l.guard
+	cli.checkTxWorkers = l.checkTxWorkers
+	cli.drainTimeout = l.drainTimeout
+	cli.metrics = l.metrics
+	cli.traceTxs = l.traceTxs
+	cli.orderedCallbacks = l.orderedCallbacks
+	if l.backPressure != "" {
+		cli.backPressure = l.backPressure
+	}
+	if l.checkLockOrder {
+		cli.enableLockOrderCheck()
+	}
+	l.clientsMtx.Lock()
+	l.clients = append(l.clients, cli)
+	l.clientsMtx.Unlock()
+	return cli, nil
 }
@@ -1,15 +1,23 @@
 package concurrent
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 var _ ApplicationCC = (*TimedApplication)(nil)
@@ -157,3 +165,348 @@ func TestReadAPI(t *testing.T) {
 	assert.True(time.Now().Before(expectStop), "Run too slow")
 	cli.Stop()
 }
+
+func TestGracefulStop(t *testing.T) {
+	assert := assert.New(t)
+	app := &TimedApplication{}
+	app.deliverTxSpan = time.Millisecond * 50
+	creator := NewAsyncLocalClientCreator(app, logger)
+	client, err := creator.NewABCIClient()
+	assert.NoError(err)
+	cli := client.(*asyncLocalClient)
+	cli.Start()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+	tx := make([]byte, 8)
+
+	cli.BeginBlockSync(types.RequestBeginBlock{})
+	stopped := make(chan struct{})
+	go func() {
+		creator.(GracefulStopper).GracefulStop()
+		close(stopped)
+	}()
+	time.Sleep(time.Millisecond * 5) //wait for go routine to start.
+
+	// the block in progress is not interrupted
+	cli.DeliverTxAsync(types.RequestDeliverTx{Tx: tx})
+	cli.EndBlockSync(types.RequestEndBlock{})
+	select {
+	case <-stopped:
+		t.Fatal("stopped before the block is committed")
+	default:
+	}
+	// no more CheckTx is accepted
+	res, err := cli.CheckTxSync(types.RequestCheckTx{Tx: tx})
+	assert.NoError(err)
+	assert.False(res.IsOK())
+	reqRes := cli.CheckTxAsync(types.RequestCheckTx{Tx: tx})
+	assert.False(reqRes.Response.GetCheckTx().IsOK())
+
+	cli.CommitSync()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("not stopped after the block is committed")
+	}
+}
+
+func TestDrainOnStop(t *testing.T) {
+	assert := assert.New(t)
+	app := &TimedApplication{}
+	app.deliverTxSpan = time.Millisecond * 20
+	cli := NewAsyncLocalClient(app, logger, new(sync.RWMutex),
+		new(sync.WaitGroup), new(sync.Mutex), new(sync.Mutex), new(sync.Mutex))
+	cli.Start()
+	var responded int32
+	cli.SetResponseCallback(func(*types.Request, *types.Response) { atomic.AddInt32(&responded, 1) })
+	tx := make([]byte, 8)
+
+	for i := 0; i < 5; i++ {
+		cli.DeliverTxAsync(types.RequestDeliverTx{Tx: tx})
+	}
+	// the queued txs are all responded before Stop returns
+	cli.Stop()
+	assert.EqualValues(5, atomic.LoadInt32(&responded))
+
+	// and the new ones are rejected
+	stoppingCode := uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeNodeStopping))
+	reqRes := cli.DeliverTxAsync(types.RequestDeliverTx{Tx: tx})
+	assert.Equal(stoppingCode, reqRes.Response.GetDeliverTx().Code)
+	reqRes = cli.CheckTxAsync(types.RequestCheckTx{Tx: tx})
+	assert.Equal(stoppingCode, reqRes.Response.GetCheckTx().Code)
+}
+
+func TestDeliverTxAfterPoolShutdown(t *testing.T) {
+	app := &TimedApplication{}
+	cli := NewAsyncLocalClient(app, logger, new(sync.RWMutex),
+		new(sync.WaitGroup), new(sync.Mutex), new(sync.Mutex), new(sync.Mutex))
+	cli.Start()
+	defer cli.Stop()
+	var responded int32
+	cli.SetResponseCallback(func(*types.Request, *types.Response) { atomic.AddInt32(&responded, 1) })
+
+	// the pool is shut down between queueing the tx and scheduling its PreDeliverTx
+	_ = cli.deliverTxPool.Shutdown(context.Background())
+	cli.DeliverTxAsync(types.RequestDeliverTx{Tx: make([]byte, 8)})
+	committed := make(chan struct{})
+	go func() {
+		cli.CommitAsync()
+		close(committed)
+	}()
+	select {
+	case <-committed:
+	case <-time.After(time.Second):
+		t.Fatal("the commit waits for a tx never delivered")
+	}
+	assert.EqualValues(t, 2, atomic.LoadInt32(&responded))
+}
+
+func TestDrainTimeout(t *testing.T) {
+	app := &TimedApplication{}
+	app.deliverTxSpan = time.Millisecond * 500
+	cli := NewAsyncLocalClient(app, logger, new(sync.RWMutex),
+		new(sync.WaitGroup), new(sync.Mutex), new(sync.Mutex), new(sync.Mutex))
+	cli.drainTimeout = time.Millisecond * 20
+	cli.Start()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+
+	cli.DeliverTxAsync(types.RequestDeliverTx{Tx: make([]byte, 8)})
+	start := time.Now()
+	cli.Stop()
+	assert.True(t, time.Since(start) < time.Millisecond*400, "Stop should not wait past the drain timeout")
+}
+
+func TestMetrics(t *testing.T) {
+	assert := assert.New(t)
+	app := &TimedApplication{}
+	app.preCheckTxSpan = time.Millisecond * 20
+	app.preDeliverTxSpan = time.Millisecond * 20
+
+	cli := NewAsyncLocalClient(app, logger, new(sync.RWMutex),
+		new(sync.WaitGroup), new(sync.Mutex), new(sync.Mutex), new(sync.Mutex))
+	preCheckTx := generic.NewHistogram("pre_check_tx_latency", 10)
+	preDeliverTx := generic.NewHistogram("pre_deliver_tx_latency", 10)
+	commitLockWait := generic.NewHistogram("commit_lock_wait", 10)
+	cli.metrics = NopMetrics()
+	cli.metrics.PreCheckTxLatency = preCheckTx
+	cli.metrics.PreDeliverTxLatency = preDeliverTx
+	cli.metrics.CommitLockWait = commitLockWait
+	cli.Start()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+
+	tx := make([]byte, 8)
+	cli.CheckTxAsync(types.RequestCheckTx{Tx: tx}).Wait()
+	cli.DeliverTxAsync(types.RequestDeliverTx{Tx: tx}).Wait()
+	cli.CommitAsync()
+	cli.Stop()
+
+	assert.True(preCheckTx.Quantile(0.5) >= app.preCheckTxSpan.Seconds(), "PreCheckTx latency is not recorded")
+	assert.True(preDeliverTx.Quantile(0.5) >= app.preDeliverTxSpan.Seconds(), "PreDeliverTx latency is not recorded")
+	assert.True(commitLockWait.Quantile(0.5) > 0, "commit lock wait is not recorded")
+}
+
+func TestTxTracing(t *testing.T) {
+	assert := assert.New(t)
+	app := &TimedApplication{}
+	app.preCheckTxSpan = time.Millisecond * 10
+	app.checkTxSpan = time.Millisecond * 10
+
+	var buf bytes.Buffer
+	cli := NewAsyncLocalClient(app, log.NewTMLogger(log.NewSyncWriter(&buf)), new(sync.RWMutex),
+		new(sync.WaitGroup), new(sync.Mutex), new(sync.Mutex), new(sync.Mutex))
+	cli.traceTxs = true
+	cli.Start()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+
+	cli.CheckTxAsync(types.RequestCheckTx{Tx: []byte("check")}).Wait()
+	cli.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte("deliver")}).Wait()
+	cli.CommitAsync()
+	cli.Stop()
+
+	logs := buf.String()
+	assert.Equal(2, strings.Count(logs, "Traced tx"))
+	assert.Contains(logs, "kind=check_tx")
+	assert.Contains(logs, "kind=deliver_tx")
+	assert.Contains(logs, "hash="+fmt.Sprintf("%X", tmhash.Sum([]byte("check"))))
+}
+
+func TestLockOrderCheck(t *testing.T) {
+	assert := assert.New(t)
+	app := &TimedApplication{}
+	client, _ := NewAsyncLocalClientCreator(app, logger).NewABCIClient()
+	cli := client.(*asyncLocalClient)
+	cli.enableLockOrderCheck()
+	cli.Start()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+
+	// the locks are acquired in order by the ABCI calls
+	tx := make([]byte, 8)
+	assert.NotPanics(func() {
+		cli.CheckTxAsync(types.RequestCheckTx{Tx: tx}).Wait()
+		cli.DeliverTxAsync(types.RequestDeliverTx{Tx: tx}).Wait()
+		cli.EndBlockAsync(types.RequestEndBlock{})
+		cli.CommitAsync()
+		cli.QueryAsync(types.RequestQuery{})
+	})
+
+	// a new ABCI path taking the app lock before the commit lock
+	cli.rwLock.Lock()
+	assert.Panics(func() { cli.commitLock.Lock() })
+	cli.rwLock.Unlock()
+	cli.checkTxMidLock.Lock()
+	assert.Panics(func() { cli.checkTxLowLock.Lock() })
+	cli.checkTxMidLock.Unlock()
+	cli.Stop()
+}
+
+func TestDeliverTxBackPressure(t *testing.T) {
+	assert := assert.New(t)
+	newClient := func(policy BackPressurePolicy, logger log.Logger) *asyncLocalClient {
+		app := &TimedApplication{}
+		app.deliverTxSpan = time.Millisecond
+		cli := NewAsyncLocalClient(app, logger, new(sync.RWMutex),
+			new(sync.WaitGroup), new(sync.Mutex), new(sync.Mutex), new(sync.Mutex))
+		cli.backPressure = policy
+		return cli
+	}
+	const txs = WorkerPoolQueue * 8
+
+	// every DeliverTx is executed, the CheckTx are rejected while the queue is full
+	cli := newClient(BackPressureReject, logger)
+	rejected := generic.NewCounter("rejected_check_txs")
+	cli.metrics.RejectedCheckTxs = rejected
+	for len(cli.deliverTxQueue) < cap(cli.deliverTxQueue) {
+		cli.deliverTxQueue <- WorkItem{}
+	}
+	assert.True(cli.shedCheckTx())
+	<-cli.deliverTxQueue
+	assert.False(cli.shedCheckTx())
+	for len(cli.deliverTxQueue) > 0 {
+		<-cli.deliverTxQueue
+	}
+	cli.Start()
+	var deliveredCount int32
+	cli.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if req.GetDeliverTx() != nil {
+			assert.True(res.GetDeliverTx().IsOK())
+			atomic.AddInt32(&deliveredCount, 1)
+		}
+	})
+	var rejectedRes int
+	for i := 0; i < txs; i++ {
+		cli.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte{byte(i)}})
+		if len(cli.deliverTxQueue) == cap(cli.deliverTxQueue) {
+			reqRes := cli.CheckTxAsync(types.RequestCheckTx{Tx: []byte{byte(i)}})
+			if reqRes.Response != nil && !reqRes.Response.GetCheckTx().IsOK() {
+				rejectedRes++
+			}
+		}
+	}
+	cli.Stop()
+	assert.EqualValues(txs, atomic.LoadInt32(&deliveredCount))
+	assert.EqualValues(rejectedRes+1, rejected.Value())
+
+	// the txs beyond the queue are buffered and all executed in order
+	var buf bytes.Buffer
+	cli = newClient(BackPressureGrow, log.NewTMLogger(log.NewSyncWriter(&buf)))
+	cli.Start()
+	var mtx sync.Mutex
+	var delivered []byte
+	cli.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		assert.True(res.GetDeliverTx().IsOK())
+		mtx.Lock()
+		delivered = append(delivered, req.GetDeliverTx().Tx...)
+		mtx.Unlock()
+	})
+	for i := 0; i < txs; i++ {
+		cli.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte{byte(i)}})
+	}
+	cli.Stop()
+	assert.Len(delivered, txs)
+	for i, tx := range delivered {
+		assert.EqualValues(i, tx)
+	}
+	assert.Contains(buf.String(), "DeliverTx queue above high watermark")
+	assert.Contains(buf.String(), "DeliverTx queue back below low watermark")
+}
+
+func TestParseBackPressurePolicy(t *testing.T) {
+	for _, policy := range []BackPressurePolicy{BackPressureBlock, BackPressureReject, BackPressureGrow} {
+		parsed, err := ParseBackPressurePolicy(string(policy))
+		assert.NoError(t, err)
+		assert.Equal(t, policy, parsed)
+	}
+	_, err := ParseBackPressurePolicy("drop")
+	assert.Error(t, err)
+}
+
+// preEndBlockApp records when PreEndBlock and EndBlock run
+type preEndBlockApp struct {
+	*TimedApplication
+	mtx              sync.Mutex
+	deliveringTx     int32
+	preparedDuringTx bool
+	prepared         bool
+	endedPrepared    bool
+}
+
+func (app *preEndBlockApp) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	atomic.StoreInt32(&app.deliveringTx, 1)
+	defer atomic.StoreInt32(&app.deliveringTx, 0)
+	return app.TimedApplication.DeliverTx(req)
+}
+
+func (app *preEndBlockApp) PreEndBlock(types.RequestEndBlock) {
+	time.Sleep(time.Millisecond * 10)
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.preparedDuringTx = atomic.LoadInt32(&app.deliveringTx) == 1
+	app.prepared = true
+}
+
+func (app *preEndBlockApp) EndBlock(types.RequestEndBlock) types.ResponseEndBlock {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.endedPrepared = app.prepared
+	return types.ResponseEndBlock{}
+}
+
+func TestPreEndBlock(t *testing.T) {
+	assert := assert.New(t)
+	app := &preEndBlockApp{TimedApplication: &TimedApplication{}}
+	app.deliverTxSpan = time.Millisecond * 50
+	cli := NewAsyncLocalClient(app, logger, new(sync.RWMutex),
+		new(sync.WaitGroup), new(sync.Mutex), new(sync.Mutex), new(sync.Mutex))
+	cli.Start()
+	defer cli.Stop()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+
+	cli.BeginBlockAsync(types.RequestBeginBlock{})
+	cli.DeliverTxAsync(types.RequestDeliverTx{Tx: make([]byte, 8)})
+	time.Sleep(time.Millisecond * 10) // the DeliverTx is running
+	cli.EndBlockAsync(types.RequestEndBlock{})
+
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	assert.True(app.preparedDuringTx, "PreEndBlock should run concurrently with DeliverTx")
+	assert.True(app.endedPrepared, "EndBlock should wait for PreEndBlock")
+}
+
+func TestQueueStats(t *testing.T) {
+	assert := assert.New(t)
+	app := &TimedApplication{}
+	creator := NewAsyncLocalClientCreator(app, logger)
+	mempool, _ := creator.NewABCIClient()
+	consensus, _ := creator.NewABCIClient()
+
+	// the deepest queue of the clients is reported
+	for i := 0; i < 3; i++ {
+		mempool.(*asyncLocalClient).checkTxQueue <- WorkItem{}
+	}
+	consensus.(*asyncLocalClient).checkTxQueue <- WorkItem{}
+	stats := creator.(QueueStatsReporter).QueueStats()
+	assert.Equal([]QueueStats{
+		{Queue: "check_tx", Depth: 3, Capacity: WorkerPoolQueue * 2},
+		{Queue: "deliver_tx", Depth: 0, Capacity: WorkerPoolQueue * 2},
+	}, stats)
+	assert.Equal(3/float64(WorkerPoolQueue*2), stats[0].Saturation())
+}
@@ -0,0 +1,135 @@
+package concurrent
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BackPressurePolicy decides what DeliverTxAsync does when deliverTxQueue is full
+type BackPressurePolicy string
+
+const (
+	// BackPressureBlock waits for the queue to have room, it is the default
+	BackPressureBlock BackPressurePolicy = "block"
+	// BackPressureReject rejects the new CheckTx while the queue is full, so that fewer txs get
+	// into the mempool. DeliverTx still waits for room, every tx of a block must be executed.
+	BackPressureReject BackPressurePolicy = "reject"
+	// BackPressureGrow keeps the txs beyond the queue in an unbounded buffer
+	BackPressureGrow BackPressurePolicy = "grow"
+)
+
+// ParseBackPressurePolicy returns the policy named s
+func ParseBackPressurePolicy(s string) (BackPressurePolicy, error) {
+	switch policy := BackPressurePolicy(s); policy {
+	case BackPressureBlock, BackPressureReject, BackPressureGrow:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown back-pressure policy %q, expect %s, %s or %s",
+			s, BackPressureBlock, BackPressureReject, BackPressureGrow)
+	}
+}
+
+// BackPressureSetter is implemented by the client creators whose DeliverTx queue is bounded.
+type BackPressureSetter interface {
+	SetDeliverTxBackPressure(policy BackPressurePolicy)
+}
+
+// queueFullCheckTxResponse rejects a CheckTx while the DeliverTx queue is full
+func queueFullCheckTxResponse() types.ResponseCheckTx {
+	result := sdk.ErrInternal("deliver tx queue is full, retry later").Result()
+	return types.ResponseCheckTx{
+		Code: uint32(result.Code),
+		Log:  result.Log,
+	}
+}
+
+// shedCheckTx returns true if a new CheckTx is rejected by BackPressureReject
+func (app *asyncLocalClient) shedCheckTx() bool {
+	if app.backPressure != BackPressureReject || app.deliverTxQueueDepth() < cap(app.deliverTxQueue) {
+		return false
+	}
+	app.metrics.RejectedCheckTxs.Add(1)
+	return true
+}
+
+// enqueueDeliverTx sends the item to deliverTxQueue according to the back-pressure policy.
+// The caller holds queueLock.
+func (app *asyncLocalClient) enqueueDeliverTx(item WorkItem) {
+	switch app.backPressure {
+	case BackPressureGrow:
+		app.overflowMtx.Lock()
+		if !app.pumping {
+			select {
+			case app.deliverTxQueue <- item:
+				app.overflowMtx.Unlock()
+				app.checkHighWatermark()
+				return
+			default:
+			}
+			app.pumping = true
+			app.wgPump.Add(1)
+			go app.pumpOverflow()
+		}
+		app.overflow = append(app.overflow, item) // behind the ones already waiting, to keep the order
+		app.overflowMtx.Unlock()
+	default:
+		app.deliverTxQueue <- item
+	}
+	app.checkHighWatermark()
+}
+
+// pumpOverflow moves the txs of the overflow buffer to deliverTxQueue in order, until it is empty
+func (app *asyncLocalClient) pumpOverflow() {
+	defer app.wgPump.Done()
+	for {
+		app.overflowMtx.Lock()
+		if len(app.overflow) == 0 {
+			app.overflow = nil
+			app.pumping = false
+			app.overflowMtx.Unlock()
+			return
+		}
+		item := app.overflow[0]
+		app.overflow = app.overflow[1:]
+		app.overflowMtx.Unlock()
+		app.deliverTxQueue <- item
+	}
+}
+
+// deliverTxQueueDepth counts the txs waiting in deliverTxQueue and in the overflow buffer
+func (app *asyncLocalClient) deliverTxQueueDepth() int {
+	app.overflowMtx.Lock()
+	defer app.overflowMtx.Unlock()
+	return len(app.deliverTxQueue) + len(app.overflow)
+}
+
+// checkHighWatermark logs once when the DeliverTx queue gets above three quarters of its capacity,
+// the app executing the txs slower than the node receives them may stall the consensus
+func (app *asyncLocalClient) checkHighWatermark() {
+	depth := app.deliverTxQueueDepth()
+	if depth >= cap(app.deliverTxQueue)*3/4 && atomic.CompareAndSwapInt32(&app.aboveWatermark, 0, 1) {
+		app.log.Error("DeliverTx queue above high watermark, the app executes the txs slowly",
+			"depth", depth, "capacity", cap(app.deliverTxQueue), "policy", app.backPressure)
+	}
+}
+
+// checkLowWatermark logs once when the DeliverTx queue gets back below half of its capacity
+func (app *asyncLocalClient) checkLowWatermark() {
+	if atomic.LoadInt32(&app.aboveWatermark) == 0 {
+		return
+	}
+	depth := app.deliverTxQueueDepth()
+	if depth < cap(app.deliverTxQueue)/2 && atomic.CompareAndSwapInt32(&app.aboveWatermark, 1, 0) {
+		app.log.Info("DeliverTx queue back below low watermark", "depth", depth)
+	}
+}
+
+// SetDeliverTxBackPressure sets what the clients do when their DeliverTx queue is full,
+// it must be called before creating the clients.
+func (l *localAsyncClientCreator) SetDeliverTxBackPressure(policy BackPressurePolicy) {
+	l.backPressure = policy
+}
@@ -0,0 +1,78 @@
+package concurrent
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GracefulStopper is implemented by the client creators that can stop the app
+// without interrupting the block in progress.
+type GracefulStopper interface {
+	GracefulStop()
+}
+
+// blockGuard is shared by the clients of one app, it is held from BeginBlock
+// until Commit returns so that a stop never lands in the middle of a block.
+type blockGuard struct {
+	stopping int32
+	mtx      sync.Mutex
+	inBlock  bool // only accessed by the consensus connection
+}
+
+func (g *blockGuard) enterBlock() {
+	g.mtx.Lock()
+	g.inBlock = true
+}
+
+func (g *blockGuard) exitBlock() {
+	if g.inBlock {
+		g.inBlock = false
+		g.mtx.Unlock()
+	}
+}
+
+func (g *blockGuard) isStopping() bool {
+	return atomic.LoadInt32(&g.stopping) == 1
+}
+
+// stop waits for the block in progress to be committed and holds back the next one
+func (g *blockGuard) stop() {
+	atomic.StoreInt32(&g.stopping, 1)
+	g.mtx.Lock()
+}
+
+// stoppingCheckTxResponse rejects a CheckTx which is not executed because the node is stopping
+func stoppingCheckTxResponse() types.ResponseCheckTx {
+	result := sdk.ErrNodeStopping("the tx is not checked as the node is stopping").Result()
+	return types.ResponseCheckTx{
+		Code: uint32(result.Code),
+		Log:  result.Log,
+	}
+}
+
+// stoppingDeliverTxResponse rejects a DeliverTx which is not executed because the node is stopping
+func stoppingDeliverTxResponse() types.ResponseDeliverTx {
+	result := sdk.ErrNodeStopping("the tx is not delivered as the node is stopping").Result()
+	return types.ResponseDeliverTx{
+		Code: uint32(result.Code),
+		Log:  result.Log,
+	}
+}
+
+// GracefulStop rejects the new CheckTx, lets the current block's DeliverTx/Commit
+// finish and saves the caches of the app. The next block will not start, so
+// the process is expected to exit afterwards.
+func (l *localAsyncClientCreator) GracefulStop() {
+	l.log.Info("Stopping gracefully, waiting for the block in progress")
+	l.guard.stop()
+	l.rwLock.Lock()
+	defer l.rwLock.Unlock()
+	if warmer, ok := l.app.(CacheWarmer); ok {
+		warmer.SaveHotKeys()
+	}
+	l.log.Info("Block committed and caches saved")
+}
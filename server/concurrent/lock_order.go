@@ -0,0 +1,164 @@
+package concurrent
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// LockOrderChecker is implemented by the client creators that can assert the order in which
+// their locks are acquired.
+type LockOrderChecker interface {
+	EnableLockOrderCheck()
+}
+
+// lockRank is the position of a lock in the hierarchy of asyncLocalClient, a goroutine
+// must acquire the locks in increasing rank
+type lockRank int
+
+const (
+	rankCheckTxLow lockRank = iota + 1
+	rankCheckTxMid
+	rankCommit
+	rankApp
+)
+
+func (r lockRank) String() string {
+	switch r {
+	case rankCheckTxLow:
+		return "checkTxLowLock"
+	case rankCheckTxMid:
+		return "checkTxMidLock"
+	case rankCommit:
+		return "commitLock"
+	case rankApp:
+		return "rwLock"
+	default:
+		return "unknown lock " + strconv.Itoa(int(r))
+	}
+}
+
+// rwLocker is the part of sync.RWMutex used by asyncLocalClient
+type rwLocker interface {
+	sync.Locker
+	RLock()
+	RUnlock()
+}
+
+// heldLocks records the ranks of the locks held by every goroutine
+var heldLocks = struct {
+	sync.Mutex
+	byGoroutine map[uint64][]lockRank
+}{byGoroutine: make(map[uint64][]lockRank)}
+
+// checkLockOrder panics with the stacks of all the goroutines if the current goroutine
+// holds a lock whose rank is not lower than rank
+func checkLockOrder(rank lockRank) {
+	gid := goroutineID()
+	heldLocks.Lock()
+	held := append([]lockRank(nil), heldLocks.byGoroutine[gid]...)
+	heldLocks.Unlock()
+	for _, h := range held {
+		if h >= rank {
+			buf := make([]byte, 1<<20)
+			n := runtime.Stack(buf, true)
+			panic(fmt.Sprintf("lock order violated: goroutine %d acquires %s while holding %v\n%s",
+				gid, rank, held, buf[:n]))
+		}
+	}
+}
+
+func lockAcquired(rank lockRank) {
+	gid := goroutineID()
+	heldLocks.Lock()
+	heldLocks.byGoroutine[gid] = append(heldLocks.byGoroutine[gid], rank)
+	heldLocks.Unlock()
+}
+
+// lockReleased forgets the lock, it is ignored if the lock is released by another goroutine
+func lockReleased(rank lockRank) {
+	gid := goroutineID()
+	heldLocks.Lock()
+	defer heldLocks.Unlock()
+	held := heldLocks.byGoroutine[gid]
+	for i := len(held) - 1; i >= 0; i-- {
+		if held[i] == rank {
+			held = append(held[:i], held[i+1:]...)
+			break
+		}
+	}
+	if len(held) == 0 {
+		delete(heldLocks.byGoroutine, gid)
+	} else {
+		heldLocks.byGoroutine[gid] = held
+	}
+}
+
+// goroutineID parses the id of the current goroutine from its stack, it is slow
+// and only meant for debugging
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	buf = buf[:bytes.IndexByte(buf, ' ')]
+	id, err := strconv.ParseUint(string(buf), 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("cannot parse goroutine id: %v", err))
+	}
+	return id
+}
+
+// orderedMutex asserts the order of acquisition of the wrapped mutex
+type orderedMutex struct {
+	mtx  sync.Locker
+	rank lockRank
+}
+
+func (m *orderedMutex) Lock() {
+	checkLockOrder(m.rank)
+	m.mtx.Lock()
+	lockAcquired(m.rank)
+}
+
+func (m *orderedMutex) Unlock() {
+	lockReleased(m.rank)
+	m.mtx.Unlock()
+}
+
+// orderedRWMutex asserts the order of acquisition of the wrapped rw mutex
+type orderedRWMutex struct {
+	mtx  rwLocker
+	rank lockRank
+}
+
+func (m *orderedRWMutex) Lock() {
+	checkLockOrder(m.rank)
+	m.mtx.Lock()
+	lockAcquired(m.rank)
+}
+
+func (m *orderedRWMutex) Unlock() {
+	lockReleased(m.rank)
+	m.mtx.Unlock()
+}
+
+func (m *orderedRWMutex) RLock() {
+	checkLockOrder(m.rank)
+	m.mtx.RLock()
+	lockAcquired(m.rank)
+}
+
+func (m *orderedRWMutex) RUnlock() {
+	lockReleased(m.rank)
+	m.mtx.RUnlock()
+}
+
+// enableLockOrderCheck makes the client panic when it acquires its locks out of order
+func (app *asyncLocalClient) enableLockOrderCheck() {
+	app.checkTxLowLock = &orderedMutex{mtx: app.checkTxLowLock, rank: rankCheckTxLow}
+	app.checkTxMidLock = &orderedMutex{mtx: app.checkTxMidLock, rank: rankCheckTxMid}
+	app.commitLock = &orderedMutex{mtx: app.commitLock, rank: rankCommit}
+	app.rwLock = &orderedRWMutex{mtx: app.rwLock, rank: rankApp}
+}
@@ -0,0 +1,99 @@
+package concurrent
+
+import (
+	metricsPkg "github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsSubsystem is a subsystem shared by all metrics exposed by this package.
+const MetricsSubsystem = "abci_async"
+
+// MetricsEnabler is implemented by the client creators that can expose their metrics
+// through the Prometheus endpoint of the node.
+type MetricsEnabler interface {
+	EnablePrometheusMetrics(namespace string)
+}
+
+// Metrics contains Metrics exposed by this package.
+type Metrics struct {
+	// Number of the CheckTx/DeliverTx waiting in the queues, labeled by queue
+	QueueDepth metricsPkg.Gauge
+	// Time spent in PreCheckTx in seconds
+	PreCheckTxLatency metricsPkg.Histogram
+	// Time spent in PreDeliverTx in seconds
+	PreDeliverTxLatency metricsPkg.Histogram
+	// Number of the workers running a task, labeled by pool
+	PoolBusyWorkers metricsPkg.Gauge
+	// Number of the workers of the pools, labeled by pool
+	PoolSize metricsPkg.Gauge
+	// Time spent waiting for commitLock in seconds, labeled by caller
+	CommitLockWait metricsPkg.Histogram
+	// Number of the CheckTx rejected as the DeliverTx queue is full
+	RejectedCheckTxs metricsPkg.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+func PrometheusMetrics(namespace string) *Metrics {
+	return &Metrics{
+		QueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "queue_depth",
+			Help:      "Number of the CheckTx/DeliverTx waiting in the queues.",
+		}, []string{"queue"}),
+		PreCheckTxLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pre_check_tx_latency",
+			Help:      "Time spent in PreCheckTx in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{}),
+		PreDeliverTxLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pre_deliver_tx_latency",
+			Help:      "Time spent in PreDeliverTx in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{}),
+		PoolBusyWorkers: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pool_busy_workers",
+			Help:      "Number of the workers of the pools running a task.",
+		}, []string{"pool"}),
+		PoolSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pool_size",
+			Help:      "Number of the workers of the pools.",
+		}, []string{"pool"}),
+		CommitLockWait: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "commit_lock_wait",
+			Help:      "Time spent waiting for the commit lock in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"caller"}),
+		RejectedCheckTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_check_txs",
+			Help:      "Number of the CheckTx rejected as the DeliverTx queue is full.",
+		}, []string{}),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		QueueDepth:          discard.NewGauge(),
+		PreCheckTxLatency:   discard.NewHistogram(),
+		PreDeliverTxLatency: discard.NewHistogram(),
+		PoolBusyWorkers:     discard.NewGauge(),
+		PoolSize:            discard.NewGauge(),
+		CommitLockWait:      discard.NewHistogram(),
+		RejectedCheckTxs:    discard.NewCounter(),
+	}
+}
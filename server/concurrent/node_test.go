@@ -0,0 +1,303 @@
+package concurrent
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+)
+
+// committedState is what a nodeTestApp keeps on disk, it outlives the app across restarts
+type committedState struct {
+	height int64
+	txs    map[string]bool
+	chain  []byte
+}
+
+func newCommittedState() *committedState {
+	return &committedState{txs: make(map[string]bool)}
+}
+
+// hash chains the txs in the order they were delivered in, so two runs only end with the same app
+// hash if they executed the same txs in the same order
+func (s *committedState) hash() []byte {
+	return s.chain
+}
+
+var (
+	_ ApplicationCC      = (*nodeTestApp)(nil)
+	_ ParallelCheckTxApp = (*nodeTestApp)(nil)
+)
+
+// nodeTestApp records the delivered txs, a tx delivered twice is counted as a duplicate
+type nodeTestApp struct {
+	abci.BaseApplication
+
+	mtx        sync.Mutex
+	disk       *committedState
+	pending    map[string]bool
+	delivered  []string
+	duplicates int
+}
+
+func newNodeTestApp(disk *committedState) *nodeTestApp {
+	return &nodeTestApp{disk: disk, pending: make(map[string]bool)}
+}
+
+func (app *nodeTestApp) Info(abci.RequestInfo) abci.ResponseInfo {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return abci.ResponseInfo{LastBlockHeight: app.disk.height, LastBlockAppHash: app.disk.hash()}
+}
+
+func (app *nodeTestApp) CheckTx(abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{}
+}
+
+func (app *nodeTestApp) ReCheckTx(abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{}
+}
+
+func (app *nodeTestApp) PreCheckTx(abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{}
+}
+
+func (app *nodeTestApp) CheckTxAccounts(req abci.RequestCheckTx) ([][]byte, bool) {
+	return [][]byte{req.Tx[:1]}, true
+}
+
+func (app *nodeTestApp) PreDeliverTx(abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	return abci.ResponseDeliverTx{}
+}
+
+func (app *nodeTestApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	tx := string(req.Tx)
+	if app.disk.txs[tx] || app.pending[tx] {
+		app.duplicates++
+		return abci.ResponseDeliverTx{}
+	}
+	app.pending[tx] = true
+	app.delivered = append(app.delivered, tx)
+	return abci.ResponseDeliverTx{}
+}
+
+func (app *nodeTestApp) Commit() abci.ResponseCommit {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	for _, tx := range app.delivered {
+		app.disk.txs[tx] = true
+		sum := sha256.Sum256(append(app.disk.chain, tx...))
+		app.disk.chain = sum[:]
+	}
+	app.pending = make(map[string]bool)
+	app.delivered = nil
+	app.disk.height++
+	return abci.ResponseCommit{Data: app.disk.hash()}
+}
+
+func (app *nodeTestApp) StartRecovery(*abci.Manifest) error {
+	return nil
+}
+
+func (app *nodeTestApp) WriteRecoveryChunk(abci.SHA256Sum, *abci.AppStateChunk, bool) error {
+	return nil
+}
+
+// committed returns whether the tx is committed, and the number of committed txs
+func (app *nodeTestApp) committed(tx []byte) (bool, int) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.disk.txs[string(tx)], len(app.disk.txs)
+}
+
+func (app *nodeTestApp) appHash() []byte {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.disk.hash()
+}
+
+// testNode runs a Tendermint node in-process, its databases are kept in memory across restarts
+type testNode struct {
+	t      *testing.T
+	config *cfg.Config
+	dbs    map[string]dbm.DB
+	disk   *committedState
+
+	app  *nodeTestApp
+	node *nm.Node
+}
+
+func newTestNode(t *testing.T, name string) *testNode {
+	config := cfg.ResetTestRoot(name)
+	config.RPC.ListenAddress = ""
+	config.P2P.ListenAddress = "tcp://127.0.0.1:0"
+	config.Consensus.TimeoutCommit = 10 * time.Millisecond
+	return &testNode{
+		t:      t,
+		config: config,
+		dbs:    make(map[string]dbm.DB),
+		disk:   newCommittedState(),
+	}
+}
+
+// start runs the node on a new app and client creator, the app is restored from the committed state
+func (n *testNode) start(newCreator func(app abci.Application) proxy.ClientCreator) {
+	n.app = newNodeTestApp(n.disk)
+	nodeKey, err := p2p.LoadOrGenNodeKey(n.config.NodeKeyFile())
+	require.NoError(n.t, err)
+	dbProvider := func(ctx *nm.DBContext) (dbm.DB, error) {
+		if db, ok := n.dbs[ctx.ID]; ok {
+			return db, nil
+		}
+		db := dbm.NewMemDB()
+		n.dbs[ctx.ID] = db
+		return db, nil
+	}
+	n.node, err = nm.NewNode(n.config,
+		privval.LoadFilePV(n.config.PrivValidatorKeyFile(), n.config.PrivValidatorStateFile()),
+		nodeKey,
+		newCreator(n.app),
+		nm.DefaultGenesisDocProviderFunc(n.config),
+		dbProvider,
+		nm.DefaultMetricsProvider(n.config.Instrumentation),
+		log.NewNopLogger(),
+	)
+	require.NoError(n.t, err)
+	require.NoError(n.t, n.node.Start())
+}
+
+// stop tears the node down along with its ABCI clients, GracefulStop is not called as it holds
+// back the next block until the process exits
+func (n *testNode) stop() {
+	require.NoError(n.t, n.node.Stop())
+	n.node.Wait()
+}
+
+func (n *testNode) cleanup() {
+	os.RemoveAll(n.config.RootDir)
+}
+
+// submit sends the txs to the mempool and waits for all their CheckTx to be responded
+func (n *testNode) submit(txs [][]byte) (responded int32) {
+	var wg sync.WaitGroup
+	for _, tx := range txs {
+		wg.Add(1)
+		err := n.node.Mempool().CheckTx(tx, func(*abci.Response) {
+			atomic.AddInt32(&responded, 1)
+			wg.Done()
+		})
+		if err != nil {
+			// rejected without calling the app, e.g. the tx is in the cache
+			wg.Done()
+		}
+	}
+	waitGroupTimeout(n.t, &wg, 10*time.Second, "CheckTx responses are lost")
+	return responded
+}
+
+// waitCommitted resubmits the txs lost by a restart until they are all committed
+func (n *testNode) waitCommitted(txs [][]byte, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for {
+		var missing [][]byte
+		count := 0
+		for _, tx := range txs {
+			var committed bool
+			if committed, count = n.app.committed(tx); !committed {
+				missing = append(missing, tx)
+			}
+		}
+		if len(missing) == 0 {
+			return
+		}
+		require.True(n.t, time.Now().Before(deadline), "%d txs committed out of %d", count, len(txs))
+		if n.node.Mempool().Size() == 0 {
+			n.submit(missing)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func waitGroupTimeout(t *testing.T, wg *sync.WaitGroup, timeout time.Duration, msg string) {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatal(msg)
+	}
+}
+
+func nodeTestTxs(from, to int) [][]byte {
+	txs := make([][]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		// the first byte is the account, so that the txs are spread over the parallel CheckTx workers
+		txs = append(txs, []byte(fmt.Sprintf("%c-tx-%d", 'a'+i%8, i)))
+	}
+	return txs
+}
+
+func TestNodeWithAsyncClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs a Tendermint node")
+	}
+	const loadSize = 400
+	txs := nodeTestTxs(0, 2*loadSize)
+	asyncCreator := func(app abci.Application) proxy.ClientCreator {
+		creator := NewParallelCheckTxClientCreator(app, log.NewNopLogger(), 4, DefaultDrainTimeout)
+		creator.(OrderedCallbacksEnabler).EnableOrderedCallbacks()
+		return creator
+	}
+
+	node := newTestNode(t, "async_client")
+	defer node.cleanup()
+	node.start(asyncCreator)
+
+	// every CheckTx is responded even if the node is stopped with txs in flight
+	var responded int32
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		responded = node.submit(txs[:loadSize])
+	}()
+	time.Sleep(200 * time.Millisecond)
+	node.stop()
+	waitGroupTimeout(t, &wg, 10*time.Second, "CheckTx responses are lost on stop")
+	require.True(t, responded > 0)
+
+	// the restarted node replays the blocks the app missed and takes the rest of the load
+	node.start(asyncCreator)
+	require.Equal(t, int32(loadSize), node.submit(txs[loadSize:]))
+	node.waitCommitted(txs, 30*time.Second)
+	node.stop()
+	require.Zero(t, node.app.duplicates)
+
+	// a serial client replaying the blocks of the node from scratch ends with the same app hash
+	asyncHash := node.app.appHash()
+	require.NotNil(t, asyncHash)
+	node.disk = newCommittedState()
+	node.start(proxy.NewLocalClientCreator)
+	node.waitCommitted(txs, 30*time.Second)
+	node.stop()
+	require.Zero(t, node.app.duplicates)
+	require.Equal(t, asyncHash, node.app.appHash())
+}
@@ -0,0 +1,51 @@
+package concurrent
+
+import (
+	"sync"
+)
+
+// OrderedCallbacksEnabler is implemented by the client creators that can guarantee the callbacks
+// of CheckTx fire in the order of the requests.
+type OrderedCallbacksEnabler interface {
+	EnableOrderedCallbacks()
+}
+
+// callbackOrderer calls the callbacks of a queue in the order the requests were dispatched,
+// the callbacks of the requests finished before a previous one are buffered until it finishes.
+type callbackOrderer struct {
+	mtx     sync.Mutex
+	next    uint64            // the sequence of the next callback to call
+	pending map[uint64]func() // the callbacks waiting for a previous one
+}
+
+func newCallbackOrderer() *callbackOrderer {
+	return &callbackOrderer{pending: make(map[uint64]func())}
+}
+
+// done calls cb once the callbacks of all the requests before seq are called, with the callbacks
+// of the next requests already finished. The callbacks are not called concurrently.
+func (o *callbackOrderer) done(seq uint64, cb func()) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if seq != o.next {
+		o.pending[seq] = cb
+		return
+	}
+	cb()
+	for o.next++; ; o.next++ {
+		cb, ok := o.pending[o.next]
+		if !ok {
+			return
+		}
+		delete(o.pending, o.next)
+		cb()
+	}
+}
+
+// EnableOrderedCallbacks makes the callbacks of CheckTx fire in the order of the requests even when
+// the txs are checked in parallel, as some versions of the Tendermint mempool rely on it. A tx
+// checked quickly is then responded after the slower ones received before it. It must be called
+// before creating the clients.
+func (l *localAsyncClientCreator) EnableOrderedCallbacks() {
+	l.orderedCallbacks = true
+}
@@ -0,0 +1,131 @@
+package concurrent
+
+import (
+	"sync"
+
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// accountLocks marks the accounts of the txs being checked. Only the dispatcher
+// locks, in the order the txs arrive, so the txs sharing an account are checked
+// in order and the locking cannot deadlock.
+type accountLocks struct {
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	locked map[string]bool
+}
+
+func newAccountLocks() *accountLocks {
+	l := &accountLocks{locked: make(map[string]bool)}
+	l.cond = sync.NewCond(&l.mtx)
+	return l
+}
+
+// lock waits until none of the accounts is locked and locks them all
+func (l *accountLocks) lock(accounts [][]byte) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	for l.isLocked(accounts) {
+		l.cond.Wait()
+	}
+	for _, acc := range accounts {
+		l.locked[string(acc)] = true
+	}
+}
+
+func (l *accountLocks) isLocked(accounts [][]byte) bool {
+	for _, acc := range accounts {
+		if l.locked[string(acc)] {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *accountLocks) unlock(accounts [][]byte) {
+	l.mtx.Lock()
+	for _, acc := range accounts {
+		delete(l.locked, string(acc))
+	}
+	l.mtx.Unlock()
+	l.cond.Broadcast()
+}
+
+// parallelCheckTxWorker replaces checkTxWorker when the app supports it. The txs touching
+// disjoint accounts are checked by up to checkTxWorkers goroutines holding the read lock,
+// the others are checked exclusively. The responses of different accounts may come back
+// out of order, unless orderedCallbacks is set.
+func (app *asyncLocalClient) parallelCheckTxWorker(checker ParallelCheckTxApp) {
+	defer app.wgWorkers.Done()
+	locks := newAccountLocks()
+	slots := make(chan struct{}, app.checkTxWorkers)
+	cbMtx := new(sync.Mutex) // the callbacks are not called concurrently
+	orderer := newCallbackOrderer()
+	var seq uint64
+	for i := range app.checkTxQueue {
+		app.metrics.QueueDepth.With("queue", "check_tx").Set(float64(len(app.checkTxQueue)))
+		i.trace.mark(stagePickup)
+		dispatch := func(cb func()) {
+			cbMtx.Lock()
+			defer cbMtx.Unlock()
+			cb()
+		}
+		if app.orderedCallbacks {
+			s := seq
+			dispatch = func(cb func()) { orderer.done(s, cb) }
+			seq++
+		}
+		i.mtx.Lock() // wait the PreCheckTx finish
+		i.mtx.Unlock()
+		var accounts [][]byte
+		parallel := false
+		if i.reqRes.Response == nil {
+			accounts, parallel = checker.CheckTxAccounts(types.RequestCheckTx{Tx: i.reqRes.Request.GetCheckTx().GetTx()})
+		}
+		if !parallel {
+			app.rwLock.Lock() // wait the running CheckTx finish
+			app.runCheckTx(i, dispatch)
+			app.rwLock.Unlock()
+			continue
+		}
+
+		locks.lock(accounts)
+		slots <- struct{}{}
+		// the read lock is taken before the next tx is dispatched, otherwise an exclusive
+		// tx waiting for the write lock would block the txs dispatched before it
+		app.rwLock.RLock()
+		go func(i WorkItem, accounts [][]byte, dispatch func(cb func())) {
+			defer func() { <-slots }()
+			defer app.rwLock.RUnlock()
+			app.runCheckTx(i, dispatch, func() { locks.unlock(accounts) })
+		}(i, accounts, dispatch)
+	}
+	// the queue is drained, wait for the txs still being checked
+	for n := 0; n < cap(slots); n++ {
+		slots <- struct{}{}
+	}
+}
+
+// runCheckTx runs the real CheckTx if the PreCheckTx passed and responds, the callbacks are
+// called through dispatch. The done funcs are called once the response is set.
+func (app *asyncLocalClient) runCheckTx(i WorkItem, dispatch func(cb func()), done ...func()) {
+	i.trace.mark(stageExecStart)
+	if i.reqRes.Response == nil {
+		tx := types.RequestCheckTx{Tx: i.reqRes.Request.GetCheckTx().GetTx()}
+		res := app.Application.CheckTx(tx)
+		i.reqRes.Response = types.ToResponseCheckTx(res) // Set response
+	}
+	i.trace.mark(stageExecEnd)
+	for _, f := range done {
+		f()
+	}
+	i.reqRes.Done()
+	app.wgCommit.Done() // enable Commit to start
+	dispatch(func() {
+		if cb := i.reqRes.GetCallback(); cb != nil {
+			cb(i.reqRes.Response)
+		}
+		app.Callback(i.reqRes.Request, i.reqRes.Response)
+		i.trace.emit(app.log)
+	})
+}
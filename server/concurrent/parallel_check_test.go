@@ -0,0 +1,151 @@
+package concurrent
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+var _ ParallelCheckTxApp = (*ParallelApplication)(nil)
+
+// ParallelApplication takes the first byte of a tx as its account, a tx starting
+// with 0 has to be checked exclusively
+type ParallelApplication struct {
+	TimedApplication
+
+	mtx     sync.Mutex
+	running int
+	maxRun  int
+	checked []byte
+}
+
+func (app *ParallelApplication) CheckTx(tx types.RequestCheckTx) types.ResponseCheckTx {
+	app.mtx.Lock()
+	app.running++
+	if app.running > app.maxRun {
+		app.maxRun = app.running
+	}
+	app.mtx.Unlock()
+	time.Sleep(app.checkTxSpan)
+	app.mtx.Lock()
+	app.running--
+	app.checked = append(app.checked, tx.Tx...)
+	app.mtx.Unlock()
+	return types.ResponseCheckTx{}
+}
+
+func (app *ParallelApplication) CheckTxAccounts(req types.RequestCheckTx) ([][]byte, bool) {
+	if req.Tx[0] == 0 {
+		return nil, false
+	}
+	return [][]byte{req.Tx[:1]}, true
+}
+
+func newParallelClient(app types.Application, workers int) *asyncLocalClient {
+	client, _ := NewParallelCheckTxClientCreator(app, logger, workers, DefaultDrainTimeout).NewABCIClient()
+	cli := client.(*asyncLocalClient)
+	cli.Start()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+	return cli
+}
+
+func TestParallelCheckTx(t *testing.T) {
+	assert := assert.New(t)
+	app := &ParallelApplication{}
+	app.checkTxSpan = time.Millisecond * 50
+	cli := newParallelClient(app, 4)
+	defer cli.Stop()
+
+	// 4 accounts run at the same time, if all are sequential, it needs 200ms
+	start := time.Now()
+	for i := 1; i <= 4; i++ {
+		cli.CheckTxAsync(types.RequestCheckTx{Tx: []byte{byte(i)}})
+	}
+	cli.CommitSync()
+	assert.True(time.Since(start) < time.Millisecond*150, "Run too slow")
+	assert.Equal(4, app.maxRun)
+}
+
+func TestParallelCheckTxSameAccount(t *testing.T) {
+	assert := assert.New(t)
+	app := &ParallelApplication{}
+	app.checkTxSpan = time.Millisecond * 10
+	cli := newParallelClient(app, 4)
+	defer cli.Stop()
+
+	// the txs of account 1 are in order, the exclusive tx runs alone
+	txs := [][]byte{{1, 1}, {2, 1}, {1, 2}, {0, 1}, {2, 2}, {1, 3}}
+	for _, tx := range txs {
+		cli.CheckTxAsync(types.RequestCheckTx{Tx: tx})
+	}
+	cli.CommitSync()
+
+	var ofAccount1 []byte
+	for i := 0; i < len(app.checked); i += 2 {
+		if app.checked[i] == 1 {
+			ofAccount1 = append(ofAccount1, app.checked[i+1])
+		}
+	}
+	assert.Equal([]byte{1, 2, 3}, ofAccount1)
+	assert.Equal(2, app.maxRun)
+}
+
+func TestParallelCheckTxUnsupported(t *testing.T) {
+	app := &TimedApplication{}
+	cli := newParallelClient(app, 4)
+	defer cli.Stop()
+	res := cli.CheckTxAsync(types.RequestCheckTx{Tx: []byte{1}})
+	cli.CommitSync()
+	assert.True(t, res.Response.GetCheckTx().IsOK())
+}
+
+// SlowFirstApplication checks the txs of account 1 slower than the others
+type SlowFirstApplication struct {
+	ParallelApplication
+}
+
+func (app *SlowFirstApplication) CheckTx(tx types.RequestCheckTx) types.ResponseCheckTx {
+	if tx.Tx[0] == 1 {
+		time.Sleep(app.checkTxSpan)
+	}
+	return types.ResponseCheckTx{}
+}
+
+func TestParallelCheckTxOrderedCallbacks(t *testing.T) {
+	for _, ordered := range []bool{false, true} {
+		app := &SlowFirstApplication{}
+		app.checkTxSpan = time.Millisecond * 50
+		creator := NewParallelCheckTxClientCreator(app, logger, 4, DefaultDrainTimeout)
+		if ordered {
+			creator.(OrderedCallbacksEnabler).EnableOrderedCallbacks()
+		}
+		client, _ := creator.NewABCIClient()
+		cli := client.(*asyncLocalClient)
+		cli.Start()
+		var mtx sync.Mutex
+		var responded []byte
+		cli.SetResponseCallback(func(req *types.Request, res *types.Response) {
+			mtx.Lock()
+			responded = append(responded, req.GetCheckTx().Tx[0])
+			mtx.Unlock()
+		})
+
+		// the txs of the other accounts are checked while the one of account 1 sleeps
+		txs := [][]byte{{1}, {2}, {3}, {0}, {4}}
+		for _, tx := range txs {
+			cli.CheckTxAsync(types.RequestCheckTx{Tx: tx})
+		}
+		cli.CommitSync()
+		cli.Stop()
+
+		if ordered {
+			assert.Equal(t, []byte{1, 2, 3, 0, 4}, responded)
+		} else {
+			assert.ElementsMatch(t, []byte{1, 2, 3, 0, 4}, responded)
+			assert.NotEqual(t, byte(1), responded[0], "the slow tx is not responded first")
+		}
+	}
+}
@@ -4,7 +4,9 @@
 package pool
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -12,10 +14,21 @@ import (
 // goroutines during some period of time.
 var ErrScheduleTimeout = fmt.Errorf("schedule error: timed out")
 
+// ErrPoolClosed returned by Pool to indicate that it is shut down and
+// does not accept tasks any more.
+var ErrPoolClosed = fmt.Errorf("schedule error: pool is closed")
+
 // Pool contains logic of goroutine reuse.
 type Pool struct {
 	sem  chan struct{}
 	work chan func()
+
+	ctx    context.Context // done once Shutdown is called
+	cancel context.CancelFunc
+
+	mtx     sync.RWMutex // held to send to work, exclusively to close it
+	closed  bool         // work is closed, guarded by mtx
+	workers sync.WaitGroup
 }
 
 // NewPool creates new goroutine pool with given size. It also creates a work
@@ -28,12 +41,16 @@ func NewPool(size, queue, spawn int) *Pool {
 	if spawn > size {
 		panic("spawn > workers")
 	}
+	ctx, cancel := context.WithCancel(context.Background())
 	p := &Pool{
-		sem:  make(chan struct{}, size),
-		work: make(chan func(), queue),
+		sem:    make(chan struct{}, size),
+		work:   make(chan func(), queue),
+		ctx:    ctx,
+		cancel: cancel,
 	}
 	for i := 0; i < spawn; i++ {
 		p.sem <- struct{}{}
+		p.workers.Add(1)
 		go p.worker(func() {})
 	}
 
@@ -41,30 +58,84 @@ func NewPool(size, queue, spawn int) *Pool {
 }
 
 // Schedule schedules task to be executed over pool's workers.
+// The task is dropped if the pool is shut down.
 func (p *Pool) Schedule(task func()) {
-	p.schedule(task, nil)
+	p.schedule(context.Background(), task, nil)
 }
 
 // ScheduleTimeout schedules task to be executed over pool's workers.
 // It returns ErrScheduleTimeout when no free workers met during given timeout.
 func (p *Pool) ScheduleTimeout(timeout time.Duration, task func()) error {
-	return p.schedule(task, time.After(timeout))
+	return p.schedule(context.Background(), task, time.After(timeout))
 }
 
-func (p *Pool) schedule(task func(), timeout <-chan time.Time) error {
+// ScheduleCtx schedules task to be executed over pool's workers. It returns ctx.Err()
+// if ctx is done before a worker or a queue slot is free, and ErrPoolClosed after Shutdown.
+// Once scheduled, the task is always called so it can release its resources: the context
+// it gets is done if ctx is, or if the pool was shut down before the task started.
+func (p *Pool) ScheduleCtx(ctx context.Context, task func(context.Context)) error {
+	return p.schedule(ctx, func() {
+		if p.ctx.Err() != nil {
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+			task(canceled)
+			return
+		}
+		task(ctx)
+	}, nil)
+}
+
+func (p *Pool) schedule(ctx context.Context, task func(), timeout <-chan time.Time) error {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
 	select {
 	case <-timeout:
 		return ErrScheduleTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.ctx.Done():
+		return ErrPoolClosed
 	case p.work <- task:
 		return nil
 	case p.sem <- struct{}{}:
+		p.workers.Add(1)
 		go p.worker(task)
 		return nil
 	}
 }
 
+// Shutdown stops accepting tasks, cancels the contexts of the queued ones and waits for
+// the workers to run them all. It returns ctx.Err() if ctx is done before.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.cancel() // unblocks the callers waiting for a free worker
+	p.mtx.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.work)
+	}
+	p.mtx.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (p *Pool) worker(task func()) {
-	defer func() { <-p.sem }()
+	defer func() {
+		<-p.sem
+		p.workers.Done()
+	}()
 
 	task()
 
@@ -0,0 +1,49 @@
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleCtx(t *testing.T) {
+	p := NewPool(1, 1, 1)
+	block := make(chan struct{})
+	p.Schedule(func() { <-block })
+	// the queue slot is taken, the next one waits for a free worker
+	var canceled int32
+	require.NoError(t, p.ScheduleCtx(context.Background(), func(ctx context.Context) {
+		if ctx.Err() != nil {
+			atomic.AddInt32(&canceled, 1)
+		}
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := p.ScheduleCtx(ctx, func(context.Context) {})
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	// the queued task is still called, with a canceled context
+	shutdown := make(chan error)
+	go func() { shutdown <- p.Shutdown(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	close(block)
+	require.NoError(t, <-shutdown)
+	require.Equal(t, int32(1), atomic.LoadInt32(&canceled))
+
+	require.Equal(t, ErrPoolClosed, p.ScheduleCtx(context.Background(), func(context.Context) {}))
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	p := NewPool(1, 0, 1)
+	block := make(chan struct{})
+	defer close(block)
+	p.Schedule(func() { <-block })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, p.Shutdown(ctx))
+}
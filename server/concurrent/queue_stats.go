@@ -0,0 +1,41 @@
+package concurrent
+
+// QueueStats is the number of the requests waiting in a queue of the clients, the DeliverTx kept in the
+// overflow buffer with BackPressureGrow count in the depth so it may exceed the capacity
+type QueueStats struct {
+	Queue    string `json:"queue"`
+	Depth    int    `json:"depth"`
+	Capacity int    `json:"capacity"`
+}
+
+// Saturation is the ratio of the depth to the capacity of the queue
+func (s QueueStats) Saturation() float64 {
+	if s.Capacity == 0 {
+		return 0
+	}
+	return float64(s.Depth) / float64(s.Capacity)
+}
+
+// QueueStatsReporter is implemented by the client creators whose clients queue the CheckTx/DeliverTx.
+type QueueStatsReporter interface {
+	// QueueStats returns the stats of the check_tx and deliver_tx queues, of the client with the
+	// deepest one for each as every connection of the node has its own client
+	QueueStats() []QueueStats
+}
+
+// QueueStats implements QueueStatsReporter
+func (l *localAsyncClientCreator) QueueStats() []QueueStats {
+	checkTx := QueueStats{Queue: "check_tx"}
+	deliverTx := QueueStats{Queue: "deliver_tx"}
+	l.clientsMtx.Lock()
+	defer l.clientsMtx.Unlock()
+	for _, cli := range l.clients {
+		if depth := len(cli.checkTxQueue); depth >= checkTx.Depth {
+			checkTx.Depth, checkTx.Capacity = depth, cap(cli.checkTxQueue)
+		}
+		if depth := cli.deliverTxQueueDepth(); depth >= deliverTx.Depth {
+			deliverTx.Depth, deliverTx.Capacity = depth, cap(cli.deliverTxQueue)
+		}
+	}
+	return []QueueStats{checkTx, deliverTx}
+}
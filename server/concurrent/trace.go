@@ -0,0 +1,75 @@
+package concurrent
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// TxTracingEnabler is implemented by the client creators that can trace every CheckTx/DeliverTx.
+type TxTracingEnabler interface {
+	EnableTxTracing()
+}
+
+// the stages of a CheckTx/DeliverTx in asyncLocalClient
+const (
+	stageArrive    = iota // CheckTxAsync/DeliverTxAsync is called
+	stageEnqueue          // the locks are acquired, the tx is sent to the queue
+	stagePreStart         // a pool worker starts PreCheckTx/PreDeliverTx
+	stagePreEnd           // PreCheckTx/PreDeliverTx returns
+	stagePickup           // the queue worker takes the tx
+	stageExecStart        // the app lock is acquired, the real CheckTx/DeliverTx starts
+	stageExecEnd          // the real CheckTx/DeliverTx returns, or is skipped as PreCheckTx failed
+	stageCallback         // the callbacks are dispatched
+	numStages
+)
+
+// txTrace records when a tx goes through the stages. A nil txTrace records nothing,
+// so the callers do not check whether the tracing is enabled.
+type txTrace struct {
+	kind   string
+	tx     []byte
+	stages [numStages]time.Time
+}
+
+func newTxTrace(enabled bool, kind string, tx []byte) *txTrace {
+	if !enabled {
+		return nil
+	}
+	t := &txTrace{kind: kind, tx: tx}
+	t.mark(stageArrive)
+	return t
+}
+
+func (t *txTrace) mark(stage int) {
+	if t == nil {
+		return
+	}
+	t.stages[stage] = time.Now()
+}
+
+func (t *txTrace) between(from, to int) time.Duration {
+	if t.stages[from].IsZero() || t.stages[to].IsZero() {
+		return 0
+	}
+	return t.stages[to].Sub(t.stages[from])
+}
+
+// emit marks the callback stage and logs the time spent in every stage
+func (t *txTrace) emit(logger log.Logger) {
+	if t == nil {
+		return
+	}
+	t.mark(stageCallback)
+	logger.Info("Traced tx", "kind", t.kind, "hash", cmn.HexBytes(tmhash.Sum(t.tx)),
+		"lockWait", t.between(stageArrive, stageEnqueue),
+		"preWait", t.between(stageEnqueue, stagePreStart),
+		"pre", t.between(stagePreStart, stagePreEnd),
+		"pickupWait", t.between(stageEnqueue, stagePickup),
+		"execWait", t.between(stagePickup, stageExecStart),
+		"exec", t.between(stageExecStart, stageExecEnd),
+		"callback", t.between(stageExecEnd, stageCallback),
+		"total", t.between(stageArrive, stageCallback))
+}
@@ -1,16 +1,105 @@
 package config
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/server/concurrent"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// The keys of the options shared with the flags of the start command are the names of the flags,
+// so a flag given on the command line overrides the value of the config file.
+
 // BaseConfig defines the server's basic configuration
 type BaseConfig struct {
+	// AccountCacheSize is the number of accounts kept in memory between the blocks,
+	// zero uses the default of the app
+	AccountCacheSize int `mapstructure:"account_cache_size"`
+
+	// AccountCacheEviction is the policy choosing the accounts to evict: lru, arc or 2q
+	AccountCacheEviction string `mapstructure:"account_cache_eviction"`
+
+	// Pruning is the pruning strategy of the state: syncable, nothing or everything
+	Pruning string `mapstructure:"pruning"`
+
+	// WarmUpCache saves the hot keys of the account cache on stop and pre-loads them on start
+	WarmUpCache bool `mapstructure:"warm-up-cache"`
+
+	// MinimumFees are the fees a tx must pay for the node to accept it into its mempool, e.g. "100:BNB"
+	MinimumFees string `mapstructure:"minimum_fees"`
+}
+
+// ABCIConfig tunes the asynchronous ABCI client between tendermint and the app
+type ABCIConfig struct {
+	// CheckTxWorkers is the number of goroutines running CheckTx
+	CheckTxWorkers int `mapstructure:"checktx-workers"`
+
+	// DrainTimeout bounds the wait for the queued CheckTx/DeliverTx on stop
+	DrainTimeout time.Duration `mapstructure:"abci-drain-timeout"`
+
+	// DeliverTxBackPressure is what to do when the DeliverTx queue is full: block, reject or grow
+	DeliverTxBackPressure string `mapstructure:"abci-deliver-tx-backpressure"`
+
+	// OrderedCallbacks fires the CheckTx callbacks in the order of the requests
+	OrderedCallbacks bool `mapstructure:"abci-ordered-callbacks"`
+}
+
+// TelemetryConfig defines the metrics reported by the app
+type TelemetryConfig struct {
+	// Telemetry reports the metrics of the caches of the app through the Prometheus endpoint
+	// of tendermint, which is enabled by instrumentation.prometheus in config.toml
+	Telemetry bool `mapstructure:"telemetry"`
 }
 
 // Config defines the server's top level configuration
 type Config struct {
-	BaseConfig `mapstructure:",squash"`
+	BaseConfig      `mapstructure:",squash"`
+	ABCIConfig      `mapstructure:",squash"`
+	TelemetryConfig `mapstructure:",squash"`
 }
 
 func DefaultConfig() *Config {
-	return &Config{BaseConfig{}}
+	return &Config{
+		BaseConfig: BaseConfig{
+			AccountCacheEviction: "lru",
+			Pruning:              "syncable",
+		},
+		ABCIConfig: ABCIConfig{
+			CheckTxWorkers:        1,
+			DrainTimeout:          concurrent.DefaultDrainTimeout,
+			DeliverTxBackPressure: string(concurrent.BackPressureBlock),
+		},
+	}
+}
+
+// ValidateBasic checks the values of the config, so a node does not start with a wrong one
+func (c *Config) ValidateBasic() error {
+	if c.AccountCacheSize < 0 {
+		return fmt.Errorf("account_cache_size should not be negative, got %d", c.AccountCacheSize)
+	}
+	if _, err := auth.ParseCacheEvictionPolicy(c.AccountCacheEviction); err != nil {
+		return err
+	}
+	if _, err := sdk.ParseCoins(c.MinimumFees); err != nil {
+		return fmt.Errorf("invalid minimum_fees %q: %v", c.MinimumFees, err)
+	}
+	switch c.Pruning {
+	case "syncable", "nothing", "everything":
+	default:
+		return fmt.Errorf("unknown pruning strategy %q, expect syncable, nothing or everything", c.Pruning)
+	}
+	if c.CheckTxWorkers < 1 {
+		return fmt.Errorf("checktx-workers should be at least 1, got %d", c.CheckTxWorkers)
+	}
+	if c.DrainTimeout <= 0 {
+		return fmt.Errorf("abci-drain-timeout should be positive, got %s", c.DrainTimeout)
+	}
+	if _, err := concurrent.ParseBackPressurePolicy(c.DeliverTxBackPressure); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Storage for init gen-tx command input parameters
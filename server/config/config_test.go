@@ -0,0 +1,54 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	require.Nil(t, DefaultConfig().ValidateBasic())
+
+	for _, modify := range []func(*Config){
+		func(c *Config) { c.AccountCacheSize = -1 },
+		func(c *Config) { c.AccountCacheEviction = "fifo" },
+		func(c *Config) { c.Pruning = "some" },
+		func(c *Config) { c.MinimumFees = "100" },
+		func(c *Config) { c.CheckTxWorkers = 0 },
+		func(c *Config) { c.DrainTimeout = 0 },
+		func(c *Config) { c.DeliverTxBackPressure = "drop" },
+	} {
+		conf := DefaultConfig()
+		modify(conf)
+		require.NotNil(t, conf.ValidateBasic())
+	}
+}
+
+func TestWriteAndParseConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "app_config")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := DefaultConfig()
+	conf.AccountCacheSize = 5000
+	conf.Pruning = "nothing"
+	conf.MinimumFees = "100:BNB"
+	conf.CheckTxWorkers = 4
+	conf.DrainTimeout = 3 * time.Second
+	conf.Telemetry = true
+	path := filepath.Join(dir, "gaiad.toml")
+	WriteConfigFile(path, conf)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(path)
+	require.Nil(t, viper.ReadInConfig())
+	parsed, err := ParseAndValidateConfig()
+	require.Nil(t, err)
+	require.Equal(t, conf, parsed)
+}
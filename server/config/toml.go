@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"fmt"
 	"text/template"
 
 	"github.com/spf13/viper"
@@ -13,6 +14,42 @@ const defaultConfigTemplate = `# This is a TOML config file.
 
 ##### main base config options #####
 
+# Number of accounts kept in memory between the blocks, 0 uses the default of the app
+account_cache_size = {{ .BaseConfig.AccountCacheSize }}
+
+# Which accounts to evict when the account cache is full: "lru", "arc" or "2q".
+# "arc" and "2q" keep the frequently used accounts when all the accounts are scanned
+account_cache_eviction = "{{ .BaseConfig.AccountCacheEviction }}"
+
+# Pruning strategy of the state: "syncable", "nothing" or "everything"
+pruning = "{{ .BaseConfig.Pruning }}"
+
+# Save the hot keys of the account cache on stop and pre-load them on start
+warm-up-cache = {{ .BaseConfig.WarmUpCache }}
+
+# Minimum fees a tx must pay for this node to accept it into its mempool, e.g. "100:BNB".
+# The fees of a tx are the ones of its msgs set by governance, the empty string accepts any tx
+minimum_fees = "{{ .BaseConfig.MinimumFees }}"
+
+##### abci client options #####
+
+# Number of goroutines running CheckTx, the txs of different accounts are checked in parallel if more than one
+checktx-workers = {{ .ABCIConfig.CheckTxWorkers }}
+
+# How long to wait on stop for the queued CheckTx/DeliverTx to be responded
+abci-drain-timeout = "{{ .ABCIConfig.DrainTimeout }}"
+
+# What to do when the DeliverTx queue is full: "block", "reject" (DeliverTx still blocks, new CheckTx are rejected) or "grow"
+abci-deliver-tx-backpressure = "{{ .ABCIConfig.DeliverTxBackPressure }}"
+
+# Fire the CheckTx callbacks in the order of the requests even when the txs are checked in parallel
+abci-ordered-callbacks = {{ .ABCIConfig.OrderedCallbacks }}
+
+##### telemetry options #####
+
+# Report the metrics of the caches of the app, served by the Prometheus endpoint of tendermint
+# when instrumentation.prometheus is enabled in config.toml
+telemetry = {{ .TelemetryConfig.Telemetry }}
 `
 
 var configTemplate *template.Template
@@ -32,6 +69,18 @@ func ParseConfig() (*Config, error) {
 	return conf, err
 }
 
+// ParseAndValidateConfig retrieves the configuration like ParseConfig and validates it.
+func ParseAndValidateConfig() (*Config, error) {
+	conf, err := ParseConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := conf.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid app config: %v", err)
+	}
+	return conf, nil
+}
+
 // WriteConfigFile renders config using the template and writes it to configFilePath.
 func WriteConfigFile(configFilePath string, config *Config) {
 	var buffer bytes.Buffer
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/server/config"
+)
+
+// InitConfigCmd rewrites the config file of the app with the comments of all its options
+func InitConfigCmd(ctx *Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "init-config",
+		Short: "Rewrite the app config file " + appConfigFile + " with the comments of all the options",
+		Long: `Rewrite the app config file with the comments of all the options. The values set in the
+existing file are kept, so it documents the options added by an upgrade, and the defaults are
+written for the options not set.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := config.ParseAndValidateConfig()
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(ctx.Config.RootDir, appConfigFile)
+			config.WriteConfigFile(path, conf)
+			fmt.Println("Wrote", path)
+			return nil
+		},
+	}
+}
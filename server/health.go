@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+
+	"github.com/cosmos/cosmos-sdk/server/concurrent"
+)
+
+const (
+	// readyMaxQueueSaturation is the saturation of an ABCI queue above which the node is not ready
+	readyMaxQueueSaturation = 0.9
+	healthShutdownTimeout   = 5 * time.Second
+)
+
+// CacheReporter is implemented by applications reporting the sizes of their caches to the health endpoints
+type CacheReporter interface {
+	CacheSizes() map[string]int
+}
+
+// HeightReporter is implemented by applications reporting the height of their last committed state
+type HeightReporter interface {
+	LastBlockHeight() int64
+}
+
+// HealthStatus is the state of the node reported by the health endpoints
+type HealthStatus struct {
+	Ready           bool                    `json:"ready"`
+	Reasons         []string                `json:"reasons,omitempty"` // why the node is not ready
+	CatchingUp      bool                    `json:"catching_up"`
+	LastBlockHeight int64                   `json:"last_block_height"`
+	LastBlockTime   time.Time               `json:"last_block_time"`
+	AppHeight       int64                   `json:"app_height"`
+	Queues          []concurrent.QueueStats `json:"queues,omitempty"`
+	Caches          map[string]int          `json:"caches,omitempty"`
+}
+
+// healthChecker collects the HealthStatus of the node, the optional sources are nil if unavailable
+type healthChecker struct {
+	catchingUp  func() bool
+	lastBlock   func() (int64, time.Time)
+	app         HeightReporter
+	queues      concurrent.QueueStatsReporter
+	caches      CacheReporter
+	maxBlockAge time.Duration // the node is not ready if its last block is older, no limit if 0
+	now         func() time.Time
+}
+
+func newNodeHealthChecker(tmNode *node.Node, app interface{}, cliCreator interface{}, maxBlockAge time.Duration) *healthChecker {
+	checker := &healthChecker{
+		catchingUp: func() bool {
+			return tmNode.ConsensusReactor().FastSync()
+		},
+		lastBlock: func() (int64, time.Time) {
+			height := tmNode.BlockStore().Height()
+			if meta := tmNode.BlockStore().LoadBlockMeta(height); meta != nil {
+				return height, meta.Header.Time
+			}
+			return height, time.Time{}
+		},
+		maxBlockAge: maxBlockAge,
+		now:         time.Now,
+	}
+	checker.app, _ = app.(HeightReporter)
+	checker.queues, _ = cliCreator.(concurrent.QueueStatsReporter)
+	checker.caches, _ = app.(CacheReporter)
+	return checker
+}
+
+// Status returns the state of the node, it is ready to serve if it has caught up, its last block is recent
+// enough and its ABCI queues are not saturated
+func (c *healthChecker) Status() HealthStatus {
+	status := HealthStatus{CatchingUp: c.catchingUp()}
+	status.LastBlockHeight, status.LastBlockTime = c.lastBlock()
+	if c.app != nil {
+		status.AppHeight = c.app.LastBlockHeight()
+	}
+	if c.queues != nil {
+		status.Queues = c.queues.QueueStats()
+	}
+	if c.caches != nil {
+		status.Caches = c.caches.CacheSizes()
+	}
+
+	if status.CatchingUp {
+		status.Reasons = append(status.Reasons, "catching up")
+	}
+	if status.LastBlockHeight == 0 {
+		status.Reasons = append(status.Reasons, "no block committed")
+	} else if c.maxBlockAge > 0 {
+		if age := c.now().Sub(status.LastBlockTime); age > c.maxBlockAge {
+			status.Reasons = append(status.Reasons, fmt.Sprintf("last block is %s old", age.Round(time.Second)))
+		}
+	}
+	for _, queue := range status.Queues {
+		if queue.Saturation() > readyMaxQueueSaturation {
+			status.Reasons = append(status.Reasons, fmt.Sprintf("%s queue is saturated", queue.Queue))
+		}
+	}
+	status.Ready = len(status.Reasons) == 0
+	return status
+}
+
+// healthHandler responds the status of the node, with 200 as long as the node is up
+func (c *healthChecker) healthHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealthStatus(w, http.StatusOK, c.Status())
+}
+
+// readyHandler responds the status of the node, with 503 if it is not ready to serve
+func (c *healthChecker) readyHandler(w http.ResponseWriter, r *http.Request) {
+	status := c.Status()
+	code := http.StatusOK
+	if !status.Ready {
+		code = http.StatusServiceUnavailable
+	}
+	writeHealthStatus(w, code, status)
+}
+
+func writeHealthStatus(w http.ResponseWriter, code int, status HealthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+func (c *healthChecker) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", c.healthHandler)
+	mux.HandleFunc("/ready", c.readyHandler)
+	return mux
+}
+
+// startHealthServer serves /health and /ready on addr, suitable for the health checks of load balancers
+func startHealthServer(addr string, checker *healthChecker, logger log.Logger) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{Handler: checker.handler()}
+	go func() {
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Error("health server stopped", "err", err)
+		}
+	}()
+	logger.Info("Started health server", "addr", listener.Addr().String())
+	return srv, nil
+}
+
+func stopHealthServer(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthShutdownTimeout)
+	defer cancel()
+	_ = srv.Shutdown(ctx)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/server/concurrent"
+)
+
+type mockHealthApp struct{}
+
+func (mockHealthApp) LastBlockHeight() int64     { return 10 }
+func (mockHealthApp) CacheSizes() map[string]int { return map[string]int{"account": 5} }
+
+type mockQueues []concurrent.QueueStats
+
+func (q mockQueues) QueueStats() []concurrent.QueueStats { return q }
+
+func TestHealthChecker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	catchingUp := true
+	queues := mockQueues{{Queue: "deliver_tx", Depth: 1, Capacity: 10}}
+	checker := &healthChecker{
+		catchingUp:  func() bool { return catchingUp },
+		lastBlock:   func() (int64, time.Time) { return 10, now.Add(-time.Minute) },
+		app:         mockHealthApp{},
+		queues:      queues,
+		caches:      mockHealthApp{},
+		maxBlockAge: 2 * time.Minute,
+		now:         func() time.Time { return now },
+	}
+	handler := checker.handler()
+	get := func(path string) (int, HealthStatus) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		var status HealthStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		return rec.Code, status
+	}
+
+	// the node catching up is healthy but not ready
+	code, status := get("/health")
+	require.Equal(t, http.StatusOK, code)
+	require.False(t, status.Ready)
+	require.Equal(t, []string{"catching up"}, status.Reasons)
+	require.Equal(t, int64(10), status.LastBlockHeight)
+	require.Equal(t, int64(10), status.AppHeight)
+	require.Equal(t, map[string]int{"account": 5}, status.Caches)
+	require.Equal(t, []concurrent.QueueStats(queues), status.Queues)
+	code, _ = get("/ready")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	catchingUp = false
+	code, status = get("/ready")
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, status.Ready)
+
+	// a saturated queue or a stale block make the node not ready
+	queues[0].Depth = 10
+	checker.now = func() time.Time { return now.Add(time.Hour) }
+	code, status = get("/ready")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Len(t, status.Reasons, 2)
+}
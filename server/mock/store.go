@@ -70,6 +70,10 @@ func (ms multiStore) GetCommitStore(key sdk.StoreKey) sdk.CommitStore {
 	panic("not implemented")
 }
 
+func (ms multiStore) CacheMultiStoreWithVersion(ver int64) (sdk.CacheMultiStore, error) {
+	panic("not implemented")
+}
+
 func (ms multiStore) MountStoreWithDB(key sdk.StoreKey, typ sdk.StoreType, db dbm.DB) {
 	ms.kv[key] = kvStore{store: make(map[string][]byte)}
 }
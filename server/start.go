@@ -1,6 +1,9 @@
 package server
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -22,9 +25,25 @@ const (
 	flagAddress        = "address"
 	flagTraceStore     = "trace-store"
 	flagPruning        = "pruning"
+	flagArchive        = "archive"
+	flagWarmUpCache    = "warm-up-cache"
 	flagSequentialABCI = "seq-abci"
+	flagCheckTxWorkers = "checktx-workers"
+	flagDrainTimeout   = "abci-drain-timeout"
+	flagHaltHeight     = "halt-height"
+	flagTraceTxs       = "abci-trace-txs"
+	flagLockOrder      = "abci-check-lock-order"
+	flagHaltTime       = "halt-time"
+	flagBackPressure   = "abci-deliver-tx-backpressure"
+	flagOrderedCb      = "abci-ordered-callbacks"
+	flagHealthAddr     = "health-addr"
+	flagHealthMaxAge   = "health-max-block-age"
 )
 
+// nodeStopTimeout bounds the wait for tendermint to stop once the app has stopped gracefully,
+// the consensus routine may be blocked on the next block that will never start
+const nodeStopTimeout = 10 * time.Second
+
 var BlockStore *tmstore.BlockStore
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -51,7 +70,19 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
 	cmd.Flags().Bool(flagSequentialABCI, false, "Run abci app in sync mode")
+	cmd.Flags().Int(flagCheckTxWorkers, 1, "Number of goroutines running CheckTx, the txs of different accounts are checked in parallel if more than one")
+	cmd.Flags().Duration(flagDrainTimeout, concurrent.DefaultDrainTimeout, "How long to wait on stop for the queued CheckTx/DeliverTx to be responded")
+	cmd.Flags().Bool(flagTraceTxs, false, "Log the time spent by every CheckTx/DeliverTx in the locks, queues, PreCheckTx/PreDeliverTx and the app")
+	cmd.Flags().String(flagBackPressure, string(concurrent.BackPressureBlock), "What to do when the DeliverTx queue is full: block, reject (DeliverTx still blocks, new CheckTx are rejected) or grow")
+	cmd.Flags().Bool(flagOrderedCb, false, "Fire the CheckTx callbacks in the order of the requests even when the txs are checked in parallel")
+	cmd.Flags().Bool(flagLockOrder, false, "Debug only: panic with all the goroutine stacks when the locks of the ABCI client are acquired out of order")
 	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	cmd.Flags().Bool(flagWarmUpCache, false, "Save the hot keys of the account cache on stop and pre-load them on start")
+	cmd.Flags().Bool(flagArchive, false, "Run as an archive node: keep all historical state (overrides --pruning) and serve queries at any height")
+	cmd.Flags().Int64(flagHaltHeight, 0, "Stop the node cleanly after committing the block at this height, 0 disables it")
+	cmd.Flags().String(flagHealthAddr, "", "Serve the /health and /ready endpoints on this address, e.g. 0.0.0.0:26670, disabled if empty")
+	cmd.Flags().Duration(flagHealthMaxAge, 0, "The node is not ready if its last block is older than this, 0 disables the check")
+	cmd.Flags().Int64(flagHaltTime, 0, "Stop the node cleanly after committing the first block at or after this unix time in seconds, 0 disables it")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -125,8 +156,27 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	if isSequentialABCI {
 		cliCreator = proxy.NewLocalClientCreator(app)
 	} else {
-		cliCreator = concurrent.NewAsyncLocalClientCreator(app,
-			ctx.Logger.With("module", "abciCli"))
+		cliCreator = concurrent.NewParallelCheckTxClientCreator(app,
+			ctx.Logger.With("module", "abciCli"), viper.GetInt(flagCheckTxWorkers), viper.GetDuration(flagDrainTimeout))
+		if enabler, ok := cliCreator.(concurrent.MetricsEnabler); ok && cfg.Instrumentation.Prometheus {
+			enabler.EnablePrometheusMetrics(cfg.Instrumentation.Namespace)
+		}
+		if enabler, ok := cliCreator.(concurrent.TxTracingEnabler); ok && viper.GetBool(flagTraceTxs) {
+			enabler.EnableTxTracing()
+		}
+		if enabler, ok := cliCreator.(concurrent.OrderedCallbacksEnabler); ok && viper.GetBool(flagOrderedCb) {
+			enabler.EnableOrderedCallbacks()
+		}
+		if checker, ok := cliCreator.(concurrent.LockOrderChecker); ok && viper.GetBool(flagLockOrder) {
+			checker.EnableLockOrderCheck()
+		}
+		if setter, ok := cliCreator.(concurrent.BackPressureSetter); ok {
+			policy, err := concurrent.ParseBackPressurePolicy(viper.GetString(flagBackPressure))
+			if err != nil {
+				return nil, err
+			}
+			setter.SetDeliverTxBackPressure(policy)
+		}
 	}
 
 	// create & start tendermint node
@@ -151,9 +201,34 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		return nil, err
 	}
 
+	var healthSrv *http.Server
+	if addr := viper.GetString(flagHealthAddr); addr != "" {
+		checker := newNodeHealthChecker(tmNode, app, cliCreator, viper.GetDuration(flagHealthMaxAge))
+		healthSrv, err = startHealthServer(addr, checker, ctx.Logger.With("module", "health"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	TrapSignal(func() {
+		if healthSrv != nil {
+			stopHealthServer(healthSrv)
+		}
+		// finish the block in progress before tearing down the node, so the app is never killed during Commit
+		if stopper, ok := cliCreator.(concurrent.GracefulStopper); ok {
+			stopper.GracefulStop()
+		}
 		if tmNode.IsRunning() {
-			_ = tmNode.Stop()
+			stopped := make(chan struct{})
+			go func() {
+				_ = tmNode.Stop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(nodeStopTimeout):
+				ctx.Logger.Error("timed out stopping the node", "timeout", nodeStopTimeout)
+			}
 		}
 	})
 
@@ -23,6 +23,9 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 )
 
+// appConfigFile is the path of the config file of the app, relative to the home directory
+const appConfigFile = "config/gaiad.toml"
+
 // server context
 type Context struct {
 	Config *cfg.Config
@@ -99,21 +102,25 @@ func interceptLoadConfig() (conf *cfg.Config, err error) {
 
 	if conf == nil {
 		conf, err = tcmd.ParseConfig() // NOTE: ParseConfig() creates dir/files as necessary.
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	cosmosConfigFilePath := filepath.Join(rootDir, "config/gaiad.toml")
-	viper.SetConfigName("cosmos")
-	_ = viper.MergeInConfig()
-	var cosmosConf *config.Config
-	if _, err := os.Stat(cosmosConfigFilePath); os.IsNotExist(err) {
-		cosmosConf, _ := config.ParseConfig()
-		config.WriteConfigFile(cosmosConfigFilePath, cosmosConf)
+	// the options of the app are kept apart in gaiad.toml, written with their defaults if missing
+	appConfigFilePath := filepath.Join(rootDir, appConfigFile)
+	if _, err := os.Stat(appConfigFilePath); os.IsNotExist(err) {
+		appConf, _ := config.ParseConfig()
+		config.WriteConfigFile(appConfigFilePath, appConf)
 	}
 
-	if cosmosConf == nil {
-		_, err = config.ParseConfig()
+	viper.SetConfigName("gaiad")
+	if err = viper.MergeInConfig(); err != nil {
+		return nil, err
+	}
+	if _, err = config.ParseAndValidateConfig(); err != nil {
+		return nil, err
 	}
-
 	return
 }
 
@@ -145,6 +152,7 @@ func AddCommands(
 
 	rootCmd.AddCommand(
 		UnsafeResetAllCmd(ctx),
+		InitConfigCmd(ctx),
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
@@ -210,12 +218,13 @@ func TrapSignal(cleanupFunc func()) {
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		sig := <-sigs
+		// os.Exit does not run deferred calls, the cleanup must finish before it
 		switch sig {
 		case syscall.SIGTERM:
-			defer cleanupFunc()
+			cleanupFunc()
 			os.Exit(128 + int(syscall.SIGTERM))
 		case syscall.SIGINT:
-			defer cleanupFunc()
+			cleanupFunc()
 			os.Exit(128 + int(syscall.SIGINT))
 		}
 	}()
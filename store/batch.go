@@ -0,0 +1,45 @@
+package store
+
+// kvOp is a write buffered in a kvBatch, a nil value deletes the key.
+type kvOp struct {
+	key   []byte
+	value []byte
+}
+
+// kvBatch buffers the writes to a store, and hands them to apply in the order they were made on Write.
+type kvBatch struct {
+	ops   []kvOp
+	apply func(ops []kvOp)
+}
+
+var _ KVStoreBatch = (*kvBatch)(nil)
+
+func newKVBatch(apply func(ops []kvOp)) *kvBatch {
+	return &kvBatch{apply: apply}
+}
+
+// Implements KVStoreBatch.
+func (b *kvBatch) Set(key, value []byte) {
+	if key == nil {
+		panic("key is nil")
+	}
+	if value == nil {
+		panic("value is nil")
+	}
+	b.ops = append(b.ops, kvOp{key: key, value: value})
+}
+
+// Implements KVStoreBatch.
+func (b *kvBatch) Delete(key []byte) {
+	if key == nil {
+		panic("key is nil")
+	}
+	b.ops = append(b.ops, kvOp{key: key})
+}
+
+// Implements KVStoreBatch.
+func (b *kvBatch) Write() {
+	ops := b.ops
+	b.ops = nil
+	b.apply(ops)
+}
@@ -0,0 +1,131 @@
+package benchmark
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func testWorkload() Workload {
+	return GenerateWorkload(GenerateParams{
+		Seed:        1,
+		Stores:      []string{"acc", "stake"},
+		Blocks:      50,
+		OpsPerBlock: 100,
+		KeySpace:    1000,
+		ValueSize:   64,
+		DeleteRatio: 0.1,
+	})
+}
+
+func TestRun(t *testing.T) {
+	w := testWorkload()
+	var results []Result
+	for _, cfg := range DefaultConfigs() {
+		res, err := Run(w, cfg, dbm.NewMemDB())
+		require.NoError(t, err)
+		require.Equal(t, len(w.Blocks), res.Blocks)
+		require.Equal(t, int64(len(w.Blocks)), res.LastCommit.Version)
+		require.True(t, res.DiskWrites > 0)
+		require.True(t, res.WriteAmplification() > 1)
+		require.True(t, res.P50Commit <= res.P99Commit && res.P99Commit <= res.MaxCommit)
+		results = append(results, res)
+	}
+	// the configs do not change the state
+	for _, res := range results[1:] {
+		require.Equal(t, results[0].LastCommit, res.LastCommit)
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReport(&out, results))
+	require.Contains(t, out.String(), "syncable-large-cache")
+
+	_, err := Run(Workload{Blocks: []Block{{Height: 1, Ops: []Op{{Store: "unknown", Key: []byte("k")}}}}}, DefaultConfigs()[0], dbm.NewMemDB())
+	require.Error(t, err)
+}
+
+func TestSaveLoadWorkload(t *testing.T) {
+	w := testWorkload()
+	var buf bytes.Buffer
+	require.NoError(t, SaveWorkload(&buf, w))
+	loaded, err := LoadWorkload(&buf)
+	require.NoError(t, err)
+	require.Equal(t, w.LogicalBytes(), loaded.LogicalBytes())
+	require.Equal(t, len(w.Blocks), len(loaded.Blocks))
+}
+
+func TestParseTrace(t *testing.T) {
+	iavl, err := store.LoadIAVLStore(dbm.NewMemDB(), sdk.CommitID{}, sdk.PruneNothing)
+	require.NoError(t, err)
+	var trace bytes.Buffer
+	for height := int64(2); height >= 1; height-- {
+		traced := store.NewTraceKVStore(iavl.(sdk.KVStore), &trace, sdk.TraceContext{"blockHeight": height})
+		traced.Set([]byte("a"), []byte("1"))
+		traced.Get([]byte("a"))
+		traced.Delete([]byte("b"))
+	}
+
+	w, err := ParseTrace(&trace, "main")
+	require.NoError(t, err)
+	require.Equal(t, []string{"main"}, w.Stores)
+	require.Len(t, w.Blocks, 2)
+	require.Equal(t, int64(1), w.Blocks[0].Height)
+	require.Equal(t, []Op{
+		{Store: "main", Key: []byte("a"), Value: []byte("1")},
+		{Store: "main", Key: []byte("b"), Delete: true},
+	}, w.Blocks[0].Ops)
+
+	_, err = Run(w, DefaultConfigs()[0], dbm.NewMemDB())
+	require.NoError(t, err)
+}
+
+// TestReport prints the report of the workload saved at $STORE_BENCH_WORKLOAD
+func TestReport(t *testing.T) {
+	path := os.Getenv("STORE_BENCH_WORKLOAD")
+	if path == "" {
+		t.Skip("STORE_BENCH_WORKLOAD is not set")
+	}
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	w, err := LoadWorkload(f)
+	require.NoError(t, err)
+
+	var results []Result
+	for _, cfg := range DefaultConfigs() {
+		dir, err := os.MkdirTemp("", "store-bench")
+		require.NoError(t, err)
+		db, err := dbm.NewGoLevelDB("bench", dir)
+		require.NoError(t, err)
+		res, err := Run(w, cfg, db)
+		db.Close()
+		os.RemoveAll(dir)
+		require.NoError(t, err)
+		results = append(results, res)
+	}
+	var out bytes.Buffer
+	require.NoError(t, WriteReport(&out, results))
+	t.Log("\n" + out.String())
+}
+
+func BenchmarkCommit(b *testing.B) {
+	w := testWorkload()
+	for _, cfg := range DefaultConfigs() {
+		b.Run(cfg.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				res, err := Run(w, cfg, dbm.NewMemDB())
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportMetric(float64(res.TotalCommit.Nanoseconds())/float64(res.Blocks), "ns/commit")
+				b.ReportMetric(res.WriteAmplification(), "write-amp")
+			}
+		})
+	}
+}
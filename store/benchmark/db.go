@@ -0,0 +1,88 @@
+package benchmark
+
+import (
+	"sync/atomic"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// countingDB counts what reaches the underlying db
+type countingDB struct {
+	dbm.DB
+	writes int64
+	bytes  int64
+}
+
+var _ dbm.DB = (*countingDB)(nil)
+
+func newCountingDB(db dbm.DB) *countingDB {
+	return &countingDB{DB: db}
+}
+
+func (db *countingDB) count(writes, bytes int64) {
+	atomic.AddInt64(&db.writes, writes)
+	atomic.AddInt64(&db.bytes, bytes)
+}
+
+func (db *countingDB) Set(key, value []byte) {
+	db.count(1, int64(len(key)+len(value)))
+	db.DB.Set(key, value)
+}
+
+func (db *countingDB) SetSync(key, value []byte) {
+	db.count(1, int64(len(key)+len(value)))
+	db.DB.SetSync(key, value)
+}
+
+func (db *countingDB) Delete(key []byte) {
+	db.count(1, int64(len(key)))
+	db.DB.Delete(key)
+}
+
+func (db *countingDB) DeleteSync(key []byte) {
+	db.count(1, int64(len(key)))
+	db.DB.DeleteSync(key)
+}
+
+func (db *countingDB) NewBatch() dbm.Batch {
+	return &countingBatch{Batch: db.DB.NewBatch(), db: db}
+}
+
+func (db *countingDB) stats() (writes, bytes int64) {
+	return atomic.LoadInt64(&db.writes), atomic.LoadInt64(&db.bytes)
+}
+
+// countingBatch counts its ops when it is written
+type countingBatch struct {
+	dbm.Batch
+	db     *countingDB
+	writes int64
+	bytes  int64
+}
+
+func (b *countingBatch) Set(key, value []byte) {
+	b.writes++
+	b.bytes += int64(len(key) + len(value))
+	b.Batch.Set(key, value)
+}
+
+func (b *countingBatch) Delete(key []byte) {
+	b.writes++
+	b.bytes += int64(len(key))
+	b.Batch.Delete(key)
+}
+
+func (b *countingBatch) Write() {
+	b.flush()
+	b.Batch.Write()
+}
+
+func (b *countingBatch) WriteSync() {
+	b.flush()
+	b.Batch.WriteSync()
+}
+
+func (b *countingBatch) flush() {
+	b.db.count(b.writes, b.bytes)
+	b.writes, b.bytes = 0, 0
+}
@@ -0,0 +1,14 @@
+/*
+Package benchmark replays block workloads against the multistore to measure the
+cost of committing them with different configurations: the size of the IAVL node
+cache and the pruning strategy.
+
+A workload is either generated with GenerateWorkload, saved as JSON, or built from
+the output of a node started with --trace-store using ParseTrace. For every config
+Run reports the commit latency, the writes and bytes reaching the db, the write
+amplification against the bytes written by the workload, and the memory allocated.
+
+	go test ./store/benchmark -bench . -benchtime 1x
+	STORE_BENCH_WORKLOAD=workload.json go test ./store/benchmark -run TestReport -v
+*/
+package benchmark
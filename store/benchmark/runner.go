@@ -0,0 +1,143 @@
+package benchmark
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Config is a multistore configuration to benchmark
+type Config struct {
+	Name          string
+	IAVLCacheSize int
+	Pruning       sdk.PruningStrategy
+}
+
+// DefaultConfigs covers the knobs worth comparing when tuning the defaults
+func DefaultConfigs() []Config {
+	return []Config{
+		{Name: "syncable", IAVLCacheSize: 10000, Pruning: sdk.PruneSyncable},
+		{Name: "syncable-small-cache", IAVLCacheSize: 1000, Pruning: sdk.PruneSyncable},
+		{Name: "syncable-large-cache", IAVLCacheSize: 100000, Pruning: sdk.PruneSyncable},
+		{Name: "nothing", IAVLCacheSize: 10000, Pruning: sdk.PruneNothing},
+		{Name: "everything", IAVLCacheSize: 10000, Pruning: sdk.PruneEverything},
+	}
+}
+
+// Result of replaying a workload with a config
+type Result struct {
+	Config     Config
+	Blocks     int
+	LastCommit sdk.CommitID
+
+	TotalCommit time.Duration
+	P50Commit   time.Duration
+	P99Commit   time.Duration
+	MaxCommit   time.Duration
+
+	DiskWrites   int64 // sets and deletes reaching the db
+	DiskBytes    int64 // bytes of the keys and values reaching the db
+	LogicalBytes int64 // bytes of the keys and values written by the workload
+
+	TotalAlloc uint64 // bytes allocated during the replay
+	HeapInuse  uint64 // bytes in use at the end of the replay
+}
+
+// WriteAmplification is the ratio of the bytes written to the db to the bytes written by the workload
+func (r Result) WriteAmplification() float64 {
+	if r.LogicalBytes == 0 {
+		return 0
+	}
+	return float64(r.DiskBytes) / float64(r.LogicalBytes)
+}
+
+// Run replays the workload against a fresh multistore on db
+func Run(w Workload, cfg Config, db dbm.DB) (Result, error) {
+	cdb := newCountingDB(db)
+	rs := store.NewCommitMultiStore(cdb)
+	rs.SetIAVLCacheSize(cfg.IAVLCacheSize)
+	rs.SetPruning(cfg.Pruning)
+	keys := make(map[string]sdk.StoreKey, len(w.Stores))
+	for _, name := range w.Stores {
+		key := sdk.NewKVStoreKey(name)
+		keys[name] = key
+		rs.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	}
+	if err := rs.LoadLatestVersion(); err != nil {
+		return Result{}, err
+	}
+
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	res := Result{Config: cfg, Blocks: len(w.Blocks), LogicalBytes: w.LogicalBytes()}
+	latencies := make([]time.Duration, 0, len(w.Blocks))
+	for _, block := range w.Blocks {
+		cms := rs.CacheMultiStore()
+		for _, op := range block.Ops {
+			key, ok := keys[op.Store]
+			if !ok {
+				return Result{}, fmt.Errorf("unknown store %s at height %d", op.Store, block.Height)
+			}
+			if op.Delete {
+				cms.GetKVStore(key).Delete(op.Key)
+			} else {
+				cms.GetKVStore(key).Set(op.Key, op.Value)
+			}
+		}
+		cms.Write()
+
+		start := time.Now()
+		res.LastCommit = rs.Commit()
+		latencies = append(latencies, time.Since(start))
+	}
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	res.TotalAlloc = after.TotalAlloc - before.TotalAlloc
+	res.HeapInuse = after.HeapInuse
+	res.DiskWrites, res.DiskBytes = cdb.stats()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	for _, latency := range latencies {
+		res.TotalCommit += latency
+	}
+	res.P50Commit = percentile(latencies, 50)
+	res.P99Commit = percentile(latencies, 99)
+	res.MaxCommit = percentile(latencies, 100)
+	return res, nil
+}
+
+// percentile expects the durations to be sorted
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*p+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// WriteReport prints the results as a table
+func WriteReport(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "config\tcache\tpruning\tblocks\tcommit total\tp50\tp99\tmax\tdisk writes\tdisk bytes\twrite amp\talloc\theap")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%d\t%d\t%.2f\t%d\t%d\n",
+			r.Config.Name, r.Config.IAVLCacheSize, r.Config.Pruning, r.Blocks,
+			r.TotalCommit, r.P50Commit, r.P99Commit, r.MaxCommit,
+			r.DiskWrites, r.DiskBytes, r.WriteAmplification(), r.TotalAlloc, r.HeapInuse)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,149 @@
+package benchmark
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+// Op is a write or a delete in one of the stores
+type Op struct {
+	Store  string `json:"store"`
+	Key    []byte `json:"key"`
+	Value  []byte `json:"value,omitempty"`
+	Delete bool   `json:"delete,omitempty"`
+}
+
+// Block holds the ops of one block, they are committed together
+type Block struct {
+	Height int64 `json:"height"`
+	Ops    []Op  `json:"ops"`
+}
+
+// Workload is a sequence of blocks replayed against the multistore. Reads are left
+// out, they do not reach the disk before the commit.
+type Workload struct {
+	Stores []string `json:"stores"`
+	Blocks []Block  `json:"blocks"`
+}
+
+// LogicalBytes is the size of the keys and values written by the workload
+func (w Workload) LogicalBytes() int64 {
+	var n int64
+	for _, block := range w.Blocks {
+		for _, op := range block.Ops {
+			n += int64(len(op.Key) + len(op.Value))
+		}
+	}
+	return n
+}
+
+// LoadWorkload reads a workload saved as JSON
+func LoadWorkload(r io.Reader) (Workload, error) {
+	var w Workload
+	if err := json.NewDecoder(r).Decode(&w); err != nil {
+		return Workload{}, err
+	}
+	return w, nil
+}
+
+// SaveWorkload writes the workload as JSON
+func SaveWorkload(wr io.Writer, w Workload) error {
+	return json.NewEncoder(wr).Encode(w)
+}
+
+type traceOperation struct {
+	Operation string                 `json:"operation"`
+	Key       string                 `json:"key"`
+	Value     string                 `json:"value"`
+	Metadata  map[string]interface{} `json:"metadata"`
+}
+
+// ParseTrace builds a workload from the output of --trace-store. The trace does not
+// tell the stores apart, so all the ops are replayed against a single store.
+func ParseTrace(r io.Reader, store string) (Workload, error) {
+	w := Workload{Stores: []string{store}}
+	blocks := make(map[int64]*Block)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var traced traceOperation
+		if err := json.Unmarshal(scanner.Bytes(), &traced); err != nil {
+			return Workload{}, err
+		}
+		if traced.Operation != "write" && traced.Operation != "delete" {
+			continue
+		}
+		height, ok := traced.Metadata["blockHeight"].(float64)
+		if !ok {
+			return Workload{}, fmt.Errorf("missing block height in trace %s", scanner.Text())
+		}
+		key, err := base64.StdEncoding.DecodeString(traced.Key)
+		if err != nil {
+			return Workload{}, err
+		}
+		value, err := base64.StdEncoding.DecodeString(traced.Value)
+		if err != nil {
+			return Workload{}, err
+		}
+		block, ok := blocks[int64(height)]
+		if !ok {
+			block = &Block{Height: int64(height)}
+			blocks[int64(height)] = block
+		}
+		op := Op{Store: store, Key: key}
+		if traced.Operation == "delete" {
+			op.Delete = true
+		} else {
+			op.Value = value
+		}
+		block.Ops = append(block.Ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return Workload{}, err
+	}
+	for _, block := range blocks {
+		w.Blocks = append(w.Blocks, *block)
+	}
+	sort.Slice(w.Blocks, func(i, j int) bool { return w.Blocks[i].Height < w.Blocks[j].Height })
+	return w, nil
+}
+
+// GenerateParams describes a synthetic workload
+type GenerateParams struct {
+	Seed        int64
+	Stores      []string
+	Blocks      int
+	OpsPerBlock int
+	KeySpace    int     // number of distinct keys per store
+	ValueSize   int     // size of the values in bytes
+	DeleteRatio float64 // fraction of the ops that are deletes
+}
+
+// GenerateWorkload creates a deterministic random workload
+func GenerateWorkload(p GenerateParams) Workload {
+	rnd := rand.New(rand.NewSource(p.Seed))
+	w := Workload{Stores: p.Stores}
+	for height := 1; height <= p.Blocks; height++ {
+		block := Block{Height: int64(height), Ops: make([]Op, 0, p.OpsPerBlock)}
+		for i := 0; i < p.OpsPerBlock; i++ {
+			op := Op{
+				Store: p.Stores[rnd.Intn(len(p.Stores))],
+				Key:   []byte(fmt.Sprintf("key-%08d", rnd.Intn(p.KeySpace))),
+			}
+			if rnd.Float64() < p.DeleteRatio {
+				op.Delete = true
+			} else {
+				op.Value = make([]byte, p.ValueSize)
+				rnd.Read(op.Value)
+			}
+			block.Ops = append(block.Ops, op)
+		}
+		w.Blocks = append(w.Blocks, block)
+	}
+	return w
+}
@@ -25,6 +25,7 @@ type cacheKVStore struct {
 }
 
 var _ CacheKVStore = (*cacheKVStore)(nil)
+var _ BatchKVStore = (*cacheKVStore)(nil)
 
 // nolint
 func NewCacheKVStore(parent KVStore) *cacheKVStore {
@@ -80,6 +81,17 @@ func (ci *cacheKVStore) Delete(key []byte) {
 	ci.setCacheValue(key, nil, true, true)
 }
 
+// Implements BatchKVStore, the writes of the batch are cached under a single lock.
+func (ci *cacheKVStore) NewBatch() KVStoreBatch {
+	return newKVBatch(func(ops []kvOp) {
+		ci.mtx.Lock()
+		defer ci.mtx.Unlock()
+		for _, op := range ops {
+			ci.setCacheValue(op.key, op.value, op.value == nil, true)
+		}
+	})
+}
+
 // Implements KVStore
 func (ci *cacheKVStore) Prefix(prefix []byte) KVStore {
 	return prefixStore{ci, prefix}
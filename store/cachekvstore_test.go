@@ -60,6 +60,28 @@ func TestCacheKVStore(t *testing.T) {
 	require.Empty(t, mem.Get(keyFmt(1)), "Expected `key1` to be empty")
 }
 
+func TestCacheKVStoreBatch(t *testing.T) {
+	mem := dbStoreAdapter{dbm.NewMemDB()}
+	mem.Set(keyFmt(1), valFmt(1))
+	st := NewCacheKVStore(mem)
+
+	batch := st.NewBatch()
+	batch.Set(keyFmt(2), valFmt(2))
+	batch.Delete(keyFmt(1))
+	require.Equal(t, valFmt(1), st.Get(keyFmt(1)))
+	require.Empty(t, st.Get(keyFmt(2)))
+
+	// the batch is written to the cache, not to its parent
+	batch.Write()
+	require.Empty(t, st.Get(keyFmt(1)))
+	require.Equal(t, valFmt(2), st.Get(keyFmt(2)))
+	require.Equal(t, valFmt(1), mem.Get(keyFmt(1)))
+
+	st.Write()
+	require.Empty(t, mem.Get(keyFmt(1)))
+	require.Equal(t, valFmt(2), mem.Get(keyFmt(2)))
+}
+
 func TestCacheKVStoreNested(t *testing.T) {
 	mem := dbStoreAdapter{dbm.NewMemDB()}
 	st := NewCacheKVStore(mem)
@@ -19,6 +19,8 @@ type (
 	KVPair           = types.KVPair
 	Iterator         = types.Iterator
 	CacheKVStore     = types.CacheKVStore
+	BatchKVStore     = types.BatchKVStore
+	KVStoreBatch     = types.KVStoreBatch
 	CommitKVStore    = types.CommitKVStore
 	CacheWrapper     = types.CacheWrapper
 	CacheWrap        = types.CacheWrap
@@ -20,7 +20,12 @@ const (
 
 // load the iavl store
 func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningStrategy) (CommitStore, error) {
-	tree := iavl.NewMutableTree(db, defaultIAVLCacheSize)
+	return LoadIAVLStoreWithCacheSize(db, id, pruning, defaultIAVLCacheSize)
+}
+
+// load the iavl store caching at most cacheSize nodes
+func LoadIAVLStoreWithCacheSize(db dbm.DB, id CommitID, pruning sdk.PruningStrategy, cacheSize int) (CommitStore, error) {
+	tree := iavl.NewMutableTree(db, cacheSize)
 	_, err := tree.LoadVersion(id.Version)
 	if err != nil {
 		return nil, err
@@ -33,6 +38,7 @@ func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningStrategy) (CommitS
 //----------------------------------------
 
 var _ KVStore = (*IavlStore)(nil)
+var _ BatchKVStore = (*IavlStore)(nil)
 var _ CommitStore = (*IavlStore)(nil)
 var _ Queryable = (*IavlStore)(nil)
 
@@ -164,6 +170,21 @@ func (st *IavlStore) Delete(key []byte) {
 	st.Tree.Remove(key)
 }
 
+// Implements BatchKVStore. The IAVL tree has no write batching, the buffered writes are
+// applied one by one with Tree.Set and Tree.Remove, and nothing reaches the DB before
+// SaveVersion: the batch only spares the callers a separate path for the IAVL store.
+func (st *IavlStore) NewBatch() KVStoreBatch {
+	return newKVBatch(func(ops []kvOp) {
+		for _, op := range ops {
+			if op.value == nil {
+				st.Tree.Remove(op.key)
+			} else {
+				st.Tree.Set(op.key, op.value)
+			}
+		}
+	})
+}
+
 // Implements KVStore
 func (st *IavlStore) Prefix(prefix []byte) KVStore {
 	return prefixStore{st, prefix}
@@ -440,3 +461,74 @@ func cp(bz []byte) (ret []byte) {
 	copy(ret, bz)
 	return ret
 }
+
+//----------------------------------------
+
+var _ KVStore = (*immutableIavlStore)(nil)
+
+// immutableIavlStore is a read-only view over a historical version of an
+// IavlStore. It is used to serve queries against past heights.
+type immutableIavlStore struct {
+	tree *iavl.ImmutableTree
+}
+
+// GetImmutableVersion returns a read-only KVStore over the given version of
+// the tree. The version must not have been pruned.
+func (st *IavlStore) GetImmutableVersion(version int64) (KVStore, error) {
+	tree, err := st.Tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return immutableIavlStore{tree}, nil
+}
+
+// Implements Store.
+func (st immutableIavlStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st immutableIavlStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st immutableIavlStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Set(key, value []byte) {
+	panic("cannot write to a historical iavl store")
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Get(key []byte) (value []byte) {
+	_, v := st.tree.Get(key)
+	return v
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Has(key []byte) (exists bool) {
+	return st.tree.Has(key)
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Delete(key []byte) {
+	panic("cannot delete from a historical iavl store")
+}
+
+// Implements KVStore
+func (st immutableIavlStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Iterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) ReverseIterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
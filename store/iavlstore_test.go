@@ -69,6 +69,28 @@ func TestIAVLStoreGetSetHasDelete(t *testing.T) {
 	require.False(t, exists)
 }
 
+func TestIAVLStoreBatch(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, _ := newTree(t, db)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+
+	batch := iavlStore.NewBatch()
+	batch.Set([]byte("hello"), []byte("notgoodbye"))
+	batch.Delete([]byte("aloha"))
+	batch.Set([]byte("ciao"), []byte("arrivederci"))
+	require.Panics(t, func() { batch.Set([]byte("nil"), nil) })
+
+	// nothing is written before the batch is
+	require.EqualValues(t, treeData["hello"], iavlStore.Get([]byte("hello")))
+	require.True(t, iavlStore.Has([]byte("aloha")))
+	require.False(t, iavlStore.Has([]byte("ciao")))
+
+	batch.Write()
+	require.EqualValues(t, "notgoodbye", iavlStore.Get([]byte("hello")))
+	require.False(t, iavlStore.Has([]byte("aloha")))
+	require.EqualValues(t, "arrivederci", iavlStore.Get([]byte("ciao")))
+}
+
 func TestIAVLIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
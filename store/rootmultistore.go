@@ -22,12 +22,13 @@ const (
 // cacheMultiStore which is for cache-wrapping other MultiStores. It implements
 // the CommitMultiStore interface.
 type rootMultiStore struct {
-	db           dbm.DB
-	lastCommitID CommitID
-	pruning      sdk.PruningStrategy
-	storesParams map[StoreKey]storeParams
-	stores       map[StoreKey]CommitStore
-	keysByName   map[string]StoreKey
+	db            dbm.DB
+	lastCommitID  CommitID
+	pruning       sdk.PruningStrategy
+	iavlCacheSize int
+	storesParams  map[StoreKey]storeParams
+	stores        map[StoreKey]CommitStore
+	keysByName    map[string]StoreKey
 
 	traceWriter  io.Writer
 	traceContext TraceContext
@@ -39,13 +40,20 @@ var _ Queryable = (*rootMultiStore)(nil)
 // nolint
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
-		db:           db,
-		storesParams: make(map[StoreKey]storeParams),
-		stores:       make(map[StoreKey]CommitStore),
-		keysByName:   make(map[string]StoreKey),
+		db:            db,
+		iavlCacheSize: defaultIAVLCacheSize,
+		storesParams:  make(map[StoreKey]storeParams),
+		stores:        make(map[StoreKey]CommitStore),
+		keysByName:    make(map[string]StoreKey),
 	}
 }
 
+// SetIAVLCacheSize sets the number of nodes cached by each IAVL store,
+// it only applies to the stores loaded afterwards.
+func (rs *rootMultiStore) SetIAVLCacheSize(size int) {
+	rs.iavlCacheSize = size
+}
+
 // Implements CommitMultiStore
 func (rs *rootMultiStore) SetPruning(pruning sdk.PruningStrategy) {
 	rs.pruning = pruning
@@ -242,6 +250,40 @@ func (rs *rootMultiStore) CacheMultiStore() CacheMultiStore {
 	return newCacheMultiStoreFromRMS(rs)
 }
 
+// CacheMultiStoreWithVersion implements CommitMultiStore. It cache-wraps a
+// read-only view of every IAVL substore at the given version, so the version
+// must still be held by the stores (see PruneNothing). Non-IAVL substores are
+// wrapped at their current state.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error) {
+	if version <= 0 || version > rs.lastCommitID.Version {
+		return nil, fmt.Errorf("version %d is out of range, latest version is %d", version, rs.lastCommitID.Version)
+	}
+
+	cms := cacheMultiStore{
+		db:           NewCacheKVStore(dbStoreAdapter{rs.db}),
+		stores:       make(map[StoreKey]CacheWrap, len(rs.stores)),
+		keysByName:   rs.keysByName,
+		traceWriter:  rs.traceWriter,
+		traceContext: rs.traceContext,
+	}
+	for key, store := range rs.stores {
+		var wrapper CacheWrapper = store
+		if iavlStore, ok := store.(*IavlStore); ok {
+			historical, err := iavlStore.GetImmutableVersion(version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load version %d of store %s: %v", version, key.Name(), err)
+			}
+			wrapper = historical
+		}
+		if cms.TracingEnabled() {
+			cms.stores[key] = wrapper.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
+		} else {
+			cms.stores[key] = wrapper.CacheWrap()
+		}
+	}
+	return cms, nil
+}
+
 // Implements MultiStore.
 func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 	return rs.stores[key]
@@ -353,7 +395,7 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		// TODO: id?
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
-		store, err = LoadIAVLStore(db, id, rs.pruning)
+		store, err = LoadIAVLStoreWithCacheSize(db, id, rs.pruning, rs.iavlCacheSize)
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
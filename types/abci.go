@@ -11,5 +11,8 @@ type BeginBlocker func(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeg
 // run code after the transactions in a block and return updates to the validator set
 type EndBlocker func(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock
 
+// prepare the EndBlocker on the state of the last block, concurrently with the transactions of the block
+type PreEndBlocker func(ctx Context, req abci.RequestEndBlock)
+
 // respond to p2p filtering queries from Tendermint
 type PeerFilter func(info string) abci.ResponseQuery
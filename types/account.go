@@ -23,5 +23,15 @@ type Account interface {
 
 	GetCoins() Coins
 	SetCoins(Coins) error
+
 	Clone() Account
 }
+
+// FlagsAccount is an Account carrying the flags set by its owner, e.g. to require a memo on
+// transfers. The account types which do not implement it can not be flagged.
+type FlagsAccount interface {
+	Account
+
+	GetFlags() uint64
+	SetFlags(uint64) error
+}
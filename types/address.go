@@ -64,6 +64,12 @@ func AccAddressFromBech32(address string) (addr AccAddress, err error) {
 	return AccAddress(bz), nil
 }
 
+// ModuleAddress derives the address of a pool owned by a module. The address is the hash of
+// the module name and the purpose of the pool, no private key exists for it.
+func ModuleAddress(module, purpose string) AccAddress {
+	return AccAddress(crypto.AddressHash([]byte(module + "/" + purpose)))
+}
+
 // Returns boolean for whether two AccAddresses are Equal
 func (aa AccAddress) Equals(aa2 AccAddress) bool {
 	if aa.Empty() && aa2.Empty() {
@@ -125,13 +131,7 @@ func (aa AccAddress) Bytes() []byte {
 
 // String implements the Stringer interface.
 func (aa AccAddress) String() string {
-	bech32PrefixAccAddr := GetConfig().GetBech32AccountAddrPrefix()
-	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixAccAddr, aa.Bytes())
-	if err != nil {
-		panic(err)
-	}
-
-	return bech32Addr
+	return bech32String(accAddrStringCache, GetConfig().GetBech32AccountAddrPrefix(), aa.Bytes())
 }
 
 // Format implements the fmt.Formatter interface.
@@ -242,13 +242,7 @@ func (va ValAddress) Bytes() []byte {
 
 // String implements the Stringer interface.
 func (va ValAddress) String() string {
-	bech32PrefixValAddr := GetConfig().GetBech32ValidatorAddrPrefix()
-	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixValAddr, va.Bytes())
-	if err != nil {
-		panic(err)
-	}
-
-	return bech32Addr
+	return bech32String(valAddrStringCache, GetConfig().GetBech32ValidatorAddrPrefix(), va.Bytes())
 }
 
 // Format implements the fmt.Formatter interface.
@@ -364,13 +358,7 @@ func (ca ConsAddress) Bytes() []byte {
 
 // String implements the Stringer interface.
 func (ca ConsAddress) String() string {
-	bech32PrefixConsAddr := GetConfig().GetBech32ConsensusAddrPrefix()
-	bech32Addr, err := bech32.ConvertAndEncode(bech32PrefixConsAddr, ca.Bytes())
-	if err != nil {
-		panic(err)
-	}
-
-	return bech32Addr
+	return bech32String(consAddrStringCache, GetConfig().GetBech32ConsensusAddrPrefix(), ca.Bytes())
 }
 
 // Format implements the fmt.Formatter interface.
@@ -0,0 +1,48 @@
+package types
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// addrStringCacheSize is the number of bech32 strings kept for each address type
+const addrStringCacheSize = 50000
+
+// String of the addresses is called constantly in logs, events and map keys, so their bech32
+// encodings are cached, keyed by the raw bytes of the address
+var (
+	accAddrStringCache  = newAddrStringCache()
+	valAddrStringCache  = newAddrStringCache()
+	consAddrStringCache = newAddrStringCache()
+)
+
+// the prefix is kept to stay right if the config changes, e.g. in tests
+type cachedAddrString struct {
+	prefix string
+	bech32 string
+}
+
+func newAddrStringCache() *lru.Cache {
+	cache, err := lru.New(addrStringCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return cache
+}
+
+// bech32String returns the bech32 encoding of addr with the given prefix, from the cache if found
+func bech32String(cache *lru.Cache, prefix string, addr []byte) string {
+	key := string(addr)
+	if value, ok := cache.Get(key); ok {
+		if cached := value.(cachedAddrString); cached.prefix == prefix {
+			return cached.bech32
+		}
+	}
+
+	bech32Addr, err := bech32.ConvertAndEncode(prefix, addr)
+	if err != nil {
+		panic(err)
+	}
+	cache.Add(key, cachedAddrString{prefix: prefix, bech32: bech32Addr})
+	return bech32Addr
+}
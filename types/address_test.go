@@ -178,3 +178,44 @@ func TestConsAddress(t *testing.T) {
 		require.NotNil(t, err)
 	}
 }
+
+func TestModuleAddress(t *testing.T) {
+	addr := types.ModuleAddress("gov", "deposit")
+	require.Equal(t, types.AddrLen, len(addr))
+	require.Equal(t, addr, types.ModuleAddress("gov", "deposit"))
+	require.NotEqual(t, addr, types.ModuleAddress("gov", "fee"))
+	require.NotEqual(t, addr, types.ModuleAddress("bridge", "deposit"))
+}
+
+func TestAddressStringCache(t *testing.T) {
+	var pub ed25519.PubKeyEd25519
+	rand.Read(pub[:])
+	acc := types.AccAddress(pub.Address())
+	val := types.ValAddress(pub.Address())
+
+	str := acc.String()
+	require.Equal(t, str, acc.String())
+	res, err := types.AccAddressFromBech32(str)
+	require.Nil(t, err)
+	require.Equal(t, acc, res)
+	// the same bytes are encoded with the prefix of each address type
+	require.NotEqual(t, str, val.String())
+
+	// the cached string follows the prefix
+	config := types.GetConfig()
+	accPrefix, accPubPrefix := config.GetBech32AccountAddrPrefix(), config.GetBech32AccountPubPrefix()
+	config.SetBech32PrefixForAccount("test", "testpub")
+	defer config.SetBech32PrefixForAccount(accPrefix, accPubPrefix)
+	require.NotEqual(t, str, acc.String())
+	require.Equal(t, "test1", acc.String()[:5])
+}
+
+func BenchmarkAccAddressString(b *testing.B) {
+	var pub ed25519.PubKeyEd25519
+	rand.Read(pub[:])
+	acc := types.AccAddress(pub.Address())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = acc.String()
+	}
+}
@@ -4,14 +4,22 @@ type AccountStoreCache interface {
 	GetAccount(addr AccAddress) Account
 	SetAccount(addr AccAddress, acc Account)
 	Delete(addr AccAddress)
-	ClearCache() // only used by state sync to clear genesis status of accounts
+	ClearCache() // used by state sync to clear genesis status of accounts, and when the state is rolled back
 }
 
+// AccountCache buffers the account changes of a block or a tx, like a CacheMultiStore does
+// for the other stores.
 type AccountCache interface {
 	AccountStoreCache
 
+	// CacheWrap returns a cache buffering the changes on top of this one, until Write is called
+	CacheWrap() AccountCache
+	// Deprecated: use CacheWrap
 	Cache() AccountCache
+	// Write flushes the buffered changes to the parent and empties the cache
 	Write()
+	// Discard drops the buffered changes, the parent is left untouched
+	Discard()
 }
 
 type DummyAccountCache struct {
@@ -30,9 +38,16 @@ func (d *DummyAccountCache) Delete(addr AccAddress) {
 func (d *DummyAccountCache) ClearCache() {
 }
 
-func (d *DummyAccountCache) Cache() AccountCache {
+func (d *DummyAccountCache) CacheWrap() AccountCache {
 	return d
 }
 
+func (d *DummyAccountCache) Cache() AccountCache {
+	return d.CacheWrap()
+}
+
 func (d *DummyAccountCache) Write() {
 }
+
+func (d *DummyAccountCache) Discard() {
+}
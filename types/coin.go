@@ -290,7 +290,7 @@ var (
 	reAmt  = `[[:digit:]]+`
 	reSpc  = `\:`
 	reDnm  = `[[:alnum:]]{2,8}(\.[[:alpha:]])?(-[0-9A-Z]{2,6})?`
-	reCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reAmt, reSpc, reDnm))
+	reCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(\S+)$`, reAmt, reSpc))
 )
 
 // ParseCoin parses a cli input for one coin type, returning errors if invalid.
@@ -304,6 +304,9 @@ func ParseCoin(coinStr string) (coin Coin, err error) {
 		return
 	}
 	denomStr, amountStr := matches[2], matches[1]
+	if err = ValidateDenom(denomStr); err != nil {
+		return
+	}
 
 	amount, err := strconv.ParseInt(amountStr, 10, 64)
 	if err != nil {
@@ -262,7 +262,7 @@ func (c Context) TransientStore(key StoreKey) KVStore {
 // written to the context when writeCache is called.
 func (c Context) CacheContext() (cc Context, writeCache func()) {
 	cms := c.MultiStore().CacheMultiStore()
-	accountCache := c.AccountCache().Cache()
+	accountCache := c.AccountCache().CacheWrap()
 
 	cc = c.WithMultiStore(cms).WithAccountCache(accountCache)
 	return cc, func() {
@@ -17,8 +17,19 @@ var (
 	// bnb prefix address:  bnb1v8vkkymvhe2sf7gd2092ujc6hweta38xadu2pj
 	// tbnb prefix address: tbnb1v8vkkymvhe2sf7gd2092ujc6hweta38xnc4wpr
 	PegAccount = AccAddress(crypto.AddressHash([]byte("BinanceChainPegAccount")))
+
+	// PegModuleAccount replaces PegAccount since ModuleAccountUpgrade
+	PegModuleAccount = ModuleAddress("bridge", "peg")
 )
 
+// GetPegAccount returns the account escrowing the tokens transferred cross chain
+func GetPegAccount() AccAddress {
+	if IsUpgrade(ModuleAccountUpgrade) {
+		return PegModuleAccount
+	}
+	return PegAccount
+}
+
 func GetPegInTag(symbol string, amount int64) Tag {
 	return MakeTag(fmt.Sprintf(pegInTagName, symbol), []byte(strconv.FormatInt(amount, 10)))
 }
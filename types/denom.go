@@ -0,0 +1,58 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DenomValidator checks whether a denomination is well formed
+type DenomValidator func(denom string) error
+
+// MaxDenomLength is the length limit applied to every denomination regardless of the registered rules
+const MaxDenomLength = 128
+
+// BEP2DenomValidator accepts the native symbols and the issued token symbols, e.g. "BNB" and "ABC-123".
+// Denominations can be 2 ~ 10 characters long (8 + .B suffix).
+// Extra token symbol tx hash suffix can be 2 ~ 6 characters long.
+var BEP2DenomValidator = NewRegexDenomValidator(reDnm)
+
+// denomValidators are registered at app init, a denomination is valid if any of them accepts it
+var denomValidators = []DenomValidator{BEP2DenomValidator}
+
+// NewRegexDenomValidator returns a validator that accepts the denominations fully matching the pattern
+func NewRegexDenomValidator(pattern string) DenomValidator {
+	re := regexp.MustCompile(fmt.Sprintf(`^(?:%s)$`, pattern))
+	return func(denom string) error {
+		if !re.MatchString(denom) {
+			return fmt.Errorf("denom %s does not match %s", denom, pattern)
+		}
+		return nil
+	}
+}
+
+// RegisterDenomValidator adds a rule to the registry, it should only be called during app init
+func RegisterDenomValidator(validator DenomValidator) {
+	denomValidators = append(denomValidators, validator)
+}
+
+// SetDenomValidators replaces all the rules in the registry, it should only be called during app init
+func SetDenomValidators(validators ...DenomValidator) {
+	denomValidators = validators
+}
+
+// ValidateDenom checks the denomination against the registered rules
+func ValidateDenom(denom string) error {
+	if len(denom) == 0 || len(denom) > MaxDenomLength {
+		return fmt.Errorf("invalid denom length %d", len(denom))
+	}
+	var err error
+	for _, validator := range denomValidators {
+		if err = validator(denom); err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no denom validator registered")
+	}
+	return fmt.Errorf("invalid denom %s: %v", denom, err)
+}
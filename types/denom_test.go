@@ -0,0 +1,38 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDenom(t *testing.T) {
+	defer SetDenomValidators(BEP2DenomValidator)
+	ibcDenom := "ibc/" + strings.Repeat("27394FB092D2ECCD", 4)
+
+	require.NoError(t, ValidateDenom("BNB"))
+	require.NoError(t, ValidateDenom("ABC-123"))
+	require.NoError(t, ValidateDenom("ABC.B-123"))
+	require.Error(t, ValidateDenom(""))
+	require.Error(t, ValidateDenom("A"))
+	require.Error(t, ValidateDenom("ABC-1234567"))
+	require.Error(t, ValidateDenom(ibcDenom))
+
+	RegisterDenomValidator(NewRegexDenomValidator(`ibc/[0-9A-F]{64}`))
+	require.NoError(t, ValidateDenom(ibcDenom))
+	require.NoError(t, ValidateDenom("ABC-123"))
+	require.Error(t, ValidateDenom("ibc/27394FB092D2ECCD"))
+	coins, err := ParseCoins("10:" + ibcDenom + ",5:ABC-123")
+	require.NoError(t, err)
+	require.Equal(t, Coins{{"ABC-123", 5}, {ibcDenom, 10}}, coins)
+
+	// forks can drop the BEP2 rules
+	SetDenomValidators(NewRegexDenomValidator(`[a-z][a-z0-9/]{2,127}`))
+	require.NoError(t, ValidateDenom("uatom"))
+	require.Error(t, ValidateDenom("ABC-123"))
+	require.Error(t, ValidateDenom(strings.Repeat("a", MaxDenomLength+1)))
+
+	SetDenomValidators()
+	require.Error(t, ValidateDenom("BNB"))
+}
@@ -1,13 +1,12 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
-
-	"github.com/cosmos/cosmos-sdk/codec"
 )
 
 // ABCICodeType - combined codetype / codespace
@@ -59,6 +58,9 @@ const (
 	CodeMsgNotSupported     CodeType = 14
 	CodeInvalidAccountFlags CodeType = 15
 	CodeInvalidTxMemo       CodeType = 16
+	CodeInvalidAccount      CodeType = 17
+	CodeTxExpired           CodeType = 18
+	CodeNodeStopping        CodeType = 19
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -109,6 +111,12 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "account flags is invalid"
 	case CodeInvalidTxMemo:
 		return "transaction memo is invalid"
+	case CodeInvalidAccount:
+		return "account encoding is invalid"
+	case CodeTxExpired:
+		return "transaction expired"
+	case CodeNodeStopping:
+		return "node is stopping"
 	default:
 		return unknownCodeMsg(code)
 	}
@@ -152,6 +160,9 @@ func ErrInsufficientCoins(msg string) Error {
 func ErrInvalidCoins(msg string) Error {
 	return newErrorWithRootCodespace(CodeInvalidCoins, msg)
 }
+func ErrInsufficientFee(msg string) Error {
+	return newErrorWithRootCodespace(CodeInsufficientFee, msg)
+}
 func ErrMemoTooLarge(msg string) Error {
 	return newErrorWithRootCodespace(CodeMemoTooLarge, msg)
 }
@@ -164,6 +175,15 @@ func ErrInvalidAccountFlags(msg string) Error {
 func ErrInvalidTxMemo(msg string) Error {
 	return newErrorWithRootCodespace(CodeInvalidTxMemo, msg)
 }
+func ErrInvalidAccount(msg string) Error {
+	return newErrorWithRootCodespace(CodeInvalidAccount, msg)
+}
+func ErrTxExpired(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxExpired, msg)
+}
+func ErrNodeStopping(msg string) Error {
+	return newErrorWithRootCodespace(CodeNodeStopping, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
@@ -218,6 +238,8 @@ type sdkError struct {
 	codespace CodespaceType
 	code      CodeType
 	cmnError
+
+	cause error
 }
 
 // Implements Error.
@@ -226,11 +248,26 @@ func (err *sdkError) WithDefaultCodespace(cs CodespaceType) Error {
 	if codespace == CodespaceUndefined {
 		codespace = cs
 	}
-	return &sdkError{
-		codespace: cs,
-		code:      err.code,
-		cmnError:  err.cmnError,
+	copied := *err
+	copied.codespace = cs
+	return &copied
+}
+
+// WrapError returns a copy of err wrapping cause, the chain of causes is listed in the ABCI log.
+// The errors not created by NewError are returned unchanged.
+func WrapError(err Error, cause error) Error {
+	sdkErr, ok := err.(*sdkError)
+	if !ok {
+		return err
 	}
+	copied := *sdkErr
+	copied.cause = cause
+	return &copied
+}
+
+// Cause returns the error wrapped by WrapError, if any
+func (err *sdkError) Cause() error {
+	return err.cause
 }
 
 // Implements ABCIError.
@@ -242,6 +279,14 @@ func (err *sdkError) TraceSDK(format string, args ...interface{}) Error {
 
 // Implements ABCIError.
 func (err *sdkError) Error() string {
+	if err.cause != nil {
+		return fmt.Sprintf(`ERROR:
+Codespace: %d
+Code: %d
+Message: %#v
+Cause: %#v
+`, err.codespace, err.code, err.cmnError.Error(), err.cause.Error())
+	}
 	return fmt.Sprintf(`ERROR:
 Codespace: %d
 Code: %d
@@ -271,15 +316,16 @@ func (err *sdkError) Code() CodeType {
 
 // Implements ABCIError.
 func (err *sdkError) ABCILog() string {
-	cdc := codec.New()
 	errMsg := err.cmnError.Error()
-	jsonErr := humanReadableError{
+	jsonErr := ABCIErrorLog{
 		Codespace: err.codespace,
 		Code:      err.code,
 		ABCICode:  err.ABCICode(),
+		Causes:    errorCauses(err.cause),
 		Message:   errMsg,
 	}
-	bz, er := cdc.MarshalJSON(jsonErr)
+	// amino does not support maps, the metadata is serialized by encoding/json
+	bz, er := json.Marshal(jsonErr)
 	if er != nil {
 		panic(er)
 	}
@@ -287,6 +333,24 @@ func (err *sdkError) ABCILog() string {
 	return stringifiedJSON
 }
 
+// errorCauses flattens the chain of causes, from the outermost to the root cause
+func errorCauses(cause error) []ErrorCause {
+	var causes []ErrorCause
+	for cause != nil {
+		errCause := ErrorCause{Error: cause.Error()}
+		if err, ok := cause.(Error); ok {
+			errCause = ErrorCause{Codespace: err.Codespace(), Code: err.Code(), Error: err.RawError()}
+		}
+		causes = append(causes, errCause)
+		if causer, ok := cause.(interface{ Cause() error }); ok {
+			cause = causer.Cause()
+		} else {
+			cause = nil
+		}
+	}
+	return causes
+}
+
 func (err *sdkError) Result() Result {
 	return Result{
 		Code: err.ABCICode(),
@@ -329,10 +393,46 @@ func mustGetMsgIndex(abciLog string) int {
 	return msgIdx + len("message\":\"")
 }
 
-// parses the error into an object-like struct for exporting
-type humanReadableError struct {
-	Codespace CodespaceType `json:"codespace"`
-	Code      CodeType      `json:"code"`
-	ABCICode  ABCICodeType  `json:"abci_code"`
-	Message   string        `json:"message"`
+// ABCIErrorLog is the ABCI log of an error, the message is kept as the last field so that
+// AppendMsgToErr can prepend to it. The module and the metadata are set by AnnotateABCILog.
+type ABCIErrorLog struct {
+	Codespace CodespaceType     `json:"codespace"`
+	Code      CodeType          `json:"code"`
+	ABCICode  ABCICodeType      `json:"abci_code"`
+	Module    string            `json:"module,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Causes    []ErrorCause      `json:"causes,omitempty"`
+	Message   string            `json:"message"`
+}
+
+// ErrorCause is an error wrapped by another, the codes are only set for the causes which are sdk errors
+type ErrorCause struct {
+	Codespace CodespaceType `json:"codespace,omitempty"`
+	Code      CodeType      `json:"code,omitempty"`
+	Error     string        `json:"error"`
+}
+
+// ParseABCILog parses the ABCI log of an error, it returns false if the log is not one
+func ParseABCILog(log string) (ABCIErrorLog, bool) {
+	var errLog ABCIErrorLog
+	if err := json.Unmarshal([]byte(log), &errLog); err != nil || errLog.ABCICode == ABCICodeOK {
+		return ABCIErrorLog{}, false
+	}
+	return errLog, true
+}
+
+// AnnotateABCILog sets the module raising an error and its metadata in the ABCI log of the error,
+// the logs which are not the ABCI log of an error are returned unchanged.
+func AnnotateABCILog(log string, module string, metadata map[string]string) string {
+	errLog, ok := ParseABCILog(log)
+	if !ok {
+		return log
+	}
+	errLog.Module = module
+	errLog.Metadata = metadata
+	bz, err := json.Marshal(errLog)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
 }
@@ -89,3 +89,50 @@ func TestAppendMsgToErr(t *testing.T) {
 			fmt.Sprintf("Should have formatted the error message of ABCI Log. tc #%d", i))
 	}
 }
+
+func TestErrorCauses(t *testing.T) {
+	root := fmt.Errorf("connection refused")
+	inner := WrapError(NewError(CodespaceType(3), CodeType(101), "duplicated sequence"), root)
+	err := WrapError(ErrInternal("failed to write package"), inner)
+
+	// the wrapping errors are copies
+	require.Nil(t, ErrInternal("failed to write package").(*sdkError).Cause())
+	require.Equal(t, inner, err.(*sdkError).Cause())
+	require.Equal(t, inner, err.WithDefaultCodespace(CodespaceType(2)).(*sdkError).Cause())
+
+	errLog, ok := ParseABCILog(err.ABCILog())
+	require.True(t, ok)
+	require.Equal(t, ABCIErrorLog{
+		Codespace: CodespaceRoot,
+		Code:      CodeInternal,
+		ABCICode:  err.ABCICode(),
+		Causes: []ErrorCause{
+			{Codespace: CodespaceType(3), Code: CodeType(101), Error: "duplicated sequence"},
+			{Error: "connection refused"},
+		},
+		Message: "failed to write package",
+	}, errLog)
+
+	resLog, ok := err.Result().ErrorLog()
+	require.True(t, ok)
+	require.Equal(t, errLog, resLog)
+	_, ok = Result{Log: "ok"}.ErrorLog()
+	require.False(t, ok)
+	_, ok = Result{Code: err.ABCICode(), Log: "not json"}.ErrorLog()
+	require.False(t, ok)
+
+	// the module and the metadata are set on the log
+	annotated := AnnotateABCILog(err.ABCILog(), "oracle", map[string]string{"msg_index": "0"})
+	errLog, ok = ParseABCILog(annotated)
+	require.True(t, ok)
+	require.Equal(t, "oracle", errLog.Module)
+	require.Equal(t, map[string]string{"msg_index": "0"}, errLog.Metadata)
+	require.Len(t, errLog.Causes, 2)
+	require.Equal(t, "not json", AnnotateABCILog("not json", "oracle", nil))
+
+	// the message stays the last field of the log
+	msg := AppendMsgToErr("something unexpected happened", annotated)
+	errLog, ok = ParseABCILog(msg)
+	require.True(t, ok)
+	require.Equal(t, "something unexpected happened; failed to write package", errLog.Message)
+}
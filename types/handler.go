@@ -30,3 +30,7 @@ type AnteHandler func(ctx Context, tx Tx,
 	runTxMode RunTxMode) (newCtx Context, result Result, abort bool)
 
 type PreChecker func(ctx Context, txBytes []byte, tx Tx) Result
+
+// ReCheckFilter decides in ReCheckTx whether a tx kept in the mempool may still be executed, before the
+// AnteHandler. The txs it rejects are evicted from the mempool.
+type ReCheckFilter func(ctx Context, tx Tx) Result
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+)
+
+// Rand returns a pseudo-random generator seeded with the hash of the current block, the height
+// and the salt, so every node draws the same numbers in the same order while executing a block.
+// Modules pass their own salt, e.g. their name, to draw numbers independent of each other.
+//
+// The numbers are NOT unpredictable: anyone knowing the block hash knows them, and the proposer
+// has some influence on the block hash. Use it for shuffling or sampling where fairness among
+// honest parties is enough, never for anything an attacker gains by predicting or biasing.
+//
+// The generator is not safe for concurrent use, and each call returns a new one starting over
+// from the same seed.
+func (c Context) Rand(salt string) *rand.Rand {
+	return rand.New(rand.NewSource(c.RandSeed(salt)))
+}
+
+// RandSeed returns the seed of the generator returned by Rand.
+func (c Context) RandSeed(salt string) int64 {
+	hasher := sha256.New()
+	hasher.Write(c.BlockHash())
+	var height [8]byte
+	binary.BigEndian.PutUint64(height[:], uint64(c.BlockHeight()))
+	hasher.Write(height[:])
+	hasher.Write([]byte(salt))
+	return int64(binary.BigEndian.Uint64(hasher.Sum(nil)[:8]))
+}
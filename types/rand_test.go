@@ -0,0 +1,26 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestContextRand(t *testing.T) {
+	ctx := types.NewContext(nil, abci.Header{Height: 10}, types.RunTxModeDeliver, log.NewNopLogger()).
+		WithBlockHash([]byte("block hash"))
+
+	// the same block and salt draw the same numbers
+	require.Equal(t, ctx.Rand("oracle").Perm(20), ctx.Rand("oracle").Perm(20))
+
+	// another salt, block hash or height draws other numbers
+	seed := ctx.RandSeed("oracle")
+	require.NotEqual(t, seed, ctx.RandSeed("stake"))
+	require.NotEqual(t, seed, ctx.WithBlockHash([]byte("other hash")).RandSeed("oracle"))
+	require.NotEqual(t, seed, ctx.WithBlockHeight(11).RandSeed("oracle"))
+}
@@ -35,3 +35,12 @@ func (res Result) GetEvents() []abci.Event {
 	}
 	return events
 }
+
+// ErrorLog parses the log of a failed result, it returns false if the result is OK or its log
+// is not the ABCI log of an error
+func (res Result) ErrorLog() (ABCIErrorLog, bool) {
+	if res.IsOK() {
+		return ABCIErrorLog{}, false
+	}
+	return ParseABCILog(res.Log)
+}
@@ -142,3 +142,18 @@ type StakingHooks interface {
 
 	OnSelfDelDropBelowMin(ctx Context, operator ValAddress)
 }
+
+// event hooks for delegations, called around every change of a delegation so that external modules
+// (liquidity mining, cross-chain staking...) can keep a shadow accounting of the delegated shares.
+// Any number of them can be registered on the staking keeper, alongside its StakingHooks.
+type DelegationHooks interface {
+	BeforeDelegationCreated(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Called before a new delegation is stored
+	BeforeDelegationSharesModified(ctx Context, delAddr AccAddress, valAddr ValAddress) // Called before the shares of an existing delegation change
+	AfterDelegationModified(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Called once a created or modified delegation is stored
+	BeforeDelegationRemoved(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Called before a delegation is deleted
+}
+
+// ModuleDelegatorHooks event hooks for modules delegating from their own accounts
+type ModuleDelegatorHooks interface {
+	AfterModuleRewardPaid(ctx Context, module string, valAddr ValAddress, rewardAddr AccAddress, amount int64) // Called after a reward of a module delegation is paid
+}
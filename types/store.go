@@ -115,6 +115,10 @@ type CommitMultiStore interface {
 	// calls to Mount*Store() are complete.
 	LoadLatestVersion() error
 
+	// Cache wrap a read-only view of a specific persisted version.
+	// Returns an error if the version has been pruned or never existed.
+	CacheMultiStoreWithVersion(ver int64) (CacheMultiStore, error)
+
 	// Load a specific persisted version.  When you load an old
 	// version, or when the last commit attempt didn't complete,
 	// the next commit after loading must be idempotent (return the
@@ -166,6 +170,21 @@ type KVStore interface {
 	Prefix(prefix []byte) KVStore
 }
 
+// KVStoreBatch collects writes to a KVStore, they are applied at once by Write.
+type KVStoreBatch interface {
+	Set(key, value []byte)
+	Delete(key []byte)
+	Write()
+}
+
+// BatchKVStore is implemented by the KVStores able to apply a batch of writes at once.
+type BatchKVStore interface {
+	KVStore
+
+	// NewBatch returns an empty batch, none of its writes is visible before its Write is called
+	NewBatch() KVStoreBatch
+}
+
 // Alias iterator to db's Iterator for convenience.
 type Iterator = dbm.Iterator
 
@@ -14,6 +14,51 @@ const (
 	BEP82                = "BEP82" // https://github.com/bnb-chain/BEPs/pull/82
 	FixFailAckPackage    = "FixFailAckPackage"
 	BEP128               = "BEP128" //https://github.com/bnb-chain/BEPs/pull/128
+	ModuleAccountUpgrade = "ModuleAccountUpgrade"
+	IBCChannelStats      = "IBCChannelStats"      // track per channel package stats in the ibc store
+	GovDepositDenoms     = "GovDepositDenoms"     // accept proposal deposits in whitelisted non-bond denoms
+	SlashInfractionTypes = "SlashInfractionTypes" // slash fraction params for oracle and bridge misbehavior
+	MinRewardPayout      = "MinRewardPayout"      // carry forward the staking rewards below a threshold
+	OracleClaimDedup     = "OracleClaimDedup"     // store the identical oracle claim payloads once
+	AccountLanes         = "AccountLanes"         // independent sequences for the lanes of an account
+	GovProposalPruning   = "GovProposalPruning"   // prune the finished proposals older than the proposal retention
+	OracleClaimCheck     = "OracleClaimCheck"     // validate the packages of an oracle claim before applying them
+	MultisigAccounts     = "MultisigAccounts"     // multisig accounts whose keys and threshold are updated on chain
+	BondedTokensAverage  = "BondedTokensAverage"  // time-weighted average of the bonded tokens for the inflation
+	AccountFlags         = "AccountFlags"         // flags set by the owner of an account, e.g. to require a memo on transfers
+	ProphecyExpiration   = "ProphecyExpiration"   // delete the oracle prophecies which do not reach consensus in time
+	OracleClaimIncentive = "OracleClaimIncentive" // reward the validators claiming the consensus, slash the contradicting ones
+	IBCLoadCompression   = "IBCLoadCompression"   // compress the large loads of the ibc packages sent to a side chain
+	DelegationCap        = "DelegationCap"        // cap the tokens a single delegator may delegate to a validator
+	ProphecySnapshot     = "ProphecySnapshot"     // optionally weigh the claims of a prophecy by the validator set at its creation
+	ClaimSizeLimit       = "ClaimSizeLimit"       // limit the size of the oracle claim payloads
+	GovWeightedVotes     = "GovWeightedVotes"     // split a governance vote across several options
+	CrossChainRegistry   = "CrossChainRegistry"   // register destination chains and channels by governance
+	IBCRelayFeeEscrow    = "IBCRelayFeeEscrow"    // escrow the relay fees of ibc packages and pay them to the relayers of the acks
+	IBCDeliveryWatermark = "IBCDeliveryWatermark" // only clean up the ibc packages up to the deliveries confirmed by the oracle
+	IBCChannelLimits     = "IBCChannelLimits"     // limit the size and rate of the ibc packages sent on a channel
+	ClaimSizeFee         = "ClaimSizeFee"         // charge the oracle claims in proportion to their size
+	FeesSpentCounter     = "FeesSpentCounter"     // count the tx fees spent by each account
+	IBCPackageRouting    = "IBCPackageRouting"    // route the packages received from a side chain to their handlers by the ibc keeper
+	CommissionAgreements = "CommissionAgreements" // discounted commission rates of validators for specific delegators
+	TxValidUntilHeight   = "TxValidUntilHeight"   // txs only valid up to a height, evicted from the mempool once expired
+	DelegationWithdraw   = "DelegationWithdraw"   // withdraw addresses set for specific delegations
+	TallyBreakdown       = "TallyBreakdown"       // store the per-validator breakdown of the tallied proposals
+	ExchangeRateHistory  = "ExchangeRateHistory"  // record the history of the delegator share exchange rates of the validators
+	ValidatorTombstone   = "ValidatorTombstone"   // tombstone double signing validators until they are untombstoned by a proposal
+	ParamChangeHistory   = "ParamChangeHistory"   // record the history of the parameter changes of each subspace
+	SupplyIndex          = "SupplyIndex"          // maintain the total supply of each denom as the account balances change
+	GovMinInitialDeposit = "GovMinInitialDeposit" // change the deposit params, including the minimum initial deposit, by proposals
+	RelayerAllowList     = "RelayerAllowList"     // restrict the relayers of a claim type to an allow-list of validators
+	ChannelSenders       = "ChannelSenders"       // restrict the modules sending syn packages on a channel by governance
+	WithdrawAddrBans     = "WithdrawAddrBans"     // ban addresses from being set as withdraw address by governance
+	AccountCacheIterate  = "AccountCacheIterate"  // iterate the accounts changed in the account cache but not written to the store
+	DistrParamsChange    = "DistrParamsChange"    // change the distribution params by governance
+	SupplyMintBurn       = "SupplyMintBurn"       // mint the inflation and burn the slashed tokens through the supply keeper
+	OracleSkipSequence   = "OracleSkipSequence"   // skip a missed oracle sequence by governance
+	BridgeTransfer       = "BridgeTransfer"       // transfer bound tokens to and from side chains through the bridge
+	AutoDistribution     = "AutoDistribution"     // distribute the rewards of all the delegations every interval of blocks
+	AccountSizeLimit     = "AccountSizeLimit"     // reject the accounts whose encoding exceeds MaxAccountBytes, on write and on read
 )
 
 var MainNetConfig = UpgradeConfig{
@@ -0,0 +1,43 @@
+package account
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// NewAnteHandler returns an AnteHandler which rejects the txs transferring to an account
+// flagged with FlagMemoRequired without a memo, before handing the tx to anteHandler.
+func NewAnteHandler(am auth.AccountKeeper, anteHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		if sdk.IsUpgrade(sdk.AccountFlags) {
+			if err := checkTransferMemo(ctx, am, tx); err != nil {
+				return ctx, err.Result(), true
+			}
+		}
+		return anteHandler(ctx, tx, mode)
+	}
+}
+
+func checkTransferMemo(ctx sdk.Context, am auth.AccountKeeper, tx sdk.Tx) sdk.Error {
+	stdTx, ok := tx.(auth.StdTx)
+	if !ok || len(stdTx.GetMemo()) != 0 {
+		return nil
+	}
+
+	for _, msg := range stdTx.GetMsgs() {
+		sendMsg, ok := msg.(bank.MsgSend)
+		if !ok {
+			continue
+		}
+		for _, out := range sendMsg.Outputs {
+			acc, ok := am.GetAccount(ctx, out.Address).(sdk.FlagsAccount)
+			if ok && acc.GetFlags()&FlagMemoRequired != 0 {
+				return sdk.ErrInvalidTxMemo(fmt.Sprintf("the transfer to %s requires a memo", out.Address))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package account
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Register concrete types on codec codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgSetAccountFlags{}, "account/MsgSetAccountFlags", nil)
+}
+
+var msgCdc = codec.New()
+
+func init() {
+	RegisterCodec(msgCdc)
+}
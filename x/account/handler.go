@@ -0,0 +1,53 @@
+package account
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+const EventTypeAccountFlagsSet = "account-flags-set"
+
+// NewHandler returns a handler for "accountFlags" type messages.
+func NewHandler(am auth.AccountKeeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgSetAccountFlags:
+			return handleMsgSetAccountFlags(ctx, am, msg)
+		default:
+			errMsg := "Unrecognized account Msg type: " + msg.Type()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgSetAccountFlags(ctx sdk.Context, am auth.AccountKeeper, msg MsgSetAccountFlags) sdk.Result {
+	if !sdk.IsUpgrade(sdk.AccountFlags) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("account flags are not supported before %s", sdk.AccountFlags)).Result()
+	}
+
+	account := am.GetAccount(ctx, msg.From)
+	if account == nil {
+		return sdk.ErrUnknownAddress(msg.From.String()).Result()
+	}
+	acc, ok := account.(sdk.FlagsAccount)
+	if !ok {
+		return sdk.ErrInvalidAccountFlags(fmt.Sprintf("the account type %T does not support flags", account)).Result()
+	}
+	if acc.GetFlags() == msg.Flags {
+		return sdk.ErrInvalidAccountFlags(fmt.Sprintf("the account flags are %#x already", msg.Flags)).Result()
+	}
+	if err := acc.SetFlags(msg.Flags); err != nil {
+		return sdk.ErrInternal(err.Error()).Result()
+	}
+	am.SetAccount(ctx, acc)
+
+	return sdk.Result{
+		Events: sdk.Events{sdk.NewEvent(EventTypeAccountFlagsSet,
+			sdk.NewAttribute("address", msg.From.String()),
+			sdk.NewAttribute("flags", strconv.FormatUint(msg.Flags, 10)),
+		)},
+	}
+}
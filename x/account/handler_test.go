@@ -0,0 +1,115 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func setup() (sdk.Context, auth.AccountKeeper) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey("acc")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	ms.LoadLatestVersion()
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(key), 10))
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	return ctx, auth.NewAccountKeeper(cdc, key, auth.ProtoBaseAccount)
+}
+
+func newAccount(ctx sdk.Context, am auth.AccountKeeper) sdk.AccAddress {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	acc := am.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 100)})
+	am.SetAccount(ctx, acc)
+	return addr
+}
+
+func TestSetAccountFlags(t *testing.T) {
+	ctx, am := setup()
+	handler := NewHandler(am)
+	addr := newAccount(ctx, am)
+
+	msg := NewMsgSetAccountFlags(addr, FlagMemoRequired)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, sdk.CodeInvalidAccountFlags, NewMsgSetAccountFlags(addr, 1<<10).ValidateBasic().Code())
+
+	// not supported before the upgrade
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), handler(ctx, msg).Code)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountFlags, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.AccountFlags)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	require.True(t, handler(ctx, msg).IsOK())
+	require.Equal(t, FlagMemoRequired, am.GetAccount(ctx, addr).(sdk.FlagsAccount).GetFlags())
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidAccountFlags), handler(ctx, msg).Code)
+
+	unknown := NewMsgSetAccountFlags(sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()), FlagMemoRequired)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownAddress), handler(ctx, unknown).Code)
+}
+
+func TestTransferMemoCheck(t *testing.T) {
+	ctx, am := setup()
+	from := newAccount(ctx, am)
+	to := newAccount(ctx, am)
+	acc := am.GetAccount(ctx, to).(sdk.FlagsAccount)
+	acc.SetFlags(FlagMemoRequired)
+	am.SetAccount(ctx, acc)
+
+	passed := false
+	anteHandler := NewAnteHandler(am, func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (sdk.Context, sdk.Result, bool) {
+		passed = true
+		return ctx, sdk.Result{}, false
+	})
+	coins := sdk.Coins{sdk.NewCoin("BNB", 10)}
+	send := bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+	noMemo := auth.NewStdTx([]sdk.Msg{send}, nil, "", 0, nil)
+	withMemo := auth.NewStdTx([]sdk.Msg{send}, nil, "deposit 42", 0, nil)
+
+	// the flag is ignored before the upgrade
+	_, res, abort := anteHandler(ctx, noMemo, sdk.RunTxModeDeliver)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+	require.True(t, passed)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountFlags, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.AccountFlags)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	passed = false
+	_, res, abort = anteHandler(ctx, noMemo, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidTxMemo), res.Code)
+	require.False(t, passed)
+
+	_, res, abort = anteHandler(ctx, withMemo, sdk.RunTxModeDeliver)
+	require.False(t, abort)
+	require.True(t, passed)
+
+	// transfers to the other accounts need no memo
+	passed = false
+	back := bank.NewMsgSend([]bank.Input{bank.NewInput(to, coins)}, []bank.Output{bank.NewOutput(from, coins)})
+	_, res, abort = anteHandler(ctx, auth.NewStdTx([]sdk.Msg{back}, nil, "", 0, nil), sdk.RunTxModeDeliver)
+	require.False(t, abort)
+	require.True(t, passed)
+}
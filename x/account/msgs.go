@@ -0,0 +1,56 @@
+package account
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const RouteKey = "accountFlags"
+
+// The flags an account owner can set, each one is a bit of the account flags
+const (
+	// FlagMemoRequired rejects the transfers to the account without a memo, e.g. for the
+	// deposit addresses of exchanges which tell their users apart by the memo
+	FlagMemoRequired uint64 = 1 << iota
+
+	knownFlags = FlagMemoRequired
+)
+
+// MsgSetAccountFlags replaces the flags of the account of the sender
+type MsgSetAccountFlags struct {
+	From  sdk.AccAddress `json:"from"`
+	Flags uint64         `json:"flags"`
+}
+
+var _ sdk.Msg = MsgSetAccountFlags{}
+
+func NewMsgSetAccountFlags(from sdk.AccAddress, flags uint64) MsgSetAccountFlags {
+	return MsgSetAccountFlags{From: from, Flags: flags}
+}
+
+// nolint
+func (msg MsgSetAccountFlags) Route() string { return RouteKey }
+func (msg MsgSetAccountFlags) Type() string  { return "setAccountFlags" }
+func (msg MsgSetAccountFlags) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+func (msg MsgSetAccountFlags) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// Implements Msg.
+func (msg MsgSetAccountFlags) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if msg.Flags&^knownFlags != 0 {
+		return sdk.ErrInvalidAccountFlags(fmt.Sprintf("unknown account flags %#x", msg.Flags&^knownFlags))
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgSetAccountFlags) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
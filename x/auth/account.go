@@ -28,6 +28,7 @@ type BaseAccount struct {
 	PubKey        crypto.PubKey  `json:"public_key"`
 	AccountNumber int64          `json:"account_number"`
 	Sequence      int64          `json:"sequence"`
+	Flags         uint64         `json:"flags,omitempty"`
 }
 
 // Prototype function for BaseAccount
@@ -99,6 +100,17 @@ func (acc *BaseAccount) SetSequence(seq int64) error {
 	return nil
 }
 
+// Implements sdk.FlagsAccount.
+func (acc *BaseAccount) GetFlags() uint64 {
+	return acc.Flags
+}
+
+// Implements sdk.FlagsAccount.
+func (acc *BaseAccount) SetFlags(flags uint64) error {
+	acc.Flags = flags
+	return nil
+}
+
 // Implements sdk.Account.
 func (acc *BaseAccount) Clone() sdk.Account {
 	// given the fact PubKey and Address doesn't change,
@@ -109,6 +121,7 @@ func (acc *BaseAccount) Clone() sdk.Account {
 		Address:       acc.Address,
 		AccountNumber: acc.AccountNumber,
 		Sequence:      acc.Sequence,
+		Flags:         acc.Flags,
 	}
 
 	if acc.Coins == nil {
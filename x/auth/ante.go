@@ -19,6 +19,12 @@ const (
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers
 func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
+	return NewAnteHandlerWithSigCache(am, nil)
+}
+
+// NewAnteHandlerWithSigCache returns an AnteHandler like NewAnteHandler which does not verify
+// again the signatures found in sigCache, e.g. the ones verified in PreCheckTx or CheckTx
+func NewAnteHandlerWithSigCache(am AccountKeeper, sigCache *SigCache) sdk.AnteHandler {
 	return func(
 		ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode,
 	) (newCtx sdk.Context, res sdk.Result, abort bool) {
@@ -40,6 +46,10 @@ func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
 			if err != nil {
 				return newCtx, err.Result(), true
 			}
+			err = validateValidUntilHeight(ctx, stdTx, mode)
+			if err != nil {
+				return newCtx, err.Result(), true
+			}
 		}
 
 		// stdSigs contains the sequence number, account number, and signatures
@@ -50,7 +60,7 @@ func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
 		if !res.IsOK() {
 			return newCtx, res, true
 		}
-		res = validateAccNumAndSequence(ctx, signerAccs, stdSigs)
+		res = validateAccNumAndSequence(ctx, am, signerAccs, stdSigs)
 		if !res.IsOK() {
 			return newCtx, res, true
 		}
@@ -71,10 +81,13 @@ func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
 				signBytes = nil
 			}
 			signerAccs[i], res = processSig(newCtx, signerAccs[i],
-				stdSigs[i], signBytes, mode)
+				stdSigs[i], signBytes, mode, sigCache)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
+			if lane := stdSigs[i].Lane; lane != 0 {
+				am.setLaneSequence(newCtx, signerAccs[i].GetAddress(), lane, stdSigs[i].Sequence+1)
+			}
 
 			// Save the account.
 			am.SetAccount(newCtx, signerAccs[i])
@@ -111,6 +124,28 @@ func validateBasic(tx StdTx) (err sdk.Error) {
 	return nil
 }
 
+// validateValidUntilHeight checks that the block the tx is executed in is not beyond its valid until height,
+// a checked tx is executed in the block after the last committed one at best
+func validateValidUntilHeight(ctx sdk.Context, tx StdTx, mode sdk.RunTxMode) sdk.Error {
+	if tx.ValidUntilHeight == 0 {
+		return nil
+	}
+	if !sdk.IsUpgrade(sdk.TxValidUntilHeight) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("valid until height is not supported before %s", sdk.TxValidUntilHeight))
+	}
+	if tx.ValidUntilHeight < 0 {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("invalid valid until height %d", tx.ValidUntilHeight))
+	}
+	height := ctx.BlockHeight()
+	if mode != sdk.RunTxModeDeliver && mode != sdk.RunTxModeDeliverAfterPre {
+		height++
+	}
+	if height > tx.ValidUntilHeight {
+		return sdk.ErrTxExpired(fmt.Sprintf("tx is valid until height %d, the block height is %d", tx.ValidUntilHeight, height))
+	}
+	return nil
+}
+
 func getSignerAccs(ctx sdk.Context, am AccountKeeper, addrs []sdk.AccAddress) (accs []sdk.Account, res sdk.Result) {
 	accs = make([]sdk.Account, len(addrs))
 	for i := 0; i < len(accs); i++ {
@@ -122,7 +157,7 @@ func getSignerAccs(ctx sdk.Context, am AccountKeeper, addrs []sdk.AccAddress) (a
 	return
 }
 
-func validateAccNumAndSequence(ctx sdk.Context, accs []sdk.Account, sigs []StdSignature) sdk.Result {
+func validateAccNumAndSequence(ctx sdk.Context, am AccountKeeper, accs []sdk.Account, sigs []StdSignature) sdk.Result {
 	for i := 0; i < len(accs); i++ {
 		// On InitChain, make sure account number == 0
 		if ctx.BlockHeight() == 0 && sigs[i].AccountNumber != 0 {
@@ -137,20 +172,32 @@ func validateAccNumAndSequence(ctx sdk.Context, accs []sdk.Account, sigs []StdSi
 				fmt.Sprintf("Invalid account number. Got %d, expected %d", sigs[i].AccountNumber, accnum)).Result()
 		}
 
-		// Check sequence number.
-		seq := accs[i].GetSequence()
-		if seq != sigs[i].Sequence {
+		// Check the sequence number of the lane.
+		lane := sigs[i].Lane
+		if lane != 0 && !sdk.IsUpgrade(sdk.AccountLanes) {
+			return sdk.ErrInvalidSequence("Lanes are not enabled yet").Result()
+		}
+		if err := validateLane(lane); err != nil {
+			return err.Result()
+		}
+		seq := am.GetLaneSequence(ctx, accs[i], lane)
+		if seq != sigs[i].Sequence && lane == 0 {
 			return sdk.ErrInvalidSequence(
 				fmt.Sprintf("Invalid sequence. Got %d, expected %d", sigs[i].Sequence, seq)).Result()
 		}
+		if seq != sigs[i].Sequence {
+			return sdk.ErrInvalidSequence(
+				fmt.Sprintf("Invalid sequence of lane %d. Got %d, expected %d", lane, sigs[i].Sequence, seq)).Result()
+		}
 	}
 	return sdk.Result{}
 }
 
-// verify the signature and increment the sequence.
+// verify the signature and increment the sequence if the tx is in lane 0, the caller
+// increments the sequences of the other lanes.
 // if the account doesn't have a pubkey, set it.
-func processSig(ctx sdk.Context,
-	acc sdk.Account, sig StdSignature, signBytes []byte, mode sdk.RunTxMode) (updatedAcc sdk.Account, res sdk.Result) {
+func processSig(ctx sdk.Context, acc sdk.Account, sig StdSignature, signBytes []byte, mode sdk.RunTxMode,
+	sigCache *SigCache) (updatedAcc sdk.Account, res sdk.Result) {
 	pubKey, res := processPubKey(acc, sig, mode == sdk.RunTxModeSimulate)
 	if !res.IsOK() {
 		return nil, res
@@ -160,9 +207,16 @@ func processSig(ctx sdk.Context,
 	if err != nil {
 		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
-	if (mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeDeliver) && !pubKey.VerifyBytes(signBytes, sig.Signature) {
+	// the prechecked txs are verified again against the pubkey of the account at execution, which is
+	// cheap if the PreChecker filled sigCache
+	verify := mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeDeliver ||
+		(sigCache != nil && (mode == sdk.RunTxModeCheckAfterPre || mode == sdk.RunTxModeDeliverAfterPre))
+	if verify && !verifyBytes(pubKey, signBytes, sig.Signature, sigCache) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
+	if sig.Lane != 0 {
+		return acc, res
+	}
 	// increment the sequence number
 	err = acc.SetSequence(acc.GetSequence() + 1)
 	if err != nil {
@@ -173,6 +227,13 @@ func processSig(ctx sdk.Context,
 	return acc, res
 }
 
+func verifyBytes(pubKey crypto.PubKey, signBytes []byte, sig []byte, sigCache *SigCache) bool {
+	if sigCache == nil {
+		return pubKey.VerifyBytes(signBytes, sig)
+	}
+	return sigCache.VerifyBytes(pubKey, signBytes, sig)
+}
+
 var dummySecp256k1Pubkey secp256k1.PubKeySecp256k1
 
 func init() {
@@ -208,8 +269,8 @@ func processPubKey(acc sdk.Account, sig StdSignature, simulate bool) (crypto.Pub
 func getSignBytesList(chainID string, stdTx StdTx, stdSigs []StdSignature) (signatureBytesList [][]byte) {
 	signatureBytesList = make([][]byte, len(stdSigs))
 	for i := 0; i < len(stdSigs); i++ {
-		signatureBytesList[i] = StdSignBytes(chainID,
-			stdSigs[i].AccountNumber, stdSigs[i].Sequence,
+		signatureBytesList[i] = StdSignBytesWithValidUntil(chainID,
+			stdSigs[i].AccountNumber, stdSigs[i].Lane, stdSigs[i].Sequence, stdTx.ValidUntilHeight,
 			stdTx.Msgs, stdTx.Memo, stdTx.Source, stdTx.Data)
 	}
 	return
@@ -335,6 +335,111 @@ func TestAnteHandlerSequences(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
 }
 
+func newTestLaneTx(ctx sdk.Context, msgs []sdk.Msg, priv crypto.PrivKey, accNum int64, lane int64, seq int64) sdk.Tx {
+	sig, err := priv.Sign(StdSignBytesWithLane(ctx.ChainID(), accNum, lane, seq, msgs, "", 0, nil))
+	if err != nil {
+		panic(err)
+	}
+	sigs := []StdSignature{{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNum, Sequence: seq, Lane: lane}}
+	return NewStdTx(msgs, sigs, "", 0, nil)
+}
+
+func TestAnteHandlerLanes(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewAnteHandler(mapper)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	ctx = ctx.WithBlockHeight(1)
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+
+	// the lanes are rejected before the upgrade
+	tx := newTestLaneTx(ctx, msgs, priv1, 0, 1, 0)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeInvalidSequence)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountLanes, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.AccountLanes)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// each lane has its own sequence
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeInvalidSequence)
+	checkValidTx(t, anteHandler, ctx, newTestLaneTx(ctx, msgs, priv1, 0, 1, 1), sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, newTestLaneTx(ctx, msgs, priv1, 0, 2, 0), sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, newTestLaneTx(ctx, msgs, priv1, 0, 0, 0), sdk.RunTxModeDeliver)
+	require.Equal(t, []LaneSequence{{0, 1}, {1, 2}, {2, 1}}, mapper.GetLaneSequences(ctx, mapper.GetAccount(ctx, addr1)))
+
+	// a tx signed for another lane is invalid
+	tx = newTestLaneTx(ctx, msgs, priv1, 0, 2, 1)
+	tx.(StdTx).Signatures[0].Lane = 3
+	tx.(StdTx).Signatures[0].Sequence = 0
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeUnauthorized)
+
+	// the lanes are bounded
+	checkInvalidTx(t, anteHandler, ctx, newTestLaneTx(ctx, msgs, priv1, 0, MaxLaneID+1, 0), sdk.RunTxModeDeliver, sdk.CodeInvalidSequence)
+	checkInvalidTx(t, anteHandler, ctx, newTestLaneTx(ctx, msgs, priv1, 0, -1, 0), sdk.RunTxModeDeliver, sdk.CodeInvalidSequence)
+}
+
+func newTestValidUntilTx(ctx sdk.Context, msgs []sdk.Msg, priv crypto.PrivKey, accNum int64, seq int64, validUntil int64) sdk.Tx {
+	sig, err := priv.Sign(StdSignBytesWithValidUntil(ctx.ChainID(), accNum, 0, seq, validUntil, msgs, "", 0, nil))
+	if err != nil {
+		panic(err)
+	}
+	sigs := []StdSignature{{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accNum, Sequence: seq}}
+	return NewStdTx(msgs, sigs, "", 0, nil).WithValidUntilHeight(validUntil)
+}
+
+func TestAnteHandlerValidUntilHeight(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewAnteHandler(mapper)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	ctx = ctx.WithBlockHeight(5)
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+
+	// the valid until height is rejected before the upgrade
+	tx := newTestValidUntilTx(ctx, msgs, priv1, 0, 0, 5)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeUnknownRequest)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.TxValidUntilHeight, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.TxValidUntilHeight)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// a checked tx is executed in the next block at best
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeCheck, sdk.CodeTxExpired)
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	checkInvalidTx(t, anteHandler, ctx, newTestValidUntilTx(ctx, msgs, priv1, 0, 1, 4), sdk.RunTxModeDeliver, sdk.CodeTxExpired)
+	checkInvalidTx(t, anteHandler, ctx, newTestValidUntilTx(ctx, msgs, priv1, 0, 1, -1), sdk.RunTxModeDeliver, sdk.CodeUnknownRequest)
+
+	// the valid until height is signed
+	tx = newTestValidUntilTx(ctx, msgs, priv1, 0, 1, 6)
+	checkInvalidTx(t, anteHandler, ctx, tx.(StdTx).WithValidUntilHeight(7), sdk.RunTxModeDeliver, sdk.CodeUnauthorized)
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeCheck)
+}
+
 func TestAnteHandlerMultiSigner(t *testing.T) {
 	// setup
 	ms, capKey, _ := setupMultiStore()
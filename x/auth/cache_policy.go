@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// CacheEvictionPolicy decides which accounts the account store cache evicts when it is full
+type CacheEvictionPolicy string
+
+const (
+	// EvictLRU evicts the least recently used account, it is the default
+	EvictLRU CacheEvictionPolicy = "lru"
+	// EvictARC balances the recently and the frequently used accounts, so that a scan of the
+	// accounts, e.g. IterateAccounts, does not evict the hot ones
+	EvictARC CacheEvictionPolicy = "arc"
+	// Evict2Q keeps the accounts used more than once apart from the ones used once, it resists
+	// scans like EvictARC with less overhead
+	Evict2Q CacheEvictionPolicy = "2q"
+)
+
+// ParseCacheEvictionPolicy returns the policy named s, the empty string is EvictLRU
+func ParseCacheEvictionPolicy(s string) (CacheEvictionPolicy, error) {
+	switch policy := CacheEvictionPolicy(s); policy {
+	case "":
+		return EvictLRU, nil
+	case EvictLRU, EvictARC, Evict2Q:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown cache eviction policy %q, expect %s, %s or %s",
+			s, EvictLRU, EvictARC, Evict2Q)
+	}
+}
+
+// evictingCache is the cache of the accounts, whatever its eviction policy
+type evictingCache interface {
+	Get(key interface{}) (value interface{}, ok bool)
+	Peek(key interface{}) (value interface{}, ok bool)
+	// Add returns whether an entry was evicted to make room for the new one
+	Add(key, value interface{}) (evicted bool)
+	Remove(key interface{})
+	Purge()
+	Keys() []interface{}
+	Len() int
+}
+
+func newEvictingCache(policy CacheEvictionPolicy, size int) (evictingCache, error) {
+	switch policy {
+	case EvictLRU, "":
+		cache, err := lru.New(size)
+		return lruCache{cache}, err
+	case EvictARC:
+		cache, err := lru.NewARC(size)
+		return &arcCache{cache: cache, size: size}, err
+	case Evict2Q:
+		cache, err := lru.New2Q(size)
+		return &twoQueueCache{cache: cache, size: size}, err
+	default:
+		return nil, fmt.Errorf("unknown cache eviction policy %q", policy)
+	}
+}
+
+type lruCache struct {
+	*lru.Cache
+}
+
+func (c lruCache) Remove(key interface{}) {
+	c.Cache.Remove(key)
+}
+
+// the ARC and 2Q caches do not report their evictions, an entry is evicted when a new key
+// is added to a full cache
+type arcCache struct {
+	cache *lru.ARCCache
+	size  int
+}
+
+func (c *arcCache) Get(key interface{}) (interface{}, bool)  { return c.cache.Get(key) }
+func (c *arcCache) Peek(key interface{}) (interface{}, bool) { return c.cache.Peek(key) }
+func (c *arcCache) Remove(key interface{})                   { c.cache.Remove(key) }
+func (c *arcCache) Purge()                                   { c.cache.Purge() }
+func (c *arcCache) Keys() []interface{}                      { return c.cache.Keys() }
+func (c *arcCache) Len() int                                 { return c.cache.Len() }
+
+func (c *arcCache) Add(key, value interface{}) bool {
+	evicted := c.cache.Len() >= c.size && !c.cache.Contains(key)
+	c.cache.Add(key, value)
+	return evicted
+}
+
+type twoQueueCache struct {
+	cache *lru.TwoQueueCache
+	size  int
+}
+
+func (c *twoQueueCache) Get(key interface{}) (interface{}, bool)  { return c.cache.Get(key) }
+func (c *twoQueueCache) Peek(key interface{}) (interface{}, bool) { return c.cache.Peek(key) }
+func (c *twoQueueCache) Remove(key interface{})                   { c.cache.Remove(key) }
+func (c *twoQueueCache) Purge()                                   { c.cache.Purge() }
+func (c *twoQueueCache) Keys() []interface{}                      { return c.cache.Keys() }
+func (c *twoQueueCache) Len() int                                 { return c.cache.Len() }
+
+func (c *twoQueueCache) Add(key, value interface{}) bool {
+	evicted := c.cache.Len() >= c.size && !c.cache.Contains(key)
+	c.cache.Add(key, value)
+	return evicted
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestParseCacheEvictionPolicy(t *testing.T) {
+	for s, expected := range map[string]CacheEvictionPolicy{"": EvictLRU, "lru": EvictLRU, "arc": EvictARC, "2q": Evict2Q} {
+		policy, err := ParseCacheEvictionPolicy(s)
+		require.NoError(t, err)
+		require.Equal(t, expected, policy)
+	}
+	_, err := ParseCacheEvictionPolicy("fifo")
+	require.Error(t, err)
+}
+
+func TestAccountStoreCacheEvictionPolicies(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStore := ms.GetKVStore(capKey)
+	addrs := make([]sdk.AccAddress, 20)
+	for i := range addrs {
+		addrs[i] = sdk.AccAddress(fmt.Sprintf("addr%02d", i))
+		accountStore.Set(AddressStoreKey(addrs[i]), cdc.MustMarshalBinaryBare(&BaseAccount{Address: addrs[i]}))
+	}
+
+	// the hot accounts are used twice, then all the others are scanned once
+	hot, scanned := addrs[:4], addrs[4:]
+	hotHits := func(policy CacheEvictionPolicy) int64 {
+		cache := NewAccountStoreCacheWithPolicy(cdc, accountStore, 8, policy, log.NewNopLogger())
+		for i := 0; i < 2; i++ {
+			for _, addr := range hot {
+				require.NotNil(t, cache.GetAccount(addr))
+			}
+		}
+		for _, addr := range scanned {
+			require.NotNil(t, cache.GetAccount(addr))
+		}
+		before := cache.(MeteredAccountStoreCache).Stats()
+		require.Equal(t, int64(len(hot)+len(scanned)-8), before.Evictions, "policy %s", policy)
+		require.Equal(t, 8, before.Len)
+		for _, addr := range hot {
+			require.NotNil(t, cache.GetAccount(addr))
+		}
+		return cache.(MeteredAccountStoreCache).Stats().Hits - before.Hits
+	}
+
+	require.Equal(t, int64(0), hotHits(EvictLRU))
+	require.Equal(t, int64(len(hot)), hotHits(EvictARC))
+	require.Equal(t, int64(len(hot)), hotHits(Evict2Q))
+}
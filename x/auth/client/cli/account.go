@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -18,13 +19,12 @@ func GetAccountCmdDefault(storeName string, cdc *codec.Codec) *cobra.Command {
 
 // GetAccountDecoder gets the account decoder for auth.DefaultAccount.
 func GetAccountDecoder(cdc *codec.Codec) auth.AccountDecoder {
-	return func(accBytes []byte) (acct sdk.Account, err error) {
-		err = cdc.UnmarshalBinaryBare(accBytes, &acct)
+	return func(accBytes []byte) (sdk.Account, error) {
+		acct, err := auth.DecodeAccount(cdc, accBytes)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-
-		return acct, err
+		return acct, nil
 	}
 }
 
@@ -73,3 +73,61 @@ func GetAccountCmd(storeName string, cdc *codec.Codec, decoder auth.AccountDecod
 		},
 	}
 }
+
+// accountInspection is the output of the inspect-account command
+type accountInspection struct {
+	Address string      `json:"address"`
+	Size    int         `json:"size"`
+	MaxSize int         `json:"max_size"`
+	Valid   bool        `json:"valid"`
+	Error   string      `json:"error,omitempty"`
+	Raw     string      `json:"raw,omitempty"`
+	Account sdk.Account `json:"account,omitempty"`
+}
+
+// GetInspectAccountCmd returns a command that fetches the raw account entry
+// at a given address and reports whether it can be decoded, printing the raw
+// bytes of corrupted entries so that they can be repaired.
+func GetInspectAccountCmd(storeName string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "inspect-account [address]",
+		Short: "Inspect the raw account entry at an address and check it can be decoded",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, err := cliCtx.QueryStore(auth.AddressStoreKey(addr), storeName)
+			if err != nil {
+				return err
+			} else if len(res) == 0 {
+				return context.ErrInvalidAccount(addr)
+			}
+
+			inspection := accountInspection{
+				Address: addr.String(),
+				Size:    len(res),
+				MaxSize: auth.MaxAccountBytes,
+			}
+			acc, decodeErr := auth.DecodeAccount(cdc, res)
+			if decodeErr != nil {
+				inspection.Error = decodeErr.RawError()
+				inspection.Raw = hex.EncodeToString(res)
+			} else {
+				inspection.Valid = true
+				inspection.Account = acc
+			}
+
+			output, err := cdc.MarshalJSONIndent(inspection, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
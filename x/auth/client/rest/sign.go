@@ -20,6 +20,7 @@ type SignBody struct {
 	ChainID          string     `json:"chain_id"`
 	AccountNumber    int64      `json:"account_number"`
 	Sequence         int64      `json:"sequence"`
+	Lane             int64      `json:"lane"`
 	AppendSig        bool       `json:"append_sig"`
 }
 
@@ -45,6 +46,7 @@ func SignTxRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Ha
 			ChainID:       m.ChainID,
 			AccountNumber: m.AccountNumber,
 			Sequence:      m.Sequence,
+			Lane:          m.Lane,
 		}
 
 		signedTx, err := txBldr.SignStdTx(m.LocalAccountName, m.Password, m.Tx, m.AppendSig)
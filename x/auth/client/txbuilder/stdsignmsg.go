@@ -12,6 +12,8 @@ type StdSignMsg struct {
 	ChainID       string    `json:"chain_id"`
 	AccountNumber int64     `json:"account_number"`
 	Sequence      int64     `json:"sequence"`
+	Lane          int64     `json:"lane,omitempty"`
+	ValidUntil    int64     `json:"valid_until_height,omitempty"`
 	Msgs          []sdk.Msg `json:"msgs"`
 	Memo          string    `json:"memo"`
 	Source        int64     `json:"source"`
@@ -20,5 +22,11 @@ type StdSignMsg struct {
 
 // get message bytes
 func (msg StdSignMsg) Bytes() []byte {
-	return auth.StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, msg.Msgs, msg.Memo, msg.Source, msg.Data)
+	return auth.StdSignBytesWithValidUntil(msg.ChainID, msg.AccountNumber, msg.Lane, msg.Sequence, msg.ValidUntil,
+		msg.Msgs, msg.Memo, msg.Source, msg.Data)
+}
+
+// StdTx returns the tx of the message with the given signatures
+func (msg StdSignMsg) StdTx(sigs []auth.StdSignature) auth.StdTx {
+	return auth.NewStdTx(msg.Msgs, sigs, msg.Memo, msg.Source, msg.Data).WithValidUntilHeight(msg.ValidUntil)
 }
@@ -15,6 +15,8 @@ type TxBuilder struct {
 	Codec         *codec.Codec
 	AccountNumber int64
 	Sequence      int64
+	Lane          int64
+	ValidUntil    int64
 	ChainID       string
 	Memo          string
 	Source        int64
@@ -27,6 +29,8 @@ func NewTxBuilderFromCLI() TxBuilder {
 		ChainID:       viper.GetString(client.FlagChainID),
 		AccountNumber: viper.GetInt64(client.FlagAccountNumber),
 		Sequence:      viper.GetInt64(client.FlagSequence),
+		Lane:          viper.GetInt64(client.FlagLane),
+		ValidUntil:    viper.GetInt64(client.FlagValidUntil),
 		Memo:          viper.GetString(client.FlagMemo),
 		Source:        viper.GetInt64(client.FlagSource),
 	}
@@ -50,6 +54,18 @@ func (bldr TxBuilder) WithSequence(sequence int64) TxBuilder {
 	return bldr
 }
 
+// WithLane returns a copy of the context with an updated lane, the sequence is of this lane.
+func (bldr TxBuilder) WithLane(lane int64) TxBuilder {
+	bldr.Lane = lane
+	return bldr
+}
+
+// WithValidUntil returns a copy of the context with the height of the last block the tx may be included in.
+func (bldr TxBuilder) WithValidUntil(height int64) TxBuilder {
+	bldr.ValidUntil = height
+	return bldr
+}
+
 // WithMemo returns a copy of the context with an updated memo.
 func (bldr TxBuilder) WithMemo(memo string) TxBuilder {
 	bldr.Memo = memo
@@ -80,6 +96,8 @@ func (bldr TxBuilder) Build(msgs []sdk.Msg) (StdSignMsg, error) {
 		ChainID:       bldr.ChainID,
 		AccountNumber: bldr.AccountNumber,
 		Sequence:      bldr.Sequence,
+		Lane:          bldr.Lane,
+		ValidUntil:    bldr.ValidUntil,
 		Memo:          bldr.Memo,
 		Msgs:          msgs,
 		Source:        bldr.Source,
@@ -93,7 +111,7 @@ func (bldr TxBuilder) Sign(name, passphrase string, msg StdSignMsg) ([]byte, err
 	if err != nil {
 		return nil, err
 	}
-	return bldr.Codec.MarshalBinaryLengthPrefixed(auth.NewStdTx(msg.Msgs, []auth.StdSignature{sig}, msg.Memo, msg.Source, msg.Data))
+	return bldr.Codec.MarshalBinaryLengthPrefixed(msg.StdTx([]auth.StdSignature{sig}))
 }
 
 // BuildAndSign builds a single message to be signed, and signs a transaction
@@ -129,10 +147,11 @@ func (bldr TxBuilder) BuildWithPubKey(name string, msgs []sdk.Msg) ([]byte, erro
 	sigs := []auth.StdSignature{{
 		AccountNumber: msg.AccountNumber,
 		Sequence:      msg.Sequence,
+		Lane:          msg.Lane,
 		PubKey:        info.GetPubKey(),
 	}}
 
-	return bldr.Codec.MarshalBinaryLengthPrefixed(auth.NewStdTx(msg.Msgs, sigs, msg.Memo, msg.Source, msg.Data))
+	return bldr.Codec.MarshalBinaryLengthPrefixed(msg.StdTx(sigs))
 }
 
 // SignStdTx appends a signature to a StdTx and returns a copy of a it. If append
@@ -142,6 +161,8 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appen
 		ChainID:       bldr.ChainID,
 		AccountNumber: bldr.AccountNumber,
 		Sequence:      bldr.Sequence,
+		Lane:          bldr.Lane,
+		ValidUntil:    stdTx.GetValidUntilHeight(),
 		Msgs:          stdTx.GetMsgs(),
 		Memo:          stdTx.GetMemo(),
 		Source:        stdTx.GetSource(),
@@ -157,7 +178,8 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appen
 	} else {
 		sigs = append(sigs, stdSignature)
 	}
-	signedStdTx = auth.NewStdTx(stdTx.GetMsgs(), sigs, stdTx.GetMemo(), stdTx.GetSource(), stdTx.GetData())
+	signedStdTx = auth.NewStdTx(stdTx.GetMsgs(), sigs, stdTx.GetMemo(), stdTx.GetSource(), stdTx.GetData()).
+		WithValidUntilHeight(stdTx.GetValidUntilHeight())
 	return
 }
 
@@ -174,6 +196,7 @@ func MakeSignature(name, passphrase string, msg StdSignMsg) (sig auth.StdSignatu
 	return auth.StdSignature{
 		AccountNumber: msg.AccountNumber,
 		Sequence:      msg.Sequence,
+		Lane:          msg.Lane,
 		PubKey:        pubkey,
 		Signature:     sigBytes,
 	}, nil
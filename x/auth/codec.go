@@ -9,7 +9,9 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterInterface((*types.Account)(nil), nil)
 	cdc.RegisterConcrete(&BaseAccount{}, "auth/Account", nil)
+	cdc.RegisterConcrete(&MultisigAccount{}, "auth/MultisigAccount", nil)
 	cdc.RegisterConcrete(StdTx{}, "auth/StdTx", nil)
+	cdc.RegisterConcrete(MsgUpdateMultisig{}, "auth/MsgUpdateMultisig", nil)
 }
 
 var msgCdc = codec.New()
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The fees spent by an account are the sum of the tx fees deducted from it since sdk.FeesSpentCounter,
+// they are kept apart from the account and only stored once the account pays a fee.
+
+var feesSpentPrefix = []byte("feesSpent:")
+
+// FeesSpentStoreKey is the key of the fees spent by an account in the account store
+func FeesSpentStoreKey(addr sdk.AccAddress) []byte {
+	key := make([]byte, 0, len(feesSpentPrefix)+1+len(addr))
+	key = append(key, feesSpentPrefix...)
+	key = append(key, byte(len(addr)))
+	return append(key, addr...)
+}
+
+// GetFeesSpent returns the tx fees the account has paid
+func (am AccountKeeper) GetFeesSpent(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	bz := ctx.KVStore(am.key).Get(FeesSpentStoreKey(addr))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	var fees sdk.Coins
+	am.cdc.MustUnmarshalBinaryBare(bz, &fees)
+	return fees
+}
+
+func (am AccountKeeper) addFeesSpent(ctx sdk.Context, addr sdk.AccAddress, fee sdk.Coins) {
+	fees := am.GetFeesSpent(ctx, addr).Plus(fee)
+	ctx.KVStore(am.key).Set(FeesSpentStoreKey(addr), am.cdc.MustMarshalBinaryBare(fees))
+}
+
+// DeductFees subtracts the fee of a tx from the account paying it and saves the account, the fee
+// is added to the fees spent by the account after sdk.FeesSpentCounter.
+func DeductFees(ctx sdk.Context, am AccountKeeper, acc sdk.Account, fee sdk.Coins) (sdk.Account, sdk.Result) {
+	if fee.IsZero() {
+		return acc, sdk.Result{}
+	}
+	if !fee.IsValid() {
+		return nil, sdk.ErrInvalidCoins(fmt.Sprintf("invalid fee amount: %s", fee)).Result()
+	}
+
+	newCoins := acc.GetCoins().Minus(fee)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientFunds(fmt.Sprintf("insufficient funds to pay for fees; %s < %s", acc.GetCoins(), fee)).Result()
+	}
+	if err := acc.SetCoins(newCoins); err != nil {
+		return nil, sdk.ErrInternal(err.Error()).Result()
+	}
+	am.SetAccount(ctx, acc)
+
+	if sdk.IsUpgrade(sdk.FeesSpentCounter) {
+		am.addFeesSpent(ctx, acc.GetAddress(), fee)
+	}
+	return acc, sdk.Result{}
+}
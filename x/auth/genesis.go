@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"bytes"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LoadGenesisAccounts stores the genesis accounts at once, they take consecutive account numbers
+// in the given order. Instead of being buffered by the account cache of ctx until the commit, the
+// accounts are written to the account store in a single batch, and the account store cache is
+// filled up to its capacity with the accounts holding the most native tokens, so the first blocks
+// of a chain bootstrapped with lots of accounts do not start with a cold cache.
+// It has to be called before any of the accounts is read through ctx.
+func (am AccountKeeper) LoadGenesisAccounts(ctx sdk.Context, accs []sdk.Account) {
+	if len(accs) == 0 {
+		return
+	}
+
+	first, err := am.ReserveAccountNumbers(ctx, int64(len(accs)))
+	if err != nil {
+		panic(err)
+	}
+	for i, acc := range accs {
+		if err := acc.SetAccountNumber(first + int64(i)); err != nil {
+			panic(err)
+		}
+	}
+
+	// the accounts can only skip the account cache of ctx if it is right on top of the store cache,
+	// otherwise they go through the cache layers like any other account
+	if cache, ok := ctx.AccountCache().(*accountCache); ok {
+		if storeCache, ok := cache.parent.(*accountStoreCache); ok {
+			storeCache.loadAccounts(accs)
+			return
+		}
+	}
+	for _, acc := range accs {
+		am.SetAccount(ctx, acc)
+	}
+}
+
+// loadAccounts writes the accounts in a single batch if the underlying store supports it, then
+// caches the accounts holding the most native tokens, the richest one is the most recently used.
+func (ac *accountStoreCache) loadAccounts(accs []sdk.Account) {
+	if batchStore, ok := ac.store.(sdk.BatchKVStore); ok {
+		batch := batchStore.NewBatch()
+		for _, acc := range accs {
+			batch.Set(AddressStoreKey(acc.GetAddress()), ac.encodeAccount(acc))
+		}
+		batch.Write()
+	} else {
+		for _, acc := range accs {
+			ac.store.Set(AddressStoreKey(acc.GetAddress()), ac.encodeAccount(acc))
+		}
+	}
+
+	richest := make([]sdk.Account, len(accs))
+	copy(richest, accs)
+	sort.Slice(richest, func(i, j int) bool {
+		bi := richest[i].GetCoins().AmountOf(sdk.NativeTokenSymbol)
+		bj := richest[j].GetCoins().AmountOf(sdk.NativeTokenSymbol)
+		if bi != bj {
+			return bi > bj
+		}
+		return bytes.Compare(richest[i].GetAddress(), richest[j].GetAddress()) < 0
+	})
+	if len(richest) > ac.cap {
+		richest = richest[:ac.cap]
+	}
+	for i := len(richest) - 1; i >= 0; i-- {
+		ac.setAccountToCache(richest[i].GetAddress(), richest[i])
+	}
+	ac.logStats("Loaded genesis accounts", "count", len(accs))
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	codec "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestLoadGenesisAccounts(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStore := ms.GetKVStore(capKey)
+	accountStoreCache := NewAccountStoreCache(cdc, accountStore, 2)
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(NewAccountCache(accountStoreCache))
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	// an account created before takes the first number
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, sdk.AccAddress("addr0")))
+
+	addrs := []sdk.AccAddress{sdk.AccAddress("addr1"), sdk.AccAddress("addr2"), sdk.AccAddress("addr3"), sdk.AccAddress("addr4")}
+	balances := []int64{10, 30, 0, 20}
+	accs := make([]sdk.Account, len(addrs))
+	for i, addr := range addrs {
+		accs[i] = &BaseAccount{Address: addr, Coins: sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, balances[i])}}
+	}
+	mapper.LoadGenesisAccounts(ctx, accs)
+
+	// the cache keeps the richest accounts, the richest is the most recently used
+	cache := accountStoreCache.(interface{ CachedAddrs() []sdk.AccAddress })
+	require.Equal(t, []sdk.AccAddress{addrs[3], addrs[1]}, cache.CachedAddrs())
+
+	// the accounts are written to the store right away
+	for i, addr := range addrs {
+		stored, err := DecodeAccount(cdc, accountStore.Get(AddressStoreKey(addr)))
+		require.NoError(t, err)
+		require.Equal(t, int64(i+1), stored.GetAccountNumber())
+		require.Equal(t, int64(i+1), mapper.GetAccount(ctx, addr).GetAccountNumber())
+	}
+	require.Equal(t, int64(len(addrs)+1), mapper.PeekNextAccountNumber(ctx))
+}
+
+func TestLoadGenesisAccountsThroughCache(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStore := ms.GetKVStore(capKey)
+	accountCache := NewAccountCache(NewAccountStoreCache(cdc, accountStore, 10)).CacheWrap()
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	// the accounts are buffered by the cache layers which are not right on top of the store cache
+	addr := sdk.AccAddress("addr1")
+	mapper.LoadGenesisAccounts(ctx, []sdk.Account{&BaseAccount{Address: addr}})
+	require.Nil(t, accountStore.Get(AddressStoreKey(addr)))
+	require.NotNil(t, mapper.GetAccount(ctx, addr))
+
+	accountCache.Discard()
+	require.Nil(t, mapper.GetAccount(ctx, addr))
+}
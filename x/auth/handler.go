@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const EventTypeMultisigUpdated = "multisig-updated"
+
+// NewHandler returns a handler for "auth" type messages.
+func NewHandler(am AccountKeeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgUpdateMultisig:
+			return handleMsgUpdateMultisig(ctx, am, msg)
+		default:
+			errMsg := "Unrecognized auth Msg type: " + msg.Type()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// The ante handler has verified the signature of the account with its current keys
+func handleMsgUpdateMultisig(ctx sdk.Context, am AccountKeeper, msg MsgUpdateMultisig) sdk.Result {
+	if !sdk.IsUpgrade(sdk.MultisigAccounts) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("multisig accounts are not supported before %s", sdk.MultisigAccounts)).Result()
+	}
+
+	var multisigAcc *MultisigAccount
+	switch acc := am.GetAccount(ctx, msg.Address).(type) {
+	case nil:
+		return sdk.ErrUnknownAddress(msg.Address.String()).Result()
+	case *MultisigAccount:
+		multisigAcc = acc
+		multisigAcc.PubKeys = msg.PubKeys
+		multisigAcc.Threshold = msg.Threshold
+	case *BaseAccount:
+		acc.PubKey = nil
+		multisigAcc = NewMultisigAccount(acc, msg.PubKeys, msg.Threshold)
+	default:
+		return sdk.ErrInvalidAccount(fmt.Sprintf("%T cannot be turned into a multisig account", acc)).Result()
+	}
+	am.SetAccount(ctx, multisigAcc)
+
+	return sdk.Result{
+		Events: sdk.Events{sdk.NewEvent(EventTypeMultisigUpdated,
+			sdk.NewAttribute("address", msg.Address.String()),
+			sdk.NewAttribute("keys", strconv.Itoa(len(msg.PubKeys))),
+			sdk.NewAttribute("threshold", strconv.Itoa(msg.Threshold)),
+		)},
+	}
+}
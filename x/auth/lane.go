@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The lanes let an account send txs in several independent orders, e.g. one for trading and
+// one for transfers, so a tx stuck in one lane does not hold the txs of the others. Lane 0 is
+// the sequence of the account itself, lanes 1 to MaxLaneID have their own sequences, which
+// are kept apart from the account and only stored once used.
+const MaxLaneID = 15
+
+// LaneSequence is the sequence of the next tx of a lane
+type LaneSequence struct {
+	Lane     int64 `json:"lane"`
+	Sequence int64 `json:"sequence"`
+}
+
+var laneSequencePrefix = []byte("laneSequence:")
+
+// LaneSequenceStoreKey is the key of the sequence of a lane of an account in the account store,
+// the address is prefixed by its length so the lanes of an account are iterated apart from the others.
+func LaneSequenceStoreKey(addr sdk.AccAddress, lane int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(lane))
+	return append(laneSequencesKey(addr), bz...)
+}
+
+func laneSequencesKey(addr sdk.AccAddress) []byte {
+	key := make([]byte, 0, len(laneSequencePrefix)+1+len(addr)+8)
+	key = append(key, laneSequencePrefix...)
+	key = append(key, byte(len(addr)))
+	return append(key, addr...)
+}
+
+func validateLane(lane int64) sdk.Error {
+	if lane < 0 || lane > MaxLaneID {
+		return sdk.ErrInvalidSequence(fmt.Sprintf("Invalid lane %d, expected 0 to %d", lane, MaxLaneID))
+	}
+	return nil
+}
+
+// GetLaneSequence returns the sequence of the next tx of the lane of the account,
+// lane 0 is the sequence of the account.
+func (am AccountKeeper) GetLaneSequence(ctx sdk.Context, acc sdk.Account, lane int64) int64 {
+	if lane == 0 {
+		return acc.GetSequence()
+	}
+	return DecodeLaneSequence(ctx.KVStore(am.key).Get(LaneSequenceStoreKey(acc.GetAddress(), lane)))
+}
+
+// DecodeLaneSequence decodes the value stored at LaneSequenceStoreKey, nil is a lane not used yet
+func DecodeLaneSequence(bz []byte) int64 {
+	if len(bz) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// setLaneSequence sets the sequence of a lane other than 0, the caller saves the
+// sequence of lane 0 with the account.
+func (am AccountKeeper) setLaneSequence(ctx sdk.Context, addr sdk.AccAddress, lane int64, sequence int64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(sequence))
+	ctx.KVStore(am.key).Set(LaneSequenceStoreKey(addr, lane), bz)
+}
+
+// GetLaneSequences returns the sequences of the lanes used by the account, lane 0 included
+func (am AccountKeeper) GetLaneSequences(ctx sdk.Context, acc sdk.Account) []LaneSequence {
+	seqs := []LaneSequence{{Lane: 0, Sequence: acc.GetSequence()}}
+	prefix := laneSequencesKey(acc.GetAddress())
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(am.key), prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		seqs = append(seqs, LaneSequence{
+			Lane:     int64(binary.BigEndian.Uint64(iter.Key()[len(prefix):])),
+			Sequence: DecodeLaneSequence(iter.Value()),
+		})
+	}
+	return seqs
+}
@@ -1,14 +1,17 @@
 package auth
 
 import (
+	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 
-	"github.com/hashicorp/golang-lru"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/metrics"
 )
 
 /*
@@ -24,6 +27,11 @@ it's based on the below facts:
 
 var globalAccountNumberKey = []byte("globalAccountNumber")
 
+// MaxAccountBytes caps the size of an encoded account after the AccountSizeLimit upgrade:
+// a tx producing a larger account is rejected, and larger entries are reported as
+// corrupted instead of being handed to the decoder.
+const MaxAccountBytes = 1 << 20
+
 // This AccountKeeper encodes/decodes accounts using the
 // go-amino (binary) encoding/decoding library.
 type AccountKeeper struct {
@@ -95,6 +103,16 @@ func (am AccountKeeper) GetAccount(ctx sdk.Context, addr sdk.AccAddress) sdk.Acc
 func (am AccountKeeper) SetAccount(ctx sdk.Context, acc sdk.Account) {
 	addr := acc.GetAddress()
 	cache := ctx.AccountCache()
+	if sdk.IsUpgrade(sdk.AccountSizeLimit) {
+		// the account is only encoded when the block is committed, so it is checked here to fail
+		// the tx producing it instead of writing an account that can no longer be read
+		if bz := am.encodeAccount(acc); len(bz) > MaxAccountBytes {
+			panic(sdk.ErrInvalidAccount(fmt.Sprintf("encoded account has %d bytes, exceeds the limit %d", len(bz), MaxAccountBytes)))
+		}
+	}
+	if sdk.IsUpgrade(sdk.SupplyIndex) {
+		am.updateSupply(ctx, cache.GetAccount(addr), acc)
+	}
 	cache.SetAccount(addr, acc)
 }
 
@@ -102,24 +120,68 @@ func (am AccountKeeper) SetAccount(ctx sdk.Context, acc sdk.Account) {
 func (am AccountKeeper) RemoveAccount(ctx sdk.Context, acc sdk.Account) {
 	addr := acc.GetAddress()
 	cache := ctx.AccountCache()
+	if sdk.IsUpgrade(sdk.SupplyIndex) {
+		am.updateSupply(ctx, cache.GetAccount(addr), nil)
+	}
 	cache.Delete(addr)
 }
 
 // Implements sdk.AccountKeeper.
+// After the AccountCacheIterate upgrade, the accounts changed in ctx.AccountCache() but not written
+// to the store yet are iterated with their current value, the deleted ones are skipped.
 func (am AccountKeeper) IterateAccounts(ctx sdk.Context, process func(sdk.Account) (stop bool)) {
+	am.IterateAccountsFrom(ctx, nil, process)
+}
+
+// IterateAccountsFrom iterates over the accounts in address order, starting at the
+// first account whose address is not lower than start. Like IterateAccounts, it sees
+// the changes of ctx.AccountCache().
+func (am AccountKeeper) IterateAccountsFrom(ctx sdk.Context, start sdk.AccAddress, process func(sdk.Account) (stop bool)) {
+	var dirty map[string]sdk.Account
+	// the overlay changes the iterated accounts, so it must start at the same height on all nodes
+	if cache, ok := ctx.AccountCache().(*accountCache); ok && sdk.IsUpgrade(sdk.AccountCacheIterate) {
+		dirty = cache.dirtyAccounts()
+	}
+	dirtyAddrs := make([]string, 0, len(dirty))
+	for addr := range dirty {
+		if addr >= string(start) {
+			dirtyAddrs = append(dirtyAddrs, addr)
+		}
+	}
+	sort.Strings(dirtyAddrs)
+
 	store := ctx.KVStore(am.key)
-	iter := sdk.KVStorePrefixIterator(store, []byte("account:"))
+	prefix := []byte("account:")
+	iter := store.Iterator(AddressStoreKey(start), sdk.PrefixEndBytes(prefix))
 	defer iter.Close()
-	for {
-		if !iter.Valid() {
-			return
+	// merge the changed accounts into the ones of the store, like the iterator of cachekv
+	for iter.Valid() || len(dirtyAddrs) > 0 {
+		var acc sdk.Account
+		if iter.Valid() {
+			storeAddr := string(iter.Key()[len(prefix):])
+			if len(dirtyAddrs) > 0 && dirtyAddrs[0] <= storeAddr {
+				if dirtyAddrs[0] == storeAddr {
+					iter.Next()
+				}
+				acc = dirty[dirtyAddrs[0]]
+				dirtyAddrs = dirtyAddrs[1:]
+			} else {
+				var err sdk.Error
+				if acc, err = am.decodeAccount(iter.Value()); err != nil {
+					ctx.Logger().Error("skip corrupted account", "key", iter.Key(), "err", err.Error())
+				}
+				iter.Next()
+			}
+		} else {
+			acc = dirty[dirtyAddrs[0]]
+			dirtyAddrs = dirtyAddrs[1:]
+		}
+		if acc == nil { // deleted or corrupted
+			continue
 		}
-		val := iter.Value()
-		acc := am.decodeAccount(val)
 		if process(acc) {
 			return
 		}
-		iter.Next()
 	}
 }
 
@@ -157,6 +219,13 @@ func (am AccountKeeper) setSequence(ctx sdk.Context, addr sdk.AccAddress, newSeq
 
 // Returns and increments the global account number counter
 func (am AccountKeeper) GetNextAccountNumber(ctx sdk.Context) int64 {
+	accNumber := am.PeekNextAccountNumber(ctx)
+	am.setNextAccountNumber(ctx, accNumber+1)
+	return accNumber
+}
+
+// PeekNextAccountNumber returns the number of the next created account without taking it
+func (am AccountKeeper) PeekNextAccountNumber(ctx sdk.Context) int64 {
 	var accNumber int64
 	store := ctx.KVStore(am.key)
 	bz := store.Get(globalAccountNumberKey)
@@ -168,11 +237,49 @@ func (am AccountKeeper) GetNextAccountNumber(ctx sdk.Context) int64 {
 			panic(err)
 		}
 	}
+	return accNumber
+}
 
-	bz = am.cdc.MustMarshalBinaryLengthPrefixed(accNumber + 1)
-	store.Set(globalAccountNumberKey, bz)
+// SetInitialAccountNumber makes the next created account take the given number, e.g. to
+// continue the numbers of an imported state. The counter never goes back, as the numbers
+// below it may be taken already.
+func (am AccountKeeper) SetInitialAccountNumber(ctx sdk.Context, accNumber int64) error {
+	if next := am.PeekNextAccountNumber(ctx); accNumber < next {
+		return fmt.Errorf("account number %d is below the next account number %d", accNumber, next)
+	}
+	am.setNextAccountNumber(ctx, accNumber)
+	return nil
+}
 
-	return accNumber
+// ReserveAccountNumbers takes count consecutive account numbers at once, e.g. for the accounts
+// created by a hard-fork upgrade, and returns the first of them.
+func (am AccountKeeper) ReserveAccountNumbers(ctx sdk.Context, count int64) (int64, error) {
+	if count <= 0 {
+		return 0, fmt.Errorf("cannot reserve %d account numbers", count)
+	}
+	first := am.PeekNextAccountNumber(ctx)
+	am.setNextAccountNumber(ctx, first+count)
+	return first, nil
+}
+
+// MigrateNextAccountNumber moves the counter past the numbers of all the stored accounts,
+// so the accounts imported with their numbers are never given a taken number again.
+func (am AccountKeeper) MigrateNextAccountNumber(ctx sdk.Context) int64 {
+	next := am.PeekNextAccountNumber(ctx)
+	am.IterateAccounts(ctx, func(acc sdk.Account) bool {
+		if acc.GetAccountNumber() >= next {
+			next = acc.GetAccountNumber() + 1
+		}
+		return false
+	})
+	am.setNextAccountNumber(ctx, next)
+	return next
+}
+
+func (am AccountKeeper) setNextAccountNumber(ctx sdk.Context, accNumber int64) {
+	store := ctx.KVStore(am.key)
+	bz := am.cdc.MustMarshalBinaryLengthPrefixed(accNumber)
+	store.Set(globalAccountNumberKey, bz)
 }
 
 //----------------------------------------
@@ -186,38 +293,133 @@ func (am AccountKeeper) encodeAccount(acc sdk.Account) []byte {
 	return bz
 }
 
-func (am AccountKeeper) decodeAccount(bz []byte) (acc sdk.Account) {
-	err := am.cdc.UnmarshalBinaryBare(bz, &acc)
-	if err != nil {
-		panic(err)
+func (am AccountKeeper) decodeAccount(bz []byte) (sdk.Account, sdk.Error) {
+	return DecodeAccount(am.cdc, bz)
+}
+
+// DecodeAccount decodes an account stored in the account store. Corrupted bytes, and
+// oversized ones after the AccountSizeLimit upgrade, are reported with a CodeInvalidAccount
+// error, the decoder is never allowed to panic.
+func DecodeAccount(cdc *codec.Codec, bz []byte) (acc sdk.Account, err sdk.Error) {
+	if sdk.IsUpgrade(sdk.AccountSizeLimit) && len(bz) > MaxAccountBytes {
+		return nil, sdk.ErrInvalidAccount(fmt.Sprintf("encoded account has %d bytes, exceeds the limit %d", len(bz), MaxAccountBytes))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			acc, err = nil, sdk.ErrInvalidAccount(fmt.Sprintf("failed to decode account: %v", r))
+		}
+	}()
+
+	if decodeErr := cdc.UnmarshalBinaryBare(bz, &acc); decodeErr != nil {
+		return nil, sdk.ErrInvalidAccount(fmt.Sprintf("failed to decode account: %v", decodeErr))
+	}
+	if acc == nil {
+		return nil, sdk.ErrInvalidAccount("decoded account is nil")
+	}
+	return acc, nil
+}
+
+// GetRawAccount returns the raw bytes stored for addr together with the result
+// of decoding them, so corrupted entries can be inspected.
+func (am AccountKeeper) GetRawAccount(ctx sdk.Context, addr sdk.AccAddress) ([]byte, sdk.Account, sdk.Error) {
+	bz := ctx.KVStore(am.key).Get(AddressStoreKey(addr))
+	if bz == nil {
+		return nil, nil, sdk.ErrUnknownAddress(addr.String())
 	}
-	return
+	acc, err := DecodeAccount(am.cdc, bz)
+	return bz, acc, err
 }
 
 func NewAccountStoreCache(cdc *codec.Codec, store sdk.KVStore, cap int) sdk.AccountStoreCache {
-	cache, err := lru.New(cap)
+	return NewAccountStoreCacheWithLogger(cdc, store, cap, log.NewNopLogger())
+}
+
+// NewAccountStoreCacheWithLogger returns an account store cache logging its writes and usage to logger
+func NewAccountStoreCacheWithLogger(cdc *codec.Codec, store sdk.KVStore, cap int, logger log.Logger) sdk.AccountStoreCache {
+	return NewAccountStoreCacheWithPolicy(cdc, store, cap, EvictLRU, logger)
+}
+
+// NewAccountStoreCacheWithPolicy returns an account store cache evicting the accounts according to policy
+func NewAccountStoreCacheWithPolicy(cdc *codec.Codec, store sdk.KVStore, cap int, policy CacheEvictionPolicy, logger log.Logger) sdk.AccountStoreCache {
+	cache, err := newEvictingCache(policy, cap)
 	if err != nil {
 		panic(err)
 	}
 
 	return &accountStoreCache{
-		cdc:   cdc,
-		cache: cache,
-		store: store,
+		cdc:     cdc,
+		cache:   cache,
+		cap:     cap,
+		store:   store,
+		logger:  logger,
+		metrics: metrics.NopAccountCacheMetrics(),
 	}
 }
 
+// AccountStoreCacheStats is the usage of an account store cache since it was created,
+// the evictions are the accounts removed to make room for others.
+type AccountStoreCacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Len       int
+	Cap       int
+}
+
+func (s AccountStoreCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// MeteredAccountStoreCache is implemented by the account store caches reporting their usage
+type MeteredAccountStoreCache interface {
+	Stats() AccountStoreCacheStats
+	EnablePrometheusMetrics()
+}
+
+var _ MeteredAccountStoreCache = (*accountStoreCache)(nil)
+
 type accountStoreCache struct {
 	cdc   *codec.Codec
-	cache *lru.Cache
+	cache evictingCache
+	cap   int
 	store sdk.KVStore
+
+	logger  log.Logger
+	metrics *metrics.AccountCacheMetrics
+
+	// accessed atomically
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+func (ac *accountStoreCache) EnablePrometheusMetrics() {
+	ac.metrics = metrics.PrometheusAccountCacheMetrics()
+}
+
+func (ac *accountStoreCache) Stats() AccountStoreCacheStats {
+	return AccountStoreCacheStats{
+		Hits:      atomic.LoadInt64(&ac.hits),
+		Misses:    atomic.LoadInt64(&ac.misses),
+		Evictions: atomic.LoadInt64(&ac.evictions),
+		Len:       ac.cache.Len(),
+		Cap:       ac.cap,
+	}
 }
 
 func (ac *accountStoreCache) getAccountFromCache(addr sdk.AccAddress) (acc sdk.Account, ok bool) {
 	cacc, ok := ac.cache.Get(string(addr))
 	if !ok {
+		atomic.AddInt64(&ac.misses, 1)
+		ac.metrics.Misses.Add(1)
 		return nil, ok
 	}
+	atomic.AddInt64(&ac.hits, 1)
+	ac.metrics.Hits.Add(1)
 	if acc, ok := cacc.(sdk.Account); ok {
 		return acc.Clone(), ok
 	}
@@ -225,7 +427,10 @@ func (ac *accountStoreCache) getAccountFromCache(addr sdk.AccAddress) (acc sdk.A
 }
 
 func (ac *accountStoreCache) setAccountToCache(addr sdk.AccAddress, acc sdk.Account) {
-	ac.cache.Add(string(addr), acc.Clone())
+	if ac.cache.Add(string(addr), acc.Clone()) {
+		atomic.AddInt64(&ac.evictions, 1)
+		ac.metrics.Evictions.Add(1)
+	}
 }
 
 func (ac *accountStoreCache) GetAccount(addr sdk.AccAddress) sdk.Account {
@@ -237,7 +442,13 @@ func (ac *accountStoreCache) GetAccount(addr sdk.AccAddress) sdk.Account {
 	if bz == nil {
 		return nil
 	}
-	acc := ac.decodeAccount(bz)
+	// a corrupted entry must not read as a missing account, the account would be recreated
+	// over it, so the tx reading it fails instead. It can be inspected with GetRawAccount.
+	acc, err := ac.decodeAccount(bz)
+	if err != nil {
+		ac.logger.Error("failed to decode account", "addr", addr.String(), "err", err.Error())
+		panic(err)
+	}
 	ac.setAccountToCache(addr, acc)
 	return acc
 }
@@ -254,14 +465,79 @@ func (ac *accountStoreCache) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
 }
 
 func (ac *accountStoreCache) Delete(addr sdk.AccAddress) {
-	ac.setAccountToCache(addr, nil)
+	ac.cache.Remove(string(addr))
 	ac.store.Delete(AddressStoreKey(addr))
 }
 
+// accountBatchWriter is implemented by the parents able to apply all the writes of an accountCache at once
+type accountBatchWriter interface {
+	writeBatch(keys []string, values []cValue)
+}
+
+var _ accountBatchWriter = (*accountStoreCache)(nil)
+
+// writeBatch writes the accounts through a batch if the underlying store supports it,
+// otherwise they are written one by one in key order.
+func (ac *accountStoreCache) writeBatch(keys []string, values []cValue) {
+	batchStore, ok := ac.store.(sdk.BatchKVStore)
+	if !ok {
+		for i, key := range keys {
+			if values[i].deleted {
+				ac.Delete(sdk.AccAddress(key))
+			} else if values[i].acc != nil {
+				ac.SetAccount(sdk.AccAddress(key), values[i].acc)
+			}
+		}
+		return
+	}
+
+	batch := batchStore.NewBatch()
+	for i, key := range keys {
+		addr := sdk.AccAddress(key)
+		if values[i].deleted {
+			batch.Delete(AddressStoreKey(addr))
+		} else if values[i].acc != nil {
+			batch.Set(AddressStoreKey(addr), ac.encodeAccount(values[i].acc))
+		}
+	}
+	batch.Write()
+
+	for i, key := range keys {
+		if values[i].deleted {
+			ac.cache.Remove(key)
+		} else if values[i].acc != nil {
+			ac.setAccountToCache(sdk.AccAddress(key), values[i].acc)
+		}
+	}
+	ac.logStats("Wrote accounts to store", "count", len(keys))
+}
+
 func (ac *accountStoreCache) ClearCache() {
+	ac.logStats("Clearing account cache")
 	ac.cache.Purge()
 }
 
+func (ac *accountStoreCache) logStats(msg string, keyvals ...interface{}) {
+	stats := ac.Stats()
+	ac.logger.Debug(msg, append(keyvals, "hits", stats.Hits, "misses", stats.Misses,
+		"evictions", stats.Evictions, "len", stats.Len, "cap", stats.Cap)...)
+}
+
+// CachedAddrs returns the addresses of the cached accounts, from the least to the most recently used
+// with EvictLRU
+func (ac *accountStoreCache) CachedAddrs() []sdk.AccAddress {
+	keys := ac.cache.Keys()
+	addrs := make([]sdk.AccAddress, 0, len(keys))
+	for _, key := range keys {
+		if cacc, ok := ac.cache.Peek(key); ok {
+			if _, ok := cacc.(sdk.Account); ok {
+				addrs = append(addrs, sdk.AccAddress(key.(string)))
+			}
+		}
+	}
+	return addrs
+}
+
 func (ac *accountStoreCache) encodeAccount(acc sdk.Account) []byte {
 	bz, err := ac.cdc.MarshalBinaryBare(acc)
 	if err != nil {
@@ -270,12 +546,8 @@ func (ac *accountStoreCache) encodeAccount(acc sdk.Account) []byte {
 	return bz
 }
 
-func (ac *accountStoreCache) decodeAccount(bz []byte) (acc sdk.Account) {
-	err := ac.cdc.UnmarshalBinaryBare(bz, &acc)
-	if err != nil {
-		panic(err)
-	}
-	return
+func (ac *accountStoreCache) decodeAccount(bz []byte) (sdk.Account, sdk.Error) {
+	return DecodeAccount(ac.cdc, bz)
 }
 
 type cValue struct {
@@ -311,12 +583,21 @@ func (ac *accountCache) ClearCache() {
 	ac.cache = sync.Map{}
 }
 
-func (ac *accountCache) Cache() sdk.AccountCache {
+func (ac *accountCache) CacheWrap() sdk.AccountCache {
 	return &accountCache{
 		parent: ac,
 	}
 }
 
+// Deprecated: use CacheWrap
+func (ac *accountCache) Cache() sdk.AccountCache {
+	return ac.CacheWrap()
+}
+
+func (ac *accountCache) Discard() {
+	ac.cache = sync.Map{}
+}
+
 func (ac *accountCache) Write() {
 	// We need a copy of all of the keys.
 	// Not the best, but probably not a bottleneck depending.
@@ -333,19 +614,25 @@ func (ac *accountCache) Write() {
 
 	sort.Strings(keys)
 
-	// TODO: Consider allowing usage of Batch, which would allow the write to
-	// at least happen atomically.
-	for _, key := range keys {
+	values := make([]cValue, len(keys))
+	for i, key := range keys {
 		// value should exist here, so does not check ok
 		value, _ := ac.cache.Load(key)
-		cacheValue := value.(cValue)
+		values[i] = value.(cValue)
+	}
 
-		if cacheValue.deleted {
-			ac.parent.Delete(sdk.AccAddress(key))
-		} else if cacheValue.acc == nil {
-			// Skip, it already doesn't exist in parent.
-		} else {
-			ac.parent.SetAccount(sdk.AccAddress(key), cacheValue.acc)
+	if batchWriter, ok := ac.parent.(accountBatchWriter); ok {
+		batchWriter.writeBatch(keys, values)
+	} else {
+		for i, key := range keys {
+			cacheValue := values[i]
+			if cacheValue.deleted {
+				ac.parent.Delete(sdk.AccAddress(key))
+			} else if cacheValue.acc == nil {
+				// Skip, it already doesn't exist in parent.
+			} else {
+				ac.parent.SetAccount(sdk.AccAddress(key), cacheValue.acc)
+			}
 		}
 	}
 
@@ -353,6 +640,26 @@ func (ac *accountCache) Write() {
 	ac.cache = sync.Map{}
 }
 
+// dirtyAccounts returns the accounts changed in the cache and in its parent caches but not
+// written to the store yet, the deleted ones are nil.
+func (ac *accountCache) dirtyAccounts() map[string]sdk.Account {
+	dirty := make(map[string]sdk.Account)
+	if parent, ok := ac.parent.(*accountCache); ok {
+		dirty = parent.dirtyAccounts()
+	}
+	ac.cache.Range(func(key, value interface{}) bool {
+		if cacheValue := value.(cValue); cacheValue.dirty {
+			if cacheValue.deleted || cacheValue.acc == nil {
+				dirty[key.(string)] = nil
+			} else {
+				dirty[key.(string)] = cacheValue.acc.Clone()
+			}
+		}
+		return true
+	})
+	return dirty
+}
+
 func (ac *accountCache) getAccountFromCache(addr sdk.AccAddress) (acc sdk.Account) {
 	cacheVal, ok := ac.cache.Load(string(addr))
 	if !ok {
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -106,6 +107,141 @@ func TestAccountMapperRemoveAccount(t *testing.T) {
 	require.Equal(t, accSeq2, acc2.GetSequence())
 }
 
+func TestAccountNumberMigration(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+
+	// make context and mapper
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	require.Equal(t, int64(0), mapper.PeekNextAccountNumber(ctx))
+	require.Equal(t, int64(0), mapper.GetNextAccountNumber(ctx))
+	require.Equal(t, int64(1), mapper.PeekNextAccountNumber(ctx))
+
+	// the counter never goes back
+	require.NotNil(t, mapper.SetInitialAccountNumber(ctx, 0))
+	require.Nil(t, mapper.SetInitialAccountNumber(ctx, 100))
+	require.Equal(t, int64(100), mapper.GetNextAccountNumber(ctx))
+
+	_, err := mapper.ReserveAccountNumbers(ctx, 0)
+	require.NotNil(t, err)
+	first, err := mapper.ReserveAccountNumbers(ctx, 10)
+	require.Nil(t, err)
+	require.Equal(t, int64(101), first)
+	require.Equal(t, int64(111), mapper.PeekNextAccountNumber(ctx))
+
+	// an imported account with a number beyond the counter
+	acc := mapper.NewAccountWithAddress(ctx, sdk.AccAddress([]byte("addr1")))
+	require.Equal(t, int64(111), acc.GetAccountNumber())
+	acc.SetAccountNumber(500)
+	mapper.SetAccount(ctx, acc)
+	accountCache.Write()
+	require.Equal(t, int64(501), mapper.MigrateNextAccountNumber(ctx))
+	require.Equal(t, int64(501), mapper.PeekNextAccountNumber(ctx))
+}
+
+func TestDecodeAccount(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	addr := sdk.AccAddress([]byte("some-address"))
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	bz := mapper.encodeAccount(acc)
+
+	decoded, err := DecodeAccount(cdc, bz)
+	require.Nil(t, err)
+	require.Equal(t, addr, decoded.GetAddress())
+
+	// corrupted bytes
+	_, err = DecodeAccount(cdc, bz[:len(bz)/2])
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInvalidAccount, err.Code())
+
+	_, err = DecodeAccount(cdc, []byte{0xff, 0xff, 0xff})
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInvalidAccount, err.Code())
+
+	// oversized entry, only capped after the upgrade
+	oversized := mapper.encodeAccount(oversizedAccount(t, mapper, ctx, addr))
+	_, err = DecodeAccount(cdc, oversized)
+	require.Nil(t, err)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountSizeLimit, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.AccountSizeLimit)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	_, err = DecodeAccount(cdc, oversized)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInvalidAccount, err.Code())
+
+	// raw inspection of a corrupted entry
+	ctx.KVStore(capKey).Set(AddressStoreKey(addr), bz[:len(bz)/2])
+	raw, _, err := mapper.GetRawAccount(ctx, addr)
+	require.Equal(t, bz[:len(bz)/2], raw)
+	require.Equal(t, sdk.CodeInvalidAccount, err.Code())
+
+	// the store cache does not read it as a missing account
+	storeCache := NewAccountStoreCache(cdc, ms.GetKVStore(capKey), 10)
+	require.Panics(t, func() { storeCache.GetAccount(addr) })
+}
+
+// oversizedAccount returns an account holding enough denoms to be encoded in more than MaxAccountBytes
+func oversizedAccount(t *testing.T, mapper AccountKeeper, ctx sdk.Context, addr sdk.AccAddress) sdk.Account {
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	coins := make(sdk.Coins, MaxAccountBytes/64)
+	for i := range coins {
+		coins[i] = sdk.NewCoin(fmt.Sprintf("%060d", i), 1)
+	}
+	acc.SetCoins(coins)
+	require.True(t, len(mapper.encodeAccount(acc)) > MaxAccountBytes)
+	return acc
+}
+
+func TestSetAccountSizeLimit(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	addr := sdk.AccAddress([]byte("some-address"))
+	acc := oversizedAccount(t, mapper, ctx, addr)
+	coins := acc.GetCoins()
+
+	// written as is before the upgrade
+	mapper.SetAccount(ctx, acc)
+	require.NotNil(t, mapper.GetAccount(ctx, addr))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountSizeLimit, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.AccountSizeLimit)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	other := mapper.NewAccountWithAddress(ctx, sdk.AccAddress([]byte("other-address")))
+	other.SetCoins(coins)
+	require.Panics(t, func() { mapper.SetAccount(ctx, other) })
+	require.Nil(t, mapper.GetAccount(ctx, other.GetAddress()))
+
+	// a smaller account is still written
+	other.SetCoins(coins[:1])
+	mapper.SetAccount(ctx, other)
+	require.Equal(t, coins[:1], mapper.GetAccount(ctx, other.GetAddress()).GetCoins())
+}
+
 func BenchmarkAccountMapperGetAccountFound(b *testing.B) {
 	ms, capKey, _ := setupMultiStore()
 	cdc := codec.New()
@@ -129,3 +265,231 @@ func BenchmarkAccountMapperGetAccountFound(b *testing.B) {
 		mapper.GetAccount(ctx, sdk.AccAddress(arr))
 	}
 }
+
+func TestAccountStoreCacheCachedAddrs(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStoreCache := NewAccountStoreCache(cdc, ms.GetKVStore(capKey), 10)
+
+	addr1, addr2 := sdk.AccAddress("addr1"), sdk.AccAddress("addr2")
+	accountStoreCache.SetAccount(addr1, &BaseAccount{Address: addr1})
+	accountStoreCache.SetAccount(addr2, &BaseAccount{Address: addr2})
+	// touching an account makes it the most recently used
+	accountStoreCache.GetAccount(addr1)
+
+	cache := accountStoreCache.(interface{ CachedAddrs() []sdk.AccAddress })
+	require.Equal(t, []sdk.AccAddress{addr2, addr1}, cache.CachedAddrs())
+}
+
+func TestAccountCacheWriteBatch(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStore := ms.GetKVStore(capKey)
+	require.Implements(t, (*sdk.BatchKVStore)(nil), accountStore)
+	accountStoreCache := NewAccountStoreCache(cdc, accountStore, 10)
+
+	addr1, addr2 := sdk.AccAddress("addr1"), sdk.AccAddress("addr2")
+	accountCache := NewAccountCache(accountStoreCache)
+	accountCache.SetAccount(addr1, &BaseAccount{Address: addr1, Sequence: 1})
+	accountCache.SetAccount(addr2, &BaseAccount{Address: addr2, Sequence: 2})
+	accountCache.Write()
+
+	require.EqualValues(t, 1, accountStoreCache.GetAccount(addr1).GetSequence())
+	require.NotNil(t, accountStore.Get(AddressStoreKey(addr2)))
+
+	accountCache = NewAccountCache(accountStoreCache)
+	accountCache.Delete(addr1)
+	acc := accountCache.GetAccount(addr2)
+	require.NoError(t, acc.SetSequence(3))
+	accountCache.SetAccount(addr2, acc)
+	accountCache.Write()
+
+	require.Nil(t, accountStoreCache.GetAccount(addr1))
+	require.Nil(t, accountStore.Get(AddressStoreKey(addr1)))
+	require.EqualValues(t, 3, accountStoreCache.GetAccount(addr2).GetSequence())
+	stored, err := DecodeAccount(cdc, accountStore.Get(AddressStoreKey(addr2)))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, stored.GetSequence())
+}
+
+func TestAccountStoreCacheStats(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStore := ms.GetKVStore(capKey)
+	accountStoreCache := NewAccountStoreCacheWithLogger(cdc, accountStore, 2, log.NewNopLogger())
+	metered, ok := accountStoreCache.(MeteredAccountStoreCache)
+	require.True(t, ok)
+
+	addrs := []sdk.AccAddress{sdk.AccAddress("addr1"), sdk.AccAddress("addr2"), sdk.AccAddress("addr3")}
+	for i, addr := range addrs {
+		accountStore.Set(AddressStoreKey(addr), cdc.MustMarshalBinaryBare(&BaseAccount{Address: addr, Sequence: int64(i)}))
+	}
+
+	require.NotNil(t, accountStoreCache.GetAccount(addrs[0]))
+	require.NotNil(t, accountStoreCache.GetAccount(addrs[0]))
+	require.NotNil(t, accountStoreCache.GetAccount(addrs[1]))
+	require.Equal(t, AccountStoreCacheStats{Hits: 1, Misses: 2, Len: 2, Cap: 2}, metered.Stats())
+
+	// addr2 is the least recently used account
+	require.NotNil(t, accountStoreCache.GetAccount(addrs[0]))
+	require.NotNil(t, accountStoreCache.GetAccount(addrs[2]))
+	stats := metered.Stats()
+	require.Equal(t, AccountStoreCacheStats{Hits: 2, Misses: 3, Evictions: 1, Len: 2, Cap: 2}, stats)
+	require.Equal(t, 0.4, stats.HitRate())
+
+	accountStoreCache.ClearCache()
+	require.Equal(t, AccountStoreCacheStats{Hits: 2, Misses: 3, Evictions: 1, Cap: 2}, metered.Stats())
+}
+
+func TestAccountCacheDiscard(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountStore := ms.GetKVStore(capKey)
+	accountStoreCache := NewAccountStoreCache(cdc, accountStore, 10)
+	accountCache := NewAccountCache(accountStoreCache)
+
+	addr1, addr2 := sdk.AccAddress("addr1"), sdk.AccAddress("addr2")
+	accountCache.SetAccount(addr1, &BaseAccount{Address: addr1, Sequence: 1})
+
+	// the changes of a discarded cache wrap do not reach the parent
+	wrapped := accountCache.CacheWrap()
+	acc := wrapped.GetAccount(addr1)
+	require.NoError(t, acc.SetSequence(2))
+	wrapped.SetAccount(addr1, acc)
+	wrapped.SetAccount(addr2, &BaseAccount{Address: addr2})
+	require.EqualValues(t, 2, wrapped.GetAccount(addr1).GetSequence())
+	wrapped.Discard()
+	require.EqualValues(t, 1, wrapped.GetAccount(addr1).GetSequence())
+	wrapped.Write()
+	require.EqualValues(t, 1, accountCache.GetAccount(addr1).GetSequence())
+	require.Nil(t, accountCache.GetAccount(addr2))
+
+	// the written ones do
+	wrapped = accountCache.CacheWrap()
+	wrapped.Delete(addr1)
+	wrapped.Write()
+	require.Nil(t, accountCache.GetAccount(addr1))
+
+	accountCache.SetAccount(addr1, &BaseAccount{Address: addr1, Sequence: 3})
+	accountCache.Write()
+	require.EqualValues(t, 3, accountStoreCache.GetAccount(addr1).GetSequence())
+
+	// deleting from the store cache drops the cached account
+	accountStoreCache.Delete(addr1)
+	require.Nil(t, accountStoreCache.GetAccount(addr1))
+	require.Nil(t, accountStore.Get(AddressStoreKey(addr1)))
+}
+
+func TestIterateAccountsThroughCache(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	setSeq := func(ctx sdk.Context, addr byte, seq int64) {
+		acc := mapper.NewAccountWithAddress(ctx, sdk.AccAddress{addr})
+		require.NoError(t, acc.SetSequence(seq))
+		mapper.SetAccount(ctx, acc)
+	}
+	for _, addr := range []byte{1, 3, 5} {
+		setSeq(ctx, addr, 0)
+	}
+	accountCache.Write()
+
+	// un-flushed changes of the block and of the tx
+	setSeq(ctx, 3, 1)
+	mapper.RemoveAccount(ctx, mapper.GetAccount(ctx, sdk.AccAddress{5}))
+	setSeq(ctx, 2, 0)
+	setSeq(ctx, 6, 0)
+	txCtx := ctx.WithAccountCache(accountCache.CacheWrap())
+	setSeq(txCtx, 4, 0)
+	mapper.RemoveAccount(txCtx, mapper.GetAccount(txCtx, sdk.AccAddress{1}))
+
+	iterate := func(ctx sdk.Context, start sdk.AccAddress, limit int) (addrs []byte, seqs []int64) {
+		mapper.IterateAccountsFrom(ctx, start, func(acc sdk.Account) bool {
+			addrs = append(addrs, acc.GetAddress()[0])
+			seqs = append(seqs, acc.GetSequence())
+			return len(addrs) == limit
+		})
+		return
+	}
+	// only the store is iterated before the upgrade
+	addrs, seqs := iterate(txCtx, nil, 10)
+	require.Equal(t, []byte{1, 3, 5}, addrs)
+	require.Equal(t, []int64{0, 0, 0}, seqs)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountCacheIterate, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.AccountCacheIterate)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	addrs, seqs = iterate(txCtx, nil, 10)
+	require.Equal(t, []byte{2, 3, 4, 6}, addrs)
+	require.Equal(t, []int64{0, 1, 0, 0}, seqs)
+	addrs, _ = iterate(ctx, nil, 10)
+	require.Equal(t, []byte{1, 2, 3, 6}, addrs)
+	addrs, _ = iterate(txCtx, sdk.AccAddress{3}, 2)
+	require.Equal(t, []byte{3, 4}, addrs)
+
+	var all []byte
+	mapper.IterateAccounts(txCtx, func(acc sdk.Account) bool {
+		all = append(all, acc.GetAddress()[0])
+		return false
+	})
+	require.Equal(t, []byte{2, 3, 4, 6}, all)
+}
+
+// unbatchedKVStore hides the NewBatch of a store, its accounts are written one by one
+type unbatchedKVStore struct {
+	sdk.KVStore
+}
+
+// BenchmarkAccountCacheWrite writes and commits a block touching 5000 accounts, with and without
+// the batch of the store. The IAVL tree has no write batching so the batch does not lower the commit
+// latency, on a cacheKVStore it only takes the lock once.
+func BenchmarkAccountCacheWrite(b *testing.B) {
+	const touched = 5000
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+
+	run := func(b *testing.B, wrap func(sdk.KVStore) sdk.KVStore) {
+		ms, capKey, _ := setupMultiStore()
+		cms := ms.(sdk.CommitMultiStore)
+		storeCache := NewAccountStoreCache(cdc, wrap(ms.GetKVStore(capKey)), touched)
+		mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+		accs := make([]sdk.Account, touched)
+		for i := range accs {
+			accs[i] = mapper.proto()
+			accs[i].SetAddress(sdk.AccAddress([]byte(fmt.Sprintf("address-%05d", i))))
+		}
+
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			accountCache := NewAccountCache(storeCache)
+			for _, acc := range accs {
+				acc.SetCoins(sdk.Coins{sdk.NewCoin("foocoin", int64(n+1))})
+				accountCache.SetAccount(acc.GetAddress(), acc)
+			}
+			accountCache.Write()
+			cms.Commit()
+		}
+	}
+
+	iavl := func(store sdk.KVStore) sdk.KVStore { return store }
+	cacheKV := func(store sdk.KVStore) sdk.KVStore { return store.CacheWrap().(sdk.KVStore) }
+	unbatched := func(wrap func(sdk.KVStore) sdk.KVStore) func(sdk.KVStore) sdk.KVStore {
+		return func(store sdk.KVStore) sdk.KVStore { return unbatchedKVStore{wrap(store)} }
+	}
+	b.Run("iavl batch", func(b *testing.B) { run(b, iavl) })
+	b.Run("iavl one by one", func(b *testing.B) { run(b, unbatched(iavl)) })
+	b.Run("cachekv batch", func(b *testing.B) { run(b, cacheKV) })
+	b.Run("cachekv one by one", func(b *testing.B) { run(b, unbatched(cacheKV)) })
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	metricsPkg "github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics contains Metrics exposed by this package.
+type Metrics struct {
+	SigCacheHits   metricsPkg.Counter
+	SigCacheMisses metricsPkg.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+func PrometheusMetrics() *Metrics {
+	return &Metrics{
+		SigCacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "auth",
+			Name:      "sig_cache_hits",
+			Help:      "The number of signatures found verified in the cache",
+		}, []string{}),
+		SigCacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "auth",
+			Name:      "sig_cache_misses",
+			Help:      "The number of signatures verified as not found in the cache",
+		}, []string{}),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SigCacheHits:   discard.NewCounter(),
+		SigCacheMisses: discard.NewCounter(),
+	}
+}
+
+// AccountCacheMetrics contains the metrics of the LRU cache of the account store.
+type AccountCacheMetrics struct {
+	Hits      metricsPkg.Counter
+	Misses    metricsPkg.Counter
+	Evictions metricsPkg.Counter
+}
+
+// PrometheusAccountCacheMetrics returns AccountCacheMetrics build using Prometheus client library.
+func PrometheusAccountCacheMetrics() *AccountCacheMetrics {
+	return &AccountCacheMetrics{
+		Hits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "auth",
+			Name:      "account_cache_hits",
+			Help:      "The number of accounts found in the cache",
+		}, []string{}),
+		Misses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "auth",
+			Name:      "account_cache_misses",
+			Help:      "The number of accounts not found in the cache",
+		}, []string{}),
+		Evictions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "auth",
+			Name:      "account_cache_evictions",
+			Help:      "The number of accounts evicted from the cache to make room for others",
+		}, []string{}),
+	}
+}
+
+// NopAccountCacheMetrics returns no-op AccountCacheMetrics.
+func NopAccountCacheMetrics() *AccountCacheMetrics {
+	return &AccountCacheMetrics{
+		Hits:      discard.NewCounter(),
+		Misses:    discard.NewCounter(),
+		Evictions: discard.NewCounter(),
+	}
+}
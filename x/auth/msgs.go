@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const RouteKey = "auth"
+
+// MsgUpdateMultisig sets the keys and the threshold of a multisig account. Sent by another
+// account, it turns that account into a multisig account of the same address.
+type MsgUpdateMultisig struct {
+	Address   sdk.AccAddress  `json:"address"`
+	PubKeys   []crypto.PubKey `json:"pub_keys"`
+	Threshold int             `json:"threshold"`
+}
+
+var _ sdk.Msg = MsgUpdateMultisig{}
+
+func NewMsgUpdateMultisig(addr sdk.AccAddress, pubKeys []crypto.PubKey, threshold int) MsgUpdateMultisig {
+	return MsgUpdateMultisig{Address: addr, PubKeys: pubKeys, Threshold: threshold}
+}
+
+// nolint
+func (msg MsgUpdateMultisig) Route() string { return RouteKey }
+func (msg MsgUpdateMultisig) Type() string  { return "update_multisig" }
+func (msg MsgUpdateMultisig) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+func (msg MsgUpdateMultisig) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// Implements Msg.
+func (msg MsgUpdateMultisig) ValidateBasic() sdk.Error {
+	if len(msg.Address) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	return ValidateMultisigKeys(msg.PubKeys, msg.Threshold)
+}
+
+// Implements Msg.
+func (msg MsgUpdateMultisig) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxMultisigPubKeys is the most keys a multisig account can have
+const MaxMultisigPubKeys = 20
+
+var _ sdk.Account = (*MultisigAccount)(nil)
+
+// MultisigAccount is an account signed by Threshold of its PubKeys. Unlike an account with an amino
+// multisig pubkey, its address does not depend on the keys, so they and the threshold can be
+// changed with MsgUpdateMultisig.
+type MultisigAccount struct {
+	*BaseAccount
+
+	PubKeys   []crypto.PubKey `json:"pub_keys"`
+	Threshold int             `json:"threshold"`
+}
+
+// NewMultisigAccount turns acc into a multisig account of the keys
+func NewMultisigAccount(acc *BaseAccount, pubKeys []crypto.PubKey, threshold int) *MultisigAccount {
+	return &MultisigAccount{
+		BaseAccount: acc,
+		PubKeys:     pubKeys,
+		Threshold:   threshold,
+	}
+}
+
+// ValidateMultisigKeys returns an error if the keys and threshold cannot sign for a multisig account
+func ValidateMultisigKeys(pubKeys []crypto.PubKey, threshold int) sdk.Error {
+	if len(pubKeys) == 0 || len(pubKeys) > MaxMultisigPubKeys {
+		return sdk.ErrInvalidPubKey("a multisig account should have 1 to 20 keys")
+	}
+	if threshold <= 0 || threshold > len(pubKeys) {
+		return sdk.ErrInvalidPubKey("the threshold of a multisig account should be between 1 and its number of keys")
+	}
+	seen := make(map[string]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		if pubKey == nil {
+			return sdk.ErrInvalidPubKey("nil key in a multisig account")
+		}
+		if _, ok := pubKey.(multisig.PubKeyMultisigThreshold); ok {
+			return sdk.ErrInvalidPubKey("a multisig account cannot have multisig keys")
+		}
+		if seen[string(pubKey.Bytes())] {
+			return sdk.ErrInvalidPubKey("duplicated key in a multisig account")
+		}
+		seen[string(pubKey.Bytes())] = true
+	}
+	return nil
+}
+
+// GetPubKey returns the threshold key of the account, which verifies its multisignatures
+func (acc *MultisigAccount) GetPubKey() crypto.PubKey {
+	if ValidateMultisigKeys(acc.PubKeys, acc.Threshold) != nil {
+		// no signature is valid for an account stored with broken keys
+		return multisig.PubKeyMultisigThreshold{K: uint(len(acc.PubKeys) + 1), PubKeys: acc.PubKeys}
+	}
+	return multisig.NewPubKeyMultisigThreshold(acc.Threshold, acc.PubKeys)
+}
+
+// SetPubKey only accepts the key of the account, its keys are changed with MsgUpdateMultisig
+func (acc *MultisigAccount) SetPubKey(pubKey crypto.PubKey) error {
+	if pubKey == nil || !pubKey.Equals(acc.GetPubKey()) {
+		return errors.New("cannot override the keys of a MultisigAccount")
+	}
+	return nil
+}
+
+// Implements sdk.Account.
+func (acc *MultisigAccount) Clone() sdk.Account {
+	pubKeys := make([]crypto.PubKey, len(acc.PubKeys))
+	copy(pubKeys, acc.PubKeys)
+	return &MultisigAccount{
+		BaseAccount: acc.BaseAccount.Clone().(*BaseAccount),
+		PubKeys:     pubKeys,
+		Threshold:   acc.Threshold,
+	}
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func newMultisigTestTx(ctx sdk.Context, msgs []sdk.Msg, keys []crypto.PubKey, signers []crypto.PrivKey, accNum int64, seq int64) sdk.Tx {
+	signBytes := StdSignBytes(ctx.ChainID(), accNum, seq, msgs, "", 0, nil)
+	msig := multisig.NewMultisig(len(keys))
+	for _, priv := range signers {
+		sig, err := priv.Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+		if err := msig.AddSignatureFromPubKey(sig, priv.PubKey(), keys); err != nil {
+			panic(err)
+		}
+	}
+	sigs := []StdSignature{{PubKey: multisig.NewPubKeyMultisigThreshold(2, keys), Signature: msig.Marshal(), AccountNumber: accNum, Sequence: seq}}
+	return NewStdTx(msgs, sigs, "", 0, nil)
+}
+
+func TestMultisigAccount(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewAnteHandler(mapper)
+	handler := NewHandler(mapper)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	ctx = ctx.WithBlockHeight(1)
+
+	var privs []crypto.PrivKey
+	var keys []crypto.PubKey
+	for i := 0; i < 4; i++ {
+		priv, _ := privAndAddr()
+		privs = append(privs, priv)
+		keys = append(keys, priv.PubKey())
+	}
+	// an account of an amino multisig key of the first 3 keys
+	addr := sdk.AccAddress(multisig.NewPubKeyMultisigThreshold(2, keys[:3]).Address())
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc)
+
+	msg := NewMsgUpdateMultisig(addr, keys[1:], 2)
+	require.Nil(t, msg.ValidateBasic())
+	tx := newMultisigTestTx(ctx, []sdk.Msg{msg}, keys[:3], privs[:2], 0, 0)
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	require.False(t, handler(ctx, msg).IsOK())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.MultisigAccounts, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.MultisigAccounts)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	tx = newMultisigTestTx(ctx, []sdk.Msg{msg}, keys[:3], privs[:2], 0, 1)
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	require.True(t, handler(ctx, msg).IsOK())
+
+	// the account keeps its address, coins and sequence, and is stored as a multisig account
+	accountCache.Write()
+	stored := mapper.GetAccount(ctx.WithAccountCache(getAccountCache(cdc, ms, capKey)), addr)
+	multisigAcc, ok := stored.(*MultisigAccount)
+	require.True(t, ok)
+	require.Equal(t, keys[1:], multisigAcc.PubKeys)
+	require.Equal(t, 2, multisigAcc.Threshold)
+	require.Equal(t, newCoins(), multisigAcc.GetCoins())
+	require.EqualValues(t, 2, multisigAcc.GetSequence())
+
+	// the signatures of the former keys are rejected, the new keys sign
+	msgs := []sdk.Msg{newTestMsg(addr)}
+	checkInvalidTx(t, anteHandler, ctx, newMultisigTestTx(ctx, msgs, keys[:3], privs[:2], 0, 2), sdk.RunTxModeDeliver, sdk.CodeUnauthorized)
+	checkInvalidTx(t, anteHandler, ctx, newMultisigTestTx(ctx, msgs, keys[1:], privs[3:], 0, 2), sdk.RunTxModeDeliver, sdk.CodeUnauthorized)
+	checkValidTx(t, anteHandler, ctx, newMultisigTestTx(ctx, msgs, keys[1:], privs[2:], 0, 2), sdk.RunTxModeDeliver)
+
+	// the threshold can be changed as well
+	msg = NewMsgUpdateMultisig(addr, keys[1:], 1)
+	checkValidTx(t, anteHandler, ctx, newMultisigTestTx(ctx, []sdk.Msg{msg}, keys[1:], privs[1:3], 0, 3), sdk.RunTxModeDeliver)
+	require.True(t, handler(ctx, msg).IsOK())
+	checkValidTx(t, anteHandler, ctx, newMultisigTestTx(ctx, msgs, keys[1:], privs[3:], 0, 4), sdk.RunTxModeDeliver)
+
+	require.False(t, handler(ctx, NewMsgUpdateMultisig(sdk.AccAddress([]byte("unknown")), keys, 1)).IsOK())
+}
+
+func TestMsgUpdateMultisigValidateBasic(t *testing.T) {
+	_, addr := privAndAddr()
+	var keys []crypto.PubKey
+	for i := 0; i <= MaxMultisigPubKeys; i++ {
+		priv, _ := privAndAddr()
+		keys = append(keys, priv.PubKey())
+	}
+
+	require.Nil(t, NewMsgUpdateMultisig(addr, keys[:3], 3).ValidateBasic())
+	require.NotNil(t, NewMsgUpdateMultisig(addr[:10], keys[:3], 2).ValidateBasic())
+	require.NotNil(t, NewMsgUpdateMultisig(addr, nil, 0).ValidateBasic())
+	require.NotNil(t, NewMsgUpdateMultisig(addr, keys[:3], 0).ValidateBasic())
+	require.NotNil(t, NewMsgUpdateMultisig(addr, keys[:3], 4).ValidateBasic())
+	require.NotNil(t, NewMsgUpdateMultisig(addr, keys, 2).ValidateBasic())
+	require.NotNil(t, NewMsgUpdateMultisig(addr, []crypto.PubKey{keys[0], keys[0]}, 1).ValidateBasic())
+	nested := multisig.NewPubKeyMultisigThreshold(1, keys[:2])
+	require.NotNil(t, NewMsgUpdateMultisig(addr, []crypto.PubKey{nested, keys[2]}, 1).ValidateBasic())
+}
@@ -0,0 +1,11 @@
+package auth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func RegisterUpgradeBeginBlocker(am AccountKeeper) {
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.SupplyIndex, func(ctx sdk.Context) {
+		am.InitSupplyIndex(ctx)
+	})
+}
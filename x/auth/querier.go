@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryAccounts      = "accounts"
+	QueryLaneSequences = "laneSequences"
+	QueryAccount       = "account"
+
+	DefaultAccountsPageLimit = 100
+	MaxAccountsPageLimit     = 1000
+)
+
+// QueryAccountsParams selects a page of accounts in address order. After is the cursor,
+// the page starts right after that address, leave it empty to get the first page.
+type QueryAccountsParams struct {
+	After sdk.AccAddress `json:"after"`
+	Limit int            `json:"limit"`
+}
+
+// AccountsPage is a page of accounts, Next is the cursor of the following page and is
+// empty once the last account is returned.
+type AccountsPage struct {
+	Accounts []sdk.Account  `json:"accounts"`
+	Next     sdk.AccAddress `json:"next"`
+}
+
+// QueryLaneSequencesParams selects the account whose lane sequences are queried
+type QueryLaneSequencesParams struct {
+	Address sdk.AccAddress `json:"address"`
+}
+
+// QueryAccountParams selects the account queried with its proof, the height of the request
+// selects the version of the store, 0 is the latest one a proof is available for
+type QueryAccountParams struct {
+	Address sdk.AccAddress `json:"address"`
+}
+
+// AccountWithProof is an account with the merkle proof of its value in the account store at
+// Height, to verify against the app hash of the header at Height+1
+type AccountWithProof struct {
+	Account sdk.Account   `json:"account"`
+	Height  int64         `json:"height"`
+	Proof   *merkle.Proof `json:"proof"`
+}
+
+// creates a querier for auth REST endpoints
+func NewQuerier(k AccountKeeper, cdc *codec.Codec) sdk.Querier {
+	return NewQuerierWithProofs(k, cdc, nil)
+}
+
+// NewQuerierWithProofs creates a querier that also serves the accounts with their proofs, it
+// reads them from the committed multistore, the context of a query only has cached stores.
+func NewQuerierWithProofs(k AccountKeeper, cdc *codec.Codec, committed sdk.Queryable) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryAccounts:
+			return queryAccounts(ctx, cdc, req, k)
+		case QueryLaneSequences:
+			return queryLaneSequences(ctx, cdc, req, k)
+		case QueryAccount:
+			return queryAccount(cdc, req, k, committed)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown auth query endpoint")
+		}
+	}
+}
+
+func queryAccounts(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k AccountKeeper) (res []byte, err sdk.Error) {
+	var params QueryAccountsParams
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+	if params.Limit < 0 || params.Limit > MaxAccountsPageLimit {
+		return nil, sdk.ErrUnknownRequest("limit should be between 0 and 1000")
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultAccountsPageLimit
+	}
+
+	var start sdk.AccAddress
+	if len(params.After) != 0 {
+		// the smallest address greater than the cursor
+		start = append(append(sdk.AccAddress{}, params.After...), 0x00)
+	}
+
+	page := AccountsPage{Accounts: make([]sdk.Account, 0, params.Limit)}
+	k.IterateAccountsFrom(ctx, start, func(acc sdk.Account) bool {
+		if len(page.Accounts) == params.Limit {
+			page.Next = page.Accounts[len(page.Accounts)-1].GetAddress()
+			return true
+		}
+		page.Accounts = append(page.Accounts, acc)
+		return false
+	})
+
+	res, errRes = codec.MarshalJSONIndent(cdc, page)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryLaneSequences(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k AccountKeeper) (res []byte, err sdk.Error) {
+	var params QueryLaneSequencesParams
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+
+	acc := k.GetAccount(ctx, params.Address)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(params.Address.String())
+	}
+	res, errRes = codec.MarshalJSONIndent(cdc, k.GetLaneSequences(ctx, acc))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryAccount(cdc *codec.Codec, req abci.RequestQuery, k AccountKeeper, committed sdk.Queryable) (res []byte, err sdk.Error) {
+	if committed == nil {
+		return nil, sdk.ErrUnknownRequest("account proofs are not served by this node")
+	}
+	var params QueryAccountParams
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+
+	storeRes := committed.Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/%s/key", k.key.Name()),
+		Data:   AddressStoreKey(params.Address),
+		Height: req.Height,
+		Prove:  true,
+	})
+	if !storeRes.IsOK() || storeRes.Proof == nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to query the account store: %s", storeRes.Log))
+	}
+	if storeRes.Value == nil {
+		return nil, sdk.ErrUnknownAddress(params.Address.String())
+	}
+	acc, decodeErr := DecodeAccount(cdc, storeRes.Value)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	res, errRes = codec.MarshalJSONIndent(cdc, AccountWithProof{Account: acc, Height: storeRes.Height, Proof: storeRes.Proof})
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	codec "github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestQueryAccounts(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	for i := byte(5); i > 0; i-- {
+		mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, sdk.AccAddress([]byte{i})))
+	}
+	accountCache.Write()
+
+	querier := NewQuerier(mapper, cdc)
+	query := func(params QueryAccountsParams) (AccountsPage, sdk.Error) {
+		req := abci.RequestQuery{Data: cdc.MustMarshalJSON(params)}
+		bz, err := querier(ctx, []string{QueryAccounts}, req)
+		if err != nil {
+			return AccountsPage{}, err
+		}
+		var page AccountsPage
+		cdc.MustUnmarshalJSON(bz, &page)
+		return page, nil
+	}
+
+	var addrs []sdk.AccAddress
+	var cursor sdk.AccAddress
+	for pages := 0; ; pages++ {
+		require.True(t, pages < 3)
+		page, err := query(QueryAccountsParams{After: cursor, Limit: 2})
+		require.Nil(t, err)
+		for _, acc := range page.Accounts {
+			addrs = append(addrs, acc.GetAddress())
+		}
+		if len(page.Next) == 0 {
+			break
+		}
+		cursor = page.Next
+	}
+	require.Equal(t, []sdk.AccAddress{{1}, {2}, {3}, {4}, {5}}, addrs)
+
+	page, err := query(QueryAccountsParams{})
+	require.Nil(t, err)
+	require.Len(t, page.Accounts, 5)
+	require.Empty(t, page.Next)
+
+	page, err = query(QueryAccountsParams{After: sdk.AccAddress{5}})
+	require.Nil(t, err)
+	require.Empty(t, page.Accounts)
+
+	_, err = query(QueryAccountsParams{Limit: MaxAccountsPageLimit + 1})
+	require.NotNil(t, err)
+}
+
+func TestQueryLaneSequences(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	require.NoError(t, acc.SetSequence(4))
+	mapper.SetAccount(ctx, acc)
+	mapper.setLaneSequence(ctx, addr, 3, 7)
+	// the lanes of an account whose address starts with the same bytes are not returned
+	mapper.setLaneSequence(ctx, sdk.AccAddress([]byte("addr12")), 1, 9)
+
+	querier := NewQuerier(mapper, cdc)
+	req := abci.RequestQuery{Data: cdc.MustMarshalJSON(QueryLaneSequencesParams{Address: addr})}
+	bz, err := querier(ctx, []string{QueryLaneSequences}, req)
+	require.Nil(t, err)
+	var seqs []LaneSequence
+	cdc.MustUnmarshalJSON(bz, &seqs)
+	require.Equal(t, []LaneSequence{{Lane: 0, Sequence: 4}, {Lane: 3, Sequence: 7}}, seqs)
+
+	req = abci.RequestQuery{Data: cdc.MustMarshalJSON(QueryLaneSequencesParams{Address: sdk.AccAddress([]byte("addr2"))})}
+	_, err = querier(ctx, []string{QueryLaneSequences}, req)
+	require.NotNil(t, err)
+}
+
+func TestQueryAccountWithProof(t *testing.T) {
+	// the stores of setupMultiStore share the same db, so the versions of one overwrite the other
+	capKey := sdk.NewKVStoreKey("capkey")
+	var cms sdk.CommitMultiStore = store.NewCommitMultiStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, cms, capKey)
+	ctx := sdk.NewContext(cms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	require.NoError(t, acc.SetSequence(4))
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("foocoin", 10)}))
+	mapper.SetAccount(ctx, acc)
+	accountCache.Write()
+	commitID := cms.Commit()
+
+	query := func(querier sdk.Querier, addr sdk.AccAddress) (AccountWithProof, sdk.Error) {
+		req := abci.RequestQuery{Data: cdc.MustMarshalJSON(QueryAccountParams{Address: addr}), Height: commitID.Version}
+		bz, err := querier(ctx, []string{QueryAccount}, req)
+		if err != nil {
+			return AccountWithProof{}, err
+		}
+		var res AccountWithProof
+		cdc.MustUnmarshalJSON(bz, &res)
+		return res, nil
+	}
+
+	_, err := query(NewQuerier(mapper, cdc), addr)
+	require.NotNil(t, err)
+
+	querier := NewQuerierWithProofs(mapper, cdc, cms.(sdk.Queryable))
+	res, err := query(querier, addr)
+	require.Nil(t, err)
+	require.Equal(t, commitID.Version, res.Height)
+	require.Equal(t, acc, res.Account)
+
+	// the proof chains the account to the app hash of the commit
+	prt := store.DefaultProofRuntime()
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(capKey.Name()), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(AddressStoreKey(addr), merkle.KeyEncodingURL)
+	require.NoError(t, prt.VerifyValue(res.Proof, commitID.Hash, kp.String(), cdc.MustMarshalBinaryBare(acc)))
+
+	_, err = query(querier, sdk.AccAddress([]byte("addr2")))
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeUnknownAddress, err.Code())
+}
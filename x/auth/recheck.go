@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewReCheckFilter returns a ReCheckFilter evicting the txs of the mempool which can no longer be executed:
+// the txs expired at the next block, and the txs whose signers are gone or whose sequences are already used.
+// It only reads the accounts, the AnteHandler still checks the txs it keeps.
+func NewReCheckFilter(am AccountKeeper) sdk.ReCheckFilter {
+	return func(ctx sdk.Context, tx sdk.Tx) sdk.Result {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return sdk.ErrInternal("tx must be StdTx").Result()
+		}
+		if stdTx.ValidUntilHeight != 0 && ctx.BlockHeight()+1 > stdTx.ValidUntilHeight {
+			return sdk.ErrTxExpired(fmt.Sprintf("tx is valid until height %d, the block height is %d",
+				stdTx.ValidUntilHeight, ctx.BlockHeight()+1)).Result()
+		}
+
+		stdSigs := stdTx.GetSignatures()
+		for i, addr := range stdTx.GetSigners() {
+			acc := am.GetAccount(ctx, addr)
+			if acc == nil {
+				return sdk.ErrUnknownAddress(addr.String()).Result()
+			}
+			if i >= len(stdSigs) {
+				break
+			}
+			if seq := am.GetLaneSequence(ctx, acc, stdSigs[i].Lane); stdSigs[i].Sequence < seq {
+				return sdk.ErrInvalidSequence(fmt.Sprintf("Sequence %d is already used, the next one is %d",
+					stdSigs[i].Sequence, seq)).Result()
+			}
+		}
+		return sdk.Result{}
+	}
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestReCheckFilter(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	filter := NewReCheckFilter(mapper)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeReCheck, log.NewNopLogger()).WithAccountCache(accountCache)
+	ctx = ctx.WithBlockHeight(5)
+
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	require.Nil(t, acc1.SetSequence(2))
+	mapper.SetAccount(ctx, acc1)
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+
+	// the txs which can still be included are kept
+	require.True(t, filter(ctx, newTestValidUntilTx(ctx, msgs, priv1, 0, 2, 0)).IsOK())
+	require.True(t, filter(ctx, newTestValidUntilTx(ctx, msgs, priv1, 0, 2, 6)).IsOK())
+	require.True(t, filter(ctx, newTestValidUntilTx(ctx, msgs, priv1, 0, 3, 0)).IsOK())
+
+	// the txs expired at the next block are evicted
+	res := filter(ctx, newTestValidUntilTx(ctx, msgs, priv1, 0, 2, 5))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeTxExpired), res.Code)
+
+	// the txs whose sequences are used are evicted
+	res = filter(ctx, newTestValidUntilTx(ctx, msgs, priv1, 0, 1, 0))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence), res.Code)
+
+	// the txs of unknown accounts are evicted
+	res = filter(ctx, newTestValidUntilTx(ctx, []sdk.Msg{newTestMsg(addr2)}, priv2, 1, 0, 0))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownAddress), res.Code)
+}
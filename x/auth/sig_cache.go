@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/metrics"
+)
+
+// DefaultSigCacheSize is the number of verified signatures kept by default,
+// enough for the txs of a few full blocks
+const DefaultSigCacheSize = 30000
+
+// SigCache remembers the signatures successfully verified, so the signature of a tx
+// verified in PreCheckTx/CheckTx is not verified again in DeliverTx.
+// It is safe for concurrent use.
+type SigCache struct {
+	cache   *lru.Cache
+	Metrics *metrics.Metrics
+}
+
+func NewSigCache(size int) *SigCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &SigCache{
+		cache:   cache,
+		Metrics: metrics.NopMetrics(),
+	}
+}
+
+func (c *SigCache) EnablePrometheusMetrics() {
+	c.Metrics = metrics.PrometheusMetrics()
+}
+
+// the key is made of the pubkey and the hash of the sign bytes, the signature is the value
+func sigCacheKey(pubKey crypto.PubKey, signBytes []byte) string {
+	hash := sha256.Sum256(signBytes)
+	return string(append(pubKey.Bytes(), hash[:]...))
+}
+
+// VerifyBytes returns the same as pubKey.VerifyBytes, without verifying the signatures
+// found in the cache again
+func (c *SigCache) VerifyBytes(pubKey crypto.PubKey, signBytes []byte, sig []byte) bool {
+	key := sigCacheKey(pubKey, signBytes)
+	if cached, ok := c.cache.Get(key); ok && bytes.Equal(cached.([]byte), sig) {
+		c.Metrics.SigCacheHits.Add(1)
+		return true
+	}
+	c.Metrics.SigCacheMisses.Add(1)
+	if !pubKey.VerifyBytes(signBytes, sig) {
+		return false
+	}
+	c.cache.Add(key, sig)
+	return true
+}
+
+// NewSigVerifyPreChecker returns a PreChecker verifying the signatures of a StdTx with the pubkeys
+// of the signer accounts, or the pubkeys they carry if the accounts have none yet, and filling sigCache,
+// so the AnteHandler built with the same cache does not verify them again. A tx whose signatures
+// cannot be verified is rejected, so it is not cached as prechecked.
+func NewSigVerifyPreChecker(am AccountKeeper, sigCache *SigCache) sdk.PreChecker {
+	return func(ctx sdk.Context, txBytes []byte, tx sdk.Tx) sdk.Result {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return sdk.ErrInternal("tx must be StdTx").Result()
+		}
+		if err := validateBasic(stdTx); err != nil {
+			return err.Result()
+		}
+
+		stdSigs := stdTx.GetSignatures()
+		signerAccs, res := getSignerAccs(ctx, am, stdTx.GetSigners())
+		if !res.IsOK() {
+			return res
+		}
+		signBytesList := getSignBytesList(ctx.ChainID(), stdTx, stdSigs)
+		for i, sig := range stdSigs {
+			pubKey, res := processPubKey(signerAccs[i], sig, false)
+			if !res.IsOK() {
+				return res
+			}
+			if !sigCache.VerifyBytes(pubKey, signBytesList[i], sig.Signature) {
+				return sdk.ErrUnauthorized("signature verification failed").Result()
+			}
+		}
+		return sdk.Result{}
+	}
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSigCache(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	sigCache := NewSigCache(DefaultSigCacheSize)
+	hits, misses := generic.NewCounter("hits"), generic.NewCounter("misses")
+	sigCache.Metrics.SigCacheHits = hits
+	sigCache.Metrics.SigCacheMisses = misses
+	preChecker := NewSigVerifyPreChecker(mapper, sigCache)
+	anteHandler := NewAnteHandlerWithSigCache(mapper, sigCache)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeCheck, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	mapper.SetAccount(ctx, acc1)
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+
+	// the signature verified in PreCheckTx is not verified again
+	tx := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	require.True(t, preChecker(ctx, nil, tx).IsOK())
+	require.Equal(t, float64(0), hits.Value())
+	require.Equal(t, float64(1), misses.Value())
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	require.Equal(t, float64(1), hits.Value())
+	require.Equal(t, float64(1), misses.Value())
+
+	// another signature of the same sign bytes is verified
+	stdTx := tx.(StdTx)
+	stdTx.Signatures[0].Signature = append([]byte{}, stdTx.Signatures[0].Signature...)
+	stdTx.Signatures[0].Signature[0] ^= 0xff
+	require.False(t, preChecker(ctx, nil, stdTx).IsOK())
+	require.Equal(t, float64(1), hits.Value())
+	require.Equal(t, float64(2), misses.Value())
+
+	// the signatures failing the verification are not cached
+	tx = newTestTxWithSignBytes(msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{1}, []byte("bad sign bytes"), "")
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeCheck, sdk.CodeUnauthorized)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeCheck, sdk.CodeUnauthorized)
+	require.Equal(t, float64(1), hits.Value())
+	require.Equal(t, float64(4), misses.Value())
+}
+
+func TestSigCacheMismatchedKey(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	sigCache := NewSigCache(DefaultSigCacheSize)
+	preChecker := NewSigVerifyPreChecker(mapper, sigCache)
+	anteHandler := NewAnteHandlerWithSigCache(mapper, sigCache)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeCheck, log.NewNopLogger()).WithAccountCache(accountCache).WithBlockHeight(1)
+
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetPubKey(priv1.PubKey())
+	mapper.SetAccount(ctx, acc1)
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addr2))
+
+	// a tx of addr1 signed by the key of addr2
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	tx := newTestTx(ctx, msgs, []crypto.PrivKey{priv2}, []int64{0}, []int64{0})
+	require.False(t, preChecker(ctx, nil, tx).IsOK())
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeCheckAfterPre, sdk.CodeUnauthorized)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliverAfterPre, sdk.CodeUnauthorized)
+
+	// the signature without pubkey is verified with the pubkey of the account
+	stdTx := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0}).(StdTx)
+	stdTx.Signatures[0].PubKey = nil
+	require.True(t, preChecker(ctx, nil, stdTx).IsOK())
+	checkValidTx(t, anteHandler, ctx, stdTx, sdk.RunTxModeDeliverAfterPre)
+
+	// the account of addr2 has no pubkey yet, the tx must carry it
+	msgs = []sdk.Msg{newTestMsg(addr2)}
+	stdTx = newTestTx(ctx, msgs, []crypto.PrivKey{priv2}, []int64{1}, []int64{0}).(StdTx)
+	stdTx.Signatures[0].PubKey = nil
+	require.False(t, preChecker(ctx, nil, stdTx).IsOK())
+	checkInvalidTx(t, anteHandler, ctx, stdTx, sdk.RunTxModeDeliverAfterPre, sdk.CodeInvalidPubKey)
+}
@@ -22,6 +22,8 @@ type StdTx struct {
 	Memo       string         `json:"memo"`
 	Source     int64          `json:"source"`
 	Data       []byte         `json:"data"`
+	// the tx is only valid in the blocks up to this height, 0 is valid forever
+	ValidUntilHeight int64 `json:"valid_until_height,omitempty"`
 }
 
 func NewStdTx(msgs []sdk.Msg, sigs []StdSignature, memo string, source int64, data []byte) StdTx {
@@ -66,6 +68,16 @@ func (tx StdTx) GetSource() int64 { return tx.Source }
 //nolint
 func (tx StdTx) GetData() []byte { return tx.Data }
 
+//nolint
+func (tx StdTx) GetValidUntilHeight() int64 { return tx.ValidUntilHeight }
+
+// WithValidUntilHeight returns a copy of the tx valid in the blocks up to height, the signatures
+// cover the height so it is set before signing.
+func (tx StdTx) WithValidUntilHeight(height int64) StdTx {
+	tx.ValidUntilHeight = height
+	return tx
+}
+
 // Signatures returns the signature of signers who signed the Msg.
 // GetSignatures returns the signature of signers who signed the Msg.
 // CONTRACT: Length returned is same as length of
@@ -82,31 +94,47 @@ func (tx StdTx) GetSignatures() []StdSignature { return tx.Signatures }
 // It includes the result of msg.GetSignBytes(),
 // as well as the ChainID (prevent cross chain replay)
 // and the Sequence numbers for each signature (prevent
-// inchain replay and enforce tx ordering per account).
+// inchain replay and enforce tx ordering per account lane).
+// Lane and ValidUntilHeight are omitted when 0, so the sign bytes of the txs without them are unchanged.
 type StdSignDoc struct {
-	AccountNumber int64             `json:"account_number"`
-	ChainID       string            `json:"chain_id"`
-	Memo          string            `json:"memo"`
-	Msgs          []json.RawMessage `json:"msgs"`
-	Sequence      int64             `json:"sequence"`
-	Source        int64             `json:"source"`
-	Data          []byte            `json:"data"`
+	AccountNumber    int64             `json:"account_number"`
+	ChainID          string            `json:"chain_id"`
+	Memo             string            `json:"memo"`
+	Msgs             []json.RawMessage `json:"msgs"`
+	Lane             int64             `json:"lane,omitempty"`
+	Sequence         int64             `json:"sequence"`
+	Source           int64             `json:"source"`
+	Data             []byte            `json:"data"`
+	ValidUntilHeight int64             `json:"valid_until_height,omitempty"`
 }
 
 // StdSignBytes returns the bytes to sign for a transaction.
 func StdSignBytes(chainID string, accnum int64, sequence int64, msgs []sdk.Msg, memo string, source int64, data []byte) []byte {
+	return StdSignBytesWithLane(chainID, accnum, 0, sequence, msgs, memo, source, data)
+}
+
+// StdSignBytesWithLane returns the bytes to sign for a transaction ordered by the sequence of a lane.
+func StdSignBytesWithLane(chainID string, accnum int64, lane int64, sequence int64, msgs []sdk.Msg, memo string, source int64, data []byte) []byte {
+	return StdSignBytesWithValidUntil(chainID, accnum, lane, sequence, 0, msgs, memo, source, data)
+}
+
+// StdSignBytesWithValidUntil returns the bytes to sign for a transaction only valid up to validUntilHeight.
+func StdSignBytesWithValidUntil(chainID string, accnum int64, lane int64, sequence int64, validUntilHeight int64,
+	msgs []sdk.Msg, memo string, source int64, data []byte) []byte {
 	var msgsBytes []json.RawMessage
 	for _, msg := range msgs {
 		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
 	}
 	bz, err := msgCdc.MarshalJSON(StdSignDoc{
-		AccountNumber: accnum,
-		ChainID:       chainID,
-		Memo:          memo,
-		Msgs:          msgsBytes,
-		Sequence:      sequence,
-		Source:        source,
-		Data:          data,
+		AccountNumber:    accnum,
+		ChainID:          chainID,
+		Memo:             memo,
+		Msgs:             msgsBytes,
+		Lane:             lane,
+		Sequence:         sequence,
+		Source:           source,
+		Data:             data,
+		ValidUntilHeight: validUntilHeight,
 	})
 	if err != nil {
 		panic(err)
@@ -120,6 +148,7 @@ type StdSignature struct {
 	Signature     []byte           `json:"signature"`
 	AccountNumber int64            `json:"account_number"`
 	Sequence      int64            `json:"sequence"`
+	Lane          int64            `json:"lane,omitempty"` // the sequence is of this lane of the account
 }
 
 // logic for standard transaction decoding
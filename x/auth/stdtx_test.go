@@ -48,3 +48,21 @@ func TestStdSignBytes(t *testing.T) {
 		require.Equal(t, tc.want, got, "Got unexpected result on test case i: %d", i)
 	}
 }
+
+func TestStdSignBytesWithLane(t *testing.T) {
+	msgs := []sdk.Msg{sdk.NewTestMsg(addr)}
+	// lane 0 signs the same bytes as before the lanes
+	require.Equal(t, StdSignBytes("1234", 3, 6, msgs, "memo", 0, nil), StdSignBytesWithLane("1234", 3, 0, 6, msgs, "memo", 0, nil))
+	got := string(StdSignBytesWithLane("1234", 3, 2, 6, msgs, "memo", 0, nil))
+	want := fmt.Sprintf("{\"account_number\":\"3\",\"chain_id\":\"1234\",\"data\":null,\"lane\":\"2\",\"memo\":\"memo\",\"msgs\":[[\"%s\"]],\"sequence\":\"6\",\"source\":\"0\"}", addr)
+	require.Equal(t, want, got)
+}
+
+func TestStdSignBytesWithValidUntil(t *testing.T) {
+	msgs := []sdk.Msg{sdk.NewTestMsg(addr)}
+	// a tx without valid until height signs the same bytes as before
+	require.Equal(t, StdSignBytesWithLane("1234", 3, 2, 6, msgs, "memo", 0, nil), StdSignBytesWithValidUntil("1234", 3, 2, 6, 0, msgs, "memo", 0, nil))
+	got := string(StdSignBytesWithValidUntil("1234", 3, 0, 6, 10, msgs, "memo", 0, nil))
+	want := fmt.Sprintf("{\"account_number\":\"3\",\"chain_id\":\"1234\",\"data\":null,\"memo\":\"memo\",\"msgs\":[[\"%s\"]],\"sequence\":\"6\",\"source\":\"0\",\"valid_until_height\":\"10\"}", addr)
+	require.Equal(t, want, got)
+}
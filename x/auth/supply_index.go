@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The total supply of each denom is maintained from sdk.SupplyIndex on: every account write adjusts
+// the totals by the change of the account coins, so the supply is known without scanning the accounts.
+
+var supplyPrefix = []byte("supply:")
+
+// SupplyStoreKey is the key of the total supply of denom in the account store
+func SupplyStoreKey(denom string) []byte {
+	return append(append([]byte{}, supplyPrefix...), denom...)
+}
+
+// GetTotalSupply returns the total amount of denom held by the accounts
+func (am AccountKeeper) GetTotalSupply(ctx sdk.Context, denom string) int64 {
+	bz := ctx.KVStore(am.key).Get(SupplyStoreKey(denom))
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// IterateTotalSupplies iterates over the total supply of the denoms held by an account, ordered by denom
+func (am AccountKeeper) IterateTotalSupplies(ctx sdk.Context, process func(denom string, amount int64) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(am.key), supplyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if process(string(iter.Key()[len(supplyPrefix):]), int64(binary.BigEndian.Uint64(iter.Value()))) {
+			return
+		}
+	}
+}
+
+func (am AccountKeeper) setTotalSupply(ctx sdk.Context, denom string, amount int64) {
+	store := ctx.KVStore(am.key)
+	if amount == 0 {
+		store.Delete(SupplyStoreKey(denom))
+		return
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(amount))
+	store.Set(SupplyStoreKey(denom), bz)
+}
+
+// updateSupply adjusts the total supplies by the change of the coins from oldAcc to newAcc,
+// either of them is nil if the account is created or removed
+func (am AccountKeeper) updateSupply(ctx sdk.Context, oldAcc, newAcc sdk.Account) {
+	var oldCoins, newCoins sdk.Coins
+	if oldAcc != nil {
+		oldCoins = oldAcc.GetCoins()
+	}
+	if newAcc != nil {
+		newCoins = newAcc.GetCoins()
+	}
+	for _, diff := range newCoins.Minus(oldCoins) {
+		if diff.Amount != 0 {
+			am.setTotalSupply(ctx, diff.Denom, am.GetTotalSupply(ctx, diff.Denom)+diff.Amount)
+		}
+	}
+}
+
+// InitSupplyIndex computes the total supplies from the accounts, it runs once when sdk.SupplyIndex
+// activates and the totals are maintained by SetAccount and RemoveAccount afterwards.
+func (am AccountKeeper) InitSupplyIndex(ctx sdk.Context) {
+	var stale []string
+	am.IterateTotalSupplies(ctx, func(denom string, _ int64) bool {
+		stale = append(stale, denom)
+		return false
+	})
+	for _, denom := range stale {
+		am.setTotalSupply(ctx, denom, 0)
+	}
+
+	var totals sdk.Coins
+	am.IterateAccounts(ctx, func(acc sdk.Account) (stop bool) {
+		totals = totals.Plus(acc.GetCoins())
+		return false
+	})
+	for _, coin := range totals {
+		am.setTotalSupply(ctx, coin.Denom, coin.Amount)
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// GetCmdQuerySupply implements the supply query command.
+func GetCmdQuerySupply(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "supply [denom]",
+		Short: "Query the total supply of a denom, or of all denoms, split by where it is held",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var res []byte
+			var err error
+			if len(args) == 0 {
+				res, err = cliCtx.QueryWithData(fmt.Sprintf("custom/bank/%s", bank.QuerySupplies), nil)
+			} else {
+				bz, mErr := cdc.MarshalJSON(bank.QuerySupplyParams{Denom: args[0]})
+				if mErr != nil {
+					return mErr
+				}
+				res, err = cliCtx.QueryWithData(fmt.Sprintf("custom/bank/%s", bank.QuerySupply), bz)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryFeesSpent implements the fees spent query command.
+func GetCmdQueryFeesSpent(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fees-spent [address]",
+		Short: "Query the sum of the tx fees an account has paid",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(bank.QueryFeesSpentParams{Address: addr})
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/bank/%s", bank.QueryFeesSpent), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
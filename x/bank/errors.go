@@ -11,6 +11,9 @@ const (
 
 	CodeInvalidInput  sdk.CodeType = 101
 	CodeInvalidOutput sdk.CodeType = 102
+
+	CodeOutflowLimitExceeded sdk.CodeType = 103
+	CodeModulePermission     sdk.CodeType = 104
 )
 
 // NOTE: Don't stringer this, we'll put better messages in later.
@@ -20,6 +23,10 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid input coins"
 	case CodeInvalidOutput:
 		return "invalid output coins"
+	case CodeOutflowLimitExceeded:
+		return "outflow limit exceeded"
+	case CodeModulePermission:
+		return "module has no permission"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -44,6 +51,14 @@ func ErrNoOutputs(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeInvalidOutput, "")
 }
 
+func ErrOutflowLimitExceeded(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeOutflowLimitExceeded, msg)
+}
+
+func ErrModulePermission(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeModulePermission, msg)
+}
+
 //----------------------------------------
 
 func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
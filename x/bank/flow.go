@@ -0,0 +1,315 @@
+package bank
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RiskSeverity tells what happens when a risk rule is breached.
+type RiskSeverity uint8
+
+const (
+	// SeverityTx rejects the operation that breaches the rule, so the tx fails
+	SeverityTx RiskSeverity = iota
+	// SeverityChain also rejects the operation and halts the chain at the end of the block
+	SeverityChain
+)
+
+func (s RiskSeverity) String() string {
+	switch s {
+	case SeverityTx:
+		return "tx"
+	case SeverityChain:
+		return "chain"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// RiskRule caps the amount of Denom an account can send out within a block. A rule
+// without Account applies to every account that has no rule of its own for Denom.
+type RiskRule struct {
+	Account            sdk.AccAddress `json:"account"`
+	Denom              string         `json:"denom"`
+	MaxOutflowPerBlock int64          `json:"max_outflow_per_block"`
+	Severity           RiskSeverity   `json:"severity"`
+}
+
+// CoinFlow is a movement of coins done through the bank keeper of a module. From is
+// empty when coins are created and To is empty when coins are destroyed.
+type CoinFlow struct {
+	Height int64          `json:"height"`
+	Module string         `json:"module"`
+	From   sdk.AccAddress `json:"from"`
+	To     sdk.AccAddress `json:"to"`
+	Amount sdk.Coins      `json:"amount"`
+}
+
+// CoinFlowTracer records the coin flows of the current block and checks the outflow of
+// each account against the risk rules. Modules opt in by using a keeper returned by Wrap.
+// The risk rules are kept in the store of key and the outflows of the block in the transient
+// store of tkey, an outflow is only accounted once the operation succeeds and is discarded with
+// its tx if the tx fails. Only flows in deliver mode are traced, they are recorded in memory
+// when the keeper is called, so the flows of a tx that fails afterwards are kept as well.
+type CoinFlowTracer struct {
+	cdc  *codec.Codec
+	key  sdk.StoreKey
+	tkey sdk.StoreKey
+
+	mtx      sync.Mutex
+	maxFlows int
+	flows    []CoinFlow
+	dropped  int
+	breach   string
+}
+
+var riskRulePrefix = []byte("riskRule:")
+
+// NewCoinFlowTracer creates a tracer keeping at most maxFlows flows per block.
+func NewCoinFlowTracer(cdc *codec.Codec, key sdk.StoreKey, tkey sdk.StoreKey, maxFlows int) *CoinFlowTracer {
+	return &CoinFlowTracer{
+		cdc:      cdc,
+		key:      key,
+		tkey:     tkey,
+		maxFlows: maxFlows,
+	}
+}
+
+func riskRuleKey(addr sdk.AccAddress, denom string) []byte {
+	key := make([]byte, 0, len(riskRulePrefix)+1+len(addr)+len(denom))
+	key = append(key, riskRulePrefix...)
+	key = append(key, byte(len(addr)))
+	key = append(key, addr...)
+	return append(key, denom...)
+}
+
+// SetRiskRule adds or replaces the rule of rule.Account for rule.Denom
+func (t *CoinFlowTracer) SetRiskRule(ctx sdk.Context, rule RiskRule) {
+	ctx.KVStore(t.key).Set(riskRuleKey(rule.Account, rule.Denom), t.cdc.MustMarshalBinaryBare(rule))
+}
+
+// DeleteRiskRule removes the rule of addr for denom, an empty addr removes the default rule of denom
+func (t *CoinFlowTracer) DeleteRiskRule(ctx sdk.Context, addr sdk.AccAddress, denom string) {
+	ctx.KVStore(t.key).Delete(riskRuleKey(addr, denom))
+}
+
+// GetRiskRules returns all the risk rules
+func (t *CoinFlowTracer) GetRiskRules(ctx sdk.Context) []RiskRule {
+	rules := make([]RiskRule, 0)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(t.key), riskRulePrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var rule RiskRule
+		t.cdc.MustUnmarshalBinaryBare(iter.Value(), &rule)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// getRiskRule returns the rule of addr for denom, or the default rule of denom if addr has none
+func (t *CoinFlowTracer) getRiskRule(ctx sdk.Context, addr sdk.AccAddress, denom string) (RiskRule, bool) {
+	store := ctx.KVStore(t.key)
+	bz := store.Get(riskRuleKey(addr, denom))
+	if bz == nil {
+		bz = store.Get(riskRuleKey(nil, denom))
+	}
+	if bz == nil {
+		return RiskRule{}, false
+	}
+	var rule RiskRule
+	t.cdc.MustUnmarshalBinaryBare(bz, &rule)
+	return rule, true
+}
+
+// Wrap returns a keeper that traces the flows done by module through keeper.
+func (t *CoinFlowTracer) Wrap(module string, keeper Keeper) Keeper {
+	return tracingKeeper{Keeper: keeper, module: module, tracer: t}
+}
+
+// BeginBlocker clears the flows of the previous block, the outflows are cleared with the
+// transient store.
+func (t *CoinFlowTracer) BeginBlocker(ctx sdk.Context) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.flows = t.flows[:0]
+	t.dropped = 0
+}
+
+// EndBlocker halts the chain if a rule of SeverityChain was breached in the block.
+func (t *CoinFlowTracer) EndBlocker(ctx sdk.Context) {
+	t.mtx.Lock()
+	breach := t.breach
+	t.mtx.Unlock()
+	if breach != "" {
+		ctx.Logger().With("module", "bank").Error("halt the chain on coin flow risk", "breach", breach)
+		panic(fmt.Sprintf("coin flow risk rule breached: %s", breach))
+	}
+}
+
+// Flows returns the flows of the current block and the number of flows that did not
+// fit in the buffer.
+func (t *CoinFlowTracer) Flows() ([]CoinFlow, int) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	flows := make([]CoinFlow, len(t.flows))
+	copy(flows, t.flows)
+	return flows, t.dropped
+}
+
+// getOutflow returns the coins addr has sent out in the block
+func (t *CoinFlowTracer) getOutflow(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	bz := ctx.TransientStore(t.tkey).Get(addr)
+	if bz == nil {
+		return nil
+	}
+	var outflow sdk.Coins
+	t.cdc.MustUnmarshalBinaryBare(bz, &outflow)
+	return outflow
+}
+
+// checkOutflow returns an error if sending amt out of addr breaches a rule
+func (t *CoinFlowTracer) checkOutflow(ctx sdk.Context, module string, addr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	if !ctx.IsDeliverTx() || amt.IsZero() {
+		return nil
+	}
+	outflow := t.getOutflow(ctx, addr).Plus(amt)
+	for _, coin := range amt {
+		rule, found := t.getRiskRule(ctx, addr, coin.Denom)
+		if !found || outflow.AmountOf(coin.Denom) <= rule.MaxOutflowPerBlock {
+			continue
+		}
+
+		breach := fmt.Sprintf("outflow of %d%s from %s by %s exceeds %d%s per block at height %d",
+			outflow.AmountOf(coin.Denom), coin.Denom, addr, module, rule.MaxOutflowPerBlock, coin.Denom, ctx.BlockHeight())
+		if rule.Severity == SeverityChain {
+			t.mtx.Lock()
+			if t.breach == "" {
+				t.breach = breach
+			}
+			t.mtx.Unlock()
+		}
+		return ErrOutflowLimitExceeded(DefaultCodespace, breach)
+	}
+	return nil
+}
+
+// addOutflow accounts amt sent out of addr by an operation that succeeded
+func (t *CoinFlowTracer) addOutflow(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) {
+	if !ctx.IsDeliverTx() || amt.IsZero() {
+		return
+	}
+	outflow := t.getOutflow(ctx, addr).Plus(amt)
+	ctx.TransientStore(t.tkey).Set(addr, t.cdc.MustMarshalBinaryBare(outflow))
+}
+
+func (t *CoinFlowTracer) record(ctx sdk.Context, module string, from, to sdk.AccAddress, amt sdk.Coins) {
+	if !ctx.IsDeliverTx() || amt.IsZero() {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if len(t.flows) >= t.maxFlows {
+		t.dropped++
+		return
+	}
+	t.flows = append(t.flows, CoinFlow{
+		Height: ctx.BlockHeight(),
+		Module: module,
+		From:   from,
+		To:     to,
+		Amount: amt,
+	})
+}
+
+// tracingKeeper reports every balance change of the wrapped keeper to the tracer
+type tracingKeeper struct {
+	Keeper
+	module string
+	tracer *CoinFlowTracer
+}
+
+var _ Keeper = tracingKeeper{}
+
+func (k tracingKeeper) SetCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	oldCoins := k.GetCoins(ctx, addr)
+	removed, added := coinsDiff(oldCoins, amt)
+	if err := k.tracer.checkOutflow(ctx, k.module, addr, removed); err != nil {
+		return err
+	}
+	if err := k.Keeper.SetCoins(ctx, addr, amt); err != nil {
+		return err
+	}
+	k.tracer.addOutflow(ctx, addr, removed)
+	k.tracer.record(ctx, k.module, addr, nil, removed)
+	k.tracer.record(ctx, k.module, nil, addr, added)
+	return nil
+}
+
+func (k tracingKeeper) SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	if err := k.tracer.checkOutflow(ctx, k.module, addr, amt); err != nil {
+		return amt, nil, err
+	}
+	coins, tags, err := k.Keeper.SubtractCoins(ctx, addr, amt)
+	if err == nil {
+		k.tracer.addOutflow(ctx, addr, amt)
+		k.tracer.record(ctx, k.module, addr, nil, amt)
+	}
+	return coins, tags, err
+}
+
+func (k tracingKeeper) AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	coins, tags, err := k.Keeper.AddCoins(ctx, addr, amt)
+	if err == nil {
+		k.tracer.record(ctx, k.module, nil, addr, amt)
+	}
+	return coins, tags, err
+}
+
+func (k tracingKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	if err := k.tracer.checkOutflow(ctx, k.module, fromAddr, amt); err != nil {
+		return nil, err
+	}
+	tags, err := k.Keeper.SendCoins(ctx, fromAddr, toAddr, amt)
+	if err == nil {
+		k.tracer.addOutflow(ctx, fromAddr, amt)
+		k.tracer.record(ctx, k.module, fromAddr, toAddr, amt)
+	}
+	return tags, err
+}
+
+func (k tracingKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs []Output) (sdk.Tags, sdk.Error) {
+	for _, in := range inputs {
+		if err := k.tracer.checkOutflow(ctx, k.module, in.Address, in.Coins); err != nil {
+			return nil, err
+		}
+	}
+	tags, err := k.Keeper.InputOutputCoins(ctx, inputs, outputs)
+	if err == nil {
+		for _, in := range inputs {
+			k.tracer.addOutflow(ctx, in.Address, in.Coins)
+			k.tracer.record(ctx, k.module, in.Address, nil, in.Coins)
+		}
+		for _, out := range outputs {
+			k.tracer.record(ctx, k.module, nil, out.Address, out.Coins)
+		}
+	}
+	return tags, err
+}
+
+// coinsDiff splits the change from oldCoins to newCoins into the coins removed and added
+func coinsDiff(oldCoins, newCoins sdk.Coins) (removed, added sdk.Coins) {
+	for _, coin := range oldCoins {
+		if diff := coin.Amount - newCoins.AmountOf(coin.Denom); diff > 0 {
+			removed = append(removed, sdk.NewCoin(coin.Denom, diff))
+		}
+	}
+	for _, coin := range newCoins {
+		if diff := coin.Amount - oldCoins.AmountOf(coin.Denom); diff > 0 {
+			added = append(added, sdk.NewCoin(coin.Denom, diff))
+		}
+	}
+	return removed, added
+}
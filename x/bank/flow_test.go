@@ -0,0 +1,102 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestCoinFlowTracer(t *testing.T) {
+	db := dbm.NewMemDB()
+	authKey := sdk.NewKVStoreKey("authkey")
+	flowKey := sdk.NewKVStoreKey("flowkey")
+	tflowKey := sdk.NewTransientStoreKey("transient_flowkey")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(flowKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tflowKey, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{Height: 5}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(getAccountCache(cdc, ms, authKey))
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+
+	pool := sdk.AccAddress([]byte("pool"))
+	user := sdk.AccAddress([]byte("user"))
+	other := sdk.AccAddress([]byte("other"))
+	tracer := NewCoinFlowTracer(cdc, flowKey, tflowKey, 4)
+	defaultRule := RiskRule{Denom: "foocoin", MaxOutflowPerBlock: 100, Severity: SeverityTx}
+	poolRule := RiskRule{Account: pool, Denom: "foocoin", MaxOutflowPerBlock: 30, Severity: SeverityChain}
+	tracer.SetRiskRule(ctx, defaultRule)
+	tracer.SetRiskRule(ctx, poolRule)
+	require.Len(t, tracer.GetRiskRules(ctx), 2)
+	keeper := tracer.Wrap("stake", NewBaseKeeper(accountKeeper))
+
+	require.Nil(t, keeper.SetCoins(ctx, pool, sdk.Coins{sdk.NewCoin("foocoin", 100)}))
+	_, _, err := keeper.AddCoins(ctx, user, sdk.Coins{sdk.NewCoin("foocoin", 200)})
+	require.Nil(t, err)
+
+	_, err = keeper.SendCoins(ctx, pool, user, sdk.Coins{sdk.NewCoin("foocoin", 20)})
+	require.Nil(t, err)
+	// a failed operation does not count as outflow
+	_, err = keeper.SendCoins(ctx, other, user, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+	_, err = keeper.SendCoins(ctx, user, other, sdk.Coins{sdk.NewCoin("foocoin", 60)})
+	require.Nil(t, err)
+
+	flows, dropped := tracer.Flows()
+	require.Equal(t, 0, dropped)
+	require.Equal(t, []CoinFlow{
+		{Height: 5, Module: "stake", To: pool, Amount: sdk.Coins{sdk.NewCoin("foocoin", 100)}},
+		{Height: 5, Module: "stake", To: user, Amount: sdk.Coins{sdk.NewCoin("foocoin", 200)}},
+		{Height: 5, Module: "stake", From: pool, To: user, Amount: sdk.Coins{sdk.NewCoin("foocoin", 20)}},
+		{Height: 5, Module: "stake", From: user, To: other, Amount: sdk.Coins{sdk.NewCoin("foocoin", 60)}},
+	}, flows)
+
+	// the default rule rejects the tx
+	_, _, err = keeper.SubtractCoins(ctx, user, sdk.Coins{sdk.NewCoin("foocoin", 50)})
+	require.Equal(t, CodeOutflowLimitExceeded, err.Code())
+	require.Equal(t, int64(160), keeper.GetCoins(ctx, user).AmountOf("foocoin"))
+	tracer.EndBlocker(ctx)
+
+	// other denoms are not limited, the buffer is full
+	_, err = keeper.SendCoins(ctx, user, other, sdk.Coins{sdk.NewCoin("barcoin", 0)})
+	require.Nil(t, err)
+	require.Nil(t, keeper.SetCoins(ctx, other, sdk.Coins{sdk.NewCoin("barcoin", 1000), sdk.NewCoin("foocoin", 60)}))
+	_, dropped = tracer.Flows()
+	require.Equal(t, 1, dropped)
+
+	// nothing is traced outside of deliver tx
+	checkCtx := ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	_, err = keeper.SendCoins(checkCtx, pool, user, sdk.Coins{sdk.NewCoin("foocoin", 50)})
+	require.Nil(t, err)
+
+	// the pool rule halts the chain
+	err = keeper.SetCoins(ctx, pool, sdk.Coins{})
+	require.Equal(t, CodeOutflowLimitExceeded, err.Code())
+	require.Panics(t, func() { tracer.EndBlocker(ctx) })
+
+	// limits are per block
+	ms.Commit()
+	tracer.BeginBlocker(ctx)
+	flows, _ = tracer.Flows()
+	require.Empty(t, flows)
+	_, _, err = keeper.SubtractCoins(ctx, user, sdk.Coins{sdk.NewCoin("foocoin", 100)})
+	require.Nil(t, err)
+
+	// removing the default rule lifts the limit
+	tracer.DeleteRiskRule(ctx, nil, "foocoin")
+	require.Equal(t, []RiskRule{poolRule}, tracer.GetRiskRules(ctx))
+	_, _, err = keeper.SubtractCoins(ctx, user, sdk.Coins{sdk.NewCoin("foocoin", 60)})
+	require.Nil(t, err)
+}
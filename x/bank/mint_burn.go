@@ -0,0 +1,122 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Permissions a module account is granted to change the supply
+const (
+	PermMinter = "minter"
+	PermBurner = "burner"
+)
+
+const (
+	EventTypeMint = "mint"
+	EventTypeBurn = "burn"
+
+	AttributeKeyModule = "module"
+	AttributeKeyAmount = "amount"
+)
+
+type moduleAccount struct {
+	module      string
+	addr        sdk.AccAddress
+	permissions []string
+}
+
+func (acc moduleAccount) hasPermission(permission string) bool {
+	for _, p := range acc.permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AddModuleAccount registers the account of a module which mints or burns coins. How its balance
+// is reported is up to the app, see AddEscrowAccount and SetStakingAccount.
+func (keeper *SupplyKeeper) AddModuleAccount(module string, addr sdk.AccAddress, permissions ...string) {
+	for _, acc := range keeper.modules {
+		if acc.module == module {
+			panic(fmt.Sprintf("module account of %s already added", module))
+		}
+	}
+	keeper.modules = append(keeper.modules, moduleAccount{module, addr, permissions})
+}
+
+// GetModuleAddress returns the address of the account of a module
+func (keeper SupplyKeeper) GetModuleAddress(module string) (sdk.AccAddress, bool) {
+	acc, found := keeper.getModuleAccount(module)
+	return acc.addr, found
+}
+
+func (keeper SupplyKeeper) getModuleAccount(module string) (moduleAccount, bool) {
+	for _, acc := range keeper.modules {
+		if acc.module == module {
+			return acc, true
+		}
+	}
+	return moduleAccount{}, false
+}
+
+func (keeper SupplyKeeper) checkSupplyChange(module, permission string, amt sdk.Coins) (moduleAccount, sdk.Error) {
+	acc, found := keeper.getModuleAccount(module)
+	if !found {
+		return moduleAccount{}, ErrModulePermission(DefaultCodespace, fmt.Sprintf("module account of %s does not exist", module))
+	}
+	if !acc.hasPermission(permission) {
+		return moduleAccount{}, ErrModulePermission(DefaultCodespace, fmt.Sprintf("module %s is not a %s", module, permission))
+	}
+	if !amt.IsValid() || !amt.IsPositive() {
+		return moduleAccount{}, sdk.ErrInvalidCoins(amt.String())
+	}
+	return acc, nil
+}
+
+// MintCoins creates amt in the account of module, the module has to be a minter. The total supply
+// is read from the supply index, so minting is only available from sdk.SupplyIndex on.
+func (keeper SupplyKeeper) MintCoins(ctx sdk.Context, module string, amt sdk.Coins) sdk.Error {
+	if !sdk.IsUpgrade(sdk.SupplyIndex) {
+		return sdk.ErrInternal(fmt.Sprintf("minting is not supported before %s", sdk.SupplyIndex))
+	}
+	acc, err := keeper.checkSupplyChange(module, PermMinter, amt)
+	if err != nil {
+		return err
+	}
+
+	for _, coin := range amt {
+		// the balance of the module account is part of the total supply, so it cannot overflow either
+		if keeper.am.GetTotalSupply(ctx, coin.Denom) > sdk.TokenMaxTotalSupply-coin.Amount {
+			return sdk.ErrInvalidCoins(fmt.Sprintf("minting %s exceeds the max total supply", coin))
+		}
+	}
+	if _, _, err := addCoins(ctx, keeper.am, acc.addr, amt); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeMint,
+		sdk.NewAttribute(AttributeKeyModule, module),
+		sdk.NewAttribute(AttributeKeyAmount, amt.String()),
+	))
+	return nil
+}
+
+// BurnCoins destroys amt held by the account of module, the module has to be a burner
+func (keeper SupplyKeeper) BurnCoins(ctx sdk.Context, module string, amt sdk.Coins) sdk.Error {
+	acc, err := keeper.checkSupplyChange(module, PermBurner, amt)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := subtractCoins(ctx, keeper.am, acc.addr, amt); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeBurn,
+		sdk.NewAttribute(AttributeKeyModule, module),
+		sdk.NewAttribute(AttributeKeyAmount, amt.String()),
+	))
+	return nil
+}
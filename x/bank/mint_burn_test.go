@@ -0,0 +1,77 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestMintBurnCoins(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyIndex, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.SupplyIndex)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	ms, authKey := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, authKey)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+
+	mintAddr := sdk.ModuleAddress("mint", "pool")
+	burnAddr := sdk.ModuleAddress("bridge", "burn")
+	supplyKeeper := NewSupplyKeeper(accountKeeper)
+	supplyKeeper.AddModuleAccount("mint", mintAddr, PermMinter)
+	supplyKeeper.AddModuleAccount("bridge", burnAddr, PermBurner)
+	supplyKeeper.AddEscrowAccount("mint", mintAddr)
+	supplyKeeper.AddEscrowAccount("bridge", burnAddr)
+	require.Panics(t, func() { supplyKeeper.AddModuleAccount("mint", mintAddr) })
+
+	addr, found := supplyKeeper.GetModuleAddress("mint")
+	require.True(t, found)
+	require.Equal(t, mintAddr, addr)
+
+	coins := sdk.Coins{sdk.NewCoin("foo", 100)}
+	require.Nil(t, supplyKeeper.MintCoins(ctx, "mint", coins))
+	require.Equal(t, coins, getCoins(ctx, accountKeeper, mintAddr))
+	require.Equal(t, int64(100), supplyKeeper.GetSupply(ctx, "foo").Total)
+	require.Equal(t, []ModuleBalance{{"mint", 100}, {"bridge", 0}}, supplyKeeper.GetSupply(ctx, "foo").Escrowed)
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, EventTypeMint, events[0].Type)
+	require.Equal(t, "mint", string(events[0].Attributes[0].Value))
+	require.Equal(t, "100foo", string(events[0].Attributes[1].Value))
+
+	// permissions are enforced per module account
+	require.Equal(t, CodeModulePermission, supplyKeeper.MintCoins(ctx, "bridge", coins).Code())
+	require.Equal(t, CodeModulePermission, supplyKeeper.BurnCoins(ctx, "mint", coins).Code())
+	require.Equal(t, CodeModulePermission, supplyKeeper.MintCoins(ctx, "gov", coins).Code())
+
+	require.Equal(t, sdk.CodeInvalidCoins, supplyKeeper.MintCoins(ctx, "mint", sdk.Coins{sdk.NewCoin("foo", 0)}).Code())
+	require.Equal(t, sdk.CodeInvalidCoins,
+		supplyKeeper.MintCoins(ctx, "mint", sdk.Coins{sdk.NewCoin("foo", sdk.TokenMaxTotalSupply)}).Code())
+
+	_, _, err := subtractCoins(ctx, accountKeeper, mintAddr, sdk.Coins{sdk.NewCoin("foo", 60)})
+	require.Nil(t, err)
+	_, _, err = addCoins(ctx, accountKeeper, burnAddr, sdk.Coins{sdk.NewCoin("foo", 60)})
+	require.Nil(t, err)
+
+	require.Equal(t, sdk.CodeInsufficientCoins, supplyKeeper.BurnCoins(ctx, "bridge", sdk.Coins{sdk.NewCoin("foo", 61)}).Code())
+	require.Nil(t, supplyKeeper.BurnCoins(ctx, "bridge", sdk.Coins{sdk.NewCoin("foo", 60)}))
+	require.True(t, getCoins(ctx, accountKeeper, burnAddr).IsZero())
+	require.Equal(t, int64(40), supplyKeeper.GetSupply(ctx, "foo").Total)
+
+	events = ctx.EventManager().Events()
+	require.Equal(t, EventTypeBurn, events[len(events)-1].Type)
+}
@@ -0,0 +1,103 @@
+package bank
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QuerySupply    = "supply"
+	QuerySupplies  = "supplies"
+	QueryFeesSpent = "feesSpent"
+)
+
+type QuerySupplyParams struct {
+	Denom string `json:"denom"`
+}
+
+// QueryFeesSpentParams selects the account whose fees spent are queried
+type QueryFeesSpentParams struct {
+	Address sdk.AccAddress `json:"address"`
+}
+
+// FeesSpent is the sum of the tx fees an account has paid
+type FeesSpent struct {
+	Address sdk.AccAddress `json:"address"`
+	Fees    sdk.Coins      `json:"fees"`
+}
+
+// creates a querier for bank REST endpoints
+func NewQuerier(k SupplyKeeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QuerySupply:
+			return querySupply(ctx, cdc, req, k)
+		case QuerySupplies:
+			return querySupplies(ctx, cdc, k)
+		case QueryFeesSpent:
+			return queryFeesSpent(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown bank query endpoint")
+		}
+	}
+}
+
+// the supply is only known once the supply index is maintained
+func checkSupplyIndex() sdk.Error {
+	if !sdk.IsUpgrade(sdk.SupplyIndex) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("supply queries are not supported before %s", sdk.SupplyIndex))
+	}
+	return nil
+}
+
+func querySupply(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k SupplyKeeper) (res []byte, err sdk.Error) {
+	if err := checkSupplyIndex(); err != nil {
+		return nil, err
+	}
+	var params QuerySupplyParams
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+	if len(params.Denom) == 0 {
+		return nil, sdk.ErrInvalidCoins("denom is empty")
+	}
+
+	res, errRes = codec.MarshalJSONIndent(cdc, k.GetSupply(ctx, params.Denom))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func querySupplies(ctx sdk.Context, cdc *codec.Codec, k SupplyKeeper) (res []byte, err sdk.Error) {
+	if err := checkSupplyIndex(); err != nil {
+		return nil, err
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, k.GetSupplies(ctx))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryFeesSpent(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k SupplyKeeper) (res []byte, err sdk.Error) {
+	var params QueryFeesSpentParams
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+	if len(params.Address) == 0 {
+		return nil, sdk.ErrInvalidAddress("address is empty")
+	}
+
+	res, errRes = codec.MarshalJSONIndent(cdc, FeesSpent{Address: params.Address, Fees: k.am.GetFeesSpent(ctx, params.Address)})
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 )
@@ -30,6 +31,35 @@ func NonnegativeBalanceInvariant(mapper auth.AccountKeeper) simulation.Invariant
 	}
 }
 
+// SupplyInvariant checks that the total supply of every denom maintained by the supply index is the sum
+// of the balances of all accounts, mints and burns have to go through the supply keeper to keep it
+func SupplyInvariant(supplyKeeper bank.SupplyKeeper, mapper auth.AccountKeeper) simulation.Invariant {
+	return func(app *baseapp.BaseApp) error {
+		if !sdk.IsUpgrade(sdk.SupplyIndex) {
+			return nil
+		}
+		ctx := app.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		totalCoins := sdk.Coins{}
+		for _, acc := range mock.GetAllAccounts(mapper, ctx) {
+			totalCoins = totalCoins.Plus(acc.GetCoins())
+		}
+
+		supplies := supplyKeeper.GetSupplies(ctx)
+		if len(supplies) != len(totalCoins) {
+			return fmt.Errorf("accounts hold %d denoms but the supply index has %d", len(totalCoins), len(supplies))
+		}
+		for _, supply := range supplies {
+			if held := totalCoins.AmountOf(supply.Denom); supply.Total != held {
+				return fmt.Errorf("total supply of %s is %d but accounts hold %d", supply.Denom, supply.Total, held)
+			}
+			if supply.Circulating < 0 || supply.Bonded < 0 || supply.Unbonding < 0 {
+				return fmt.Errorf("negative supply of %s: %+v", supply.Denom, supply)
+			}
+		}
+		return nil
+	}
+}
+
 // TotalCoinsInvariant checks that the sum of the coins across all accounts
 // is what is expected
 func TotalCoinsInvariant(mapper auth.AccountKeeper, totalSupplyFn func() sdk.Coins) simulation.Invariant {
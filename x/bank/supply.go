@@ -0,0 +1,121 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// Supply is the total supply of a denom split by where the tokens are held.
+// Circulating is what remains in accounts that are not module escrows.
+type Supply struct {
+	Denom       string          `json:"denom"`
+	Total       int64           `json:"total"`
+	Circulating int64           `json:"circulating"`
+	Escrowed    []ModuleBalance `json:"escrowed"`
+	Bonded      int64           `json:"bonded"`
+	Unbonding   int64           `json:"unbonding"`
+}
+
+// ModuleBalance is the amount of a denom escrowed by a module
+type ModuleBalance struct {
+	Module string `json:"module"`
+	Amount int64  `json:"amount"`
+}
+
+// StakingSupplyKeeper reports the staked tokens that are being unbonded. Staked tokens
+// are held by the staking account, the part of its balance that is not unbonding is bonded.
+type StakingSupplyKeeper interface {
+	GetTotalUnbondingBalance(ctx sdk.Context, denom string) int64
+}
+
+type escrowAccount struct {
+	module string
+	addrs  []sdk.AccAddress
+}
+
+// SupplyKeeper reports the supply of every denom
+type SupplyKeeper struct {
+	am auth.AccountKeeper
+
+	escrows     []escrowAccount
+	modules     []moduleAccount
+	stakingAddr sdk.AccAddress
+	sk          StakingSupplyKeeper
+}
+
+// NewSupplyKeeper returns a new SupplyKeeper
+func NewSupplyKeeper(am auth.AccountKeeper) SupplyKeeper {
+	return SupplyKeeper{am: am}
+}
+
+// AddEscrowAccount reports the balances of addrs as escrowed by module, a module migrated to
+// a new address lists both the legacy and the new one
+func (keeper *SupplyKeeper) AddEscrowAccount(module string, addrs ...sdk.AccAddress) {
+	keeper.escrows = append(keeper.escrows, escrowAccount{module, addrs})
+}
+
+// SetStakingAccount reports the balance of addr as staked
+func (keeper *SupplyKeeper) SetStakingAccount(addr sdk.AccAddress, sk StakingSupplyKeeper) {
+	keeper.stakingAddr = addr
+	keeper.sk = sk
+}
+
+// GetSupplies returns the supply of every denom held by an account, ordered by denom. The totals
+// are read from the supply index maintained by the account keeper since sdk.SupplyIndex.
+func (keeper SupplyKeeper) GetSupplies(ctx sdk.Context) []Supply {
+	var supplies []Supply
+	keeper.am.IterateTotalSupplies(ctx, func(denom string, amount int64) (stop bool) {
+		supplies = append(supplies, keeper.buildSupply(ctx, denom, amount))
+		return false
+	})
+	if supplies == nil {
+		supplies = make([]Supply, 0)
+	}
+	return supplies
+}
+
+// GetSupply returns the supply of denom, all amounts are zero if no account holds it
+func (keeper SupplyKeeper) GetSupply(ctx sdk.Context, denom string) Supply {
+	return keeper.buildSupply(ctx, denom, keeper.am.GetTotalSupply(ctx, denom))
+}
+
+// buildSupply splits the total supply of denom by the escrow and staking accounts holding it
+func (keeper SupplyKeeper) buildSupply(ctx sdk.Context, denom string, total int64) Supply {
+	supply := Supply{
+		Denom:    denom,
+		Total:    total,
+		Escrowed: make([]ModuleBalance, 0, len(keeper.escrows)),
+	}
+	circulating := total
+	for _, escrow := range keeper.escrows {
+		var amount int64
+		for _, addr := range escrow.addrs {
+			amount += keeper.balanceOf(ctx, addr, denom)
+		}
+		supply.Escrowed = append(supply.Escrowed, ModuleBalance{escrow.module, amount})
+		circulating -= amount
+	}
+	if keeper.stakingAddr != nil {
+		if stakedAmount := keeper.balanceOf(ctx, keeper.stakingAddr, denom); stakedAmount > 0 {
+			if keeper.sk != nil {
+				supply.Unbonding = keeper.sk.GetTotalUnbondingBalance(ctx, denom)
+			}
+			if supply.Unbonding > stakedAmount {
+				// slashing may leave unbonding records above what is actually held
+				supply.Unbonding = stakedAmount
+			}
+			supply.Bonded = stakedAmount - supply.Unbonding
+			circulating -= stakedAmount
+		}
+	}
+	supply.Circulating = circulating
+	return supply
+}
+
+func (keeper SupplyKeeper) balanceOf(ctx sdk.Context, addr sdk.AccAddress, denom string) int64 {
+	acc := keeper.am.GetAccount(ctx, addr)
+	if acc == nil {
+		return 0
+	}
+	return acc.GetCoins().AmountOf(denom)
+}
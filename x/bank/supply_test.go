@@ -0,0 +1,138 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+type unbondingKeeper int64
+
+func (k unbondingKeeper) GetTotalUnbondingBalance(_ sdk.Context, denom string) int64 {
+	if denom == "stake" {
+		return int64(k)
+	}
+	return 0
+}
+
+func TestSupply(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, authKey)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+	bankKeeper := NewBaseKeeper(accountKeeper)
+
+	holder := sdk.AccAddress([]byte("holder"))
+	govAddr := sdk.AccAddress([]byte("gov"))
+	govModuleAddr := sdk.ModuleAddress("gov", "deposit")
+	stakingAddr := sdk.AccAddress([]byte("staking"))
+	removed := sdk.AccAddress([]byte("removed"))
+	// the accounts set before the upgrade are indexed when it activates
+	bankKeeper.SetCoins(ctx, holder, sdk.Coins{sdk.NewCoin("foo", 10), sdk.NewCoin("stake", 100)})
+	bankKeeper.SetCoins(ctx, removed, sdk.Coins{sdk.NewCoin("stake", 30)})
+	accountCache.Write()
+
+	supplyKeeper := NewSupplyKeeper(accountKeeper)
+	supplyKeeper.AddEscrowAccount("gov", govAddr, govModuleAddr)
+	supplyKeeper.SetStakingAccount(stakingAddr, unbondingKeeper(15))
+
+	querier := NewQuerier(supplyKeeper, cdc)
+	bz, err := cdc.MarshalJSON(QuerySupplyParams{Denom: "stake"})
+	require.Nil(t, err)
+	_, sdkErr := querier(ctx, []string{QuerySupply}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyIndex, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.SupplyIndex)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	accountKeeper.InitSupplyIndex(ctx)
+	require.Equal(t, int64(130), accountKeeper.GetTotalSupply(ctx, "stake"))
+
+	// the later changes are maintained
+	bankKeeper.SetCoins(ctx, govAddr, sdk.Coins{sdk.NewCoin("stake", 20)})
+	bankKeeper.SetCoins(ctx, govModuleAddr, sdk.Coins{sdk.NewCoin("stake", 5)})
+	bankKeeper.SetCoins(ctx, stakingAddr, sdk.Coins{sdk.NewCoin("stake", 50)})
+	accountKeeper.RemoveAccount(ctx, accountKeeper.GetAccount(ctx, removed))
+	accountCache.Write()
+
+	require.Equal(t, []Supply{
+		{Denom: "foo", Total: 10, Circulating: 10, Escrowed: []ModuleBalance{{"gov", 0}}},
+		{Denom: "stake", Total: 175, Circulating: 100, Escrowed: []ModuleBalance{{"gov", 25}}, Bonded: 35, Unbonding: 15},
+	}, supplyKeeper.GetSupplies(ctx))
+	require.Equal(t, Supply{Denom: "bar", Escrowed: []ModuleBalance{{"gov", 0}}}, supplyKeeper.GetSupply(ctx, "bar"))
+
+	res, sdkErr := querier(ctx, []string{QuerySupply}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var supply Supply
+	require.Nil(t, cdc.UnmarshalJSON(res, &supply))
+	require.Equal(t, int64(35), supply.Bonded)
+
+	// rebuilding the index gives the same totals
+	accountKeeper.InitSupplyIndex(ctx)
+	require.Equal(t, int64(175), accountKeeper.GetTotalSupply(ctx, "stake"))
+	require.Equal(t, int64(10), accountKeeper.GetTotalSupply(ctx, "foo"))
+}
+
+func TestFeesSpent(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, authKey)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+	bankKeeper := NewBaseKeeper(accountKeeper)
+
+	payer := sdk.AccAddress([]byte("payer"))
+	bankKeeper.SetCoins(ctx, payer, sdk.Coins{sdk.NewCoin("stake", 100)})
+	fee := sdk.Coins{sdk.NewCoin("stake", 10)}
+
+	// the fees are not counted before the upgrade
+	_, res := auth.DeductFees(ctx, accountKeeper, accountKeeper.GetAccount(ctx, payer), fee)
+	require.True(t, res.IsOK())
+	require.Equal(t, sdk.Coins{}, accountKeeper.GetFeesSpent(ctx, payer))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.FeesSpentCounter, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.FeesSpentCounter)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	_, res = auth.DeductFees(ctx, accountKeeper, accountKeeper.GetAccount(ctx, payer), fee)
+	require.True(t, res.IsOK())
+	_, res = auth.DeductFees(ctx, accountKeeper, accountKeeper.GetAccount(ctx, payer), sdk.Coins{sdk.NewCoin("stake", 5)})
+	require.True(t, res.IsOK())
+	_, res = auth.DeductFees(ctx, accountKeeper, accountKeeper.GetAccount(ctx, payer), sdk.Coins{sdk.NewCoin("stake", 100)})
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("stake", 75)}, bankKeeper.GetCoins(ctx, payer))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("stake", 15)}, accountKeeper.GetFeesSpent(ctx, payer))
+
+	querier := NewQuerier(NewSupplyKeeper(accountKeeper), cdc)
+	bz, err := cdc.MarshalJSON(QueryFeesSpentParams{Address: payer})
+	require.Nil(t, err)
+	bz, sdkErr := querier(ctx, []string{QueryFeesSpent}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var spent FeesSpent
+	require.Nil(t, cdc.UnmarshalJSON(bz, &spent))
+	require.Equal(t, FeesSpent{Address: payer, Fees: sdk.Coins{sdk.NewCoin("stake", 15)}}, spent)
+
+	bz, err = cdc.MarshalJSON(QueryFeesSpentParams{})
+	require.Nil(t, err)
+	_, sdkErr = querier(ctx, []string{QueryFeesSpent}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+}
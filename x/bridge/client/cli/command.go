@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+func AddCommands(cmd *cobra.Command, cdc *amino.Codec) {
+	bridgeCmd := &cobra.Command{
+		Use:   "bridge",
+		Short: "bridge commands to bind tokens and transfer them to and from side chains",
+	}
+	bridgeCmd.AddCommand(
+		client.PostCommands(
+			BindCmd(cdc),
+			UnbindCmd(cdc),
+			TransferOutCmd(cdc))...)
+	bridgeCmd.AddCommand(
+		client.GetCommands(
+			GetCmdQueryBinding(cdc),
+			GetCmdQueryBindings(cdc),
+			GetCmdQueryTransferOut(cdc),
+			GetCmdQueryTransferOuts(cdc))...)
+	cmd.AddCommand(bridgeCmd)
+}
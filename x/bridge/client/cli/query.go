@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bridge"
+)
+
+const flagFrom = "sender"
+
+// GetCmdQueryBinding queries the binding of a token
+func GetCmdQueryBinding(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "binding [symbol]",
+		Short: "Query the binding of a token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryBridge(cdc, bridge.QueryBinding, bridge.QueryBindingParams{Symbol: args[0]})
+		},
+	}
+}
+
+// GetCmdQueryBindings queries all the bindings
+func GetCmdQueryBindings(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bindings",
+		Short: "Query the bindings of all the tokens",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryBridge(cdc, bridge.QueryBindings, nil)
+		},
+	}
+}
+
+// GetCmdQueryTransferOut queries a transfer out waiting for its ack
+func GetCmdQueryTransferOut(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "transfer-out [id]",
+		Short: "Query a transfer out waiting for the ack of the side chain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			return queryBridge(cdc, bridge.QueryTransferOut, bridge.QueryTransferOutParams{Id: id})
+		},
+	}
+}
+
+// GetCmdQueryTransferOuts queries the transfers out waiting for their acks, optionally of a sender
+func GetCmdQueryTransferOuts(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-outs",
+		Short: "Query the transfers out waiting for the ack of the side chain",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var params bridge.QueryTransferOutsParams
+			if sender := viper.GetString(flagFrom); sender != "" {
+				addr, err := sdk.AccAddressFromBech32(sender)
+				if err != nil {
+					return err
+				}
+				params.From = addr
+			}
+			return queryBridge(cdc, bridge.QueryTransferOuts, params)
+		},
+	}
+	cmd.Flags().String(flagFrom, "", "only the transfers of the sender")
+	return cmd
+}
+
+func queryBridge(cdc *codec.Codec, endpoint string, params interface{}) error {
+	var bz []byte
+	if params != nil {
+		var err error
+		if bz, err = cdc.MarshalJSON(params); err != nil {
+			return err
+		}
+	}
+
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", bridge.QuerierRoute, endpoint), bz)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(res))
+	return nil
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/bridge"
+)
+
+const (
+	flagSideChainId      = "side-chain-id"
+	flagSymbol           = "symbol"
+	flagAmount           = "amount"
+	flagContractAddress  = "contract-address"
+	flagContractDecimals = "contract-decimals"
+	flagExpireTime       = "expire-time"
+	flagTo               = "to"
+)
+
+// BindCmd binds a token to a token contract of a side chain
+func BindCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bind",
+		Short: "Bind a token to a token contract of a side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var contractAddress bsc.Address
+			if err := contractAddress.UnmarshalText([]byte(viper.GetString(flagContractAddress))); err != nil {
+				return err
+			}
+			return sendTx(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return bridge.NewMsgBind(from, viper.GetString(flagSideChainId), viper.GetString(flagSymbol),
+					viper.GetInt64(flagAmount), contractAddress, int8(viper.GetInt(flagContractDecimals)), viper.GetInt64(flagExpireTime))
+			})
+		},
+	}
+	cmd.Flags().String(flagSideChainId, "", "side chain id of the token contract")
+	cmd.Flags().String(flagSymbol, "", "symbol of the token")
+	cmd.Flags().Int64(flagAmount, 0, "amount of the token locked to peg the supply of the contract")
+	cmd.Flags().String(flagContractAddress, "", "hex address of the token contract")
+	cmd.Flags().Int(flagContractDecimals, 0, "decimals of the token contract")
+	cmd.Flags().Int64(flagExpireTime, 0, "unix time in seconds before which the side chain approves the binding")
+	return cmd
+}
+
+// UnbindCmd removes the binding of a token
+func UnbindCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbind",
+		Short: "Unbind a token from its token contract",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sendTx(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return bridge.NewMsgUnbind(from, viper.GetString(flagSymbol))
+			})
+		},
+	}
+	cmd.Flags().String(flagSymbol, "", "symbol of the token")
+	return cmd
+}
+
+// TransferOutCmd transfers tokens of a bound token to an address of a side chain
+func TransferOutCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-out",
+		Short: "Transfer tokens of a bound token to an address of the side chain it is bound to",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var to bsc.Address
+			if err := to.UnmarshalText([]byte(viper.GetString(flagTo))); err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(viper.GetString(flagAmount))
+			if err != nil {
+				return err
+			}
+			return sendTx(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return bridge.NewMsgTransferOut(from, to, amount, viper.GetInt64(flagExpireTime))
+			})
+		},
+	}
+	cmd.Flags().String(flagTo, "", "hex address of the receiver on the side chain")
+	cmd.Flags().String(flagAmount, "", "amount to transfer, e.g. 100000000XYZ-000")
+	cmd.Flags().Int64(flagExpireTime, 0, "unix time in seconds after which the side chain rejects the transfer, at most a week ahead")
+	return cmd
+}
+
+func sendTx(cdc *codec.Codec, newMsg func(from sdk.AccAddress) sdk.Msg) error {
+	txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+	from, err := cliCtx.GetFromAddress()
+	if err != nil {
+		return err
+	}
+	msg := newMsg(from)
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if cliCtx.GenerateOnly {
+		return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+	}
+	return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+}
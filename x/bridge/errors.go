@@ -0,0 +1,57 @@
+package bridge
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 14
+
+	CodeInvalidBind      sdk.CodeType = 101
+	CodeNotTokenOwner    sdk.CodeType = 102
+	CodeAlreadyBound     sdk.CodeType = 103
+	CodeBindingNotFound  sdk.CodeType = 104
+	CodeInvalidPackage   sdk.CodeType = 105
+	CodeBindNotPending   sdk.CodeType = 106
+	CodeSideChainNotInit sdk.CodeType = 107
+	CodeInvalidTransfer  sdk.CodeType = 108
+	CodeTransferNotFound sdk.CodeType = 109
+)
+
+func ErrInvalidBind(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidBind, msg)
+}
+
+func ErrNotTokenOwner(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeNotTokenOwner, fmt.Sprintf("only the owner of token %s can bind or unbind it", symbol))
+}
+
+func ErrAlreadyBound(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeAlreadyBound, fmt.Sprintf("token %s is already bound or being bound", symbol))
+}
+
+func ErrBindingNotFound(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeBindingNotFound, fmt.Sprintf("token %s is not bound", symbol))
+}
+
+func ErrInvalidPackage(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPackage, msg)
+}
+
+func ErrBindNotPending(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeBindNotPending, fmt.Sprintf("token %s has no pending bind request", symbol))
+}
+
+func ErrSideChainNotInit(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeSideChainNotInit, "the keeper is not prepared for side chain")
+}
+
+func ErrInvalidTransfer(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidTransfer, msg)
+}
+
+func ErrTransferNotFound(codespace sdk.CodespaceType, id uint64) sdk.Error {
+	return sdk.NewError(codespace, CodeTransferNotFound, fmt.Sprintf("transfer %d is not found", id))
+}
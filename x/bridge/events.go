@@ -0,0 +1,18 @@
+package bridge
+
+const (
+	EventTypeTransferOut = "bridge_transfer_out"
+	EventTypeTransferIn  = "bridge_transfer_in"
+	EventTypeRefund      = "bridge_refund"
+
+	AttributeKeyTransferId   = "TransferId"
+	AttributeKeySymbol       = "Symbol"
+	AttributeKeyAmount       = "Amount"
+	AttributeKeySender       = "Sender"
+	AttributeKeyReceiver     = "Receiver"
+	AttributeKeyRefundReason = "RefundReason"
+
+	RefundReasonRejected = "rejected"
+	RefundReasonCrashed  = "crashed"
+	RefundReasonTimeout  = "timeout"
+)
@@ -0,0 +1,95 @@
+package bridge
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all bridge state that must be provided at genesis
+type GenesisState struct {
+	Bindings       []Binding     `json:"bindings"`
+	TransferOuts   []TransferOut `json:"transfer_outs"`
+	NextTransferId uint64        `json:"next_transfer_id"`
+}
+
+func NewGenesisState(bindings []Binding, transferOuts []TransferOut, nextTransferId uint64) GenesisState {
+	return GenesisState{
+		Bindings:       bindings,
+		TransferOuts:   transferOuts,
+		NextTransferId: nextTransferId,
+	}
+}
+
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState([]Binding{}, []TransferOut{}, 0)
+}
+
+// InitGenesis sets the bindings and the transfers waiting for their acks, the tokens they lock are expected
+// to be in the peg account of the genesis state
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, binding := range data.Bindings {
+		keeper.setBinding(ctx, binding)
+	}
+	for _, transfer := range data.TransferOuts {
+		keeper.setTransferOut(ctx, transfer)
+	}
+	keeper.setNextTransferId(ctx, data.NextTransferId)
+}
+
+// WriteGenesis returns a GenesisState for a given context and keeper
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	bindings := make([]Binding, 0)
+	keeper.IterateBindings(ctx, func(binding Binding) bool {
+		bindings = append(bindings, binding)
+		return false
+	})
+	transferOuts := make([]TransferOut, 0)
+	keeper.IterateTransferOuts(ctx, func(transfer TransferOut) bool {
+		transferOuts = append(transferOuts, transfer)
+		return false
+	})
+	return NewGenesisState(bindings, transferOuts, keeper.GetNextTransferId(ctx))
+}
+
+// ValidateGenesis checks that the bindings and the transfers are unique and well formed, and that the
+// transfers have ids below the next transfer id
+func ValidateGenesis(data GenesisState) error {
+	symbols := make(map[string]bool, len(data.Bindings))
+	for _, binding := range data.Bindings {
+		if symbols[binding.Symbol] {
+			return fmt.Errorf("duplicate binding of token %s", binding.Symbol)
+		}
+		symbols[binding.Symbol] = true
+		if err := sdk.ValidateDenom(binding.Symbol); err != nil {
+			return fmt.Errorf("invalid binding of token %s: %v", binding.Symbol, err)
+		}
+		if binding.Status != BindStatusPending && binding.Status != BindStatusBound && binding.Status != BindStatusUnbound {
+			return fmt.Errorf("invalid status %d of the binding of token %s", binding.Status, binding.Symbol)
+		}
+		if binding.LockedAmount < 0 {
+			return fmt.Errorf("negative locked amount of the binding of token %s", binding.Symbol)
+		}
+	}
+
+	ids := make(map[uint64]bool, len(data.TransferOuts))
+	for _, transfer := range data.TransferOuts {
+		if ids[transfer.Id] {
+			return fmt.Errorf("duplicate transfer out %d", transfer.Id)
+		}
+		ids[transfer.Id] = true
+		if transfer.Id >= data.NextTransferId {
+			return fmt.Errorf("transfer out %d is not below the next transfer id %d", transfer.Id, data.NextTransferId)
+		}
+		if len(transfer.From) != sdk.AddrLen {
+			return fmt.Errorf("invalid sender of transfer out %d", transfer.Id)
+		}
+		if !transfer.Amount.IsPositive() {
+			return fmt.Errorf("invalid amount %s of transfer out %d", transfer.Amount, transfer.Id)
+		}
+		if transfer.ExpireTime <= 0 {
+			return fmt.Errorf("invalid expire time %d of transfer out %d", transfer.ExpireTime, transfer.Id)
+		}
+	}
+	return nil
+}
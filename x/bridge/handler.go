@@ -0,0 +1,67 @@
+package bridge
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(keeper Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgBind:
+			return handleMsgBind(ctx, keeper, msg)
+		case MsgUnbind:
+			return handleMsgUnbind(ctx, keeper, msg)
+		case MsgTransferOut:
+			return handleMsgTransferOut(ctx, keeper, msg)
+		default:
+			errMsg := "Unrecognized bridge msg type"
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgBind(ctx sdk.Context, keeper Keeper, msg MsgBind) sdk.Result {
+	sequence, err := keeper.Bind(ctx, msg)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Data: []byte(strconv.FormatUint(sequence, 10)),
+	}
+}
+
+func handleMsgUnbind(ctx sdk.Context, keeper Keeper, msg MsgUnbind) sdk.Result {
+	sequence, err := keeper.Unbind(ctx, msg)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Data: []byte(strconv.FormatUint(sequence, 10)),
+	}
+}
+
+func handleMsgTransferOut(ctx sdk.Context, keeper Keeper, msg MsgTransferOut) sdk.Result {
+	if !sdk.IsUpgrade(sdk.BridgeTransfer) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("transfer out is not supported before %s", sdk.BridgeTransfer)).Result()
+	}
+
+	transfer, err := keeper.TransferOut(ctx, msg)
+	if err != nil {
+		return err.Result()
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeTransferOut,
+		sdk.NewAttribute(AttributeKeyTransferId, strconv.FormatUint(transfer.Id, 10)),
+		sdk.NewAttribute(AttributeKeySymbol, transfer.Amount.Denom),
+		sdk.NewAttribute(AttributeKeyAmount, strconv.FormatInt(transfer.Amount.Amount, 10)),
+		sdk.NewAttribute(AttributeKeySender, transfer.From.String()),
+		sdk.NewAttribute(AttributeKeyReceiver, transfer.To.String()),
+	))
+	return sdk.Result{
+		Data:   []byte(strconv.FormatUint(transfer.Id, 10)),
+		Tags:   sdk.Tags{sdk.GetPegInTag(transfer.Amount.Denom, transfer.Amount.Amount)},
+		Events: ctx.EventManager().Events(),
+	}
+}
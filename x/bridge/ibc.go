@@ -0,0 +1,171 @@
+package bridge
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+var _ sdk.CrossChainApplication = Keeper{}
+
+// ExecuteSynPackage processes the approval of a bind package. An approved binding is finalized,
+// a rejected or expired one is rolled back.
+func (k Keeper) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
+	logger := ctx.Logger().With("module", "x/bridge")
+	var resCode uint32
+	var pack ApproveBindSynPackage
+	var sdkErr sdk.Error
+	if err := rlp.DecodeBytes(payload, &pack); err != nil {
+		sdkErr = ErrInvalidPackage(k.codespace, "failed to decode approve bind package")
+	} else {
+		symbol := bytesToSymbol(pack.TokenSymbol)
+		binding, found := k.GetBinding(ctx, symbol)
+		if found && pack.Status == BindStatusApproved && binding.ExpireTime >= ctx.BlockHeader().Time.Unix() {
+			sdkErr = k.finalizeBind(ctx, symbol)
+		} else {
+			sdkErr = k.rollbackBind(ctx, symbol)
+		}
+		if sdkErr == nil {
+			logger.Info("processed approve bind package", "symbol", symbol, "status", pack.Status)
+		}
+	}
+	if sdkErr != nil {
+		resCode = uint32(sdkErr.ABCICode())
+	}
+	ackPackage, err := sTypes.GenCommonAckPackage(resCode)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.ExecuteResult{
+		Payload: ackPackage,
+		Err:     sdkErr,
+		Tags:    sdk.EmptyTags(),
+	}
+}
+
+func (k Keeper) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	var ackPackage sTypes.CommonAckPackage
+	if err := rlp.DecodeBytes(payload, &ackPackage); err != nil {
+		return sdk.ExecuteResult{Err: ErrInvalidPackage(k.codespace, "failed to decode ack package")}
+	}
+	if !ackPackage.IsOk() {
+		// a rejected bind package is rolled back by its approve bind package
+		ctx.Logger().With("module", "x/bridge").Error("side chain failed to process bind package", "code", ackPackage.Code)
+	}
+	return sdk.ExecuteResult{}
+}
+
+// When the ack application crash, payload is the payload of the origin package.
+func (k Keeper) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	var pack BindSynPackage
+	if err := rlp.DecodeBytes(payload, &pack); err != nil {
+		return sdk.ExecuteResult{Err: ErrInvalidPackage(k.codespace, "failed to decode bind package")}
+	}
+	if pack.PackageType != BindTypeBind {
+		ctx.Logger().With("module", "x/bridge").Error("side chain process unbind package crashed", "symbol", bytesToSymbol(pack.TokenSymbol))
+		return sdk.ExecuteResult{}
+	}
+	return sdk.ExecuteResult{Err: k.rollbackBind(ctx, bytesToSymbol(pack.TokenSymbol))}
+}
+
+// transferOutApp handles the acks of the transfer out packages
+type transferOutApp struct {
+	k *Keeper
+}
+
+var _ sdk.CrossChainApplication = transferOutApp{}
+
+func (app transferOutApp) ExecuteSynPackage(ctx sdk.Context, _ []byte, _ int64) sdk.ExecuteResult {
+	return sdk.ExecuteResult{Err: ErrInvalidPackage(app.k.codespace, "unexpected syn package on the transfer out channel")}
+}
+
+// ExecuteAckPackage settles a transfer out processed by the side chain, or refunds it if it is rejected. The
+// side chain rejects an expired transfer, a transfer is never refunded before its ack as it may be delivered late.
+func (app transferOutApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	var ackPackage TransferOutAckPackage
+	if err := rlp.DecodeBytes(payload, &ackPackage); err != nil {
+		return sdk.ExecuteResult{Err: ErrInvalidPackage(app.k.codespace, "failed to decode transfer out ack package")}
+	}
+	switch ackPackage.Code {
+	case 0:
+		return sdk.ExecuteResult{Err: app.k.settleTransferOut(ctx, ackPackage.TransferId)}
+	case TransferOutAckCodeTimeout:
+		return app.refund(ctx, ackPackage.TransferId, RefundReasonTimeout)
+	default:
+		return app.refund(ctx, ackPackage.TransferId, RefundReasonRejected)
+	}
+}
+
+// When the ack application crash, payload is the payload of the origin package.
+func (app transferOutApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	var pack TransferOutSynPackage
+	if err := rlp.DecodeBytes(payload, &pack); err != nil {
+		return sdk.ExecuteResult{Err: ErrInvalidPackage(app.k.codespace, "failed to decode transfer out package")}
+	}
+	return app.refund(ctx, pack.TransferId, RefundReasonCrashed)
+}
+
+func (app transferOutApp) refund(ctx sdk.Context, id uint64, reason string) sdk.ExecuteResult {
+	transfer, err := app.k.refundTransferOut(ctx, id)
+	if err != nil {
+		return sdk.ExecuteResult{Err: err}
+	}
+	ctx.Logger().With("module", "x/bridge").Info("refunded transfer out", "id", id, "reason", reason)
+	return sdk.ExecuteResult{
+		Tags: append(sdk.Tags(newRefundEvent(transfer, reason).Attributes),
+			sdk.GetPegOutTag(transfer.Amount.Denom, transfer.Amount.Amount)),
+	}
+}
+
+// transferInApp handles the transfer in packages
+type transferInApp struct {
+	k *Keeper
+}
+
+var _ sdk.CrossChainApplication = transferInApp{}
+
+// ExecuteSynPackage releases the tokens of a transfer in package, the side chain refunds the transfer if the
+// ack carries an error code
+func (app transferInApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
+	var resCode uint32
+	var tags sdk.Tags
+	var pack TransferInSynPackage
+	var sdkErr sdk.Error
+	if !sdk.IsUpgrade(sdk.BridgeTransfer) {
+		sdkErr = ErrInvalidPackage(app.k.codespace, fmt.Sprintf("transfer in is not supported before %s", sdk.BridgeTransfer))
+	} else if err := rlp.DecodeBytes(payload, &pack); err != nil {
+		sdkErr = ErrInvalidPackage(app.k.codespace, "failed to decode transfer in package")
+	} else if sdkErr = app.k.TransferIn(ctx, pack); sdkErr == nil {
+		symbol := bytesToSymbol(pack.TokenSymbol)
+		amount := bsc.ConvertBSCAmountToBCAmount(pack.Amount)
+		tags = sdk.Tags(sdk.NewEvent(EventTypeTransferIn,
+			sdk.NewAttribute(AttributeKeySymbol, symbol),
+			sdk.NewAttribute(AttributeKeyAmount, strconv.FormatInt(amount, 10)),
+			sdk.NewAttribute(AttributeKeyReceiver, pack.Receiver.String()),
+		).Attributes).AppendTags(sdk.Tags{sdk.GetPegOutTag(symbol, amount)})
+	}
+	if sdkErr != nil {
+		resCode = uint32(sdkErr.ABCICode())
+	}
+	ackPackage, err := sTypes.GenCommonAckPackage(resCode)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.ExecuteResult{
+		Payload: ackPackage,
+		Err:     sdkErr,
+		Tags:    tags,
+	}
+}
+
+func (app transferInApp) ExecuteAckPackage(ctx sdk.Context, _ []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{Err: ErrInvalidPackage(app.k.codespace, "unexpected ack package on the transfer in channel")}
+}
+
+func (app transferInApp) ExecuteFailAckPackage(ctx sdk.Context, _ []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{Err: ErrInvalidPackage(app.k.codespace, "unexpected fail ack package on the transfer in channel")}
+}
@@ -0,0 +1,249 @@
+package bridge
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+const ChannelName = "bind"
+
+// ChannelIds are the ids the channels of the bridge are registered with. They are picked by the app,
+// so that they do not clash with the other channels of the node.
+type ChannelIds struct {
+	Bind        sdk.ChannelID
+	TransferOut sdk.ChannelID
+	TransferIn  sdk.ChannelID
+}
+
+// Bridge Keeper
+type Keeper struct {
+	storeKey  sdk.StoreKey
+	cdc       *codec.Codec
+	codespace sdk.CodespaceType
+
+	ck          bank.Keeper
+	tokenMapper TokenMapper
+
+	// if you want to enable side chains, you need call `SetupForSideChain`
+	ScKeeper  *sidechain.Keeper
+	ibcKeeper *ibc.Keeper
+
+	// shared memory for block level state
+	pool *sdk.Pool
+}
+
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, ck bank.Keeper, tokenMapper TokenMapper, codespace sdk.CodespaceType, pool *sdk.Pool) Keeper {
+	return Keeper{
+		storeKey:    storeKey,
+		cdc:         cdc,
+		codespace:   codespace,
+		ck:          ck,
+		tokenMapper: tokenMapper,
+		pool:        pool,
+	}
+}
+
+// SetupForSideChain registers the channels of the bridge with the keeper itself, so call it on the
+// keeper the app keeps
+func (k *Keeper) SetupForSideChain(scKeeper *sidechain.Keeper, ibcKeeper *ibc.Keeper, channelIds ChannelIds) {
+	k.ScKeeper = scKeeper
+	moduleIbcKeeper := ibcKeeper.ForModule(MsgRoute)
+	k.ibcKeeper = &moduleIbcKeeper
+	k.registerChannel(ChannelName, channelIds.Bind, k)
+	k.registerChannel(TransferOutChannelName, channelIds.TransferOut, transferOutApp{k: k})
+	k.registerChannel(TransferInChannelName, channelIds.TransferIn, transferInApp{k: k})
+}
+
+func (k *Keeper) registerChannel(name string, id sdk.ChannelID, app sdk.CrossChainApplication) {
+	err := k.ScKeeper.RegisterChannel(name, id, app)
+	if err != nil {
+		panic(fmt.Sprintf("register ibc channel failed, channel=%s, err=%s", name, err.Error()))
+	}
+}
+
+func (k Keeper) GetBinding(ctx sdk.Context, symbol string) (Binding, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildBindingKey(symbol))
+	if bz == nil {
+		return Binding{}, false
+	}
+	var binding Binding
+	k.cdc.MustUnmarshalBinaryBare(bz, &binding)
+	return binding, true
+}
+
+// IterateBindings iterates the bindings in ascending order of their symbols
+func (k Keeper) IterateBindings(ctx sdk.Context, cb func(binding Binding) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixBinding)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var binding Binding
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &binding)
+		if cb(binding) {
+			return
+		}
+	}
+}
+
+func (k Keeper) setBinding(ctx sdk.Context, binding Binding) {
+	ctx.KVStore(k.storeKey).Set(buildBindingKey(binding.Symbol), k.cdc.MustMarshalBinaryBare(binding))
+}
+
+func (k Keeper) deleteBinding(ctx sdk.Context, symbol string) {
+	ctx.KVStore(k.storeKey).Delete(buildBindingKey(symbol))
+}
+
+// updateLockedAmount changes the amount held in the peg account for a binding by delta, an unbound
+// binding is deleted once nothing is held for it anymore
+func (k Keeper) updateLockedAmount(ctx sdk.Context, binding Binding, delta int64) {
+	binding.LockedAmount += delta
+	if binding.Status == BindStatusUnbound && binding.LockedAmount == 0 {
+		k.deleteBinding(ctx, binding.Symbol)
+		return
+	}
+	k.setBinding(ctx, binding)
+}
+
+func (k Keeper) checkTokenOwner(ctx sdk.Context, symbol string, from sdk.AccAddress) sdk.Error {
+	owner, found := k.tokenMapper.GetTokenOwner(ctx, symbol)
+	if !found {
+		return ErrInvalidBind(k.codespace, fmt.Sprintf("token %s is not issued", symbol))
+	}
+	if !owner.Equals(from) {
+		return ErrNotTokenOwner(k.codespace, symbol)
+	}
+	return nil
+}
+
+// Bind locks the amount to peg of the token and sends the bind package to the side chain, the
+// binding stays pending until the side chain approves it. It returns the sequence of the package.
+func (k Keeper) Bind(ctx sdk.Context, msg MsgBind) (uint64, sdk.Error) {
+	if k.ibcKeeper == nil {
+		return 0, ErrSideChainNotInit(k.codespace)
+	}
+	if err := k.checkTokenOwner(ctx, msg.Symbol, msg.From); err != nil {
+		return 0, err
+	}
+	if _, found := k.GetBinding(ctx, msg.Symbol); found {
+		return 0, ErrAlreadyBound(k.codespace, msg.Symbol)
+	}
+	totalSupply := k.tokenMapper.GetTokenTotalSupply(ctx, msg.Symbol)
+	if msg.Amount > totalSupply {
+		return 0, ErrInvalidBind(k.codespace, fmt.Sprintf("amount %d exceeds the total supply %d", msg.Amount, totalSupply))
+	}
+	if msg.ExpireTime <= ctx.BlockHeader().Time.Unix() {
+		return 0, ErrInvalidBind(k.codespace, fmt.Sprintf("expire time %d is not in the future", msg.ExpireTime))
+	}
+
+	if msg.Amount > 0 {
+		if _, err := k.ck.SendCoins(ctx, msg.From, sdk.GetPegAccount(), sdk.Coins{sdk.NewCoin(msg.Symbol, msg.Amount)}); err != nil {
+			return 0, err
+		}
+		k.addAddrs(ctx, msg.From)
+	}
+
+	pack := BindSynPackage{
+		PackageType:  BindTypeBind,
+		TokenSymbol:  symbolToBytes(msg.Symbol),
+		ContractAddr: msg.ContractAddress,
+		TotalSupply:  bsc.ConvertBCAmountToBSCAmount(totalSupply),
+		PeggyAmount:  bsc.ConvertBCAmountToBSCAmount(msg.Amount),
+		Decimals:     uint8(msg.ContractDecimals),
+		ExpireTime:   uint64(msg.ExpireTime),
+	}
+	sequence, err := k.sendBindPackage(ctx, msg.SideChainId, pack)
+	if err != nil {
+		return 0, err
+	}
+
+	k.setBinding(ctx, Binding{
+		Symbol:           msg.Symbol,
+		Owner:            msg.From,
+		SideChainId:      msg.SideChainId,
+		ContractAddress:  msg.ContractAddress,
+		ContractDecimals: msg.ContractDecimals,
+		LockedAmount:     msg.Amount,
+		ExpireTime:       msg.ExpireTime,
+		Status:           BindStatusPending,
+	})
+	return sequence, nil
+}
+
+// Unbind sends the unbind package of a finalized binding to the side chain. The tokens held in the peg
+// account for the binding back the tokens on the side chain, so the binding is kept as unbound until they
+// are all transferred back in, no more tokens can be transferred out meanwhile.
+func (k Keeper) Unbind(ctx sdk.Context, msg MsgUnbind) (uint64, sdk.Error) {
+	if k.ibcKeeper == nil {
+		return 0, ErrSideChainNotInit(k.codespace)
+	}
+	if err := k.checkTokenOwner(ctx, msg.Symbol, msg.From); err != nil {
+		return 0, err
+	}
+	binding, found := k.GetBinding(ctx, msg.Symbol)
+	if !found || binding.Status != BindStatusBound {
+		return 0, ErrBindingNotFound(k.codespace, msg.Symbol)
+	}
+
+	pack := BindSynPackage{
+		PackageType:  BindTypeUnbind,
+		TokenSymbol:  symbolToBytes(msg.Symbol),
+		ContractAddr: binding.ContractAddress,
+		TotalSupply:  big.NewInt(0),
+		PeggyAmount:  big.NewInt(0),
+	}
+	sequence, err := k.sendBindPackage(ctx, binding.SideChainId, pack)
+	if err != nil {
+		return 0, err
+	}
+	binding.Status = BindStatusUnbound
+	k.updateLockedAmount(ctx, binding, 0)
+	return sequence, nil
+}
+
+func (k Keeper) sendBindPackage(ctx sdk.Context, sideChainId string, pack BindSynPackage) (uint64, sdk.Error) {
+	bz, err := rlp.EncodeToBytes(&pack)
+	if err != nil {
+		return 0, sdk.ErrInternal("failed to encode bind package")
+	}
+	return k.ibcKeeper.CreateIBCSyncPackage(ctx, sideChainId, ChannelName, bz, nil)
+}
+
+// finalizeBind marks a pending binding as bound
+func (k Keeper) finalizeBind(ctx sdk.Context, symbol string) sdk.Error {
+	binding, found := k.GetBinding(ctx, symbol)
+	if !found || binding.Status != BindStatusPending {
+		return ErrBindNotPending(k.codespace, symbol)
+	}
+	binding.Status = BindStatusBound
+	k.setBinding(ctx, binding)
+	return nil
+}
+
+// rollbackBind deletes a pending binding and returns the locked tokens to the owner
+func (k Keeper) rollbackBind(ctx sdk.Context, symbol string) sdk.Error {
+	binding, found := k.GetBinding(ctx, symbol)
+	if !found || binding.Status != BindStatusPending {
+		return ErrBindNotPending(k.codespace, symbol)
+	}
+	if binding.LockedAmount > 0 {
+		if _, err := k.ck.SendCoins(ctx, sdk.GetPegAccount(), binding.Owner, sdk.Coins{sdk.NewCoin(symbol, binding.LockedAmount)}); err != nil {
+			return err
+		}
+		k.addAddrs(ctx, binding.Owner)
+	}
+	k.deleteBinding(ctx, symbol)
+	return nil
+}
+
+func (k Keeper) addAddrs(ctx sdk.Context, addr sdk.AccAddress) {
+	if ctx.IsDeliverTx() && k.pool != nil {
+		k.pool.AddAddrs([]sdk.AccAddress{addr, sdk.GetPegAccount()})
+	}
+}
@@ -0,0 +1,403 @@
+package bridge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const (
+	testSideChainId   = "bsc"
+	testSymbol        = "XYZ-000"
+	testBindChannelId = sdk.ChannelID(0x21)
+)
+
+var testChannelIds = ChannelIds{Bind: testBindChannelId, TransferOut: 0x22, TransferIn: 0x23}
+
+var (
+	owner = sdk.AccAddress([]byte("owner_______________"))
+	other = sdk.AccAddress([]byte("other_______________"))
+)
+
+type mockTokenMapper struct{}
+
+func (mockTokenMapper) GetTokenOwner(_ sdk.Context, symbol string) (sdk.AccAddress, bool) {
+	return owner, symbol == testSymbol
+}
+
+func (mockTokenMapper) GetTokenTotalSupply(_ sdk.Context, _ string) int64 {
+	return 10e8
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, bank.Keeper) {
+	keyBridge := sdk.NewKVStoreKey("bridge")
+	keyIBC := sdk.NewKVStoreKey("ibc")
+	keySideChain := sdk.NewKVStoreKey("sc")
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyBridge, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterBaseAccount(cdc)
+	RegisterCodec(cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid", Time: time.Unix(1000, 0)}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(accountCache)
+
+	ck := bank.NewBaseKeeper(auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount))
+	scKeeper := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), cdc)
+	ibcKeeper := ibc.NewKeeper(keyIBC, pk.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scKeeper)
+
+	destChainID := sdk.ChainID(0x000f)
+	scKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, scKeeper.RegisterDestChain(testSideChainId, destChainID))
+	scKeeper.SetSideChainIdAndStorePrefix(ctx, testSideChainId, []byte{0x99})
+	sideCtx, err := scKeeper.PrepareCtxForSideChain(ctx, testSideChainId)
+	require.NoError(t, err)
+	ibcKeeper.SetParams(sideCtx, ibc.Params{RelayerFee: ibc.DefaultRelayerFeeParam})
+
+	keeper := NewKeeper(cdc, keyBridge, ck, mockTokenMapper{}, DefaultCodespace, new(sdk.Pool))
+	keeper.SetupForSideChain(&scKeeper, &ibcKeeper, testChannelIds)
+	scKeeper.SetChannelSendPermission(ctx, destChainID, testChannelIds.Bind, sdk.ChannelAllow)
+	scKeeper.SetChannelSendPermission(ctx, destChainID, testChannelIds.TransferOut, sdk.ChannelAllow)
+
+	ck.SetCoins(ctx, owner, sdk.Coins{sdk.NewCoin(testSymbol, 10e8)})
+	return ctx, keeper, ck
+}
+
+func approvePayload(t *testing.T, status uint32) []byte {
+	bz, err := rlp.EncodeToBytes(&ApproveBindSynPackage{Status: status, TokenSymbol: symbolToBytes(testSymbol)})
+	require.NoError(t, err)
+	return bz
+}
+
+func TestBindApproved(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	contract := bsc.Address{0x01}
+
+	msg := NewMsgBind(other, testSideChainId, testSymbol, 4e8, contract, 8, 2000)
+	_, err := keeper.Bind(ctx, msg)
+	require.Equal(t, CodeNotTokenOwner, err.Code())
+
+	msg = NewMsgBind(owner, testSideChainId, testSymbol, 11e8, contract, 8, 2000)
+	_, err = keeper.Bind(ctx, msg)
+	require.Equal(t, CodeInvalidBind, err.Code())
+
+	msg = NewMsgBind(owner, testSideChainId, testSymbol, 4e8, contract, 8, 2000)
+	sequence, err := keeper.Bind(ctx, msg)
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), sequence)
+	require.Equal(t, int64(6e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+	require.Equal(t, int64(4e8), ck.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(testSymbol))
+
+	binding, found := keeper.GetBinding(ctx, testSymbol)
+	require.True(t, found)
+	require.Equal(t, BindStatusPending, binding.Status)
+
+	_, err = keeper.Bind(ctx, msg)
+	require.Equal(t, CodeAlreadyBound, err.Code())
+
+	// a pending binding can not be unbound
+	_, err = keeper.Unbind(ctx, NewMsgUnbind(owner, testSymbol))
+	require.Equal(t, CodeBindingNotFound, err.Code())
+
+	res := keeper.ExecuteSynPackage(ctx, approvePayload(t, BindStatusApproved), 0)
+	require.Nil(t, res.Err)
+	binding, _ = keeper.GetBinding(ctx, testSymbol)
+	require.Equal(t, BindStatusBound, binding.Status)
+
+	// the locked tokens stay pegged after unbinding, until they are transferred back in
+	sequence, err = keeper.Unbind(ctx, NewMsgUnbind(owner, testSymbol))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), sequence)
+	binding, found = keeper.GetBinding(ctx, testSymbol)
+	require.True(t, found)
+	require.Equal(t, BindStatusUnbound, binding.Status)
+	require.Equal(t, int64(4e8), ck.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(testSymbol))
+	_, err = keeper.Unbind(ctx, NewMsgUnbind(owner, testSymbol))
+	require.Equal(t, CodeBindingNotFound, err.Code())
+	_, err = keeper.Bind(ctx, msg)
+	require.Equal(t, CodeAlreadyBound, err.Code())
+
+	require.Nil(t, keeper.TransferIn(ctx, TransferInSynPackage{
+		TokenSymbol:  symbolToBytes(testSymbol),
+		ContractAddr: contract,
+		Amount:       bsc.ConvertBCAmountToBSCAmount(4e8),
+		Receiver:     owner,
+		ExpireTime:   2000,
+	}))
+	require.Equal(t, int64(10e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+	_, found = keeper.GetBinding(ctx, testSymbol)
+	require.False(t, found)
+
+	// the token can be bound again once all its tokens are back
+	_, err = keeper.Bind(ctx, msg)
+	require.Nil(t, err)
+}
+
+func TestBindRollback(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	msg := NewMsgBind(owner, testSideChainId, testSymbol, 4e8, bsc.Address{0x01}, 8, 2000)
+
+	// rejected by the side chain
+	_, err := keeper.Bind(ctx, msg)
+	require.Nil(t, err)
+	res := keeper.ExecuteSynPackage(ctx, approvePayload(t, 1), 0)
+	require.Nil(t, res.Err)
+	_, found := keeper.GetBinding(ctx, testSymbol)
+	require.False(t, found)
+	require.Equal(t, int64(10e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+
+	// approved after the binding expired
+	_, err = keeper.Bind(ctx, msg)
+	require.Nil(t, err)
+	expiredCtx := ctx.WithBlockHeader(abci.Header{ChainID: "foochainid", Time: time.Unix(2001, 0)})
+	res = keeper.ExecuteSynPackage(expiredCtx, approvePayload(t, BindStatusApproved), 0)
+	require.Nil(t, res.Err)
+	_, found = keeper.GetBinding(ctx, testSymbol)
+	require.False(t, found)
+	require.Equal(t, int64(10e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+
+	// the side chain crashed processing the bind package
+	_, err = keeper.Bind(ctx, msg)
+	require.Nil(t, err)
+	payload, encodeErr := rlp.EncodeToBytes(&BindSynPackage{
+		PackageType: BindTypeBind,
+		TokenSymbol: symbolToBytes(testSymbol),
+		TotalSupply: bsc.ConvertBCAmountToBSCAmount(10e8),
+		PeggyAmount: bsc.ConvertBCAmountToBSCAmount(4e8),
+	})
+	require.NoError(t, encodeErr)
+	res = keeper.ExecuteFailAckPackage(ctx, payload)
+	require.Nil(t, res.Err)
+	_, found = keeper.GetBinding(ctx, testSymbol)
+	require.False(t, found)
+	require.Equal(t, int64(10e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+	require.True(t, ck.GetCoins(ctx, sdk.GetPegAccount()).IsZero())
+}
+
+func bindToken(t *testing.T, ctx sdk.Context, keeper Keeper, contract bsc.Address) {
+	_, err := keeper.Bind(ctx, NewMsgBind(owner, testSideChainId, testSymbol, 4e8, contract, 8, 2000))
+	require.Nil(t, err)
+	res := keeper.ExecuteSynPackage(ctx, approvePayload(t, BindStatusApproved), 0)
+	require.Nil(t, res.Err)
+}
+
+func transferOutAckPayload(t *testing.T, id uint64, code uint32) []byte {
+	bz, err := rlp.EncodeToBytes(&TransferOutAckPackage{TransferId: id, Code: code})
+	require.NoError(t, err)
+	return bz
+}
+
+func TestTransferOut(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	app := transferOutApp{k: &keeper}
+	to := bsc.Address{0x02}
+	amount := sdk.NewCoin(testSymbol, 1e8)
+
+	_, err := keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, amount, 2000))
+	require.Equal(t, CodeBindingNotFound, err.Code())
+
+	bindToken(t, ctx, keeper, bsc.Address{0x01})
+	_, err = keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, amount, 1000))
+	require.Equal(t, CodeInvalidTransfer, err.Code())
+	tooLate := time.Unix(1000, 0).Add(MaxTransferOutExpiry).Unix() + 1
+	_, err = keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, amount, tooLate))
+	require.Equal(t, CodeInvalidTransfer, err.Code())
+	_, err = keeper.TransferOut(ctx, NewMsgTransferOut(other, to, amount, 2000))
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+
+	// acked by the side chain
+	transfer, err := keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, amount, 2000))
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), transfer.Id)
+	require.Equal(t, int64(5e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+	require.Equal(t, int64(5e8), ck.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(testSymbol))
+	res := app.ExecuteAckPackage(ctx, transferOutAckPayload(t, transfer.Id, 0))
+	require.Nil(t, res.Err)
+	_, found := keeper.GetTransferOut(ctx, transfer.Id)
+	require.False(t, found)
+	require.Equal(t, int64(5e8), ck.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(testSymbol))
+	res = app.ExecuteAckPackage(ctx, transferOutAckPayload(t, transfer.Id, 0))
+	require.Equal(t, CodeTransferNotFound, res.Err.Code())
+
+	// rejected by the side chain
+	transfer, err = keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, amount, 2000))
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), transfer.Id)
+	res = app.ExecuteAckPackage(ctx, transferOutAckPayload(t, transfer.Id, 1))
+	require.Nil(t, res.Err)
+	_, found = keeper.GetTransferOut(ctx, transfer.Id)
+	require.False(t, found)
+	require.Equal(t, int64(5e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+
+	// the side chain crashed processing the transfer out package
+	transfer, err = keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, amount, 2000))
+	require.Nil(t, err)
+	payload, encodeErr := rlp.EncodeToBytes(&TransferOutSynPackage{
+		TransferId:  transfer.Id,
+		TokenSymbol: symbolToBytes(testSymbol),
+		Recipient:   to,
+		Amount:      bsc.ConvertBCAmountToBSCAmount(1e8),
+		ExpireTime:  2000,
+	})
+	require.NoError(t, encodeErr)
+	res = app.ExecuteFailAckPackage(ctx, payload)
+	require.Nil(t, res.Err)
+	require.Equal(t, int64(5e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+	require.Equal(t, uint64(3), keeper.GetNextTransferId(ctx))
+}
+
+func TestTransferOutUpgrade(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	bindToken(t, ctx, keeper, bsc.Address{0x01})
+	handler := NewHandler(keeper)
+	msg := NewMsgTransferOut(owner, bsc.Address{0x02}, sdk.NewCoin(testSymbol, 1e8), 2000)
+
+	res := handler(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), res.Code)
+	require.Equal(t, int64(6e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BridgeTransfer, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.BridgeTransfer)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK())
+	require.Equal(t, int64(5e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+}
+
+func TestTransferOutTimeout(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	app := transferOutApp{k: &keeper}
+	bindToken(t, ctx, keeper, bsc.Address{0x01})
+	to := bsc.Address{0x02}
+
+	first, err := keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, sdk.NewCoin(testSymbol, 1e8), 2000))
+	require.Nil(t, err)
+	second, err := keeper.TransferOut(ctx, NewMsgTransferOut(owner, to, sdk.NewCoin(testSymbol, 2e8), 2000))
+	require.Nil(t, err)
+
+	// long expired, the transfers are still kept until their acks
+	lateCtx := ctx.WithBlockHeader(abci.Header{Time: time.Unix(2000, 0).Add(30 * 24 * time.Hour)})
+	res := app.ExecuteAckPackage(lateCtx, transferOutAckPayload(t, first.Id, TransferOutAckCodeTimeout))
+	require.Nil(t, res.Err)
+	require.Contains(t, res.Tags, sdk.MakeTag(AttributeKeyRefundReason, []byte(RefundReasonTimeout)))
+	_, found := keeper.GetTransferOut(ctx, first.Id)
+	require.False(t, found)
+	require.Equal(t, int64(4e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+
+	// a late ack of a delivered transfer does not release the tokens again
+	res = app.ExecuteAckPackage(lateCtx, transferOutAckPayload(t, second.Id, 0))
+	require.Nil(t, res.Err)
+	require.Equal(t, int64(4e8), ck.GetCoins(ctx, owner).AmountOf(testSymbol))
+	require.Equal(t, int64(6e8), ck.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(testSymbol))
+}
+
+func TestTransferIn(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	app := transferInApp{k: &keeper}
+	contract := bsc.Address{0x01}
+	pack := TransferInSynPackage{
+		TokenSymbol:  symbolToBytes(testSymbol),
+		ContractAddr: contract,
+		Amount:       bsc.ConvertBCAmountToBSCAmount(1e8),
+		Receiver:     other,
+		ExpireTime:   2000,
+	}
+	execute := func(pack TransferInSynPackage) sdk.ExecuteResult {
+		payload, err := rlp.EncodeToBytes(&pack)
+		require.NoError(t, err)
+		return app.ExecuteSynPackage(ctx, payload, 0)
+	}
+
+	res := execute(pack)
+	require.Equal(t, CodeInvalidPackage, res.Err.Code())
+	require.NotEmpty(t, res.Payload)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BridgeTransfer, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.BridgeTransfer)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	res = execute(pack)
+	require.Equal(t, CodeBindingNotFound, res.Err.Code())
+
+	bindToken(t, ctx, keeper, contract)
+	wrongContract := pack
+	wrongContract.ContractAddr = bsc.Address{0x03}
+	require.Equal(t, CodeInvalidTransfer, execute(wrongContract).Err.Code())
+	expired := pack
+	expired.ExpireTime = 999
+	require.Equal(t, CodeInvalidTransfer, execute(expired).Err.Code())
+	tooMuch := pack
+	tooMuch.Amount = bsc.ConvertBCAmountToBSCAmount(5e8)
+	require.Equal(t, CodeInvalidTransfer, execute(tooMuch).Err.Code())
+
+	res = execute(pack)
+	require.Nil(t, res.Err)
+	var ack sTypes.CommonAckPackage
+	require.NoError(t, rlp.DecodeBytes(res.Payload, &ack))
+	require.True(t, ack.IsOk())
+	require.Equal(t, int64(1e8), ck.GetCoins(ctx, other).AmountOf(testSymbol))
+	require.Equal(t, int64(3e8), ck.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(testSymbol))
+	binding, _ := keeper.GetBinding(ctx, testSymbol)
+	require.Equal(t, int64(3e8), binding.LockedAmount)
+}
+
+func TestGenesis(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	bindToken(t, ctx, keeper, bsc.Address{0x01})
+	_, err := keeper.TransferOut(ctx, NewMsgTransferOut(owner, bsc.Address{0x02}, sdk.NewCoin(testSymbol, 1e8), 2000))
+	require.Nil(t, err)
+
+	genesis := WriteGenesis(ctx, keeper)
+	require.NoError(t, ValidateGenesis(genesis))
+	require.Len(t, genesis.Bindings, 1)
+	require.Len(t, genesis.TransferOuts, 1)
+	require.Equal(t, uint64(1), genesis.NextTransferId)
+
+	newCtx, newKeeper, newCk := createTestInput(t)
+	newCk.SetCoins(newCtx, sdk.GetPegAccount(), sdk.Coins{sdk.NewCoin(testSymbol, 5e8)})
+	InitGenesis(newCtx, newKeeper, genesis)
+	require.Equal(t, genesis, WriteGenesis(newCtx, newKeeper))
+
+	invalid := genesis
+	invalid.NextTransferId = 0
+	require.Error(t, ValidateGenesis(invalid))
+	invalid = genesis
+	invalid.Bindings = append(invalid.Bindings, genesis.Bindings[0])
+	require.Error(t, ValidateGenesis(invalid))
+	require.NoError(t, ValidateGenesis(DefaultGenesisState()))
+}
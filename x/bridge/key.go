@@ -0,0 +1,22 @@
+package bridge
+
+import (
+	"encoding/binary"
+)
+
+var (
+	PrefixBinding     = []byte{0x00} // symbol -> Binding
+	PrefixTransferOut = []byte{0x01} // transfer id -> TransferOut
+	KeyNextTransferId = []byte{0x03}
+)
+
+func buildBindingKey(symbol string) []byte {
+	return append(append([]byte{}, PrefixBinding...), symbol...)
+}
+
+func buildTransferOutKey(id uint64) []byte {
+	key := make([]byte, len(PrefixTransferOut)+8)
+	copy(key, PrefixTransferOut)
+	binary.BigEndian.PutUint64(key[len(PrefixTransferOut):], id)
+	return key
+}
@@ -0,0 +1,191 @@
+package bridge
+
+import (
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	MsgRoute     = "bridge"
+	QuerierRoute = "bridge"
+
+	MaxSymbolLength = 32
+)
+
+var _, _, _ sdk.Msg = MsgBind{}, MsgUnbind{}, MsgTransferOut{}
+
+// MsgBind binds an issued token to a token contract of a side chain. Amount of the token is
+// locked in the peg account, it is the supply the contract may release on the side chain.
+type MsgBind struct {
+	From             sdk.AccAddress `json:"from"`
+	SideChainId      string         `json:"side_chain_id"`
+	Symbol           string         `json:"symbol"`
+	Amount           int64          `json:"amount"`
+	ContractAddress  bsc.Address    `json:"contract_address"`
+	ContractDecimals int8           `json:"contract_decimals"`
+	ExpireTime       int64          `json:"expire_time"` // unix seconds, the side chain approves the binding before it
+}
+
+func NewMsgBind(from sdk.AccAddress, sideChainId, symbol string, amount int64, contractAddress bsc.Address,
+	contractDecimals int8, expireTime int64) MsgBind {
+	return MsgBind{
+		From:             from,
+		SideChainId:      sideChainId,
+		Symbol:           symbol,
+		Amount:           amount,
+		ContractAddress:  contractAddress,
+		ContractDecimals: contractDecimals,
+		ExpireTime:       expireTime,
+	}
+}
+
+func (msg MsgBind) Route() string { return MsgRoute }
+func (msg MsgBind) Type() string  { return "bind" }
+
+func (msg MsgBind) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgBind) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgBind) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, sdk.GetPegAccount()}
+}
+
+func (msg MsgBind) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if len(msg.SideChainId) == 0 {
+		return ErrInvalidBind(DefaultCodespace, "side chain id should not be empty")
+	}
+	if len(msg.Symbol) == 0 || len(msg.Symbol) > MaxSymbolLength {
+		return ErrInvalidBind(DefaultCodespace, "symbol should not be empty or longer than 32 bytes")
+	}
+	if err := sdk.ValidateDenom(msg.Symbol); err != nil {
+		return ErrInvalidBind(DefaultCodespace, err.Error())
+	}
+	if msg.Amount < 0 {
+		return ErrInvalidBind(DefaultCodespace, "amount should not be negative")
+	}
+	if msg.ContractAddress == (bsc.Address{}) {
+		return ErrInvalidBind(DefaultCodespace, "contract address should not be empty")
+	}
+	if msg.ContractDecimals < 0 {
+		return ErrInvalidBind(DefaultCodespace, "contract decimals should not be negative")
+	}
+	if msg.ExpireTime <= 0 {
+		return ErrInvalidBind(DefaultCodespace, "expire time should be positive")
+	}
+	return nil
+}
+
+// MsgUnbind removes the binding of a token, the side chain stops accepting transfers of it
+type MsgUnbind struct {
+	From   sdk.AccAddress `json:"from"`
+	Symbol string         `json:"symbol"`
+}
+
+func NewMsgUnbind(from sdk.AccAddress, symbol string) MsgUnbind {
+	return MsgUnbind{
+		From:   from,
+		Symbol: symbol,
+	}
+}
+
+func (msg MsgUnbind) Route() string { return MsgRoute }
+func (msg MsgUnbind) Type() string  { return "unbind" }
+
+func (msg MsgUnbind) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgUnbind) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgUnbind) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func (msg MsgUnbind) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if len(msg.Symbol) == 0 || len(msg.Symbol) > MaxSymbolLength {
+		return ErrInvalidBind(DefaultCodespace, "symbol should not be empty or longer than 32 bytes")
+	}
+	if err := sdk.ValidateDenom(msg.Symbol); err != nil {
+		return ErrInvalidBind(DefaultCodespace, err.Error())
+	}
+	return nil
+}
+
+// MsgTransferOut transfers tokens of a bound token to an address of the side chain it is bound to,
+// the side chain releases them from the token contract before ExpireTime
+type MsgTransferOut struct {
+	From       sdk.AccAddress `json:"from"`
+	To         bsc.Address    `json:"to"`
+	Amount     sdk.Coin       `json:"amount"`
+	ExpireTime int64          `json:"expire_time"` // unix seconds
+}
+
+func NewMsgTransferOut(from sdk.AccAddress, to bsc.Address, amount sdk.Coin, expireTime int64) MsgTransferOut {
+	return MsgTransferOut{
+		From:       from,
+		To:         to,
+		Amount:     amount,
+		ExpireTime: expireTime,
+	}
+}
+
+func (msg MsgTransferOut) Route() string { return MsgRoute }
+func (msg MsgTransferOut) Type() string  { return "transferOut" }
+
+func (msg MsgTransferOut) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgTransferOut) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgTransferOut) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, sdk.GetPegAccount()}
+}
+
+func (msg MsgTransferOut) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if msg.To == (bsc.Address{}) {
+		return ErrInvalidTransfer(DefaultCodespace, "receiver address should not be empty")
+	}
+	if len(msg.Amount.Denom) == 0 || len(msg.Amount.Denom) > MaxSymbolLength {
+		return ErrInvalidTransfer(DefaultCodespace, "symbol should not be empty or longer than 32 bytes")
+	}
+	if err := sdk.ValidateDenom(msg.Amount.Denom); err != nil {
+		return ErrInvalidTransfer(DefaultCodespace, err.Error())
+	}
+	if msg.Amount.Amount <= 0 {
+		return ErrInvalidTransfer(DefaultCodespace, "amount should be positive")
+	}
+	if msg.ExpireTime <= 0 {
+		return ErrInvalidTransfer(DefaultCodespace, "expire time should be positive")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package bridge
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryBinding      = "binding"
+	QueryBindings     = "bindings"
+	QueryTransferOut  = "transferOut"
+	QueryTransferOuts = "transferOuts"
+)
+
+// Params for query 'custom/bridge/binding'
+type QueryBindingParams struct {
+	Symbol string
+}
+
+// Params for query 'custom/bridge/transferOut'
+type QueryTransferOutParams struct {
+	Id uint64
+}
+
+// Params for query 'custom/bridge/transferOuts'
+type QueryTransferOutsParams struct {
+	From sdk.AccAddress
+}
+
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryBinding:
+			var params QueryBindingParams
+			if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err.Error()))
+			}
+			binding, found := k.GetBinding(ctx, params.Symbol)
+			if !found {
+				return nil, ErrBindingNotFound(k.codespace, params.Symbol)
+			}
+			return marshalResult(k.cdc, binding)
+		case QueryBindings:
+			bindings := make([]Binding, 0)
+			k.IterateBindings(ctx, func(binding Binding) bool {
+				bindings = append(bindings, binding)
+				return false
+			})
+			return marshalResult(k.cdc, bindings)
+		case QueryTransferOut:
+			var params QueryTransferOutParams
+			if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err.Error()))
+			}
+			transfer, found := k.GetTransferOut(ctx, params.Id)
+			if !found {
+				return nil, ErrTransferNotFound(k.codespace, params.Id)
+			}
+			return marshalResult(k.cdc, transfer)
+		case QueryTransferOuts:
+			var params QueryTransferOutsParams
+			if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err.Error()))
+			}
+			transfers := make([]TransferOut, 0)
+			k.IterateTransferOuts(ctx, func(transfer TransferOut) bool {
+				if params.From.Empty() || transfer.From.Equals(params.From) {
+					transfers = append(transfers, transfer)
+				}
+				return false
+			})
+			return marshalResult(k.cdc, transfers)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown bridge query endpoint")
+		}
+	}
+}
+
+func marshalResult(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,197 @@
+package bridge
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TransferOutChannelName = "transferOut"
+	TransferInChannelName  = "transferIn"
+
+	// MaxTransferOutExpiry is how far in the future a transfer out may expire. A transfer is only refunded
+	// by the ack of the side chain, which rejects it once expired, so the tokens are not escrowed for long.
+	MaxTransferOutExpiry = 7 * 24 * time.Hour
+)
+
+// maxTransferInAmount is the largest amount of a transfer in package that converts to an int64
+var maxTransferInAmount = bsc.ConvertBCAmountToBSCAmount(math.MaxInt64)
+
+func (k Keeper) GetTransferOut(ctx sdk.Context, id uint64) (TransferOut, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildTransferOutKey(id))
+	if bz == nil {
+		return TransferOut{}, false
+	}
+	var transfer TransferOut
+	k.cdc.MustUnmarshalBinaryBare(bz, &transfer)
+	return transfer, true
+}
+
+func (k Keeper) setTransferOut(ctx sdk.Context, transfer TransferOut) {
+	ctx.KVStore(k.storeKey).Set(buildTransferOutKey(transfer.Id), k.cdc.MustMarshalBinaryBare(transfer))
+}
+
+func (k Keeper) deleteTransferOut(ctx sdk.Context, transfer TransferOut) {
+	ctx.KVStore(k.storeKey).Delete(buildTransferOutKey(transfer.Id))
+}
+
+// IterateTransferOuts iterates the transfers waiting for their acks in ascending order of their ids
+func (k Keeper) IterateTransferOuts(ctx sdk.Context, cb func(transfer TransferOut) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixTransferOut)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var transfer TransferOut
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &transfer)
+		if cb(transfer) {
+			return
+		}
+	}
+}
+
+// GetNextTransferId returns the id of the next transfer out
+func (k Keeper) GetNextTransferId(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(KeyNextTransferId)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) setNextTransferId(ctx sdk.Context, id uint64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, id)
+	ctx.KVStore(k.storeKey).Set(KeyNextTransferId, bz)
+}
+
+// TransferOut escrows the tokens of a bound token in the peg account and sends the transfer out package to
+// the side chain the token is bound to. The transfer is kept until the side chain acks it.
+func (k Keeper) TransferOut(ctx sdk.Context, msg MsgTransferOut) (TransferOut, sdk.Error) {
+	if k.ibcKeeper == nil {
+		return TransferOut{}, ErrSideChainNotInit(k.codespace)
+	}
+	symbol := msg.Amount.Denom
+	binding, found := k.GetBinding(ctx, symbol)
+	if !found || binding.Status != BindStatusBound {
+		return TransferOut{}, ErrBindingNotFound(k.codespace, symbol)
+	}
+	if msg.ExpireTime <= ctx.BlockHeader().Time.Unix() {
+		return TransferOut{}, ErrInvalidTransfer(k.codespace, fmt.Sprintf("expire time %d is not in the future", msg.ExpireTime))
+	}
+	if maxExpireTime := ctx.BlockHeader().Time.Add(MaxTransferOutExpiry).Unix(); msg.ExpireTime > maxExpireTime {
+		return TransferOut{}, ErrInvalidTransfer(k.codespace, fmt.Sprintf("expire time %d is after %d", msg.ExpireTime, maxExpireTime))
+	}
+
+	if _, err := k.ck.SendCoins(ctx, msg.From, sdk.GetPegAccount(), sdk.Coins{msg.Amount}); err != nil {
+		return TransferOut{}, err
+	}
+	k.addAddrs(ctx, msg.From)
+
+	transfer := TransferOut{
+		Id:          k.GetNextTransferId(ctx),
+		From:        msg.From,
+		SideChainId: binding.SideChainId,
+		To:          msg.To,
+		Amount:      msg.Amount,
+		ExpireTime:  msg.ExpireTime,
+	}
+	pack := TransferOutSynPackage{
+		TransferId:   transfer.Id,
+		TokenSymbol:  symbolToBytes(symbol),
+		ContractAddr: binding.ContractAddress,
+		Recipient:    msg.To,
+		Amount:       bsc.ConvertBCAmountToBSCAmount(msg.Amount.Amount),
+		ExpireTime:   uint64(msg.ExpireTime),
+	}
+	bz, err := rlp.EncodeToBytes(&pack)
+	if err != nil {
+		return TransferOut{}, sdk.ErrInternal("failed to encode transfer out package")
+	}
+	if _, err := k.ibcKeeper.CreateIBCSyncPackage(ctx, binding.SideChainId, TransferOutChannelName, bz, nil); err != nil {
+		return TransferOut{}, err
+	}
+
+	k.setTransferOut(ctx, transfer)
+	k.setNextTransferId(ctx, transfer.Id+1)
+	k.updateLockedAmount(ctx, binding, msg.Amount.Amount)
+	return transfer, nil
+}
+
+// settleTransferOut deletes a transfer acked by the side chain
+func (k Keeper) settleTransferOut(ctx sdk.Context, id uint64) sdk.Error {
+	transfer, found := k.GetTransferOut(ctx, id)
+	if !found {
+		return ErrTransferNotFound(k.codespace, id)
+	}
+	k.deleteTransferOut(ctx, transfer)
+	return nil
+}
+
+// refundTransferOut returns the escrowed tokens of a transfer to its sender and deletes it
+func (k Keeper) refundTransferOut(ctx sdk.Context, id uint64) (TransferOut, sdk.Error) {
+	transfer, found := k.GetTransferOut(ctx, id)
+	if !found {
+		return TransferOut{}, ErrTransferNotFound(k.codespace, id)
+	}
+	if _, err := k.ck.SendCoins(ctx, sdk.GetPegAccount(), transfer.From, sdk.Coins{transfer.Amount}); err != nil {
+		return TransferOut{}, err
+	}
+	k.addAddrs(ctx, transfer.From)
+	k.deleteTransferOut(ctx, transfer)
+	if binding, found := k.GetBinding(ctx, transfer.Amount.Denom); found {
+		k.updateLockedAmount(ctx, binding, -transfer.Amount.Amount)
+	}
+	return transfer, nil
+}
+
+func newRefundEvent(transfer TransferOut, reason string) sdk.Event {
+	return sdk.NewEvent(EventTypeRefund,
+		sdk.NewAttribute(AttributeKeyTransferId, strconv.FormatUint(transfer.Id, 10)),
+		sdk.NewAttribute(AttributeKeySymbol, transfer.Amount.Denom),
+		sdk.NewAttribute(AttributeKeyAmount, strconv.FormatInt(transfer.Amount.Amount, 10)),
+		sdk.NewAttribute(AttributeKeyReceiver, transfer.From.String()),
+		sdk.NewAttribute(AttributeKeyRefundReason, reason),
+	)
+}
+
+// TransferIn releases the tokens of a transfer in package from the peg account to its receiver
+func (k Keeper) TransferIn(ctx sdk.Context, pack TransferInSynPackage) sdk.Error {
+	symbol := bytesToSymbol(pack.TokenSymbol)
+	// the tokens transferred out before the token was unbound can still come back
+	binding, found := k.GetBinding(ctx, symbol)
+	if !found || (binding.Status != BindStatusBound && binding.Status != BindStatusUnbound) {
+		return ErrBindingNotFound(k.codespace, symbol)
+	}
+	if binding.ContractAddress != pack.ContractAddr {
+		return ErrInvalidTransfer(k.codespace, fmt.Sprintf("contract %s is not bound to token %s", pack.ContractAddr.String(), symbol))
+	}
+	if pack.ExpireTime < uint64(ctx.BlockHeader().Time.Unix()) {
+		return ErrInvalidTransfer(k.codespace, fmt.Sprintf("transfer expired at %d", pack.ExpireTime))
+	}
+	if len(pack.Receiver) != sdk.AddrLen {
+		return ErrInvalidTransfer(k.codespace, "invalid receiver address")
+	}
+	if pack.Amount == nil || pack.Amount.Sign() <= 0 || pack.Amount.Cmp(maxTransferInAmount) > 0 {
+		return ErrInvalidTransfer(k.codespace, "amount should be positive and fit in int64 on the beacon chain")
+	}
+	amount := bsc.ConvertBSCAmountToBCAmount(pack.Amount)
+	if amount <= 0 {
+		return ErrInvalidTransfer(k.codespace, fmt.Sprintf("amount %s is less than the smallest unit", pack.Amount.String()))
+	}
+	if amount > binding.LockedAmount {
+		return ErrInvalidTransfer(k.codespace, fmt.Sprintf("amount %d exceeds the locked amount %d", amount, binding.LockedAmount))
+	}
+
+	if _, err := k.ck.SendCoins(ctx, sdk.GetPegAccount(), pack.Receiver, sdk.Coins{sdk.NewCoin(symbol, amount)}); err != nil {
+		return err
+	}
+	k.addAddrs(ctx, pack.Receiver)
+	k.updateLockedAmount(ctx, binding, -amount)
+	return nil
+}
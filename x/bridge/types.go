@@ -0,0 +1,113 @@
+package bridge
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type BindStatus int8
+
+const (
+	BindStatusPending BindStatus = 0 // waiting for the side chain to approve
+	BindStatusBound   BindStatus = 1
+	BindStatusUnbound BindStatus = 2 // waiting for the tokens on the side chain to be transferred back in
+)
+
+// Binding of an issued token to a token contract of a side chain. LockedAmount is held in the peg account
+// for the binding: the amount locked by the bind plus the amount transferred out and not transferred back.
+type Binding struct {
+	Symbol           string         `json:"symbol"`
+	Owner            sdk.AccAddress `json:"owner"`
+	SideChainId      string         `json:"side_chain_id"`
+	ContractAddress  bsc.Address    `json:"contract_address"`
+	ContractDecimals int8           `json:"contract_decimals"`
+	LockedAmount     int64          `json:"locked_amount"`
+	ExpireTime       int64          `json:"expire_time"`
+	Status           BindStatus     `json:"status"`
+}
+
+// TokenMapper gives access to the issued tokens, it is implemented by the token module of the node
+type TokenMapper interface {
+	GetTokenOwner(ctx sdk.Context, symbol string) (sdk.AccAddress, bool)
+	GetTokenTotalSupply(ctx sdk.Context, symbol string) int64
+}
+
+type BindPackageType uint8
+
+const (
+	BindTypeBind   BindPackageType = 0
+	BindTypeUnbind BindPackageType = 1
+)
+
+// BindSynPackage is sent to the side chain to bind or unbind a token
+type BindSynPackage struct {
+	PackageType  BindPackageType
+	TokenSymbol  [32]byte
+	ContractAddr bsc.Address
+	TotalSupply  *big.Int
+	PeggyAmount  *big.Int
+	Decimals     uint8
+	ExpireTime   uint64
+}
+
+const BindStatusApproved uint32 = 0
+
+// ApproveBindSynPackage is sent by the side chain to approve or reject a bind package
+type ApproveBindSynPackage struct {
+	Status      uint32
+	TokenSymbol [32]byte
+}
+
+func symbolToBytes(symbol string) [32]byte {
+	var bz [32]byte
+	copy(bz[:], symbol)
+	return bz
+}
+
+func bytesToSymbol(bz [32]byte) string {
+	return string(bytes.TrimRight(bz[:], "\x00"))
+}
+
+// TransferOut is a transfer of tokens to a side chain waiting for its ack, the tokens are escrowed in the
+// peg account and refunded to From if the side chain rejects the transfer or does not ack it in time
+type TransferOut struct {
+	Id          uint64         `json:"id"`
+	From        sdk.AccAddress `json:"from"`
+	SideChainId string         `json:"side_chain_id"`
+	To          bsc.Address    `json:"to"`
+	Amount      sdk.Coin       `json:"amount"`
+	ExpireTime  int64          `json:"expire_time"`
+}
+
+// TransferOutSynPackage is sent to the side chain to release the transferred tokens from the token contract
+type TransferOutSynPackage struct {
+	TransferId   uint64
+	TokenSymbol  [32]byte
+	ContractAddr bsc.Address
+	Recipient    bsc.Address
+	Amount       *big.Int
+	ExpireTime   uint64
+}
+
+// TransferOutAckCodeTimeout is the code the side chain acks an expired transfer out package with
+const TransferOutAckCodeTimeout uint32 = 1
+
+// TransferOutAckPackage is sent by the side chain to ack a transfer out package, the transfer is refunded
+// unless Code is 0
+type TransferOutAckPackage struct {
+	TransferId uint64
+	Code       uint32
+}
+
+// TransferInSynPackage is sent by the side chain to release the tokens locked in the token contract to
+// a receiver, it is answered with a common ack package
+type TransferInSynPackage struct {
+	TokenSymbol  [32]byte
+	ContractAddr bsc.Address
+	Amount       *big.Int
+	Receiver     sdk.AccAddress
+	ExpireTime   uint64
+}
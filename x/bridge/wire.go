@@ -0,0 +1,17 @@
+package bridge
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgBind{}, "cosmos-sdk/MsgBind", nil)
+	cdc.RegisterConcrete(MsgUnbind{}, "cosmos-sdk/MsgUnbind", nil)
+	cdc.RegisterConcrete(MsgTransferOut{}, "cosmos-sdk/MsgTransferOut", nil)
+}
+
+var msgCdc = codec.New()
+
+func init() {
+	RegisterCodec(msgCdc)
+}
@@ -20,6 +20,20 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 	k.SetPreviousProposerConsAddr(ctx, consAddr)
 }
 
+// apply the governance decisions of the previous blocks
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	if sdk.IsUpgrade(sdk.WithdrawAddrBans) {
+		k.ExecuteWithdrawAddrBansProposals(ctx)
+	}
+	if sdk.IsUpgrade(sdk.DistrParamsChange) {
+		k.ExecuteDistrParamsChangeProposals(ctx)
+	}
+	if sdk.IsUpgrade(sdk.AutoDistribution) {
+		k.DistributeRewards(ctx)
+	}
+	k.SweepStaleRewards(ctx)
+}
+
 // percent precommit votes for the previous block
 func getPreviousPercentPrecommitVotes(req abci.RequestBeginBlock) sdk.Dec {
 
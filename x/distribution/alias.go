@@ -8,25 +8,46 @@ import (
 )
 
 type (
-	Keeper = keeper.Keeper
-	Hooks  = keeper.Hooks
+	Keeper                 = keeper.Keeper
+	Hooks                  = keeper.Hooks
+	WithdrawAddrBansHooks  = keeper.WithdrawAddrBansHooks
+	DistrParamsChangeHooks = keeper.DistrParamsChangeHooks
 
 	DelegatorWithdrawInfo = types.DelegatorWithdrawInfo
 	DelegationDistInfo    = types.DelegationDistInfo
 	ValidatorDistInfo     = types.ValidatorDistInfo
 	TotalAccum            = types.TotalAccum
 	FeePool               = types.FeePool
+	WithdrawAddrBans      = types.WithdrawAddrBans
+	DistrParamsChange     = types.DistrParamsChange
+
+	CrossStakeRewardPackage  = types.CrossStakeRewardPackage
+	SideChainReward          = types.SideChainReward
+	DelegatorSideChainReward = types.DelegatorSideChainReward
+	DelegationAccumDebug     = types.DelegationAccumDebug
+	RewardAtRisk             = types.RewardAtRisk
 
 	MsgSetWithdrawAddress          = types.MsgSetWithdrawAddress
 	MsgWithdrawDelegatorRewardsAll = types.MsgWithdrawDelegatorRewardsAll
 	MsgWithdrawDelegatorReward     = types.MsgWithdrawDelegatorReward
 	MsgWithdrawValidatorRewardsAll = types.MsgWithdrawValidatorRewardsAll
+	MsgSetCommissionAgreement      = types.MsgSetCommissionAgreement
+	MsgRemoveCommissionAgreement   = types.MsgRemoveCommissionAgreement
+	CommissionAgreement            = types.CommissionAgreement
+
+	DelegationWithdrawInfo = types.DelegationWithdrawInfo
+	WithdrawAddrs          = types.WithdrawAddrs
+	DelegationReward       = types.DelegationReward
+	DelegatorRewards       = types.DelegatorRewards
+	ValidatorCommission    = types.ValidatorCommission
 
 	GenesisState = types.GenesisState
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper                 = keeper.NewKeeper
+	NewWithdrawAddrBansHooks  = keeper.NewWithdrawAddrBansHooks
+	NewDistrParamsChangeHooks = keeper.NewDistrParamsChangeHooks
 
 	GetValidatorDistInfoKey     = keeper.GetValidatorDistInfoKey
 	GetDelegationDistInfoKey    = keeper.GetDelegationDistInfoKey
@@ -37,8 +58,18 @@ var (
 	DelegationDistInfoKey       = keeper.DelegationDistInfoKey
 	DelegatorWithdrawInfoKey    = keeper.DelegatorWithdrawInfoKey
 	ProposerKey                 = keeper.ProposerKey
+	GetSideChainRewardKey       = keeper.GetSideChainRewardKey
+	GetDelegatorSideRewardKey   = keeper.GetDelegatorSideRewardKey
+	SideChainRewardKey          = keeper.SideChainRewardKey
+	DelegatorSideRewardKey      = keeper.DelegatorSideRewardKey
+	GetBannedWithdrawAddrKey    = keeper.GetBannedWithdrawAddrKey
+	BannedWithdrawAddrKey       = keeper.BannedWithdrawAddrKey
 	DefaultParamspace           = keeper.DefaultParamspace
 
+	GetDelegationWithdrawAddrKey  = keeper.GetDelegationWithdrawAddrKey
+	GetDelegationWithdrawAddrsKey = keeper.GetDelegationWithdrawAddrsKey
+	DelegationWithdrawKey         = keeper.DelegationWithdrawKey
+
 	InitialFeePool = types.InitialFeePool
 
 	NewGenesisState              = types.NewGenesisState
@@ -51,17 +82,29 @@ var (
 	NewMsgWithdrawDelegatorRewardsAll = types.NewMsgWithdrawDelegatorRewardsAll
 	NewMsgWithdrawDelegatorReward     = types.NewMsgWithdrawDelegatorReward
 	NewMsgWithdrawValidatorRewardsAll = types.NewMsgWithdrawValidatorRewardsAll
+	NewMsgSetCommissionAgreement      = types.NewMsgSetCommissionAgreement
+	NewMsgRemoveCommissionAgreement   = types.NewMsgRemoveCommissionAgreement
+
+	NewMsgSetDelegationWithdrawAddress = types.NewMsgSetDelegationWithdrawAddress
 )
 
 const (
-	DefaultCodespace = types.DefaultCodespace
-	CodeInvalidInput = types.CodeInvalidInput
+	DefaultCodespace     = types.DefaultCodespace
+	CodeInvalidInput     = types.CodeInvalidInput
+	CodeInvalidPackage   = types.CodeInvalidPackage
+	CodeInvalidSideChain = types.CodeInvalidSideChain
+	CodeNoWithdrawPubKey = types.CodeNoWithdrawPubKey
+
+	ChannelName = keeper.ChannelName
+	ChannelId   = keeper.ChannelId
 )
 
 var (
-	ErrNilDelegatorAddr = types.ErrNilDelegatorAddr
-	ErrNilWithdrawAddr  = types.ErrNilWithdrawAddr
-	ErrNilValidatorAddr = types.ErrNilValidatorAddr
+	ErrNilDelegatorAddr   = types.ErrNilDelegatorAddr
+	ErrNilWithdrawAddr    = types.ErrNilWithdrawAddr
+	ErrNilValidatorAddr   = types.ErrNilValidatorAddr
+	ErrInvalidPackage     = types.ErrInvalidPackage
+	ErrInvalidSideChainId = types.ErrInvalidSideChainId
 )
 
 var (
@@ -69,6 +112,8 @@ var (
 	ActionWithdrawDelegatorRewardsAll = tags.ActionWithdrawDelegatorRewardsAll
 	ActionWithdrawDelegatorReward     = tags.ActionWithdrawDelegatorReward
 	ActionWithdrawValidatorRewardsAll = tags.ActionWithdrawValidatorRewardsAll
+	ActionSetCommissionAgreement      = tags.ActionSetCommissionAgreement
+	ActionRemoveCommissionAgreement   = tags.ActionRemoveCommissionAgreement
 
 	TagAction    = tags.Action
 	TagValidator = tags.Validator
@@ -0,0 +1,39 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// NewAnteHandler returns an AnteHandler which rejects the txs setting the withdraw address of a delegation
+// to an account whose public key is not known on chain, before handing the tx to anteHandler. The rewards
+// of specific delegations are meant for cold wallets, which must have signed a tx once to prove they are
+// controlled by a key and the address is not mistyped.
+func NewAnteHandler(am auth.AccountKeeper, anteHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		if sdk.IsUpgrade(sdk.DelegationWithdraw) {
+			if err := checkDelegationWithdrawAddrs(ctx, am, tx); err != nil {
+				return ctx, err.Result(), true
+			}
+		}
+		return anteHandler(ctx, tx, mode)
+	}
+}
+
+func checkDelegationWithdrawAddrs(ctx sdk.Context, am auth.AccountKeeper, tx sdk.Tx) sdk.Error {
+	for _, msg := range tx.GetMsgs() {
+		setMsg, ok := msg.(types.MsgSetWithdrawAddress)
+		if !ok || setMsg.ValidatorAddr.Empty() || setMsg.WithdrawAddr.Equals(setMsg.DelegatorAddr) {
+			continue
+		}
+		acc := am.GetAccount(ctx, setMsg.WithdrawAddr)
+		if acc == nil || acc.GetPubKey() == nil {
+			return types.ErrNoWithdrawPubKey(types.DefaultCodespace,
+				fmt.Sprintf("the public key of withdraw address %s is unknown, it must sign a tx first", setMsg.WithdrawAddr))
+		}
+	}
+	return nil
+}
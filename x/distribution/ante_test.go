@@ -0,0 +1,68 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+)
+
+func TestDelegationWithdrawAddrCheck(t *testing.T) {
+	ctx, am, _, _, _ := keeper.CreateTestInputDefault(t, false, 0)
+	delAddr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	valAddr := sdk.ValAddress(ed25519.GenPrivKey().PubKey().Address())
+	unknown := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	keyless := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	am.SetAccount(ctx, am.NewAccountWithAddress(ctx, keyless))
+	signedPk := ed25519.GenPrivKey().PubKey()
+	signed := sdk.AccAddress(signedPk.Address())
+	acc := am.NewAccountWithAddress(ctx, signed)
+	require.Nil(t, acc.SetPubKey(signedPk))
+	am.SetAccount(ctx, acc)
+
+	passed := false
+	anteHandler := NewAnteHandler(am, func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (sdk.Context, sdk.Result, bool) {
+		passed = true
+		return ctx, sdk.Result{}, false
+	})
+	run := func(msg sdk.Msg) (sdk.Result, bool) {
+		passed = false
+		_, res, abort := anteHandler(ctx, auth.NewStdTx([]sdk.Msg{msg}, nil, "", 0, nil), sdk.RunTxModeDeliver)
+		return res, abort
+	}
+
+	// nothing is checked before the upgrade
+	_, abort := run(NewMsgSetDelegationWithdrawAddress(delAddr, valAddr, unknown))
+	require.False(t, abort)
+	require.True(t, passed)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.DelegationWithdraw, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.DelegationWithdraw)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// the withdraw address of a delegation must have a known public key
+	for _, addr := range []sdk.AccAddress{unknown, keyless} {
+		res, abort := run(NewMsgSetDelegationWithdrawAddress(delAddr, valAddr, addr))
+		require.True(t, abort)
+		require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNoWithdrawPubKey), res.Code)
+		require.False(t, passed)
+	}
+	_, abort = run(NewMsgSetDelegationWithdrawAddress(delAddr, valAddr, signed))
+	require.False(t, abort)
+	require.True(t, passed)
+
+	// resetting the withdraw address of a delegation and the withdraw address of the delegator are not checked
+	_, abort = run(NewMsgSetDelegationWithdrawAddress(delAddr, valAddr, delAddr))
+	require.False(t, abort)
+	require.True(t, passed)
+	_, abort = run(NewMsgSetWithdrawAddress(delAddr, unknown))
+	require.False(t, abort)
+	require.True(t, passed)
+}
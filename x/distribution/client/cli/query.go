@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+)
+
+// GetCmdQueryWithdrawAddrs implements the command to query the withdraw addresses of a delegator
+func GetCmdQueryWithdrawAddrs(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-addrs [delegator-addr]",
+		Short: "Query the withdraw address of a delegator and the withdraw addresses of its delegations",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(distribution.QueryWithdrawAddrsParams{DelegatorAddr: delAddr})
+			if err != nil {
+				return err
+			}
+			response, err := cliCtx.QueryWithData("custom/distr/"+distribution.QueryWithdrawAddrs, bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -20,6 +20,7 @@ import (
 var (
 	flagOnlyFromValidator = "only-from-validator"
 	flagIsValidator       = "is-validator"
+	flagValidator         = "validator"
 )
 
 // command to withdraw rewards
@@ -85,7 +86,7 @@ func GetCmdWithdrawRewards(cdc *codec.Codec) *cobra.Command {
 func GetCmdSetWithdrawAddr(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set-withdraw-addr [withdraw-addr]",
-		Short: "change the default withdraw address for rewards associated with an address",
+		Short: "change the default withdraw address for rewards associated with an address, or only for its delegation to --validator",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -105,6 +106,83 @@ func GetCmdSetWithdrawAddr(cdc *codec.Codec) *cobra.Command {
 			}
 
 			msg := types.NewMsgSetWithdrawAddress(delAddr, withdrawAddr)
+			if validator := viper.GetString(flagValidator); validator != "" {
+				valAddr, err := sdk.ValAddressFromBech32(validator)
+				if err != nil {
+					return err
+				}
+				msg = types.NewMsgSetDelegationWithdrawAddress(delAddr, valAddr, withdrawAddr)
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagValidator, "", "only set the withdraw address of the delegation to this validator address (in bech), the delegator address resets it to the default withdraw address of the delegator")
+	return cmd
+}
+
+// command to set the commission rate a validator charges a delegator
+func GetCmdSetCommissionAgreement(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-commission-agreement [delegator-addr] [rate]",
+		Short: "set the commission rate the validator charges a delegator, it must be below the commission rate of the validator",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			addr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			rate, err := sdk.NewDecFromStr(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetCommissionAgreement(sdk.ValAddress(addr.Bytes()), delAddr, rate)
+
+			// build and sign the transaction, then broadcast to Tendermint
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// command to remove the commission agreement of a validator with a delegator
+func GetCmdRemoveCommissionAgreement(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-commission-agreement [delegator-addr]",
+		Short: "remove the commission agreement of the validator with a delegator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			addr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRemoveCommissionAgreement(sdk.ValAddress(addr.Bytes()), delAddr)
 
 			// build and sign the transaction, then broadcast to Tendermint
 			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+)
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	// Get the rewards the delegations of a delegator would withdraw now
+	r.HandleFunc(
+		"/distribution/delegators/{delegatorAddr}/rewards",
+		delegatorRewardsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
+	// Get the commission a validator would withdraw now
+	r.HandleFunc(
+		"/distribution/validators/{validatorAddr}/commission",
+		validatorCommissionHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+}
+
+// HTTP request handler to query the outstanding rewards of a delegator
+func delegatorRewardsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		delegatorAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["delegatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cdc.MarshalJSON(distribution.QueryDelegatorRewardsParams{DelegatorAddr: delegatorAddr})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/distr/"+distribution.QueryDelegatorRewards, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// HTTP request handler to query the outstanding commission of a validator
+func validatorCommissionHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		validatorAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cdc.MarshalJSON(distribution.QueryValidatorCommissionParams{ValidatorAddr: validatorAddr})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/distr/"+distribution.QueryValidatorCommission, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -0,0 +1,13 @@
+package rest
+
+import (
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes registers distribution-related REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	registerQueryRoutes(cliCtx, r, cdc)
+}
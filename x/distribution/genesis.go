@@ -11,6 +11,17 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	keeper.SetCommunityTax(ctx, data.CommunityTax)
 	keeper.SetBaseProposerReward(ctx, data.BaseProposerReward)
 	keeper.SetBonusProposerReward(ctx, data.BonusProposerReward)
+	if len(data.FeePoolDenoms) > 0 {
+		keeper.SetFeePoolDenoms(ctx, data.FeePoolDenoms)
+		keeper.SetBurnUnlistedFees(ctx, data.BurnUnlistedFees)
+	}
+	if data.RewardClaimDeadline > 0 {
+		keeper.SetRewardClaimDeadline(ctx, data.RewardClaimDeadline)
+	}
+	if data.AutoDistInterval > 0 {
+		keeper.SetAutoDistInterval(ctx, data.AutoDistInterval)
+		keeper.SetAutoDistBatchSize(ctx, data.AutoDistBatchSize)
+	}
 
 	for _, vdi := range data.ValidatorDistInfos {
 		keeper.SetValidatorDistInfo(ctx, vdi)
@@ -21,6 +32,15 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	for _, dw := range data.DelegatorWithdrawInfos {
 		keeper.SetDelegatorWithdrawAddr(ctx, dw.DelegatorAddr, dw.WithdrawAddr)
 	}
+	for _, addr := range data.BannedWithdrawAddrs {
+		keeper.BanWithdrawAddr(ctx, addr)
+	}
+	for _, agreement := range data.CommissionAgreements {
+		keeper.InitCommissionAgreement(ctx, agreement)
+	}
+	for _, dw := range data.DelegationWithdrawInfos {
+		keeper.SetDelegationWithdrawAddr(ctx, dw.DelegatorAddr, dw.ValidatorAddr, dw.WithdrawAddr)
+	}
 }
 
 // WriteGenesis returns a GenesisState for a given context and keeper. The
@@ -33,6 +53,26 @@ func WriteGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	vdis := keeper.GetAllValidatorDistInfos(ctx)
 	ddis := keeper.GetAllDelegationDistInfos(ctx)
 	dwis := keeper.GetAllDelegatorWithdrawInfos(ctx)
-	return NewGenesisState(feePool, communityTax, baseProposerRewards,
+	genesis := NewGenesisState(feePool, communityTax, baseProposerRewards,
 		bonusProposerRewards, vdis, ddis, dwis)
+	genesis.FeePoolDenoms = keeper.GetFeePoolDenoms(ctx)
+	genesis.BurnUnlistedFees = keeper.GetBurnUnlistedFees(ctx)
+	genesis.RewardClaimDeadline = keeper.GetRewardClaimDeadline(ctx)
+	genesis.AutoDistInterval = keeper.GetAutoDistInterval(ctx)
+	if genesis.AutoDistInterval > 0 {
+		genesis.AutoDistBatchSize = keeper.GetAutoDistBatchSize(ctx)
+	}
+	keeper.IterateBannedWithdrawAddrs(ctx, func(addr sdk.AccAddress) bool {
+		genesis.BannedWithdrawAddrs = append(genesis.BannedWithdrawAddrs, addr)
+		return false
+	})
+	keeper.IterateCommissionAgreements(ctx, nil, func(agreement types.CommissionAgreement) bool {
+		genesis.CommissionAgreements = append(genesis.CommissionAgreements, agreement)
+		return false
+	})
+	keeper.IterateDelegationWithdrawAddrs(ctx, nil, func(info types.DelegationWithdrawInfo) bool {
+		genesis.DelegationWithdrawInfos = append(genesis.DelegationWithdrawInfos, info)
+		return false
+	})
+	return genesis
 }
@@ -1,6 +1,8 @@
 package distribution
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	"github.com/cosmos/cosmos-sdk/x/distribution/tags"
@@ -19,6 +21,10 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgWithdrawDelegatorReward(ctx, msg, k)
 		case types.MsgWithdrawValidatorRewardsAll:
 			return handleMsgWithdrawValidatorRewardsAll(ctx, msg, k)
+		case types.MsgSetCommissionAgreement:
+			return handleMsgSetCommissionAgreement(ctx, msg, k)
+		case types.MsgRemoveCommissionAgreement:
+			return handleMsgRemoveCommissionAgreement(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in distribution module").Result()
 		}
@@ -31,15 +37,23 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 // now we just perform action and save
 
 func handleMsgModifyWithdrawAddress(ctx sdk.Context, msg types.MsgSetWithdrawAddress, k keeper.Keeper) sdk.Result {
+	if !msg.ValidatorAddr.Empty() && !sdk.IsUpgrade(sdk.DelegationWithdraw) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("withdraw addresses of delegations are not supported before %s", sdk.DelegationWithdraw)).Result()
+	}
 
-	k.SetDelegatorWithdrawAddr(ctx, msg.DelegatorAddr, msg.WithdrawAddr)
+	if err := k.SetWithdrawAddr(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.WithdrawAddr); err != nil {
+		return err.Result()
+	}
 
-	tags := sdk.NewTags(
+	resTags := sdk.NewTags(
 		tags.Action, tags.ActionModifyWithdrawAddress,
 		tags.Delegator, []byte(msg.DelegatorAddr.String()),
 	)
+	if !msg.ValidatorAddr.Empty() {
+		resTags = resTags.AppendTag(tags.Validator, []byte(msg.ValidatorAddr.String()))
+	}
 	return sdk.Result{
-		Tags: tags,
+		Tags: resTags,
 	}
 }
 
@@ -88,3 +102,45 @@ func handleMsgWithdrawValidatorRewardsAll(ctx sdk.Context, msg types.MsgWithdraw
 		Tags: tags,
 	}
 }
+
+func handleMsgSetCommissionAgreement(ctx sdk.Context, msg types.MsgSetCommissionAgreement, k keeper.Keeper) sdk.Result {
+	if !sdk.IsUpgrade(sdk.CommissionAgreements) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("commission agreements are not supported before %s", sdk.CommissionAgreements)).Result()
+	}
+
+	err := k.SetCommissionAgreement(ctx, types.NewCommissionAgreement(msg.ValidatorAddr, msg.DelegatorAddr, msg.Rate))
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionSetCommissionAgreement,
+		tags.Validator, []byte(msg.ValidatorAddr.String()),
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags:   tags,
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgRemoveCommissionAgreement(ctx sdk.Context, msg types.MsgRemoveCommissionAgreement, k keeper.Keeper) sdk.Result {
+	if !sdk.IsUpgrade(sdk.CommissionAgreements) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("commission agreements are not supported before %s", sdk.CommissionAgreements)).Result()
+	}
+
+	err := k.RemoveCommissionAgreement(ctx, msg.ValidatorAddr, msg.DelegatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionRemoveCommissionAgreement,
+		tags.Validator, []byte(msg.ValidatorAddr.String()),
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags:   tags,
+		Events: ctx.EventManager().Events(),
+	}
+}
@@ -16,6 +16,7 @@ func (k Keeper) AllocateTokens(ctx sdk.Context, percentVotes sdk.Dec, proposer s
 	// get the fees which have been getting collected through all the
 	// transactions in the block
 	feesCollected := k.feeCollectionKeeper.GetCollectedFees(ctx)
+	feesCollected, unlistedFees := k.splitUnlistedFees(ctx, feesCollected)
 	feesCollectedDec := types.NewDecCoins(feesCollected)
 
 	// allocated rewards to proposer
@@ -36,6 +37,11 @@ func (k Keeper) AllocateTokens(ctx sdk.Context, percentVotes sdk.Dec, proposer s
 	feePool := k.GetFeePool(ctx)
 	feePool.CommunityPool = feePool.CommunityPool.Plus(communityFunding)
 
+	// the fees in unlisted denoms go to the community pool, or are burned by being dropped
+	if len(unlistedFees) > 0 && !k.GetBurnUnlistedFees(ctx) {
+		feePool.CommunityPool = feePool.CommunityPool.Plus(types.NewDecCoins(unlistedFees))
+	}
+
 	// set the global pool within the distribution module
 	poolReceived := feesCollectedDec.Minus(proposerReward).Minus(communityFunding)
 	feePool.Pool = feePool.Pool.Plus(poolReceived)
@@ -46,3 +52,51 @@ func (k Keeper) AllocateTokens(ctx sdk.Context, percentVotes sdk.Dec, proposer s
 	// clear the now distributed fees
 	k.feeCollectionKeeper.ClearCollectedFees(ctx)
 }
+
+// split the fees into the ones accepted into the fee pool and the ones in unlisted denoms
+func (k Keeper) splitUnlistedFees(ctx sdk.Context, fees sdk.Coins) (listed, unlisted sdk.Coins) {
+	denoms := k.GetFeePoolDenoms(ctx)
+	if len(denoms) == 0 {
+		return fees, nil
+	}
+
+	whitelist := make(map[string]bool, len(denoms))
+	for _, denom := range denoms {
+		whitelist[denom] = true
+	}
+	for _, fee := range fees {
+		if whitelist[fee.Denom] {
+			listed = append(listed, fee)
+		} else {
+			unlisted = append(unlisted, fee)
+		}
+	}
+	return listed, unlisted
+}
+
+// AllocateCommunityPoolToValidator moves amt from the community pool to the pool of a validator, the
+// commission of the validator applied. Nothing is moved if the community pool falls short.
+func (k Keeper) AllocateCommunityPoolToValidator(ctx sdk.Context, valAddr sdk.ValAddress, amt sdk.Coins) bool {
+	validator := k.stakeKeeper.Validator(ctx, valAddr)
+	if validator == nil {
+		return false
+	}
+
+	feePool := k.GetFeePool(ctx)
+	for _, coin := range amt {
+		if feePool.CommunityPool.AmountOf(coin.Denom).LT(sdk.NewDecFromInt(coin.Amount)) {
+			return false
+		}
+	}
+
+	reward := types.NewDecCoins(amt)
+	commission := reward.MulDec(validator.GetCommission())
+	vdi := k.GetValidatorDistInfo(ctx, valAddr)
+	vdi.PoolCommission = vdi.PoolCommission.Plus(commission)
+	vdi.Pool = vdi.Pool.Plus(reward.Minus(commission))
+	feePool.CommunityPool = feePool.CommunityPool.Minus(reward)
+
+	k.SetValidatorDistInfo(ctx, vdi)
+	k.SetFeePool(ctx, feePool)
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -108,3 +109,63 @@ func TestAllocateTokensWithPartialPrecommitPower(t *testing.T) {
 	require.Equal(t, 1, len(feePool.Pool))
 	require.True(sdk.DecEq(t, expRes, feePool.Pool[0].Amount))
 }
+
+func TestAllocateTokensWithFeePoolDenoms(t *testing.T) {
+	ctx, _, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	_, _ = sk.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	keeper.SetFeePoolDenoms(ctx, []string{denom})
+	require.Equal(t, []string{denom}, keeper.GetFeePoolDenoms(ctx))
+	require.False(t, keeper.GetBurnUnlistedFees(ctx))
+
+	// the dust denom goes to the community pool
+	feeInputs := sdk.NewDecWithoutFra(100).RawInt()
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin("dust", feeInputs), sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+
+	feePool := keeper.GetFeePool(ctx)
+	require.Equal(t, 1, len(feePool.Pool))
+	require.Equal(t, denom, feePool.Pool[0].Denom)
+	require.True(sdk.DecEq(t, sdk.NewDecFromInt(feeInputs), feePool.CommunityPool.AmountOf("dust")))
+
+	// the dust denom is burned
+	keeper.SetBurnUnlistedFees(ctx, true)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin("dust", feeInputs), sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+
+	feePool = keeper.GetFeePool(ctx)
+	require.Equal(t, 1, len(feePool.Pool))
+	require.True(sdk.DecEq(t, sdk.NewDecFromInt(feeInputs), feePool.CommunityPool.AmountOf("dust")))
+}
+
+func TestAllocateCommunityPoolToValidator(t *testing.T) {
+	ctx, _, keeper, sk, _ := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	_, _ = sk.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	feePool := keeper.GetFeePool(ctx)
+	feePool.CommunityPool = types.DecCoins{types.NewDecCoin(denom, 100)}
+	keeper.SetFeePool(ctx, feePool)
+
+	reward := sdk.Coins{sdk.NewCoin(denom, 60)}
+	require.True(t, keeper.AllocateCommunityPoolToValidator(ctx, valOpAddr1, reward))
+	require.True(sdk.DecEq(t, sdk.NewDec(40), keeper.GetFeePool(ctx).CommunityPool.AmountOf(denom)))
+	vdi := keeper.GetValidatorDistInfo(ctx, valOpAddr1)
+	require.True(sdk.DecEq(t, sdk.NewDec(60), vdi.Pool.AmountOf(denom).Add(vdi.PoolCommission.AmountOf(denom))))
+
+	// the community pool falls short, or the validator does not exist
+	require.False(t, keeper.AllocateCommunityPoolToValidator(ctx, valOpAddr1, reward))
+	require.False(t, keeper.AllocateCommunityPoolToValidator(ctx, valOpAddr2, sdk.Coins{sdk.NewCoin(denom, 1)}))
+	require.True(sdk.DecEq(t, sdk.NewDec(40), keeper.GetFeePool(ctx).CommunityPool.AmountOf(denom)))
+}
@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// Returns the number of blocks between two automatic distributions of the rewards of all the delegations,
+// the rewards are only distributed when they are withdrawn if 0
+func (k Keeper) GetAutoDistInterval(ctx sdk.Context) (blocks int64) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyAutoDistInterval, &blocks)
+	return
+}
+
+// nolint: errcheck
+func (k Keeper) SetAutoDistInterval(ctx sdk.Context, blocks int64) {
+	k.paramSpace.Set(ctx, ParamStoreKeyAutoDistInterval, &blocks)
+}
+
+// Returns the max number of delegations an automatic distribution settles in a block
+func (k Keeper) GetAutoDistBatchSize(ctx sdk.Context) int64 {
+	if size := k.getAutoDistBatchSizeParam(ctx); size > 0 {
+		return size
+	}
+	return types.DefaultAutoDistBatchSize
+}
+
+// getAutoDistBatchSizeParam returns the batch size param as set, 0 for the default
+func (k Keeper) getAutoDistBatchSizeParam(ctx sdk.Context) (size int64) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyAutoDistBatchSize, &size)
+	return
+}
+
+// nolint: errcheck
+func (k Keeper) SetAutoDistBatchSize(ctx sdk.Context, size int64) {
+	k.paramSpace.Set(ctx, ParamStoreKeyAutoDistBatchSize, &size)
+}
+
+// getAutoDistCursor returns the key of the next delegation distribution info of the automatic distribution
+// in progress, if any
+func (k Keeper) getAutoDistCursor(ctx sdk.Context) (cursor []byte, inProgress bool) {
+	cursor = ctx.KVStore(k.storeKey).Get(AutoDistributionKey)
+	return cursor, cursor != nil
+}
+
+func (k Keeper) setAutoDistCursor(ctx sdk.Context, cursor []byte) {
+	store := ctx.KVStore(k.storeKey)
+	if cursor == nil {
+		store.Delete(AutoDistributionKey)
+		return
+	}
+	store.Set(AutoDistributionKey, cursor)
+}
+
+// DistributeRewards withdraws the rewards of all the delegations to their withdraw addresses every auto
+// distribution interval. A distribution settles at most a batch of delegations in a block, it goes on in
+// the next blocks until all the delegations are settled.
+func (k Keeper) DistributeRewards(ctx sdk.Context) {
+	interval := k.GetAutoDistInterval(ctx)
+	cursor, inProgress := k.getAutoDistCursor(ctx)
+	if interval <= 0 {
+		if inProgress {
+			k.setAutoDistCursor(ctx, nil)
+		}
+		return
+	}
+	if !inProgress {
+		height := ctx.BlockHeight()
+		if height == 0 || height%interval != 0 {
+			return
+		}
+		cursor = DelegationDistInfoKey
+	}
+
+	batchSize := k.GetAutoDistBatchSize(ctx)
+	var batch []types.DelegationDistInfo
+	var next []byte
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(cursor, sdk.PrefixEndBytes(DelegationDistInfoKey))
+	for ; iterator.Valid(); iterator.Next() {
+		if int64(len(batch)) == batchSize {
+			next = iterator.Key()
+			break
+		}
+		var ddi types.DelegationDistInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &ddi)
+		batch = append(batch, ddi)
+	}
+	iterator.Close()
+	k.setAutoDistCursor(ctx, next)
+
+	for _, delInfo := range batch {
+		k.distributeDelegationReward(ctx, delInfo)
+	}
+}
+
+// distributeDelegationReward withdraws the rewards of a delegation to its withdraw address
+func (k Keeper) distributeDelegationReward(ctx sdk.Context, delInfo types.DelegationDistInfo) {
+	delAddr, valAddr := delInfo.DelegatorAddr, delInfo.ValOperatorAddr
+	if k.stakeKeeper.Validator(ctx, valAddr) == nil || k.stakeKeeper.Delegation(ctx, delAddr, valAddr) == nil ||
+		!k.HasValidatorDistInfo(ctx, valAddr) {
+		// the records are left over, there is nothing to withdraw against
+		return
+	}
+
+	withdrawAddr, coins, err := k.withdrawDelegationReward(ctx, delAddr, valAddr)
+	if err != nil {
+		panic(err)
+	}
+	if coins.IsZero() {
+		return
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeRewardDistributed,
+		sdk.NewAttribute(types.AttributeKeyDelegator, delAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyValidator, valAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyWithdrawAddr, withdrawAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, coins.String()),
+	))
+}
@@ -0,0 +1,98 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestDistributeRewards(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	require.True(t, stakeHandler(ctx, msgCreateValidator).IsOK())
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	require.True(t, stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10)).IsOK())
+	require.True(t, stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr2, valOpAddr1, 20)).IsOK())
+	keeper.SetDelegatorWithdrawAddr(ctx, delAddr2, delAddr3)
+
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(40).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(40).RawInt())
+
+	// nothing is distributed without interval
+	ctx = ctx.WithBlockHeight(10)
+	keeper.DistributeRewards(ctx)
+	require.Equal(t, int64(0), keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).WithdrawalHeight)
+
+	keeper.SetAutoDistInterval(ctx, 10)
+	keeper.SetAutoDistBatchSize(ctx, 3)
+	require.Equal(t, int64(10), keeper.GetAutoDistInterval(ctx))
+	require.Equal(t, int64(3), keeper.GetAutoDistBatchSize(ctx))
+
+	// the distributions only start every interval blocks
+	ctx = ctx.WithBlockHeight(15)
+	keeper.DistributeRewards(ctx)
+	require.Equal(t, int64(0), keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).WithdrawalHeight)
+
+	// the three delegations are settled in one batch
+	ctx = ctx.WithBlockHeight(20).WithEventManager(sdk.NewEventManager())
+	keeper.DistributeRewards(ctx)
+	_, inProgress := keeper.getAutoDistCursor(ctx)
+	require.False(t, inProgress)
+	require.Len(t, ctx.EventManager().Events(), 3)
+	require.Equal(t, types.EventTypeRewardDistributed, ctx.EventManager().Events()[0].Type)
+
+	amt := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(90+25).RawInt(), amt) // 90 + 100 tokens * 10/40
+	amt = accMapper.GetAccount(ctx, delAddr3).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(100+50).RawInt(), amt) // 100 + 100 tokens * 20/40 to the withdraw address
+	amt = accMapper.GetAccount(ctx, delAddr2).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(80).RawInt(), amt)
+
+	// no distribution runs until the next interval
+	ctx = ctx.WithBlockHeight(21).WithEventManager(sdk.NewEventManager())
+	keeper.DistributeRewards(ctx)
+	require.Empty(t, ctx.EventManager().Events())
+
+	// a batch of two of the three delegations is settled in the first block
+	keeper.SetAutoDistBatchSize(ctx, 2)
+	ctx = ctx.WithBlockHeight(30)
+	keeper.DistributeRewards(ctx)
+	settled := 0
+	for _, addr := range []sdk.AccAddress{sdk.AccAddress(valOpAddr1), delAddr1, delAddr2} {
+		if keeper.GetDelegationDistInfo(ctx, addr, valOpAddr1).WithdrawalHeight == 30 {
+			settled++
+		}
+	}
+	require.Equal(t, 2, settled)
+	_, inProgress = keeper.getAutoDistCursor(ctx)
+	require.True(t, inProgress)
+
+	// the rest is settled in the next block
+	ctx = ctx.WithBlockHeight(31)
+	keeper.DistributeRewards(ctx)
+	_, inProgress = keeper.getAutoDistCursor(ctx)
+	require.False(t, inProgress)
+	for _, addr := range []sdk.AccAddress{sdk.AccAddress(valOpAddr1), delAddr1, delAddr2} {
+		require.True(t, keeper.GetDelegationDistInfo(ctx, addr, valOpAddr1).WithdrawalHeight >= 30)
+	}
+
+	// disabling the distributions stops the one in progress
+	ctx = ctx.WithBlockHeight(40)
+	keeper.DistributeRewards(ctx)
+	_, inProgress = keeper.getAutoDistCursor(ctx)
+	require.True(t, inProgress)
+	keeper.SetAutoDistInterval(ctx, 0)
+	ctx = ctx.WithBlockHeight(41)
+	keeper.DistributeRewards(ctx)
+	_, inProgress = keeper.getAutoDistCursor(ctx)
+	require.False(t, inProgress)
+}
@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// get the commission agreement of a validator with a delegator
+func (k Keeper) GetCommissionAgreement(ctx sdk.Context, valAddr sdk.ValAddress,
+	delAddr sdk.AccAddress) (agreement types.CommissionAgreement, found bool) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetCommissionAgreementKey(valAddr, delAddr))
+	if b == nil {
+		return agreement, false
+	}
+	var rate sdk.Dec
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &rate)
+	return types.NewCommissionAgreement(valAddr, delAddr, rate), true
+}
+
+// set a commission agreement without checking it, used at genesis
+func (k Keeper) InitCommissionAgreement(ctx sdk.Context, agreement types.CommissionAgreement) {
+	k.setCommissionAgreement(ctx, agreement)
+}
+
+func (k Keeper) setCommissionAgreement(ctx sdk.Context, agreement types.CommissionAgreement) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(agreement.Rate)
+	store.Set(GetCommissionAgreementKey(agreement.ValidatorAddr, agreement.DelegatorAddr), b)
+}
+
+// iterate over the commission agreements of a validator, or of all the validators if valAddr is empty
+func (k Keeper) IterateCommissionAgreements(ctx sdk.Context, valAddr sdk.ValAddress,
+	fn func(agreement types.CommissionAgreement) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetCommissionAgreementsKey(valAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()[len(CommissionAgreementKey):]
+		var rate sdk.Dec
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &rate)
+		agreement := types.NewCommissionAgreement(sdk.ValAddress(key[:sdk.AddrLen]), sdk.AccAddress(key[sdk.AddrLen:]), rate)
+		if fn(agreement) {
+			return
+		}
+	}
+}
+
+// SetCommissionAgreement sets the commission rate a validator charges a delegator, it must be below the
+// commission rate of the validator. The rewards of the delegation are withdrawn at the former rate first.
+func (k Keeper) SetCommissionAgreement(ctx sdk.Context, agreement types.CommissionAgreement) sdk.Error {
+	validator := k.stakeKeeper.Validator(ctx, agreement.ValidatorAddr)
+	if validator == nil {
+		return types.ErrNoValidatorDistInfo(k.codespace)
+	}
+	if !agreement.Rate.LT(validator.GetCommission()) {
+		return types.ErrInvalidCommissionAgreement(k.codespace, fmt.Sprintf("rate %s is not below the commission rate %s",
+			agreement.Rate, validator.GetCommission()))
+	}
+
+	if _, found := k.GetCommissionAgreement(ctx, agreement.ValidatorAddr, agreement.DelegatorAddr); !found {
+		count := 0
+		k.IterateCommissionAgreements(ctx, agreement.ValidatorAddr, func(_ types.CommissionAgreement) bool {
+			count++
+			return false
+		})
+		if count >= types.MaxCommissionAgreements {
+			return types.ErrInvalidCommissionAgreement(k.codespace, fmt.Sprintf("a validator has at most %d agreements",
+				types.MaxCommissionAgreements))
+		}
+	}
+
+	k.settleDelegationReward(ctx, agreement.DelegatorAddr, agreement.ValidatorAddr)
+	k.setCommissionAgreement(ctx, agreement)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeCommissionAgreementSet,
+		sdk.NewAttribute(types.AttributeKeyValidator, agreement.ValidatorAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyDelegator, agreement.DelegatorAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyRate, agreement.Rate.String()),
+	))
+	return nil
+}
+
+// RemoveCommissionAgreement ends the agreement of a validator with a delegator, the rewards of the delegation
+// are withdrawn at the agreed rate first.
+func (k Keeper) RemoveCommissionAgreement(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) sdk.Error {
+	if _, found := k.GetCommissionAgreement(ctx, valAddr, delAddr); !found {
+		return types.ErrNoCommissionAgreement(k.codespace)
+	}
+
+	k.settleDelegationReward(ctx, delAddr, valAddr)
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetCommissionAgreementKey(valAddr, delAddr))
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeCommissionAgreementRemoved,
+		sdk.NewAttribute(types.AttributeKeyValidator, valAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyDelegator, delAddr.String()),
+	))
+	return nil
+}
+
+// settleDelegationReward withdraws the rewards of a delegation if it exists
+func (k Keeper) settleDelegationReward(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !k.HasDelegationDistInfo(ctx, delAddr, valAddr) || !k.HasValidatorDistInfo(ctx, valAddr) ||
+		k.stakeKeeper.Delegation(ctx, delAddr, valAddr) == nil {
+		return
+	}
+	if err := k.WithdrawDelegationReward(ctx, delAddr, valAddr); err != nil {
+		panic(err)
+	}
+}
+
+// settleCommissionAgreements withdraws the rewards of the delegations with an agreement before the validator
+// withdraws its commission, so that the commission they are rebated is still in the commission pool
+func (k Keeper) settleCommissionAgreements(ctx sdk.Context, valAddr sdk.ValAddress) {
+	if !sdk.IsUpgrade(sdk.CommissionAgreements) {
+		return
+	}
+	var delAddrs []sdk.AccAddress
+	k.IterateCommissionAgreements(ctx, valAddr, func(agreement types.CommissionAgreement) bool {
+		delAddrs = append(delAddrs, agreement.DelegatorAddr)
+		return false
+	})
+	for _, delAddr := range delAddrs {
+		k.settleDelegationReward(ctx, delAddr, valAddr)
+	}
+}
+
+// applyCommissionAgreement moves the commission waived by the agreement of a delegation from the commission
+// pool of the validator to the rewards withdrawn by the delegation
+func (k Keeper) applyCommissionAgreement(ctx sdk.Context, vi types.ValidatorDistInfo, delAddr sdk.AccAddress,
+	commissionRate sdk.Dec, withdrawn types.DecCoins) (types.ValidatorDistInfo, types.DecCoins) {
+
+	if !sdk.IsUpgrade(sdk.CommissionAgreements) || withdrawn.IsZero() {
+		return vi, withdrawn
+	}
+	agreement, found := k.GetCommissionAgreement(ctx, vi.OperatorAddr, delAddr)
+	if !found {
+		return vi, withdrawn
+	}
+	rebate := types.CapDecCoins(types.CommissionRebate(withdrawn, commissionRate, agreement.Rate), vi.PoolCommission)
+	if rebate.IsZero() {
+		return vi, withdrawn
+	}
+	vi.PoolCommission = vi.PoolCommission.Minus(rebate)
+	return vi, withdrawn.Plus(rebate)
+}
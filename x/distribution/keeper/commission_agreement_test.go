@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestCommissionAgreement(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CommissionAgreements, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.CommissionAgreements)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	//first make a validator with 10% commission
+	msgCreateValidator := stake.NewTestMsgCreateValidatorWithCommission(
+		valOpAddr1, valConsPk1, sdk.NewDecWithoutFra(10).RawInt(), sdk.NewDecWithPrec(1, 1))
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	// delegate
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr2, valOpAddr1, 20))
+	require.True(t, got.IsOK())
+
+	// the agreed rate must be below the commission rate
+	err := keeper.SetCommissionAgreement(ctx, types.NewCommissionAgreement(valOpAddr1, delAddr1, sdk.NewDecWithPrec(1, 1)))
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeInvalidAgreement, err.Code())
+	err = keeper.SetCommissionAgreement(ctx, types.NewCommissionAgreement(valOpAddr2, delAddr1, sdk.ZeroDec()))
+	require.NotNil(t, err)
+
+	// delegator 1 pays no commission
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	err = keeper.SetCommissionAgreement(ctx, types.NewCommissionAgreement(valOpAddr1, delAddr1, sdk.ZeroDec()))
+	require.Nil(t, err)
+	require.Len(t, ctx.EventManager().Events(), 1)
+	require.Equal(t, types.EventTypeCommissionAgreementSet, ctx.EventManager().Events()[0].Type)
+	agreement, found := keeper.GetCommissionAgreement(ctx, valOpAddr1, delAddr1)
+	require.True(t, found)
+	require.Equal(t, sdk.ZeroDec(), agreement.Rate)
+
+	// allocate 100 denom of fees
+	feeInputs := sdk.NewDecWithoutFra(100).RawInt()
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	ctx = ctx.WithBlockHeight(1)
+
+	// the validator withdraws its commission, the delegation of delegator 1 is settled first
+	err = keeper.WithdrawValidatorRewardsAll(ctx, valOpAddr1)
+	require.Nil(t, err)
+	amt := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(115).RawInt(), amt) // 90 + 100*90% tokens * 10/40 + rebate of the 10% commission
+	amt = accMapper.GetAccount(ctx, sdk.AccAddress(valOpAddr1)).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(120).RawInt(), amt) // 90 + 100*90% tokens * 10/40 + 10 - rebate of 2.5
+
+	// delegator 2 pays the full commission
+	keeper.WithdrawDelegationReward(ctx, delAddr2, valOpAddr1)
+	amt = accMapper.GetAccount(ctx, delAddr2).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(125).RawInt(), amt) // 80 + 100*90% tokens * 20/40
+
+	var agreements []types.CommissionAgreement
+	keeper.IterateCommissionAgreements(ctx, valOpAddr1, func(agreement types.CommissionAgreement) bool {
+		agreements = append(agreements, agreement)
+		return false
+	})
+	require.Equal(t, []types.CommissionAgreement{agreement}, agreements)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.Nil(t, keeper.RemoveCommissionAgreement(ctx, valOpAddr1, delAddr1))
+	require.Len(t, ctx.EventManager().Events(), 1)
+	require.Equal(t, types.EventTypeCommissionAgreementRemoved, ctx.EventManager().Events()[0].Type)
+	_, found = keeper.GetCommissionAgreement(ctx, valOpAddr1, delAddr1)
+	require.False(t, found)
+	err = keeper.RemoveCommissionAgreement(ctx, valOpAddr1, delAddr1)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeNoAgreement, err.Code())
+}
+
+func TestCommissionRebate(t *testing.T) {
+	withdrawn := types.DecCoins{types.NewDecCoin("steak", sdk.NewDecWithoutFra(90).RawInt())}
+
+	// 90 after a commission of 10% is 100 before, 5% of it is rebated at an agreed rate of 5%
+	rebate := types.CommissionRebate(withdrawn, sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(5, 2))
+	require.Equal(t, types.DecCoins{types.NewDecCoin("steak", sdk.NewDecWithoutFra(5).RawInt())}, rebate)
+
+	require.True(t, types.CommissionRebate(withdrawn, sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(1, 1)).IsZero())
+	require.True(t, types.CommissionRebate(withdrawn, sdk.OneDec(), sdk.ZeroDec()).IsZero())
+
+	capped := types.CapDecCoins(rebate, types.DecCoins{types.NewDecCoin("steak", sdk.NewDecWithoutFra(2).RawInt())})
+	require.Equal(t, types.DecCoins{types.NewDecCoin("steak", sdk.NewDecWithoutFra(2).RawInt())}, capped)
+	require.True(t, types.CapDecCoins(rebate, types.DecCoins{}).IsZero())
+}
@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const (
+	ChannelName = "crossStakeReward"
+	ChannelId   = sdk.ChannelID(12)
+)
+
+var _ sdk.CrossChainApplication = Keeper{}
+
+// SetupForSideChain registers the channel on which the rewards of cross chain stake are received
+func (k *Keeper) SetupForSideChain(scKeeper *sidechain.Keeper, pool *sdk.Pool) {
+	k.ScKeeper = scKeeper
+	k.pool = pool
+	err := k.ScKeeper.RegisterChannel(ChannelName, ChannelId, *k)
+	if err != nil {
+		panic(fmt.Sprintf("register ibc channel failed, channel=%s, err=%s", ChannelName, err.Error()))
+	}
+}
+
+func (k Keeper) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
+	var resCode uint32
+	pack, err := k.checkCrossStakeRewardPackage(payload)
+	if err == nil {
+		err = k.creditSideChainRewards(ctx, pack)
+	}
+	if err != nil {
+		resCode = uint32(err.ABCICode())
+	}
+	ackPackage, encodeErr := sTypes.GenCommonAckPackage(resCode)
+	if encodeErr != nil {
+		panic(encodeErr)
+	}
+	return sdk.ExecuteResult{
+		Payload: ackPackage,
+		Err:     err,
+		Tags:    sdk.EmptyTags(),
+	}
+}
+
+func (k Keeper) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	panic("receive unexpected ack package")
+}
+
+// When the ack application crash, payload is the payload of the origin package.
+func (k Keeper) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	panic("receive unexpected fail ack package")
+}
+
+func (k Keeper) checkCrossStakeRewardPackage(payload []byte) (*types.CrossStakeRewardPackage, sdk.Error) {
+	var pack types.CrossStakeRewardPackage
+	if err := rlp.DecodeBytes(payload, &pack); err != nil {
+		return nil, types.ErrInvalidPackage(k.codespace, "failed to parse the payload")
+	}
+	if len(pack.Recipients) == 0 || len(pack.Recipients) != len(pack.Amounts) {
+		return nil, types.ErrInvalidPackage(k.codespace, "recipients and amounts mismatch")
+	}
+	maxAmount := bsc.ConvertBCAmountToBSCAmount(math.MaxInt64)
+	for i, recipient := range pack.Recipients {
+		if len(recipient) != sdk.AddrLen {
+			return nil, types.ErrInvalidPackage(k.codespace, fmt.Sprintf("wrong recipient length, expected=%d", sdk.AddrLen))
+		}
+		amount := pack.Amounts[i]
+		if amount == nil || amount.Sign() < 0 || amount.Cmp(maxAmount) > 0 {
+			return nil, types.ErrInvalidPackage(k.codespace, fmt.Sprintf("invalid reward amount of %s", recipient))
+		}
+	}
+	return &pack, nil
+}
+
+// creditSideChainRewards unlocks the rewards from the peg account and sends them to the withdraw
+// addresses of the delegators
+func (k Keeper) creditSideChainRewards(ctx sdk.Context, pack *types.CrossStakeRewardPackage) sdk.Error {
+	if k.ScKeeper == nil {
+		return sdk.ErrInternal("the keeper is not prepared for side chain")
+	}
+	sideChainId, err := k.ScKeeper.GetDestChainName(pack.SideChainId)
+	if err != nil {
+		return types.ErrInvalidSideChainId(k.codespace)
+	}
+
+	total := k.GetSideChainReward(ctx, sideChainId)
+	changedAddrs := []sdk.AccAddress{sdk.GetPegAccount()}
+	for i, delAddr := range pack.Recipients {
+		amount := bsc.ConvertBSCAmountToBCAmount(pack.Amounts[i])
+		if amount == 0 {
+			continue
+		}
+		reward := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, amount)}
+		withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delAddr)
+		if _, err := k.bankKeeper.SendCoins(ctx, sdk.GetPegAccount(), withdrawAddr, reward); err != nil {
+			return err
+		}
+		changedAddrs = append(changedAddrs, withdrawAddr)
+
+		k.setDelegatorSideChainReward(ctx, delAddr, sideChainId, k.GetDelegatorSideChainReward(ctx, delAddr, sideChainId).Plus(reward))
+		total = total.Plus(reward)
+	}
+	k.setSideChainReward(ctx, sideChainId, total)
+
+	if ctx.IsDeliverTx() && k.pool != nil {
+		k.pool.AddAddrs(changedAddrs)
+	}
+	return nil
+}
+
+// get the total reward credited from a side chain
+func (k Keeper) GetSideChainReward(ctx sdk.Context, sideChainId string) (reward sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetSideChainRewardKey(sideChainId))
+	if b == nil {
+		return sdk.Coins{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &reward)
+	return
+}
+
+func (k Keeper) setSideChainReward(ctx sdk.Context, sideChainId string, reward sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(reward)
+	store.Set(GetSideChainRewardKey(sideChainId), b)
+}
+
+// get the reward credited to a delegator from a side chain
+func (k Keeper) GetDelegatorSideChainReward(ctx sdk.Context, delAddr sdk.AccAddress, sideChainId string) (reward sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDelegatorSideRewardKey(delAddr, sideChainId))
+	if b == nil {
+		return sdk.Coins{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &reward)
+	return
+}
+
+func (k Keeper) setDelegatorSideChainReward(ctx sdk.Context, delAddr sdk.AccAddress, sideChainId string, reward sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(reward)
+	store.Set(GetDelegatorSideRewardKey(delAddr, sideChainId), b)
+}
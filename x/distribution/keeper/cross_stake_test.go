@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+func TestCrossStakeReward(t *testing.T) {
+	ctx, accMapper, keeper, sk, _ := CreateTestInputDefault(t, false, 0)
+	sideChainId := "bsc"
+	sideChainID := sdk.ChainID(1)
+	require.NoError(t, sk.ScKeeper.RegisterDestChain(sideChainId, sideChainID))
+	keeper.SetupForSideChain(sk.ScKeeper, new(sdk.Pool))
+
+	_, _, err := keeper.bankKeeper.AddCoins(ctx, sdk.GetPegAccount(), sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 7e8)})
+	require.Nil(t, err)
+	keeper.SetDelegatorWithdrawAddr(ctx, delAddr2, delAddr3)
+
+	encode := func(pack types.CrossStakeRewardPackage) []byte {
+		bz, err := rlp.EncodeToBytes(&pack)
+		require.NoError(t, err)
+		return bz
+	}
+
+	// malformed packages are rejected
+	res := keeper.ExecuteSynPackage(ctx, []byte("invalid"), 0)
+	require.Equal(t, types.CodeInvalidPackage, res.Err.Code())
+	res = keeper.ExecuteSynPackage(ctx, encode(types.CrossStakeRewardPackage{
+		SideChainId: sideChainID,
+		Recipients:  []sdk.AccAddress{delAddr1},
+		Amounts:     []*big.Int{},
+	}), 0)
+	require.Equal(t, types.CodeInvalidPackage, res.Err.Code())
+	res = keeper.ExecuteSynPackage(ctx, encode(types.CrossStakeRewardPackage{
+		SideChainId: sdk.ChainID(2),
+		Recipients:  []sdk.AccAddress{delAddr1},
+		Amounts:     []*big.Int{bsc.ConvertBCAmountToBSCAmount(1e8)},
+	}), 0)
+	require.Equal(t, types.CodeInvalidSideChain, res.Err.Code())
+
+	pack := types.CrossStakeRewardPackage{
+		SideChainId: sideChainID,
+		Recipients:  []sdk.AccAddress{delAddr1, delAddr2},
+		Amounts:     []*big.Int{bsc.ConvertBCAmountToBSCAmount(1e8), bsc.ConvertBCAmountToBSCAmount(2e8)},
+	}
+	res = keeper.ExecuteSynPackage(ctx, encode(pack), 0)
+	require.Nil(t, res.Err)
+	res = keeper.ExecuteSynPackage(ctx, encode(pack), 0)
+	require.Nil(t, res.Err)
+
+	// rewards are paid to the withdraw address of the delegator
+	require.Equal(t, int64(2e8), accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(4e8), accMapper.GetAccount(ctx, delAddr3).GetCoins().AmountOf(sdk.NativeTokenSymbol))
+
+	require.Equal(t, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 6e8)}, keeper.GetSideChainReward(ctx, sideChainId))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 4e8)}, keeper.GetDelegatorSideChainReward(ctx, delAddr2, sideChainId))
+	require.True(t, keeper.GetDelegatorSideChainReward(ctx, delAddr3, sideChainId).IsZero())
+
+	// the peg account can not pay more than it holds
+	res = keeper.ExecuteSynPackage(ctx, encode(pack), 0)
+	require.NotNil(t, res.Err)
+}
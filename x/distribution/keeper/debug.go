@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// GetDelegationAccumDebug returns the accum records of a delegation and of its validator along
+// with the rewards it would withdraw now, without modifying any state.
+func (k Keeper) GetDelegationAccumDebug(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) (types.DelegationAccumDebug, sdk.Error) {
+
+	if !k.HasDelegationDistInfo(ctx, delAddr, valAddr) {
+		return types.DelegationAccumDebug{}, types.ErrNoDelegationDistInfo(k.codespace)
+	}
+	if !k.HasValidatorDistInfo(ctx, valAddr) {
+		return types.DelegationAccumDebug{}, types.ErrNoValidatorDistInfo(k.codespace)
+	}
+
+	debug := types.DelegationAccumDebug{
+		Height:                   ctx.BlockHeight(),
+		FeePool:                  k.GetFeePool(ctx),
+		ValidatorInfo:            k.GetValidatorDistInfo(ctx, valAddr),
+		DelegationInfo:           k.GetDelegationDistInfo(ctx, delAddr, valAddr),
+		DelegatorWithdrawAddr:    k.GetDelegatorWithdrawAddr(ctx, delAddr),
+		LastTotalPower:           sdk.NewDecFromInt(k.stakeKeeper.GetLastTotalPower(ctx)),
+		LastValidatorPower:       sdk.NewDecFromInt(k.stakeKeeper.GetLastValidatorPower(ctx, valAddr)),
+		ValidatorDelegatorShares: sdk.ZeroDec(),
+		DelegatorShares:          sdk.ZeroDec(),
+		CommissionRate:           sdk.ZeroDec(),
+		PendingRewards:           types.DecCoins{},
+	}
+
+	validator := k.stakeKeeper.Validator(ctx, valAddr)
+	delegation := k.stakeKeeper.Delegation(ctx, delAddr, valAddr)
+	if validator == nil || delegation == nil {
+		// the records are left over, there is nothing to withdraw against
+		return debug, nil
+	}
+	debug.ValidatorDelegatorShares = validator.GetDelegatorShares()
+	debug.DelegatorShares = delegation.GetShares()
+	debug.CommissionRate = validator.GetCommission()
+
+	// same computation as WithdrawDelegationReward, the results are not stored
+	_, valInfo, _, pendingRewards := debug.DelegationInfo.WithdrawRewards(debug.FeePool, debug.ValidatorInfo,
+		debug.Height, debug.LastTotalPower, debug.LastValidatorPower, debug.ValidatorDelegatorShares,
+		debug.DelegatorShares, debug.CommissionRate)
+	_, debug.PendingRewards = k.applyCommissionAgreement(ctx, valInfo, delAddr, debug.CommissionRate, pendingRewards)
+	return debug, nil
+}
@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestGetDelegationAccumDebug(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	_, err := keeper.GetDelegationAccumDebug(ctx, delAddr1, valOpAddr1)
+	require.Equal(t, types.CodeNoDistributionInfo, err.Code())
+
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	require.True(t, stakeHandler(ctx, msgCreateValidator).IsOK())
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	require.True(t, stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10)).IsOK())
+
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+
+	ctx = ctx.WithBlockHeight(1)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(10).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(10).RawInt())
+
+	debug, err := keeper.GetDelegationAccumDebug(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), debug.Height)
+	require.Equal(t, int64(0), debug.DelegationInfo.WithdrawalHeight)
+	require.Equal(t, valOpAddr1, debug.ValidatorInfo.OperatorAddr)
+	require.Equal(t, delAddr1, debug.DelegatorWithdrawAddr)
+	require.Equal(t, sdk.NewDecWithoutFra(10), debug.LastTotalPower)
+	require.Equal(t, sdk.NewDecWithoutFra(20), debug.ValidatorDelegatorShares)
+	require.Equal(t, sdk.NewDecWithoutFra(10), debug.DelegatorShares)
+
+	// the query has no side effect
+	require.Equal(t, debug.FeePool, keeper.GetFeePool(ctx))
+	require.Equal(t, int64(0), keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).WithdrawalHeight)
+
+	// and predicts the withdrawal
+	before := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	after := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	pending, _ := debug.PendingRewards.TruncateDecimal()
+	require.True(t, after-before > 0)
+	require.Equal(t, after-before, pending.AmountOf(denom))
+}
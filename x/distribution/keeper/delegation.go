@@ -75,76 +75,68 @@ func (k Keeper) RemoveDelegatorWithdrawAddr(ctx sdk.Context, delAddr, withdrawAd
 func (k Keeper) WithdrawDelegationReward(ctx sdk.Context, delegatorAddr sdk.AccAddress,
 	valAddr sdk.ValAddress) sdk.Error {
 
-	if !k.HasDelegationDistInfo(ctx, delegatorAddr, valAddr) {
-		return types.ErrNoDelegationDistInfo(k.codespace)
+	_, _, err := k.withdrawDelegationReward(ctx, delegatorAddr, valAddr)
+	return err
+}
+
+// withdrawDelegationReward withdraws the rewards of a delegation and returns where they went
+func (k Keeper) withdrawDelegationReward(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) (withdrawAddr sdk.AccAddress, paid sdk.Coins, err sdk.Error) {
+
+	if !k.HasDelegationDistInfo(ctx, delAddr, valAddr) {
+		return nil, nil, types.ErrNoDelegationDistInfo(k.codespace)
 	}
 
-	// TODO: Reconcile with duplicate code in getDelegatorRewardsAll.
+	withdraw := k.takeDelegationRewards(ctx, delAddr, valAddr)
+	withdrawAddr = k.GetDelegationWithdrawAddr(ctx, delAddr, valAddr)
+	return withdrawAddr, k.payWithdrawal(ctx, withdrawAddr, withdraw), nil
+}
+
+// takeDelegationRewards withdraws the rewards of a delegation from the distribution records, the caller
+// decides where they go
+func (k Keeper) takeDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) types.DecCoins {
 	height := ctx.BlockHeight()
 	lastTotalPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastTotalPower(ctx))
 	lastValPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastValidatorPower(ctx, valAddr))
 	feePool := k.GetFeePool(ctx)
-	delInfo := k.GetDelegationDistInfo(ctx, delegatorAddr, valAddr)
+	delInfo := k.GetDelegationDistInfo(ctx, delAddr, valAddr)
 	valInfo := k.GetValidatorDistInfo(ctx, valAddr)
 	validator := k.stakeKeeper.Validator(ctx, valAddr)
-	delegation := k.stakeKeeper.Delegation(ctx, delegatorAddr, valAddr)
+	delegation := k.stakeKeeper.Delegation(ctx, delAddr, valAddr)
 
 	delInfo, valInfo, feePool, withdraw := delInfo.WithdrawRewards(feePool, valInfo, height, lastTotalPower,
 		lastValPower, validator.GetDelegatorShares(), delegation.GetShares(), validator.GetCommission())
+	valInfo, withdraw = k.applyCommissionAgreement(ctx, valInfo, delAddr, validator.GetCommission(), withdraw)
 
+	k.SetFeePool(ctx, feePool)
 	k.SetValidatorDistInfo(ctx, valInfo)
 	k.SetDelegationDistInfo(ctx, delInfo)
-	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delegatorAddr)
-	coinsToAdd, change := withdraw.TruncateDecimal()
-	feePool.CommunityPool = feePool.CommunityPool.Plus(change)
-	k.SetFeePool(ctx, feePool)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, coinsToAdd)
-	if err != nil {
-		panic(err)
-	}
-	return nil
+	return withdraw
 }
 
 //___________________________________________________________________________________________
 
 // return all rewards for all delegations of a delegator
 func (k Keeper) WithdrawDelegationRewardsAll(ctx sdk.Context, delegatorAddr sdk.AccAddress) {
-	height := ctx.BlockHeight()
-	withdraw := k.getDelegatorRewardsAll(ctx, delegatorAddr, height)
-	feePool := k.GetFeePool(ctx)
-	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delegatorAddr)
-	coinsToAdd, change := withdraw.TruncateDecimal()
-	feePool.CommunityPool = feePool.CommunityPool.Plus(change)
-	k.SetFeePool(ctx, feePool)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, coinsToAdd)
-	if err != nil {
-		panic(err)
-	}
+	withdraw := k.getDelegatorRewardsAll(ctx, delegatorAddr)
+	k.payWithdrawal(ctx, k.GetDelegatorWithdrawAddr(ctx, delegatorAddr), withdraw)
 }
 
-// return all rewards for all delegations of a delegator
-func (k Keeper) getDelegatorRewardsAll(ctx sdk.Context, delAddr sdk.AccAddress, height int64) types.DecCoins {
+// return all rewards for all delegations of a delegator, the rewards of the delegations with their own withdraw
+// address are sent to it instead
+func (k Keeper) getDelegatorRewardsAll(ctx sdk.Context, delAddr sdk.AccAddress) types.DecCoins {
 
 	withdraw := types.DecCoins{}
-	lastTotalPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastTotalPower(ctx))
 
 	// iterate over all the delegations
-	// TODO: Reconcile with duplicate code in WithdrawDelegationReward.
 	operationAtDelegation := func(_ int64, del sdk.Delegation) (stop bool) {
-		feePool := k.GetFeePool(ctx)
 		valAddr := del.GetValidatorAddr()
-		lastValPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastValidatorPower(ctx, valAddr))
-		delInfo := k.GetDelegationDistInfo(ctx, delAddr, valAddr)
-		valInfo := k.GetValidatorDistInfo(ctx, valAddr)
-		validator := k.stakeKeeper.Validator(ctx, valAddr)
-		delegation := k.stakeKeeper.Delegation(ctx, delAddr, valAddr)
-
-		delInfo, valInfo, feePool, diWithdraw := delInfo.WithdrawRewards(feePool, valInfo, height, lastTotalPower,
-			lastValPower, validator.GetDelegatorShares(), delegation.GetShares(), validator.GetCommission())
-		withdraw = withdraw.Plus(diWithdraw)
-		k.SetFeePool(ctx, feePool)
-		k.SetValidatorDistInfo(ctx, valInfo)
-		k.SetDelegationDistInfo(ctx, delInfo)
+		diWithdraw := k.takeDelegationRewards(ctx, delAddr, valAddr)
+		if withdrawAddr, found := k.getDelegationWithdrawAddr(ctx, delAddr, valAddr); found {
+			k.payWithdrawal(ctx, withdrawAddr, diWithdraw)
+		} else {
+			withdraw = withdraw.Plus(diWithdraw)
+		}
 		return false
 	}
 	k.stakeKeeper.IterateDelegations(ctx, delAddr, operationAtDelegation)
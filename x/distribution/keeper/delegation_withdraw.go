@@ -0,0 +1,99 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// get the withdraw address of a delegation, the withdraw address of the delegator if it has none
+func (k Keeper) GetDelegationWithdrawAddr(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) sdk.AccAddress {
+	if withdrawAddr, found := k.getDelegationWithdrawAddr(ctx, delAddr, valAddr); found {
+		return withdrawAddr
+	}
+	return k.GetDelegatorWithdrawAddr(ctx, delAddr)
+}
+
+func (k Keeper) getDelegationWithdrawAddr(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) (sdk.AccAddress, bool) {
+
+	if !sdk.IsUpgrade(sdk.DelegationWithdraw) {
+		return nil, false
+	}
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDelegationWithdrawAddrKey(delAddr, valAddr))
+	if b == nil {
+		return nil, false
+	}
+	return sdk.AccAddress(b), true
+}
+
+// set the withdraw address of a delegation
+func (k Keeper) SetDelegationWithdrawAddr(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+	withdrawAddr sdk.AccAddress) {
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDelegationWithdrawAddrKey(delAddr, valAddr), withdrawAddr.Bytes())
+}
+
+// remove the withdraw address of a delegation, its rewards go to the withdraw address of the delegator again
+func (k Keeper) RemoveDelegationWithdrawAddr(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegationWithdrawAddrKey(delAddr, valAddr))
+}
+
+// iterate over the withdraw addresses of the delegations of a delegator, or of all the delegators if delAddr is empty
+func (k Keeper) IterateDelegationWithdrawAddrs(ctx sdk.Context, delAddr sdk.AccAddress,
+	fn func(info types.DelegationWithdrawInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationWithdrawAddrsKey(delAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()[len(DelegationWithdrawKey):]
+		info := types.NewDelegationWithdrawInfo(sdk.AccAddress(key[:sdk.AddrLen]), sdk.ValAddress(key[sdk.AddrLen:]),
+			sdk.AccAddress(iterator.Value()))
+		if fn(info) {
+			return
+		}
+	}
+}
+
+// SetWithdrawAddr sets the withdraw address of a delegator, or of its delegation to valAddr if it is not empty.
+// Setting the withdraw address of a delegation to the delegator address removes it. The rewards of the
+// delegation are withdrawn to the former address first.
+func (k Keeper) SetWithdrawAddr(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+	withdrawAddr sdk.AccAddress) sdk.Error {
+
+	if err := k.CheckWithdrawAddr(ctx, withdrawAddr); err != nil {
+		return err
+	}
+	if valAddr.Empty() {
+		k.SetDelegatorWithdrawAddr(ctx, delAddr, withdrawAddr)
+		return nil
+	}
+
+	if k.stakeKeeper.Delegation(ctx, delAddr, valAddr) == nil {
+		return types.ErrNoDelegationDistInfo(k.codespace)
+	}
+	k.settleDelegationReward(ctx, delAddr, valAddr)
+	if withdrawAddr.Equals(delAddr) {
+		k.RemoveDelegationWithdrawAddr(ctx, delAddr, valAddr)
+	} else {
+		k.SetDelegationWithdrawAddr(ctx, delAddr, valAddr, withdrawAddr)
+	}
+	return nil
+}
+
+// payWithdrawal sends the withdrawn rewards to withdrawAddr and returns the coins sent, the decimal change
+// goes to the community pool
+func (k Keeper) payWithdrawal(ctx sdk.Context, withdrawAddr sdk.AccAddress, withdraw types.DecCoins) sdk.Coins {
+	feePool := k.GetFeePool(ctx)
+	coinsToAdd, change := withdraw.TruncateDecimal()
+	feePool.CommunityPool = feePool.CommunityPool.Plus(change)
+	k.SetFeePool(ctx, feePool)
+	if _, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, coinsToAdd); err != nil {
+		panic(err)
+	}
+	return coinsToAdd
+}
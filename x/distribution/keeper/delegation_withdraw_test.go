@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestDelegationWithdrawAddr(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.DelegationWithdraw, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.DelegationWithdraw)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	balance := func(addr sdk.AccAddress) int64 {
+		return accMapper.GetAccount(ctx, addr).GetCoins().AmountOf(denom)
+	}
+
+	//first make a validator with 10% commission
+	msgCreateValidator := stake.NewTestMsgCreateValidatorWithCommission(
+		valOpAddr1, valConsPk1, sdk.NewDecWithoutFra(10).RawInt(), sdk.NewDecWithPrec(1, 1))
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	// delegate the same amount
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr2, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+
+	// the delegation must exist and the withdraw address must not be banned
+	err := keeper.SetWithdrawAddr(ctx, delAddr1, valOpAddr2, delAddr3)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeNoDistributionInfo, err.Code())
+	keeper.BanWithdrawAddr(ctx, valAccAddr3)
+	err = keeper.SetWithdrawAddr(ctx, delAddr1, valOpAddr1, valAccAddr3)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeBannedWithdrawAddr, err.Code())
+
+	// the rewards of the delegation of delegator 1 go to delegator 3
+	require.Nil(t, keeper.SetWithdrawAddr(ctx, delAddr1, valOpAddr1, delAddr3))
+	require.Equal(t, delAddr3, keeper.GetDelegationWithdrawAddr(ctx, delAddr1, valOpAddr1))
+	require.Equal(t, delAddr1, keeper.GetDelegationWithdrawAddr(ctx, delAddr1, valOpAddr2))
+	require.Equal(t, delAddr1, keeper.GetDelegatorWithdrawAddr(ctx, delAddr1))
+
+	// allocate 100 denom of fees
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	ctx = ctx.WithBlockHeight(1)
+
+	before1, before2, before3 := balance(delAddr1), balance(delAddr2), balance(delAddr3)
+	keeper.WithdrawDelegationRewardsAll(ctx, delAddr1)
+	keeper.WithdrawDelegationRewardsAll(ctx, delAddr2)
+	require.Equal(t, before1, balance(delAddr1))
+	require.True(t, balance(delAddr2) > before2)
+	require.Equal(t, balance(delAddr2)-before2, balance(delAddr3)-before3)
+
+	var infos []types.DelegationWithdrawInfo
+	keeper.IterateDelegationWithdrawAddrs(ctx, nil, func(info types.DelegationWithdrawInfo) bool {
+		infos = append(infos, info)
+		return false
+	})
+	require.Equal(t, []types.DelegationWithdrawInfo{types.NewDelegationWithdrawInfo(delAddr1, valOpAddr1, delAddr3)}, infos)
+
+	// the delegator address resets the withdraw address of the delegation
+	require.Nil(t, keeper.SetWithdrawAddr(ctx, delAddr1, valOpAddr1, delAddr1))
+	_, found := keeper.getDelegationWithdrawAddr(ctx, delAddr1, valOpAddr1)
+	require.False(t, found)
+
+	// the withdraw address of a delegation is removed with it
+	require.Nil(t, keeper.SetWithdrawAddr(ctx, delAddr1, valOpAddr1, delAddr3))
+	keeper.Hooks().OnDelegationRemoved(ctx, delAddr1, valOpAddr1)
+	_, found = keeper.getDelegationWithdrawAddr(ctx, delAddr1, valOpAddr1)
+	require.False(t, found)
+
+	// the delegator withdraw address is set as before
+	require.Nil(t, keeper.SetWithdrawAddr(ctx, delAddr2, nil, delAddr3))
+	require.Equal(t, delAddr3, keeper.GetDelegatorWithdrawAddr(ctx, delAddr2))
+	require.Equal(t, delAddr3, keeper.GetDelegationWithdrawAddr(ctx, delAddr2, valOpAddr1))
+
+	// resetting a delegation with the delegator address falls back to the withdraw address of the delegator
+	require.Nil(t, keeper.SetWithdrawAddr(ctx, delAddr2, valOpAddr1, delAddr1))
+	require.Nil(t, keeper.SetWithdrawAddr(ctx, delAddr2, valOpAddr1, delAddr2))
+	require.Equal(t, delAddr3, keeper.GetDelegationWithdrawAddr(ctx, delAddr2, valOpAddr1))
+}
@@ -62,6 +62,9 @@ func (k Keeper) onDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress,
 	valAddr sdk.ValAddress) {
 
 	k.RemoveDelegationDistInfo(ctx, delAddr, valAddr)
+	if sdk.IsUpgrade(sdk.DelegationWithdraw) {
+		k.RemoveDelegationWithdrawAddr(ctx, delAddr, valAddr)
+	}
 }
 
 //_________________________________________________________________________________________
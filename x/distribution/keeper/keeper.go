@@ -1,10 +1,14 @@
 package keeper
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
 )
 
 // keeper of the stake store
@@ -18,6 +22,15 @@ type Keeper struct {
 
 	// codespace
 	codespace sdk.CodespaceType
+
+	// if you want to receive the rewards of cross chain stake, you need call `SetupForSideChain`
+	ScKeeper *sidechain.Keeper
+
+	// optional, to ban withdraw addresses by governance
+	govKeeper *gov.Keeper
+
+	// shared memory for block level state
+	pool *sdk.Pool
 }
 
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace, ck types.BankKeeper,
@@ -86,6 +99,11 @@ func ParamTypeTable() params.TypeTable {
 		ParamStoreKeyCommunityTax, sdk.Dec{},
 		ParamStoreKeyBaseProposerReward, sdk.Dec{},
 		ParamStoreKeyBonusProposerReward, sdk.Dec{},
+		ParamStoreKeyFeePoolDenoms, []string{},
+		ParamStoreKeyBurnUnlistedFees, false,
+		ParamStoreKeyRewardClaimDeadline, time.Duration(0),
+		ParamStoreKeyAutoDistInterval, int64(0),
+		ParamStoreKeyAutoDistBatchSize, int64(0),
 	)
 }
 
@@ -127,3 +145,26 @@ func (k Keeper) GetBonusProposerReward(ctx sdk.Context) sdk.Dec {
 func (k Keeper) SetBonusProposerReward(ctx sdk.Context, percent sdk.Dec) {
 	k.paramSpace.Set(ctx, ParamStoreKeyBonusProposerReward, &percent)
 }
+
+// Returns the denoms accepted into the fee pool, every denom is accepted if empty
+func (k Keeper) GetFeePoolDenoms(ctx sdk.Context) (denoms []string) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyFeePoolDenoms, &denoms)
+	return
+}
+
+// nolint: errcheck
+func (k Keeper) SetFeePoolDenoms(ctx sdk.Context, denoms []string) {
+	k.paramSpace.Set(ctx, ParamStoreKeyFeePoolDenoms, &denoms)
+}
+
+// Returns whether the fees in the denoms out of the fee pool whitelist are burned
+// instead of being sent to the community pool
+func (k Keeper) GetBurnUnlistedFees(ctx sdk.Context) (burn bool) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyBurnUnlistedFees, &burn)
+	return
+}
+
+// nolint: errcheck
+func (k Keeper) SetBurnUnlistedFees(ctx sdk.Context, burn bool) {
+	k.paramSpace.Set(ctx, ParamStoreKeyBurnUnlistedFees, &burn)
+}
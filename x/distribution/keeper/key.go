@@ -11,11 +11,23 @@ var (
 	DelegationDistInfoKey    = []byte{0x02} // prefix for each key to a delegation distribution
 	DelegatorWithdrawInfoKey = []byte{0x03} // prefix for each key to a delegator withdraw info
 	ProposerKey              = []byte{0x04} // key for storing the proposer operator address
+	SideChainRewardKey       = []byte{0x05} // prefix for the total reward credited from each side chain
+	DelegatorSideRewardKey   = []byte{0x06} // prefix for the reward credited to a delegator from each side chain
+	BannedWithdrawAddrKey    = []byte{0x07} // prefix for the addresses which can not be set as withdraw address
+	CommissionAgreementKey   = []byte{0x08} // prefix for the commission agreements of each validator
+	AutoDistributionKey      = []byte{0x09} // key for the next delegation of the automatic distribution in progress
+	DelegationWithdrawKey    = []byte{0x0A} // prefix for the withdraw addresses of specific delegations
+	RewardSweepKey           = []byte{0x0B} // key for the state of the sweeps of the stale rewards
 
 	// params store
 	ParamStoreKeyCommunityTax        = []byte("communitytax")
 	ParamStoreKeyBaseProposerReward  = []byte("baseproposerreward")
 	ParamStoreKeyBonusProposerReward = []byte("bonusproposerreward")
+	ParamStoreKeyFeePoolDenoms       = []byte("feepooldenoms")
+	ParamStoreKeyBurnUnlistedFees    = []byte("burnunlistedfees")
+	ParamStoreKeyRewardClaimDeadline = []byte("rewardclaimdeadline")
+	ParamStoreKeyAutoDistInterval    = []byte("autodistinterval")
+	ParamStoreKeyAutoDistBatchSize   = []byte("autodistbatchsize")
 )
 
 const (
@@ -44,3 +56,42 @@ func GetDelegationDistInfosKey(delAddr sdk.AccAddress) []byte {
 func GetDelegatorWithdrawAddrKey(delAddr sdk.AccAddress) []byte {
 	return append(DelegatorWithdrawInfoKey, delAddr.Bytes()...)
 }
+
+// gets the prefix for the withdraw addresses of the delegations of a delegator
+func GetDelegationWithdrawAddrsKey(delAddr sdk.AccAddress) []byte {
+	return append(DelegationWithdrawKey, delAddr.Bytes()...)
+}
+
+// gets the key for the withdraw address of a delegation
+// VALUE: sdk.AccAddress
+func GetDelegationWithdrawAddrKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(GetDelegationWithdrawAddrsKey(delAddr), valAddr.Bytes()...)
+}
+
+// gets the key for the total reward credited from a side chain
+// VALUE: sdk.Coins
+func GetSideChainRewardKey(sideChainId string) []byte {
+	return append(SideChainRewardKey, []byte(sideChainId)...)
+}
+
+// gets the key for the reward credited to a delegator from a side chain
+// VALUE: sdk.Coins
+func GetDelegatorSideRewardKey(delAddr sdk.AccAddress, sideChainId string) []byte {
+	return append(append(DelegatorSideRewardKey, delAddr.Bytes()...), []byte(sideChainId)...)
+}
+
+// gets the key marking an address as banned from being a withdraw address
+func GetBannedWithdrawAddrKey(addr sdk.AccAddress) []byte {
+	return append(BannedWithdrawAddrKey, addr.Bytes()...)
+}
+
+// gets the prefix for the commission agreements of a validator
+func GetCommissionAgreementsKey(valAddr sdk.ValAddress) []byte {
+	return append(CommissionAgreementKey, valAddr.Bytes()...)
+}
+
+// gets the key for the commission agreement of a validator with a delegator
+// VALUE: sdk.Dec
+func GetCommissionAgreementKey(valAddr sdk.ValAddress, delAddr sdk.AccAddress) []byte {
+	return append(GetCommissionAgreementsKey(valAddr), delAddr.Bytes()...)
+}
@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// GetDelegatorRewards returns the rewards the delegations of a delegator would withdraw now, without
+// modifying any state. The delegations are computed in the order WithdrawDelegationRewardsAll withdraws them.
+func (k Keeper) GetDelegatorRewards(ctx sdk.Context, delAddr sdk.AccAddress) types.DelegatorRewards {
+	rewards := types.DelegatorRewards{
+		DelegatorAddr: delAddr,
+		Rewards:       make([]types.DelegationReward, 0),
+		Total:         types.DecCoins{},
+	}
+	height := ctx.BlockHeight()
+	lastTotalPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastTotalPower(ctx))
+	feePool := k.GetFeePool(ctx)
+
+	k.stakeKeeper.IterateDelegations(ctx, delAddr, func(_ int64, del sdk.Delegation) (stop bool) {
+		valAddr := del.GetValidatorAddr()
+		validator := k.stakeKeeper.Validator(ctx, valAddr)
+		if validator == nil || !k.HasDelegationDistInfo(ctx, delAddr, valAddr) || !k.HasValidatorDistInfo(ctx, valAddr) {
+			return false
+		}
+		lastValPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastValidatorPower(ctx, valAddr))
+		delInfo := k.GetDelegationDistInfo(ctx, delAddr, valAddr)
+		valInfo := k.GetValidatorDistInfo(ctx, valAddr)
+
+		var reward types.DecCoins
+		_, valInfo, feePool, reward = delInfo.WithdrawRewards(feePool, valInfo, height, lastTotalPower,
+			lastValPower, validator.GetDelegatorShares(), del.GetShares(), validator.GetCommission())
+		_, reward = k.applyCommissionAgreement(ctx, valInfo, delAddr, validator.GetCommission(), reward)
+		rewards.Rewards = append(rewards.Rewards, types.DelegationReward{ValidatorAddr: valAddr, Reward: reward})
+		rewards.Total = rewards.Total.Plus(reward)
+		return false
+	})
+	return rewards
+}
+
+// GetValidatorCommission returns the commission a validator would withdraw now, without modifying any state.
+// The commission rebated to the delegations with an agreement is deducted as WithdrawValidatorRewardsAll does.
+func (k Keeper) GetValidatorCommission(ctx sdk.Context, valAddr sdk.ValAddress) (types.ValidatorCommission, sdk.Error) {
+	if !k.HasValidatorDistInfo(ctx, valAddr) {
+		return types.ValidatorCommission{}, types.ErrNoValidatorDistInfo(k.codespace)
+	}
+	commission := types.ValidatorCommission{ValidatorAddr: valAddr, Commission: types.DecCoins{}}
+	validator := k.stakeKeeper.Validator(ctx, valAddr)
+	if validator == nil {
+		return commission, nil
+	}
+
+	height := ctx.BlockHeight()
+	lastTotalPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastTotalPower(ctx))
+	lastValPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastValidatorPower(ctx, valAddr))
+	feePool := k.GetFeePool(ctx)
+	valInfo := k.GetValidatorDistInfo(ctx, valAddr)
+
+	if sdk.IsUpgrade(sdk.CommissionAgreements) {
+		k.IterateCommissionAgreements(ctx, valAddr, func(agreement types.CommissionAgreement) bool {
+			delegation := k.stakeKeeper.Delegation(ctx, agreement.DelegatorAddr, valAddr)
+			if delegation == nil || !k.HasDelegationDistInfo(ctx, agreement.DelegatorAddr, valAddr) {
+				return false
+			}
+			delInfo := k.GetDelegationDistInfo(ctx, agreement.DelegatorAddr, valAddr)
+			var reward types.DecCoins
+			_, valInfo, feePool, reward = delInfo.WithdrawRewards(feePool, valInfo, height, lastTotalPower,
+				lastValPower, validator.GetDelegatorShares(), delegation.GetShares(), validator.GetCommission())
+			valInfo, _ = k.applyCommissionAgreement(ctx, valInfo, agreement.DelegatorAddr, validator.GetCommission(), reward)
+			return false
+		})
+	}
+	_, _, commission.Commission = valInfo.WithdrawCommission(feePool, height, lastTotalPower, lastValPower,
+		validator.GetCommission())
+	return commission, nil
+}
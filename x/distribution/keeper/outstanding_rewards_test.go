@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestOutstandingRewards(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CommissionAgreements, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.CommissionAgreements)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	balance := func(addr sdk.AccAddress) int64 {
+		return accMapper.GetAccount(ctx, addr).GetCoins().AmountOf(denom)
+	}
+
+	// two validators with 10% commission
+	got := stakeHandler(ctx, stake.NewTestMsgCreateValidatorWithCommission(
+		valOpAddr1, valConsPk1, sdk.NewDecWithoutFra(10).RawInt(), sdk.NewDecWithPrec(1, 1)))
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	got = stakeHandler(ctx, stake.NewTestMsgCreateValidatorWithCommission(
+		valOpAddr2, valConsPk2, sdk.NewDecWithoutFra(10).RawInt(), sdk.NewDecWithPrec(1, 1)))
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	// delegator 1 delegates to both, and pays no commission to validator 1
+	require.True(t, stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10)).IsOK())
+	require.True(t, stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr2, 10)).IsOK())
+	require.Nil(t, keeper.SetCommissionAgreement(ctx, types.NewCommissionAgreement(valOpAddr1, delAddr1, sdk.ZeroDec())))
+
+	// nothing is outstanding before the fees are allocated
+	rewards := keeper.GetDelegatorRewards(ctx, delAddr1)
+	require.Len(t, rewards.Rewards, 2)
+	require.True(t, rewards.Total.IsZero())
+	_, err := keeper.GetValidatorCommission(ctx, valOpAddr3)
+	require.NotNil(t, err)
+
+	// allocate 100 denom of fees to each validator
+	for _, valConsAddr := range []sdk.ConsAddress{valConsAddr1, valConsAddr2} {
+		fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+		keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr)
+	}
+	ctx = ctx.WithBlockHeight(1)
+
+	// the queries do not modify the state
+	rewards = keeper.GetDelegatorRewards(ctx, delAddr1)
+	require.Equal(t, rewards, keeper.GetDelegatorRewards(ctx, delAddr1))
+	require.Equal(t, delAddr1, rewards.DelegatorAddr)
+	require.Len(t, rewards.Rewards, 2)
+	require.False(t, rewards.Total.IsZero())
+	require.Equal(t, rewards.Total, rewards.Rewards[0].Reward.Plus(rewards.Rewards[1].Reward))
+	commission1, err := keeper.GetValidatorCommission(ctx, valOpAddr1)
+	require.Nil(t, err)
+	commission2, err := keeper.GetValidatorCommission(ctx, valOpAddr2)
+	require.Nil(t, err)
+	require.Equal(t, valOpAddr1, commission1.ValidatorAddr)
+	// the commission of validator 1 is lower by the rebate of delegator 1
+	require.True(t, commission1.Commission.AmountOf(denom).LT(commission2.Commission.AmountOf(denom)))
+
+	// the outstanding rewards and commission are what is withdrawn
+	before := balance(delAddr1)
+	keeper.WithdrawDelegationRewardsAll(ctx, delAddr1)
+	total, _ := rewards.Total.TruncateDecimal()
+	require.Equal(t, before+total.AmountOf(denom), balance(delAddr1))
+
+	selfRewards := keeper.GetDelegatorRewards(ctx, sdk.AccAddress(valOpAddr2))
+	before = balance(sdk.AccAddress(valOpAddr2))
+	require.Nil(t, keeper.WithdrawValidatorRewardsAll(ctx, valOpAddr2))
+	total, _ = selfRewards.Total.Plus(commission2.Commission).TruncateDecimal()
+	require.Equal(t, before+total.AmountOf(denom), balance(sdk.AccAddress(valOpAddr2)))
+
+	// nothing is left once withdrawn
+	commission2, err = keeper.GetValidatorCommission(ctx, valOpAddr2)
+	require.Nil(t, err)
+	require.True(t, commission2.Commission.IsZero())
+	require.True(t, keeper.GetDelegatorRewards(ctx, delAddr1).Total.IsZero())
+}
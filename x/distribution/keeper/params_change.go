@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+// GetDistrParams returns the distribution params changed by the DistrParamsChange proposals
+func (k Keeper) GetDistrParams(ctx sdk.Context) types.DistrParamsChange {
+	return types.DistrParamsChange{
+		FeePoolDenoms:       k.GetFeePoolDenoms(ctx),
+		BurnUnlistedFees:    k.GetBurnUnlistedFees(ctx),
+		RewardClaimDeadline: k.GetRewardClaimDeadline(ctx),
+		AutoDistInterval:    k.GetAutoDistInterval(ctx),
+		AutoDistBatchSize:   k.getAutoDistBatchSizeParam(ctx),
+	}
+}
+
+// SetDistrParams sets the distribution params changed by the DistrParamsChange proposals
+func (k Keeper) SetDistrParams(ctx sdk.Context, p types.DistrParamsChange) {
+	k.SetFeePoolDenoms(ctx, p.FeePoolDenoms)
+	k.SetBurnUnlistedFees(ctx, p.BurnUnlistedFees)
+	k.SetRewardClaimDeadline(ctx, p.RewardClaimDeadline)
+	k.SetAutoDistInterval(ctx, p.AutoDistInterval)
+	k.SetAutoDistBatchSize(ctx, p.AutoDistBatchSize)
+}
+
+// ExecuteDistrParamsChangeProposals applies the DistrParamsChange proposals passed since the last block.
+func (k Keeper) ExecuteDistrParamsChangeProposals(ctx sdk.Context) {
+	if k.govKeeper == nil {
+		return
+	}
+	logger := ctx.Logger().With("module", "distr")
+	for _, proposal := range sidechain.CollectPassedProposals(ctx, k.govKeeper, gov.ProposalTypeDistrParamsChange) {
+		var change types.DistrParamsChange
+		if err := types.MsgCdc.UnmarshalJSON([]byte(proposal.GetDescription()), &change); err != nil {
+			logger.Error("Get broken data when unmarshal DistrParamsChange msg, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		if err := change.Check(); err != nil {
+			logger.Error("The DistrParamsChange proposal is invalid, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		k.SetDistrParams(params.WithProposalID(ctx, proposal.GetProposalID()), change)
+		logger.Info("Changed distribution params", "proposalId", proposal.GetProposalID(), "params", change)
+	}
+}
+
+// ---------------------    DistrParamsChangeHooks  -----------------
+type DistrParamsChangeHooks struct{}
+
+func NewDistrParamsChangeHooks() DistrParamsChangeHooks {
+	return DistrParamsChangeHooks{}
+}
+
+var _ gov.GovHooks = DistrParamsChangeHooks{}
+
+func (hooks DistrParamsChangeHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeDistrParamsChange {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	var change types.DistrParamsChange
+	if err := types.MsgCdc.UnmarshalJSON([]byte(proposal.GetDescription()), &change); err != nil {
+		return fmt.Errorf("unmarshal DistrParamsChange failed: %v", err)
+	}
+	return change.Check()
+}
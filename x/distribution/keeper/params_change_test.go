@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+func TestDistrParamsChange(t *testing.T) {
+	ctx, _, keeper, _, _ := CreateTestInputDefault(t, false, 0)
+
+	require.Equal(t, types.DistrParamsChange{}, keeper.GetDistrParams(ctx))
+	change := types.DistrParamsChange{FeePoolDenoms: []string{"steak", "BNB"}, BurnUnlistedFees: true,
+		RewardClaimDeadline: 24 * time.Hour, AutoDistInterval: 100, AutoDistBatchSize: 50}
+	keeper.SetDistrParams(ctx, change)
+	require.Equal(t, change, keeper.GetDistrParams(ctx))
+
+	require.NoError(t, types.DistrParamsChange{}.Check())
+	require.Error(t, types.DistrParamsChange{FeePoolDenoms: []string{"steak", "steak"}}.Check())
+	require.Error(t, types.DistrParamsChange{FeePoolDenoms: []string{""}}.Check())
+	require.Error(t, types.DistrParamsChange{RewardClaimDeadline: -time.Hour}.Check())
+	require.Error(t, types.DistrParamsChange{AutoDistInterval: -1}.Check())
+	require.Error(t, types.DistrParamsChange{AutoDistBatchSize: -1}.Check())
+
+	// a zero batch size is kept as set, the default applies
+	change.AutoDistBatchSize = 0
+	keeper.SetDistrParams(ctx, change)
+	require.Equal(t, change, keeper.GetDistrParams(ctx))
+	require.Equal(t, types.DefaultAutoDistBatchSize, keeper.GetAutoDistBatchSize(ctx))
+
+	hooks := NewDistrParamsChangeHooks()
+	proposal := &gov.TextProposal{ProposalType: gov.ProposalTypeDistrParamsChange}
+	proposal.Description = string(types.MsgCdc.MustMarshalJSON(change))
+	require.NoError(t, hooks.OnProposalSubmitted(ctx, proposal))
+	proposal.Description = `{"fee_pool_denoms":["steak","steak"]}`
+	require.Error(t, hooks.OnProposalSubmitted(ctx, proposal))
+	proposal.Description = "not json"
+	require.Error(t, hooks.OnProposalSubmitted(ctx, proposal))
+}
@@ -0,0 +1,177 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// Returns how long the rewards of a delegation can be left unwithdrawn before they are swept into
+// the community pool, the rewards are never swept if 0
+func (k Keeper) GetRewardClaimDeadline(ctx sdk.Context) (deadline time.Duration) {
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyRewardClaimDeadline, &deadline)
+	return
+}
+
+// nolint: errcheck
+func (k Keeper) SetRewardClaimDeadline(ctx sdk.Context, deadline time.Duration) {
+	k.paramSpace.Set(ctx, ParamStoreKeyRewardClaimDeadline, &deadline)
+}
+
+func (k Keeper) getRewardSweep(ctx sdk.Context) (sweep types.RewardSweep, found bool) {
+	b := ctx.KVStore(k.storeKey).Get(RewardSweepKey)
+	if b == nil {
+		return sweep, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &sweep)
+	return sweep, true
+}
+
+func (k Keeper) setRewardSweep(ctx sdk.Context, sweep types.RewardSweep) {
+	ctx.KVStore(k.storeKey).Set(RewardSweepKey, k.cdc.MustMarshalBinaryLengthPrefixed(sweep))
+}
+
+func (k Keeper) removeRewardSweep(ctx sdk.Context) {
+	ctx.KVStore(k.storeKey).Delete(RewardSweepKey)
+}
+
+// SweepStaleRewards moves the rewards of the delegations not withdrawn for the claim deadline into
+// the community pool. A sweep starts every claim deadline and sweeps the rewards not withdrawn since
+// the former sweep started, so a reward is swept when it is between one and two deadlines old. A sweep
+// checks at most a batch of delegations in a block, it goes on in the next blocks until all the
+// delegations are checked.
+func (k Keeper) SweepStaleRewards(ctx sdk.Context) {
+	k.sweepStaleRewards(ctx, types.RewardSweepBatchSize)
+}
+
+func (k Keeper) sweepStaleRewards(ctx sdk.Context, batchSize int64) {
+	deadline := k.GetRewardClaimDeadline(ctx)
+	sweep, found := k.getRewardSweep(ctx)
+	if deadline <= 0 {
+		if found {
+			k.removeRewardSweep(ctx)
+		}
+		return
+	}
+
+	height, now := ctx.BlockHeight(), ctx.BlockHeader().Time
+	if !found {
+		// the deadline is counted from now on
+		k.setRewardSweep(ctx, types.RewardSweep{StartHeight: height, StartTime: now})
+		return
+	}
+	if sweep.Cursor == nil {
+		if now.Before(sweep.StartTime.Add(deadline)) {
+			return
+		}
+		sweep = types.RewardSweep{
+			StartHeight:  height,
+			StartTime:    now,
+			CutoffHeight: sweep.StartHeight,
+			Cursor:       DelegationDistInfoKey,
+		}
+	}
+
+	var stale []types.DelegationDistInfo
+	var next []byte
+	checked := int64(0)
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(sweep.Cursor, sdk.PrefixEndBytes(DelegationDistInfoKey))
+	for ; iterator.Valid(); iterator.Next() {
+		if checked == batchSize {
+			next = iterator.Key()
+			break
+		}
+		checked++
+		var ddi types.DelegationDistInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &ddi)
+		if ddi.WithdrawalHeight < sweep.CutoffHeight {
+			stale = append(stale, ddi)
+		}
+	}
+	iterator.Close()
+	sweep.Cursor = next
+	k.setRewardSweep(ctx, sweep)
+
+	for _, delInfo := range stale {
+		k.sweepDelegationReward(ctx, delInfo)
+	}
+}
+
+// sweepDelegationReward moves the rewards of a delegation into the community pool
+func (k Keeper) sweepDelegationReward(ctx sdk.Context, delInfo types.DelegationDistInfo) {
+	delAddr, valAddr := delInfo.DelegatorAddr, delInfo.ValOperatorAddr
+	if k.stakeKeeper.Validator(ctx, valAddr) == nil || k.stakeKeeper.Delegation(ctx, delAddr, valAddr) == nil ||
+		!k.HasValidatorDistInfo(ctx, valAddr) {
+		// the records are left over, there is nothing to withdraw against
+		return
+	}
+
+	swept := k.takeDelegationRewards(ctx, delAddr, valAddr)
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(swept)
+	k.SetFeePool(ctx, feePool)
+	if swept.IsZero() {
+		return
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeRewardSwept,
+		sdk.NewAttribute(types.AttributeKeyDelegator, delAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyValidator, valAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, swept.String()),
+	))
+}
+
+// GetRewardsAtRisk lists the pending rewards of the delegations of a delegator by the time they are
+// swept at, nothing is at risk if there is no claim deadline.
+func (k Keeper) GetRewardsAtRisk(ctx sdk.Context, delAddr sdk.AccAddress) []types.RewardAtRisk {
+	deadline := k.GetRewardClaimDeadline(ctx)
+	rewards := make([]types.RewardAtRisk, 0)
+	if deadline <= 0 {
+		return rewards
+	}
+	sweep, found := k.getRewardSweep(ctx)
+	if !found {
+		// the deadline is counted from the end of the block
+		sweep = types.RewardSweep{StartHeight: ctx.BlockHeight(), StartTime: ctx.BlockHeader().Time}
+	}
+	nextSweep := sweep.StartTime.Add(deadline)
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationDistInfosKey(delAddr))
+	var ddis []types.DelegationDistInfo
+	for ; iterator.Valid(); iterator.Next() {
+		var ddi types.DelegationDistInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &ddi)
+		ddis = append(ddis, ddi)
+	}
+	iterator.Close()
+
+	for _, ddi := range ddis {
+		debug, err := k.GetDelegationAccumDebug(ctx, delAddr, ddi.ValOperatorAddr)
+		if err != nil {
+			continue
+		}
+		sweepTime := nextSweep
+		if sweep.Cursor != nil && ddi.WithdrawalHeight < sweep.CutoffHeight &&
+			bytes.Compare(GetDelegationDistInfoKey(delAddr, ddi.ValOperatorAddr), sweep.Cursor) >= 0 {
+			// not checked by the sweep in progress yet
+			sweepTime = sweep.StartTime
+		} else if ddi.WithdrawalHeight >= sweep.StartHeight {
+			sweepTime = nextSweep.Add(deadline)
+		}
+		rewards = append(rewards, types.RewardAtRisk{
+			ValidatorAddr:    ddi.ValOperatorAddr,
+			WithdrawalHeight: ddi.WithdrawalHeight,
+			SweepTime:        sweepTime,
+			Rewards:          debug.PendingRewards,
+		})
+	}
+	sort.SliceStable(rewards, func(i, j int) bool {
+		return rewards[i].SweepTime.Before(rewards[j].SweepTime)
+	})
+	return rewards
+}
@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestSweepStaleRewards(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	require.True(t, stakeHandler(ctx, msgCreateValidator).IsOK())
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	require.True(t, stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10)).IsOK())
+
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(10).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(10).RawInt())
+
+	// nothing is at risk without deadline
+	start := time.Unix(1600000000, 0).UTC()
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(start)
+	require.Empty(t, keeper.GetRewardsAtRisk(ctx, delAddr1))
+	communityPool := keeper.GetFeePool(ctx).CommunityPool
+	keeper.SweepStaleRewards(ctx)
+	require.Equal(t, communityPool, keeper.GetFeePool(ctx).CommunityPool)
+	_, found := keeper.getRewardSweep(ctx)
+	require.False(t, found)
+
+	deadline := 24 * time.Hour
+	keeper.SetRewardClaimDeadline(ctx, deadline)
+	require.Equal(t, deadline, keeper.GetRewardClaimDeadline(ctx))
+
+	atRisk := keeper.GetRewardsAtRisk(ctx, delAddr1)
+	require.Len(t, atRisk, 1)
+	require.Equal(t, valOpAddr1, atRisk[0].ValidatorAddr)
+	require.Equal(t, start.Add(deadline), atRisk[0].SweepTime)
+	require.False(t, atRisk[0].Rewards.IsZero())
+
+	// the deadline is counted from the block it is set in
+	keeper.SweepStaleRewards(ctx)
+	ctx = ctx.WithBlockHeight(15).WithBlockTime(start.Add(deadline - time.Second))
+	keeper.SweepStaleRewards(ctx)
+	require.Equal(t, int64(0), keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).WithdrawalHeight)
+
+	ctx = ctx.WithBlockHeight(20).WithBlockTime(start.Add(deadline))
+	// the self-delegation of the validator is swept as well
+	pending := keeper.GetRewardsAtRisk(ctx, delAddr1)[0].Rewards
+	selfPending := keeper.GetRewardsAtRisk(ctx, sdk.AccAddress(valOpAddr1))[0].Rewards
+	communityPool = keeper.GetFeePool(ctx).CommunityPool
+	before := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+
+	// a sweep checks a batch of delegations in a block
+	keeper.sweepStaleRewards(ctx, 1)
+	require.Len(t, ctx.EventManager().Events(), 1)
+	sweep, _ := keeper.getRewardSweep(ctx)
+	require.NotNil(t, sweep.Cursor)
+	require.Equal(t, int64(10), sweep.CutoffHeight)
+	keeper.sweepStaleRewards(ctx, 1)
+	sweep, _ = keeper.getRewardSweep(ctx)
+	require.Nil(t, sweep.Cursor)
+
+	require.Equal(t, int64(20), keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).WithdrawalHeight)
+	require.Equal(t, communityPool.Plus(pending).Plus(selfPending), keeper.GetFeePool(ctx).CommunityPool)
+	require.Equal(t, before, accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom))
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 2)
+	for _, event := range events {
+		require.Equal(t, types.EventTypeRewardSwept, event.Type)
+	}
+
+	// the rewards withdrawn since the sweep started are swept two deadlines later
+	atRisk = keeper.GetRewardsAtRisk(ctx, delAddr1)
+	require.Equal(t, start.Add(3*deadline), atRisk[0].SweepTime)
+	ctx = ctx.WithBlockHeight(30).WithBlockTime(start.Add(2 * deadline))
+	keeper.SweepStaleRewards(ctx)
+	require.Equal(t, int64(20), keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).WithdrawalHeight)
+
+	// removing the deadline drops the sweeps
+	keeper.SetRewardClaimDeadline(ctx, 0)
+	keeper.SweepStaleRewards(ctx)
+	_, found = keeper.getRewardSweep(ctx)
+	require.False(t, found)
+}
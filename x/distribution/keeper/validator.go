@@ -47,12 +47,14 @@ func (k Keeper) WithdrawValidatorRewardsAll(ctx sdk.Context, operatorAddr sdk.Va
 		return types.ErrNoValidatorDistInfo(k.codespace)
 	}
 
+	k.settleCommissionAgreements(ctx, operatorAddr)
+
 	// withdraw self-delegation
 	height := ctx.BlockHeight()
 	validator := k.stakeKeeper.Validator(ctx, operatorAddr)
 	lastValPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastValidatorPower(ctx, operatorAddr))
 	accAddr := sdk.AccAddress(operatorAddr.Bytes())
-	withdraw := k.getDelegatorRewardsAll(ctx, accAddr, height)
+	withdraw := k.getDelegatorRewardsAll(ctx, accAddr)
 
 	// withdrawal validator commission rewards
 	lastTotalPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastTotalPower(ctx))
@@ -62,16 +64,9 @@ func (k Keeper) WithdrawValidatorRewardsAll(ctx sdk.Context, operatorAddr sdk.Va
 		lastValPower, validator.GetCommission())
 	withdraw = withdraw.Plus(commission)
 	k.SetValidatorDistInfo(ctx, valInfo)
-
-	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, accAddr)
-	truncated, change := withdraw.TruncateDecimal()
-	feePool.CommunityPool = feePool.CommunityPool.Plus(change)
 	k.SetFeePool(ctx, feePool)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, truncated)
-	if err != nil {
-		panic(err)
-	}
 
+	k.payWithdrawal(ctx, k.GetDelegatorWithdrawAddr(ctx, accAddr), withdraw)
 	return nil
 }
 
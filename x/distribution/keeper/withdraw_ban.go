@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+func (k *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
+	k.govKeeper = govKeeper
+}
+
+// BanWithdrawAddr forbids addr to be set as withdraw address, the withdraw addresses already set are kept
+func (k Keeper) BanWithdrawAddr(ctx sdk.Context, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetBannedWithdrawAddrKey(addr), []byte{0x01})
+}
+
+// UnbanWithdrawAddr allows addr to be set as withdraw address again
+func (k Keeper) UnbanWithdrawAddr(ctx sdk.Context, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetBannedWithdrawAddrKey(addr))
+}
+
+func (k Keeper) IsWithdrawAddrBanned(ctx sdk.Context, addr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetBannedWithdrawAddrKey(addr))
+}
+
+// iterate over the banned withdraw addresses
+func (k Keeper) IterateBannedWithdrawAddrs(ctx sdk.Context, fn func(addr sdk.AccAddress) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, BannedWithdrawAddrKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if fn(sdk.AccAddress(iterator.Key()[len(BannedWithdrawAddrKey):])) {
+			return
+		}
+	}
+}
+
+// CheckWithdrawAddr returns an error if addr can not be set as withdraw address
+func (k Keeper) CheckWithdrawAddr(ctx sdk.Context, addr sdk.AccAddress) sdk.Error {
+	if k.IsWithdrawAddrBanned(ctx, addr) {
+		return types.ErrBannedWithdrawAddr(k.codespace)
+	}
+	return nil
+}
+
+// ExecuteWithdrawAddrBansProposals applies the WithdrawAddrBans proposals passed since the last block.
+func (k Keeper) ExecuteWithdrawAddrBansProposals(ctx sdk.Context) {
+	if k.govKeeper == nil {
+		return
+	}
+	logger := ctx.Logger().With("module", "distr")
+	for _, proposal := range sidechain.CollectPassedProposals(ctx, k.govKeeper, gov.ProposalTypeWithdrawAddrBans) {
+		var bans types.WithdrawAddrBans
+		if err := types.MsgCdc.UnmarshalJSON([]byte(proposal.GetDescription()), &bans); err != nil {
+			logger.Error("Get broken data when unmarshal WithdrawAddrBans msg, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		if err := bans.Check(); err != nil {
+			logger.Error("The WithdrawAddrBans proposal is invalid, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		for _, addr := range bans.Ban {
+			k.BanWithdrawAddr(ctx, addr)
+		}
+		for _, addr := range bans.Unban {
+			k.UnbanWithdrawAddr(ctx, addr)
+		}
+		logger.Info("Changed banned withdraw addresses", "proposalId", proposal.GetProposalID(),
+			"banned", len(bans.Ban), "unbanned", len(bans.Unban))
+	}
+}
+
+// ---------------------    WithdrawAddrBansHooks  -----------------
+type WithdrawAddrBansHooks struct{}
+
+func NewWithdrawAddrBansHooks() WithdrawAddrBansHooks {
+	return WithdrawAddrBansHooks{}
+}
+
+var _ gov.GovHooks = WithdrawAddrBansHooks{}
+
+func (hooks WithdrawAddrBansHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeWithdrawAddrBans {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	var bans types.WithdrawAddrBans
+	if err := types.MsgCdc.UnmarshalJSON([]byte(proposal.GetDescription()), &bans); err != nil {
+		return fmt.Errorf("unmarshal WithdrawAddrBans failed: %v", err)
+	}
+	return bans.Check()
+}
@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+func TestBanWithdrawAddr(t *testing.T) {
+	ctx, _, keeper, _, _ := CreateTestInputDefault(t, false, 0)
+
+	require.NoError(t, keeper.CheckWithdrawAddr(ctx, delAddr1))
+	keeper.BanWithdrawAddr(ctx, delAddr1)
+	keeper.BanWithdrawAddr(ctx, delAddr2)
+	require.True(t, keeper.IsWithdrawAddrBanned(ctx, delAddr1))
+	err := keeper.CheckWithdrawAddr(ctx, delAddr1)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeBannedWithdrawAddr, err.Code())
+	require.NoError(t, keeper.CheckWithdrawAddr(ctx, delAddr3))
+
+	var banned []sdk.AccAddress
+	keeper.IterateBannedWithdrawAddrs(ctx, func(addr sdk.AccAddress) bool {
+		banned = append(banned, addr)
+		return false
+	})
+	require.ElementsMatch(t, []sdk.AccAddress{delAddr1, delAddr2}, banned)
+
+	keeper.UnbanWithdrawAddr(ctx, delAddr1)
+	require.False(t, keeper.IsWithdrawAddrBanned(ctx, delAddr1))
+	require.NoError(t, keeper.CheckWithdrawAddr(ctx, delAddr1))
+	require.True(t, keeper.IsWithdrawAddrBanned(ctx, delAddr2))
+
+	require.NoError(t, types.WithdrawAddrBans{Ban: []sdk.AccAddress{delAddr1}, Unban: []sdk.AccAddress{delAddr2}}.Check())
+	require.Error(t, types.WithdrawAddrBans{}.Check())
+	require.Error(t, types.WithdrawAddrBans{Ban: []sdk.AccAddress{delAddr1}, Unban: []sdk.AccAddress{delAddr1}}.Check())
+	require.Error(t, types.WithdrawAddrBans{Ban: []sdk.AccAddress{sdk.AccAddress("short")}}.Check())
+}
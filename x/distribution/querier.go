@@ -0,0 +1,253 @@
+package distribution
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// query endpoints supported by the distribution Querier
+const (
+	QuerySideChainReward          = "sideChainReward"
+	QueryDelegatorSideChainReward = "delegatorSideChainReward"
+	// QueryDebugDelegationAccum is meant for debugging, its output follows the internal state and may change
+	QueryDebugDelegationAccum = "debugDelegationAccum"
+	QueryRewardsAtRisk        = "rewardsAtRisk"
+	QueryCommissionAgreements = "commissionAgreements"
+	QueryWithdrawAddrs        = "withdrawAddrs"
+	QueryDelegatorRewards     = "delegatorRewards"
+	QueryValidatorCommission  = "validatorCommission"
+)
+
+type QuerySideChainRewardParams struct {
+	SideChainId string
+}
+
+type QueryDelegatorSideChainRewardParams struct {
+	DelegatorAddr sdk.AccAddress
+	SideChainId   string
+}
+
+type QueryRewardsAtRiskParams struct {
+	DelegatorAddr sdk.AccAddress
+}
+
+// the agreements of a validator, or of a validator with a delegator if DelegatorAddr is set
+type QueryCommissionAgreementsParams struct {
+	ValidatorAddr sdk.ValAddress
+	DelegatorAddr sdk.AccAddress
+}
+
+type QueryWithdrawAddrsParams struct {
+	DelegatorAddr sdk.AccAddress
+}
+
+type QueryDelegatorRewardsParams struct {
+	DelegatorAddr sdk.AccAddress
+}
+
+type QueryValidatorCommissionParams struct {
+	ValidatorAddr sdk.ValAddress
+}
+
+type QueryDebugDelegationAccumParams struct {
+	DelegatorAddr sdk.AccAddress
+	ValidatorAddr sdk.ValAddress
+}
+
+func NewQuerier(k keeper.Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QuerySideChainReward:
+			return querySideChainReward(ctx, cdc, req, k)
+		case QueryDelegatorSideChainReward:
+			return queryDelegatorSideChainReward(ctx, cdc, req, k)
+		case QueryDebugDelegationAccum:
+			return queryDebugDelegationAccum(ctx, cdc, req, k)
+		case QueryRewardsAtRisk:
+			return queryRewardsAtRisk(ctx, cdc, req, k)
+		case QueryCommissionAgreements:
+			return queryCommissionAgreements(ctx, cdc, req, k)
+		case QueryWithdrawAddrs:
+			return queryWithdrawAddrs(ctx, cdc, req, k)
+		case QueryDelegatorRewards:
+			return queryDelegatorRewards(ctx, cdc, req, k)
+		case QueryValidatorCommission:
+			return queryValidatorCommission(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown distr query endpoint")
+		}
+	}
+}
+
+func querySideChainReward(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QuerySideChainRewardParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if len(params.SideChainId) == 0 {
+		return nil, types.ErrInvalidSideChainId(types.DefaultCodespace)
+	}
+
+	reward := types.SideChainReward{
+		SideChainId: params.SideChainId,
+		Amount:      k.GetSideChainReward(ctx, params.SideChainId),
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, reward)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryDelegatorSideChainReward(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QueryDelegatorSideChainRewardParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.DelegatorAddr.Empty() {
+		return nil, types.ErrNilDelegatorAddr(types.DefaultCodespace)
+	}
+	if len(params.SideChainId) == 0 {
+		return nil, types.ErrInvalidSideChainId(types.DefaultCodespace)
+	}
+
+	reward := types.DelegatorSideChainReward{
+		DelegatorAddr: params.DelegatorAddr,
+		SideChainId:   params.SideChainId,
+		Amount:        k.GetDelegatorSideChainReward(ctx, params.DelegatorAddr, params.SideChainId),
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, reward)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryDebugDelegationAccum(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QueryDebugDelegationAccumParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.DelegatorAddr.Empty() {
+		return nil, types.ErrNilDelegatorAddr(types.DefaultCodespace)
+	}
+	if params.ValidatorAddr.Empty() {
+		return nil, types.ErrNilValidatorAddr(types.DefaultCodespace)
+	}
+
+	debug, sdkErr := k.GetDelegationAccumDebug(ctx, params.DelegatorAddr, params.ValidatorAddr)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, debug)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryRewardsAtRisk(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QueryRewardsAtRiskParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.DelegatorAddr.Empty() {
+		return nil, types.ErrNilDelegatorAddr(types.DefaultCodespace)
+	}
+
+	bz, err := codec.MarshalJSONIndent(cdc, k.GetRewardsAtRisk(ctx, params.DelegatorAddr))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryCommissionAgreements(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QueryCommissionAgreementsParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.ValidatorAddr.Empty() {
+		return nil, types.ErrNilValidatorAddr(types.DefaultCodespace)
+	}
+
+	agreements := make([]types.CommissionAgreement, 0)
+	if params.DelegatorAddr.Empty() {
+		k.IterateCommissionAgreements(ctx, params.ValidatorAddr, func(agreement types.CommissionAgreement) bool {
+			agreements = append(agreements, agreement)
+			return false
+		})
+	} else if agreement, found := k.GetCommissionAgreement(ctx, params.ValidatorAddr, params.DelegatorAddr); found {
+		agreements = append(agreements, agreement)
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, agreements)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryWithdrawAddrs(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QueryWithdrawAddrsParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.DelegatorAddr.Empty() {
+		return nil, types.ErrNilDelegatorAddr(types.DefaultCodespace)
+	}
+
+	withdrawAddrs := types.WithdrawAddrs{
+		DelegatorAddr: params.DelegatorAddr,
+		WithdrawAddr:  k.GetDelegatorWithdrawAddr(ctx, params.DelegatorAddr),
+		Delegations:   make([]types.DelegationWithdrawInfo, 0),
+	}
+	k.IterateDelegationWithdrawAddrs(ctx, params.DelegatorAddr, func(info types.DelegationWithdrawInfo) bool {
+		withdrawAddrs.Delegations = append(withdrawAddrs.Delegations, info)
+		return false
+	})
+	bz, err := codec.MarshalJSONIndent(cdc, withdrawAddrs)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryDelegatorRewards(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QueryDelegatorRewardsParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.DelegatorAddr.Empty() {
+		return nil, types.ErrNilDelegatorAddr(types.DefaultCodespace)
+	}
+
+	bz, err := codec.MarshalJSONIndent(cdc, k.GetDelegatorRewards(ctx, params.DelegatorAddr))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryValidatorCommission(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keeper.Keeper) ([]byte, sdk.Error) {
+	var params QueryValidatorCommissionParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.ValidatorAddr.Empty() {
+		return nil, types.ErrNilValidatorAddr(types.DefaultCodespace)
+	}
+
+	commission, sdkErr := k.GetValidatorCommission(ctx, params.ValidatorAddr)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, commission)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -10,6 +10,8 @@ var (
 	ActionWithdrawDelegatorRewardsAll = []byte("withdraw-delegator-rewards-all")
 	ActionWithdrawDelegatorReward     = []byte("withdraw-delegator-reward")
 	ActionWithdrawValidatorRewardsAll = []byte("withdraw-validator-rewards-all")
+	ActionSetCommissionAgreement      = []byte("set-commission-agreement")
+	ActionRemoveCommissionAgreement   = []byte("remove-commission-agreement")
 
 	Action    = sdk.TagAction
 	Validator = sdk.TagSrcValidator
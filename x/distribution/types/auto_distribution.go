@@ -0,0 +1,12 @@
+package types
+
+// the rewards of the delegations are distributed to their withdraw addresses every auto distribution interval
+const (
+	EventTypeRewardDistributed = "reward_distributed"
+
+	AttributeKeyWithdrawAddr = "withdraw_addr"
+
+	// DefaultAutoDistBatchSize is the number of delegations an automatic distribution settles in a block if the
+	// batch size is not set
+	DefaultAutoDistBatchSize int64 = 1000
+)
@@ -10,6 +10,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgWithdrawDelegatorReward{}, "cosmos-sdk/MsgWithdrawDelegationReward", nil)
 	cdc.RegisterConcrete(MsgWithdrawValidatorRewardsAll{}, "cosmos-sdk/MsgWithdrawValidatorRewardsAll", nil)
 	cdc.RegisterConcrete(MsgSetWithdrawAddress{}, "cosmos-sdk/MsgModifyWithdrawAddress", nil)
+	cdc.RegisterConcrete(MsgSetCommissionAgreement{}, "cosmos-sdk/MsgSetCommissionAgreement", nil)
+	cdc.RegisterConcrete(MsgRemoveCommissionAgreement{}, "cosmos-sdk/MsgRemoveCommissionAgreement", nil)
 }
 
 // generic sealed codec to be used throughout module
@@ -0,0 +1,58 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// a validator charges the delegators it has an agreement with a discounted commission rate
+const (
+	EventTypeCommissionAgreementSet     = "commission_agreement_set"
+	EventTypeCommissionAgreementRemoved = "commission_agreement_removed"
+
+	AttributeKeyRate = "rate"
+
+	// MaxCommissionAgreements is the max number of agreements of a validator, the delegations with an
+	// agreement withdraw their rewards whenever the validator withdraws its commission
+	MaxCommissionAgreements = 100
+)
+
+// CommissionAgreement is the commission rate a validator charges the rewards of a delegator, it only
+// applies while it is below the commission rate of the validator
+type CommissionAgreement struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	Rate          sdk.Dec        `json:"rate"`
+}
+
+func NewCommissionAgreement(valAddr sdk.ValAddress, delAddr sdk.AccAddress, rate sdk.Dec) CommissionAgreement {
+	return CommissionAgreement{
+		ValidatorAddr: valAddr,
+		DelegatorAddr: delAddr,
+		Rate:          rate,
+	}
+}
+
+// CommissionRebate returns the part of the commission waived by an agreed rate for the rewards withdrawn by a
+// delegation. The rewards were charged the commission rate of the validator, the rebate brings them to what
+// they are at the agreed rate.
+func CommissionRebate(withdrawn DecCoins, commissionRate, agreedRate sdk.Dec) DecCoins {
+	if !agreedRate.LT(commissionRate) || !commissionRate.LT(sdk.OneDec()) {
+		return DecCoins{}
+	}
+	return withdrawn.MulDec(commissionRate.Sub(agreedRate)).QuoDec(sdk.OneDec().Sub(commissionRate))
+}
+
+// CapDecCoins returns the coins capped by the amounts of max denom by denom
+func CapDecCoins(coins, max DecCoins) DecCoins {
+	capped := DecCoins{}
+	for _, coin := range coins {
+		amount := coin.Amount
+		if limit := max.AmountOf(coin.Denom); limit.LT(amount) {
+			amount = limit
+		}
+		if amount.GT(sdk.ZeroDec()) {
+			capped = append(capped, DecCoin{Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return capped
+}
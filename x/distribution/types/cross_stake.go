@@ -0,0 +1,28 @@
+package types
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CrossStakeRewardPackage carries the rewards of the delegations to the validators of a side chain,
+// the amounts are in the decimals of the side chain
+type CrossStakeRewardPackage struct {
+	SideChainId sdk.ChainID
+	Recipients  []sdk.AccAddress
+	Amounts     []*big.Int
+}
+
+// SideChainReward is the total reward credited from a side chain
+type SideChainReward struct {
+	SideChainId string    `json:"side_chain_id"`
+	Amount      sdk.Coins `json:"amount"`
+}
+
+// DelegatorSideChainReward is the reward credited to a delegator from a side chain
+type DelegatorSideChainReward struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	SideChainId   string         `json:"side_chain_id"`
+	Amount        sdk.Coins      `json:"amount"`
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelegationAccumDebug is the raw accum state behind the rewards of a delegation, together
+// with the stake values used to compute them, as of Height.
+type DelegationAccumDebug struct {
+	Height int64 `json:"height"`
+
+	FeePool               FeePool            `json:"fee_pool"`
+	ValidatorInfo         ValidatorDistInfo  `json:"validator_info"`
+	DelegationInfo        DelegationDistInfo `json:"delegation_info"`
+	DelegatorWithdrawAddr sdk.AccAddress     `json:"delegator_withdraw_addr"`
+
+	LastTotalPower           sdk.Dec `json:"last_total_power"`
+	LastValidatorPower       sdk.Dec `json:"last_validator_power"`
+	ValidatorDelegatorShares sdk.Dec `json:"validator_delegator_shares"`
+	DelegatorShares          sdk.Dec `json:"delegator_shares"`
+	CommissionRate           sdk.Dec `json:"commission_rate"`
+
+	// rewards the delegation would withdraw at Height
+	PendingRewards DecCoins `json:"pending_rewards"`
+}
@@ -176,3 +176,21 @@ func (coins DecCoins) AmountOf(denom string) sdk.Dec {
 		}
 	}
 }
+
+// returns whether all the amounts are zero
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func (coins DecCoins) String() string {
+	out := make([]string, len(coins))
+	for i, coin := range coins {
+		out[i] = fmt.Sprintf("%v%v", coin.Amount, coin.Denom)
+	}
+	return strings.Join(out, ",")
+}
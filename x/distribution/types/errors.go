@@ -11,6 +11,12 @@ const (
 	DefaultCodespace       sdk.CodespaceType = 6
 	CodeInvalidInput       CodeType          = 103
 	CodeNoDistributionInfo CodeType          = 104
+	CodeInvalidPackage     CodeType          = 105
+	CodeInvalidSideChain   CodeType          = 106
+	CodeBannedWithdrawAddr CodeType          = 107
+	CodeInvalidAgreement   CodeType          = 108
+	CodeNoAgreement        CodeType          = 109
+	CodeNoWithdrawPubKey   CodeType          = 110
 )
 
 func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
@@ -28,3 +34,21 @@ func ErrNoDelegationDistInfo(codespace sdk.CodespaceType) sdk.Error {
 func ErrNoValidatorDistInfo(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeNoDistributionInfo, "no validator distribution info")
 }
+func ErrInvalidPackage(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPackage, msg)
+}
+func ErrInvalidSideChainId(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidSideChain, "invalid side chain id")
+}
+func ErrBannedWithdrawAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeBannedWithdrawAddr, "withdraw address is banned")
+}
+func ErrInvalidCommissionAgreement(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAgreement, msg)
+}
+func ErrNoCommissionAgreement(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoAgreement, "no commission agreement")
+}
+func ErrNoWithdrawPubKey(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeNoWithdrawPubKey, msg)
+}
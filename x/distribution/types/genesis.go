@@ -1,6 +1,10 @@
 package types
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 // the address for where distributions rewards are withdrawn to by default
 // this struct is only used at genesis to feed in default withdraw addresses
@@ -9,15 +13,38 @@ type DelegatorWithdrawInfo struct {
 	WithdrawAddr  sdk.AccAddress `json:"withdraw_addr"`
 }
 
+// the address the rewards of a delegation are withdrawn to, instead of the withdraw address of the delegator
+type DelegationWithdrawInfo struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	WithdrawAddr  sdk.AccAddress `json:"withdraw_addr"`
+}
+
+func NewDelegationWithdrawInfo(delAddr sdk.AccAddress, valAddr sdk.ValAddress, withdrawAddr sdk.AccAddress) DelegationWithdrawInfo {
+	return DelegationWithdrawInfo{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		WithdrawAddr:  withdrawAddr,
+	}
+}
+
 // GenesisState - all distribution state that must be provided at genesis
 type GenesisState struct {
-	FeePool                FeePool                 `json:"fee_pool"`
-	CommunityTax           sdk.Dec                 `json:"community_tax"`
-	BaseProposerReward     sdk.Dec                 `json:"base_proposer_reward"`
-	BonusProposerReward    sdk.Dec                 `json:"bonus_proposer_reward"`
-	ValidatorDistInfos     []ValidatorDistInfo     `json:"validator_dist_infos"`
-	DelegationDistInfos    []DelegationDistInfo    `json:"delegator_dist_infos"`
-	DelegatorWithdrawInfos []DelegatorWithdrawInfo `json:"delegator_withdraw_infos"`
+	FeePool                 FeePool                  `json:"fee_pool"`
+	CommunityTax            sdk.Dec                  `json:"community_tax"`
+	BaseProposerReward      sdk.Dec                  `json:"base_proposer_reward"`
+	BonusProposerReward     sdk.Dec                  `json:"bonus_proposer_reward"`
+	ValidatorDistInfos      []ValidatorDistInfo      `json:"validator_dist_infos"`
+	DelegationDistInfos     []DelegationDistInfo     `json:"delegator_dist_infos"`
+	DelegatorWithdrawInfos  []DelegatorWithdrawInfo  `json:"delegator_withdraw_infos"`
+	BannedWithdrawAddrs     []sdk.AccAddress         `json:"banned_withdraw_addrs,omitempty"`
+	FeePoolDenoms           []string                 `json:"fee_pool_denoms,omitempty"`
+	BurnUnlistedFees        bool                     `json:"burn_unlisted_fees,omitempty"`
+	RewardClaimDeadline     time.Duration            `json:"reward_claim_deadline,omitempty"`
+	CommissionAgreements    []CommissionAgreement    `json:"commission_agreements,omitempty"`
+	AutoDistInterval        int64                    `json:"auto_dist_interval,omitempty"`
+	AutoDistBatchSize       int64                    `json:"auto_dist_batch_size,omitempty"`
+	DelegationWithdrawInfos []DelegationWithdrawInfo `json:"delegation_withdraw_infos,omitempty"`
 }
 
 func NewGenesisState(feePool FeePool, communityTax, baseProposerReward, bonusProposerReward sdk.Dec,
@@ -11,13 +11,18 @@ const MsgRoute = "distr"
 // Verify interface at compile time
 var _, _ sdk.Msg = &MsgSetWithdrawAddress{}, &MsgWithdrawDelegatorRewardsAll{}
 var _, _ sdk.Msg = &MsgWithdrawDelegatorReward{}, &MsgWithdrawValidatorRewardsAll{}
+var _, _ sdk.Msg = &MsgSetCommissionAgreement{}, &MsgRemoveCommissionAgreement{}
 
 //______________________________________________________________________
 
-// msg struct for changing the withdraw address for a delegator (or validator self-delegation)
+// msg struct for changing the withdraw address for a delegator (or validator self-delegation),
+// or only for its delegation to ValidatorAddr if set. Setting the withdraw address of a delegation
+// to DelegatorAddr removes it, the rewards of the delegation go to the withdraw address of the
+// delegator again, which is not DelegatorAddr if the delegator has set one.
 type MsgSetWithdrawAddress struct {
 	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
 	WithdrawAddr  sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr,omitempty"`
 }
 
 func NewMsgSetWithdrawAddress(delAddr, withdrawAddr sdk.AccAddress) MsgSetWithdrawAddress {
@@ -27,6 +32,14 @@ func NewMsgSetWithdrawAddress(delAddr, withdrawAddr sdk.AccAddress) MsgSetWithdr
 	}
 }
 
+func NewMsgSetDelegationWithdrawAddress(delAddr sdk.AccAddress, valAddr sdk.ValAddress, withdrawAddr sdk.AccAddress) MsgSetWithdrawAddress {
+	return MsgSetWithdrawAddress{
+		DelegatorAddr: delAddr,
+		WithdrawAddr:  withdrawAddr,
+		ValidatorAddr: valAddr,
+	}
+}
+
 func (msg MsgSetWithdrawAddress) Route() string { return MsgRoute }
 func (msg MsgSetWithdrawAddress) Type() string  { return "set_withdraw_address" }
 
@@ -189,3 +202,105 @@ func (msg MsgWithdrawValidatorRewardsAll) ValidateBasic() sdk.Error {
 func (msg MsgWithdrawValidatorRewardsAll) GetInvolvedAddresses() []sdk.AccAddress {
 	return msg.GetSigners()
 }
+
+//______________________________________________________________________
+
+// msg struct for a validator to charge a delegator a discounted commission rate
+type MsgSetCommissionAgreement struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	Rate          sdk.Dec        `json:"rate"`
+}
+
+func NewMsgSetCommissionAgreement(valAddr sdk.ValAddress, delAddr sdk.AccAddress, rate sdk.Dec) MsgSetCommissionAgreement {
+	return MsgSetCommissionAgreement{
+		ValidatorAddr: valAddr,
+		DelegatorAddr: delAddr,
+		Rate:          rate,
+	}
+}
+
+func (msg MsgSetCommissionAgreement) Route() string { return MsgRoute }
+func (msg MsgSetCommissionAgreement) Type() string  { return "set_commission_agreement" }
+
+// Return address that must sign over msg.GetSignBytes()
+func (msg MsgSetCommissionAgreement) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr.Bytes())}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSetCommissionAgreement) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSetCommissionAgreement) ValidateBasic() sdk.Error {
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	if msg.DelegatorAddr.Equals(sdk.AccAddress(msg.ValidatorAddr)) {
+		return ErrInvalidCommissionAgreement(DefaultCodespace, "a validator can not have an agreement with itself")
+	}
+	if msg.Rate.LT(sdk.ZeroDec()) || !msg.Rate.LT(sdk.OneDec()) {
+		return ErrInvalidCommissionAgreement(DefaultCodespace, "rate should be in [0, 1)")
+	}
+	return nil
+}
+
+func (msg MsgSetCommissionAgreement) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr), msg.DelegatorAddr}
+}
+
+//______________________________________________________________________
+
+// msg struct for a validator to end the agreement with a delegator
+type MsgRemoveCommissionAgreement struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+}
+
+func NewMsgRemoveCommissionAgreement(valAddr sdk.ValAddress, delAddr sdk.AccAddress) MsgRemoveCommissionAgreement {
+	return MsgRemoveCommissionAgreement{
+		ValidatorAddr: valAddr,
+		DelegatorAddr: delAddr,
+	}
+}
+
+func (msg MsgRemoveCommissionAgreement) Route() string { return MsgRoute }
+func (msg MsgRemoveCommissionAgreement) Type() string  { return "remove_commission_agreement" }
+
+// Return address that must sign over msg.GetSignBytes()
+func (msg MsgRemoveCommissionAgreement) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr.Bytes())}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgRemoveCommissionAgreement) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgRemoveCommissionAgreement) ValidateBasic() sdk.Error {
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	return nil
+}
+
+func (msg MsgRemoveCommissionAgreement) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr), msg.DelegatorAddr}
+}
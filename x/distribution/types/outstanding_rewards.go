@@ -0,0 +1,25 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelegationReward is the reward a delegation would withdraw now
+type DelegationReward struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Reward        DecCoins       `json:"reward"`
+}
+
+// DelegatorRewards are the rewards the delegations of a delegator would withdraw now, Total is what
+// withdrawing them all pays before the decimals are truncated
+type DelegatorRewards struct {
+	DelegatorAddr sdk.AccAddress     `json:"delegator_addr"`
+	Rewards       []DelegationReward `json:"rewards"`
+	Total         DecCoins           `json:"total"`
+}
+
+// ValidatorCommission is the commission a validator would withdraw now
+type ValidatorCommission struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Commission    DecCoins       `json:"commission"`
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DistrParamsChange is the description of a DistrParamsChange proposal, the distribution params
+// to set. An empty FeePoolDenoms accepts every denom into the fee pool, a zero RewardClaimDeadline
+// never sweeps the rewards, a zero AutoDistInterval never distributes them automatically and a zero
+// AutoDistBatchSize settles DefaultAutoDistBatchSize delegations in a block.
+type DistrParamsChange struct {
+	FeePoolDenoms       []string      `json:"fee_pool_denoms"`
+	BurnUnlistedFees    bool          `json:"burn_unlisted_fees"`
+	RewardClaimDeadline time.Duration `json:"reward_claim_deadline"`
+	AutoDistInterval    int64         `json:"auto_dist_interval"`
+	AutoDistBatchSize   int64         `json:"auto_dist_batch_size"`
+}
+
+func (p DistrParamsChange) Check() error {
+	seen := make(map[string]bool, len(p.FeePoolDenoms))
+	for _, denom := range p.FeePoolDenoms {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return err
+		}
+		if seen[denom] {
+			return fmt.Errorf("duplicated fee pool denom %s", denom)
+		}
+		seen[denom] = true
+	}
+	if p.RewardClaimDeadline < 0 {
+		return fmt.Errorf("reward claim deadline should not be negative")
+	}
+	if p.AutoDistInterval < 0 {
+		return fmt.Errorf("auto distribution interval should not be negative")
+	}
+	if p.AutoDistBatchSize < 0 {
+		return fmt.Errorf("auto distribution batch size should not be negative")
+	}
+	return nil
+}
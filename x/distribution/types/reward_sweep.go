@@ -0,0 +1,37 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the rewards left unwithdrawn past the claim deadline are swept into the community pool
+const (
+	EventTypeRewardSwept = "reward_swept"
+
+	AttributeKeyDelegator = "delegator"
+	AttributeKeyValidator = "validator"
+	AttributeKeyAmount    = "amount"
+
+	// RewardSweepBatchSize is the number of delegations a sweep checks in a block
+	RewardSweepBatchSize int64 = 1000
+)
+
+// RewardSweep is the state of the sweeps of the stale rewards. A sweep starts a claim deadline after the
+// former one and sweeps the rewards not withdrawn since the former one started.
+type RewardSweep struct {
+	StartHeight  int64     `json:"start_height"`  // the height the last sweep started at
+	StartTime    time.Time `json:"start_time"`    // the block time the last sweep started at
+	CutoffHeight int64     `json:"cutoff_height"` // the rewards last withdrawn before the height are stale
+	Cursor       []byte    `json:"cursor"`        // the next delegation to check, nil if no sweep is in progress
+}
+
+// RewardAtRisk is the pending reward of a delegation along with the earliest block time it is swept
+// at if it is not withdrawn before
+type RewardAtRisk struct {
+	ValidatorAddr    sdk.ValAddress `json:"validator_addr"`
+	WithdrawalHeight int64          `json:"withdrawal_height"`
+	SweepTime        time.Time      `json:"sweep_time"`
+	Rewards          DecCoins       `json:"rewards"`
+}
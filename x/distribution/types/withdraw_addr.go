@@ -0,0 +1,13 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WithdrawAddrs are the addresses the rewards of a delegator are withdrawn to, the rewards of the
+// delegations in Delegations go to their own withdraw address instead of WithdrawAddr
+type WithdrawAddrs struct {
+	DelegatorAddr sdk.AccAddress           `json:"delegator_addr"`
+	WithdrawAddr  sdk.AccAddress           `json:"withdraw_addr"`
+	Delegations   []DelegationWithdrawInfo `json:"delegations"`
+}
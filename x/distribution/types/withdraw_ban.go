@@ -0,0 +1,33 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WithdrawAddrBans is the description of a WithdrawAddrBans proposal, the addresses in Ban can no
+// longer be set as withdraw address, the ones in Unban can be again.
+type WithdrawAddrBans struct {
+	Ban   []sdk.AccAddress `json:"ban"`
+	Unban []sdk.AccAddress `json:"unban"`
+}
+
+func (b WithdrawAddrBans) Check() error {
+	if len(b.Ban) == 0 && len(b.Unban) == 0 {
+		return fmt.Errorf("no address to ban or unban")
+	}
+	seen := make(map[string]bool, len(b.Ban)+len(b.Unban))
+	for _, addrs := range [][]sdk.AccAddress{b.Ban, b.Unban} {
+		for _, addr := range addrs {
+			if len(addr) != sdk.AddrLen {
+				return fmt.Errorf("invalid address %s, expected length %d", addr, sdk.AddrLen)
+			}
+			if seen[string(addr)] {
+				return fmt.Errorf("duplicated address %s", addr)
+			}
+			seen[string(addr)] = true
+		}
+	}
+	return nil
+}
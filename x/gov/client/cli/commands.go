@@ -24,6 +24,7 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 			GetCmdSubmitListProposal(cdc),
 			GetCmdSubmitDelistProposal(cdc),
 			GetCmdVote(cdc),
+			GetCmdVoteWeighted(cdc),
 		)...,
 	)
 
@@ -37,6 +38,7 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 			GetCmdQueryDeposits(storeGov, cdc),
 			GetCmdQueryVote(storeGov, cdc),
 			GetCmdQueryVotes(storeGov, cdc),
+			GetCmdQueryTallyBreakdown(storeGov, cdc),
 		)...,
 	)
 	cmd.AddCommand(govCmd)
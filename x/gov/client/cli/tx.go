@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +33,7 @@ const (
 	flagDeposit           = "deposit"
 	flagVoter             = "voter"
 	flagOption            = "option"
+	flagOptions           = "options"
 	flagDepositer         = "depositer"
 	flagStatus            = "status"
 	flagLatestProposalIDs = "latest"
@@ -41,6 +43,7 @@ const (
 	flagInitPrice         = "init-price"
 	flagExpireTime        = "expire-time"
 	flagSideChainId       = "side-chain-id"
+	flagLimit             = "limit"
 )
 
 type proposal struct {
@@ -320,6 +323,74 @@ func GetCmdVote(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdVoteWeighted implements splitting a vote across several options.
+func GetCmdVoteWeighted(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-weighted",
+		Short: "Split a vote for an active proposal across several options, e.g. yes=70,abstain=30",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			voterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			proposalID := viper.GetInt64(flagProposalID)
+			options, err := parseWeightedVoteOptions(viper.GetString(flagOptions))
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgVoteWeighted(voterAddr, proposalID, options)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			fmt.Printf("Vote[Voter:%s,ProposalID:%d,Options:%s]",
+				voterAddr.String(), proposalID, options,
+			)
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
+	cmd.Flags().String(flagOptions, "", "percentages of the vote by option {yes, no, no_with_veto, abstain}, summing to 100, e.g. yes=70,abstain=30")
+
+	return cmd
+}
+
+// parseWeightedVoteOptions parses a comma separated list of option=percentage
+func parseWeightedVoteOptions(str string) (gov.WeightedVoteOptions, error) {
+	var options gov.WeightedVoteOptions
+	for _, pair := range strings.Split(str, ",") {
+		fields := strings.Split(strings.TrimSpace(pair), "=")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("'%s' is not of the form option=percentage", pair)
+		}
+		option, err := gov.VoteOptionFromString(client.NormalizeVoteOption(fields[0]))
+		if err != nil {
+			return nil, err
+		}
+		percentage, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || percentage <= 0 || percentage > 100 {
+			return nil, fmt.Errorf("the percentage of %s should be an integer in range 1 to 100", fields[0])
+		}
+		options = append(options, gov.WeightedVoteOption{Option: option, Weight: sdk.NewDecWithPrec(percentage, 2)})
+	}
+	return options, nil
+}
+
 // GetCmdQueryProposal implements the query proposal command.
 func GetCmdQueryProposal(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -627,6 +698,43 @@ func GetCmdQueryTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryTallyBreakdown implements the command to query how each validator contributed to a tally.
+func GetCmdQueryTallyBreakdown(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tally-breakdown",
+		Short: "Get the per-validator breakdown of a proposal tally, ordered by voting power",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			proposalID := viper.GetInt64(flagProposalID)
+			sideChainId := viper.GetString(flagSideChainId)
+
+			params := gov.QueryTallyBreakdownParams{
+				BaseParams: gov.NewBaseParams(sideChainId),
+				ProposalID: proposalID,
+				Limit:      viper.GetInt(flagLimit),
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/tally_breakdown", queryRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal is being tallied")
+	cmd.Flags().Int(flagLimit, 0, "number of top voters to show, all validators if 0")
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
+
+	return cmd
+}
+
 // GetCmdSubmitListProposal implements submitting a proposal transaction command.
 func GetCmdSubmitListProposal(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -6,6 +6,9 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 )
 
 func TestParseSubmitProposalFlags(t *testing.T) {
@@ -69,3 +72,17 @@ func TestParseSubmitProposalFlags(t *testing.T) {
 	err = badJSON.Close()
 	require.Nil(t, err, "unexpected error")
 }
+
+func TestParseWeightedVoteOptions(t *testing.T) {
+	options, err := parseWeightedVoteOptions("yes=70, abstain=30")
+	require.Nil(t, err)
+	require.Equal(t, gov.WeightedVoteOptions{
+		{Option: gov.OptionYes, Weight: sdk.NewDecWithPrec(7, 1)},
+		{Option: gov.OptionAbstain, Weight: sdk.NewDecWithPrec(3, 1)},
+	}, options)
+
+	for _, str := range []string{"", "yes", "maybe=100", "yes=0.7,no=0.3", "yes=101", "yes=-1"} {
+		_, err = parseWeightedVoteOptions(str)
+		require.Error(t, err, str)
+	}
+}
@@ -38,6 +38,22 @@ func NormalizeProposalType(proposalType string) string {
 		return "CSCParamsChange"
 	case "ManageChanPermission", "manage_chan_permission":
 		return "ManageChanPermission"
+	case "DepositParamsChange", "deposit_params_change":
+		return "DepositParamsChange"
+	case "RelayerAllowList", "relayer_allow_list":
+		return "RelayerAllowList"
+	case "ManageChanSenders", "manage_chan_senders":
+		return "ManageChanSenders"
+	case "WithdrawAddrBans", "withdraw_addr_bans":
+		return "WithdrawAddrBans"
+	case "SkipSequence", "skip_sequence":
+		return "SkipSequence"
+	case "RegisterDestChain", "register_dest_chain":
+		return "RegisterDestChain"
+	case "RegisterChannel", "register_channel":
+		return "RegisterChannel"
+	case "DistrParamsChange", "distr_params_change":
+		return "DistrParamsChange"
 	}
 	return ""
 }
@@ -10,6 +10,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProposal{}, "cosmos-sdk/MsgSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgDeposit{}, "cosmos-sdk/MsgDeposit", nil)
 	cdc.RegisterConcrete(MsgVote{}, "cosmos-sdk/MsgVote", nil)
+	cdc.RegisterConcrete(MsgVoteWeighted{}, "cosmos-sdk/MsgVoteWeighted", nil)
 
 	cdc.RegisterConcrete(MsgSideChainSubmitProposal{}, "cosmos-sdk/MsgSideChainSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgSideChainDeposit{}, "cosmos-sdk/MsgSideChainDeposit", nil)
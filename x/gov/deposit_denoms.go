@@ -0,0 +1,74 @@
+package gov
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// PriceFeed gives the price of a denom in another one, e.g. the bond denom
+type PriceFeed interface {
+	// GetPrice returns how many quoteDenom one unit of denom is worth, false if it is unknown
+	GetPrice(ctx sdk.Context, denom, quoteDenom string) (sdk.Dec, bool)
+}
+
+// SetPriceFeed sets the prices used to weight the deposits made in DepositParams.DepositDenoms,
+// e.g. the market prices of the node app. By default, the prices are DepositParams.DepositPrices.
+func (keeper *Keeper) SetPriceFeed(priceFeed PriceFeed) {
+	keeper.priceFeed = priceFeed
+}
+
+// depositParamsPriceFeed gives the prices set by governance in DepositParams.DepositPrices
+type depositParamsPriceFeed struct {
+	paramSpace params.Subspace
+}
+
+var _ PriceFeed = depositParamsPriceFeed{}
+
+func (f depositParamsPriceFeed) GetPrice(ctx sdk.Context, denom, quoteDenom string) (sdk.Dec, bool) {
+	var depositParams DepositParams
+	f.paramSpace.Get(ctx, ParamStoreKeyDepositParams, &depositParams)
+	if len(depositParams.MinDeposit) != 1 || depositParams.MinDeposit[0].Denom != quoteDenom {
+		return sdk.Dec{}, false
+	}
+	for _, price := range depositParams.DepositPrices {
+		if price.Denom == denom {
+			return price.Price, true
+		}
+	}
+	return sdk.Dec{}, false
+}
+
+// IsDepositEnough returns true if deposit covers minDeposit. Since GovDepositDenoms, the coins of
+// deposit in the DepositDenoms also count, converted into the denom of MinDeposit at their price.
+func (keeper Keeper) IsDepositEnough(ctx sdk.Context, deposit, minDeposit sdk.Coins) bool {
+	if deposit.IsGTE(minDeposit) {
+		return true
+	}
+	if !sdk.IsUpgrade(sdk.GovDepositDenoms) || len(minDeposit) != 1 {
+		return false
+	}
+	bondDenom := minDeposit[0].Denom
+	return keeper.DepositWeight(ctx, deposit, bondDenom).Cmp(big.NewInt(minDeposit[0].Amount)) >= 0
+}
+
+// DepositWeight returns the amount of bondDenom the deposit is worth. Coins neither in bondDenom
+// nor in the DepositDenoms, or without a price, weigh nothing.
+func (keeper Keeper) DepositWeight(ctx sdk.Context, deposit sdk.Coins, bondDenom string) *big.Int {
+	weight := big.NewInt(deposit.AmountOf(bondDenom))
+	for _, denom := range keeper.GetDepositParams(ctx).DepositDenoms {
+		amount := deposit.AmountOf(denom)
+		if amount <= 0 {
+			continue
+		}
+		price, ok := keeper.priceFeed.GetPrice(ctx, denom, bondDenom)
+		if !ok || !price.GT(sdk.ZeroDec()) {
+			continue
+		}
+		// amounts of coins and the raw value of a Dec share the same precision
+		converted := new(big.Int).Mul(big.NewInt(amount), big.NewInt(price.RawInt()))
+		weight.Add(weight, converted.Quo(converted, big.NewInt(sdk.OneDec().RawInt())))
+	}
+	return weight
+}
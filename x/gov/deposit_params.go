@@ -0,0 +1,55 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// The description of a DepositParamsChange proposal is the json encoded DepositParams to set
+func (keeper Keeper) getDepositParamsChange(proposal Proposal) (DepositParams, error) {
+	var dp DepositParams
+	if err := keeper.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &dp); err != nil {
+		return DepositParams{}, fmt.Errorf("unmarshal DepositParams failed: %v", err)
+	}
+	if err := dp.Check(); err != nil {
+		return DepositParams{}, err
+	}
+	if len(dp.DepositDenoms) > 0 && !sdk.IsUpgrade(sdk.GovDepositDenoms) {
+		return DepositParams{}, fmt.Errorf("deposit_denoms is not supported before %s", sdk.GovDepositDenoms)
+	}
+	if dp.ProposalRetention != 0 && !sdk.IsUpgrade(sdk.GovProposalPruning) {
+		return DepositParams{}, fmt.Errorf("proposal_retention is not supported before %s", sdk.GovProposalPruning)
+	}
+	return dp, nil
+}
+
+// executeDepositParamsChange sets the deposit params of a passed DepositParamsChange proposal
+func (keeper Keeper) executeDepositParamsChange(ctx sdk.Context, proposal Proposal) {
+	logger := ctx.Logger().With("module", "x/gov")
+	dp, err := keeper.getDepositParamsChange(proposal)
+	if err != nil {
+		logger.Error("The DepositParamsChange proposal is invalid, will skip.",
+			"proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	keeper.SetDepositParams(params.WithProposalID(ctx, proposal.GetProposalID()), dp)
+	proposal.SetStatus(StatusExecuted)
+	logger.Info("Changed deposit params", "proposalId", proposal.GetProposalID(), "params", dp)
+}
+
+// ---------------------    DepositParamsChangeHooks  -----------------
+type DepositParamsChangeHooks struct {
+	k Keeper
+}
+
+var _ GovHooks = DepositParamsChangeHooks{}
+
+func (hooks DepositParamsChangeHooks) OnProposalSubmitted(ctx sdk.Context, proposal Proposal) error {
+	if proposal.GetProposalType() != ProposalTypeDepositParamsChange {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+	_, err := hooks.k.getDepositParamsChange(proposal)
+	return err
+}
@@ -3,6 +3,7 @@ package gov
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
@@ -13,11 +14,81 @@ type Vote struct {
 	Voter      sdk.AccAddress `json:"voter"`       //  address of the voter
 	ProposalID int64          `json:"proposal_id"` //  proposalID of the proposal
 	Option     VoteOption     `json:"option"`      //  option from OptionSet chosen by the voter
+	// split of a weighted vote across options, Option is OptionEmpty if set
+	Options WeightedVoteOptions `json:"options,omitempty"`
 }
 
 // Returns whether 2 votes are equal
 func (voteA Vote) Equals(voteB Vote) bool {
-	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option
+	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option &&
+		voteA.Options.Equals(voteB.Options)
+}
+
+// WeightedOptions returns the split of the vote, a plain vote gives its whole weight to its option
+func (voteA Vote) WeightedOptions() WeightedVoteOptions {
+	if len(voteA.Options) > 0 {
+		return voteA.Options
+	}
+	if voteA.Option == OptionEmpty {
+		return nil
+	}
+	return WeightedVoteOptions{{Option: voteA.Option, Weight: sdk.OneDec()}}
+}
+
+// WeightedVoteOption is the share of a weighted vote given to an option
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option"`
+	Weight sdk.Dec    `json:"weight"`
+}
+
+// WeightedVoteOptions is the split of a weighted vote, the weights sum to one
+type WeightedVoteOptions []WeightedVoteOption
+
+// Returns whether 2 splits are equal
+func (options WeightedVoteOptions) Equals(other WeightedVoteOptions) bool {
+	if len(options) != len(other) {
+		return false
+	}
+	for i := range options {
+		if options[i].Option != other[i].Option || !options[i].Weight.Equal(other[i].Weight) {
+			return false
+		}
+	}
+	return true
+}
+
+// validate checks the options are distinct and valid, and their weights are positive and sum to one
+func (options WeightedVoteOptions) validate() error {
+	if len(options) == 0 {
+		return errors.New("no vote option")
+	}
+	seen := make(map[VoteOption]bool, len(options))
+	total := sdk.ZeroDec()
+	for _, option := range options {
+		if !validVoteOption(option.Option) {
+			return errors.Errorf("'%v' is not a valid voting option", option.Option)
+		}
+		if seen[option.Option] {
+			return errors.Errorf("duplicated voting option %s", option.Option)
+		}
+		seen[option.Option] = true
+		if !option.Weight.GT(sdk.ZeroDec()) || option.Weight.GT(sdk.OneDec()) {
+			return errors.Errorf("the weight of %s should be in range (0, 1]", option.Option)
+		}
+		total = total.Add(option.Weight)
+	}
+	if !total.Equal(sdk.OneDec()) {
+		return errors.Errorf("the weights sum to %s instead of 1", total)
+	}
+	return nil
+}
+
+func (options WeightedVoteOptions) String() string {
+	out := make([]string, 0, len(options))
+	for _, option := range options {
+		out = append(out, fmt.Sprintf("%s=%s", option.Option, option.Weight))
+	}
+	return strings.Join(out, ",")
 }
 
 // Returns whether a vote is empty
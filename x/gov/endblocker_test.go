@@ -12,6 +12,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -482,3 +483,159 @@ func TestTickPassedVotingPeriodAllAbstain(t *testing.T) {
 	validatorCoins := ck.GetCoins(ctx, addrs[0])
 	require.Equal(t, validatorCoins, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 5000e8)})
 }
+
+func TestSubmitProposalMinInitialDeposit(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	depositParams := keeper.GetDepositParams(ctx)
+	depositParams.MinInitialDepositRatio = sdk.NewDecWithPrec(25, 2)
+	keeper.SetDepositParams(ctx, depositParams)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 500e8)}, depositParams.MinInitialDeposit())
+
+	govHandler := gov.NewHandler(keeper)
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 499e8)}, 1000))
+	require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeInsufficientDeposit), res.Code)
+	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
+
+	res = govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 500e8)}, 1000))
+	require.True(t, res.IsOK())
+}
+
+func TestTickPassedDepositParamsChange(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	validator0 := stake.NewValidator(sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	stakeKeeper.SetValidator(ctx, validator0)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator0)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator0, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	depositParams := keeper.GetDepositParams(ctx)
+	votingPeriod := 1000 * time.Second
+
+	// the proposal type is rejected before the upgrade
+	msg := gov.NewMsgSubmitProposal("Test", string(mapp.Cdc.MustMarshalJSON(depositParams)),
+		gov.ProposalTypeDepositParamsChange, addrs[0], depositParams.MinDeposit, votingPeriod)
+	require.NotNil(t, msg.ValidateBasic())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovMinInitialDeposit, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovMinInitialDeposit)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// invalid params are rejected at submission
+	invalidParams := depositParams
+	invalidParams.MinInitialDepositRatio = sdk.NewDecWithPrec(2, 0)
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", string(mapp.Cdc.MustMarshalJSON(invalidParams)),
+		gov.ProposalTypeDepositParamsChange, addrs[0], depositParams.MinDeposit, votingPeriod))
+	require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeInvalidProposal), res.Code)
+
+	newParams := depositParams
+	newParams.MinInitialDepositRatio = sdk.NewDecWithPrec(1, 1)
+	res = govHandler(ctx, gov.NewMsgSubmitProposal("Test", string(mapp.Cdc.MustMarshalJSON(newParams)),
+		gov.ProposalTypeDepositParamsChange, addrs[0], depositParams.MinDeposit, votingPeriod))
+	require.True(t, res.IsOK())
+	proposalID, _ := strconv.Atoi(string(res.Data))
+
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalID), gov.OptionYes))
+	require.True(t, res.IsOK())
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+
+	require.Equal(t, gov.StatusExecuted, keeper.GetProposal(ctx, int64(proposalID)).GetStatus())
+	require.Equal(t, newParams.MinInitialDepositRatio, keeper.GetDepositParams(ctx).MinInitialDepositRatio)
+}
+
+type recordingArchiver struct {
+	archived []gov.ArchivedProposal
+}
+
+func (a *recordingArchiver) ArchiveProposal(ctx sdk.Context, chainID string, archived gov.ArchivedProposal) {
+	a.archived = append(a.archived, archived)
+}
+
+func TestPruneFinishedProposals(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	votingStart := time.Unix(1600000000, 0)
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Time: votingStart})
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovProposalPruning, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovProposalPruning)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	depositParams := keeper.GetDepositParams(ctx)
+	depositParams.ProposalRetention = time.Hour
+	require.Error(t, depositParams.Check())
+	depositParams.ProposalRetention = gov.MinProposalRetention
+	require.NoError(t, depositParams.Check())
+	keeper.SetDepositParams(ctx, depositParams)
+	archiver := &recordingArchiver{}
+	keeper.SetProposalArchiver(archiver)
+
+	votingPeriod := 1000 * time.Second
+	var proposals []gov.Proposal
+	for i := 0; i < 3; i++ {
+		// out of the active queue, so that the end blocker does not tally them
+		proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, votingPeriod)
+		proposal.SetVotingStartTime(votingStart)
+		proposal.SetStatus(gov.StatusVotingPeriod)
+		keeper.SetProposal(ctx, proposal)
+		proposals = append(proposals, proposal)
+	}
+	require.Nil(t, keeper.AddVote(ctx, proposals[0].GetProposalID(), addrs[0], gov.OptionNo))
+	proposals[0].SetStatus(gov.StatusRejected)
+	keeper.SetProposal(ctx, proposals[0])
+	proposals[2].SetStatus(gov.StatusPassed)
+	keeper.SetProposal(ctx, proposals[2])
+
+	endBlock := func(blockTime time.Time) sdk.Context {
+		ctx := ctx.WithBlockTime(blockTime).WithEventManager(sdk.NewEventManager())
+		gov.EndBlocker(ctx, keeper)
+		return ctx
+	}
+	prunable := votingStart.Add(votingPeriod).Add(gov.MinProposalRetention)
+
+	endBlock(prunable.Add(-time.Second))
+	require.NotNil(t, keeper.GetProposal(ctx, proposals[0].GetProposalID()))
+	require.Empty(t, archiver.archived)
+
+	// proposal 3 waits for proposal 2, still in its voting period
+	blockCtx := endBlock(prunable)
+	require.Nil(t, keeper.GetProposal(ctx, proposals[0].GetProposalID()))
+	_, found := keeper.GetVote(ctx, proposals[0].GetProposalID(), addrs[0])
+	require.False(t, found)
+	require.NotNil(t, keeper.GetProposal(ctx, proposals[2].GetProposalID()))
+	require.Len(t, archiver.archived, 1)
+	require.Equal(t, proposals[0].GetProposalID(), archiver.archived[0].Proposal.GetProposalID())
+	require.Equal(t, []gov.Vote{{Voter: addrs[0], ProposalID: proposals[0].GetProposalID(), Option: gov.OptionNo}}, archiver.archived[0].Votes)
+	pruned := 0
+	for _, event := range blockCtx.EventManager().Events() {
+		if event.Type == events.EventTypeProposalPruned {
+			pruned++
+		}
+	}
+	require.Equal(t, 1, pruned)
+
+	proposals[1].SetStatus(gov.StatusRejected)
+	keeper.SetProposal(ctx, proposals[1])
+	endBlock(prunable)
+	require.Nil(t, keeper.GetProposal(ctx, proposals[1].GetProposalID()))
+	require.Nil(t, keeper.GetProposal(ctx, proposals[2].GetProposalID()))
+	require.Len(t, archiver.archived, 3)
+}
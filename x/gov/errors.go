@@ -25,6 +25,8 @@ const (
 	CodeInvalidProposal         sdk.CodeType = 12
 	CodeInvalidVotingPeriod     sdk.CodeType = 13
 	CodeInvalidSideChainId      sdk.CodeType = 14
+	CodeInsufficientDeposit     sdk.CodeType = 15
+	CodeInvalidDepositDenom     sdk.CodeType = 16
 )
 
 //----------------------------------------
@@ -70,6 +72,10 @@ func ErrInvalidVote(codespace sdk.CodespaceType, voteOption VoteOption) sdk.Erro
 	return sdk.NewError(codespace, CodeInvalidVote, fmt.Sprintf("'%v' is not a valid voting option", voteOption))
 }
 
+func ErrInvalidWeightedVote(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidVote, fmt.Sprintf("Invalid weighted vote: %s", msg))
+}
+
 func ErrInvalidGenesis(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidVote, msg)
 }
@@ -78,6 +84,14 @@ func ErrInvalidVotingPeriod(codespace sdk.CodespaceType, votingPeriod time.Durat
 	return sdk.NewError(codespace, CodeInvalidVotingPeriod, fmt.Sprintf("Voting period '%d' should larger than 0 and less than %s", votingPeriod, MaxVotingPeriod))
 }
 
+func ErrInsufficientInitialDeposit(codespace sdk.CodespaceType, deposit, minDeposit sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeInsufficientDeposit, fmt.Sprintf("Initial deposit %s is less than the minimum initial deposit %s", deposit, minDeposit))
+}
+
+func ErrInvalidDepositDenom(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDepositDenom, fmt.Sprintf("Coins of denom %s can not be deposited", denom))
+}
+
 func ErrInvalidSideChainId(codespace sdk.CodespaceType, sideChain string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidSideChainId, fmt.Sprintf("Invalid side chain id: %s", sideChain))
 }
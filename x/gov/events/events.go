@@ -4,6 +4,7 @@ var (
 	EventTypeProposalDropped  = "proposal-dropped"
 	EventTypeProposalPassed   = "proposal-passed"
 	EventTypeProposalRejected = "proposal-rejected"
+	EventTypeProposalPruned   = "proposal-pruned"
 
 	ProposalID        = "proposal-id"
 	VotingPeriodStart = "voting-period-start"
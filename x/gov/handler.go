@@ -19,6 +19,8 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgSubmitProposal(ctx, keeper, msg)
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
+		case MsgVoteWeighted:
+			return handleMsgVoteWeighted(ctx, keeper, msg)
 		case MsgSideChainDeposit:
 			return handleMsgSideChainDeposit(ctx, keeper, msg)
 		case MsgSideChainSubmitProposal:
@@ -33,6 +35,10 @@ func NewHandler(keeper Keeper) sdk.Handler {
 }
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
+	minInitialDeposit := keeper.GetDepositParams(ctx).MinInitialDeposit()
+	if !keeper.IsDepositEnough(ctx, msg.InitialDeposit, minInitialDeposit) {
+		return ErrInsufficientInitialDeposit(keeper.codespace, msg.InitialDeposit, minInitialDeposit).Result()
+	}
 
 	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.VotingPeriod)
 
@@ -118,6 +124,38 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 	}
 }
 
+func handleMsgVoteWeighted(ctx sdk.Context, keeper Keeper, msg MsgVoteWeighted) sdk.Result {
+	if !sdk.IsUpgrade(sdk.GovWeightedVotes) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("weighted votes are not supported before %s", sdk.GovWeightedVotes)).Result()
+	}
+
+	validator := keeper.vs.Validator(ctx, sdk.ValAddress(msg.Voter))
+
+	if validator == nil {
+		return sdk.ErrUnauthorized("Vote is not from a validator operator").Result()
+	}
+
+	if validator.GetPower().IsZero() {
+		return sdk.ErrUnauthorized("Validator is not bonded").Result()
+	}
+
+	err := keeper.AddWeightedVote(ctx, msg.ProposalID, msg.Voter, msg.Options)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(msg.ProposalID)
+
+	resTags := sdk.NewTags(
+		tags.Action, tags.ActionVoteWeighted,
+		tags.Voter, []byte(msg.Voter.String()),
+		tags.ProposalID, proposalIDBytes,
+	)
+	return sdk.Result{
+		Tags: resTags,
+	}
+}
+
 type SimpleProposal struct {
 	Id      int64
 	ChainID string
@@ -193,11 +231,15 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 			continue
 		}
 
-		passes, refundDeposits, tallyResults := Tally(ctx, keeper, activeProposal)
+		passes, refundDeposits, tallyResults, breakdown := TallyWithBreakdown(ctx, keeper, activeProposal)
 		var action string
 		if passes {
 			activeProposal.SetStatus(StatusPassed)
 			action = events.EventTypeProposalPassed
+			// the deposit params of the side chains are changed by their own param change proposals
+			if activeProposal.GetProposalType() == ProposalTypeDepositParamsChange && chainId == NativeChainID {
+				keeper.executeDepositParamsChange(ctx, activeProposal)
+			}
 
 			// refund deposits
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
@@ -218,6 +260,9 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 
 		activeProposal.SetTallyResult(tallyResults)
 		keeper.SetProposal(ctx, activeProposal)
+		if sdk.IsUpgrade(sdk.TallyBreakdown) {
+			keeper.setTallyBreakdown(ctx, activeProposal.GetProposalID(), breakdown)
+		}
 
 		logger.Info(fmt.Sprintf("proposal %d (%s) tallied; passed: %v",
 			activeProposal.GetProposalID(), activeProposal.GetTitle(), passes))
@@ -229,6 +274,9 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 		resEvents = resEvents.AppendEvent(event)
 	}
 
+	if sdk.IsUpgrade(sdk.GovProposalPruning) {
+		resEvents = resEvents.AppendEvents(keeper.PruneProposals(ctx, chainId))
+	}
 	return
 }
 
@@ -24,8 +24,19 @@ var (
 
 	// Will hold deposit of both BC chain and side chain.
 	DepositedCoinsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainDepositedCoins")))
+
+	// DepositedCoinsModuleAddr replaces DepositedCoinsAccAddr since ModuleAccountUpgrade
+	DepositedCoinsModuleAddr = sdk.ModuleAddress(DefaultParamSpace, "deposit")
 )
 
+// GetDepositedCoinsAccAddr returns the account holding the deposits of proposals
+func GetDepositedCoinsAccAddr() sdk.AccAddress {
+	if sdk.IsUpgrade(sdk.ModuleAccountUpgrade) {
+		return DepositedCoinsModuleAddr
+	}
+	return DepositedCoinsAccAddr
+}
+
 // Type declaration for parameters
 func ParamTypeTable() params.TypeTable {
 	return params.NewTypeTable(
@@ -73,6 +84,12 @@ type Keeper struct {
 
 	// if you want to enable side chains, you need call `SetupForSideChain`
 	ScKeeper SideChainKeeper
+
+	// prices of the deposit denoms, the deposit params unless set with `SetPriceFeed`
+	priceFeed PriceFeed
+
+	// receives the pruned proposals, the node log unless set with `SetProposalArchiver`
+	archiver ProposalArchiver
 }
 
 // NewKeeper returns a governance keeper. It handles:
@@ -81,7 +98,7 @@ type Keeper struct {
 // - users voting on proposals, with weight proportional to stake in the system
 // - and tallying the result of the vote.
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramsKeeper params.Keeper, paramSpace params.Subspace, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType, pool *sdk.Pool) Keeper {
-	return Keeper{
+	keeper := Keeper{
 		storeKey:     key,
 		paramsKeeper: paramsKeeper,
 		paramSpace:   paramSpace.WithTypeTable(ParamTypeTable()),
@@ -93,6 +110,9 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramsKeeper params.Keeper, p
 		codespace:    codespace,
 		pool:         pool,
 	}
+	keeper.priceFeed = depositParamsPriceFeed{keeper.paramSpace}
+	keeper.archiver = logProposalArchiver{cdc}
+	return keeper.AddHooks(ProposalTypeDepositParamsChange, DepositParamsChangeHooks{keeper})
 }
 
 func (keeper *Keeper) SetupForSideChain(scKeeper SideChainKeeper) {
@@ -160,6 +180,7 @@ func (keeper Keeper) SetProposal(ctx sdk.Context, proposal Proposal) {
 func (keeper Keeper) DeleteProposal(ctx sdk.Context, proposal Proposal) {
 	store := ctx.KVStore(keeper.storeKey)
 	store.Delete(KeyProposal(proposal.GetProposalID()))
+	store.Delete(KeyTallyBreakdown(proposal.GetProposalID()))
 }
 
 func (keeper Keeper) Iterate(ctx sdk.Context, voterAddr sdk.AccAddress, depositerAddr sdk.AccAddress, status ProposalStatus, numLatest int64, reverse bool, iter func(Proposal) bool) {
@@ -341,6 +362,35 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ac
 	return nil
 }
 
+// Adds a vote split across several options on a specific proposal, a split given entirely to
+// one option is stored as a plain vote
+func (keeper Keeper) AddWeightedVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress, options WeightedVoteOptions) sdk.Error {
+	if err := options.validate(); err != nil {
+		return ErrInvalidWeightedVote(keeper.codespace, err.Error())
+	}
+	if len(options) == 1 {
+		return keeper.AddVote(ctx, proposalID, voterAddr, options[0].Option)
+	}
+
+	proposal := keeper.GetProposal(ctx, proposalID)
+	if proposal == nil {
+		return ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if proposal.GetStatus() != StatusVotingPeriod {
+		return ErrInactiveProposal(keeper.codespace, proposalID)
+	}
+
+	vote := Vote{
+		ProposalID: proposalID,
+		Voter:      voterAddr,
+		Option:     OptionEmpty,
+		Options:    options,
+	}
+	keeper.setVote(ctx, proposalID, voterAddr, vote)
+
+	return nil
+}
+
 // Gets the vote of a specific voter on a specific proposal
 func (keeper Keeper) GetVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress) (Vote, bool) {
 	store := ctx.KVStore(keeper.storeKey)
@@ -370,6 +420,27 @@ func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk
 	store.Delete(KeyVote(proposalID, voterAddr))
 }
 
+// =====================================================
+// Tally breakdown
+
+// Gets the per-validator tally breakdown stored when the proposal was finalized
+func (keeper Keeper) GetTallyBreakdown(ctx sdk.Context, proposalID int64) (TallyBreakdown, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyTallyBreakdown(proposalID))
+	if bz == nil {
+		return nil, false
+	}
+	var breakdown TallyBreakdown
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &breakdown)
+	return breakdown, true
+}
+
+func (keeper Keeper) setTallyBreakdown(ctx sdk.Context, proposalID int64, breakdown TallyBreakdown) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(breakdown)
+	store.Set(KeyTallyBreakdown(proposalID), bz)
+}
+
 // =====================================================
 // Deposits
 
@@ -405,14 +476,23 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 		return ErrAlreadyFinishedProposal(keeper.codespace, proposalID), false
 	}
 
-	// Send coins from depositor's account to DepositedCoinsAccAddr account
-	_, err := keeper.ck.SendCoins(ctx, depositerAddr, DepositedCoinsAccAddr, depositAmount)
+	if sdk.IsUpgrade(sdk.GovDepositDenoms) {
+		depositParams := keeper.GetDepositParams(ctx)
+		for _, coin := range depositAmount {
+			if !depositParams.IsDepositDenom(coin.Denom) {
+				return ErrInvalidDepositDenom(keeper.codespace, coin.Denom), false
+			}
+		}
+	}
+
+	// Send coins from depositor's account to the deposited coins account
+	_, err := keeper.ck.SendCoins(ctx, depositerAddr, GetDepositedCoinsAccAddr(), depositAmount)
 	if err != nil {
 		return err, false
 	}
 
 	if ctx.IsDeliverTx() {
-		keeper.pool.AddAddrs([]sdk.AccAddress{depositerAddr, DepositedCoinsAccAddr})
+		keeper.pool.AddAddrs([]sdk.AccAddress{depositerAddr, GetDepositedCoinsAccAddr()})
 	}
 
 	// Update Proposal
@@ -422,7 +502,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 	// Check if deposit tipped proposal into voting period
 	// Active voting period if so
 	activatedVotingPeriod := false
-	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositParams(ctx).MinDeposit) {
+	if proposal.GetStatus() == StatusDepositPeriod && keeper.IsDepositEnough(ctx, proposal.GetTotalDeposit(), keeper.GetDepositParams(ctx).MinDeposit) {
 		keeper.ActivateVotingPeriod(ctx, proposal)
 		activatedVotingPeriod = true
 	}
@@ -455,12 +535,12 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 		deposit := &Deposit{}
 		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), deposit)
 
-		_, err := keeper.ck.SendCoins(ctx, DepositedCoinsAccAddr, deposit.Depositer, deposit.Amount)
+		_, err := keeper.ck.SendCoins(ctx, GetDepositedCoinsAccAddr(), deposit.Depositer, deposit.Amount)
 		if err != nil {
 			panic(fmt.Sprintf("refund error(%s) should not happen", err.Error()))
 		}
 
-		keeper.pool.AddAddrs([]sdk.AccAddress{deposit.Depositer, DepositedCoinsAccAddr})
+		keeper.pool.AddAddrs([]sdk.AccAddress{deposit.Depositer, GetDepositedCoinsAccAddr()})
 		store.Delete(depositsIterator.Key())
 	}
 }
@@ -488,11 +568,11 @@ func (keeper Keeper) DistributeDeposits(ctx sdk.Context, proposalID int64) {
 		ctx.Logger().Info("distribute empty deposits")
 	}
 
-	_, err := keeper.ck.SendCoins(ctx, DepositedCoinsAccAddr, proposerAccAddr, depositCoins)
+	_, err := keeper.ck.SendCoins(ctx, GetDepositedCoinsAccAddr(), proposerAccAddr, depositCoins)
 	if err != nil {
 		panic(fmt.Sprintf("distribute deposits error(%s) should not happen", err.Error()))
 	}
-	keeper.pool.AddAddrs([]sdk.AccAddress{sdk.AccAddress(proposerAccAddr), DepositedCoinsAccAddr})
+	keeper.pool.AddAddrs([]sdk.AccAddress{sdk.AccAddress(proposerAccAddr), GetDepositedCoinsAccAddr()})
 }
 
 // =====================================================
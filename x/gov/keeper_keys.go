@@ -13,6 +13,7 @@ var (
 	KeyNextProposalID        = []byte("newProposalID")
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+	KeyNextPrunedProposalID  = []byte("nextPrunedProposalID")
 )
 
 // Key for getting a specific proposal from the store
@@ -39,3 +40,8 @@ func KeyDepositsSubspace(proposalID int64) []byte {
 func KeyVotesSubspace(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("votes:%d:", proposalID))
 }
+
+// Key for getting the per-validator tally breakdown of a finalized proposal
+func KeyTallyBreakdown(proposalID int64) []byte {
+	return []byte(fmt.Sprintf("tallybreakdown:%d", proposalID))
+}
@@ -215,6 +215,105 @@ func TestDeposits(t *testing.T) {
 	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
 }
 
+func TestMigrateDepositedCoins(t *testing.T) {
+	mapp, ck, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ModuleAccountUpgrade, 10)
+	sdk.UpgradeMgr.SetHeight(9)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ModuleAccountUpgrade)
+		delete(sdk.UpgradeMgr.Config.BeginBlockers, 10)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	gov.RegisterUpgradeBeginBlocker(keeper)
+
+	fiveHundredSteak := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 500e8)}
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	err, _ := keeper.AddDeposit(ctx, proposalID, addrs[0], fiveHundredSteak)
+	require.Nil(t, err)
+	require.Equal(t, fiveHundredSteak, ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+
+	sdk.UpgradeMgr.SetHeight(10)
+	sdk.UpgradeMgr.BeginBlocker(ctx)
+	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+	require.Equal(t, fiveHundredSteak, ck.GetCoins(ctx, gov.DepositedCoinsModuleAddr))
+
+	err, _ = keeper.AddDeposit(ctx, proposalID, addrs[1], fiveHundredSteak)
+	require.Nil(t, err)
+	require.Equal(t, fiveHundredSteak.Plus(fiveHundredSteak), ck.GetCoins(ctx, gov.DepositedCoinsModuleAddr))
+
+	keeper.RefundDeposits(ctx, proposalID)
+	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsModuleAddr))
+}
+
+type fixedPriceFeed map[string]sdk.Dec
+
+func (f fixedPriceFeed) GetPrice(ctx sdk.Context, denom, quoteDenom string) (sdk.Dec, bool) {
+	price, ok := f[denom]
+	return price, ok
+}
+
+func TestDepositDenoms(t *testing.T) {
+	mapp, ck, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovDepositDenoms, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovDepositDenoms)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	depositParams := keeper.GetDepositParams(ctx)
+	depositParams.DepositDenoms = []string{"xyz", "nop"}
+	depositParams.DepositPrices = []gov.DepositPrice{{Denom: "abc", Price: sdk.NewDecWithoutFra(2)}}
+	require.Error(t, depositParams.Check())
+	depositParams.DepositPrices = []gov.DepositPrice{{Denom: "xyz", Price: sdk.NewDecWithoutFra(2)}}
+	require.NoError(t, depositParams.Check())
+	keeper.SetDepositParams(ctx, depositParams)
+
+	xyz := sdk.Coins{sdk.NewCoin("xyz", 500e8)}
+	nop := sdk.Coins{sdk.NewCoin("nop", 500e8)}
+	abc := sdk.Coins{sdk.NewCoin("abc", 500e8)}
+	_, _, err := ck.AddCoins(ctx, addrs[0], xyz.Plus(nop).Plus(abc))
+	require.Nil(t, err)
+	addr0Initial := ck.GetCoins(ctx, addrs[0])
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+
+	// denoms out of the whitelist are rejected
+	err, _ = keeper.AddDeposit(ctx, proposalID, addrs[0], abc)
+	require.NotNil(t, err)
+	require.Equal(t, gov.CodeInvalidDepositDenom, err.Code())
+
+	// 500 xyz are worth 1000 steak, nop has no price and weighs nothing
+	err, votingStarted := keeper.AddDeposit(ctx, proposalID, addrs[0], xyz.Plus(nop))
+	require.Nil(t, err)
+	require.False(t, votingStarted)
+	require.EqualValues(t, 1000e8, keeper.DepositWeight(ctx, keeper.GetProposal(ctx, proposalID).GetTotalDeposit(), gov.DefaultDepositDenom).Int64())
+
+	err, votingStarted = keeper.AddDeposit(ctx, proposalID, addrs[1], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)})
+	require.Nil(t, err)
+	require.True(t, votingStarted)
+
+	keeper.RefundDeposits(ctx, proposalID)
+	require.Equal(t, addr0Initial, ck.GetCoins(ctx, addrs[0]))
+	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+
+	// a price feed replaces the prices of the params
+	keeper.SetPriceFeed(fixedPriceFeed{"nop": sdk.NewDecWithoutFra(1)})
+	require.EqualValues(t, 500e8, keeper.DepositWeight(ctx, xyz.Plus(nop), gov.DefaultDepositDenom).Int64())
+
+	// min_deposit must have a single denom to convert into
+	depositParams.MinDeposit = depositParams.MinDeposit.Plus(abc)
+	require.Error(t, depositParams.Check())
+}
+
 func TestVotes(t *testing.T) {
 	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 2)
 	SortAddresses(addrs)
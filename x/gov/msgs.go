@@ -16,7 +16,7 @@ const (
 	MaxVotingPeriod          = 2 * 7 * 24 * 60 * 60 * time.Second // 2 weeks
 )
 
-var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
+var _, _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}, MsgVoteWeighted{}
 
 //-----------------------------------------------------------
 type ListTradingPairParams struct {
@@ -246,3 +246,65 @@ func (msg MsgVote) GetSigners() []sdk.AccAddress {
 func (msg MsgVote) GetInvolvedAddresses() []sdk.AccAddress {
 	return msg.GetSigners()
 }
+
+//-----------------------------------------------------------
+// MsgVoteWeighted
+type MsgVoteWeighted struct {
+	ProposalID int64               `json:"proposal_id"` // ID of the proposal
+	Voter      sdk.AccAddress      `json:"voter"`       //  address of the voter
+	Options    WeightedVoteOptions `json:"options"`     //  split of the vote across the options, the weights sum to one
+}
+
+func NewMsgVoteWeighted(voter sdk.AccAddress, proposalID int64, options WeightedVoteOptions) MsgVoteWeighted {
+	return MsgVoteWeighted{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Options:    options,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgVoteWeighted) Route() string { return MsgRoute }
+func (msg MsgVoteWeighted) Type() string  { return "vote_weighted" }
+
+// Implements Msg.
+func (msg MsgVoteWeighted) ValidateBasic() sdk.Error {
+	if len(msg.Voter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("length of address(%s) should be %d", string(msg.Voter), sdk.AddrLen))
+	}
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	if err := msg.Options.validate(); err != nil {
+		return ErrInvalidWeightedVote(DefaultCodespace, err.Error())
+	}
+	return nil
+}
+
+func (msg MsgVoteWeighted) String() string {
+	return fmt.Sprintf("MsgVoteWeighted{%v - %s}", msg.ProposalID, msg.Options)
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) Get(key interface{}) (value interface{}) {
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+func (msg MsgVoteWeighted) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
@@ -36,7 +36,7 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "", gov.ProposalTypeText, addrs[0], coinsPos, 1000 * time.Second, false},
 		{"Test Proposal", "the purpose of this proposal is to test", gov.ProposalTypeParameterChange, addrs[0], coinsPos, 1000 * time.Second, true},
 		{"Test Proposal", "the purpose of this proposal is to test", gov.ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, 1000 * time.Second, true},
-		{"Test Proposal", "the purpose of this proposal is to test", 0x10, addrs[0], coinsPos, 1, false},
+		{"Test Proposal", "the purpose of this proposal is to test", 0x20, addrs[0], coinsPos, 1, false},
 		{"Test Proposal", "the purpose of this proposal is to test", gov.ProposalTypeText, sdk.AccAddress{}, coinsPos, 1000 * time.Second, false},
 		{"Test Proposal", "the purpose of this proposal is to test", gov.ProposalTypeText, addrs[0], coinsZero, 1000 * time.Second, true},
 		{"Test Proposal", "the purpose of this proposal is to test", gov.ProposalTypeText, addrs[0], coinsNeg, 1000 * time.Second, false},
@@ -58,6 +58,34 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+func TestMsgSubmitProposalBeforeUpgrade(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		proposalType gov.ProposalKind
+		upgrade      string
+	}{
+		{gov.ProposalTypeUntombstoneValidator, sdk.ValidatorTombstone},
+		{gov.ProposalTypeManageChanSenders, sdk.ChannelSenders},
+		{gov.ProposalTypeWithdrawAddrBans, sdk.WithdrawAddrBans},
+		{gov.ProposalTypeDistrParamsChange, sdk.DistrParamsChange},
+		{gov.ProposalTypeSkipSequence, sdk.OracleSkipSequence},
+		{gov.ProposalTypeRegisterDestChain, sdk.CrossChainRegistry},
+		{gov.ProposalTypeRegisterChannel, sdk.CrossChainRegistry},
+	}
+
+	for _, tc := range tests {
+		msg := gov.NewMsgSubmitProposal("Test Proposal", "the purpose of this proposal is to test",
+			tc.proposalType, addrs[0], coinsPos, 1000*time.Second)
+		require.NotNil(t, msg.ValidateBasic(), "proposal type %s", tc.proposalType)
+
+		sdk.UpgradeMgr.AddUpgradeHeight(tc.upgrade, 1)
+		sdk.UpgradeMgr.SetHeight(1)
+		require.Nil(t, msg.ValidateBasic(), "proposal type %s", tc.proposalType)
+		delete(sdk.UpgradeMgr.Config.HeightMap, tc.upgrade)
+		sdk.UpgradeMgr.SetHeight(0)
+	}
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
@@ -115,6 +143,38 @@ func TestMsgVote(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for MsgVoteWeighted
+func TestMsgVoteWeighted(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	half := sdk.NewDecWithPrec(5, 1)
+	tests := []struct {
+		proposalID int64
+		voterAddr  sdk.AccAddress
+		options    gov.WeightedVoteOptions
+		expectPass bool
+	}{
+		{0, addrs[0], gov.WeightedVoteOptions{{gov.OptionYes, sdk.OneDec()}}, true},
+		{0, addrs[0], gov.WeightedVoteOptions{{gov.OptionYes, half}, {gov.OptionAbstain, half}}, true},
+		{-1, addrs[0], gov.WeightedVoteOptions{{gov.OptionYes, sdk.OneDec()}}, false},
+		{0, sdk.AccAddress{}, gov.WeightedVoteOptions{{gov.OptionYes, sdk.OneDec()}}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{{gov.OptionYes, half}}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{{gov.OptionYes, half}, {gov.OptionYes, half}}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{{gov.OptionYes, sdk.OneDec().Add(half)}, {gov.OptionNo, half.Neg()}}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{{gov.OptionYes, sdk.OneDec()}, {gov.OptionNo, sdk.ZeroDec()}}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{{gov.VoteOption(0x13), sdk.OneDec()}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := gov.NewMsgVoteWeighted(tc.voterAddr, tc.proposalID, tc.options)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
 func TestMsgSideChainSubmitProposal(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
 	tests := []struct {
@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -8,8 +9,81 @@ import (
 
 // Param around Deposits for governance
 type DepositParams struct {
-	MinDeposit       sdk.Coins     `json:"min_deposit"`        //  Minimum deposit for a proposal to enter voting period.
-	MaxDepositPeriod time.Duration `json:"max_deposit_period"` //  Maximum period for Atom holders to deposit on a proposal. Initial value: 2 months
+	MinDeposit             sdk.Coins      `json:"min_deposit"`                  //  Minimum deposit for a proposal to enter voting period.
+	MaxDepositPeriod       time.Duration  `json:"max_deposit_period"`           //  Maximum period for Atom holders to deposit on a proposal. Initial value: 2 months
+	MinInitialDepositRatio sdk.Dec        `json:"min_initial_deposit_ratio"`    //  Minimum part of MinDeposit the proposer deposits at submission. Initial value: 0
+	DepositDenoms          []string       `json:"deposit_denoms,omitempty"`     //  Denoms besides the one of MinDeposit accepted as deposit, weighted by their price. Initial value: none
+	DepositPrices          []DepositPrice `json:"deposit_prices,omitempty"`     //  Prices of the DepositDenoms in the denom of MinDeposit, unless the keeper has another price feed. Initial value: none
+	ProposalRetention      time.Duration  `json:"proposal_retention,omitempty"` //  Period finished proposals are kept after their voting period, 0 keeps them forever. Initial value: 0
+}
+
+// Check returns an error if the deposit params are invalid
+func (dp DepositParams) Check() error {
+	if !dp.MinDeposit.IsValid() || !dp.MinDeposit.IsPositive() {
+		return fmt.Errorf("min_deposit %s should be valid and positive", dp.MinDeposit)
+	}
+	if dp.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("max_deposit_period should be positive")
+	}
+	if dp.MinInitialDepositRatio.LT(sdk.ZeroDec()) || dp.MinInitialDepositRatio.GT(sdk.OneDec()) {
+		return fmt.Errorf("min_initial_deposit_ratio should be in range 0 to 1")
+	}
+	if len(dp.DepositDenoms) > 0 && len(dp.MinDeposit) != 1 {
+		return fmt.Errorf("min_deposit should have a single denom to convert deposit_denoms into")
+	}
+	seen := make(map[string]bool, len(dp.DepositDenoms))
+	for _, denom := range dp.DepositDenoms {
+		if denom == "" || seen[denom] || dp.MinDeposit.AmountOf(denom) != 0 {
+			return fmt.Errorf("deposit denom %q should be non empty, unique and not the denom of min_deposit", denom)
+		}
+		seen[denom] = true
+	}
+	priced := make(map[string]bool, len(dp.DepositPrices))
+	for _, price := range dp.DepositPrices {
+		if !seen[price.Denom] || priced[price.Denom] || !price.Price.GT(sdk.ZeroDec()) {
+			return fmt.Errorf("deposit price of %q should be unique, positive and of a deposit denom", price.Denom)
+		}
+		priced[price.Denom] = true
+	}
+	if dp.ProposalRetention != 0 && dp.ProposalRetention < MinProposalRetention {
+		return fmt.Errorf("proposal_retention should be 0 or at least %s", MinProposalRetention)
+	}
+	return nil
+}
+
+// IsDepositDenom returns true if coins of denom can be deposited on proposals
+func (dp DepositParams) IsDepositDenom(denom string) bool {
+	if dp.MinDeposit.AmountOf(denom) != 0 {
+		return true
+	}
+	for _, d := range dp.DepositDenoms {
+		if d == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// MinInitialDeposit returns the deposit required at submission
+func (dp DepositParams) MinInitialDeposit() sdk.Coins {
+	if dp.MinInitialDepositRatio.IsZero() {
+		return sdk.Coins{}
+	}
+	minInitialDeposit := sdk.Coins{}
+	for _, coin := range dp.MinDeposit {
+		// amounts of coins and the raw value of a Dec share the same precision
+		amount := sdk.NewDecWithPrec(coin.Amount, sdk.Precision).Mul(dp.MinInitialDepositRatio).RawInt()
+		if amount > 0 {
+			minInitialDeposit = append(minInitialDeposit, sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+	return minInitialDeposit
+}
+
+// DepositPrice is the price of a deposit denom in the denom of MinDeposit
+type DepositPrice struct {
+	Denom string  `json:"denom"`
+	Price sdk.Dec `json:"price"`
 }
 
 // Param around Tally votes in governance
@@ -0,0 +1,27 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func RegisterUpgradeBeginBlocker(keeper Keeper) {
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ModuleAccountUpgrade, func(ctx sdk.Context) {
+		keeper.migrateDepositedCoins(ctx)
+	})
+}
+
+// migrateDepositedCoins moves the deposits held by the legacy account to DepositedCoinsModuleAddr
+func (keeper Keeper) migrateDepositedCoins(ctx sdk.Context) {
+	coins := keeper.ck.GetCoins(ctx, DepositedCoinsAccAddr)
+	if coins.IsZero() {
+		return
+	}
+	if _, err := keeper.ck.SendCoins(ctx, DepositedCoinsAccAddr, DepositedCoinsModuleAddr, coins); err != nil {
+		panic(fmt.Sprintf("migrate deposited coins error(%s) should not happen", err.Error()))
+	}
+	if keeper.pool != nil {
+		keeper.pool.AddAddrs([]sdk.AccAddress{DepositedCoinsAccAddr, DepositedCoinsModuleAddr})
+	}
+}
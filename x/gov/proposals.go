@@ -131,6 +131,15 @@ const (
 	ProposalTypeRemoveValidator      ProposalKind = 0x07
 	ProposalTypeDelistTradingPair    ProposalKind = 0x08
 	ProposalTypeManageChanPermission ProposalKind = 0x09
+	ProposalTypeUntombstoneValidator ProposalKind = 0x0A
+	ProposalTypeDepositParamsChange  ProposalKind = 0x0B
+	ProposalTypeRelayerAllowList     ProposalKind = 0x0C
+	ProposalTypeManageChanSenders    ProposalKind = 0x0D
+	ProposalTypeWithdrawAddrBans     ProposalKind = 0x0E
+	ProposalTypeSkipSequence         ProposalKind = 0x0F
+	ProposalTypeRegisterDestChain    ProposalKind = 0x10
+	ProposalTypeRegisterChannel      ProposalKind = 0x11
+	ProposalTypeDistrParamsChange    ProposalKind = 0x12
 )
 
 // String to proposalType byte.  Returns ff if invalid.
@@ -158,13 +167,48 @@ func ProposalTypeFromString(str string) (ProposalKind, error) {
 		return ProposalTypeCSCParamsChange, nil
 	case "ManageChanPermission":
 		return ProposalTypeManageChanPermission, nil
+	case "UntombstoneValidator":
+		return ProposalTypeUntombstoneValidator, nil
+	case "DepositParamsChange":
+		return ProposalTypeDepositParamsChange, nil
+	case "RelayerAllowList":
+		return ProposalTypeRelayerAllowList, nil
+	case "ManageChanSenders":
+		return ProposalTypeManageChanSenders, nil
+	case "WithdrawAddrBans":
+		return ProposalTypeWithdrawAddrBans, nil
+	case "SkipSequence":
+		return ProposalTypeSkipSequence, nil
+	case "RegisterDestChain":
+		return ProposalTypeRegisterDestChain, nil
+	case "RegisterChannel":
+		return ProposalTypeRegisterChannel, nil
+	case "DistrParamsChange":
+		return ProposalTypeDistrParamsChange, nil
 	default:
 		return ProposalKind(0xff), errors.Errorf("'%s' is not a valid proposal type", str)
 	}
 }
 
+// proposalTypeUpgrades maps the proposal types to the upgrades which introduced them,
+// the proposals of these types are rejected before their upgrades.
+var proposalTypeUpgrades = map[ProposalKind]string{
+	ProposalTypeUntombstoneValidator: sdk.ValidatorTombstone,
+	ProposalTypeDepositParamsChange:  sdk.GovMinInitialDeposit,
+	ProposalTypeRelayerAllowList:     sdk.RelayerAllowList,
+	ProposalTypeManageChanSenders:    sdk.ChannelSenders,
+	ProposalTypeWithdrawAddrBans:     sdk.WithdrawAddrBans,
+	ProposalTypeDistrParamsChange:    sdk.DistrParamsChange,
+	ProposalTypeSkipSequence:         sdk.OracleSkipSequence,
+	ProposalTypeRegisterDestChain:    sdk.CrossChainRegistry,
+	ProposalTypeRegisterChannel:      sdk.CrossChainRegistry,
+}
+
 // is defined ProposalType?
 func validProposalType(pt ProposalKind) bool {
+	if upgrade, ok := proposalTypeUpgrades[pt]; ok && !sdk.IsUpgrade(upgrade) {
+		return false
+	}
 	if pt == ProposalTypeText ||
 		pt == ProposalTypeParameterChange ||
 		pt == ProposalTypeSoftwareUpgrade ||
@@ -173,7 +217,16 @@ func validProposalType(pt ProposalKind) bool {
 		pt == ProposalTypeCreateValidator ||
 		pt == ProposalTypeRemoveValidator ||
 		pt == ProposalTypeDelistTradingPair ||
-		pt == ProposalTypeManageChanPermission {
+		pt == ProposalTypeManageChanPermission ||
+		pt == ProposalTypeUntombstoneValidator ||
+		pt == ProposalTypeDepositParamsChange ||
+		pt == ProposalTypeRelayerAllowList ||
+		pt == ProposalTypeManageChanSenders ||
+		pt == ProposalTypeWithdrawAddrBans ||
+		pt == ProposalTypeSkipSequence ||
+		pt == ProposalTypeRegisterDestChain ||
+		pt == ProposalTypeRegisterChannel ||
+		pt == ProposalTypeDistrParamsChange {
 		return true
 	}
 	return false
@@ -236,6 +289,24 @@ func (pt ProposalKind) String() string {
 		return "CSCParamsChange"
 	case ProposalTypeManageChanPermission:
 		return "ManageChanPermission"
+	case ProposalTypeUntombstoneValidator:
+		return "UntombstoneValidator"
+	case ProposalTypeDepositParamsChange:
+		return "DepositParamsChange"
+	case ProposalTypeRelayerAllowList:
+		return "RelayerAllowList"
+	case ProposalTypeManageChanSenders:
+		return "ManageChanSenders"
+	case ProposalTypeWithdrawAddrBans:
+		return "WithdrawAddrBans"
+	case ProposalTypeSkipSequence:
+		return "SkipSequence"
+	case ProposalTypeRegisterDestChain:
+		return "RegisterDestChain"
+	case ProposalTypeRegisterChannel:
+		return "RegisterChannel"
+	case ProposalTypeDistrParamsChange:
+		return "DistrParamsChange"
 	default:
 		return ""
 	}
@@ -0,0 +1,139 @@
+package gov
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
+)
+
+const (
+	// MinProposalRetention keeps the passed proposals until the side chain and ibc modules,
+	// which look back SafeToleratePeriod + MaxVotingPeriod from the voting start, are done with them
+	MinProposalRetention = 4 * 7 * 24 * 60 * 60 * time.Second // 4 weeks
+
+	// maxPrunedProposalsPerBlock bounds the proposals the end blocker looks at, pruned or
+	// dropped ones, once the pruning is enabled on a chain with a long history
+	maxPrunedProposalsPerBlock = 100
+)
+
+// ArchivedProposal is a pruned proposal with what was stored along with it. The deposits are
+// refunded or distributed when the voting period ends, so none is left.
+type ArchivedProposal struct {
+	Proposal       Proposal       `json:"proposal"`
+	Votes          []Vote         `json:"votes"`
+	TallyBreakdown TallyBreakdown `json:"tally_breakdown"`
+}
+
+// ProposalArchiver receives the proposals right before they are pruned, e.g. to export them
+// to an indexer, the proposals are gone from the store once the block is committed.
+type ProposalArchiver interface {
+	ArchiveProposal(ctx sdk.Context, chainID string, archived ArchivedProposal)
+}
+
+// SetProposalArchiver sets the archiver of the pruned proposals, in place of the node log.
+// The node app registers its own archiver here to export them, e.g. to its publisher.
+func (keeper *Keeper) SetProposalArchiver(archiver ProposalArchiver) {
+	keeper.archiver = archiver
+}
+
+// logProposalArchiver writes the pruned proposals to the node log, so they can still be found
+// when the app does not set an archiver
+type logProposalArchiver struct {
+	cdc *codec.Codec
+}
+
+func (a logProposalArchiver) ArchiveProposal(ctx sdk.Context, chainID string, archived ArchivedProposal) {
+	logger := ctx.Logger().With("module", "x/gov")
+	bz, err := a.cdc.MarshalJSON(archived)
+	if err != nil {
+		logger.Error("failed to archive pruned proposal", "proposalId", archived.Proposal.GetProposalID(), "err", err)
+		return
+	}
+	logger.Info("archived pruned proposal", "chainId", chainID, "proposal", string(bz))
+}
+
+func (keeper Keeper) getNextPrunedProposalID(ctx sdk.Context) int64 {
+	bz := ctx.KVStore(keeper.storeKey).Get(KeyNextPrunedProposalID)
+	if bz == nil {
+		return 1
+	}
+	var proposalID int64
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &proposalID)
+	return proposalID
+}
+
+func (keeper Keeper) setNextPrunedProposalID(ctx sdk.Context, proposalID int64) {
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(proposalID)
+	ctx.KVStore(keeper.storeKey).Set(KeyNextPrunedProposalID, bz)
+}
+
+// PruneProposals deletes the finished proposals whose voting period ended more than
+// DepositParams.ProposalRetention ago, together with their votes and tally breakdown.
+// The proposals are pruned in the order of their ids, so an old proposal waits for the
+// pruning of the ones submitted before it.
+func (keeper Keeper) PruneProposals(ctx sdk.Context, chainID string) sdk.Events {
+	resEvents := sdk.EmptyEvents()
+	retention := keeper.GetDepositParams(ctx).ProposalRetention
+	if retention <= 0 {
+		return resEvents
+	}
+	maxProposalID, err := keeper.peekCurrentProposalID(ctx)
+	if err != nil {
+		return resEvents
+	}
+
+	proposalID := keeper.getNextPrunedProposalID(ctx)
+	for i := 0; proposalID < maxProposalID && i < maxPrunedProposalsPerBlock; i, proposalID = i+1, proposalID+1 {
+		proposal := keeper.GetProposal(ctx, proposalID)
+		if proposal == nil {
+			// dropped for lack of deposit
+			continue
+		}
+		if status := proposal.GetStatus(); status == StatusDepositPeriod || status == StatusVotingPeriod {
+			break
+		}
+		votingEndTime := proposal.GetVotingStartTime().Add(proposal.GetVotingPeriod())
+		if ctx.BlockHeader().Time.Before(votingEndTime.Add(retention)) {
+			break
+		}
+
+		keeper.pruneProposal(ctx, chainID, proposal)
+
+		event := sdk.NewEvent(events.EventTypeProposalPruned, sdk.NewAttribute(events.ProposalID,
+			strconv.FormatInt(proposalID, 10)))
+		if chainID != NativeChainID {
+			event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainID))
+		}
+		resEvents = resEvents.AppendEvent(event)
+	}
+	keeper.setNextPrunedProposalID(ctx, proposalID)
+	return resEvents
+}
+
+func (keeper Keeper) pruneProposal(ctx sdk.Context, chainID string, proposal Proposal) {
+	proposalID := proposal.GetProposalID()
+	archived := ArchivedProposal{Proposal: proposal, Votes: make([]Vote, 0)}
+	archived.TallyBreakdown, _ = keeper.GetTallyBreakdown(ctx, proposalID)
+
+	store := ctx.KVStore(keeper.storeKey)
+	var voteKeys [][]byte
+	votesIterator := keeper.GetVotes(ctx, proposalID)
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		var vote Vote
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(votesIterator.Value(), &vote)
+		archived.Votes = append(archived.Votes, vote)
+		voteKeys = append(voteKeys, votesIterator.Key())
+	}
+	votesIterator.Close()
+
+	keeper.archiver.ArchiveProposal(ctx, chainID, archived)
+
+	for _, key := range voteKeys {
+		store.Delete(key)
+	}
+	keeper.DeleteProposal(ctx, proposal)
+	ctx.Logger().With("module", "x/gov").Info("pruned proposal", "proposalId", proposalID, "votes", len(voteKeys))
+}
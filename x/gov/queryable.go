@@ -17,6 +17,8 @@ const (
 	QueryVotes     = "votes"
 	QueryVote      = "vote"
 	QueryTally     = "tally"
+
+	QueryTallyBreakdown = "tally_breakdown"
 )
 
 func NewQuerier(keeper Keeper) sdk.Querier {
@@ -71,6 +73,13 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 				return res, err
 			}
 			return queryTally(ctx, path[1:], req, p, keeper)
+		case QueryTallyBreakdown:
+			p := new(QueryTallyBreakdownParams)
+			ctx, err = RequestPrepare(ctx, keeper, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryTallyBreakdown(ctx, path[1:], req, p, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
 		}
@@ -232,6 +241,39 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, params *Q
 	return bz, nil
 }
 
+// Params for query 'custom/gov/tally_breakdown'
+type QueryTallyBreakdownParams struct {
+	BaseParams
+	ProposalID int64
+	Limit      int // number of top voters to return, all validators if not positive
+}
+
+// nolint: unparam
+func queryTallyBreakdown(ctx sdk.Context, path []string, req abci.RequestQuery, params *QueryTallyBreakdownParams, keeper Keeper) (res []byte, err sdk.Error) {
+
+	proposal := keeper.GetProposal(ctx, params.ProposalID)
+	if proposal == nil {
+		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
+	}
+
+	var breakdown TallyBreakdown
+
+	if proposal.GetStatus() == StatusDepositPeriod {
+		breakdown = TallyBreakdown{}
+	} else if status := proposal.GetStatus(); status == StatusPassed || status == StatusRejected || status == StatusExecuted {
+		// empty for the proposals tallied before sdk.TallyBreakdown
+		breakdown, _ = keeper.GetTallyBreakdown(ctx, params.ProposalID)
+	} else {
+		_, _, _, breakdown = TallyWithBreakdown(ctx, keeper, proposal)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, breakdown.Top(params.Limit))
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
 func RequestPrepare(ctx sdk.Context, k Keeper, req abci.RequestQuery, p SideChainIder) (newCtx sdk.Context, err sdk.Error) {
 	if req.Data == nil || len(req.Data) == 0 {
 		return ctx, nil
@@ -8,6 +8,7 @@ var (
 	ActionSubmitProposal = []byte("submit-proposal")
 	ActionDeposit        = []byte("deposit")
 	ActionVote           = []byte("vote")
+	ActionVoteWeighted   = []byte("vote-weighted")
 
 	Action            = sdk.TagAction
 	Proposer          = "proposer"
@@ -1,19 +1,51 @@
 package gov
 
 import (
+	"bytes"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // validatorGovInfo used for tallying
 type validatorGovInfo struct {
-	Address             sdk.ValAddress // address of the validator operator
-	Power               sdk.Dec        // Power of a Validator
-	DelegatorShares     sdk.Dec        // Total outstanding delegator shares
-	DelegatorDeductions sdk.Dec        // Delegator deductions from validator's delegators voting independently
-	Vote                VoteOption     // Vote of the validator
+	Address             sdk.ValAddress      // address of the validator operator
+	Power               sdk.Dec             // Power of a Validator
+	DelegatorShares     sdk.Dec             // Total outstanding delegator shares
+	DelegatorDeductions sdk.Dec             // Delegator deductions from validator's delegators voting independently
+	Vote                VoteOption          // Vote of the validator
+	Options             WeightedVoteOptions // Split of the vote of the validator, nil if it did not vote
+}
+
+// ValidatorTally records how a single bonded validator contributed to a tally
+type ValidatorTally struct {
+	Validator      sdk.ValAddress      `json:"validator"`         // address of the validator operator
+	Option         VoteOption          `json:"option"`            // option voted by the validator, OptionEmpty if it did not vote or split its vote
+	Options        WeightedVoteOptions `json:"options,omitempty"` // split of a weighted vote of the validator
+	Power          sdk.Dec             `json:"power"`             // total power of the validator at tally time
+	InheritedPower sdk.Dec             `json:"inherited_power"`   // power counted for Option after deducting independent delegator votes
+	DeductedPower  sdk.Dec             `json:"deducted_power"`    // power of delegators who voted independently
+}
+
+// TallyBreakdown is the per-validator breakdown of a tally, ordered by inherited power descending
+type TallyBreakdown []ValidatorTally
+
+// Top returns at most limit entries of the breakdown, all of them if limit is not positive
+func (tb TallyBreakdown) Top(limit int) TallyBreakdown {
+	if limit <= 0 || limit >= len(tb) {
+		return tb
+	}
+	return tb[:limit]
 }
 
 func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refundDeposits bool, tallyResults TallyResult) {
+	passes, refundDeposits, tallyResults, _ = TallyWithBreakdown(ctx, keeper, proposal)
+	return
+}
+
+// TallyWithBreakdown tallies the proposal like Tally and additionally returns
+// the contribution of every bonded validator.
+func TallyWithBreakdown(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refundDeposits bool, tallyResults TallyResult, breakdown TallyBreakdown) {
 	results := make(map[VoteOption]sdk.Dec)
 	results[OptionYes] = sdk.ZeroDec()
 	results[OptionAbstain] = sdk.ZeroDec()
@@ -46,6 +78,7 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 		valAddrStr := sdk.ValAddress(vote.Voter).String()
 		if val, ok := currValidators[valAddrStr]; ok {
 			val.Vote = vote.Option
+			val.Options = vote.WeightedOptions()
 			currValidators[valAddrStr] = val
 		} else {
 
@@ -59,7 +92,7 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 					delegatorShare := delegation.GetShares().Quo(val.DelegatorShares)
 					votingPower := val.Power.Mul(delegatorShare)
 
-					results[vote.Option] = results[vote.Option].Add(votingPower)
+					addWeightedVotingPower(results, vote.WeightedOptions(), votingPower)
 					totalVotingPower = totalVotingPower.Add(votingPower)
 				}
 
@@ -71,18 +104,35 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 	}
 
 	// iterate over the validators again to tally their voting power
+	breakdown = make(TallyBreakdown, 0, len(currValidators))
 	for _, val := range currValidators {
-		if val.Vote == OptionEmpty {
-			continue
+		votingPower := sdk.ZeroDec()
+		if !val.DelegatorShares.IsZero() {
+			sharesAfterMinus := val.DelegatorShares.Sub(val.DelegatorDeductions)
+			percentAfterMinus := sharesAfterMinus.Quo(val.DelegatorShares)
+			votingPower = val.Power.Mul(percentAfterMinus)
+		}
+
+		valTally := ValidatorTally{
+			Validator:      val.Address,
+			Option:         val.Vote,
+			Power:          val.Power,
+			InheritedPower: votingPower,
+			DeductedPower:  val.Power.Sub(votingPower),
+		}
+		if len(val.Options) > 1 {
+			valTally.Options = val.Options
 		}
+		breakdown = append(breakdown, valTally)
 
-		sharesAfterMinus := val.DelegatorShares.Sub(val.DelegatorDeductions)
-		percentAfterMinus := sharesAfterMinus.Quo(val.DelegatorShares)
-		votingPower := val.Power.Mul(percentAfterMinus)
+		if len(val.Options) == 0 {
+			continue
+		}
 
-		results[val.Vote] = results[val.Vote].Add(votingPower)
+		addWeightedVotingPower(results, val.Options, votingPower)
 		totalVotingPower = totalVotingPower.Add(votingPower)
 	}
+	sortTallyBreakdown(breakdown)
 
 	tallyingParams := keeper.GetTallyParams(ctx)
 	totalPower := keeper.vs.TotalPower(ctx)
@@ -96,26 +146,43 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 
 	// If there is no staked coins, the proposal fails
 	if keeper.vs.TotalPower(ctx).IsZero() {
-		return false, true, tallyResults
+		return false, true, tallyResults, breakdown
 	}
 	// If there is not enough quorum of votes, the proposal fails
 	percentVoting := totalVotingPower.Quo(totalPower)
 	if percentVoting.LT(tallyingParams.Quorum) {
-		return false, true, tallyResults
+		return false, true, tallyResults, breakdown
 	}
 	// If no one votes, proposal fails
 	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroDec()) {
-		return false, true, tallyResults
+		return false, true, tallyResults, breakdown
 	}
 	// If more than 1/3 of voters veto, proposal fails
 	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingParams.Veto) {
-		return false, false, tallyResults
+		return false, false, tallyResults, breakdown
 	}
 	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
 	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingParams.Threshold) {
-		return true, true, tallyResults
+		return true, true, tallyResults, breakdown
 	}
 	// If more than 1/2 of non-abstaining voters vote No, proposal fails
 
-	return false, false, tallyResults
+	return false, false, tallyResults, breakdown
+}
+
+// addWeightedVotingPower splits the voting power of a vote across its options by their weights
+func addWeightedVotingPower(results map[VoteOption]sdk.Dec, options WeightedVoteOptions, votingPower sdk.Dec) {
+	for _, option := range options {
+		results[option.Option] = results[option.Option].Add(votingPower.Mul(option.Weight))
+	}
+}
+
+// sort by inherited power descending, ties broken by validator address so the result is deterministic
+func sortTallyBreakdown(breakdown TallyBreakdown) {
+	sort.SliceStable(breakdown, func(i, j int) bool {
+		if !breakdown[i].InheritedPower.Equal(breakdown[j].InheritedPower) {
+			return breakdown[i].InheritedPower.GT(breakdown[j].InheritedPower)
+		}
+		return bytes.Compare(breakdown[i].Validator, breakdown[j].Validator) < 0
+	})
 }
@@ -1,6 +1,7 @@
 package gov_test
 
 import (
+	"strconv"
 	"testing"
 	"time"
 
@@ -539,3 +540,165 @@ func TestTallyJailedValidator(t *testing.T) {
 	require.True(t, passes)
 	require.False(t, tallyResults.Equals(gov.EmptyTallyResult()))
 }
+
+func TestTallyBreakdown(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 6, 7})
+	stake.EndBlocker(ctx, sk)
+
+	delegator1Msg := stake.NewMsgDelegate(addrs[3], sdk.ValAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 30))
+	stakeHandler(ctx, delegator1Msg)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[1], gov.OptionNo)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[2], gov.OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[3], gov.OptionNo)
+	require.Nil(t, err)
+
+	_, _, _, breakdown := gov.TallyWithBreakdown(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	require.Len(t, breakdown, 3)
+
+	// validator 2 loses the power of its delegator who voted independently
+	require.Equal(t, valAddrs[2], breakdown[0].Validator)
+	require.Equal(t, gov.OptionYes, breakdown[0].Option)
+	require.Equal(t, sdk.NewDec(37), breakdown[0].Power)
+	require.Equal(t, sdk.NewDec(7), breakdown[0].InheritedPower)
+	require.Equal(t, sdk.NewDec(30), breakdown[0].DeductedPower)
+
+	require.Equal(t, valAddrs[1], breakdown[1].Validator)
+	require.Equal(t, gov.OptionNo, breakdown[1].Option)
+	require.Equal(t, valAddrs[0], breakdown[2].Validator)
+	require.Equal(t, gov.OptionEmpty, breakdown[2].Option)
+	require.Equal(t, sdk.NewDec(5), breakdown[2].InheritedPower)
+
+	require.Len(t, breakdown.Top(1), 1)
+	require.Len(t, breakdown.Top(0), 3)
+}
+
+func TestTallyBreakdownStored(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.TallyBreakdown, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.TallyBreakdown)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+	govHandler := gov.NewHandler(keeper)
+
+	valAddrs := []sdk.ValAddress{sdk.ValAddress(addrs[0]), sdk.ValAddress(addrs[1])}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 6})
+	stake.EndBlocker(ctx, sk)
+
+	votingPeriod := 1000 * time.Second
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}, votingPeriod))
+	require.True(t, res.IsOK())
+	proposalID, _ := strconv.Atoi(string(res.Data))
+
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalID), gov.OptionYes))
+	require.True(t, res.IsOK())
+	res = govHandler(ctx, gov.NewMsgVote(addrs[1], int64(proposalID), gov.OptionYes))
+	require.True(t, res.IsOK())
+
+	// pass voting period
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+
+	proposal := keeper.GetProposal(ctx, int64(proposalID))
+	require.Equal(t, gov.StatusPassed, proposal.GetStatus())
+	breakdown, found := keeper.GetTallyBreakdown(ctx, int64(proposalID))
+	require.True(t, found)
+	require.Len(t, breakdown, 2)
+
+	// the breakdown is still served once the proposal is executed
+	proposal.SetStatus(gov.StatusExecuted)
+	keeper.SetProposal(ctx, proposal)
+
+	bz := mapp.Cdc.MustMarshalJSON(gov.QueryTallyBreakdownParams{ProposalID: int64(proposalID)})
+	bz, err := gov.NewQuerier(keeper)(ctx, []string{gov.QueryTallyBreakdown}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var queried gov.TallyBreakdown
+	mapp.Cdc.MustUnmarshalJSON(bz, &queried)
+	require.Equal(t, breakdown, queried)
+}
+
+func TestTallyWeightedVotes(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{10, 10, 10})
+	stake.EndBlocker(ctx, sk)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	// the weights must sum to one
+	err := keeper.AddWeightedVote(ctx, proposalID, addrs[0], gov.WeightedVoteOptions{
+		{Option: gov.OptionYes, Weight: sdk.NewDecWithPrec(5, 1)},
+		{Option: gov.OptionNo, Weight: sdk.NewDecWithPrec(4, 1)},
+	})
+	require.NotNil(t, err)
+
+	// a split given entirely to one option is a plain vote
+	err = keeper.AddWeightedVote(ctx, proposalID, addrs[0], gov.WeightedVoteOptions{{Option: gov.OptionYes, Weight: sdk.OneDec()}})
+	require.Nil(t, err)
+	vote, found := keeper.GetVote(ctx, proposalID, addrs[0])
+	require.True(t, found)
+	require.Equal(t, gov.OptionYes, vote.Option)
+	require.Empty(t, vote.Options)
+
+	split := gov.WeightedVoteOptions{
+		{Option: gov.OptionYes, Weight: sdk.NewDecWithPrec(5, 1)},
+		{Option: gov.OptionNo, Weight: sdk.NewDecWithPrec(5, 1)},
+	}
+	err = keeper.AddWeightedVote(ctx, proposalID, addrs[1], split)
+	require.Nil(t, err)
+	err = keeper.AddWeightedVote(ctx, proposalID, addrs[2], gov.WeightedVoteOptions{
+		{Option: gov.OptionNo, Weight: sdk.NewDecWithPrec(7, 1)},
+		{Option: gov.OptionAbstain, Weight: sdk.NewDecWithPrec(3, 1)},
+	})
+	require.Nil(t, err)
+
+	passes, _, tallyResults, breakdown := gov.TallyWithBreakdown(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	require.True(t, passes)
+	require.Equal(t, sdk.NewDecWithPrec(15, 8), tallyResults.Yes)
+	require.Equal(t, sdk.NewDecWithPrec(12, 8), tallyResults.No)
+	require.Equal(t, sdk.NewDecWithPrec(3, 8), tallyResults.Abstain)
+	for _, valTally := range breakdown {
+		if valTally.Validator.Equals(valAddrs[1]) {
+			require.Equal(t, gov.OptionEmpty, valTally.Option)
+			require.Equal(t, split, valTally.Options)
+		}
+	}
+}
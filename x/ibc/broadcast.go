@@ -0,0 +1,80 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Destination is a chain and a channel a package is broadcast to
+type Destination struct {
+	ChainName   string
+	ChannelName string
+}
+
+func (d Destination) String() string {
+	return fmt.Sprintf("%s/%s", d.ChainName, d.ChannelName)
+}
+
+// BroadcastResult is the outcome of a broadcast for one of its destinations
+type BroadcastResult struct {
+	Destination Destination
+	Sequence    uint64
+	Err         sdk.Error
+}
+
+// BroadcastResults are the outcomes of a broadcast, in the order of its destinations
+type BroadcastResults []BroadcastResult
+
+// Failed returns the results of the destinations the package was not created for
+func (results BroadcastResults) Failed() BroadcastResults {
+	failed := make(BroadcastResults, 0)
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns nil if the package was created for every destination, otherwise an error
+// with the code of the first failure and the message of all of them
+func (results BroadcastResults) Err() sdk.Error {
+	failed := results.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, result := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", result.Destination, result.Err.Error()))
+	}
+	return sdk.NewError(failed[0].Err.Codespace(), failed[0].Err.Code(),
+		fmt.Sprintf("broadcast failed for %d of %d destinations; %s", len(failed), len(results), strings.Join(msgs, "; ")))
+}
+
+// SideChainDestinations returns the destinations of the channel on all the registered side chains
+func (k *Keeper) SideChainDestinations(ctx sdk.Context, channelName string) []Destination {
+	sideChainIds, _ := k.sideKeeper.GetAllSideChainPrefixes(ctx)
+	destinations := make([]Destination, 0, len(sideChainIds))
+	for _, sideChainId := range sideChainIds {
+		destinations = append(destinations, Destination{ChainName: sideChainId, ChannelName: channelName})
+	}
+	return destinations
+}
+
+// BroadcastIBCSyncPackage creates the same sync package for each destination, with the relayer fee
+// and the next sequence of the destination. A destination failing does not stop the others, the
+// packages created are kept, the caller checks the results to decide what to do with the failures.
+func (k *Keeper) BroadcastIBCSyncPackage(ctx sdk.Context, destinations []Destination, packageLoad []byte) BroadcastResults {
+	results := make(BroadcastResults, 0, len(destinations))
+	for _, destination := range destinations {
+		sequence, err := k.CreateIBCSyncPackage(ctx, destination.ChainName, destination.ChannelName, packageLoad, nil)
+		results = append(results, BroadcastResult{
+			Destination: destination,
+			Sequence:    sequence,
+			Err:         err,
+		})
+	}
+	return results
+}
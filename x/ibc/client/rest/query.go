@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+const (
+	RestFromSequence = "from"
+	RestLimit        = "limit"
+)
+
+// RegisterRoutes registers ibc-related REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(
+		"/ibc/chains/{destChainName}/channels/{channelName}/pending_packages",
+		pendingPackagesHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+}
+
+func pendingPackagesHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		params := ibc.QueryPendingPackagesParams{
+			DestChainName: vars["destChainName"],
+			ChannelName:   vars["channelName"],
+		}
+
+		if from := r.URL.Query().Get(RestFromSequence); len(from) != 0 {
+			sequence, err := strconv.ParseUint(from, 10, 64)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid from sequence %s", from))
+				return
+			}
+			params.FromSequence = sequence
+		}
+		if limit := r.URL.Query().Get(RestLimit); len(limit) != 0 {
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid limit %s", limit))
+				return
+			}
+			params.Limit = n
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/ibc/%s", ibc.QueryPendingPackages), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -5,16 +5,25 @@ import (
 )
 
 func EndBlocker(ctx sdk.Context, keeper Keeper) {
-	if len(keeper.packageCollector.collectedPackages) == 0 {
-		return
+	keeper.refundExpiredPackages(ctx)
+	if sdk.IsUpgrade(sdk.ChannelSenders) {
+		keeper.executeChanSendersProposals(ctx)
 	}
-	var attributes []sdk.Attribute
-	for _, ibcPackageRecord := range keeper.packageCollector.collectedPackages {
-		attributes = append(attributes,
-			sdk.NewAttribute(ibcPackageInfoAttributeKey,
-				buildIBCPackageAttributeValue(ibcPackageRecord.destChainID, ibcPackageRecord.channelID, ibcPackageRecord.sequence)))
+
+	if len(keeper.packageCollector.collectedPackages) != 0 {
+		var attributes []sdk.Attribute
+		for _, ibcPackageRecord := range keeper.packageCollector.collectedPackages {
+			attributes = append(attributes,
+				sdk.NewAttribute(ibcPackageInfoAttributeKey,
+					buildIBCPackageAttributeValue(ibcPackageRecord.destChainID, ibcPackageRecord.channelID, ibcPackageRecord.sequence)))
+		}
+		keeper.packageCollector.collectedPackages = keeper.packageCollector.collectedPackages[:0]
+		event := sdk.NewEvent(ibcEventType, attributes...)
+		ctx.EventManager().EmitEvent(event)
+	}
+
+	if len(keeper.packageCollector.lifecycleEvents) != 0 {
+		ctx.EventManager().EmitEvents(keeper.packageCollector.lifecycleEvents)
+		keeper.packageCollector.lifecycleEvents = nil
 	}
-	keeper.packageCollector.collectedPackages = keeper.packageCollector.collectedPackages[:0]
-	event := sdk.NewEvent(ibcEventType, attributes...)
-	ctx.EventManager().EmitEvent(event)
 }
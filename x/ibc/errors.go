@@ -12,6 +12,15 @@ const (
 	CodeFeeParamMismatch      sdk.CodeType = 102
 	CodeInvalidChainId        sdk.CodeType = 103
 	CodeWritePackageForbidden sdk.CodeType = 104
+	CodeDuplicatedRefund      sdk.CodeType = 105
+	CodeRefundNotFound        sdk.CodeType = 106
+	CodeInvalidRefund         sdk.CodeType = 107
+	CodeSenderNotAllowed      sdk.CodeType = 108
+	CodeInvalidRelayFee       sdk.CodeType = 109
+	CodeInvalidWatermark      sdk.CodeType = 110
+	CodeChannelLimitExceeded  sdk.CodeType = 111
+	CodeNoPackageHandler      sdk.CodeType = 112
+	CodeInvalidPackage        sdk.CodeType = 113
 )
 
 func ErrDuplicatedSequence(codespace sdk.CodespaceType, msg string) sdk.Error {
@@ -29,3 +38,39 @@ func ErrInvalidChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
 func ErrWritePackageForbidden(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeWritePackageForbidden, msg)
 }
+
+func ErrDuplicatedRefund(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicatedRefund, msg)
+}
+
+func ErrRefundNotFound(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeRefundNotFound, msg)
+}
+
+func ErrInvalidRefund(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidRefund, msg)
+}
+
+func ErrSenderNotAllowed(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeSenderNotAllowed, msg)
+}
+
+func ErrInvalidRelayFee(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidRelayFee, msg)
+}
+
+func ErrInvalidWatermark(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidWatermark, msg)
+}
+
+func ErrChannelLimitExceeded(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeChannelLimitExceeded, msg)
+}
+
+func ErrNoPackageHandler(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeNoPackageHandler, msg)
+}
+
+func ErrInvalidPackage(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPackage, msg)
+}
@@ -1,7 +1,10 @@
 package ibc
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -13,6 +16,104 @@ const (
 	ibcPackageInfoAttributeValue = "%d" + separator + "%d" + separator + "%d" // destChainID channelID sequence
 )
 
+// Package lifecycle events. Every event carries the chain, channel and sequence of the package
+// so that relayers can follow a package from creation to cleanup from events alone.
+const (
+	EventTypePackageCreated       = "ibc_package_created"
+	EventTypePackageCleanup       = "ibc_package_cleanup"
+	EventTypePackageExecuted      = "ibc_package_executed"
+	EventTypePackageExecuteFailed = "ibc_package_execute_failed"
+	EventTypePackageRefund        = "ibc_package_refund"
+	EventTypeRelayFeeEscrowed     = "ibc_relay_fee_escrowed"
+	EventTypeRelayFeePaid         = "ibc_relay_fee_paid"
+
+	AttributeKeyChainID     = "chain_id"
+	AttributeKeyChannelID   = "channel_id"
+	AttributeKeySequence    = "sequence"
+	AttributeKeyPackageType = "package_type"
+	AttributeKeyPayloadHash = "payload_hash"
+	AttributeKeyResultCode  = "result_code"
+	AttributeKeyResultMsg   = "result_msg"
+	AttributeKeyCrash       = "crash"
+	AttributeKeySender      = "sender"
+	AttributeKeyAmount      = "amount"
+	AttributeKeyRelayer     = "relayer"
+)
+
 func buildIBCPackageAttributeValue(sideChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) string {
 	return fmt.Sprintf(ibcPackageInfoAttributeValue, sideChainID, channelID, sequence)
 }
+
+// PayloadHash is the hex encoded sha256 of a package as it is stored, header included.
+func PayloadHash(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+func packageAttributes(chainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(AttributeKeyChainID, strconv.FormatUint(uint64(chainID), 10)),
+		sdk.NewAttribute(AttributeKeyChannelID, strconv.FormatUint(uint64(channelID), 10)),
+		sdk.NewAttribute(AttributeKeySequence, strconv.FormatUint(sequence, 10)),
+	}
+}
+
+// NewPackageCreatedEvent is emitted when an outbound package is written.
+func NewPackageCreatedEvent(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64,
+	packageType sdk.CrossChainPackageType, payload []byte) sdk.Event {
+	attributes := append(packageAttributes(destChainID, channelID, sequence),
+		sdk.NewAttribute(AttributeKeyPackageType, strconv.FormatUint(uint64(packageType), 10)),
+		sdk.NewAttribute(AttributeKeyPayloadHash, PayloadHash(payload)),
+	)
+	return sdk.NewEvent(EventTypePackageCreated, attributes...)
+}
+
+// NewPackageCleanupEvent is emitted when the outbound packages of a channel up to sequence are deleted.
+func NewPackageCleanupEvent(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) sdk.Event {
+	return sdk.NewEvent(EventTypePackageCleanup, packageAttributes(destChainID, channelID, sequence)...)
+}
+
+// NewPackageExecutedEvent is emitted when an inbound package has been executed by its cross chain app,
+// failed executions have the type EventTypePackageExecuteFailed.
+func NewPackageExecutedEvent(srcChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64,
+	packageType sdk.CrossChainPackageType, payload []byte, result sdk.ExecuteResult, crash bool) sdk.Event {
+	eventType := EventTypePackageExecuted
+	if !result.IsOk() {
+		eventType = EventTypePackageExecuteFailed
+	}
+	attributes := append(packageAttributes(srcChainID, channelID, sequence),
+		sdk.NewAttribute(AttributeKeyPackageType, strconv.FormatUint(uint64(packageType), 10)),
+		sdk.NewAttribute(AttributeKeyPayloadHash, PayloadHash(payload)),
+		sdk.NewAttribute(AttributeKeyResultCode, strconv.FormatUint(uint64(result.Code()), 10)),
+		sdk.NewAttribute(AttributeKeyResultMsg, result.Msg()),
+		sdk.NewAttribute(AttributeKeyCrash, strconv.FormatBool(crash)),
+	)
+	return sdk.NewEvent(eventType, attributes...)
+}
+
+// NewPackageRefundEvent is emitted when the escrowed funds of an outbound package are returned to its sender.
+func NewPackageRefundEvent(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, escrow RefundEscrow) sdk.Event {
+	attributes := append(packageAttributes(destChainID, channelID, sequence),
+		sdk.NewAttribute(AttributeKeySender, escrow.Sender.String()),
+		sdk.NewAttribute(AttributeKeyAmount, escrow.Amount.String()),
+	)
+	return sdk.NewEvent(EventTypePackageRefund, attributes...)
+}
+
+// NewRelayFeeEscrowedEvent is emitted when the relay fee of an outbound package is escrowed.
+func NewRelayFeeEscrowedEvent(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, escrow RelayFeeEscrow) sdk.Event {
+	attributes := append(packageAttributes(destChainID, channelID, sequence),
+		sdk.NewAttribute(AttributeKeySender, escrow.Payer.String()),
+		sdk.NewAttribute(AttributeKeyAmount, escrow.Fee.String()),
+	)
+	return sdk.NewEvent(EventTypeRelayFeeEscrowed, attributes...)
+}
+
+// NewRelayFeePaidEvent is emitted when the escrowed relay fee of an outbound package is paid to a relayer.
+func NewRelayFeePaidEvent(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, relayer sdk.AccAddress, fee sdk.Coins) sdk.Event {
+	attributes := append(packageAttributes(destChainID, channelID, sequence),
+		sdk.NewAttribute(AttributeKeyRelayer, relayer.String()),
+		sdk.NewAttribute(AttributeKeyAmount, fee.String()),
+	)
+	return sdk.NewEvent(EventTypeRelayFeePaid, attributes...)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/bsc"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
 	param "github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
@@ -21,6 +22,14 @@ type Keeper struct {
 	paramSpace       param.Subspace
 	packageCollector *packageCollector
 	sideKeeper       sidechain.Keeper
+	refunder         *refunder
+	govKeeper        *gov.Keeper
+
+	// the module creating the packages through this keeper, see ForModule
+	senderModule string
+
+	// the handlers of the packages received on the channels, see RegisterPackageHandler
+	packageHandlers map[sdk.ChannelID]sdk.CrossChainApplication
 }
 
 func ParamTypeTable() param.TypeTable {
@@ -34,25 +43,34 @@ func NewKeeper(storeKey sdk.StoreKey, paramSpace param.Subspace, codespace sdk.C
 		packageCollector: newPackageCollector(),
 		paramSpace:       paramSpace.WithTypeTable(ParamTypeTable()),
 		sideKeeper:       sideKeeper,
+		refunder:         &refunder{},
+		packageHandlers:  make(map[sdk.ChannelID]sdk.CrossChainApplication),
 	}
 }
 
-func (k *Keeper) CreateIBCSyncPackage(ctx sdk.Context, destChainName string, channelName string, packageLoad []byte) (uint64, sdk.Error) {
+// CreateIBCSyncPackage writes a syn package with the relayer fee of the destination chain. If relayFee is not nil,
+// its fee is escrowed from its payer until the ack of the package is delivered, and then paid to the relayer
+// delivering the ack, see PayRelayFee.
+func (k *Keeper) CreateIBCSyncPackage(ctx sdk.Context, destChainName string, channelName string, packageLoad []byte,
+	relayFee *RelayFeeEscrow) (uint64, sdk.Error) {
 	relayerFee, err := k.GetRelayerFeeParam(ctx, destChainName)
 	if err != nil {
 		return 0, ErrFeeParamMismatch(DefaultCodespace, fmt.Sprintf("fail to load relayerFee, %v", err))
 	}
+	if relayFee != nil {
+		return k.createIBCSyncPackageWithFee(ctx, destChainName, channelName, packageLoad, *relayerFee, *relayFee)
+	}
 	return k.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, packageLoad, *relayerFee)
 }
 
 func (k *Keeper) CreateRawIBCPackage(ctx sdk.Context, destChainName string, channelName string,
 	packageType sdk.CrossChainPackageType, packageLoad []byte, relayerFee big.Int) (uint64, sdk.Error) {
 
-	destChainID, err := k.sideKeeper.GetDestChainID(destChainName)
+	destChainID, err := k.sideKeeper.LookupDestChainID(ctx, destChainName)
 	if err != nil {
 		return 0, sdk.ErrInternal(err.Error())
 	}
-	channelID, err := k.sideKeeper.GetChannelID(channelName)
+	channelID, err := k.sideKeeper.LookupChannelID(ctx, channelName)
 	if err != nil {
 		return 0, sdk.ErrInternal(err.Error())
 	}
@@ -63,7 +81,7 @@ func (k *Keeper) CreateRawIBCPackage(ctx sdk.Context, destChainName string, chan
 func (k *Keeper) CreateRawIBCPackageById(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
 	packageType sdk.CrossChainPackageType, packageLoad []byte) (uint64, sdk.Error) {
 
-	destChainName, err := k.sideKeeper.GetDestChainName(destChainID)
+	destChainName, err := k.sideKeeper.LookupDestChainName(ctx, destChainID)
 	if err != nil {
 		return 0, ErrInvalidChainId(DefaultCodespace, "can not find dest chain id")
 	}
@@ -81,6 +99,11 @@ func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk
 	if packageType == sdk.SynCrossChainPackageType && k.sideKeeper.GetChannelSendPermission(ctx, destChainID, channelID) != sdk.ChannelAllow {
 		return 0, ErrWritePackageForbidden(DefaultCodespace, fmt.Sprintf("channel %d is not allowed to write syn package", channelID))
 	}
+	if packageType == sdk.SynCrossChainPackageType {
+		if err := k.checkSender(ctx, channelID); err != nil {
+			return 0, err
+		}
+	}
 
 	sequence := k.sideKeeper.GetSendSequence(ctx, destChainID, channelID)
 	key := buildIBCPackageKey(k.sideKeeper.GetSrcChainID(), destChainID, channelID, sequence)
@@ -88,12 +111,32 @@ func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk
 	if kvStore.Has(key) {
 		return 0, ErrDuplicatedSequence(DefaultCodespace, "duplicated sequence")
 	}
+	if packageType == sdk.SynCrossChainPackageType {
+		if err := k.checkChannelLimit(ctx, destChainID, channelID, sequence, len(packageLoad)); err != nil {
+			return 0, err
+		}
+	}
+
+	compression := k.getPackageCompression(ctx, destChainID, len(packageLoad))
+	if compression != sTypes.CompressionNone {
+		compressed, err := sTypes.CompressPackageLoad(compression, packageLoad)
+		if err != nil {
+			return 0, sdk.ErrInternal(fmt.Sprintf("fail to compress package load, %v", err))
+		}
+		if len(compressed) < len(packageLoad) {
+			packageLoad = compressed
+		} else {
+			compression = sTypes.CompressionNone
+		}
+	}
 
 	// Assemble the package header
-	packageHeader := sTypes.EncodePackageHeader(packageType, relayerFee)
+	packageHeader := sTypes.EncodePackageHeaderWithCompression(packageType, relayerFee, compression)
 
-	kvStore.Set(key, append(packageHeader, packageLoad...))
+	payload := append(packageHeader, packageLoad...)
+	kvStore.Set(key, payload)
 	k.sideKeeper.IncrSendSequence(ctx, destChainID, channelID)
+	k.recordPackageCreated(ctx, destChainID, channelID, sequence)
 
 	if ctx.IsDeliverTx() {
 		k.packageCollector.collectedPackages = append(k.packageCollector.collectedPackages, packageRecord{
@@ -102,16 +145,17 @@ func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk
 			sequence:    sequence,
 		})
 	}
+	k.collectEvent(ctx, NewPackageCreatedEvent(destChainID, channelID, sequence, packageType, payload))
 
 	return sequence, nil
 }
 
 func (k *Keeper) GetIBCPackage(ctx sdk.Context, destChainName string, channelName string, sequence uint64) ([]byte, error) {
-	destChainID, err := k.sideKeeper.GetDestChainID(destChainName)
+	destChainID, err := k.sideKeeper.LookupDestChainID(ctx, destChainName)
 	if err != nil {
 		return nil, err
 	}
-	channelID, err := k.sideKeeper.GetChannelID(channelName)
+	channelID, err := k.sideKeeper.LookupChannelID(ctx, channelName)
 	if err != nil {
 		return nil, err
 	}
@@ -124,20 +168,43 @@ func (k *Keeper) GetIBCPackageById(ctx sdk.Context, destChainID sdk.ChainID, cha
 	return kvStore.Get(key), nil
 }
 
-func (k *Keeper) CleanupIBCPackage(ctx sdk.Context, destChainName string, channelName string, confirmedSequence uint64) {
-	destChainID, err := k.sideKeeper.GetDestChainID(destChainName)
-	if err != nil {
+// GetIBCPackageLoadById returns the header fields and the decompressed load of a package
+func (k *Keeper) GetIBCPackageLoadById(ctx sdk.Context, destChainID sdk.ChainID, channelId sdk.ChannelID, sequence uint64) (
+	packageType sdk.CrossChainPackageType, relayFee big.Int, load []byte, err error) {
+
+	payload, _ := k.GetIBCPackageById(ctx, destChainID, channelId, sequence)
+	if payload == nil {
+		err = fmt.Errorf("package %d of channel %d not found", sequence, channelId)
 		return
 	}
-	channelID, err := k.sideKeeper.GetChannelID(channelName)
+	return sTypes.DecodePackage(payload)
+}
+
+// CleanupIBCPackage deletes the outbound packages of a channel up to the sequence of watermark. Since
+// sdk.IBCDeliveryWatermark the watermark must not be past the one recorded by the keeper, see GetDeliveryWatermark,
+// before it the sequence of the watermark is trusted.
+func (k *Keeper) CleanupIBCPackage(ctx sdk.Context, destChainName string, channelName string, watermark DeliveryWatermark) sdk.Error {
+	destChainID, err := k.sideKeeper.LookupDestChainID(ctx, destChainName)
 	if err != nil {
-		return
+		return ErrInvalidChainId(k.codespace, err.Error())
+	}
+	channelID, err := k.sideKeeper.LookupChannelID(ctx, channelName)
+	if err != nil {
+		return sdk.ErrInternal(err.Error())
 	}
+	if sdk.IsUpgrade(sdk.IBCDeliveryWatermark) {
+		if sdkErr := k.checkDeliveryWatermark(ctx, destChainID, channelID, watermark); sdkErr != nil {
+			return sdkErr
+		}
+	}
+	confirmedSequence := watermark.Sequence
+
 	prefixKey := buildIBCPackageKeyPrefix(k.sideKeeper.GetSrcChainID(), destChainID, channelID)
 	kvStore := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(kvStore, prefixKey)
 	defer iterator.Close()
 
+	var cleaned []uint64
 	for ; iterator.Valid(); iterator.Next() {
 		packageKey := iterator.Key()
 		if len(packageKey) != totalPackageKeyLength {
@@ -148,6 +215,19 @@ func (k *Keeper) CleanupIBCPackage(ctx sdk.Context, destChainName string, channe
 			break
 		}
 		kvStore.Delete(packageKey)
+		cleaned = append(cleaned, sequence)
+	}
+	if len(cleaned) > 0 {
+		k.recordPackagesDelivered(ctx, destChainID, channelID, cleaned)
+		k.collectEvent(ctx, NewPackageCleanupEvent(destChainID, channelID, confirmedSequence))
+	}
+	return nil
+}
+
+// collectEvent records a package lifecycle event, events are emitted together in EndBlocker.
+func (k *Keeper) collectEvent(ctx sdk.Context, event sdk.Event) {
+	if ctx.IsDeliverTx() {
+		k.packageCollector.lifecycleEvents = k.packageCollector.lifecycleEvents.AppendEvent(event)
 	}
 }
 
@@ -163,6 +243,32 @@ func (k Keeper) GetRelayerFeeParam(ctx sdk.Context, destChainName string) (relat
 	return
 }
 
+// getPackageCompression returns how a load sent to a side chain is compressed, loads are only compressed
+// after sdk.IBCLoadCompression and from the compression threshold of the side chain
+func (k Keeper) getPackageCompression(ctx sdk.Context, destChainID sdk.ChainID, loadLength int) sTypes.CompressionType {
+	if !sdk.IsUpgrade(sdk.IBCLoadCompression) {
+		return sTypes.CompressionNone
+	}
+	destChainName, err := k.sideKeeper.LookupDestChainName(ctx, destChainID)
+	if err != nil {
+		return sTypes.CompressionNone
+	}
+	storePrefix := k.sideKeeper.GetSideChainStorePrefix(ctx, destChainName)
+	if storePrefix == nil {
+		return sTypes.CompressionNone
+	}
+	sideChainCtx := ctx.WithSideChainKeyPrefix(storePrefix)
+
+	compression := sTypes.CompressionNone
+	threshold := DefaultCompressionThreshold
+	k.paramSpace.GetIfExists(sideChainCtx, ParamPackageCompression, &compression)
+	k.paramSpace.GetIfExists(sideChainCtx, ParamCompressionThreshold, &threshold)
+	if int64(loadLength) < threshold || !compression.IsStable() {
+		return sTypes.CompressionNone
+	}
+	return compression
+}
+
 func (k Keeper) SetParams(ctx sdk.Context, params Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
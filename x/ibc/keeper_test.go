@@ -1,8 +1,11 @@
 package ibc
 
 import (
+	"bytes"
 	"math/big"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -12,8 +15,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
 )
 
 func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
@@ -23,6 +29,10 @@ func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -30,8 +40,6 @@ func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	if isCheckTx {
 		mode = sdk.RunTxModeCheck
 	}
-	keyParams := sdk.NewKVStoreKey("params")
-	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	cdc := createTestCodec()
 	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
@@ -83,7 +91,7 @@ func TestKeeper(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint64(4), sequence)
 
-	keeper.CleanupIBCPackage(ctx, destChainName, channelName, 3)
+	keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{Sequence: 3})
 
 	ibcPackage, sdkErr := keeper.GetIBCPackage(ctx, destChainName, channelName, 0)
 	require.NoError(t, sdkErr)
@@ -117,9 +125,830 @@ func TestKeeper(t *testing.T) {
 
 }
 
+func createRefundTestInput(t *testing.T) (sdk.Context, Keeper, bank.Keeper) {
+	keyIBC := sdk.NewKVStoreKey("ibc")
+	keySideChain := sdk.NewKVStoreKey("sc")
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterBaseAccount(cdc)
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid", Time: time.Unix(1000, 0)}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(accountCache)
+
+	ck := bank.NewBaseKeeper(auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount))
+	scKeeper := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), cdc)
+	ibcKeeper := NewKeeper(keyIBC, pk.Subspace(DefaultParamspace), DefaultCodespace, scKeeper)
+	ibcKeeper.SetupForRefund(ck, new(sdk.Pool))
+
+	return ctx, ibcKeeper, ck
+}
+
+func TestRefund(t *testing.T) {
+	ctx, keeper, ck := createRefundTestInput(t)
+	destChainID := sdk.ChainID(0x000f)
+	channelID := sdk.ChannelID(0x02)
+	sender := sdk.AccAddress([]byte("sender______________"))
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)}
+	ck.SetCoins(ctx, sender, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 3e8)})
+
+	// escrow three packages expiring at different times
+	for sequence := uint64(0); sequence < 3; sequence++ {
+		err := keeper.EscrowPackageFunds(ctx, destChainID, channelID, sequence, sender, amount, 2000+int64(sequence))
+		require.Nil(t, err)
+	}
+	err := keeper.EscrowPackageFunds(ctx, destChainID, channelID, 0, sender, amount, 2000)
+	require.NotNil(t, err)
+	require.Equal(t, CodeDuplicatedRefund, err.Code())
+	require.True(t, ck.GetCoins(ctx, sender).IsZero())
+	require.Equal(t, int64(3e8), ck.GetCoins(ctx, sdk.PegAccount).AmountOf(sdk.NativeTokenSymbol))
+
+	// a successful package keeps its funds pegged
+	_, err = keeper.ReleasePackageFunds(ctx, destChainID, channelID, 0)
+	require.Nil(t, err)
+	_, err = keeper.RefundPackageFunds(ctx, destChainID, channelID, 0)
+	require.Equal(t, CodeRefundNotFound, err.Code())
+
+	// a failed package is refunded exactly once
+	escrow, err := keeper.RefundPackageFunds(ctx, destChainID, channelID, 1)
+	require.Nil(t, err)
+	require.Equal(t, sender, escrow.Sender)
+	require.Equal(t, amount, ck.GetCoins(ctx, sender))
+	_, err = keeper.RefundPackageFunds(ctx, destChainID, channelID, 1)
+	require.Equal(t, CodeRefundNotFound, err.Code())
+	require.Equal(t, amount, ck.GetCoins(ctx, sender))
+
+	// nothing has expired yet
+	EndBlocker(ctx, keeper)
+	_, found := keeper.GetRefundEscrow(ctx, destChainID, channelID, 2)
+	require.True(t, found)
+
+	// an unacknowledged package is refunded after it expires
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "foochainid", Time: time.Unix(2002, 0)})
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetRefundEscrow(ctx, destChainID, channelID, 2)
+	require.False(t, found)
+	require.Equal(t, int64(2e8), ck.GetCoins(ctx, sender).AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(1e8), ck.GetCoins(ctx, sdk.PegAccount).AmountOf(sdk.NativeTokenSymbol))
+
+	var refunded []string
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == EventTypePackageRefund {
+			refunded = append(refunded, eventAttribute(event, AttributeKeySequence))
+			require.Equal(t, sender.String(), eventAttribute(event, AttributeKeySender))
+			require.Equal(t, amount.String(), eventAttribute(event, AttributeKeyAmount))
+		}
+	}
+	require.Equal(t, []string{"1", "2"}, refunded)
+}
+
+func TestSettlePackageFunds(t *testing.T) {
+	ctx, keeper, ck := createRefundTestInput(t)
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x02)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCDeliveryWatermark, 2)
+	defer sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCDeliveryWatermark, 0)
+	sdk.UpgradeMgr.SetHeight(2)
+
+	sender := sdk.AccAddress([]byte("sender______________"))
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)}
+	ck.SetCoins(ctx, sender, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 4e8)})
+	for i := int64(0); i < 4; i++ {
+		sequence, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{byte(i)}, *big.NewInt(100))
+		require.NoError(t, err)
+		require.Nil(t, keeper.EscrowPackageFunds(ctx, destChainID, channelID, sequence, sender, amount, 2000+2*i))
+	}
+	ack := func(failed bool) {
+		watermark, ok := keeper.ConfirmPackageDelivery(ctx, destChainID, channelID)
+		require.True(t, ok)
+		keeper.SettlePackageFunds(ctx, destChainID, channelID, watermark.Sequence, failed)
+	}
+
+	// an acked package keeps its funds pegged
+	require.False(t, keeper.IsAckOfRefundedPackage(ctx, destChainID, channelID))
+	ack(false)
+	_, found := keeper.GetRefundEscrow(ctx, destChainID, channelID, 0)
+	require.False(t, found)
+	require.True(t, ck.GetCoins(ctx, sender).IsZero())
+
+	// the late ack of a package refunded after it expired is ignored
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "foochainid", Time: time.Unix(2002, 0)})
+	EndBlocker(ctx, keeper)
+	require.Equal(t, amount, ck.GetCoins(ctx, sender))
+	require.True(t, keeper.IsAckOfRefundedPackage(ctx, destChainID, channelID))
+	ack(true)
+	require.Equal(t, amount, ck.GetCoins(ctx, sender))
+
+	// a failed package is refunded by its fail ack
+	require.False(t, keeper.IsAckOfRefundedPackage(ctx, destChainID, channelID))
+	ack(true)
+	require.Equal(t, int64(2e8), ck.GetCoins(ctx, sender).AmountOf(sdk.NativeTokenSymbol))
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "foochainid", Time: time.Unix(2004, 0)})
+	EndBlocker(ctx, keeper)
+	require.Equal(t, int64(2e8), ck.GetCoins(ctx, sender).AmountOf(sdk.NativeTokenSymbol))
+	require.False(t, keeper.IsAckOfRefundedPackage(ctx, destChainID, channelID))
+}
+
+func TestChannelLimits(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+	ctx, keeper := createTestInput(t, false)
+	ctx = ctx.WithBlockHeight(1)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, []byte{0x01})
+	params := Params{
+		RelayerFee:    DefaultRelayerFeeParam,
+		ChannelLimits: []ChannelLimit{{ChannelID: channelID, MaxPackageSize: 4, MaxPackagesPerBlock: 2, MaxPendingPackages: 4}},
+	}
+	require.Nil(t, params.UpdateCheck())
+	keeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x01}), params)
+	send := func(ctx sdk.Context, load []byte) sdk.Error {
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, load, *big.NewInt(100))
+		return err
+	}
+
+	// the limits are not enforced before the upgrade
+	require.Nil(t, send(ctx, make([]byte, 8)))
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCChannelLimits, 1)
+	defer sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCChannelLimits, 0)
+	sdk.UpgradeMgr.SetHeight(1)
+
+	err := send(ctx, make([]byte, 5))
+	require.Equal(t, CodeChannelLimitExceeded, err.Code())
+	require.Nil(t, send(ctx, make([]byte, 4)))
+	require.Nil(t, send(ctx, []byte{0x01}))
+	err = send(ctx, []byte{0x02})
+	require.Equal(t, CodeChannelLimitExceeded, err.Code())
+	require.Contains(t, err.Error(), "in the block")
+
+	// the count starts over in a new block, until 4 packages are pending
+	ctx = ctx.WithBlockHeight(2)
+	require.Nil(t, send(ctx, []byte{0x02}))
+	err = send(ctx, []byte{0x03})
+	require.Equal(t, CodeChannelLimitExceeded, err.Code())
+	require.Contains(t, err.Error(), "pending")
+	require.Nil(t, keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{Sequence: 0}))
+	require.Nil(t, send(ctx, []byte{0x03}))
+	require.EqualValues(t, 5, keeper.sideKeeper.GetSendSequence(ctx, destChainID, channelID))
+
+	// the acks are not limited, and neither are the other channels
+	_, err = keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.AckCrossChainPackageType, make([]byte, 8), *big.NewInt(0))
+	require.Nil(t, err)
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("staking", sdk.ChannelID(0x02), nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, sdk.ChannelID(0x02), sdk.ChannelAllow)
+	_, err = keeper.CreateRawIBCPackage(ctx, destChainName, "staking", sdk.SynCrossChainPackageType, make([]byte, 8), *big.NewInt(100))
+	require.Nil(t, err)
+
+	params.ChannelLimits = append(params.ChannelLimits, ChannelLimit{ChannelID: channelID})
+	require.Error(t, params.UpdateCheck())
+	params.ChannelLimits = []ChannelLimit{{ChannelID: channelID, MaxPackageSize: -1}}
+	require.Error(t, params.UpdateCheck())
+}
+
+func TestDeliveryWatermark(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	for i := 0; i < 4; i++ {
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{byte(i)}, *big.NewInt(100))
+		require.NoError(t, err)
+	}
+	// cleaned up before the upgrade with a trusted sequence
+	require.Nil(t, keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{Sequence: 0}))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCDeliveryWatermark, 2)
+	defer sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCDeliveryWatermark, 0)
+	sdk.UpgradeMgr.SetHeight(2)
+
+	_, found := keeper.GetDeliveryWatermark(ctx, destChainName, channelName)
+	require.False(t, found)
+	err := keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{DestChainID: destChainID, ChannelID: channelID, Sequence: 1})
+	require.Equal(t, CodeInvalidWatermark, err.Code())
+
+	// the first ack confirms the oldest package left
+	watermark, confirmed := keeper.ConfirmPackageDelivery(ctx, destChainID, channelID)
+	require.True(t, confirmed)
+	require.EqualValues(t, 1, watermark.Sequence)
+	watermark, confirmed = keeper.ConfirmPackageDelivery(ctx, destChainID, channelID)
+	require.True(t, confirmed)
+	require.EqualValues(t, 2, watermark.Sequence)
+	recorded, found := keeper.GetDeliveryWatermark(ctx, destChainName, channelName)
+	require.True(t, found)
+	require.Equal(t, watermark, recorded)
+
+	// a watermark past the recorded one or of another channel is rejected
+	err = keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{DestChainID: destChainID, ChannelID: channelID, Sequence: 3})
+	require.Equal(t, CodeInvalidWatermark, err.Code())
+	err = keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{DestChainID: destChainID, ChannelID: 0x02, Sequence: 1})
+	require.Equal(t, CodeInvalidWatermark, err.Code())
+	require.Nil(t, keeper.CleanupIBCPackage(ctx, destChainName, channelName, recorded))
+	for sequence := uint64(0); sequence < 4; sequence++ {
+		ibcPackage, err := keeper.GetIBCPackage(ctx, destChainName, channelName, sequence)
+		require.NoError(t, err)
+		require.Equal(t, sequence > 2, ibcPackage != nil)
+	}
+
+	// the watermark does not go past the packages sent
+	watermark, confirmed = keeper.ConfirmPackageDelivery(ctx, destChainID, channelID)
+	require.True(t, confirmed)
+	require.EqualValues(t, 3, watermark.Sequence)
+	_, confirmed = keeper.ConfirmPackageDelivery(ctx, destChainID, channelID)
+	require.False(t, confirmed)
+	recorded, _ = keeper.GetDeliveryWatermark(ctx, destChainName, channelName)
+	require.EqualValues(t, 3, recorded.Sequence)
+}
+
+func TestRelayFee(t *testing.T) {
+	ctx, keeper, ck := createRefundTestInput(t)
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x02)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, []byte{0x01})
+	keeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x01}), Params{RelayerFee: DefaultRelayerFeeParam})
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+
+	payer := sdk.AccAddress([]byte("payer_______________"))
+	relayer := sdk.AccAddress([]byte("relayer_____________"))
+	fee := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e6)}
+	ck.SetCoins(ctx, payer, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 3e6)})
+
+	escrow := &RelayFeeEscrow{Payer: payer, Fee: fee}
+	_, err := keeper.CreateIBCSyncPackage(ctx, destChainName, channelName, []byte{0x00}, escrow)
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+
+	upgrades := []string{sdk.IBCRelayFeeEscrow, sdk.IBCDeliveryWatermark}
+	for _, upgrade := range upgrades {
+		sdk.UpgradeMgr.AddUpgradeHeight(upgrade, 2)
+	}
+	defer func() {
+		for _, upgrade := range upgrades {
+			delete(sdk.UpgradeMgr.Config.HeightMap, upgrade)
+		}
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	sdk.UpgradeMgr.SetHeight(2)
+
+	_, err = keeper.CreateIBCSyncPackage(ctx, destChainName, channelName, []byte{0x00}, &RelayFeeEscrow{Payer: payer})
+	require.Equal(t, CodeInvalidRelayFee, err.Code())
+	// the package of sequence 0 has no relay fee
+	sequence, err := keeper.CreateIBCSyncPackage(ctx, destChainName, channelName, []byte{0x00}, nil)
+	require.Nil(t, err)
+	require.EqualValues(t, 0, sequence)
+	for i := 1; i <= 3; i++ {
+		sequence, err := keeper.CreateIBCSyncPackage(ctx, destChainName, channelName, []byte{byte(i)}, escrow)
+		require.Nil(t, err)
+		require.EqualValues(t, i, sequence)
+	}
+	// a package the payer can not afford is not written
+	_, err = keeper.CreateIBCSyncPackage(ctx, destChainName, channelName, []byte{0x04}, escrow)
+	require.NotNil(t, err)
+	require.EqualValues(t, 4, keeper.sideKeeper.GetSendSequence(ctx, destChainID, channelID))
+	require.True(t, ck.GetCoins(ctx, payer).IsZero())
+	require.Equal(t, int64(3e6), ck.GetCoins(ctx, RelayFeeEscrowAccount).AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(3e6), keeper.GetChannelRelayFees(ctx, destChainID, channelID).AmountOf(sdk.NativeTokenSymbol))
+
+	// the ack of a package without relay fee pays nothing
+	_, paid, err := keeper.PayRelayFee(ctx, destChainID, channelID, 0, relayer)
+	require.Nil(t, err)
+	require.False(t, paid)
+	require.True(t, ck.GetCoins(ctx, relayer).IsZero())
+
+	// only the fee of the acknowledged package is paid
+	paidEscrow, paid, err := keeper.PayRelayFee(ctx, destChainID, channelID, 2, relayer)
+	require.Nil(t, err)
+	require.True(t, paid)
+	require.Equal(t, *escrow, paidEscrow)
+	_, found := keeper.GetRelayFeeEscrow(ctx, destChainID, channelID, 2)
+	require.False(t, found)
+	_, found = keeper.GetRelayFeeEscrow(ctx, destChainID, channelID, 1)
+	require.True(t, found)
+	require.Equal(t, fee, ck.GetCoins(ctx, relayer))
+	_, paid, err = keeper.PayRelayFee(ctx, destChainID, channelID, 2, relayer)
+	require.Nil(t, err)
+	require.False(t, paid)
+
+	for _, sequence := range []uint64{1, 3} {
+		_, paid, err = keeper.PayRelayFee(ctx, destChainID, channelID, sequence, relayer)
+		require.Nil(t, err)
+		require.True(t, paid)
+	}
+	require.Equal(t, int64(3e6), ck.GetCoins(ctx, relayer).AmountOf(sdk.NativeTokenSymbol))
+	require.True(t, keeper.GetChannelRelayFees(ctx, destChainID, channelID).IsZero())
+	require.Empty(t, keeper.GetAllChannelRelayFees(ctx))
+
+	EndBlocker(ctx, keeper)
+	var paidSequences []string
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == EventTypeRelayFeePaid {
+			paidSequences = append(paidSequences, eventAttribute(event, AttributeKeySequence))
+			require.Equal(t, relayer.String(), eventAttribute(event, AttributeKeyRelayer))
+		}
+	}
+	require.Equal(t, []string{"2", "1", "3"}, paidSequences)
+}
+
+func eventAttribute(event sdk.Event, key string) string {
+	for _, attr := range event.Attributes {
+		if string(attr.Key) == key {
+			return string(attr.Value)
+		}
+	}
+	return ""
+}
+
+func TestPackageEvents(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+
+	for i := 0; i < 3; i++ {
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{byte(i)}, *big.NewInt(100))
+		require.NoError(t, err)
+	}
+	keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{Sequence: 1})
+	// nothing left to clean up
+	keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{Sequence: 1})
+
+	EndBlocker(ctx, keeper)
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 5)
+	require.Equal(t, ibcEventType, events[0].Type)
+	for i, event := range events[1:4] {
+		require.Equal(t, EventTypePackageCreated, event.Type)
+		require.Equal(t, "15", eventAttribute(event, AttributeKeyChainID))
+		require.Equal(t, "1", eventAttribute(event, AttributeKeyChannelID))
+		require.Equal(t, strconv.Itoa(i), eventAttribute(event, AttributeKeySequence))
+		require.Equal(t, strconv.Itoa(int(sdk.SynCrossChainPackageType)), eventAttribute(event, AttributeKeyPackageType))
+	}
+	ibcPackage, err := keeper.GetIBCPackage(ctx, destChainName, channelName, 2)
+	require.NoError(t, err)
+	require.Equal(t, PayloadHash(ibcPackage), eventAttribute(events[3], AttributeKeyPayloadHash))
+	require.Equal(t, EventTypePackageCleanup, events[4].Type)
+	require.Equal(t, "1", eventAttribute(events[4], AttributeKeySequence))
+
+	// events are emitted once
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	EndBlocker(ctx, keeper)
+	require.Len(t, ctx.EventManager().Events(), 0)
+
+	// nothing is collected outside of deliver tx
+	checkCtx := ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	_, err = keeper.CreateRawIBCPackage(checkCtx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x03}, *big.NewInt(100))
+	require.NoError(t, err)
+	EndBlocker(ctx, keeper)
+	require.Len(t, ctx.EventManager().Events(), 0)
+}
+
 func createTestCodec() *codec.Codec {
 	cdc := codec.New()
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
 	return cdc
 }
+
+func TestChannelStats(t *testing.T) {
+	destChainName, destChainID := "bsc", sdk.ChainID(0x000f)
+	channelName, channelID := "transfer", sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	fee := big.NewInt(100)
+
+	// nothing is tracked before the upgrade
+	_, err := keeper.CreateRawIBCPackage(ctx.WithBlockHeight(1), destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x00}, *fee)
+	require.NoError(t, err)
+	require.Empty(t, keeper.GetAllChannelStats(ctx))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCChannelStats, 2)
+	defer sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCChannelStats, 0)
+	sdk.UpgradeMgr.SetHeight(2)
+	for height := int64(2); height <= 3; height++ {
+		_, err = keeper.CreateRawIBCPackage(ctx.WithBlockHeight(height), destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x00}, *fee)
+		require.NoError(t, err)
+	}
+	stats := keeper.GetChannelStats(ctx, destChainID, channelID)
+	require.EqualValues(t, 2, stats.Created)
+	require.True(t, stats.AverageLatency().IsZero())
+
+	// the package created before the upgrade is delivered without a latency
+	keeper.CleanupIBCPackage(ctx.WithBlockHeight(7), destChainName, channelName, DeliveryWatermark{Sequence: 1})
+	keeper.CleanupIBCPackage(ctx.WithBlockHeight(9), destChainName, channelName, DeliveryWatermark{Sequence: 2})
+	keeper.RecordPackageFailed(ctx, destChainID, channelID)
+
+	stats = keeper.GetChannelStats(ctx, destChainID, channelID)
+	require.EqualValues(t, 3, stats.Delivered)
+	require.EqualValues(t, 1, stats.Failed)
+	require.EqualValues(t, 5+6, stats.TotalLatency)
+	require.EqualValues(t, 2, stats.LatencySamples)
+	require.Equal(t, sdk.NewDecWithoutFra(11).Quo(sdk.NewDecWithoutFra(2)), stats.AverageLatency())
+
+	querier := NewQuerier(keeper, createTestCodec())
+	bz, sdkErr := querier(ctx, []string{QueryChannelStats}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var results []ChannelStatsResult
+	require.NoError(t, createTestCodec().UnmarshalJSON(bz, &results))
+	require.Len(t, results, 1)
+	require.Equal(t, stats, results[0].Stats)
+	require.Equal(t, "550000000", results[0].AverageLatency.String()) // 5.5 blocks
+}
+
+func TestChanSenders(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	fee := big.NewInt(100)
+
+	bridge, other := keeper.ForModule("bridge"), keeper.ForModule("stake")
+	// any module may send without senders
+	_, err := other.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x00}, *fee)
+	require.NoError(t, err)
+
+	keeper.SetChanSenders(ctx, ChanSenders{ChannelID: channelID, Modules: []string{"bridge"}})
+	senders, found := keeper.GetChanSenders(ctx, channelID)
+	require.True(t, found)
+	require.Equal(t, []string{"bridge"}, senders.Modules)
+	// the senders are not checked before the upgrade
+	_, err = other.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x01}, *fee)
+	require.NoError(t, err)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ChannelSenders, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ChannelSenders)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	_, err = bridge.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x01}, *fee)
+	require.NoError(t, err)
+	_, err = other.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x02}, *fee)
+	require.NotNil(t, err)
+	require.Equal(t, CodeSenderNotAllowed, err.Code())
+	// the acks are not restricted, the syn packages must be sent through the keeper of a module
+	_, err = other.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.AckCrossChainPackageType, []byte{0x03}, *fee)
+	require.NoError(t, err)
+	_, err = keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x04}, *fee)
+	require.NotNil(t, err)
+	require.Equal(t, CodeSenderNotAllowed, err.Code())
+
+	// empty senders lift the restriction
+	keeper.SetChanSenders(ctx, ChanSenders{ChannelID: channelID})
+	_, found = keeper.GetChanSenders(ctx, channelID)
+	require.False(t, found)
+	_, err = other.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x05}, *fee)
+	require.NoError(t, err)
+
+	require.NotNil(t, ChanSenders{ChannelID: channelID, Modules: []string{"bridge", "bridge"}}.Check())
+	require.NotNil(t, ChanSenders{ChannelID: channelID, Modules: []string{""}}.Check())
+}
+
+func TestInternalChannelSenders(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "nft"
+	channelID := sdk.ChannelID(0x20)
+
+	ctx, keeper := createTestInput(t, false)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.AddChannel(ctx, sTypes.ChannelRegistration{Name: channelName,
+		ChannelId: channelID, SideChainId: destChainName, Scope: sTypes.ChannelScopeInternal}))
+	fee := big.NewInt(100)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CrossChainRegistry, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.CrossChainRegistry)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// the internal channels are checked before ChannelSenders, no module may send without senders
+	bridge, other := keeper.ForModule("bridge"), keeper.ForModule("stake")
+	_, err := bridge.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x00}, *fee)
+	require.NotNil(t, err)
+	require.Equal(t, CodeSenderNotAllowed, err.Code())
+
+	keeper.SetChanSenders(ctx, ChanSenders{ChannelID: channelID, Modules: []string{"bridge"}})
+	_, err = bridge.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x01}, *fee)
+	require.NoError(t, err)
+	_, err = other.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x02}, *fee)
+	require.NotNil(t, err)
+	require.Equal(t, CodeSenderNotAllowed, err.Code())
+	_, err = keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{0x03}, *fee)
+	require.NotNil(t, err)
+	require.Equal(t, CodeSenderNotAllowed, err.Code())
+}
+
+func TestBroadcastIBCSyncPackage(t *testing.T) {
+	channelName := "params"
+	channelID := sdk.ChannelID(0x09)
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+
+	chains := []struct {
+		name   string
+		id     sdk.ChainID
+		prefix []byte
+	}{{"bsc", sdk.ChainID(0x000f), []byte{0x01}}, {"other", sdk.ChainID(0x0010), []byte{0x02}}}
+	for _, chain := range chains {
+		require.NoError(t, keeper.sideKeeper.RegisterDestChain(chain.name, chain.id))
+		keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, chain.name, chain.prefix)
+		keeper.SetParams(ctx.WithSideChainKeyPrefix(chain.prefix), Params{RelayerFee: DefaultRelayerFeeParam})
+		keeper.sideKeeper.SetChannelSendPermission(ctx, chain.id, channelID, sdk.ChannelAllow)
+	}
+	// bsc has already been sent a package
+	_, err := keeper.CreateIBCSyncPackage(ctx, "bsc", channelName, []byte{0x00}, nil)
+	require.NoError(t, err)
+
+	destinations := keeper.SideChainDestinations(ctx, channelName)
+	require.Len(t, destinations, 2)
+	results := keeper.BroadcastIBCSyncPackage(ctx, destinations, []byte{0x01})
+	require.Nil(t, results.Err())
+	require.Len(t, results, 2)
+	for i, result := range results {
+		require.Equal(t, destinations[i], result.Destination)
+		chainID, _ := keeper.sideKeeper.GetDestChainID(result.Destination.ChainName)
+		pkg, _ := keeper.GetIBCPackageById(ctx, chainID, channelID, result.Sequence)
+		require.Equal(t, byte(0x01), pkg[len(pkg)-1])
+	}
+	require.EqualValues(t, 1, results[0].Sequence)
+	require.EqualValues(t, 0, results[1].Sequence)
+
+	// a destination failing does not stop the others
+	keeper.sideKeeper.SetChannelSendPermission(ctx, chains[0].id, channelID, sdk.ChannelForbidden)
+	results = keeper.BroadcastIBCSyncPackage(ctx, append(destinations, Destination{"btc", channelName}), []byte{0x02})
+	require.Len(t, results.Failed(), 2)
+	require.Nil(t, results[1].Err)
+	require.EqualValues(t, 1, results[1].Sequence)
+	err = results.Err()
+	require.NotNil(t, err)
+	require.Equal(t, CodeWritePackageForbidden, err.Code())
+	require.Contains(t, err.Error(), "btc/params")
+}
+
+func TestPackageCompression(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+	prefix := []byte{0x01}
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, prefix)
+	keeper.SetParams(ctx.WithSideChainKeyPrefix(prefix), Params{
+		RelayerFee:           DefaultRelayerFeeParam,
+		PackageCompression:   sTypes.CompressionSnappy,
+		CompressionThreshold: 64,
+	})
+	fee := big.NewInt(100)
+	large := bytes.Repeat([]byte("transfer"), 64)
+
+	// nothing is compressed before the upgrade
+	sequence, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, large, *fee)
+	require.NoError(t, err)
+	pkg, _ := keeper.GetIBCPackageById(ctx, destChainID, channelID, sequence)
+	require.Equal(t, large, pkg[sTypes.PackageHeaderLength:])
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCLoadCompression, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.IBCLoadCompression)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	sequence, err = keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, large, *fee)
+	require.NoError(t, err)
+	pkg, _ = keeper.GetIBCPackageById(ctx, destChainID, channelID, sequence)
+	require.True(t, len(pkg) < sTypes.PackageHeaderLength+len(large))
+	packageType, relayFee, load, loadErr := keeper.GetIBCPackageLoadById(ctx, destChainID, channelID, sequence)
+	require.NoError(t, loadErr)
+	require.Equal(t, sdk.SynCrossChainPackageType, packageType)
+	require.Equal(t, fee.Int64(), relayFee.Int64())
+	require.Equal(t, large, load)
+
+	// the loads below the threshold are left as they are
+	small := []byte{0x00, 0x01}
+	sequence, err = keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, small, *fee)
+	require.NoError(t, err)
+	pkg, _ = keeper.GetIBCPackageById(ctx, destChainID, channelID, sequence)
+	require.Equal(t, sTypes.EncodePackageHeader(sdk.SynCrossChainPackageType, *fee), pkg[:sTypes.PackageHeaderLength])
+	require.Equal(t, small, pkg[sTypes.PackageHeaderLength:])
+
+	_, _, _, loadErr = keeper.GetIBCPackageLoadById(ctx, destChainID, channelID, sequence+1)
+	require.Error(t, loadErr)
+
+	// the loads are never compressed with gzip, whose output depends on the Go version
+	params := Params{
+		RelayerFee:           DefaultRelayerFeeParam,
+		PackageCompression:   sTypes.CompressionGzip,
+		CompressionThreshold: 64,
+	}
+	require.Error(t, params.UpdateCheck())
+	keeper.SetParams(ctx.WithSideChainKeyPrefix(prefix), params)
+	sequence, err = keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, large, *fee)
+	require.NoError(t, err)
+	pkg, _ = keeper.GetIBCPackageById(ctx, destChainID, channelID, sequence)
+	require.Equal(t, large, pkg[sTypes.PackageHeaderLength:])
+}
+
+func TestIteratePendingPackages(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+
+	for i := 0; i < 5; i++ {
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{byte(i)}, *big.NewInt(100))
+		require.Nil(t, err)
+	}
+	require.Nil(t, keeper.CleanupIBCPackage(ctx, destChainName, channelName, DeliveryWatermark{Sequence: 0}))
+
+	var sequences []uint64
+	require.NoError(t, keeper.IteratePendingPackages(ctx, destChainName, channelName, 0, 0, func(pack PendingPackage) bool {
+		sequences = append(sequences, pack.Sequence)
+		return false
+	}))
+	require.Equal(t, []uint64{1, 2, 3, 4}, sequences)
+
+	sequences = nil
+	require.NoError(t, keeper.IteratePendingPackages(ctx, destChainName, channelName, 2, 1, func(pack PendingPackage) bool {
+		sequences = append(sequences, pack.Sequence)
+		payload, _ := keeper.GetIBCPackage(ctx, destChainName, channelName, pack.Sequence)
+		require.Equal(t, payload, pack.Payload)
+		return false
+	}))
+	require.Equal(t, []uint64{2}, sequences)
+	require.Error(t, keeper.IteratePendingPackages(ctx, "unknown", channelName, 0, 0, func(PendingPackage) bool { return false }))
+
+	cdc := createTestCodec()
+	querier := NewQuerier(keeper, cdc)
+	query := func(from uint64, limit int) (PendingPackagesPage, sdk.Error) {
+		bz, err := cdc.MarshalJSON(QueryPendingPackagesParams{DestChainName: destChainName, ChannelName: channelName, FromSequence: from, Limit: limit})
+		require.NoError(t, err)
+		res, sdkErr := querier(ctx, []string{QueryPendingPackages}, abci.RequestQuery{Data: bz})
+		var page PendingPackagesPage
+		if sdkErr == nil {
+			require.NoError(t, cdc.UnmarshalJSON(res, &page))
+		}
+		return page, sdkErr
+	}
+
+	page, sdkErr := query(0, 3)
+	require.Nil(t, sdkErr)
+	require.Len(t, page.Packages, 3)
+	require.True(t, page.HasNext)
+	require.EqualValues(t, 4, page.Next)
+	page, sdkErr = query(page.Next, 3)
+	require.Nil(t, sdkErr)
+	require.Len(t, page.Packages, 1)
+	require.EqualValues(t, 4, page.Packages[0].Sequence)
+	require.False(t, page.HasNext)
+	_, sdkErr = query(0, MaxPendingPackagesPageLimit+1)
+	require.NotNil(t, sdkErr)
+}
+
+type testPackageHandler struct {
+	storeKey sdk.StoreKey
+}
+
+func (h testPackageHandler) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	ctx.KVStore(h.storeKey).Set([]byte("received"), payload)
+	switch string(payload) {
+	case "panic":
+		panic("test panic")
+	case "fail":
+		return sdk.ExecuteResult{Err: sdk.ErrInternal("test failure")}
+	}
+	return sdk.ExecuteResult{Payload: append([]byte("ack:"), payload...)}
+}
+
+func (h testPackageHandler) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (h testPackageHandler) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func TestReceivePackage(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelID := sdk.ChannelID(0x01)
+	ctx, keeper, _ := createRefundTestInput(t)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	// the channel is registered without application, as the ones registered by governance
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("transfer", channelID, nil))
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, []byte{0x01})
+	keeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x01}), Params{RelayerFee: DefaultRelayerFeeParam})
+
+	synPackage := func(load string) []byte {
+		return append(sTypes.EncodePackageHeader(sdk.SynCrossChainPackageType, *big.NewInt(10)), load...)
+	}
+	receive := func(load string) (PackageReceipt, sdk.Error) {
+		sequence := keeper.sideKeeper.GetReceiveSequence(ctx, destChainID, channelID)
+		pack, err := keeper.CheckReceivedPackage(ctx, destChainID, channelID, sequence, synPackage(load))
+		if err != nil {
+			return PackageReceipt{}, err
+		}
+		require.Equal(t, sdk.SynCrossChainPackageType, pack.Type)
+		require.EqualValues(t, 10, pack.RelayFee)
+		require.Equal(t, []byte(load), pack.Load)
+		keeper.sideKeeper.IncrReceiveSequence(ctx, destChainID, channelID)
+		return keeper.DispatchPackage(ctx, pack)
+	}
+
+	_, err := receive("hello")
+	require.Equal(t, CodeNoPackageHandler, err.Code())
+
+	handler := testPackageHandler{storeKey: keeper.storeKey}
+	require.NoError(t, keeper.RegisterPackageHandler(channelID, handler))
+	require.Error(t, keeper.RegisterPackageHandler(channelID, handler))
+
+	_, err = keeper.CheckReceivedPackage(ctx, destChainID, channelID, 1, synPackage("hello"))
+	require.Equal(t, CodeInvalidPackage, err.Code())
+	_, err = keeper.CheckReceivedPackage(ctx, destChainID, channelID, 0, []byte{0x00})
+	require.Equal(t, CodeInvalidPackage, err.Code())
+
+	// a successful syn package is answered with the payload of its result
+	receipt, err := receive("hello")
+	require.Nil(t, err)
+	require.True(t, receipt.Result.IsOk())
+	require.False(t, receipt.Crash)
+	require.EqualValues(t, 0, receipt.AckSequence)
+	ackType, _, load, decodeErr := keeper.GetIBCPackageLoadById(ctx, destChainID, channelID, 0)
+	require.NoError(t, decodeErr)
+	require.Equal(t, sdk.AckCrossChainPackageType, ackType)
+	require.Equal(t, []byte("ack:hello"), load)
+	require.Equal(t, []byte("hello"), ctx.KVStore(keeper.storeKey).Get([]byte("received")))
+
+	// the changes of a failed package are discarded, no ack is written without payload
+	receipt, err = receive("fail")
+	require.Nil(t, err)
+	require.False(t, receipt.Result.IsOk())
+	require.EqualValues(t, -1, receipt.AckSequence)
+	require.Equal(t, []byte("hello"), ctx.KVStore(keeper.storeKey).Get([]byte("received")))
+
+	// a crashed syn package is answered with a fail ack carrying its load
+	receipt, err = receive("panic")
+	require.Nil(t, err)
+	require.True(t, receipt.Crash)
+	require.EqualValues(t, 1, receipt.AckSequence)
+	ackType, _, load, decodeErr = keeper.GetIBCPackageLoadById(ctx, destChainID, channelID, 1)
+	require.NoError(t, decodeErr)
+	require.Equal(t, sdk.FailAckCrossChainPackageType, ackType)
+	require.Equal(t, []byte("panic"), load)
+	require.EqualValues(t, 3, keeper.sideKeeper.GetReceiveSequence(ctx, destChainID, channelID))
+}
@@ -13,11 +13,27 @@ const (
 	channelIDLength       = 1
 	sequenceLength        = 8
 	totalPackageKeyLength = prefixLength + srcChainIdLength + destChainIDLength + channelIDLength + sequenceLength
+
+	expireTimeLength     = 8
+	refundIDLength       = destChainIDLength + channelIDLength + sequenceLength
+	totalRefundKeyLength = prefixLength + refundIDLength
+	totalExpireKeyLength = prefixLength + expireTimeLength + refundIDLength
 )
 
 var (
 	PrefixForIbcPackageKey = []byte{0x00}
 	PrefixForSequenceKey   = []byte{0x01}
+	PrefixForRefundKey     = []byte{0x02}
+	PrefixForExpireKey     = []byte{0x03}
+
+	PrefixForChannelStatsKey  = []byte{0x04}
+	PrefixForCreatedHeightKey = []byte{0x05}
+	PrefixForChanSendersKey   = []byte{0x06}
+	PrefixForRelayFeeKey      = []byte{0x07}
+	PrefixForRelayFeeTotalKey = []byte{0x08}
+	PrefixForWatermarkKey     = []byte{0x09}
+	PrefixForBlockPackagesKey = []byte{0x0a}
+	PrefixForRefundedKey      = []byte{0x0b}
 )
 
 func buildIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
@@ -41,4 +57,83 @@ func buildIBCPackageKeyPrefix(srcChainID, destChainID sdk.ChainID, channelID sdk
 	copy(key[prefixLength+srcChainIdLength+destChainIDLength:], []byte{byte(channelID)})
 
 	return key
-}
\ No newline at end of file
+}
+func putRefundID(bz []byte, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	binary.BigEndian.PutUint16(bz[:destChainIDLength], uint16(destChainID))
+	copy(bz[destChainIDLength:], []byte{byte(channelID)})
+	binary.BigEndian.PutUint64(bz[destChainIDLength+channelIDLength:], sequence)
+}
+
+func buildRefundKey(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, totalRefundKeyLength)
+
+	copy(key[:prefixLength], PrefixForRefundKey)
+	putRefundID(key[prefixLength:], destChainID, channelID, sequence)
+
+	return key
+}
+
+func buildRefundedKey(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := buildRefundKey(destChainID, channelID, sequence)
+	copy(key[:prefixLength], PrefixForRefundedKey)
+	return key
+}
+
+func buildExpireKey(expireTime int64, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, totalExpireKeyLength)
+
+	copy(key[:prefixLength], PrefixForExpireKey)
+	binary.BigEndian.PutUint64(key[prefixLength:prefixLength+expireTimeLength], uint64(expireTime))
+	putRefundID(key[prefixLength+expireTimeLength:], destChainID, channelID, sequence)
+
+	return key
+}
+
+// buildExpireEndKey returns the exclusive end key of all refunds expiring at or before expireTime
+func buildExpireEndKey(expireTime int64) []byte {
+	key := make([]byte, prefixLength+expireTimeLength)
+
+	copy(key[:prefixLength], PrefixForExpireKey)
+	binary.BigEndian.PutUint64(key[prefixLength:], uint64(expireTime+1))
+
+	return key
+}
+
+func parseExpireKey(key []byte) (destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	id := key[prefixLength+expireTimeLength:]
+	destChainID = sdk.ChainID(binary.BigEndian.Uint16(id[:destChainIDLength]))
+	channelID = sdk.ChannelID(id[destChainIDLength])
+	sequence = binary.BigEndian.Uint64(id[destChainIDLength+channelIDLength:])
+	return
+}
+
+func buildRelayFeeKey(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, totalRefundKeyLength)
+
+	copy(key[:prefixLength], PrefixForRelayFeeKey)
+	putRefundID(key[prefixLength:], destChainID, channelID, sequence)
+
+	return key
+}
+
+func buildRelayFeeChannelPrefix(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	return buildRelayFeeKey(destChainID, channelID, 0)[:prefixLength+destChainIDLength+channelIDLength]
+}
+
+func buildRelayFeeTotalKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := buildRelayFeeChannelPrefix(destChainID, channelID)
+	copy(key[:prefixLength], PrefixForRelayFeeTotalKey)
+	return key
+}
+
+func buildDeliveryWatermarkKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := buildRelayFeeChannelPrefix(destChainID, channelID)
+	copy(key[:prefixLength], PrefixForWatermarkKey)
+	return key
+}
+
+func buildBlockPackagesKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := buildRelayFeeChannelPrefix(destChainID, channelID)
+	copy(key[:prefixLength], PrefixForBlockPackagesKey)
+	return key
+}
@@ -0,0 +1,86 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// getChannelLimit returns the limits of the syn packages sent on a channel to a side chain, set by the ibc params
+// of the side chain
+func (k Keeper) getChannelLimit(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (ChannelLimit, bool) {
+	destChainName, err := k.sideKeeper.LookupDestChainName(ctx, destChainID)
+	if err != nil {
+		return ChannelLimit{}, false
+	}
+	storePrefix := k.sideKeeper.GetSideChainStorePrefix(ctx, destChainName)
+	if storePrefix == nil {
+		return ChannelLimit{}, false
+	}
+
+	var limits []ChannelLimit
+	k.paramSpace.GetIfExists(ctx.WithSideChainKeyPrefix(storePrefix), ParamChannelLimits, &limits)
+	for _, limit := range limits {
+		if limit.ChannelID == channelID {
+			return limit, true
+		}
+	}
+	return ChannelLimit{}, false
+}
+
+// checkChannelLimit checks that a syn package of loadLength sent with sequence is within the limits of its channel,
+// and counts it in the packages sent in the block
+func (k *Keeper) checkChannelLimit(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, loadLength int) sdk.Error {
+	if !sdk.IsUpgrade(sdk.IBCChannelLimits) {
+		return nil
+	}
+	limit, found := k.getChannelLimit(ctx, destChainID, channelID)
+	if !found {
+		return nil
+	}
+
+	if limit.MaxPackageSize > 0 && int64(loadLength) > limit.MaxPackageSize {
+		return ErrChannelLimitExceeded(k.codespace, fmt.Sprintf("package of %d bytes exceeds the max package size %d of channel %d",
+			loadLength, limit.MaxPackageSize, channelID))
+	}
+
+	if limit.MaxPendingPackages > 0 {
+		iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey),
+			buildIBCPackageKeyPrefix(k.sideKeeper.GetSrcChainID(), destChainID, channelID))
+		defer iterator.Close()
+		if iterator.Valid() {
+			oldest := binary.BigEndian.Uint64(iterator.Key()[totalPackageKeyLength-sequenceLength:])
+			if pending := sequence - oldest; pending >= uint64(limit.MaxPendingPackages) {
+				return ErrChannelLimitExceeded(k.codespace, fmt.Sprintf("%d packages of channel %d are pending, the max is %d",
+					pending, channelID, limit.MaxPendingPackages))
+			}
+		}
+	}
+
+	if limit.MaxPackagesPerBlock > 0 {
+		sent := k.getBlockPackages(ctx, destChainID, channelID)
+		if sent >= uint64(limit.MaxPackagesPerBlock) {
+			return ErrChannelLimitExceeded(k.codespace, fmt.Sprintf("%d packages are sent on channel %d in the block, the max is %d",
+				sent, channelID, limit.MaxPackagesPerBlock))
+		}
+		k.setBlockPackages(ctx, destChainID, channelID, sent+1)
+	}
+	return nil
+}
+
+// getBlockPackages returns the number of packages sent on a channel in the current block
+func (k *Keeper) getBlockPackages(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(buildBlockPackagesKey(destChainID, channelID))
+	if bz == nil || int64(binary.BigEndian.Uint64(bz[:8])) != ctx.BlockHeight() {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz[8:])
+}
+
+func (k *Keeper) setBlockPackages(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sent uint64) {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz[:8], uint64(ctx.BlockHeight()))
+	binary.BigEndian.PutUint64(bz[8:], sent)
+	ctx.KVStore(k.storeKey).Set(buildBlockPackagesKey(destChainID, channelID), bz)
+}
@@ -3,26 +3,49 @@ package ibc
 import (
 	"fmt"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
 )
 
 const (
 	DefaultRelayerFeeParam int64 = 1e6 // decimal is 8
+	// DefaultCompressionThreshold is the load length from which the packages are compressed by default
+	DefaultCompressionThreshold int64 = 1024
 	// Default parameter namespace
 	DefaultParamspace = "ibc"
 )
 
 var (
-	ParamRelayerFee = []byte("relayerFee")
+	ParamRelayerFee           = []byte("relayerFee")
+	ParamPackageCompression   = []byte("packageCompression")
+	ParamCompressionThreshold = []byte("compressionThreshold")
+	ParamChannelLimits        = []byte("channelLimits")
 )
 
 type Params struct {
 	RelayerFee int64 `json:"relayer_fee"`
+
+	PackageCompression   sTypes.CompressionType `json:"package_compression"`   // the loads sent to the side chain are compressed with, none by default
+	CompressionThreshold int64                  `json:"compression_threshold"` // the loads shorter than this are not compressed
+
+	ChannelLimits []ChannelLimit `json:"channel_limits"` // limits of the syn packages sent on the channels, none by default
+}
+
+// ChannelLimit limits the syn packages the modules send on a channel to the side chain, a zero limit is no limit
+type ChannelLimit struct {
+	ChannelID           sdk.ChannelID `json:"channel_id"`
+	MaxPackageSize      int64         `json:"max_package_size"`       // max length of a package load, before compression
+	MaxPackagesPerBlock int64         `json:"max_packages_per_block"` // max packages sent in a block
+	MaxPendingPackages  int64         `json:"max_pending_packages"`   // max packages sent and not cleaned up yet
 }
 
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
 	return params.KeyValuePairs{
 		{ParamRelayerFee, &p.RelayerFee},
+		{ParamPackageCompression, &p.PackageCompression},
+		{ParamCompressionThreshold, &p.CompressionThreshold},
+		{ParamChannelLimits, &p.ChannelLimits},
 	}
 }
 
@@ -30,6 +53,25 @@ func (p *Params) UpdateCheck() error {
 	if p.RelayerFee <= 0 {
 		return fmt.Errorf("the syn_package_fee should be greater than 0")
 	}
+	if !p.PackageCompression.IsValid() {
+		return fmt.Errorf("unknown package compression %d", p.PackageCompression)
+	}
+	if !p.PackageCompression.IsStable() {
+		return fmt.Errorf("the package_compression %s is not stable across builds", p.PackageCompression)
+	}
+	if p.CompressionThreshold < 0 || p.CompressionThreshold > sTypes.MaxDecompressedLoadLength {
+		return fmt.Errorf("the compression_threshold should be in range 0 to %d", sTypes.MaxDecompressedLoadLength)
+	}
+	channels := make(map[sdk.ChannelID]bool, len(p.ChannelLimits))
+	for _, limit := range p.ChannelLimits {
+		if channels[limit.ChannelID] {
+			return fmt.Errorf("duplicated limits of channel %d", limit.ChannelID)
+		}
+		channels[limit.ChannelID] = true
+		if limit.MaxPackageSize < 0 || limit.MaxPackagesPerBlock < 0 || limit.MaxPendingPackages < 0 {
+			return fmt.Errorf("the limits of channel %d should not be negative", limit.ChannelID)
+		}
+	}
 	return nil
 }
 
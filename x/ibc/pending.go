@@ -0,0 +1,45 @@
+package ibc
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PendingPackage is an outbound package of a channel which is not cleaned up yet
+type PendingPackage struct {
+	Sequence uint64 `json:"sequence"`
+	Payload  []byte `json:"payload"`
+}
+
+// IteratePendingPackages calls cb with the packages of a channel which are not cleaned up yet, in the order of
+// their sequences, starting from fromSequence. It stops once cb returns true or limit packages are iterated,
+// a limit of 0 iterates all of them.
+func (k *Keeper) IteratePendingPackages(ctx sdk.Context, destChainName string, channelName string, fromSequence uint64,
+	limit int, cb func(pack PendingPackage) (stop bool)) error {
+	destChainID, err := k.sideKeeper.LookupDestChainID(ctx, destChainName)
+	if err != nil {
+		return err
+	}
+	channelID, err := k.sideKeeper.LookupChannelID(ctx, channelName)
+	if err != nil {
+		return err
+	}
+
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	iterator := ctx.KVStore(k.storeKey).Iterator(
+		buildIBCPackageKey(srcChainID, destChainID, channelID, fromSequence),
+		sdk.PrefixEndBytes(buildIBCPackageKeyPrefix(srcChainID, destChainID, channelID)))
+	defer iterator.Close()
+	for i := 0; iterator.Valid() && (limit == 0 || i < limit); iterator.Next() {
+		pack := PendingPackage{
+			Sequence: binary.BigEndian.Uint64(iterator.Key()[totalPackageKeyLength-sequenceLength:]),
+			Payload:  iterator.Value(),
+		}
+		if cb(pack) {
+			break
+		}
+		i++
+	}
+	return nil
+}
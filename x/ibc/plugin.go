@@ -0,0 +1,38 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+func RegisterUpgradeBeginBlocker(k Keeper) {
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.IBCLoadCompression, func(ctx sdk.Context) {
+		_, prefixes := k.sideKeeper.GetAllSideChainPrefixes(ctx)
+		for _, prefix := range prefixes {
+			k.initCompressionParams(ctx.WithSideChainKeyPrefix(prefix))
+		}
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.IBCChannelLimits, func(ctx sdk.Context) {
+		_, prefixes := k.sideKeeper.GetAllSideChainPrefixes(ctx)
+		for _, prefix := range prefixes {
+			k.initChannelLimitsParams(ctx.WithSideChainKeyPrefix(prefix))
+		}
+	})
+}
+
+// initCompressionParams sets the compression params added by IBCLoadCompression, nothing is compressed by default
+func (k Keeper) initCompressionParams(ctx sdk.Context) {
+	if !k.paramSpace.Has(ctx, ParamPackageCompression) {
+		k.paramSpace.Set(ctx, ParamPackageCompression, sTypes.CompressionNone)
+	}
+	if !k.paramSpace.Has(ctx, ParamCompressionThreshold) {
+		k.paramSpace.Set(ctx, ParamCompressionThreshold, DefaultCompressionThreshold)
+	}
+}
+
+// initChannelLimitsParams sets the channel limits param added by IBCChannelLimits, no channel is limited by default
+func (k Keeper) initChannelLimitsParams(ctx sdk.Context) {
+	if !k.paramSpace.Has(ctx, ParamChannelLimits) {
+		k.paramSpace.Set(ctx, ParamChannelLimits, []ChannelLimit{})
+	}
+}
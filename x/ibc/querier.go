@@ -0,0 +1,115 @@
+package ibc
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryChannelStats    = "channelStats"
+	QueryRelayFees       = "relayFees"
+	QueryPendingPackages = "pendingPackages"
+
+	DefaultPendingPackagesPageLimit = 100
+	MaxPendingPackagesPageLimit     = 1000
+)
+
+// ChannelStatsResult is the stats of a channel with its average delivery latency in blocks
+type ChannelStatsResult struct {
+	Stats          ChannelStats `json:"stats"`
+	AverageLatency sdk.Dec      `json:"average_latency"`
+}
+
+// QueryPendingPackagesParams selects a page of the pending packages of a channel, the page starts at FromSequence
+type QueryPendingPackagesParams struct {
+	DestChainName string `json:"dest_chain_name"`
+	ChannelName   string `json:"channel_name"`
+	FromSequence  uint64 `json:"from_sequence"`
+	Limit         int    `json:"limit"`
+}
+
+// PendingPackagesPage is a page of the pending packages of a channel, Next is the FromSequence of the following
+// page and is only set if more packages are pending
+type PendingPackagesPage struct {
+	Packages []PendingPackage `json:"packages"`
+	HasNext  bool             `json:"has_next"`
+	Next     uint64           `json:"next"`
+}
+
+// creates a querier for ibc REST endpoints
+func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryChannelStats:
+			return queryChannelStats(ctx, cdc, k)
+		case QueryRelayFees:
+			return queryRelayFees(ctx, cdc, k)
+		case QueryPendingPackages:
+			return queryPendingPackages(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+func queryChannelStats(ctx sdk.Context, cdc *codec.Codec, k Keeper) (res []byte, err sdk.Error) {
+	all := k.GetAllChannelStats(ctx)
+	results := make([]ChannelStatsResult, 0, len(all))
+	for _, stats := range all {
+		results = append(results, ChannelStatsResult{Stats: stats, AverageLatency: stats.AverageLatency()})
+	}
+
+	bz, errRes := codec.MarshalJSONIndent(cdc, results)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return bz, nil
+}
+
+func queryRelayFees(ctx sdk.Context, cdc *codec.Codec, k Keeper) (res []byte, err sdk.Error) {
+	bz, errRes := codec.MarshalJSONIndent(cdc, k.GetAllChannelRelayFees(ctx))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return bz, nil
+}
+
+func queryPendingPackages(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryPendingPackagesParams
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+	if params.Limit < 0 || params.Limit > MaxPendingPackagesPageLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("limit should be between 0 and %d", MaxPendingPackagesPageLimit))
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultPendingPackagesPageLimit
+	}
+
+	// one more package is iterated to tell whether a following page exists
+	page := PendingPackagesPage{Packages: make([]PendingPackage, 0, params.Limit)}
+	errRes = k.IteratePendingPackages(ctx, params.DestChainName, params.ChannelName, params.FromSequence, params.Limit+1,
+		func(pack PendingPackage) bool {
+			if len(page.Packages) == params.Limit {
+				page.HasNext = true
+				page.Next = pack.Sequence
+				return true
+			}
+			page.Packages = append(page.Packages, pack)
+			return false
+		})
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(errRes.Error())
+	}
+
+	bz, errRes := codec.MarshalJSONIndent(cdc, page)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return bz, nil
+}
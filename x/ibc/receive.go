@@ -0,0 +1,170 @@
+package ibc
+
+import (
+	"fmt"
+	"math/big"
+	"runtime/debug"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// ReceivedPackage is a package received from a destination chain whose header is decoded
+type ReceivedPackage struct {
+	DestChainID sdk.ChainID
+	ChannelID   sdk.ChannelID
+	Sequence    uint64
+	Type        sdk.CrossChainPackageType
+	RelayFee    int64
+	Load        []byte
+	// Payload is the package as received, with its header
+	Payload []byte
+}
+
+// PackageReceipt is the result of the execution of a received package by the handler of its channel
+type PackageReceipt struct {
+	Result sdk.ExecuteResult
+	// Crash is set if the handler panicked, its changes are discarded
+	Crash bool
+	// AckSequence is the sequence of the ack or fail ack package written for a syn package, -1 if none is written
+	AckSequence int64
+}
+
+// RegisterPackageHandler registers the handler of the packages received on a channel, it takes precedence over
+// the application registered with the channel in the side chain keeper. The channels registered by governance
+// have no application, a module handles the packages of such a channel by registering a handler for it.
+func (k *Keeper) RegisterPackageHandler(channelID sdk.ChannelID, handler sdk.CrossChainApplication) error {
+	if handler == nil {
+		return fmt.Errorf("nil package handler")
+	}
+	if _, ok := k.packageHandlers[channelID]; ok {
+		return fmt.Errorf("package handler of channel %d is registered already", channelID)
+	}
+	k.packageHandlers[channelID] = handler
+	return nil
+}
+
+// GetPackageHandler returns the handler of the packages received on a channel
+func (k *Keeper) GetPackageHandler(ctx sdk.Context, channelID sdk.ChannelID) sdk.CrossChainApplication {
+	if handler, ok := k.packageHandlers[channelID]; ok {
+		return handler
+	}
+	return k.sideKeeper.GetCrossChainApp(ctx, channelID)
+}
+
+// CheckReceivedPackage checks that a package received on a channel has a handler and is the next one expected
+// on the channel, and decodes its header. The caller increases the receive sequence once it is dispatched.
+func (k *Keeper) CheckReceivedPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	sequence uint64, payload []byte) (ReceivedPackage, sdk.Error) {
+	if k.GetPackageHandler(ctx, channelID) == nil {
+		return ReceivedPackage{}, ErrNoPackageHandler(k.codespace, fmt.Sprintf("channel %d has no package handler", channelID))
+	}
+
+	expected := k.sideKeeper.GetReceiveSequence(ctx, destChainID, channelID)
+	if expected != sequence {
+		return ReceivedPackage{}, ErrInvalidPackage(k.codespace, fmt.Sprintf("package %d received on channel %d, expected %d",
+			sequence, channelID, expected))
+	}
+
+	packageType, relayFee, load, err := decodeReceivedPackage(payload)
+	if err != nil {
+		return ReceivedPackage{}, ErrInvalidPackage(k.codespace, err.Error())
+	}
+	if !sdk.IsValidCrossChainPackageType(packageType) {
+		return ReceivedPackage{}, ErrInvalidPackage(k.codespace, fmt.Sprintf("invalid package type %d", packageType))
+	}
+	if !relayFee.IsInt64() || relayFee.Sign() < 0 {
+		return ReceivedPackage{}, ErrInvalidPackage(k.codespace, fmt.Sprintf("invalid relay fee %s", relayFee.String()))
+	}
+
+	return ReceivedPackage{
+		DestChainID: destChainID,
+		ChannelID:   channelID,
+		Sequence:    sequence,
+		Type:        packageType,
+		RelayFee:    relayFee.Int64(),
+		Load:        load,
+		Payload:     payload,
+	}, nil
+}
+
+// DispatchPackage executes a received package by the handler of its channel, the changes of a failed execution
+// are discarded. A syn package is answered with an ack package carrying the payload of the result if any, or with
+// a fail ack package carrying its load if the handler panicked.
+func (k *Keeper) DispatchPackage(ctx sdk.Context, pack ReceivedPackage) (PackageReceipt, sdk.Error) {
+	handler := k.GetPackageHandler(ctx, pack.ChannelID)
+	if handler == nil {
+		return PackageReceipt{}, ErrNoPackageHandler(k.codespace, fmt.Sprintf("channel %d has no package handler", pack.ChannelID))
+	}
+
+	if pack.Type != sdk.SynCrossChainPackageType && k.IsAckOfRefundedPackage(ctx, pack.DestChainID, pack.ChannelID) {
+		ctx.Logger().With("module", "ibc").Info("ignore the ack of a refunded package",
+			"channel", pack.ChannelID, "sequence", pack.Sequence)
+		return PackageReceipt{AckSequence: -1}, nil
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	crash, result := executePackage(cacheCtx, handler, pack)
+	if result.IsOk() {
+		write()
+	}
+
+	receipt := PackageReceipt{Result: result, Crash: crash, AckSequence: -1}
+	if pack.Type != sdk.SynCrossChainPackageType {
+		return receipt, nil
+	}
+
+	var ackType sdk.CrossChainPackageType
+	var ackLoad []byte
+	if crash {
+		ackType, ackLoad = sdk.FailAckCrossChainPackageType, pack.Load
+	} else if len(result.Payload) != 0 {
+		ackType, ackLoad = sdk.AckCrossChainPackageType, result.Payload
+	} else {
+		return receipt, nil
+	}
+	sequence, sdkErr := k.CreateRawIBCPackageById(ctx, pack.DestChainID, pack.ChannelID, ackType, ackLoad)
+	if sdkErr != nil {
+		return PackageReceipt{}, sdkErr
+	}
+	receipt.AckSequence = int64(sequence)
+	return receipt, nil
+}
+
+func executePackage(ctx sdk.Context, handler sdk.CrossChainApplication, pack ReceivedPackage) (crash bool, result sdk.ExecuteResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Logger().With("module", "ibc").Error("execute package panic",
+				"channel", pack.ChannelID, "sequence", pack.Sequence, "err_log", fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack())))
+			crash = true
+			result = sdk.ExecuteResult{
+				Err: sdk.ErrInternal(fmt.Sprintf("execute package failed: %v", r)),
+			}
+		}
+	}()
+
+	switch pack.Type {
+	case sdk.SynCrossChainPackageType:
+		result = handler.ExecuteSynPackage(ctx, pack.Load, pack.RelayFee)
+	case sdk.AckCrossChainPackageType:
+		result = handler.ExecuteAckPackage(ctx, pack.Load)
+	case sdk.FailAckCrossChainPackageType:
+		result = handler.ExecuteFailAckPackage(ctx, pack.Load)
+	default:
+		panic(fmt.Sprintf("receive unexpected package type %d", pack.Type))
+	}
+	return
+}
+
+// decodeReceivedPackage decodes the header and the load of a package, the load is decompressed after
+// sdk.IBCLoadCompression
+func decodeReceivedPackage(payload []byte) (packageType sdk.CrossChainPackageType, relayFee big.Int, load []byte, err error) {
+	if sdk.IsUpgrade(sdk.IBCLoadCompression) {
+		return sTypes.DecodePackage(payload)
+	}
+	packageType, relayFee, err = sTypes.DecodePackageHeader(payload)
+	if err != nil {
+		return
+	}
+	return packageType, relayFee, payload[sTypes.PackageHeaderLength:], nil
+}
@@ -0,0 +1,186 @@
+package ibc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// RefundEscrow records funds locked in the peg account for an outgoing package, so that they
+// can be returned to the sender if the package fails on the destination chain or is never acknowledged.
+type RefundEscrow struct {
+	Sender     sdk.AccAddress `json:"sender"`
+	Amount     sdk.Coins      `json:"amount"`
+	ExpireTime int64          `json:"expire_time"` // unix seconds
+}
+
+type refunder struct {
+	ck   bank.Keeper
+	pool *sdk.Pool
+}
+
+// SetupForRefund enables the escrow and refund of package funds.
+func (k *Keeper) SetupForRefund(ck bank.Keeper, pool *sdk.Pool) {
+	k.refunder.ck = ck
+	k.refunder.pool = pool
+}
+
+// EscrowPackageFunds moves amount from sender to the peg account and records it against the
+// outgoing package. Cross chain apps call it right after writing the package.
+func (k *Keeper) EscrowPackageFunds(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64,
+	sender sdk.AccAddress, amount sdk.Coins, expireTime int64) sdk.Error {
+	if k.refunder.ck == nil {
+		return sdk.ErrInternal("refund is not set up")
+	}
+	if !amount.IsValid() || !amount.IsPositive() {
+		return ErrInvalidRefund(k.codespace, fmt.Sprintf("invalid refund amount %s", amount))
+	}
+	if expireTime <= 0 {
+		return ErrInvalidRefund(k.codespace, fmt.Sprintf("invalid expire time %d", expireTime))
+	}
+
+	kvStore := ctx.KVStore(k.storeKey)
+	refundKey := buildRefundKey(destChainID, channelID, sequence)
+	if kvStore.Has(refundKey) {
+		return ErrDuplicatedRefund(k.codespace, fmt.Sprintf("funds of package %d:%d:%d are already escrowed", destChainID, channelID, sequence))
+	}
+
+	if _, err := k.refunder.ck.SendCoins(ctx, sender, sdk.GetPegAccount(), amount); err != nil {
+		return err
+	}
+	escrow := RefundEscrow{
+		Sender:     sender,
+		Amount:     amount,
+		ExpireTime: expireTime,
+	}
+	kvStore.Set(refundKey, refundCdc.MustMarshalBinaryBare(escrow))
+	kvStore.Set(buildExpireKey(expireTime, destChainID, channelID, sequence), []byte{0x01})
+	k.addAddrs(ctx, sender)
+	return nil
+}
+
+// GetRefundEscrow returns the funds escrowed for a package that is not settled yet.
+func (k *Keeper) GetRefundEscrow(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (RefundEscrow, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildRefundKey(destChainID, channelID, sequence))
+	if bz == nil {
+		return RefundEscrow{}, false
+	}
+	var escrow RefundEscrow
+	refundCdc.MustUnmarshalBinaryBare(bz, &escrow)
+	return escrow, true
+}
+
+// ReleasePackageFunds settles a package that succeeded on the destination chain, the escrowed
+// funds stay in the peg account. SettlePackageFunds calls it for every ack.
+func (k *Keeper) ReleasePackageFunds(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (RefundEscrow, sdk.Error) {
+	escrow, found := k.GetRefundEscrow(ctx, destChainID, channelID, sequence)
+	if !found {
+		return RefundEscrow{}, ErrRefundNotFound(k.codespace, fmt.Sprintf("no escrow for package %d:%d:%d, it may have been settled already", destChainID, channelID, sequence))
+	}
+	k.deleteRefundEscrow(ctx, destChainID, channelID, sequence, escrow)
+	return escrow, nil
+}
+
+// RefundPackageFunds returns the escrowed funds of a package to its sender. Call it from the ack
+// hook when the destination chain reports a failure or timeout, SettlePackageFunds calls it for
+// every fail ack. Each escrow is settled at most once, a second refund returns an error.
+func (k *Keeper) RefundPackageFunds(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (RefundEscrow, sdk.Error) {
+	if k.refunder.ck == nil {
+		return RefundEscrow{}, sdk.ErrInternal("refund is not set up")
+	}
+	escrow, found := k.GetRefundEscrow(ctx, destChainID, channelID, sequence)
+	if !found {
+		return RefundEscrow{}, ErrRefundNotFound(k.codespace, fmt.Sprintf("no escrow for package %d:%d:%d, it may have been settled already", destChainID, channelID, sequence))
+	}
+
+	if _, err := k.refunder.ck.SendCoins(ctx, sdk.GetPegAccount(), escrow.Sender, escrow.Amount); err != nil {
+		return RefundEscrow{}, err
+	}
+	k.deleteRefundEscrow(ctx, destChainID, channelID, sequence, escrow)
+	k.addAddrs(ctx, escrow.Sender)
+	k.collectEvent(ctx, NewPackageRefundEvent(destChainID, channelID, sequence, escrow))
+	return escrow, nil
+}
+
+// refundExpiredPackages refunds every escrow whose package has not been acknowledged before
+// its expire time.
+func (k *Keeper) refundExpiredPackages(ctx sdk.Context) {
+	if k.refunder.ck == nil {
+		return
+	}
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := kvStore.Iterator(PrefixForExpireKey, buildExpireEndKey(ctx.BlockHeader().Time.Unix()))
+	var expireKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		expireKeys = append(expireKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, expireKey := range expireKeys {
+		destChainID, channelID, sequence := parseExpireKey(expireKey)
+		cacheCtx, write := ctx.CacheContext()
+		escrow, err := k.RefundPackageFunds(cacheCtx, destChainID, channelID, sequence)
+		if err != nil {
+			// keep the escrow so that a late ack can still settle it, but do not retry every block
+			ctx.Logger().With("module", "ibc").Error("failed to refund expired package",
+				"destChainID", destChainID, "channelID", channelID, "sequence", sequence, "err", err.Error())
+			kvStore.Delete(expireKey)
+			continue
+		}
+		write()
+		// the ack of the package may still arrive, it must not settle the package again
+		kvStore.Set(buildRefundedKey(destChainID, channelID, sequence), []byte{0x01})
+		ctx.Logger().With("module", "ibc").Info("refunded expired package",
+			"destChainID", destChainID, "channelID", channelID, "sequence", sequence,
+			"sender", escrow.Sender.String(), "amount", escrow.Amount.String())
+	}
+}
+
+// IsAckOfRefundedPackage reports whether the next ack of a channel acknowledges a package refunded after it
+// expired. The funds of the package are back with its sender, so the ack must not be executed.
+func (k *Keeper) IsAckOfRefundedPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) bool {
+	if !sdk.IsUpgrade(sdk.IBCDeliveryWatermark) {
+		return false
+	}
+	watermark, ok := k.nextDeliveryWatermark(ctx, destChainID, channelID)
+	return ok && ctx.KVStore(k.storeKey).Has(buildRefundedKey(destChainID, channelID, watermark.Sequence))
+}
+
+// SettlePackageFunds settles the escrow of the package confirmed by an ack or fail ack package. The funds stay
+// pegged for an ack and go back to the sender for a fail ack. The ack of a package refunded after it expired
+// only clears the refund mark, it settles nothing.
+func (k *Keeper) SettlePackageFunds(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, failed bool) {
+	kvStore := ctx.KVStore(k.storeKey)
+	refundedKey := buildRefundedKey(destChainID, channelID, sequence)
+	if kvStore.Has(refundedKey) {
+		kvStore.Delete(refundedKey)
+		return
+	}
+	if _, found := k.GetRefundEscrow(ctx, destChainID, channelID, sequence); !found {
+		return
+	}
+
+	var err sdk.Error
+	if failed {
+		_, err = k.RefundPackageFunds(ctx, destChainID, channelID, sequence)
+	} else {
+		_, err = k.ReleasePackageFunds(ctx, destChainID, channelID, sequence)
+	}
+	if err != nil {
+		ctx.Logger().With("module", "ibc").Error("failed to settle package funds",
+			"destChainID", destChainID, "channelID", channelID, "sequence", sequence, "err", err.Error())
+	}
+}
+
+func (k *Keeper) deleteRefundEscrow(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, escrow RefundEscrow) {
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Delete(buildRefundKey(destChainID, channelID, sequence))
+	kvStore.Delete(buildExpireKey(escrow.ExpireTime, destChainID, channelID, sequence))
+}
+
+func (k *Keeper) addAddrs(ctx sdk.Context, addr sdk.AccAddress) {
+	if ctx.IsDeliverTx() && k.refunder.pool != nil {
+		k.refunder.pool.AddAddrs([]sdk.AccAddress{addr, sdk.GetPegAccount()})
+	}
+}
@@ -0,0 +1,141 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RelayFeeEscrowAccount holds the relay fees paid for the outbound packages until their acks are delivered
+var RelayFeeEscrowAccount = sdk.ModuleAddress("ibc", "relay_fee")
+
+// RelayFeeEscrow records the relay fee paid for an outbound package
+type RelayFeeEscrow struct {
+	Payer sdk.AccAddress `json:"payer"`
+	Fee   sdk.Coins      `json:"fee"`
+}
+
+// createIBCSyncPackageWithFee writes a syn package and escrows the relay fee for it
+func (k *Keeper) createIBCSyncPackageWithFee(ctx sdk.Context, destChainName string, channelName string,
+	packageLoad []byte, relayerFee big.Int, escrow RelayFeeEscrow) (uint64, sdk.Error) {
+	if !sdk.IsUpgrade(sdk.IBCRelayFeeEscrow) || !sdk.IsUpgrade(sdk.IBCDeliveryWatermark) {
+		return 0, sdk.ErrUnknownRequest(fmt.Sprintf("relay fee is not supported before %s", sdk.IBCRelayFeeEscrow))
+	}
+	if k.refunder.ck == nil {
+		return 0, sdk.ErrInternal("refund is not set up")
+	}
+	if !escrow.Fee.IsValid() || !escrow.Fee.IsPositive() {
+		return 0, ErrInvalidRelayFee(k.codespace, fmt.Sprintf("invalid relay fee %s", escrow.Fee))
+	}
+	destChainID, err := k.sideKeeper.LookupDestChainID(ctx, destChainName)
+	if err != nil {
+		return 0, sdk.ErrInternal(err.Error())
+	}
+	channelID, err := k.sideKeeper.LookupChannelID(ctx, channelName)
+	if err != nil {
+		return 0, sdk.ErrInternal(err.Error())
+	}
+
+	// the fee is escrowed first, so that no package is written if the payer can not afford it
+	sequence := k.sideKeeper.GetSendSequence(ctx, destChainID, channelID)
+	if _, sdkErr := k.refunder.ck.SendCoins(ctx, escrow.Payer, RelayFeeEscrowAccount, escrow.Fee); sdkErr != nil {
+		return 0, sdkErr
+	}
+	if _, sdkErr := k.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType,
+		packageLoad, relayerFee); sdkErr != nil {
+		return 0, sdkErr
+	}
+
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Set(buildRelayFeeKey(destChainID, channelID, sequence), refundCdc.MustMarshalBinaryBare(escrow))
+	k.setChannelRelayFees(ctx, destChainID, channelID, k.GetChannelRelayFees(ctx, destChainID, channelID).Plus(escrow.Fee))
+	k.addRelayFeeAddrs(ctx, escrow.Payer)
+	k.collectEvent(ctx, NewRelayFeeEscrowedEvent(destChainID, channelID, sequence, escrow))
+	return sequence, nil
+}
+
+// GetRelayFeeEscrow returns the relay fee escrowed for a package whose ack is not delivered yet
+func (k *Keeper) GetRelayFeeEscrow(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (RelayFeeEscrow, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildRelayFeeKey(destChainID, channelID, sequence))
+	if bz == nil {
+		return RelayFeeEscrow{}, false
+	}
+	var escrow RelayFeeEscrow
+	refundCdc.MustUnmarshalBinaryBare(bz, &escrow)
+	return escrow, true
+}
+
+// GetChannelRelayFees returns the relay fees escrowed on a channel
+func (k *Keeper) GetChannelRelayFees(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) sdk.Coins {
+	bz := ctx.KVStore(k.storeKey).Get(buildRelayFeeTotalKey(destChainID, channelID))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	var fees sdk.Coins
+	refundCdc.MustUnmarshalBinaryBare(bz, &fees)
+	return fees
+}
+
+func (k *Keeper) setChannelRelayFees(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, fees sdk.Coins) {
+	kvStore := ctx.KVStore(k.storeKey)
+	if fees.IsZero() {
+		kvStore.Delete(buildRelayFeeTotalKey(destChainID, channelID))
+		return
+	}
+	kvStore.Set(buildRelayFeeTotalKey(destChainID, channelID), refundCdc.MustMarshalBinaryBare(fees))
+}
+
+// PayRelayFee pays the relay fee escrowed for the package of sequence on a channel to the relayer delivering
+// its ack. Nothing is paid if no fee is escrowed for the package.
+func (k *Keeper) PayRelayFee(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64,
+	relayer sdk.AccAddress) (RelayFeeEscrow, bool, sdk.Error) {
+	if k.refunder.ck == nil {
+		return RelayFeeEscrow{}, false, nil
+	}
+	escrow, found := k.GetRelayFeeEscrow(ctx, destChainID, channelID, sequence)
+	if !found {
+		return RelayFeeEscrow{}, false, nil
+	}
+
+	if _, err := k.refunder.ck.SendCoins(ctx, RelayFeeEscrowAccount, relayer, escrow.Fee); err != nil {
+		return RelayFeeEscrow{}, false, err
+	}
+	ctx.KVStore(k.storeKey).Delete(buildRelayFeeKey(destChainID, channelID, sequence))
+	k.setChannelRelayFees(ctx, destChainID, channelID, k.GetChannelRelayFees(ctx, destChainID, channelID).Minus(escrow.Fee))
+	k.addRelayFeeAddrs(ctx, relayer)
+	k.collectEvent(ctx, NewRelayFeePaidEvent(destChainID, channelID, sequence, relayer, escrow.Fee))
+	return escrow, true, nil
+}
+
+func (k *Keeper) addRelayFeeAddrs(ctx sdk.Context, addr sdk.AccAddress) {
+	if ctx.IsDeliverTx() && k.refunder.pool != nil {
+		k.refunder.pool.AddAddrs([]sdk.AccAddress{addr, RelayFeeEscrowAccount})
+	}
+}
+
+// ChannelRelayFees is the relay fees escrowed on a channel
+type ChannelRelayFees struct {
+	DestChainID sdk.ChainID   `json:"dest_chain_id"`
+	ChannelID   sdk.ChannelID `json:"channel_id"`
+	Fees        sdk.Coins     `json:"fees"`
+}
+
+// GetAllChannelRelayFees returns the relay fees escrowed on every channel
+func (k *Keeper) GetAllChannelRelayFees(ctx sdk.Context) []ChannelRelayFees {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixForRelayFeeTotalKey)
+	defer iterator.Close()
+	all := make([]ChannelRelayFees, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		id := iterator.Key()[prefixLength:]
+		var fees sdk.Coins
+		refundCdc.MustUnmarshalBinaryBare(iterator.Value(), &fees)
+		all = append(all, ChannelRelayFees{
+			DestChainID: sdk.ChainID(binary.BigEndian.Uint16(id[:destChainIDLength])),
+			ChannelID:   sdk.ChannelID(id[destChainIDLength]),
+			Fees:        fees,
+		})
+	}
+	return all
+}
@@ -0,0 +1,146 @@
+package ibc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// ChanSenders restricts the modules allowed to create syn packages on a channel.
+// It is also the description of a ManageChanSenders proposal, an empty list of modules
+// lifts the restriction.
+type ChanSenders struct {
+	ChannelID sdk.ChannelID `json:"channel_id"`
+	Modules   []string      `json:"modules"`
+}
+
+func (s ChanSenders) Check() error {
+	seen := make(map[string]bool, len(s.Modules))
+	for _, module := range s.Modules {
+		if module == "" {
+			return fmt.Errorf("empty module name")
+		}
+		if seen[module] {
+			return fmt.Errorf("duplicated module %s", module)
+		}
+		seen[module] = true
+	}
+	return nil
+}
+
+func buildChanSendersKey(channelID sdk.ChannelID) []byte {
+	return append(append([]byte{}, PrefixForChanSendersKey...), byte(channelID))
+}
+
+// ForModule returns a keeper creating the packages on behalf of module, which is checked against
+// the senders of the channels. Since ChannelSenders, the keeper returned by NewKeeper cannot create
+// syn packages, every module creating packages must be given its own.
+func (k Keeper) ForModule(module string) Keeper {
+	k.senderModule = module
+	return k
+}
+
+func (k *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
+	k.govKeeper = govKeeper
+}
+
+// SetChanSenders restricts the modules sending on a channel, an empty list lifts the restriction
+func (k *Keeper) SetChanSenders(ctx sdk.Context, senders ChanSenders) {
+	kvStore := ctx.KVStore(k.storeKey)
+	if len(senders.Modules) == 0 {
+		kvStore.Delete(buildChanSendersKey(senders.ChannelID))
+		return
+	}
+	kvStore.Set(buildChanSendersKey(senders.ChannelID), refundCdc.MustMarshalBinaryBare(senders))
+}
+
+// GetChanSenders returns the senders of a channel, false if any module may send on it
+func (k *Keeper) GetChanSenders(ctx sdk.Context, channelID sdk.ChannelID) (ChanSenders, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildChanSendersKey(channelID))
+	if bz == nil {
+		return ChanSenders{}, false
+	}
+	var senders ChanSenders
+	refundCdc.MustUnmarshalBinaryBare(bz, &senders)
+	return senders, true
+}
+
+// checkSender returns an error if the channel has senders that do not contain the module of this keeper.
+// The internal channels registered by governance are only written by the modules in their senders, they
+// are checked even before ChannelSenders.
+func (k *Keeper) checkSender(ctx sdk.Context, channelID sdk.ChannelID) sdk.Error {
+	internal := false
+	if sdk.IsUpgrade(sdk.CrossChainRegistry) {
+		registration, found := k.sideKeeper.GetRegisteredChannel(ctx, channelID)
+		internal = found && registration.Scope == sTypes.ChannelScopeInternal
+	}
+	if !internal && !sdk.IsUpgrade(sdk.ChannelSenders) {
+		return nil
+	}
+	if k.senderModule == "" {
+		return ErrSenderNotAllowed(DefaultCodespace,
+			fmt.Sprintf("syn package to channel %d is not created through the keeper of a module", channelID))
+	}
+	senders, found := k.GetChanSenders(ctx, channelID)
+	if !found {
+		if internal {
+			return ErrSenderNotAllowed(DefaultCodespace,
+				fmt.Sprintf("module %s is not allowed to write syn package to internal channel %d", k.senderModule, channelID))
+		}
+		return nil
+	}
+	for _, module := range senders.Modules {
+		if module == k.senderModule {
+			return nil
+		}
+	}
+	return ErrSenderNotAllowed(DefaultCodespace,
+		fmt.Sprintf("module %s is not allowed to write syn package to channel %d", k.senderModule, channelID))
+}
+
+// executeChanSendersProposals applies the ManageChanSenders proposals passed since the last block.
+func (k *Keeper) executeChanSendersProposals(ctx sdk.Context) {
+	if k.govKeeper == nil {
+		return
+	}
+	logger := ctx.Logger().With("module", "ibc")
+	for _, proposal := range sidechain.CollectPassedProposals(ctx, k.govKeeper, gov.ProposalTypeManageChanSenders) {
+		var senders ChanSenders
+		if err := refundCdc.UnmarshalJSON([]byte(proposal.GetDescription()), &senders); err != nil {
+			logger.Error("Get broken data when unmarshal ChanSenders msg, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		if err := senders.Check(); err != nil {
+			logger.Error("The ChanSenders proposal is invalid, will skip.",
+				"proposalId", proposal.GetProposalID(), "proposal", senders, "err", err)
+			continue
+		}
+		k.SetChanSenders(ctx, senders)
+		logger.Info("Changed channel senders", "proposalId", proposal.GetProposalID(), "channelId", senders.ChannelID)
+	}
+}
+
+// ---------------------    ChanSendersHooks  -----------------
+type ChanSendersHooks struct{}
+
+func NewChanSendersHooks() ChanSendersHooks {
+	return ChanSendersHooks{}
+}
+
+var _ gov.GovHooks = ChanSendersHooks{}
+
+func (hooks ChanSendersHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeManageChanSenders {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	var senders ChanSenders
+	if err := refundCdc.UnmarshalJSON([]byte(proposal.GetDescription()), &senders); err != nil {
+		return fmt.Errorf("unmarshal ChanSenders failed: %v", err)
+	}
+	return senders.Check()
+}
@@ -0,0 +1,121 @@
+package ibc
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ChannelStats counts the packages sent through a channel to a destination chain. A package is
+// delivered once the destination chain confirms it, whatever the result, and failed if a fail
+// ack comes back for it. Only the packages created after sdk.IBCChannelStats have a latency.
+type ChannelStats struct {
+	DestChainID    sdk.ChainID   `json:"dest_chain_id"`
+	ChannelID      sdk.ChannelID `json:"channel_id"`
+	Created        uint64        `json:"created"`
+	Delivered      uint64        `json:"delivered"`
+	Failed         uint64        `json:"failed"`
+	TotalLatency   uint64        `json:"total_latency"`   // in blocks
+	LatencySamples uint64        `json:"latency_samples"` // delivered packages with a latency
+}
+
+// AverageLatency returns the average delivery latency in blocks
+func (s ChannelStats) AverageLatency() sdk.Dec {
+	if s.LatencySamples == 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDecWithoutFra(int64(s.TotalLatency)).Quo(sdk.NewDecWithoutFra(int64(s.LatencySamples)))
+}
+
+func buildChannelStatsKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+channelIDLength)
+
+	copy(key[:prefixLength], PrefixForChannelStatsKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
+
+	return key
+}
+
+func buildCreatedHeightKey(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, totalRefundKeyLength)
+
+	copy(key[:prefixLength], PrefixForCreatedHeightKey)
+	putRefundID(key[prefixLength:], destChainID, channelID, sequence)
+
+	return key
+}
+
+// GetChannelStats returns the stats of a channel to a destination chain
+func (k *Keeper) GetChannelStats(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) ChannelStats {
+	bz := ctx.KVStore(k.storeKey).Get(buildChannelStatsKey(destChainID, channelID))
+	if bz == nil {
+		return ChannelStats{DestChainID: destChainID, ChannelID: channelID}
+	}
+	var stats ChannelStats
+	refundCdc.MustUnmarshalBinaryBare(bz, &stats)
+	return stats
+}
+
+// GetAllChannelStats returns the stats of every channel that has sent a package
+func (k *Keeper) GetAllChannelStats(ctx sdk.Context) []ChannelStats {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixForChannelStatsKey)
+	defer iterator.Close()
+
+	var all []ChannelStats
+	for ; iterator.Valid(); iterator.Next() {
+		var stats ChannelStats
+		refundCdc.MustUnmarshalBinaryBare(iterator.Value(), &stats)
+		all = append(all, stats)
+	}
+	return all
+}
+
+func (k *Keeper) updateChannelStats(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, update func(*ChannelStats)) {
+	stats := k.GetChannelStats(ctx, destChainID, channelID)
+	update(&stats)
+	ctx.KVStore(k.storeKey).Set(buildChannelStatsKey(destChainID, channelID), refundCdc.MustMarshalBinaryBare(stats))
+}
+
+func (k *Keeper) recordPackageCreated(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	if !sdk.IsUpgrade(sdk.IBCChannelStats) {
+		return
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(ctx.BlockHeight()))
+	ctx.KVStore(k.storeKey).Set(buildCreatedHeightKey(destChainID, channelID, sequence), bz)
+	k.updateChannelStats(ctx, destChainID, channelID, func(stats *ChannelStats) {
+		stats.Created++
+	})
+}
+
+func (k *Keeper) recordPackagesDelivered(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequences []uint64) {
+	if !sdk.IsUpgrade(sdk.IBCChannelStats) || len(sequences) == 0 {
+		return
+	}
+	kvStore := ctx.KVStore(k.storeKey)
+	k.updateChannelStats(ctx, destChainID, channelID, func(stats *ChannelStats) {
+		for _, sequence := range sequences {
+			stats.Delivered++
+			key := buildCreatedHeightKey(destChainID, channelID, sequence)
+			bz := kvStore.Get(key)
+			if bz == nil {
+				continue
+			}
+			stats.TotalLatency += uint64(ctx.BlockHeight()) - binary.BigEndian.Uint64(bz)
+			stats.LatencySamples++
+			kvStore.Delete(key)
+		}
+	})
+}
+
+// RecordPackageFailed counts a package of the channel that failed on the destination chain.
+// Call it when a fail ack package is received.
+func (k *Keeper) RecordPackageFailed(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) {
+	if !sdk.IsUpgrade(sdk.IBCChannelStats) {
+		return
+	}
+	k.updateChannelStats(ctx, destChainID, channelID, func(stats *ChannelStats) {
+		stats.Failed++
+	})
+}
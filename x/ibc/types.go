@@ -12,6 +12,8 @@ type packageRecord struct {
 
 type packageCollector struct {
 	collectedPackages []packageRecord
+	// package lifecycle events of the block, emitted in EndBlocker
+	lifecycleEvents sdk.Events
 }
 
 func newPackageCollector() *packageCollector {
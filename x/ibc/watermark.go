@@ -0,0 +1,96 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DeliveryWatermark is the highest sequence of the outbound packages of a channel whose delivery is confirmed
+// by the oracle, CleanupIBCPackage only deletes the packages up to a watermark recorded by the keeper.
+type DeliveryWatermark struct {
+	DestChainID sdk.ChainID   `json:"dest_chain_id"`
+	ChannelID   sdk.ChannelID `json:"channel_id"`
+	Sequence    uint64        `json:"sequence"`
+}
+
+// GetDeliveryWatermark returns the delivery watermark of a channel, it is not found until the first ack of the
+// channel is processed.
+func (k *Keeper) GetDeliveryWatermark(ctx sdk.Context, destChainName string, channelName string) (DeliveryWatermark, bool) {
+	destChainID, err := k.sideKeeper.LookupDestChainID(ctx, destChainName)
+	if err != nil {
+		return DeliveryWatermark{}, false
+	}
+	channelID, err := k.sideKeeper.LookupChannelID(ctx, channelName)
+	if err != nil {
+		return DeliveryWatermark{}, false
+	}
+	return k.getDeliveryWatermark(ctx, destChainID, channelID)
+}
+
+func (k *Keeper) getDeliveryWatermark(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (DeliveryWatermark, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildDeliveryWatermarkKey(destChainID, channelID))
+	if bz == nil {
+		return DeliveryWatermark{}, false
+	}
+	return DeliveryWatermark{DestChainID: destChainID, ChannelID: channelID, Sequence: binary.BigEndian.Uint64(bz)}, true
+}
+
+// ConfirmPackageDelivery moves the delivery watermark of a channel to the next outbound package, the oracle calls
+// it for every ack it processes as the destination chain acknowledges the packages of a channel in order. The
+// first ack confirms the oldest package left in the store, the ones before were cleaned up already.
+func (k *Keeper) ConfirmPackageDelivery(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (DeliveryWatermark, bool) {
+	watermark, ok := k.nextDeliveryWatermark(ctx, destChainID, channelID)
+	if !ok {
+		return watermark, false
+	}
+
+	bz := make([]byte, sequenceLength)
+	binary.BigEndian.PutUint64(bz, watermark.Sequence)
+	ctx.KVStore(k.storeKey).Set(buildDeliveryWatermarkKey(destChainID, channelID), bz)
+	return watermark, true
+}
+
+// nextDeliveryWatermark returns the watermark the next ack of a channel moves to, it is not ok if there is no
+// outbound package left to confirm
+func (k *Keeper) nextDeliveryWatermark(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (DeliveryWatermark, bool) {
+	watermark, found := k.getDeliveryWatermark(ctx, destChainID, channelID)
+	if found {
+		watermark.Sequence++
+	} else {
+		iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey),
+			buildIBCPackageKeyPrefix(k.sideKeeper.GetSrcChainID(), destChainID, channelID))
+		defer iterator.Close()
+		if !iterator.Valid() {
+			return DeliveryWatermark{}, false
+		}
+		watermark = DeliveryWatermark{
+			DestChainID: destChainID,
+			ChannelID:   channelID,
+			Sequence:    binary.BigEndian.Uint64(iterator.Key()[totalPackageKeyLength-sequenceLength:]),
+		}
+	}
+	if watermark.Sequence >= k.sideKeeper.GetSendSequence(ctx, destChainID, channelID) {
+		// more acks than packages sent, the watermark never goes past the packages sent
+		return watermark, false
+	}
+	return watermark, true
+}
+
+// checkDeliveryWatermark checks that a watermark presented for a cleanup is not past the recorded one
+func (k *Keeper) checkDeliveryWatermark(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, watermark DeliveryWatermark) sdk.Error {
+	if watermark.DestChainID != destChainID || watermark.ChannelID != channelID {
+		return ErrInvalidWatermark(k.codespace, fmt.Sprintf("watermark of %d:%d presented for channel %d:%d",
+			watermark.DestChainID, watermark.ChannelID, destChainID, channelID))
+	}
+	recorded, found := k.getDeliveryWatermark(ctx, destChainID, channelID)
+	if !found {
+		return ErrInvalidWatermark(k.codespace, fmt.Sprintf("no delivery is confirmed on channel %d:%d", destChainID, channelID))
+	}
+	if watermark.Sequence > recorded.Sequence {
+		return ErrInvalidWatermark(k.codespace, fmt.Sprintf("sequence %d is past the delivery watermark %d of channel %d:%d",
+			watermark.Sequence, recorded.Sequence, destChainID, channelID))
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 )
 
+var refundCdc = codec.New()
+
 func RegisterWire(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&Params{}, "params/IbcParamSet", nil)
 }
@@ -18,8 +18,17 @@ func BeginBlocker(ctx sdk.Context, k Keeper) {
 	params := k.GetParams(ctx)
 	totalSupply := k.sk.TotalPower(ctx)
 	bondedRatio := k.sk.BondedRatio(ctx)
+	if sdk.IsUpgrade(sdk.BondedTokensAverage) {
+		bondedRatio = k.sk.AverageBondedRatio(ctx)
+	}
 	minter.InflationLastTime = blockTime
 	minter, mintedCoin := minter.ProcessProvisions(params, totalSupply, bondedRatio)
+	// the inflated loose tokens used to have no coins behind them
+	if sdk.IsUpgrade(sdk.SupplyMintBurn) && k.supplyKeeper != nil && mintedCoin.IsPositive() {
+		if err := k.supplyKeeper.MintCoins(ctx, DefaultParamspace, sdk.Coins{mintedCoin}); err != nil {
+			ctx.Logger().With("module", "x/mint").Error("failed to mint the provisions", "amount", mintedCoin.String(), "err", err.Error())
+		}
+	}
 	k.sk.InflateSupply(ctx, sdk.NewDecFromInt(mintedCoin.Amount))
 	k.SetMinter(ctx, minter)
 }
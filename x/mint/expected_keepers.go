@@ -8,9 +8,15 @@ import (
 type StakeKeeper interface {
 	TotalPower(ctx sdk.Context) sdk.Dec
 	BondedRatio(ctx sdk.Context) sdk.Dec
+	AverageBondedRatio(ctx sdk.Context) sdk.Dec
 	InflateSupply(ctx sdk.Context, newTokens sdk.Dec)
 }
 
+// expected supply keeper interface, the module account of DefaultParamspace has to be a minter
+type SupplyKeeper interface {
+	MintCoins(ctx sdk.Context, module string, amt sdk.Coins) sdk.Error
+}
+
 // expected fee collection keeper interface
 type FeeCollectionKeeper interface {
 	AddCollectedFees(sdk.Context, sdk.Coins) sdk.Coins
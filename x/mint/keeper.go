@@ -12,6 +12,9 @@ type Keeper struct {
 	cdc        *codec.Codec
 	paramSpace params.Subspace
 	sk         StakeKeeper
+
+	// optional, mints the provisions as coins from sdk.SupplyMintBurn on
+	supplyKeeper SupplyKeeper
 }
 
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey,
@@ -26,12 +29,20 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey,
 	return keeper
 }
 
+// SetSupplyKeeper sets the supply keeper minting the provisions into ProvisionsAccAddr
+func (k *Keeper) SetSupplyKeeper(supplyKeeper SupplyKeeper) {
+	k.supplyKeeper = supplyKeeper
+}
+
 //____________________________________________________________________
 // Keys
 
 var (
 	minterKey = []byte{0x00} // the one key to use for the keeper store
 
+	// the account holding the minted provisions
+	ProvisionsAccAddr = sdk.ModuleAddress(DefaultParamspace, "provisions")
+
 	// params store for inflation params
 	ParamStoreKeyParams = []byte("params")
 )
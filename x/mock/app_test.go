@@ -1,12 +1,15 @@
 package mock
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
 )
 
 const msgRoute = "testMsg"
@@ -104,3 +107,79 @@ func TestCheckGenTx(t *testing.T) {
 		false, privKeys[0],
 	)
 }
+
+// benchMsg is a testMsg which survives the encoding of the txs, it fails in the handler if Num is 0.
+type benchMsg struct {
+	Signer sdk.AccAddress
+	Num    int64
+}
+
+func (msg benchMsg) Route() string { return msgRoute }
+func (msg benchMsg) Type() string  { return "bench" }
+func (msg benchMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON([]byte(fmt.Sprintf(`{"signer":"%s","num":%d}`, msg.Signer, msg.Num)))
+}
+func (msg benchMsg) GetSigners() []sdk.AccAddress           { return []sdk.AccAddress{msg.Signer} }
+func (msg benchMsg) GetInvolvedAddresses() []sdk.AccAddress { return msg.GetSigners() }
+func (msg benchMsg) ValidateBasic() sdk.Error               { return nil }
+
+func getBenchmarkApp(t testing.TB) (*App, BenchmarkConfig) {
+	mApp := NewApp()
+	mApp.Router().AddRoute(msgRoute, func(ctx sdk.Context, msg sdk.Msg) (res sdk.Result) {
+		if msg.(benchMsg).Num == 0 {
+			return sdk.ErrUnknownRequest("zero").Result()
+		}
+		return
+	})
+	mApp.Cdc.RegisterConcrete(benchMsg{}, "mock/benchMsg", nil)
+	require.NoError(t, mApp.CompleteSetup())
+
+	testMsgOf := func(num int64) MsgGenerator {
+		return func(_ *rand.Rand, from BenchmarkAccount, _ []BenchmarkAccount) sdk.Msg {
+			return benchMsg{Signer: from.Address, Num: num}
+		}
+	}
+	return mApp, BenchmarkConfig{
+		NumAccounts: 10,
+		TxsPerBlock: 20,
+		NumBlocks:   3,
+		GenCoins:    genCoins,
+		MsgMix:      []WeightedMsg{{Weight: 3, Generate: testMsgOf(1)}, {Weight: 1, Generate: testMsgOf(0)}},
+	}
+}
+
+func TestRunBenchmark(t *testing.T) {
+	mApp, cfg := getBenchmarkApp(t)
+
+	result, err := RunBenchmark(mApp, cfg, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Blocks)
+	require.Equal(t, 60, result.CheckedTxs)
+	require.Equal(t, int64(5), mApp.LastBlockHeight()) // genesis, the empty block and the 3 blocks
+	// the txs failing CheckTx are not delivered
+	require.True(t, result.FailedCheckTxs > 0 && result.FailedCheckTxs < 60, result.String())
+	require.Equal(t, 60-int(result.FailedCheckTxs), result.DeliveredTxs)
+	require.Equal(t, int64(0), result.FailedDeliverTxs)
+	ctx := mApp.NewContext(sdk.RunTxModeCheck, abci.Header{})
+	sequences := int64(0)
+	for _, acc := range GetAllAccounts(mApp.AccountKeeper, ctx) {
+		sequences += acc.GetSequence()
+	}
+	require.Equal(t, int64(result.DeliveredTxs), sequences)
+
+	_, err = RunBenchmark(mApp, BenchmarkConfig{NumAccounts: 1}, log.NewNopLogger())
+	require.Error(t, err)
+}
+
+func BenchmarkBlockProduction(b *testing.B) {
+	mApp, cfg := getBenchmarkApp(b)
+	cfg.NumAccounts, cfg.TxsPerBlock, cfg.NumBlocks = 1000, 1000, b.N
+	cfg.MsgMix = cfg.MsgMix[:1]
+
+	b.ResetTimer()
+	result, err := RunBenchmark(mApp, cfg, log.NewNopLogger())
+	require.NoError(b, err)
+	b.ReportMetric(result.CheckTxPerSecond(), "checktx/s")
+	b.ReportMetric(result.DeliverTxPerSecond(), "delivertx/s")
+	b.ReportMetric(result.CommitPerSecond(), "commit/s")
+}
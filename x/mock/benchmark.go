@@ -0,0 +1,211 @@
+package mock
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/server/concurrent"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BenchmarkAccount is a genesis account of a benchmark, with the sequence of its next tx.
+type BenchmarkAccount struct {
+	Address  sdk.AccAddress
+	PrivKey  crypto.PrivKey
+	AccNum   int64
+	Sequence int64
+}
+
+// MsgGenerator builds the msg of a tx signed by from, accs are all the accounts of the benchmark.
+type MsgGenerator func(r *rand.Rand, from BenchmarkAccount, accs []BenchmarkAccount) sdk.Msg
+
+// WeightedMsg is a kind of msg of the mix sent by a benchmark, it is picked with a probability
+// proportional to its weight.
+type WeightedMsg struct {
+	Weight   int
+	Generate MsgGenerator
+}
+
+// BenchmarkConfig configures a block production benchmark.
+type BenchmarkConfig struct {
+	NumAccounts int
+	TxsPerBlock int
+	NumBlocks   int
+	GenCoins    sdk.Coins
+	MsgMix      []WeightedMsg
+	Seed        int64
+}
+
+// BenchmarkResult is the time spent in each phase of the blocks of a benchmark.
+type BenchmarkResult struct {
+	Blocks           int
+	CheckedTxs       int
+	DeliveredTxs     int
+	FailedCheckTxs   int64
+	FailedDeliverTxs int64
+	CheckTx          time.Duration // from the first CheckTx of a block to the response of the last one
+	DeliverTx        time.Duration // from BeginBlock to the end of EndBlock
+	Commit           time.Duration
+}
+
+func perSecond(n int, d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(n) / d.Seconds()
+}
+
+func (r BenchmarkResult) CheckTxPerSecond() float64   { return perSecond(r.CheckedTxs, r.CheckTx) }
+func (r BenchmarkResult) DeliverTxPerSecond() float64 { return perSecond(r.DeliveredTxs, r.DeliverTx) }
+func (r BenchmarkResult) CommitPerSecond() float64    { return perSecond(r.Blocks, r.Commit) }
+
+func (r BenchmarkResult) String() string {
+	commitPerBlock := time.Duration(0)
+	if r.Blocks > 0 {
+		commitPerBlock = r.Commit / time.Duration(r.Blocks)
+	}
+	return fmt.Sprintf("%d blocks, %d checked txs (%d failed), %d delivered txs (%d failed): "+
+		"CheckTx %.1f tx/s, DeliverTx %.1f tx/s, Commit %.1f block/s (%v per block)",
+		r.Blocks, r.CheckedTxs, r.FailedCheckTxs, r.DeliveredTxs, r.FailedDeliverTxs,
+		r.CheckTxPerSecond(), r.DeliverTxPerSecond(), r.CommitPerSecond(), commitPerBlock)
+}
+
+// RunBenchmark sets the genesis of app with cfg.NumAccounts accounts, then produces cfg.NumBlocks
+// blocks through the async local client, like a node would: cfg.TxsPerBlock txs are checked and
+// the ones passing CheckTx are delivered in the next block, which is then committed.
+// The app must have completed its setup and have the routes of the msgs in cfg.MsgMix. As the
+// sequences are only refreshed after each block, a tx failing CheckTx also fails the next txs of
+// its sender in the same block, so the msgs generated should be valid.
+func RunBenchmark(app *App, cfg BenchmarkConfig, logger log.Logger) (BenchmarkResult, error) {
+	if len(cfg.MsgMix) == 0 {
+		return BenchmarkResult{}, fmt.Errorf("no msg to send")
+	}
+	totalWeight := 0
+	for _, m := range cfg.MsgMix {
+		if m.Weight <= 0 {
+			return BenchmarkResult{}, fmt.Errorf("msg weights must be positive")
+		}
+		totalWeight += m.Weight
+	}
+	r := rand.New(rand.NewSource(cfg.Seed))
+
+	genAccs, addrs, _, privKeys := CreateGenAccounts(cfg.NumAccounts, cfg.GenCoins)
+	SetGenesis(app, genAccs)
+	ctx := app.NewContext(sdk.RunTxModeCheck, abci.Header{})
+	accs := make([]BenchmarkAccount, len(genAccs))
+	for i, addr := range addrs {
+		acc := app.AccountKeeper.GetAccount(ctx, addr)
+		accs[i] = BenchmarkAccount{Address: addr, PrivKey: privKeys[i], AccNum: acc.GetAccountNumber(), Sequence: acc.GetSequence()}
+	}
+
+	cli, err := concurrent.NewAsyncLocalClientCreator(app, logger).NewABCIClient()
+	if err != nil {
+		return BenchmarkResult{}, err
+	}
+	result := BenchmarkResult{}
+	cli.SetResponseCallback(func(req *abci.Request, res *abci.Response) {
+		if checkTx := res.GetCheckTx(); checkTx != nil && checkTx.Code != abci.CodeTypeOK {
+			atomic.AddInt64(&result.FailedCheckTxs, 1)
+		}
+		if deliverTx := res.GetDeliverTx(); deliverTx != nil && deliverTx.Code != abci.CodeTypeOK {
+			atomic.AddInt64(&result.FailedDeliverTxs, 1)
+		}
+	})
+	if err := cli.Start(); err != nil {
+		return BenchmarkResult{}, err
+	}
+	defer cli.Stop()
+
+	pickMsg := func(from BenchmarkAccount) sdk.Msg {
+		n := r.Intn(totalWeight)
+		for _, m := range cfg.MsgMix {
+			if n < m.Weight {
+				return m.Generate(r, from, accs)
+			}
+			n -= m.Weight
+		}
+		panic("unreachable")
+	}
+
+	produceBlock := func(txs [][]byte) (deliver, commit time.Duration, err error) {
+		height := app.LastBlockHeight() + 1
+		start := time.Now()
+		header := abci.Header{ChainID: chainID, Height: height, Time: time.Unix(height, 0).UTC()}
+		if _, err := cli.BeginBlockSync(abci.RequestBeginBlock{Header: header}); err != nil {
+			return 0, 0, err
+		}
+		for _, tx := range txs {
+			cli.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx})
+		}
+		if _, err := cli.EndBlockSync(abci.RequestEndBlock{Height: height}); err != nil {
+			return 0, 0, err
+		}
+		deliver = time.Since(start)
+
+		start = time.Now()
+		if _, err := cli.CommitSync(); err != nil {
+			return 0, 0, err
+		}
+		return deliver, time.Since(start), nil
+	}
+
+	// the txs are checked at the height of the last block, the account numbers are not checked
+	// as expected before the first one
+	if _, _, err := produceBlock(nil); err != nil {
+		return result, err
+	}
+
+	next := 0
+	txs := make([][]byte, cfg.TxsPerBlock)
+	reqRess := make([]*abcicli.ReqRes, cfg.TxsPerBlock)
+	for b := 0; b < cfg.NumBlocks; b++ {
+		// the senders are taken in turn so that the txs of an account are in order
+		for i := range txs {
+			from := &accs[next]
+			next = (next + 1) % len(accs)
+			tx := GenTx([]sdk.Msg{pickMsg(*from)}, []int64{from.AccNum}, []int64{from.Sequence}, from.PrivKey)
+			from.Sequence++
+			if txs[i], err = app.Cdc.MarshalBinaryLengthPrefixed(tx); err != nil {
+				return result, err
+			}
+		}
+
+		start := time.Now()
+		for i, tx := range txs {
+			reqRess[i] = cli.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
+		}
+		okTxs := make([][]byte, 0, len(txs))
+		for i, reqRes := range reqRess {
+			reqRes.Wait()
+			if reqRes.Response.GetCheckTx().Code == abci.CodeTypeOK {
+				okTxs = append(okTxs, txs[i])
+			}
+		}
+		result.CheckTx += time.Since(start)
+
+		deliver, commit, err := produceBlock(okTxs)
+		if err != nil {
+			return result, err
+		}
+		result.DeliverTx += deliver
+		result.Commit += commit
+
+		result.Blocks++
+		result.CheckedTxs += len(txs)
+		result.DeliveredTxs += len(okTxs)
+		if len(okTxs) < len(txs) {
+			ctx := app.NewContext(sdk.RunTxModeCheck, abci.Header{})
+			for i := range accs {
+				accs[i].Sequence = app.AccountKeeper.GetAccount(ctx, accs[i].Address).GetSequence()
+			}
+		}
+	}
+	return result, nil
+}
@@ -6,10 +6,22 @@ import (
 )
 
 const (
-	PendingStatusText = types.PendingStatusText
-	SuccessStatusText = types.SuccessStatusText
-	FailedStatusText  = types.FailedStatusText
-	DefaultParamSpace = keeper.DefaultParamSpace
+	PendingStatusText         = types.PendingStatusText
+	SuccessStatusText         = types.SuccessStatusText
+	FailedStatusText          = types.FailedStatusText
+	ExecutionFailedStatusText = types.ExecutionFailedStatusText
+	DefaultParamSpace         = keeper.DefaultParamSpace
+
+	QueryProphecyNonVoters = types.QueryProphecyNonVoters
+	QueryReceiveSequence   = types.QueryReceiveSequence
+	QueryProphecy          = types.QueryProphecy
+	QueryClaimTypes        = types.QueryClaimTypes
+	QuerySequences         = types.QuerySequences
+	QueryPendingClaims     = types.QueryPendingClaims
+
+	MismatchStale = types.MismatchStale
+	MismatchStuck = types.MismatchStuck
+	MismatchAhead = types.MismatchAhead
 )
 
 var (
@@ -26,9 +38,15 @@ var (
 	ErrInvalidClaim                  = types.ErrInvalidClaim
 	ErrInvalidValidator              = types.ErrInvalidValidator
 	ErrInternalDB                    = types.ErrInternalDB
+	ErrRelayerNotAllowed             = types.ErrRelayerNotAllowed
+	ErrInvalidSequenceRepair         = types.ErrInvalidSequenceRepair
 
-	NewProphecy = types.NewProphecy
-	NewStatus   = types.NewStatus
+	NewRelayerAllowListHooks = keeper.NewRelayerAllowListHooks
+	NewSkipSequenceHooks     = keeper.NewSkipSequenceHooks
+
+	NewProphecy            = types.NewProphecy
+	NewItemMatchAggregator = types.NewItemMatchAggregator
+	NewStatus              = types.NewStatus
 
 	// variable aliases
 	StatusTextToString = types.StatusTextToString
@@ -37,6 +55,8 @@ var (
 	NewClaimMsg = types.NewClaimMsg
 	RouteOracle = types.RouteOracle
 	GetClaimId  = types.GetClaimId
+
+	ParseClaimId = types.ParseClaimId
 )
 
 type (
@@ -48,4 +68,35 @@ type (
 	StatusText = types.StatusText
 
 	ClaimMsg = types.ClaimMsg
+
+	ClaimType             = types.ClaimType
+	RelayerAllowList      = types.RelayerAllowList
+	RelayerAllowListHooks = keeper.RelayerAllowListHooks
+	SkipSequenceHooks     = keeper.SkipSequenceHooks
+	SkipSequence          = types.SkipSequence
+
+	WeightedClaim        = types.WeightedClaim
+	ClaimAggregator      = types.ClaimAggregator
+	ClaimItemCodec       = types.ClaimItemCodec
+	ExactMatchAggregator = types.ExactMatchAggregator
+	ItemMatchAggregator  = types.ItemMatchAggregator
+
+	QueryProphecyNonVotersParams = types.QueryProphecyNonVotersParams
+	NonVoter                     = types.NonVoter
+	ProphecyNonVoters            = types.ProphecyNonVoters
+	QueryReceiveSequenceParams   = types.QueryReceiveSequenceParams
+	QueryProphecyParams          = types.QueryProphecyParams
+	QuerySequencesParams         = types.QuerySequencesParams
+	QueryPendingClaimsParams     = types.QueryPendingClaimsParams
+	ValidatorClaim               = types.ValidatorClaim
+	ProphecyInfo                 = types.ProphecyInfo
+	ClaimTypeInfo                = types.ClaimTypeInfo
+	ClaimSequence                = types.ClaimSequence
+	PendingClaim                 = types.PendingClaim
+	PowerSnapshot                = types.PowerSnapshot
+	ValidatorPower               = types.ValidatorPower
+
+	SequenceMismatch     = types.SequenceMismatch
+	SequenceRepair       = types.SequenceRepair
+	SequenceRepairRecord = types.SequenceRepairRecord
 )
@@ -0,0 +1,301 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+const (
+	flagClaimFile     = "claim-file"
+	flagMaxRetries    = "max-retries"
+	flagRetryInterval = "retry-interval"
+)
+
+// ClaimFile is a claim read from a file. A claim without sequence is made for the current receive
+// sequence of its chain, which is looked up before every attempt.
+type ClaimFile struct {
+	ChainId  sdk.ChainID `json:"chain_id"`
+	Sequence *uint64     `json:"sequence,omitempty"`
+	Payload  string      `json:"payload"` // hex encoded
+}
+
+// ReadClaimFile reads and checks a claim file
+func ReadClaimFile(file string) (ClaimFile, []byte, error) {
+	var claim ClaimFile
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return claim, nil, err
+	}
+	if err := json.Unmarshal(bz, &claim); err != nil {
+		return claim, nil, errors.Wrapf(err, "invalid claim file %s", file)
+	}
+	payload, err := hex.DecodeString(claim.Payload)
+	if err != nil {
+		return claim, nil, errors.Wrapf(err, "invalid payload in claim file %s", file)
+	}
+	if len(payload) == 0 {
+		return claim, nil, fmt.Errorf("empty payload in claim file %s", file)
+	}
+	return claim, payload, nil
+}
+
+// GetCmdSubmitClaim signs and broadcasts the claim of a file
+func GetCmdSubmitClaim(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-claim",
+		Short: "Sign and submit the oracle claim of a file",
+		Long: `Sign and submit the oracle claim of a file, a JSON object with the chain_id, the hex encoded
+payload and optionally the sequence of the claim. A claim without sequence is made for the current
+receive sequence of the chain. The submission is retried when the sequence of the account or of the
+claim mismatches. With --generate-only the unsigned transaction is printed to be signed offline,
+the sequence of the claim must then be in the file if --offline is set.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitClaimFiles(cdc, []string{viper.GetString(flagClaimFile)})
+		},
+	}
+	cmd.Flags().String(flagClaimFile, "", "File of the claim to submit")
+	cmd.MarkFlagRequired(flagClaimFile)
+	addRetryFlags(cmd)
+	return cmd
+}
+
+// GetCmdBatchSubmitClaims signs and broadcasts the claims of files one after another
+func GetCmdBatchSubmitClaims(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-submit [claim-file...]",
+		Short: "Sign and submit the oracle claims of files in order",
+		Long: `Sign and submit the oracle claims of files in order, in a transaction each, see submit-claim
+for the format of the files. A claim whose sequence was already processed is skipped, the batch
+stops at the first claim that fails.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitClaimFiles(cdc, args)
+		},
+	}
+	addRetryFlags(cmd)
+	return cmd
+}
+
+// GetCmdQueryReceiveSequence queries the sequence of the next claim accepted from a chain
+func GetCmdQueryReceiveSequence(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "receive-sequence [chain-id]",
+		Short: "Query the sequence of the next oracle claim accepted from a chain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainId, err := sdk.ParseChainID(args[0])
+			if err != nil {
+				return err
+			}
+			sequence, err := queryReceiveSequence(context.NewCLIContext().WithCodec(cdc), chainId)
+			if err != nil {
+				return err
+			}
+			fmt.Println(sequence)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func addRetryFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(flagMaxRetries, 3, "Max number of retries of a claim on a sequence mismatch")
+	cmd.Flags().Duration(flagRetryInterval, 3*time.Second, "Time to wait before retrying a claim ahead of the receive sequence")
+}
+
+func queryReceiveSequence(cliCtx context.CLIContext, chainId sdk.ChainID) (uint64, error) {
+	bz, err := cliCtx.Codec.MarshalJSON(types.QueryReceiveSequenceParams{ChainId: chainId})
+	if err != nil {
+		return 0, err
+	}
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.RouteOracle, types.QueryReceiveSequence), bz)
+	if err != nil {
+		return 0, err
+	}
+	var sequence uint64
+	if err := cliCtx.Codec.UnmarshalJSON(res, &sequence); err != nil {
+		return 0, err
+	}
+	return sequence, nil
+}
+
+func submitClaimFiles(cdc *codec.Codec, files []string) error {
+	claims := make([]ClaimFile, len(files))
+	payloads := make([][]byte, len(files))
+	for i, file := range files {
+		var err error
+		if claims[i], payloads[i], err = ReadClaimFile(file); err != nil {
+			return err
+		}
+	}
+
+	txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+	from, err := cliCtx.GetFromAddress()
+	if err != nil {
+		return err
+	}
+
+	if cliCtx.GenerateOnly {
+		msgs := make([]sdk.Msg, len(claims))
+		for i, claim := range claims {
+			sequence, err := claimSequence(cliCtx, claim)
+			if err != nil {
+				return err
+			}
+			msgs[i] = types.NewClaimMsg(claim.ChainId, sequence, payloads[i], from)
+		}
+		return utils.PrintUnsignedStdTx(txBldr, cliCtx, msgs)
+	}
+
+	name, err := cliCtx.GetFromName()
+	if err != nil {
+		return err
+	}
+	passphrase, err := keys.GetPassphrase(name)
+	if err != nil {
+		return err
+	}
+
+	for i, claim := range claims {
+		if err := submitClaim(txBldr, cliCtx, name, passphrase, from, claim, payloads[i]); err != nil {
+			return errors.Wrapf(err, "claim %s not submitted", files[i])
+		}
+	}
+	return nil
+}
+
+func claimSequence(cliCtx context.CLIContext, claim ClaimFile) (uint64, error) {
+	if claim.Sequence != nil {
+		return *claim.Sequence, nil
+	}
+	if viper.GetBool(client.FlagOffline) {
+		return 0, fmt.Errorf("the sequence of the claim is required offline")
+	}
+	return queryReceiveSequence(cliCtx, claim.ChainId)
+}
+
+// submitClaim broadcasts a claim, the account number and sequence are looked up again before a retry
+func submitClaim(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name, passphrase string,
+	from sdk.AccAddress, claim ClaimFile, payload []byte) error {
+	maxRetries := viper.GetInt(flagMaxRetries)
+	for attempt := 0; ; attempt++ {
+		sequence, err := claimSequence(cliCtx, claim)
+		if err != nil {
+			return err
+		}
+
+		bldr := txBldr
+		if bldr.AccountNumber == 0 {
+			accNum, err := cliCtx.GetAccountNumber(from)
+			if err != nil {
+				return err
+			}
+			bldr = bldr.WithAccountNumber(accNum)
+		}
+		if bldr.Sequence == 0 || attempt > 0 {
+			accSeq, err := cliCtx.GetLaneSequence(from, bldr.Lane)
+			if err != nil {
+				return err
+			}
+			bldr = bldr.WithSequence(accSeq)
+		}
+
+		msg := types.NewClaimMsg(claim.ChainId, sequence, payload, from)
+		txBytes, err := bldr.BuildAndSign(name, passphrase, []sdk.Msg{msg})
+		if err != nil {
+			return err
+		}
+		res, err := cliCtx.BroadcastTx(txBytes)
+		if err == nil {
+			return nil
+		}
+
+		mismatch := sequenceMismatchOf(res)
+		if mismatch == noMismatch || attempt >= maxRetries {
+			return err
+		}
+		if mismatch == claimMismatch && claim.Sequence != nil {
+			current, qErr := queryReceiveSequence(cliCtx, claim.ChainId)
+			if qErr != nil {
+				return qErr
+			}
+			if current > *claim.Sequence {
+				fmt.Printf("Skipped the claim of sequence %d, the receive sequence is %d already\n", *claim.Sequence, current)
+				return nil
+			}
+			// the claims before this one have not reached consensus yet
+			time.Sleep(viper.GetDuration(flagRetryInterval))
+		}
+		fmt.Printf("Retrying the claim of sequence %d: %s\n", sequence, err.Error())
+	}
+}
+
+type sequenceMismatch int
+
+const (
+	noMismatch sequenceMismatch = iota
+	accountMismatch
+	claimMismatch
+)
+
+// sequenceMismatchOf tells whether a transaction was rejected for the sequence of its account or of its claim
+func sequenceMismatchOf(res *ctypes.ResultBroadcastTxCommit) sequenceMismatch {
+	if res == nil {
+		return noMismatch
+	}
+	for _, code := range []uint32{res.CheckTx.Code, res.DeliverTx.Code} {
+		switch code {
+		case uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence)):
+			return accountMismatch
+		case uint32(sdk.ToABCICode(types.DefaultCodespace, types.CodeInvalidSequence)):
+			return claimMismatch
+		}
+	}
+	return noMismatch
+}
+
+// AddCommands adds the oracle commands of relayers to root
+func AddCommands(root *cobra.Command, cdc *codec.Codec) {
+	oracleCmd := &cobra.Command{
+		Use:   "oracle",
+		Short: "oracle claims of relayers",
+	}
+
+	oracleCmd.AddCommand(
+		client.PostCommands(
+			GetCmdSubmitClaim(cdc),
+			GetCmdBatchSubmitClaims(cdc),
+		)...)
+
+	oracleCmd.AddCommand(
+		client.GetCommands(
+			GetCmdQueryReceiveSequence(cdc),
+			GetCmdQuerySequences(cdc),
+			GetCmdQueryClaimTypes(cdc),
+			GetCmdQueryProphecy(cdc),
+			GetCmdQueryPendingClaims(cdc),
+		)...)
+
+	root.AddCommand(oracleCmd)
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func TestReadClaimFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+		return file
+	}
+
+	claim, payload, err := ReadClaimFile(write("auto.json", `{"chain_id":97,"payload":"0a0b"}`))
+	require.NoError(t, err)
+	require.Equal(t, sdk.ChainID(97), claim.ChainId)
+	require.Nil(t, claim.Sequence)
+	require.Equal(t, []byte{0x0a, 0x0b}, payload)
+
+	claim, _, err = ReadClaimFile(write("fixed.json", `{"chain_id":97,"sequence":0,"payload":"0a"}`))
+	require.NoError(t, err)
+	require.NotNil(t, claim.Sequence)
+	require.Equal(t, uint64(0), *claim.Sequence)
+
+	_, _, err = ReadClaimFile(write("hex.json", `{"chain_id":97,"payload":"zz"}`))
+	require.Error(t, err)
+	_, _, err = ReadClaimFile(write("empty.json", `{"chain_id":97,"payload":""}`))
+	require.Error(t, err)
+	_, _, err = ReadClaimFile(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
+
+func TestSequenceMismatchOf(t *testing.T) {
+	require.Equal(t, noMismatch, sequenceMismatchOf(nil))
+
+	res := &ctypes.ResultBroadcastTxCommit{CheckTx: abci.ResponseCheckTx{Code: uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence))}}
+	require.Equal(t, accountMismatch, sequenceMismatchOf(res))
+
+	res = &ctypes.ResultBroadcastTxCommit{DeliverTx: abci.ResponseDeliverTx{Code: uint32(sdk.ToABCICode(types.DefaultCodespace, types.CodeInvalidSequence))}}
+	require.Equal(t, claimMismatch, sequenceMismatchOf(res))
+
+	res = &ctypes.ResultBroadcastTxCommit{CheckTx: abci.ResponseCheckTx{Code: uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInsufficientFee))}}
+	require.Equal(t, noMismatch, sequenceMismatchOf(res))
+}
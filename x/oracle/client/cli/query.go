@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// GetCmdQueryProphecy queries a prophecy and its claims
+func GetCmdQueryProphecy(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "prophecy [prophecy-id]",
+		Short: "Query an oracle prophecy and its claims",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryOracle(cdc, types.QueryProphecy, types.QueryProphecyParams{ProphecyID: args[0]})
+		},
+	}
+}
+
+// GetCmdQueryClaimTypes queries the registered claim types
+func GetCmdQueryClaimTypes(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim-types",
+		Short: "Query the registered oracle claim types",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryOracle(cdc, types.QueryClaimTypes, nil)
+		},
+	}
+}
+
+// GetCmdQuerySequences queries the receive sequence of every claim type of a chain
+func GetCmdQuerySequences(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sequences [chain-id]",
+		Short: "Query the sequence of the next oracle claim of every type accepted from a chain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainId, err := sdk.ParseChainID(args[0])
+			if err != nil {
+				return err
+			}
+			return queryOracle(cdc, types.QuerySequences, types.QuerySequencesParams{ChainId: chainId})
+		},
+	}
+}
+
+// GetCmdQueryPendingClaims queries the claims of a validator on the pending prophecies
+func GetCmdQueryPendingClaims(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pending-claims [validator-addr]",
+		Short: "Query the claims of a validator on the pending oracle prophecies",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			return queryOracle(cdc, types.QueryPendingClaims, types.QueryPendingClaimsParams{ValidatorAddr: valAddr})
+		},
+	}
+}
+
+func queryOracle(cdc *codec.Codec, endpoint string, params interface{}) error {
+	var bz []byte
+	if params != nil {
+		var err error
+		if bz, err = cdc.MarshalJSON(params); err != nil {
+			return err
+		}
+	}
+
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.RouteOracle, endpoint), bz)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(res))
+	return nil
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/keeper"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+const (
+	flagOutput  = "output"
+	flagKeyHome = "key-home"
+)
+
+// StateLoader opens the latest committed state of the node at home. The returned
+// context is read only, nothing is committed.
+type StateLoader func(home string) (sdk.Context, keeper.Keeper, error)
+
+// GetCmdRepairSequences inspects the oracle prophecies against the receive sequences of their
+// channels and, once the operator confirms, writes a signed repair for them. The repair is
+// applied by every node registering it with oracle.RegisterSequenceRepair at an upgrade height.
+func GetCmdRepairSequences(cdc *codec.Codec, loadState StateLoader) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repair-oracle-sequences",
+		Short: "Find the oracle prophecies mismatching their channel sequences and sign a repair",
+		Long: `Find the oracle prophecies left behind by a crash: prophecies below the receive sequence
+of their channel, above it, or finalized at it so that no claim can be made anymore.
+With --output, the operator confirms and signs a repair removing them. The node must be stopped.
+The repair is only applied if --from is the operator key of a bonded validator.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, k, err := loadState(viper.GetString(cli.HomeFlag))
+			if err != nil {
+				return err
+			}
+			mismatches := k.InspectSequences(ctx)
+			if len(mismatches) == 0 {
+				fmt.Printf("No mismatch found at height %d\n", ctx.BlockHeight())
+				return nil
+			}
+			fmt.Printf("Found %d mismatches at height %d:\n", len(mismatches), ctx.BlockHeight())
+			for _, mismatch := range mismatches {
+				fmt.Println(mismatch.String())
+			}
+
+			output := viper.GetString(flagOutput)
+			if output == "" {
+				return nil
+			}
+			buf := client.BufferStdin()
+			confirmed, err := client.GetConfirmation("Sign a repair removing these prophecies?", buf)
+			if err != nil || !confirmed {
+				return err
+			}
+
+			repair := types.SequenceRepair{Height: ctx.BlockHeight(), Mismatches: mismatches}
+			if repair, err = signRepair(repair, viper.GetString(client.FlagFrom), buf); err != nil {
+				return err
+			}
+			bz, err := codec.MarshalJSONIndent(cdc, repair)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(output, bz, 0644); err != nil {
+				return err
+			}
+			fmt.Printf("Repair signed by %s written to %s\n", repair.Signer, output)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagOutput, "", "File to write the signed repair to, only inspect if empty")
+	cmd.Flags().String(client.FlagFrom, "", "Name of the validator operator key to sign the repair with")
+	cmd.Flags().String(flagKeyHome, os.ExpandEnv("$HOME/.gaiacli"), "Directory of the keybase")
+	return cmd
+}
+
+func signRepair(repair types.SequenceRepair, name string, buf *bufio.Reader) (types.SequenceRepair, error) {
+	kb, err := keys.GetKeyBaseFromDir(viper.GetString(flagKeyHome))
+	if err != nil {
+		return repair, err
+	}
+	info, err := kb.Get(name)
+	if err != nil {
+		return repair, err
+	}
+	passphrase, err := client.GetPassword(fmt.Sprintf("Password to sign with '%s':", name), buf)
+	if err != nil {
+		return repair, err
+	}
+
+	repair.Signer = sdk.AccAddress(info.GetPubKey().Address())
+	repair.PubKey = info.GetPubKey().Bytes()
+	if repair.Signature, _, err = kb.Sign(name, passphrase, repair.SignBytes()); err != nil {
+		return repair, err
+	}
+	return repair, repair.Verify()
+}
+
+// ReadSequenceRepair reads a repair written by GetCmdRepairSequences and checks its signature
+func ReadSequenceRepair(cdc *codec.Codec, file string) (types.SequenceRepair, error) {
+	var repair types.SequenceRepair
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return repair, err
+	}
+	if err := cdc.UnmarshalJSON(bz, &repair); err != nil {
+		return repair, err
+	}
+	return repair, repair.Verify()
+}
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// RegisterRoutes registers oracle-related REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(
+		"/oracle/prophecies/{prophecyID}",
+		prophecyHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/oracle/claim_types",
+		queryHandlerFn(cliCtx, cdc, types.QueryClaimTypes, nil),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/oracle/chains/{chainID}/sequences",
+		sequencesHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/oracle/validators/{validatorAddr}/pending_claims",
+		pendingClaimsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+}
+
+func prophecyHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := types.QueryProphecyParams{ProphecyID: mux.Vars(r)["prophecyID"]}
+		queryHandlerFn(cliCtx, cdc, types.QueryProphecy, params)(w, r)
+	}
+}
+
+func sequencesHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		chainId, err := sdk.ParseChainID(mux.Vars(r)["chainID"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		queryHandlerFn(cliCtx, cdc, types.QuerySequences, types.QuerySequencesParams{ChainId: chainId})(w, r)
+	}
+}
+
+func pendingClaimsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		valAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params := types.QueryPendingClaimsParams{ValidatorAddr: valAddr}
+		queryHandlerFn(cliCtx, cdc, types.QueryPendingClaims, params)(w, r)
+	}
+}
+
+func queryHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string, params interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var bz []byte
+		if params != nil {
+			var err error
+			if bz, err = cdc.MarshalJSON(params); err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.RouteOracle, endpoint), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/oracle/types"
 	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
 )
@@ -26,6 +27,8 @@ func NewHandler(keeper Keeper) sdk.Handler {
 }
 
 func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Result {
+	// collects the prophecy lifecycle events emitted by the keeper
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
 	claim := NewClaim(types.GetClaimId(msg.ChainId, types.RelayPackagesChannelId, msg.Sequence),
 		sdk.ValAddress(msg.ValidatorAddress), hex.EncodeToString(msg.Payload))
 
@@ -34,6 +37,10 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 		return types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", types.RelayPackagesChannelId, sequence)).Result()
 	}
 
+	if sdkErr := oracleKeeper.CheckRelayer(ctx, types.RelayPackagesChannelId, sdk.ValAddress(msg.ValidatorAddress)); sdkErr != nil {
+		return sdkErr.Result()
+	}
+
 	prophecy, sdkErr := oracleKeeper.ProcessClaim(ctx, claim)
 	if sdkErr != nil {
 		return sdkErr.Result()
@@ -41,22 +48,54 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 
 	if prophecy.Status.Text == types.FailedStatusText {
 		oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
-		return sdk.Result{}
+		return sdk.Result{Events: ctx.EventManager().Events()}
 	}
 
 	if prophecy.Status.Text != types.SuccessStatusText {
-		return sdk.Result{}
+		return sdk.Result{Events: ctx.EventManager().Events()}
 	}
 
-	packages := types.Packages{}
-	err := rlp.DecodeBytes(msg.Payload, &packages)
-	if err != nil {
-		return types.ErrInvalidPayload("decode packages error").Result()
+	var packages types.Packages
+	if sdk.IsUpgrade(sdk.OracleClaimCheck) {
+		// reject a final payload that cannot be executed before applying any of its packages,
+		// the prophecy is kept so that it is not claimed again until it expires or a sequence repair
+		packages, sdkErr = oracleKeeper.ValidateClaimPackages(ctx, msg.ChainId, msg.Payload)
+		if sdkErr != nil {
+			oracleKeeper.SetProphecyExecutionFailed(ctx, prophecy)
+			ctx.Logger().With("module", "oracle").Error("claim validation failed",
+				"prophecy", prophecy.ID, "err", sdkErr.Error())
+			return sdk.Result{
+				Events: ctx.EventManager().Events().AppendEvent(sdk.NewEvent(types.EventTypeClaimExecutionFailed,
+					sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+					sdk.NewAttribute(types.ClaimFailureReason, sdkErr.Error()),
+				)),
+			}
+		}
+	} else {
+		err := rlp.DecodeBytes(msg.Payload, &packages)
+		if err != nil {
+			return types.ErrInvalidPayload("decode packages error").Result()
+		}
+	}
+
+	lifecycleEvents := ctx.EventManager().Events()
+	result := applyClaim(ctx, oracleKeeper, msg.ChainId, prophecy, packages, msg.ValidatorAddress)
+	if !result.IsOK() {
+		return result
 	}
+	result.Events = lifecycleEvents.AppendEvents(result.Events).AppendEvent(sdk.NewEvent(types.EventTypeClaimExecuted,
+		sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+		sdk.NewAttribute(types.ClaimPackageCount, strconv.Itoa(len(packages))),
+	))
+	return result
+}
 
-	events := make([]sdk.Event, 0, len(packages))
+// applyClaim executes the packages of a prophecy that reached consensus, relayer is the sender of the claim
+// making the prophecy succeed
+func applyClaim(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, prophecy types.Prophecy, packages types.Packages, relayer sdk.AccAddress) sdk.Result {
+	events := make(sdk.Events, 0, 2*len(packages))
 	for _, pack := range packages {
-		event, sdkErr := handlePackage(ctx, oracleKeeper, msg.ChainId, &pack)
+		packageEvents, sdkErr := handlePackage(ctx, oracleKeeper, chainId, &pack, relayer)
 		if sdkErr != nil {
 			// only do log, but let reset package get chance to execute.
 			ctx.Logger().With("module", "oracle").Error(fmt.Sprintf("process package failed, channel=%d, sequence=%d, error=%v", pack.ChannelId, pack.Sequence, sdkErr))
@@ -64,82 +103,75 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 		} else {
 			ctx.Logger().With("module", "oracle").Info(fmt.Sprintf("process package success, channel=%d, sequence=%d", pack.ChannelId, pack.Sequence))
 		}
-		events = append(events, event)
+		events = events.AppendEvents(packageEvents)
 
 		// increase channel sequence
-		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, pack.ChannelId)
+		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, chainId, pack.ChannelId)
+	}
+
+	if sdk.IsUpgrade(sdk.OracleClaimIncentive) {
+		events = events.AppendEvents(oracleKeeper.SettleClaimIncentives(ctx, prophecy))
 	}
 
 	// delete prophecy when execute claim success
 	oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
-	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, types.RelayPackagesChannelId)
+	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, chainId, types.RelayPackagesChannelId)
 
 	return sdk.Result{
 		Events: events,
 	}
 }
 
-func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package) (sdk.Event, sdk.Error) {
+func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package, relayer sdk.AccAddress) (sdk.Events, sdk.Error) {
+	if sdk.IsUpgrade(sdk.IBCPackageRouting) {
+		return routePackage(ctx, oracleKeeper, chainId, pack, relayer)
+	}
 	logger := ctx.Logger().With("module", "x/oracle")
 
 	crossChainApp := oracleKeeper.ScKeeper.GetCrossChainApp(ctx, pack.ChannelId)
 	if crossChainApp == nil {
-		return sdk.Event{}, types.ErrChannelNotRegistered(fmt.Sprintf("channel %d not registered", pack.ChannelId))
+		return nil, types.ErrChannelNotRegistered(fmt.Sprintf("channel %d not registered", pack.ChannelId))
 	}
 
 	sequence := oracleKeeper.ScKeeper.GetReceiveSequence(ctx, chainId, pack.ChannelId)
 	if sequence != pack.Sequence {
-		return sdk.Event{}, types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", pack.ChannelId, sequence))
+		return nil, types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", pack.ChannelId, sequence))
 	}
 
-	packageType, relayFee, err := sTypes.DecodePackageHeader(pack.Payload)
+	packageType, relayFee, load, err := pack.Decode()
 	if err != nil {
-		return sdk.Event{}, types.ErrInvalidPayloadHeader(err.Error())
+		return nil, types.ErrInvalidPayloadHeader(err.Error())
 	}
 
 	if !sdk.IsValidCrossChainPackageType(packageType) {
-		return sdk.Event{}, types.ErrInvalidPackageType()
+		return nil, types.ErrInvalidPackageType()
 	}
 
 	feeAmount := relayFee.Int64()
 	if feeAmount < 0 {
-		return sdk.Event{}, types.ErrFeeOverflow("relayFee overflow")
+		return nil, types.ErrFeeOverflow("relayFee overflow")
 	}
 
-	fee := sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: feeAmount}}
-	_, _, sdkErr := oracleKeeper.BkKeeper.SubtractCoins(ctx, sdk.PegAccount, fee)
-	if sdkErr != nil {
-		return sdk.Event{}, sdkErr
+	if sdkErr := chargeRelayFee(ctx, oracleKeeper, pack, packageType, feeAmount); sdkErr != nil {
+		return nil, sdkErr
 	}
 
-	if ctx.IsDeliverTx() {
-		// add changed accounts
-		oracleKeeper.Pool.AddAddrs([]sdk.AccAddress{sdk.PegAccount})
-
-		// add fee
-		fees.Pool.AddAndCommitFee(
-			fmt.Sprintf("cross_communication:%d:%d:%v", pack.ChannelId, pack.Sequence, packageType),
-			sdk.Fee{
-				Tokens: fee,
-				Type:   sdk.FeeForProposer,
-			},
-		)
-	}
-
-	cacheCtx, write := ctx.CacheContext()
-	crash, result := executeClaim(cacheCtx, crossChainApp, pack.Payload, packageType, feeAmount)
-	if result.IsOk() {
-		write()
-	} else if ctx.IsDeliverTx() {
-		oracleKeeper.Metrics.ErrNumOfChannels.With("channel_id", fmt.Sprintf("%d", pack.ChannelId)).Add(1)
-		destChainName, err := oracleKeeper.ScKeeper.GetDestChainName(chainId)
-		if err != nil {
-			logger.Error("failed to find name of dest chain", "chainId", chainId)
+	var crash bool
+	var result sdk.ExecuteResult
+	if packageType != sdk.SynCrossChainPackageType && oracleKeeper.IbcKeeper.IsAckOfRefundedPackage(ctx, chainId, pack.ChannelId) {
+		logger.Info("ignore the ack of a refunded package", "channelID", pack.ChannelId, "sequence", pack.Sequence)
+	} else {
+		cacheCtx, write := ctx.CacheContext()
+		crash, result = executeClaim(cacheCtx, crossChainApp, load, packageType, feeAmount)
+		if result.IsOk() {
+			write()
 		} else {
-			oracleKeeper.PublishCrossAppFailEvent(ctx, sdk.PegAccount.String(), feeAmount, destChainName)
+			reportPackageFailure(ctx, oracleKeeper, chainId, pack.ChannelId, feeAmount)
 		}
 	}
 
+	settlePackageDelivery(ctx, oracleKeeper, chainId, pack.ChannelId, packageType, relayer)
+
 	// write ack package
 	var sendSequence int64 = -1
 	if packageType == sdk.SynCrossChainPackageType {
@@ -148,7 +180,7 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 			var sendSeq uint64
 			if sdk.IsUpgrade(sdk.FixFailAckPackage) && len(pack.Payload) >= sTypes.PackageHeaderLength {
 				sendSeq, ibcErr = oracleKeeper.IbcKeeper.CreateRawIBCPackageById(ctx, chainId,
-					pack.ChannelId, sdk.FailAckCrossChainPackageType, pack.Payload[sTypes.PackageHeaderLength:])
+					pack.ChannelId, sdk.FailAckCrossChainPackageType, load)
 			} else {
 				logger.Error("found payload without header", "channelID", pack.ChannelId, "sequence", pack.Sequence, "payload", hex.EncodeToString(pack.Payload))
 				sendSeq, ibcErr = oracleKeeper.IbcKeeper.CreateRawIBCPackageById(ctx, chainId,
@@ -156,7 +188,7 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 			}
 			if ibcErr != nil {
 				logger.Error("failed to write FailAckCrossChainPackage", "err", err)
-				return sdk.Event{}, ibcErr
+				return nil, ibcErr
 			}
 			sendSequence = int64(sendSeq)
 		} else {
@@ -165,13 +197,108 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 					pack.ChannelId, sdk.AckCrossChainPackageType, result.Payload)
 				if err != nil {
 					logger.Error("failed to write AckCrossChainPackage", "err", err)
-					return sdk.Event{}, err
+					return nil, err
 				}
 				sendSequence = int64(sendSeq)
 			}
 		}
 	}
 
+	return packageEvents(chainId, pack, packageType, feeAmount, result, crash, sendSequence), nil
+}
+
+// routePackage checks and dispatches a package by the ibc keeper to the handler of its channel after
+// sdk.IBCPackageRouting, the ibc keeper also answers a syn package with its ack
+func routePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package, relayer sdk.AccAddress) (sdk.Events, sdk.Error) {
+	received, sdkErr := oracleKeeper.IbcKeeper.CheckReceivedPackage(ctx, chainId, pack.ChannelId, pack.Sequence, pack.Payload)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	if sdkErr := chargeRelayFee(ctx, oracleKeeper, pack, received.Type, received.RelayFee); sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	receipt, sdkErr := oracleKeeper.IbcKeeper.DispatchPackage(ctx, received)
+	if sdkErr != nil {
+		ctx.Logger().With("module", "x/oracle").Error("failed to dispatch package", "channelID", pack.ChannelId,
+			"sequence", pack.Sequence, "err", sdkErr.Error())
+		return nil, sdkErr
+	}
+	if !receipt.Result.IsOk() {
+		reportPackageFailure(ctx, oracleKeeper, chainId, pack.ChannelId, received.RelayFee)
+	}
+
+	settlePackageDelivery(ctx, oracleKeeper, chainId, pack.ChannelId, received.Type, relayer)
+
+	return packageEvents(chainId, pack, received.Type, received.RelayFee, receipt.Result, receipt.Crash, receipt.AckSequence), nil
+}
+
+// chargeRelayFee pays the relay fee of a package out of the peg account to the block proposer
+func chargeRelayFee(ctx sdk.Context, oracleKeeper Keeper, pack *types.Package, packageType sdk.CrossChainPackageType, feeAmount int64) sdk.Error {
+	fee := sdk.Coins{sdk.Coin{Denom: sdk.NativeTokenSymbol, Amount: feeAmount}}
+	_, _, sdkErr := oracleKeeper.BkKeeper.SubtractCoins(ctx, sdk.GetPegAccount(), fee)
+	if sdkErr != nil {
+		return sdkErr
+	}
+
+	if ctx.IsDeliverTx() {
+		// add changed accounts
+		oracleKeeper.Pool.AddAddrs([]sdk.AccAddress{sdk.GetPegAccount()})
+
+		// add fee
+		fees.Pool.AddAndCommitFee(
+			fmt.Sprintf("cross_communication:%d:%d:%v", pack.ChannelId, pack.Sequence, packageType),
+			sdk.Fee{
+				Tokens: fee,
+				Type:   sdk.FeeForProposer,
+			},
+		)
+	}
+	return nil
+}
+
+func reportPackageFailure(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, channelId sdk.ChannelID, feeAmount int64) {
+	if !ctx.IsDeliverTx() {
+		return
+	}
+	oracleKeeper.Metrics.ErrNumOfChannels.With("channel_id", fmt.Sprintf("%d", channelId)).Add(1)
+	destChainName, err := oracleKeeper.ScKeeper.LookupDestChainName(ctx, chainId)
+	if err != nil {
+		ctx.Logger().With("module", "x/oracle").Error("failed to find name of dest chain", "chainId", chainId)
+	} else {
+		oracleKeeper.PublishCrossAppFailEvent(ctx, sdk.GetPegAccount().String(), feeAmount, destChainName)
+	}
+}
+
+// settlePackageDelivery records the delivery of the outbound package acknowledged by an ack or fail ack package,
+// and settles the funds escrowed for it
+func settlePackageDelivery(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, channelId sdk.ChannelID,
+	packageType sdk.CrossChainPackageType, relayer sdk.AccAddress) {
+	if packageType == sdk.FailAckCrossChainPackageType {
+		oracleKeeper.IbcKeeper.RecordPackageFailed(ctx, chainId, channelId)
+	}
+
+	if packageType != sdk.SynCrossChainPackageType && sdk.IsUpgrade(sdk.IBCDeliveryWatermark) {
+		if watermark, ok := oracleKeeper.IbcKeeper.ConfirmPackageDelivery(ctx, chainId, channelId); ok {
+			oracleKeeper.IbcKeeper.SettlePackageFunds(ctx, chainId, channelId, watermark.Sequence,
+				packageType == sdk.FailAckCrossChainPackageType)
+
+			// the relayer delivering the ack of a package earns the relay fee escrowed for it, a failed payment
+			// is only logged so that it does not stall the channel
+			if sdk.IsUpgrade(sdk.IBCRelayFeeEscrow) {
+				if _, _, sdkErr := oracleKeeper.IbcKeeper.PayRelayFee(ctx, chainId, channelId, watermark.Sequence,
+					relayer); sdkErr != nil {
+					ctx.Logger().With("module", "x/oracle").Error("failed to pay relay fee", "channelID", channelId,
+						"sequence", watermark.Sequence, "relayer", relayer.String(), "err", sdkErr.Error())
+				}
+			}
+		}
+	}
+}
+
+func packageEvents(chainId sdk.ChainID, pack *types.Package, packageType sdk.CrossChainPackageType, feeAmount int64,
+	result sdk.ExecuteResult, crash bool, sendSequence int64) sdk.Events {
 	resultTags := sdk.NewTags(
 		types.ClaimResultCode, []byte(strconv.FormatInt(int64(result.Code()), 10)),
 		types.ClaimResultMsg, []byte(result.Msg()),
@@ -202,11 +329,12 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		Type:       types.EventTypeClaim,
 		Attributes: resultTags,
 	}
+	executedEvent := ibc.NewPackageExecutedEvent(chainId, pack.ChannelId, pack.Sequence, packageType, pack.Payload, result, crash)
 
-	return event, nil
+	return sdk.Events{event, executedEvent}
 }
 
-func executeClaim(ctx sdk.Context, app sdk.CrossChainApplication, payload []byte, packageType sdk.CrossChainPackageType, relayerFee int64) (crash bool, result sdk.ExecuteResult) {
+func executeClaim(ctx sdk.Context, app sdk.CrossChainApplication, load []byte, packageType sdk.CrossChainPackageType, relayerFee int64) (crash bool, result sdk.ExecuteResult) {
 	defer func() {
 		if r := recover(); r != nil {
 			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
@@ -221,11 +349,11 @@ func executeClaim(ctx sdk.Context, app sdk.CrossChainApplication, payload []byte
 
 	switch packageType {
 	case sdk.SynCrossChainPackageType:
-		result = app.ExecuteSynPackage(ctx, payload[sTypes.PackageHeaderLength:], relayerFee)
+		result = app.ExecuteSynPackage(ctx, load, relayerFee)
 	case sdk.AckCrossChainPackageType:
-		result = app.ExecuteAckPackage(ctx, payload[sTypes.PackageHeaderLength:])
+		result = app.ExecuteAckPackage(ctx, load)
 	case sdk.FailAckCrossChainPackageType:
-		result = app.ExecuteFailAckPackage(ctx, payload[sTypes.PackageHeaderLength:])
+		result = app.ExecuteFailAckPackage(ctx, load)
 	default:
 		panic(fmt.Sprintf("receive unexpected package type %d", packageType))
 	}
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// ValidateClaimPackages decodes the final payload of a claim and checks that all its packages can be
+// executed, it does not change the state. A payload passing the checks can still see some of its
+// packages fail in the cross chain apps, these failures are acknowledged to the side chain as usual.
+func (k Keeper) ValidateClaimPackages(ctx sdk.Context, chainId sdk.ChainID, payload []byte) (types.Packages, sdk.Error) {
+	packages := types.Packages{}
+	if err := rlp.DecodeBytes(payload, &packages); err != nil {
+		return nil, types.ErrInvalidPayload("decode packages error")
+	}
+
+	// the packages of a channel follow each other from its receive sequence
+	sequences := make(map[sdk.ChannelID]uint64)
+	var totalFee int64
+	for _, pack := range packages {
+		if !k.hasPackageHandler(ctx, pack.ChannelId) {
+			return nil, types.ErrChannelNotRegistered(fmt.Sprintf("channel %d not registered", pack.ChannelId))
+		}
+
+		sequence, ok := sequences[pack.ChannelId]
+		if !ok {
+			sequence = k.ScKeeper.GetReceiveSequence(ctx, chainId, pack.ChannelId)
+		}
+		if sequence != pack.Sequence {
+			return nil, types.ErrInvalidSequence(fmt.Sprintf("expected sequence %d of channel %d, got %d", sequence, pack.ChannelId, pack.Sequence))
+		}
+		sequences[pack.ChannelId] = sequence + 1
+
+		packageType, relayFee, _, err := pack.Decode()
+		if err != nil {
+			return nil, types.ErrInvalidPayloadHeader(err.Error())
+		}
+		if !sdk.IsValidCrossChainPackageType(packageType) {
+			return nil, types.ErrInvalidPackageType()
+		}
+		if !relayFee.IsInt64() || relayFee.Int64() < 0 || totalFee+relayFee.Int64() < totalFee {
+			return nil, types.ErrFeeOverflow("relayFee overflow")
+		}
+		totalFee += relayFee.Int64()
+	}
+
+	pegBalance := k.BkKeeper.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(sdk.NativeTokenSymbol)
+	if totalFee > pegBalance {
+		return nil, types.ErrFeeOverflow(fmt.Sprintf("relay fees %d exceed the balance %d of the peg account", totalFee, pegBalance))
+	}
+	return packages, nil
+}
+
+// SetProphecyExecutionFailed finalizes a prophecy whose packages failed the validation, it stays
+// at the receive sequence as a stuck prophecy until a sequence repair removes it. After the
+// ProphecyExpiration upgrade it also expires, so the relayers claim the sequence again.
+func (k Keeper) SetProphecyExecutionFailed(ctx sdk.Context, prophecy types.Prophecy) types.Prophecy {
+	prophecy.Status = types.NewStatus(types.ExecutionFailedStatusText, prophecy.Status.FinalClaim)
+	k.setProphecy(ctx, prophecy)
+	if sdk.IsUpgrade(sdk.ProphecyExpiration) {
+		k.dequeueProphecy(ctx, prophecy.ID)
+		k.enqueueExecutionFailedProphecy(ctx, prophecy.ID)
+	}
+	return prophecy
+}
+
+// hasPackageHandler tells whether the packages of a channel can be executed, after sdk.IBCPackageRouting they
+// are dispatched by the ibc keeper
+func (k Keeper) hasPackageHandler(ctx sdk.Context, channelID sdk.ChannelID) bool {
+	if sdk.IsUpgrade(sdk.IBCPackageRouting) {
+		return k.IbcKeeper.GetPackageHandler(ctx, channelID) != nil
+	}
+	return k.ScKeeper.GetCrossChainApp(ctx, channelID) != nil
+}
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+type nopCrossChainApp struct{}
+
+func (nopCrossChainApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (nopCrossChainApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (nopCrossChainApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func TestValidateClaimPackages(t *testing.T) {
+	mapp, ck, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	chainId := sdk.ChainID(0x0f)
+	channelId := sdk.ChannelID(0x03)
+	require.NoError(t, keeper.ScKeeper.RegisterChannel("test", channelId, nopCrossChainApp{}))
+	keeper.ScKeeper.IncrReceiveSequence(ctx, chainId, channelId)
+	_, _, err := ck.AddCoins(ctx, sdk.GetPegAccount(), sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100)})
+	require.Nil(t, err)
+
+	pack := func(channelId sdk.ChannelID, sequence uint64, packageType sdk.CrossChainPackageType, fee int64) types.Package {
+		header := sTypes.EncodePackageHeader(packageType, *big.NewInt(fee))
+		return types.Package{ChannelId: channelId, Sequence: sequence, Payload: append(header, 0x01)}
+	}
+	validate := func(packages ...types.Package) sdk.Error {
+		payload, err := rlp.EncodeToBytes(packages)
+		require.NoError(t, err)
+		decoded, sdkErr := keeper.ValidateClaimPackages(ctx, chainId, payload)
+		if sdkErr == nil {
+			require.Len(t, decoded, len(packages))
+		}
+		return sdkErr
+	}
+
+	require.Nil(t, validate(
+		pack(channelId, 1, sdk.SynCrossChainPackageType, 60),
+		pack(channelId, 2, sdk.AckCrossChainPackageType, 40),
+	))
+
+	_, sdkErr := keeper.ValidateClaimPackages(ctx, chainId, []byte{0xff, 0x01})
+	require.Equal(t, types.CodeInvalidPayload, sdkErr.Code())
+
+	sdkErr = validate(pack(sdk.ChannelID(0x04), 0, sdk.SynCrossChainPackageType, 0))
+	require.Equal(t, types.CodeChannelNotRegistered, sdkErr.Code())
+
+	// the sequences of a channel must follow each other
+	sdkErr = validate(pack(channelId, 1, sdk.SynCrossChainPackageType, 0), pack(channelId, 3, sdk.SynCrossChainPackageType, 0))
+	require.Equal(t, types.CodeInvalidSequence, sdkErr.Code())
+
+	sdkErr = validate(types.Package{ChannelId: channelId, Sequence: 1, Payload: []byte{0x00}})
+	require.Equal(t, types.CodeInvalidLengthOfPayload, sdkErr.Code())
+
+	sdkErr = validate(pack(channelId, 1, sdk.CrossChainPackageType(0x09), 0))
+	require.Equal(t, types.CodeInvalidClaim, sdkErr.Code())
+
+	// the fees cannot exceed what the peg account holds
+	sdkErr = validate(
+		pack(channelId, 1, sdk.SynCrossChainPackageType, 60),
+		pack(channelId, 2, sdk.SynCrossChainPackageType, 41),
+	)
+	require.Equal(t, types.CodeFeeOverflow, sdkErr.Code())
+
+	// nothing was applied by the validation
+	require.EqualValues(t, 1, keeper.ScKeeper.GetReceiveSequence(ctx, chainId, channelId))
+	require.EqualValues(t, 100, ck.GetCoins(ctx, sdk.GetPegAccount()).AmountOf(sdk.NativeTokenSymbol))
+}
+
+func TestSetProphecyExecutionFailed(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	prophecy := types.NewProphecy(types.GetClaimId(sdk.ChainID(0x0f), types.RelayPackagesChannelId, 0))
+	prophecy.Status = types.NewStatus(types.SuccessStatusText, TestString)
+	keeper.SetProphecyExecutionFailed(ctx, prophecy)
+
+	stored, found := keeper.GetProphecy(ctx, prophecy.ID)
+	require.True(t, found)
+	require.Equal(t, types.ExecutionFailedStatusText, stored.Status.Text)
+	require.Equal(t, TestString, stored.Status.FinalClaim)
+
+	// the prophecy is stuck at the receive sequence and can be repaired
+	mismatches := keeper.InspectSequences(ctx)
+	require.Len(t, mismatches, 1)
+	require.Equal(t, types.MismatchStuck, mismatches[0].Kind)
+	require.Equal(t, "execution_failed", mismatches[0].Status)
+
+	_, sdkErr := keeper.ProcessClaim(ctx, types.NewClaim(prophecy.ID, sdk.ValAddress([]byte("val")), TestString))
+	require.NotNil(t, sdkErr)
+}
+
+func TestExpireExecutionFailedProphecy(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyExpiration, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ProphecyExpiration)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	now := time.Unix(1600000000, 0).UTC()
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Time: now})
+
+	// retried after the default expiration when the pending prophecies never expire
+	prophecy := types.NewProphecy(types.GetClaimId(sdk.ChainID(0x0f), types.RelayPackagesChannelId, 0))
+	prophecy.Status = types.NewStatus(types.SuccessStatusText, TestString)
+	keeper.SetProphecyExecutionFailed(ctx, prophecy)
+	expireAt, found := keeper.GetProphecyExpiry(ctx, prophecy.ID)
+	require.True(t, found)
+	require.Equal(t, now.Add(types.DefaultProphecyExpiration), expireAt)
+
+	require.Empty(t, keeper.ExpireProphecies(ctx.WithBlockTime(expireAt.Add(-time.Second))))
+	events := keeper.ExpireProphecies(ctx.WithBlockTime(expireAt))
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeProphecyExpired, events[0].Type)
+	_, found = keeper.GetProphecy(ctx, prophecy.ID)
+	require.False(t, found)
+
+	// the sequence can be claimed again
+	require.Empty(t, keeper.InspectSequences(ctx))
+}
@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// claimPayloadPrefix keeps the claim payloads referred to by the prophecies apart from the other records
+var claimPayloadPrefix = []byte{0x03}
+
+// claimPayloadsKey is the prefix of the payloads of a prophecy, the ids do not contain a zero byte
+func claimPayloadsKey(id string) []byte {
+	key := append(append([]byte{}, claimPayloadPrefix...), id...)
+	return append(key, 0x00)
+}
+
+func claimPayloadKey(id string, hash string) []byte {
+	return append(claimPayloadsKey(id), hash...)
+}
+
+// GetMaxClaimSize returns the max bytes of a claim payload, 0 for no limit below MaxClaimContentSize
+func (k Keeper) GetMaxClaimSize(ctx sdk.Context) (maxSize int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyMaxClaimSize, &maxSize)
+	return
+}
+
+// CheckClaimSize rejects a claim whose content exceeds the max claim size after sdk.ClaimSizeLimit,
+// ClaimMsg.ValidateBasic has already rejected the ones above MaxClaimContentSize
+func (k Keeper) CheckClaimSize(ctx sdk.Context, claim types.Claim) sdk.Error {
+	if !sdk.IsUpgrade(sdk.ClaimSizeLimit) {
+		return nil
+	}
+	if maxSize := k.GetMaxClaimSize(ctx); maxSize > 0 && int64(len(claim.Payload)) > maxSize {
+		return types.ErrClaimTooLarge(len(claim.Payload), maxSize)
+	}
+	return nil
+}
+
+func (k Keeper) getClaimPayload(ctx sdk.Context, id string, hash string) (string, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(claimPayloadKey(id, hash))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// setClaimPayloads stores every distinct payload of a prophecy once, and deletes the ones
+// no validator claims any more
+func (k Keeper) setClaimPayloads(ctx sdk.Context, id string, payloads map[string]string) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := claimPayloadsKey(id)
+	stored := make(map[string]bool)
+	var stale [][]byte
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iter.Valid(); iter.Next() {
+		hash := string(iter.Key()[len(prefix):])
+		if _, ok := payloads[hash]; ok {
+			stored[hash] = true
+		} else {
+			stale = append(stale, iter.Key())
+		}
+	}
+	iter.Close()
+
+	for _, key := range stale {
+		store.Delete(key)
+	}
+	for hash, payload := range payloads {
+		if !stored[hash] {
+			store.Set(claimPayloadKey(id, hash), []byte(payload))
+		}
+	}
+}
+
+// deleteClaimPayloads deletes the payloads of a prophecy
+func (k Keeper) deleteClaimPayloads(ctx sdk.Context, id string) {
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	iter := sdk.KVStorePrefixIterator(store, claimPayloadsKey(id))
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
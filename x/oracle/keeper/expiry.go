@@ -0,0 +1,192 @@
+package keeper
+
+import (
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+var (
+	// prophecyExpiryQueuePrefix orders the pending prophecies by the time they expire at
+	prophecyExpiryQueuePrefix = []byte{0x04}
+	// prophecyExpiryPrefix keeps the expiry time of each queued prophecy, so that a queue entry
+	// left by a deleted prophecy does not expire a new prophecy with the same id
+	prophecyExpiryPrefix = []byte{0x05}
+)
+
+func prophecyExpiryQueueTimeKey(expireAt time.Time) []byte {
+	return append(append([]byte{}, prophecyExpiryQueuePrefix...), sdk.FormatTimeBytes(expireAt)...)
+}
+
+// prophecyExpiryQueueKey is the queue key of a prophecy, the formatted times are of a fixed length
+func prophecyExpiryQueueKey(expireAt time.Time, id string) []byte {
+	return append(prophecyExpiryQueueTimeKey(expireAt), id...)
+}
+
+func prophecyExpiryKey(id string) []byte {
+	return append(append([]byte{}, prophecyExpiryPrefix...), id...)
+}
+
+// GetProphecyExpiration returns how long a prophecy waits for consensus, 0 if prophecies never expire
+func (k Keeper) GetProphecyExpiration(ctx sdk.Context) (expiration time.Duration) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProphecyExpiration, &expiration)
+	return
+}
+
+// GetSlashNonVoters returns whether the bonded validators which did not claim on an expired prophecy are slashed
+func (k Keeper) GetSlashNonVoters(ctx sdk.Context) (slash bool) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProphecySlashNonVoters, &slash)
+	return
+}
+
+// GetProphecyExpiry returns the time the pending prophecy with the given id expires at
+func (k Keeper) GetProphecyExpiry(ctx sdk.Context, id string) (time.Time, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(prophecyExpiryKey(id))
+	if bz == nil {
+		return time.Time{}, false
+	}
+	expireAt, err := sdk.ParseTimeBytes(bz)
+	if err != nil {
+		panic(err)
+	}
+	return expireAt, true
+}
+
+// enqueueProphecy schedules the expiry of a new prophecy, nothing is scheduled if prophecies never expire
+func (k Keeper) enqueueProphecy(ctx sdk.Context, id string) {
+	expiration := k.GetProphecyExpiration(ctx)
+	if expiration <= 0 {
+		return
+	}
+	k.setProphecyExpiry(ctx, id, ctx.BlockHeader().Time.Add(expiration))
+}
+
+// enqueueExecutionFailedProphecy schedules the expiry of a prophecy whose packages failed the validation.
+// It is retried even if the pending prophecies never expire, after the default expiration then.
+func (k Keeper) enqueueExecutionFailedProphecy(ctx sdk.Context, id string) {
+	expiration := k.GetProphecyExpiration(ctx)
+	if expiration <= 0 {
+		expiration = types.DefaultProphecyExpiration
+	}
+	k.setProphecyExpiry(ctx, id, ctx.BlockHeader().Time.Add(expiration))
+}
+
+func (k Keeper) setProphecyExpiry(ctx sdk.Context, id string, expireAt time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(prophecyExpiryQueueKey(expireAt, id), []byte{})
+	store.Set(prophecyExpiryKey(id), sdk.FormatTimeBytes(expireAt))
+}
+
+// dequeueProphecy removes the scheduled expiry of a prophecy
+func (k Keeper) dequeueProphecy(ctx sdk.Context, id string) {
+	expireAt, found := k.GetProphecyExpiry(ctx, id)
+	if !found {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(prophecyExpiryQueueKey(expireAt, id))
+	store.Delete(prophecyExpiryKey(id))
+}
+
+// EnqueuePendingProphecies schedules the expiry of the pending and execution failed prophecies which
+// are not queued yet
+func (k Keeper) EnqueuePendingProphecies(ctx sdk.Context) {
+	var pendingIds, failedIds []string
+	k.iterateProphecies(ctx, func(id string, prophecy types.Prophecy) bool {
+		if _, found := k.GetProphecyExpiry(ctx, id); found {
+			return false
+		}
+		switch prophecy.Status.Text {
+		case types.PendingStatusText:
+			pendingIds = append(pendingIds, id)
+		case types.ExecutionFailedStatusText:
+			failedIds = append(failedIds, id)
+		}
+		return false
+	})
+	for _, id := range pendingIds {
+		k.enqueueProphecy(ctx, id)
+	}
+	for _, id := range failedIds {
+		k.enqueueExecutionFailedProphecy(ctx, id)
+	}
+}
+
+// eligibleValidators returns the validators expected to claim on a prophecy, the ones of its snapshot
+// still known to the stake keeper if any, the current bonded validators otherwise
+func (k Keeper) eligibleValidators(ctx sdk.Context, prophecy types.Prophecy) []stake.Validator {
+	if prophecy.Snapshot == nil {
+		return k.stakeKeeper.GetBondedValidatorsByPower(ctx)
+	}
+	validators := make([]stake.Validator, 0, len(prophecy.Snapshot.Validators))
+	for _, power := range prophecy.Snapshot.Validators {
+		if validator, found := k.stakeKeeper.GetValidator(ctx, power.Validator); found {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
+
+// ExpireProphecies deletes the pending prophecies whose expiry time has passed, and slashes the
+// bonded validators which did not claim on them if the params ask to. The expired prophecies whose
+// packages failed the validation are deleted as well, so that their sequence is claimed again.
+func (k Keeper) ExpireProphecies(ctx sdk.Context) sdk.Events {
+	store := ctx.KVStore(k.storeKey)
+	var ids []string
+	var queueKeys [][]byte
+	timeKeyLen := len(prophecyExpiryQueueTimeKey(ctx.BlockHeader().Time))
+	iter := store.Iterator(prophecyExpiryQueuePrefix, sdk.PrefixEndBytes(prophecyExpiryQueueTimeKey(ctx.BlockHeader().Time)))
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		ids = append(ids, string(key[timeKeyLen:]))
+		queueKeys = append(queueKeys, key)
+	}
+	iter.Close()
+
+	slash := k.slashingKeeper != nil && k.GetSlashNonVoters(ctx)
+	var events sdk.Events
+	for i, id := range ids {
+		store.Delete(queueKeys[i])
+		store.Delete(prophecyExpiryKey(id))
+
+		prophecy, found := k.GetProphecy(ctx, id)
+		if !found {
+			continue
+		}
+		if prophecy.Status.Text == types.ExecutionFailedStatusText {
+			// the validators reached consensus on it, none is a non-voter
+			k.DeleteProphecy(ctx, id)
+			ctx.Logger().With("module", "x/oracle").Info("execution failed prophecy expired, retry its sequence", "id", id)
+			events = events.AppendEvent(sdk.NewEvent(types.EventTypeProphecyExpired,
+				sdk.NewAttribute(types.ClaimProphecyID, id),
+				sdk.NewAttribute(types.ProphecyNonVoterCount, "0"),
+			))
+			continue
+		}
+		if prophecy.Status.Text != types.PendingStatusText {
+			continue
+		}
+
+		nonVoters := 0
+		for _, validator := range k.eligibleValidators(ctx, prophecy) {
+			if _, claimed := prophecy.ValidatorClaims[validator.OperatorAddr.String()]; claimed {
+				continue
+			}
+			nonVoters++
+			if slash {
+				k.slashingKeeper.SlashOracleNonVoter(ctx, validator)
+			}
+		}
+
+		k.DeleteProphecy(ctx, id)
+		ctx.Logger().With("module", "x/oracle").Info("prophecy expired", "id", id, "non_voters", nonVoters)
+		events = events.AppendEvent(sdk.NewEvent(types.EventTypeProphecyExpired,
+			sdk.NewAttribute(types.ClaimProphecyID, id),
+			sdk.NewAttribute(types.ProphecyNonVoterCount, strconv.Itoa(nonVoters)),
+		))
+	}
+	return events
+}
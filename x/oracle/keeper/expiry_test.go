@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+type recordingSlasher struct {
+	slashed []sdk.ValAddress
+}
+
+func (s *recordingSlasher) SlashOracleNonVoter(ctx sdk.Context, validator sdk.Validator) {
+	s.slashed = append(s.slashed, validator.GetOperator())
+}
+
+func (s *recordingSlasher) SlashOracleContradictingClaim(ctx sdk.Context, validator sdk.Validator) {
+	s.slashed = append(s.slashed, validator.GetOperator())
+}
+
+func TestExpireProphecies(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyExpiration, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ProphecyExpiration)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	now := time.Unix(1600000000, 0).UTC()
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Time: now})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{
+		ConsensusNeeded:    sdk.NewDecWithPrec(6, 1),
+		ProphecyExpiration: time.Hour,
+		SlashNonVoters:     true,
+	})
+	slasher := &recordingSlasher{}
+	keeper.SetSlashingKeeper(slasher)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	expireAt, found := keeper.GetProphecyExpiry(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, now.Add(time.Hour), expireAt)
+
+	// a deleted prophecy leaves no expiry behind
+	keeper.DeleteProphecy(ctx, AlternateTestID)
+	_, found = keeper.GetProphecyExpiry(ctx, AlternateTestID)
+	require.False(t, found)
+
+	// a later claim does not postpone the expiry
+	ctx = ctx.WithBlockTime(now.Add(30 * time.Minute))
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	expireAt, _ = keeper.GetProphecyExpiry(ctx, TestID)
+	require.Equal(t, now.Add(time.Hour), expireAt)
+
+	ctx = ctx.WithBlockTime(now.Add(time.Hour - time.Second))
+	require.Empty(t, keeper.ExpireProphecies(ctx))
+	_, found = keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+
+	ctx = ctx.WithBlockTime(now.Add(time.Hour))
+	events := keeper.ExpireProphecies(ctx)
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeProphecyExpired, events[0].Type)
+	require.Equal(t, TestID, string(events[0].Attributes[0].Value))
+	require.Equal(t, "1", string(events[0].Attributes[1].Value))
+	require.Equal(t, []sdk.ValAddress{valAddrs[2]}, slasher.slashed)
+
+	_, found = keeper.GetProphecy(ctx, TestID)
+	require.False(t, found)
+	_, found = keeper.GetProphecyExpiry(ctx, TestID)
+	require.False(t, found)
+	require.Empty(t, keeper.ExpireProphecies(ctx.WithBlockTime(now.Add(2*time.Hour))))
+}
+
+func TestProphecyExpirationDisabled(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 2)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyExpiration, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ProphecyExpiration)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	now := time.Unix(1600000000, 0).UTC()
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Time: now})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := []sdk.ValAddress{sdk.ValAddress(addrs[0]), sdk.ValAddress(addrs[1])}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)})
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	_, found := keeper.GetProphecyExpiry(ctx, TestID)
+	require.False(t, found)
+	require.Empty(t, keeper.ExpireProphecies(ctx.WithBlockTime(now.Add(365*24*time.Hour))))
+	_, found = keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+}
+
+func TestExpireProphecySnapshotNonVoters(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyExpiration, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ProphecyExpiration)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	now := time.Unix(1600000000, 0).UTC()
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Time: now})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{
+		ConsensusNeeded:    sdk.NewDecWithPrec(6, 1),
+		ProphecyExpiration: time.Hour,
+		SlashNonVoters:     true,
+	})
+	slasher := &recordingSlasher{}
+	keeper.SetSlashingKeeper(slasher)
+
+	// the third validator bonded after the prophecy was created is not expected to claim
+	prophecy := types.NewProphecy(TestID)
+	prophecy.Snapshot = &types.PowerSnapshot{TotalPower: 10, Validators: []types.ValidatorPower{
+		{Validator: valAddrs[0], Power: 5},
+		{Validator: valAddrs[1], Power: 5},
+	}}
+	prophecy.AddClaim(valAddrs[0], TestString)
+	keeper.setProphecy(ctx, prophecy)
+	keeper.enqueueProphecy(ctx, TestID)
+
+	events := keeper.ExpireProphecies(ctx.WithBlockTime(now.Add(time.Hour)))
+	require.Len(t, events, 1)
+	require.Equal(t, "1", string(events[0].Attributes[1].Value))
+	require.Equal(t, []sdk.ValAddress{valAddrs[1]}, slasher.slashed)
+}
@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// SetSlashingKeeper sets the keeper punishing the validators misbehaving on the oracle
+func (k *Keeper) SetSlashingKeeper(slashingKeeper types.SlashingKeeper) {
+	k.slashingKeeper = slashingKeeper
+}
+
+// SetDistributionKeeper sets the keeper paying the claim rewards out of the community pool
+func (k *Keeper) SetDistributionKeeper(distrKeeper types.DistributionKeeper) {
+	k.distrKeeper = distrKeeper
+}
+
+// GetClaimReward returns the native tokens shared by the validators claiming a successful prophecy
+func (k Keeper) GetClaimReward(ctx sdk.Context) (reward int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyClaimReward, &reward)
+	return
+}
+
+// GetSlashContradictingClaims returns whether the validators contradicting a successful prophecy are slashed
+func (k Keeper) GetSlashContradictingClaims(ctx sdk.Context) (slash bool) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeySlashContradictingClaims, &slash)
+	return
+}
+
+// SettleClaimIncentives rewards the bonded validators whose claims match the final claim of a successful
+// prophecy in proportion to their power, and reports the validators who claimed anything else to the
+// slashing keeper. The reward is not paid if the community pool falls short.
+func (k Keeper) SettleClaimIncentives(ctx sdk.Context, prophecy types.Prophecy) sdk.Events {
+	if prophecy.Status.Text != types.SuccessStatusText {
+		return nil
+	}
+
+	addrs := make([]string, 0, len(prophecy.ValidatorClaims))
+	for addr := range prophecy.ValidatorClaims {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	var matching []sdk.Validator
+	var contradicting []sdk.Validator
+	matchingPower := int64(0)
+	for _, addr := range addrs {
+		valAddr, err := sdk.ValAddressFromBech32(addr)
+		if err != nil {
+			continue
+		}
+		validator, found := k.stakeKeeper.GetValidator(ctx, valAddr)
+		if !found {
+			continue
+		}
+		if prophecy.ValidatorClaims[addr] == prophecy.Status.FinalClaim {
+			if validator.GetStatus().Equal(sdk.Bonded) {
+				matching = append(matching, validator)
+				matchingPower += validator.GetPower().RawInt()
+			}
+		} else {
+			contradicting = append(contradicting, validator)
+		}
+	}
+
+	var events sdk.Events
+	reward := k.GetClaimReward(ctx)
+	if reward > 0 && matchingPower > 0 && k.distrKeeper != nil {
+		for _, validator := range matching {
+			// reward * power / matchingPower may not fit in an int64 before the division
+			share := new(big.Int).Mul(big.NewInt(reward), big.NewInt(validator.GetPower().RawInt()))
+			share.Quo(share, big.NewInt(matchingPower))
+			if share.Sign() == 0 {
+				continue
+			}
+			amt := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, share.Int64())}
+			if !k.distrKeeper.AllocateCommunityPoolToValidator(ctx, validator.GetOperator(), amt) {
+				ctx.Logger().With("module", "x/oracle").Info("community pool falls short of the claim reward",
+					"prophecy", prophecy.ID, "reward", reward)
+				break
+			}
+			events = events.AppendEvent(sdk.NewEvent(types.EventTypeClaimReward,
+				sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+				sdk.NewAttribute(types.ClaimValidator, validator.GetOperator().String()),
+				sdk.NewAttribute(types.ClaimRewardAmount, strconv.FormatInt(share.Int64(), 10)),
+			))
+		}
+	}
+
+	if k.slashingKeeper != nil && k.GetSlashContradictingClaims(ctx) {
+		for _, validator := range contradicting {
+			k.slashingKeeper.SlashOracleContradictingClaim(ctx, validator)
+			events = events.AppendEvent(sdk.NewEvent(types.EventTypeClaimContradicted,
+				sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+				sdk.NewAttribute(types.ClaimValidator, validator.GetOperator().String()),
+			))
+		}
+	}
+	return events
+}
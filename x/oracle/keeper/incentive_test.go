@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+type recordingDistrKeeper struct {
+	communityPool int64
+	rewards       map[string]int64
+}
+
+func (k *recordingDistrKeeper) AllocateCommunityPoolToValidator(ctx sdk.Context, valAddr sdk.ValAddress, amt sdk.Coins) bool {
+	amount := amt.AmountOf(sdk.NativeTokenSymbol)
+	if amount > k.communityPool {
+		return false
+	}
+	k.communityPool -= amount
+	k.rewards[valAddr.String()] += amount
+	return true
+}
+
+func TestSettleClaimIncentives(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyExpiration, 1)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.OracleClaimIncentive, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ProphecyExpiration)
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.OracleClaimIncentive)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 10, 5})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{
+		ConsensusNeeded:          sdk.NewDecWithPrec(7, 1),
+		ClaimReward:              100,
+		SlashContradictingClaims: true,
+	})
+	require.Equal(t, int64(100), keeper.GetClaimReward(ctx))
+	distrKeeper := &recordingDistrKeeper{communityPool: 1000, rewards: make(map[string]int64)}
+	slasher := &recordingSlasher{}
+	keeper.SetDistributionKeeper(distrKeeper)
+	keeper.SetSlashingKeeper(slasher)
+
+	// a pending prophecy settles nothing
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], AlternateTestString))
+	require.NoError(t, err)
+	require.Empty(t, keeper.SettleClaimIncentives(ctx, prophecy))
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+
+	events := keeper.SettleClaimIncentives(ctx, prophecy)
+	require.Len(t, events, 3)
+	require.Equal(t, map[string]int64{valAddrs[0].String(): 33, valAddrs[1].String(): 66}, distrKeeper.rewards)
+	require.Equal(t, int64(901), distrKeeper.communityPool)
+	require.Equal(t, []sdk.ValAddress{valAddrs[2]}, slasher.slashed)
+	require.Equal(t, types.EventTypeClaimContradicted, events[2].Type)
+
+	// the rewards are not paid once the community pool falls short
+	distrKeeper.communityPool = 30
+	events = keeper.SettleClaimIncentives(ctx, prophecy)
+	require.Equal(t, int64(30), distrKeeper.communityPool)
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeClaimContradicted, events[0].Type)
+}
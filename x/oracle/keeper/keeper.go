@@ -1,11 +1,15 @@
 package keeper
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/oracle/metrics"
 	"github.com/cosmos/cosmos-sdk/x/oracle/types"
@@ -29,9 +33,14 @@ type Keeper struct {
 	ScKeeper    sidechain.Keeper
 	IbcKeeper   ibc.Keeper
 	BkKeeper    bank.Keeper
+	govKeeper   *gov.Keeper
 
 	Metrics   *metrics.Metrics
 	pubServer *pubsub.Server
+
+	aggregators    map[types.ClaimType]types.ClaimAggregator
+	slashingKeeper types.SlashingKeeper
+	distrKeeper    types.DistributionKeeper
 }
 
 // Parameter store
@@ -57,6 +66,7 @@ func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace param.Subspac
 		BkKeeper:    bkKeeper,
 		Metrics:     metrics.NopMetrics(),
 		Pool:        pool,
+		aggregators: make(map[types.ClaimType]types.ClaimAggregator),
 	}
 }
 
@@ -69,14 +79,212 @@ func (k *Keeper) EnablePrometheusMetrics() {
 	k.Metrics = metrics.PrometheusMetrics()
 }
 
+// in order to be compatible with before
+type paramBeforeClaimSizeLimitUpgrade struct {
+	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"`
+
+	ProphecyExpiration time.Duration `json:"ProphecyExpiration"`
+	SlashNonVoters     bool          `json:"SlashNonVoters"`
+
+	ClaimReward              int64 `json:"ClaimReward"`
+	SlashContradictingClaims bool  `json:"SlashContradictingClaims"`
+
+	SnapshotPower bool `json:"SnapshotPower"`
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeClaimSizeLimitUpgrade) KeyValuePairs() param.KeyValuePairs {
+	return param.KeyValuePairs{
+		{types.ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
+		{types.ParamStoreKeyProphecyExpiration, &p.ProphecyExpiration},
+		{types.ParamStoreKeyProphecySlashNonVoters, &p.SlashNonVoters},
+		{types.ParamStoreKeyClaimReward, &p.ClaimReward},
+		{types.ParamStoreKeySlashContradictingClaims, &p.SlashContradictingClaims},
+		{types.ParamStoreKeyProphecySnapshotPower, &p.SnapshotPower},
+	}
+}
+
+func (p *paramBeforeClaimSizeLimitUpgrade) UpdateCheck() error {
+	params := types.Params{
+		ConsensusNeeded:          p.ConsensusNeeded,
+		ProphecyExpiration:       p.ProphecyExpiration,
+		SlashNonVoters:           p.SlashNonVoters,
+		ClaimReward:              p.ClaimReward,
+		SlashContradictingClaims: p.SlashContradictingClaims,
+		SnapshotPower:            p.SnapshotPower,
+	}
+	return params.UpdateCheck()
+}
+
+func (p *paramBeforeClaimSizeLimitUpgrade) GetParamAttribute() (string, bool) {
+	return "oracle", true
+}
+
+// in order to be compatible with before
+type paramBeforeProphecySnapshotUpgrade struct {
+	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"`
+
+	ProphecyExpiration time.Duration `json:"ProphecyExpiration"`
+	SlashNonVoters     bool          `json:"SlashNonVoters"`
+
+	ClaimReward              int64 `json:"ClaimReward"`
+	SlashContradictingClaims bool  `json:"SlashContradictingClaims"`
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeProphecySnapshotUpgrade) KeyValuePairs() param.KeyValuePairs {
+	return param.KeyValuePairs{
+		{types.ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
+		{types.ParamStoreKeyProphecyExpiration, &p.ProphecyExpiration},
+		{types.ParamStoreKeyProphecySlashNonVoters, &p.SlashNonVoters},
+		{types.ParamStoreKeyClaimReward, &p.ClaimReward},
+		{types.ParamStoreKeySlashContradictingClaims, &p.SlashContradictingClaims},
+	}
+}
+
+func (p *paramBeforeProphecySnapshotUpgrade) UpdateCheck() error {
+	params := types.Params{
+		ConsensusNeeded:          p.ConsensusNeeded,
+		ProphecyExpiration:       p.ProphecyExpiration,
+		SlashNonVoters:           p.SlashNonVoters,
+		ClaimReward:              p.ClaimReward,
+		SlashContradictingClaims: p.SlashContradictingClaims,
+	}
+	return params.UpdateCheck()
+}
+
+func (p *paramBeforeProphecySnapshotUpgrade) GetParamAttribute() (string, bool) {
+	return "oracle", true
+}
+
+// in order to be compatible with before
+type paramBeforeOracleClaimIncentiveUpgrade struct {
+	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"`
+
+	ProphecyExpiration time.Duration `json:"ProphecyExpiration"`
+	SlashNonVoters     bool          `json:"SlashNonVoters"`
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeOracleClaimIncentiveUpgrade) KeyValuePairs() param.KeyValuePairs {
+	return param.KeyValuePairs{
+		{types.ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
+		{types.ParamStoreKeyProphecyExpiration, &p.ProphecyExpiration},
+		{types.ParamStoreKeyProphecySlashNonVoters, &p.SlashNonVoters},
+	}
+}
+
+func (p *paramBeforeOracleClaimIncentiveUpgrade) UpdateCheck() error {
+	params := types.Params{
+		ConsensusNeeded:    p.ConsensusNeeded,
+		ProphecyExpiration: p.ProphecyExpiration,
+		SlashNonVoters:     p.SlashNonVoters,
+	}
+	return params.UpdateCheck()
+}
+
+func (p *paramBeforeOracleClaimIncentiveUpgrade) GetParamAttribute() (string, bool) {
+	return "oracle", true
+}
+
+// in order to be compatible with before
+type paramBeforeProphecyExpirationUpgrade struct {
+	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"`
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeProphecyExpirationUpgrade) KeyValuePairs() param.KeyValuePairs {
+	return param.KeyValuePairs{
+		{types.ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
+	}
+}
+
+func (p *paramBeforeProphecyExpirationUpgrade) UpdateCheck() error {
+	params := types.Params{
+		ConsensusNeeded: p.ConsensusNeeded,
+	}
+	return params.UpdateCheck()
+}
+
+func (p *paramBeforeProphecyExpirationUpgrade) GetParamAttribute() (string, bool) {
+	return "oracle", true
+}
+
 func (k *Keeper) SetParams(ctx sdk.Context, params types.Params) {
-	k.paramSpace.SetParamSet(ctx, &params)
+	sdk.Upgrade(sdk.ProphecyExpiration, func() {
+		var pb paramBeforeProphecyExpirationUpgrade
+		pb.ConsensusNeeded = params.ConsensusNeeded
+
+		k.paramSpace.SetParamSet(ctx, &pb)
+	}, nil, func() {
+		sdk.Upgrade(sdk.OracleClaimIncentive, func() {
+			var pb paramBeforeOracleClaimIncentiveUpgrade
+			pb.ConsensusNeeded = params.ConsensusNeeded
+			pb.ProphecyExpiration = params.ProphecyExpiration
+			pb.SlashNonVoters = params.SlashNonVoters
+
+			k.paramSpace.SetParamSet(ctx, &pb)
+		}, nil, func() {
+			sdk.Upgrade(sdk.ProphecySnapshot, func() {
+				var pb paramBeforeProphecySnapshotUpgrade
+				pb.ConsensusNeeded = params.ConsensusNeeded
+				pb.ProphecyExpiration = params.ProphecyExpiration
+				pb.SlashNonVoters = params.SlashNonVoters
+				pb.ClaimReward = params.ClaimReward
+				pb.SlashContradictingClaims = params.SlashContradictingClaims
+
+				k.paramSpace.SetParamSet(ctx, &pb)
+			}, nil, func() {
+				sdk.Upgrade(sdk.ClaimSizeLimit, func() {
+					var pb paramBeforeClaimSizeLimitUpgrade
+					pb.ConsensusNeeded = params.ConsensusNeeded
+					pb.ProphecyExpiration = params.ProphecyExpiration
+					pb.SlashNonVoters = params.SlashNonVoters
+					pb.ClaimReward = params.ClaimReward
+					pb.SlashContradictingClaims = params.SlashContradictingClaims
+					pb.SnapshotPower = params.SnapshotPower
+
+					k.paramSpace.SetParamSet(ctx, &pb)
+				}, nil, func() {
+					k.paramSpace.SetParamSet(ctx, &params)
+				})
+			})
+		})
+	})
+}
+
+// GetSnapshotPower returns whether the claims of a new prophecy are weighed by the bonded validator set at its creation
+func (k Keeper) GetSnapshotPower(ctx sdk.Context) (snapshot bool) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProphecySnapshotPower, &snapshot)
+	return
+}
+
+func (k *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
+	k.govKeeper = govKeeper
 }
 
 func (k *Keeper) SetPbsbServer(p *pubsub.Server) {
 	k.pubServer = p
 }
 
+// SetClaimAggregator replaces the strategy used to reach consensus on the claims of
+// claimType, by default validators must submit identical payloads.
+func (k *Keeper) SetClaimAggregator(claimType types.ClaimType, aggregator types.ClaimAggregator) {
+	k.aggregators[claimType] = aggregator
+}
+
+// getClaimAggregator returns the aggregator of the claim type of the prophecy
+func (k Keeper) getClaimAggregator(prophecyID string) types.ClaimAggregator {
+	_, claimType, _, err := types.ParseClaimId(prophecyID)
+	if err != nil {
+		return types.ExactMatchAggregator{}
+	}
+	if aggregator, ok := k.aggregators[claimType]; ok {
+		return aggregator
+	}
+	return types.ExactMatchAggregator{}
+}
+
 // GetProphecy gets the entire prophecy data struct for a given id
 func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -88,7 +296,9 @@ func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
 	var dbProphecy types.DBProphecy
 	k.cdc.MustUnmarshalBinaryBare(bz, &dbProphecy)
 
-	deSerializedProphecy, err := dbProphecy.DeserializeFromDB()
+	deSerializedProphecy, err := dbProphecy.DeserializeFromDBWithPayloads(func(hash string) (string, bool) {
+		return k.getClaimPayload(ctx, id, hash)
+	})
 	if err != nil {
 		return types.Prophecy{}, false
 	}
@@ -100,11 +310,23 @@ func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
 func (k Keeper) DeleteProphecy(ctx sdk.Context, id string) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete([]byte(id))
+	k.deleteClaimPayloads(ctx, id)
+	k.dequeueProphecy(ctx, id)
 }
 
 // setProphecy saves a prophecy with an initial claim
 func (k Keeper) setProphecy(ctx sdk.Context, prophecy types.Prophecy) {
 	store := ctx.KVStore(k.storeKey)
+	if sdk.IsUpgrade(sdk.OracleClaimDedup) {
+		serializedProphecy, payloads, err := prophecy.SerializeForDBWithPayloadRefs()
+		if err != nil {
+			panic(err)
+		}
+		k.setClaimPayloads(ctx, prophecy.ID, payloads)
+		store.Set([]byte(prophecy.ID), k.cdc.MustMarshalBinaryBare(serializedProphecy))
+		return
+	}
+
 	serializedProphecy, err := prophecy.SerializeForDB()
 	if err != nil {
 		panic(err)
@@ -128,9 +350,19 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 		return types.Prophecy{}, types.ErrInvalidClaim()
 	}
 
+	if sdkErr := k.CheckClaimSize(ctx, claim); sdkErr != nil {
+		return types.Prophecy{}, sdkErr
+	}
+
 	prophecy, found := k.GetProphecy(ctx, claim.ID)
 	if !found {
 		prophecy = types.NewProphecy(claim.ID)
+		if sdk.IsUpgrade(sdk.ProphecyExpiration) {
+			k.enqueueProphecy(ctx, claim.ID)
+		}
+		if sdk.IsUpgrade(sdk.ProphecySnapshot) && k.GetSnapshotPower(ctx) {
+			prophecy.Snapshot = types.NewPowerSnapshot(ctx, k.stakeKeeper)
+		}
 	}
 
 	switch prophecy.Status.Text {
@@ -144,9 +376,43 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 	prophecy = k.processCompletion(ctx, prophecy)
 
 	k.setProphecy(ctx, prophecy)
+	ctx.EventManager().EmitEvents(k.claimEvents(ctx, prophecy, claim.ValidatorAddress, !found))
 	return prophecy, nil
 }
 
+// claimEvents reports the progress a claim made on a prophecy, from its creation by the first
+// claim to its success or failure
+func (k Keeper) claimEvents(ctx sdk.Context, prophecy types.Prophecy, validator sdk.ValAddress, created bool) sdk.Events {
+	var events sdk.Events
+	if created {
+		events = events.AppendEvent(sdk.NewEvent(types.EventTypeProphecyCreated,
+			sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+		))
+	}
+
+	_, _, claimedPower := prophecy.FindHighestClaim(ctx, k.stakeKeeper)
+	totalPower := strconv.FormatInt(prophecy.TotalPower(ctx, k.stakeKeeper), 10)
+	events = events.AppendEvent(sdk.NewEvent(types.EventTypeClaimAdded,
+		sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+		sdk.NewAttribute(types.ClaimValidator, validator.String()),
+		sdk.NewAttribute(types.ClaimPower, strconv.FormatInt(prophecy.ValidatorPower(ctx, k.stakeKeeper, validator), 10)),
+		sdk.NewAttribute(types.ProphecyClaimedPower, strconv.FormatInt(claimedPower, 10)),
+		sdk.NewAttribute(types.ProphecyTotalPower, totalPower),
+	))
+
+	switch prophecy.Status.Text {
+	case types.SuccessStatusText:
+		events = events.AppendEvent(sdk.NewEvent(types.EventTypeProphecySucceeded,
+			sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+		))
+	case types.FailedStatusText:
+		events = events.AppendEvent(sdk.NewEvent(types.EventTypeProphecyFailed,
+			sdk.NewAttribute(types.ClaimProphecyID, prophecy.ID),
+		))
+	}
+	return events
+}
+
 func (k Keeper) checkActiveValidator(ctx sdk.Context, validatorAddress sdk.ValAddress) bool {
 	validator, found := k.stakeKeeper.GetValidator(ctx, validatorAddress)
 	if !found {
@@ -157,31 +423,35 @@ func (k Keeper) checkActiveValidator(ctx sdk.Context, validatorAddress sdk.ValAd
 }
 
 // processCompletion looks at a given prophecy
-// and assesses whether the claim with the highest power on that prophecy has enough
+// and assesses, through the claim aggregator, whether its claims have enough
 // power to be considered successful, or alternatively,
 // will never be able to become successful due to not enough validation power being
 // left to push it over the threshold required for consensus.
 func (k Keeper) processCompletion(ctx sdk.Context, prophecy types.Prophecy) types.Prophecy {
-	highestClaim, highestClaimPower, totalClaimsPower := prophecy.FindHighestClaim(ctx, k.stakeKeeper)
-	totalPower := k.stakeKeeper.GetLastTotalPower(ctx)
-
-	highestConsensusRatio := sdk.NewDec(highestClaimPower).Quo(sdk.NewDec(totalPower))
-	remainingPossibleClaimPower := totalPower - totalClaimsPower
-	highestPossibleClaimPower := highestClaimPower + remainingPossibleClaimPower
-
-	highestPossibleConsensusRatio := sdk.NewDec(highestPossibleClaimPower).Quo(sdk.NewDec(totalPower))
-
+	claims := prophecy.WeightedClaims(ctx, k.stakeKeeper)
+	totalPower := k.consensusPower(ctx, prophecy)
 	consensusNeeded := k.GetConsensusNeeded(ctx)
 
-	if highestConsensusRatio.GTE(consensusNeeded) {
-		prophecy.Status.Text = types.SuccessStatusText
-		prophecy.Status.FinalClaim = highestClaim
-	} else if highestPossibleConsensusRatio.LT(consensusNeeded) {
-		prophecy.Status.Text = types.FailedStatusText
-	}
+	prophecy.Status = k.getClaimAggregator(prophecy.ID).Aggregate(claims, totalPower, consensusNeeded)
 	return prophecy
 }
 
+// paramSetProto returns the param set stored at the current height, the params of an upgrade
+// are only stored from its height on
+func paramSetProto() pTypes.SCParam {
+	switch {
+	case !sdk.IsUpgrade(sdk.ProphecyExpiration):
+		return new(paramBeforeProphecyExpirationUpgrade)
+	case !sdk.IsUpgrade(sdk.OracleClaimIncentive):
+		return new(paramBeforeOracleClaimIncentiveUpgrade)
+	case !sdk.IsUpgrade(sdk.ProphecySnapshot):
+		return new(paramBeforeProphecySnapshotUpgrade)
+	case !sdk.IsUpgrade(sdk.ClaimSizeLimit):
+		return new(paramBeforeClaimSizeLimitUpgrade)
+	}
+	return new(types.Params)
+}
+
 func (k *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
 	hub.SubscribeParamChange(
 		func(context sdk.Context, iChange interface{}) {
@@ -200,9 +470,7 @@ func (k *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
 				context.Logger().Debug("skip unknown param change")
 			}
 		},
-		&pTypes.ParamSpaceProto{ParamSpace: k.paramSpace, Proto: func() pTypes.SCParam {
-			return new(types.Params)
-		}},
+		&pTypes.ParamSpaceProto{ParamSpace: k.paramSpace, Proto: paramSetProto},
 		nil,
 		nil,
 	)
@@ -224,3 +492,51 @@ func (k *Keeper) PublishCrossAppFailEvent(ctx sdk.Context, from string, relayerF
 		}
 	}
 }
+
+// GetProphecyNonVoters lists the bonded validators, ordered by power, that have not claimed on
+// the pending prophecy with the given id, along with the power accumulated by the claims so far.
+func (k Keeper) GetProphecyNonVoters(ctx sdk.Context, id string) (types.ProphecyNonVoters, sdk.Error) {
+	prophecy, found := k.GetProphecy(ctx, id)
+	if !found {
+		return types.ProphecyNonVoters{}, types.ErrProphecyNotFound()
+	}
+	if prophecy.Status.Text != types.PendingStatusText {
+		return types.ProphecyNonVoters{}, types.ErrProphecyFinalized()
+	}
+
+	totalPower := prophecy.TotalPower(ctx, k.stakeKeeper)
+	highestClaim, highestClaimPower, totalClaimsPower := prophecy.FindHighestClaim(ctx, k.stakeKeeper)
+	if highestClaim == "" {
+		highestClaimPower = 0
+	}
+
+	nonVoters := make([]types.NonVoter, 0)
+	for _, validator := range k.stakeKeeper.GetBondedValidatorsByPower(ctx) {
+		if _, claimed := prophecy.ValidatorClaims[validator.OperatorAddr.String()]; claimed {
+			continue
+		}
+		// the claims of the validators out of the snapshot are not counted
+		if prophecy.Snapshot != nil && !prophecy.Snapshot.Contains(validator.OperatorAddr) {
+			continue
+		}
+		nonVoters = append(nonVoters, types.NonVoter{
+			OperatorAddr: validator.OperatorAddr,
+			Moniker:      validator.Description.Moniker,
+			Power:        validator.GetPower().RawInt(),
+		})
+	}
+
+	result := types.ProphecyNonVoters{
+		ProphecyID:             id,
+		TotalPower:             totalPower,
+		ClaimedPowerRatio:      sdk.ZeroDec(),
+		HighestClaimPowerRatio: sdk.ZeroDec(),
+		ConsensusNeeded:        k.GetConsensusNeeded(ctx),
+		NonVoters:              nonVoters,
+	}
+	if totalPower > 0 {
+		result.ClaimedPowerRatio = sdk.NewDec(totalClaimsPower).Quo(sdk.NewDec(totalPower))
+		result.HighestClaimPowerRatio = sdk.NewDec(highestClaimPower).Quo(sdk.NewDec(totalPower))
+	}
+	return result, nil
+}
@@ -194,6 +194,56 @@ func TestSuccessfulProphecyWithDisagreement(t *testing.T) {
 	require.Equal(t, status.Status.FinalClaim, TestString)
 }
 
+type commaItemCodec struct{}
+
+func (commaItemCodec) Split(payload string) ([]string, error) {
+	return strings.Split(payload, ","), nil
+}
+
+func (commaItemCodec) Join(items []string) string {
+	return strings.Join(items, ",")
+}
+
+func TestClaimAggregatorPerClaimType(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1)})
+
+	keeper.SetClaimAggregator(types.ClaimType(1), types.NewItemMatchAggregator(commaItemCodec{}))
+
+	// claims of the registered claim type agree per item
+	itemID := types.GetClaimId(sdk.ChainID(1), types.ClaimType(1), 1)
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(itemID, valAddrs[0], "a,b"))
+	require.NoError(t, err)
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(itemID, valAddrs[1], "a,c"))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(itemID, valAddrs[2], "a,d"))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, "a", prophecy.Status.FinalClaim)
+
+	// other claim types still need identical payloads
+	exactID := types.GetClaimId(sdk.ChainID(1), types.ClaimType(2), 1)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(exactID, valAddrs[0], "a,b"))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(exactID, valAddrs[1], "a,c"))
+	require.NoError(t, err)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(exactID, valAddrs[2], "a,d"))
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, prophecy.Status.Text)
+}
+
 func TestFailedProphecy(t *testing.T) {
 	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
 
@@ -278,3 +328,332 @@ func TestNonValidator(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "claim must be made by actively bonded validator"))
 }
+
+func TestGetProphecyNonVoters(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 3, 2})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)})
+
+	_, err := keeper.GetProphecyNonVoters(ctx, TestID)
+	require.Equal(t, types.CodeProphecyNotFound, err.Code())
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	nonVoters, err := keeper.GetProphecyNonVoters(ctx, TestID)
+	require.NoError(t, err)
+	require.Equal(t, TestID, nonVoters.ProphecyID)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), nonVoters.ClaimedPowerRatio)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), nonVoters.HighestClaimPowerRatio)
+	require.Equal(t, sdk.NewDecWithPrec(7, 1), nonVoters.ConsensusNeeded)
+	require.Len(t, nonVoters.NonVoters, 2)
+	require.Equal(t, valAddrs[1], nonVoters.NonVoters[0].OperatorAddr)
+	require.Equal(t, valAddrs[2], nonVoters.NonVoters[1].OperatorAddr)
+	require.Equal(t, testDescription.Moniker, nonVoters.NonVoters[0].Moniker)
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], AlternateTestString))
+	require.NoError(t, err)
+
+	nonVoters, err = keeper.GetProphecyNonVoters(ctx, TestID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecWithPrec(7, 1), nonVoters.ClaimedPowerRatio)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), nonVoters.HighestClaimPowerRatio)
+	require.Len(t, nonVoters.NonVoters, 1)
+	require.Equal(t, valAddrs[1], nonVoters.NonVoters[0].OperatorAddr)
+
+	// finalized prophecies are not open any more
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	_, err = keeper.GetProphecyNonVoters(ctx, TestID)
+	require.Equal(t, types.CodeProphecyFinalized, err.Code())
+}
+
+func TestClaimPayloadDedup(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	prophecy := types.NewProphecy(TestID)
+	prophecy.AddClaim(sdk.ValAddress(addrs[0]), TestString)
+	prophecy.AddClaim(sdk.ValAddress(addrs[1]), TestString)
+	prophecy.AddClaim(sdk.ValAddress(addrs[2]), AlternateTestString)
+
+	// the prophecies stored before the upgrade are still read
+	keeper.setProphecy(ctx, types.NewProphecy(AlternateTestID))
+	keeper.setProphecy(ctx, prophecy)
+	got, found := keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, prophecy.ValidatorClaims, got.ValidatorClaims)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.OracleClaimDedup, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.OracleClaimDedup)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	countPayloads := func(id string) int {
+		iter := sdk.KVStorePrefixIterator(ctx.KVStore(keeper.storeKey), claimPayloadsKey(id))
+		defer iter.Close()
+		n := 0
+		for ; iter.Valid(); iter.Next() {
+			n++
+		}
+		return n
+	}
+
+	keeper.setProphecy(ctx, prophecy)
+	require.Equal(t, 2, countPayloads(TestID))
+	got, found = keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, prophecy.ValidatorClaims, got.ValidatorClaims)
+	require.Len(t, got.ClaimValidators[TestString], 2)
+
+	// the payload no validator claims any more is deleted
+	prophecy.AddClaim(sdk.ValAddress(addrs[2]), TestString)
+	keeper.setProphecy(ctx, prophecy)
+	require.Equal(t, 1, countPayloads(TestID))
+	got, found = keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	require.Len(t, got.ClaimValidators[TestString], 3)
+
+	_, found = keeper.GetProphecy(ctx, AlternateTestID)
+	require.True(t, found)
+
+	keeper.DeleteProphecy(ctx, TestID)
+	_, found = keeper.GetProphecy(ctx, TestID)
+	require.False(t, found)
+	require.Equal(t, 0, countPayloads(TestID))
+}
+
+func TestProphecyPowerSnapshot(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	upgrades := []string{sdk.ProphecyExpiration, sdk.OracleClaimIncentive, sdk.ProphecySnapshot}
+	for _, name := range upgrades {
+		sdk.UpgradeMgr.AddUpgradeHeight(name, 1)
+	}
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		for _, name := range upgrades {
+			delete(sdk.UpgradeMgr.Config.HeightMap, name)
+		}
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 3, 2})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1), SnapshotPower: true})
+	require.True(t, keeper.GetSnapshotPower(ctx))
+
+	// the snapshot is taken by the first claim
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	require.NotNil(t, prophecy.Snapshot)
+	require.Equal(t, int64(10), prophecy.Snapshot.TotalPower)
+	require.Len(t, prophecy.Snapshot.Validators, 3)
+	require.True(t, prophecy.Snapshot.Contains(valAddrs[2]))
+
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)})
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	// the power of the validator set changes after the prophecies are created
+	res := stakeHandler(ctx, stake.NewMsgDelegate(addrs[1], valAddrs[1], sdk.NewCoin(gov.DefaultDepositDenom, 10)))
+	require.True(t, res.IsOK(), res.Log)
+	stake.EndBlocker(ctx, sk)
+
+	info, found := keeper.GetProphecyInfo(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, prophecy.Snapshot, info.Snapshot)
+
+	nonVoters, err := keeper.GetProphecyNonVoters(ctx, TestID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), nonVoters.TotalPower)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), nonVoters.ClaimedPowerRatio)
+
+	// the snapshot weighs the claims by the former powers
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+
+	// the current powers are used without snapshot
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[2], TestString))
+	require.NoError(t, err)
+	require.Nil(t, prophecy.Snapshot)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+}
+
+func TestClaimSizeLimit(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 3, 2})
+	stake.EndBlocker(ctx, sk)
+
+	params := types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1), MaxClaimSize: int64(len(TestString))}
+	largeClaim := TestString + " "
+
+	// no limit before the upgrade
+	keeper.SetParams(ctx, params)
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[0], largeClaim))
+	require.NoError(t, err)
+
+	upgrades := []string{sdk.ProphecyExpiration, sdk.OracleClaimIncentive, sdk.ProphecySnapshot, sdk.ClaimSizeLimit}
+	for _, name := range upgrades {
+		sdk.UpgradeMgr.AddUpgradeHeight(name, 1)
+	}
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		for _, name := range upgrades {
+			delete(sdk.UpgradeMgr.Config.HeightMap, name)
+		}
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	keeper.SetParams(ctx, params)
+	require.Equal(t, params.MaxClaimSize, keeper.GetMaxClaimSize(ctx))
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], largeClaim))
+	require.Error(t, err)
+	require.Equal(t, types.CodeClaimTooLarge, err.Code())
+	_, found := keeper.GetProphecy(ctx, TestID)
+	require.False(t, found)
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	// zero disables the limit
+	params.MaxClaimSize = 0
+	keeper.SetParams(ctx, params)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], largeClaim))
+	require.NoError(t, err)
+
+	params.MaxClaimSize = -1
+	require.Error(t, params.UpdateCheck())
+}
+
+func TestProphecyLifecycleEvents(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1)})
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 2)
+	require.Equal(t, types.EventTypeProphecyCreated, events[0].Type)
+	require.Equal(t, types.EventTypeClaimAdded, events[1].Type)
+	require.Equal(t, valAddrs[0].String(), string(events[1].Attributes[1].Value))
+	require.Equal(t, "5", string(events[1].Attributes[2].Value))
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	events = ctx.EventManager().Events()
+	require.Len(t, events, 2)
+	require.Equal(t, types.EventTypeClaimAdded, events[0].Type)
+	require.Equal(t, "10", string(events[0].Attributes[3].Value))
+	require.Equal(t, "15", string(events[0].Attributes[4].Value))
+	require.Equal(t, types.EventTypeProphecySucceeded, events[1].Type)
+
+	// a rejected claim emits nothing
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], TestString))
+	require.Error(t, err)
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestParamSetProtoBeforeUpgrades(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 1)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	upgrades := []string{sdk.ProphecyExpiration, sdk.OracleClaimIncentive}
+	for _, name := range upgrades {
+		sdk.UpgradeMgr.AddUpgradeHeight(name, 1)
+	}
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		for _, name := range append(upgrades, sdk.ProphecySnapshot, sdk.ClaimSizeLimit) {
+			delete(sdk.UpgradeMgr.Config.HeightMap, name)
+		}
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1), ClaimReward: 10})
+
+	// the params of the coming upgrades are not stored yet
+	proto := paramSetProto()
+	require.NotPanics(t, func() { keeper.paramSpace.GetParamSet(ctx, proto) })
+	require.Nil(t, proto.UpdateCheck())
+	require.Equal(t, int64(10), proto.(*paramBeforeProphecySnapshotUpgrade).ClaimReward)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecySnapshot, 2)
+	sdk.UpgradeMgr.SetHeight(2)
+	require.Panics(t, func() { keeper.paramSpace.GetParamSet(ctx, paramSetProto()) })
+
+	// what the begin blocker of the upgrade stores
+	keeper.SetParams(ctx, types.Params{
+		ConsensusNeeded:          keeper.GetConsensusNeeded(ctx),
+		ProphecyExpiration:       keeper.GetProphecyExpiration(ctx),
+		SlashNonVoters:           keeper.GetSlashNonVoters(ctx),
+		ClaimReward:              keeper.GetClaimReward(ctx),
+		SlashContradictingClaims: keeper.GetSlashContradictingClaims(ctx),
+	})
+	proto = paramSetProto()
+	require.NotPanics(t, func() { keeper.paramSpace.GetParamSet(ctx, proto) })
+	require.Equal(t, int64(10), proto.(*paramBeforeClaimSizeLimitUpgrade).ClaimReward)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ClaimSizeLimit, 3)
+	sdk.UpgradeMgr.SetHeight(3)
+	require.Panics(t, func() { keeper.paramSpace.GetParamSet(ctx, paramSetProto()) })
+	keeper.SetParams(ctx, types.Params{
+		ConsensusNeeded:          keeper.GetConsensusNeeded(ctx),
+		ProphecyExpiration:       keeper.GetProphecyExpiration(ctx),
+		SlashNonVoters:           keeper.GetSlashNonVoters(ctx),
+		ClaimReward:              keeper.GetClaimReward(ctx),
+		SlashContradictingClaims: keeper.GetSlashContradictingClaims(ctx),
+		SnapshotPower:            keeper.GetSnapshotPower(ctx),
+	})
+	require.NotPanics(t, func() { keeper.paramSpace.GetParamSet(ctx, paramSetProto()) })
+
+}
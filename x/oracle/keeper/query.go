@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// GetProphecyInfo returns the prophecy with the given id along with its claims in a deterministic order
+func (k Keeper) GetProphecyInfo(ctx sdk.Context, id string) (types.ProphecyInfo, bool) {
+	prophecy, found := k.GetProphecy(ctx, id)
+	if !found {
+		return types.ProphecyInfo{}, false
+	}
+
+	claims := make([]types.ValidatorClaim, 0, len(prophecy.ValidatorClaims))
+	for addr, payload := range prophecy.ValidatorClaims {
+		valAddr, err := sdk.ValAddressFromBech32(addr)
+		if err != nil {
+			continue
+		}
+		claims = append(claims, types.ValidatorClaim{Validator: valAddr, Payload: payload})
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[i].Validator.String() < claims[j].Validator.String()
+	})
+
+	info := types.ProphecyInfo{
+		ID:       prophecy.ID,
+		Status:   prophecy.Status,
+		Claims:   claims,
+		Snapshot: prophecy.Snapshot,
+	}
+	if prophecy.Status.Text == types.PendingStatusText {
+		if expireAt, found := k.GetProphecyExpiry(ctx, id); found {
+			info.ExpireAt = &expireAt
+		}
+	}
+	return info, true
+}
+
+// GetClaimTypes returns the registered claim types in ascending order
+func (k Keeper) GetClaimTypes() []types.ClaimTypeInfo {
+	ids := k.ScKeeper.GetChannelIDs()
+	claimTypes := make([]types.ClaimTypeInfo, 0, len(ids))
+	for _, id := range ids {
+		name, _ := k.ScKeeper.GetChannelName(id)
+		claimTypes = append(claimTypes, types.ClaimTypeInfo{ClaimType: id, Name: name})
+	}
+	return claimTypes
+}
+
+// GetClaimSequences returns the receive sequence of every registered claim type of a chain
+func (k Keeper) GetClaimSequences(ctx sdk.Context, chainId sdk.ChainID) []types.ClaimSequence {
+	claimTypes := k.GetClaimTypes()
+	sequences := make([]types.ClaimSequence, 0, len(claimTypes))
+	for _, claimType := range claimTypes {
+		sequences = append(sequences, types.ClaimSequence{
+			ClaimType: claimType.ClaimType,
+			Name:      claimType.Name,
+			Sequence:  k.ScKeeper.GetReceiveSequence(ctx, chainId, claimType.ClaimType),
+		})
+	}
+	return sequences
+}
+
+// GetPendingClaims returns the claims of a validator on the pending prophecies, ordered by prophecy id
+func (k Keeper) GetPendingClaims(ctx sdk.Context, validatorAddr sdk.ValAddress) []types.PendingClaim {
+	claims := make([]types.PendingClaim, 0)
+	k.iterateProphecies(ctx, func(id string, prophecy types.Prophecy) bool {
+		if prophecy.Status.Text != types.PendingStatusText {
+			return false
+		}
+		if payload, claimed := prophecy.ValidatorClaims[validatorAddr.String()]; claimed {
+			claims = append(claims, types.PendingClaim{
+				ProphecyID:  id,
+				PayloadHash: types.ClaimPayloadHash(payload),
+			})
+		}
+		return false
+	})
+	return claims
+}
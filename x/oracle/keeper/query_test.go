@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestQueryProphecies(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 3, 2})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)})
+
+	var chainId sdk.ChainID = 1
+	pendingID := types.GetClaimId(chainId, types.RelayPackagesChannelId, 0)
+	otherID := types.GetClaimId(chainId, types.RelayPackagesChannelId, 1)
+
+	_, found := keeper.GetProphecyInfo(ctx, pendingID)
+	require.False(t, found)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(pendingID, valAddrs[2], AlternateTestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(pendingID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(otherID, valAddrs[1], TestString))
+	require.NoError(t, err)
+
+	info, found := keeper.GetProphecyInfo(ctx, pendingID)
+	require.True(t, found)
+	require.Equal(t, pendingID, info.ID)
+	require.Equal(t, types.PendingStatusText, info.Status.Text)
+	require.Len(t, info.Claims, 2)
+	require.True(t, info.Claims[0].Validator.String() < info.Claims[1].Validator.String())
+	for _, claim := range info.Claims {
+		if claim.Validator.Equals(valAddrs[0]) {
+			require.Equal(t, TestString, claim.Payload)
+		} else {
+			require.Equal(t, valAddrs[2], claim.Validator)
+			require.Equal(t, AlternateTestString, claim.Payload)
+		}
+	}
+	require.Nil(t, info.ExpireAt)
+
+	pending := keeper.GetPendingClaims(ctx, valAddrs[0])
+	require.Equal(t, []types.PendingClaim{{ProphecyID: pendingID, PayloadHash: types.ClaimPayloadHash(TestString)}}, pending)
+	pending = keeper.GetPendingClaims(ctx, valAddrs[1])
+	require.Equal(t, []types.PendingClaim{{ProphecyID: otherID, PayloadHash: types.ClaimPayloadHash(TestString)}}, pending)
+
+	// claims on finalized prophecies are not pending any more
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(pendingID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Empty(t, keeper.GetPendingClaims(ctx, valAddrs[0]))
+	require.Len(t, keeper.GetPendingClaims(ctx, valAddrs[1]), 1)
+}
+
+func TestQueryClaimSequences(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	var claimType types.ClaimType = 0x31
+	require.NoError(t, keeper.ScKeeper.RegisterChannel("testClaims", claimType, nil))
+
+	claimTypes := keeper.GetClaimTypes()
+	require.Contains(t, claimTypes, types.ClaimTypeInfo{ClaimType: claimType, Name: "testClaims"})
+	for i := 1; i < len(claimTypes); i++ {
+		require.True(t, claimTypes[i-1].ClaimType < claimTypes[i].ClaimType)
+	}
+
+	var chainId sdk.ChainID = 1
+	keeper.ScKeeper.IncrReceiveSequence(ctx, chainId, claimType)
+	keeper.ScKeeper.IncrReceiveSequence(ctx, chainId, claimType)
+
+	sequences := keeper.GetClaimSequences(ctx, chainId)
+	require.Len(t, sequences, len(claimTypes))
+	require.Contains(t, sequences, types.ClaimSequence{ClaimType: claimType, Name: "testClaims", Sequence: 2})
+	require.Contains(t, keeper.GetClaimSequences(ctx, 2), types.ClaimSequence{ClaimType: claimType, Name: "testClaims", Sequence: 0})
+}
@@ -0,0 +1,219 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+// relayerAllowListPrefix keeps the allow-lists apart from prophecies, whose ids start with a digit
+var relayerAllowListPrefix = []byte{0x01}
+
+func relayerAllowListKey(claimType types.ClaimType) []byte {
+	return append(relayerAllowListPrefix, byte(claimType))
+}
+
+// SetRelayerAllowList restricts the relayers of a claim type, an empty list lifts the restriction
+func (k Keeper) SetRelayerAllowList(ctx sdk.Context, allowList types.RelayerAllowList) {
+	store := ctx.KVStore(k.storeKey)
+	if len(allowList.Relayers) == 0 {
+		store.Delete(relayerAllowListKey(allowList.ClaimType))
+		return
+	}
+	store.Set(relayerAllowListKey(allowList.ClaimType), k.cdc.MustMarshalBinaryBare(allowList))
+}
+
+// GetRelayerAllowList returns the allow-list of a claim type, false if anyone may relay the claims
+func (k Keeper) GetRelayerAllowList(ctx sdk.Context, claimType types.ClaimType) (types.RelayerAllowList, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(relayerAllowListKey(claimType))
+	if bz == nil {
+		return types.RelayerAllowList{}, false
+	}
+	var allowList types.RelayerAllowList
+	k.cdc.MustUnmarshalBinaryBare(bz, &allowList)
+	return allowList, true
+}
+
+// CheckRelayer returns an error if the claim type has an allow-list that does not contain the validator
+func (k Keeper) CheckRelayer(ctx sdk.Context, claimType types.ClaimType, validatorAddr sdk.ValAddress) sdk.Error {
+	if !sdk.IsUpgrade(sdk.RelayerAllowList) {
+		return nil
+	}
+	allowList, found := k.GetRelayerAllowList(ctx, claimType)
+	if !found {
+		return nil
+	}
+	for _, relayer := range allowList.Relayers {
+		if relayer.Equals(validatorAddr) {
+			return nil
+		}
+	}
+	return types.ErrRelayerNotAllowed(claimType)
+}
+
+// consensusPower returns the power the claims of a prophecy are weighed against. Only the allowed relayers
+// may claim on a claim type with an allow-list, so it is their power in the validator set of the prophecy
+// rather than the total power.
+func (k Keeper) consensusPower(ctx sdk.Context, prophecy types.Prophecy) int64 {
+	if !sdk.IsUpgrade(sdk.RelayerAllowList) {
+		return prophecy.TotalPower(ctx, k.stakeKeeper)
+	}
+	_, claimType, _, err := types.ParseClaimId(prophecy.ID)
+	if err != nil {
+		return prophecy.TotalPower(ctx, k.stakeKeeper)
+	}
+	allowList, found := k.GetRelayerAllowList(ctx, claimType)
+	if !found {
+		return prophecy.TotalPower(ctx, k.stakeKeeper)
+	}
+	var power int64
+	for _, relayer := range allowList.Relayers {
+		power += prophecy.ValidatorPower(ctx, k.stakeKeeper, relayer)
+	}
+	return power
+}
+
+// executeRelayerAllowListProposals applies the RelayerAllowList proposals passed since the last block.
+func (k Keeper) executeRelayerAllowListProposals(ctx sdk.Context) {
+	if k.govKeeper == nil {
+		return
+	}
+	logger := ctx.Logger().With("module", "x/oracle")
+	for _, proposal := range sidechain.CollectPassedProposals(ctx, k.govKeeper, gov.ProposalTypeRelayerAllowList) {
+		var allowList types.RelayerAllowList
+		if err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &allowList); err != nil {
+			logger.Error("Get broken data when unmarshal RelayerAllowList msg, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		if err := allowList.Check(); err != nil {
+			logger.Error("The RelayerAllowList proposal is invalid, will skip.",
+				"proposalId", proposal.GetProposalID(), "proposal", allowList, "err", err)
+			continue
+		}
+		k.SetRelayerAllowList(ctx, allowList)
+		logger.Info("Changed relayer allow-list", "proposalId", proposal.GetProposalID(), "claimType", allowList.ClaimType)
+	}
+}
+
+// SkipSequence moves the receive sequence of a claim type past a sequence the relayers cannot claim, the
+// prophecy left at the sequence is deleted. Nothing is skipped if the receive sequence moved on already.
+func (k Keeper) SkipSequence(ctx sdk.Context, skip types.SkipSequence) sdk.Error {
+	sequence := k.ScKeeper.GetReceiveSequence(ctx, skip.ChainId, skip.ClaimType)
+	if sequence != skip.Sequence {
+		return types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", skip.ClaimType, sequence))
+	}
+
+	id := types.GetClaimId(skip.ChainId, skip.ClaimType, skip.Sequence)
+	if _, found := k.GetProphecy(ctx, id); found {
+		k.DeleteProphecy(ctx, id)
+	}
+	k.ScKeeper.IncrReceiveSequence(ctx, skip.ChainId, skip.ClaimType)
+	return nil
+}
+
+// executeSkipSequenceProposals applies the SkipSequence proposals passed since the last block.
+func (k Keeper) executeSkipSequenceProposals(ctx sdk.Context) sdk.Events {
+	if k.govKeeper == nil {
+		return nil
+	}
+	logger := ctx.Logger().With("module", "x/oracle")
+	var events sdk.Events
+	for _, proposal := range sidechain.CollectPassedProposals(ctx, k.govKeeper, gov.ProposalTypeSkipSequence) {
+		var skip types.SkipSequence
+		if err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &skip); err != nil {
+			logger.Error("Get broken data when unmarshal SkipSequence msg, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		if err := skip.Check(); err != nil {
+			logger.Error("The SkipSequence proposal is invalid, will skip.",
+				"proposalId", proposal.GetProposalID(), "proposal", skip, "err", err)
+			continue
+		}
+		if err := k.SkipSequence(ctx, skip); err != nil {
+			logger.Error("The sequence of the SkipSequence proposal is not current, will skip.",
+				"proposalId", proposal.GetProposalID(), "proposal", skip, "err", err.Error())
+			continue
+		}
+		logger.Info("Skipped sequence", "proposalId", proposal.GetProposalID(), "chainId", skip.ChainId,
+			"claimType", skip.ClaimType, "sequence", skip.Sequence)
+		events = events.AppendEvent(sdk.NewEvent(types.EventTypeSequenceSkipped,
+			sdk.NewAttribute(types.ClaimChainID, strconv.FormatUint(uint64(skip.ChainId), 10)),
+			sdk.NewAttribute(types.ClaimChannel, strconv.FormatUint(uint64(skip.ClaimType), 10)),
+			sdk.NewAttribute(types.ClaimReceiveSequence, strconv.FormatUint(skip.Sequence, 10)),
+		))
+	}
+	return events
+}
+
+// EndBlocker applies the passed RelayerAllowList and SkipSequence proposals and deletes the expired
+// prophecies, call it after gov.EndBlocker
+func (k Keeper) EndBlocker(ctx sdk.Context) {
+	if sdk.IsUpgrade(sdk.RelayerAllowList) {
+		k.executeRelayerAllowListProposals(ctx)
+	}
+	if sdk.IsUpgrade(sdk.OracleSkipSequence) {
+		ctx.EventManager().EmitEvents(k.executeSkipSequenceProposals(ctx))
+	}
+	if sdk.IsUpgrade(sdk.ProphecyExpiration) {
+		ctx.EventManager().EmitEvents(k.ExpireProphecies(ctx))
+	}
+}
+
+// ---------------------    RelayerAllowListHooks  -----------------
+type RelayerAllowListHooks struct {
+	k Keeper
+}
+
+func NewRelayerAllowListHooks(keeper Keeper) RelayerAllowListHooks {
+	return RelayerAllowListHooks{keeper}
+}
+
+var _ gov.GovHooks = RelayerAllowListHooks{}
+
+func (hooks RelayerAllowListHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeRelayerAllowList {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	var allowList types.RelayerAllowList
+	if err := hooks.k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &allowList); err != nil {
+		return fmt.Errorf("unmarshal RelayerAllowList failed: %v", err)
+	}
+	return allowList.Check()
+}
+
+// ---------------------    SkipSequenceHooks  -----------------
+type SkipSequenceHooks struct {
+	k Keeper
+}
+
+func NewSkipSequenceHooks(keeper Keeper) SkipSequenceHooks {
+	return SkipSequenceHooks{keeper}
+}
+
+var _ gov.GovHooks = SkipSequenceHooks{}
+
+func (hooks SkipSequenceHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeSkipSequence {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	var skip types.SkipSequence
+	if err := hooks.k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &skip); err != nil {
+		return fmt.Errorf("unmarshal SkipSequence failed: %v", err)
+	}
+	if err := skip.Check(); err != nil {
+		return err
+	}
+	// a proposal can only skip the sequence the claim type is stalled at
+	if sequence := hooks.k.ScKeeper.GetReceiveSequence(ctx, skip.ChainId, skip.ClaimType); sequence != skip.Sequence {
+		return fmt.Errorf("current sequence of channel %d is %d", skip.ClaimType, sequence)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestRelayerAllowList(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	relayer, other := sdk.ValAddress(addrs[0]), sdk.ValAddress(addrs[1])
+	var claimType, otherClaimType types.ClaimType = 0x01, 0x02
+
+	// the allow-lists are not enforced before the upgrade
+	keeper.SetRelayerAllowList(ctx, types.RelayerAllowList{ClaimType: claimType, Relayers: []sdk.ValAddress{relayer}})
+	require.Nil(t, keeper.CheckRelayer(ctx, claimType, other))
+	keeper.SetRelayerAllowList(ctx, types.RelayerAllowList{ClaimType: claimType})
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RelayerAllowList, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.RelayerAllowList)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// anyone may relay without an allow-list
+	require.Nil(t, keeper.CheckRelayer(ctx, claimType, other))
+
+	keeper.SetRelayerAllowList(ctx, types.RelayerAllowList{ClaimType: claimType, Relayers: []sdk.ValAddress{relayer}})
+	allowList, found := keeper.GetRelayerAllowList(ctx, claimType)
+	require.True(t, found)
+	require.Equal(t, []sdk.ValAddress{relayer}, allowList.Relayers)
+	require.Nil(t, keeper.CheckRelayer(ctx, claimType, relayer))
+	err := keeper.CheckRelayer(ctx, claimType, other)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeRelayerNotAllowed, err.Code())
+	require.Nil(t, keeper.CheckRelayer(ctx, otherClaimType, other))
+
+	// an empty allow-list lifts the restriction
+	keeper.SetRelayerAllowList(ctx, types.RelayerAllowList{ClaimType: claimType})
+	_, found = keeper.GetRelayerAllowList(ctx, claimType)
+	require.False(t, found)
+	require.Nil(t, keeper.CheckRelayer(ctx, claimType, other))
+
+	require.NotNil(t, types.RelayerAllowList{ClaimType: claimType, Relayers: []sdk.ValAddress{relayer, relayer}}.Check())
+}
+
+func TestRelayerAllowListConsensus(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RelayerAllowList, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.RelayerAllowList)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stake.NewStakeHandler(sk), ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1)})
+
+	// the allowed relayers reach consensus on their own
+	claimType := types.ClaimType(1)
+	keeper.SetRelayerAllowList(ctx, types.RelayerAllowList{ClaimType: claimType, Relayers: valAddrs[:2]})
+	id := types.GetClaimId(sdk.ChainID(1), claimType, 1)
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(id, valAddrs[0], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(id, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+
+	// a single relayer is all the power of its claim type
+	keeper.SetRelayerAllowList(ctx, types.RelayerAllowList{ClaimType: claimType, Relayers: valAddrs[2:]})
+	id = types.GetClaimId(sdk.ChainID(1), claimType, 2)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(id, valAddrs[2], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+}
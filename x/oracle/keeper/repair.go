@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// sequenceRepairPrefix keeps the repair audit records apart from prophecies and allow-lists
+var sequenceRepairPrefix = []byte{0x02}
+
+func sequenceRepairKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(sequenceRepairPrefix, bz...)
+}
+
+// iterateProphecies calls process with the id of every prophecy until it returns true.
+// Prophecy ids start with the digit of a chain id, other records of the store start with a control byte.
+func (k Keeper) iterateProphecies(ctx sdk.Context, process func(id string, prophecy types.Prophecy) bool) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) == 0 || key[0] < '0' || key[0] > '9' {
+			continue
+		}
+		prophecy, found := k.GetProphecy(ctx, string(key))
+		if !found {
+			continue
+		}
+		if process(string(key), prophecy) {
+			return
+		}
+	}
+}
+
+// checkSequence returns the mismatch of a prophecy with the receive sequence of its claim type
+func (k Keeper) checkSequence(ctx sdk.Context, id string, prophecy types.Prophecy) (types.SequenceMismatch, bool) {
+	chainId, claimType, sequence, err := types.ParseClaimId(id)
+	if err != nil {
+		return types.SequenceMismatch{}, false
+	}
+	mismatch := types.SequenceMismatch{
+		ProphecyID:      id,
+		ChainID:         chainId,
+		ClaimType:       claimType,
+		Sequence:        sequence,
+		ReceiveSequence: k.ScKeeper.GetReceiveSequence(ctx, chainId, claimType),
+		Status:          prophecy.Status.Text.String(),
+	}
+	switch {
+	case sequence < mismatch.ReceiveSequence:
+		mismatch.Kind = types.MismatchStale
+	case sequence > mismatch.ReceiveSequence:
+		mismatch.Kind = types.MismatchAhead
+	case prophecy.Status.Text != types.PendingStatusText:
+		mismatch.Kind = types.MismatchStuck
+	default:
+		return types.SequenceMismatch{}, false
+	}
+	return mismatch, true
+}
+
+// InspectSequences returns the prophecies that do not match the receive sequence of their claim type,
+// such prophecies are left behind when a node crashes in the middle of a claim.
+func (k Keeper) InspectSequences(ctx sdk.Context) []types.SequenceMismatch {
+	var mismatches []types.SequenceMismatch
+	k.iterateProphecies(ctx, func(id string, prophecy types.Prophecy) bool {
+		if mismatch, found := k.checkSequence(ctx, id, prophecy); found {
+			mismatches = append(mismatches, mismatch)
+		}
+		return false
+	})
+	return mismatches
+}
+
+// ApplySequenceRepair deletes the prophecies of a signed repair that are still mismatched the same way,
+// so that the relayers can claim their sequences again, and records the repair. The repair must be
+// signed by the operator of a bonded validator.
+func (k Keeper) ApplySequenceRepair(ctx sdk.Context, repair types.SequenceRepair) (types.SequenceRepairRecord, sdk.Error) {
+	if err := repair.Verify(); err != nil {
+		return types.SequenceRepairRecord{}, types.ErrInvalidSequenceRepair(err.Error())
+	}
+	if k.stakeKeeper.GetLastValidatorPower(ctx, sdk.ValAddress(repair.Signer)) <= 0 {
+		return types.SequenceRepairRecord{}, types.ErrInvalidSequenceRepair(
+			fmt.Sprintf("signer %s is not the operator of a bonded validator", repair.Signer))
+	}
+
+	logger := ctx.Logger().With("module", "x/oracle")
+	record := types.SequenceRepairRecord{AppliedHeight: ctx.BlockHeight(), Repair: repair}
+	for _, expected := range repair.Mismatches {
+		prophecy, found := k.GetProphecy(ctx, expected.ProphecyID)
+		if !found {
+			logger.Info("skip repairing a prophecy gone", "prophecy", expected.ProphecyID)
+			continue
+		}
+		mismatch, found := k.checkSequence(ctx, expected.ProphecyID, prophecy)
+		if !found || mismatch != expected {
+			logger.Info("skip repairing a prophecy changed", "prophecy", expected.ProphecyID)
+			continue
+		}
+		k.DeleteProphecy(ctx, expected.ProphecyID)
+		record.Removed = append(record.Removed, expected.ProphecyID)
+		logger.Info("repaired prophecy", "mismatch", mismatch.String(), "signer", repair.Signer)
+	}
+
+	ctx.KVStore(k.storeKey).Set(sequenceRepairKey(record.AppliedHeight), k.cdc.MustMarshalBinaryBare(record))
+	return record, nil
+}
+
+// GetSequenceRepairRecords returns the audit records of the applied repairs by height
+func (k Keeper) GetSequenceRepairRecords(ctx sdk.Context) []types.SequenceRepairRecord {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), sequenceRepairPrefix)
+	defer iter.Close()
+	var records []types.SequenceRepairRecord
+	for ; iter.Valid(); iter.Next() {
+		var record types.SequenceRepairRecord
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,100 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func signRepair(t *testing.T, privKey crypto.PrivKey, repair types.SequenceRepair) types.SequenceRepair {
+	repair.Signer = sdk.AccAddress(privKey.PubKey().Address())
+	repair.PubKey = privKey.PubKey().Bytes()
+	sig, err := privKey.Sign(repair.SignBytes())
+	require.NoError(t, err)
+	repair.Signature = sig
+	return repair
+}
+
+func TestSequenceRepair(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, privKeys := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 10})
+	createValidators(t, stake.NewStakeHandler(sk), ctx, []sdk.ValAddress{sdk.ValAddress(addrs[0])}, []int64{5})
+	stake.EndBlocker(ctx, sk)
+
+	var chainId sdk.ChainID = 1
+	var claimType types.ClaimType = 0x01
+	keeper.ScKeeper.IncrReceiveSequence(ctx, chainId, claimType)
+	keeper.ScKeeper.IncrReceiveSequence(ctx, chainId, claimType)
+
+	stale := types.NewProphecy(types.GetClaimId(chainId, claimType, 0))
+	stuck := types.NewProphecy(types.GetClaimId(chainId, claimType, 2))
+	stuck.Status = types.NewStatus(types.SuccessStatusText, "")
+	ahead := types.NewProphecy(types.GetClaimId(chainId, claimType, 5))
+	pending := types.NewProphecy(types.GetClaimId(chainId, 0x02, 0))
+	for _, prophecy := range []types.Prophecy{stale, stuck, ahead, pending} {
+		keeper.setProphecy(ctx, prophecy)
+	}
+	keeper.SetRelayerAllowList(ctx, types.RelayerAllowList{ClaimType: claimType, Relayers: []sdk.ValAddress{sdk.ValAddress(make([]byte, sdk.AddrLen))}})
+
+	mismatches := keeper.InspectSequences(ctx)
+	require.Len(t, mismatches, 3)
+	kinds := make(map[string]string)
+	for _, mismatch := range mismatches {
+		require.EqualValues(t, 2, mismatch.ReceiveSequence)
+		kinds[mismatch.ProphecyID] = mismatch.Kind
+	}
+	require.Equal(t, map[string]string{
+		stale.ID: types.MismatchStale,
+		stuck.ID: types.MismatchStuck,
+		ahead.ID: types.MismatchAhead,
+	}, kinds)
+
+	// an unsigned repair is rejected
+	repair := types.SequenceRepair{Height: ctx.BlockHeight(), Mismatches: mismatches}
+	_, err := keeper.ApplySequenceRepair(ctx, repair)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeInvalidSequenceRepair, err.Code())
+	// a repair signed by a non validator is rejected
+	_, err = keeper.ApplySequenceRepair(ctx, signRepair(t, secp256k1.GenPrivKey(), repair))
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeInvalidSequenceRepair, err.Code())
+	_, err = keeper.ApplySequenceRepair(ctx, signRepair(t, privKeys[1], repair))
+	require.NotNil(t, err)
+
+	signed := signRepair(t, privKeys[0], repair)
+	tampered := signed
+	tampered.Height++
+	_, err = keeper.ApplySequenceRepair(ctx, tampered)
+	require.NotNil(t, err)
+
+	// the prophecy changed since the inspection is kept
+	keeper.DeleteProphecy(ctx, ahead.ID)
+	ahead.Status = types.NewStatus(types.FailedStatusText, "")
+	keeper.setProphecy(ctx, ahead)
+
+	record, err := keeper.ApplySequenceRepair(ctx, signed)
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{stale.ID, stuck.ID}, record.Removed)
+	_, found := keeper.GetProphecy(ctx, stuck.ID)
+	require.False(t, found)
+	_, found = keeper.GetProphecy(ctx, ahead.ID)
+	require.True(t, found)
+	_, found = keeper.GetProphecy(ctx, pending.ID)
+	require.True(t, found)
+	_, found = keeper.GetRelayerAllowList(ctx, claimType)
+	require.True(t, found)
+
+	records := keeper.GetSequenceRepairRecords(ctx)
+	require.Len(t, records, 1)
+	require.Equal(t, record.Removed, records[0].Removed)
+	require.Equal(t, signed.Signature, records[0].Repair.Signature)
+	require.EqualValues(t, 10, records[0].AppliedHeight)
+}
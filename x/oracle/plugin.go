@@ -1,6 +1,8 @@
 package oracle
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/oracle/types"
 )
@@ -10,8 +12,76 @@ func RegisterUpgradeBeginBlocker(keeper Keeper) {
 		keeper.SetParams(ctx, types.Params{ConsensusNeeded: types.DefaultConsensusNeeded})
 	})
 
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ModuleAccountUpgrade, func(ctx sdk.Context) {
+		migratePegAccount(ctx, keeper)
+	})
+
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ProphecyExpiration, func(ctx sdk.Context) {
+		keeper.SetParams(ctx, types.Params{
+			ConsensusNeeded:    keeper.GetConsensusNeeded(ctx),
+			ProphecyExpiration: types.DefaultProphecyExpiration,
+		})
+		keeper.EnqueuePendingProphecies(ctx)
+	})
+
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.OracleClaimIncentive, func(ctx sdk.Context) {
+		keeper.SetParams(ctx, types.Params{
+			ConsensusNeeded:    keeper.GetConsensusNeeded(ctx),
+			ProphecyExpiration: keeper.GetProphecyExpiration(ctx),
+			SlashNonVoters:     keeper.GetSlashNonVoters(ctx),
+		})
+	})
+
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ProphecySnapshot, func(ctx sdk.Context) {
+		keeper.SetParams(ctx, types.Params{
+			ConsensusNeeded:          keeper.GetConsensusNeeded(ctx),
+			ProphecyExpiration:       keeper.GetProphecyExpiration(ctx),
+			SlashNonVoters:           keeper.GetSlashNonVoters(ctx),
+			ClaimReward:              keeper.GetClaimReward(ctx),
+			SlashContradictingClaims: keeper.GetSlashContradictingClaims(ctx),
+		})
+	})
+
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ClaimSizeLimit, func(ctx sdk.Context) {
+		keeper.SetParams(ctx, types.Params{
+			ConsensusNeeded:          keeper.GetConsensusNeeded(ctx),
+			ProphecyExpiration:       keeper.GetProphecyExpiration(ctx),
+			SlashNonVoters:           keeper.GetSlashNonVoters(ctx),
+			ClaimReward:              keeper.GetClaimReward(ctx),
+			SlashContradictingClaims: keeper.GetSlashContradictingClaims(ctx),
+			SnapshotPower:            keeper.GetSnapshotPower(ctx),
+		})
+	})
+
 	err := keeper.ScKeeper.RegisterChannel(types.RelayPackagesChannelName, types.RelayPackagesChannelId, nil)
 	if err != nil {
 		panic("register relay packages channel error")
 	}
 }
+
+// migratePegAccount moves the tokens escrowed by the legacy peg account to sdk.PegModuleAccount
+func migratePegAccount(ctx sdk.Context, keeper Keeper) {
+	coins := keeper.BkKeeper.GetCoins(ctx, sdk.PegAccount)
+	if coins.IsZero() {
+		return
+	}
+	if _, err := keeper.BkKeeper.SendCoins(ctx, sdk.PegAccount, sdk.PegModuleAccount, coins); err != nil {
+		panic(fmt.Sprintf("migrate peg account error(%s) should not happen", err.Error()))
+	}
+	if keeper.Pool != nil {
+		keeper.Pool.AddAddrs([]sdk.AccAddress{sdk.PegAccount, sdk.PegModuleAccount})
+	}
+}
+
+// RegisterSequenceRepair applies a signed repair at the height of the upgrade, every node of the
+// chain has to register the same repair for the same upgrade.
+func RegisterSequenceRepair(keeper Keeper, upgradeName string, repair types.SequenceRepair) {
+	sdk.UpgradeMgr.RegisterBeginBlocker(upgradeName, func(ctx sdk.Context) {
+		record, err := keeper.ApplySequenceRepair(ctx, repair)
+		if err != nil {
+			ctx.Logger().With("module", "x/oracle").Error("sequence repair not applied", "upgrade", upgradeName, "err", err.Error())
+			return
+		}
+		ctx.Logger().With("module", "x/oracle").Info("sequence repair applied", "upgrade", upgradeName, "removed", len(record.Removed))
+	})
+}
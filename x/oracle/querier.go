@@ -0,0 +1,121 @@
+package oracle
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case types.QueryProphecyNonVoters:
+			return queryProphecyNonVoters(ctx, cdc, req, k)
+		case types.QueryReceiveSequence:
+			return queryReceiveSequence(ctx, cdc, req, k)
+		case types.QueryProphecy:
+			return queryProphecy(ctx, cdc, req, k)
+		case types.QueryClaimTypes:
+			return queryClaimTypes(cdc, k)
+		case types.QuerySequences:
+			return querySequences(ctx, cdc, req, k)
+		case types.QueryPendingClaims:
+			return queryPendingClaims(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown oracle query endpoint")
+		}
+	}
+}
+
+func queryProphecyNonVoters(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryProphecyNonVotersParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.ProphecyID == "" {
+		return nil, types.ErrInvalidIdentifier()
+	}
+
+	nonVoters, sdkErr := k.GetProphecyNonVoters(ctx, params.ProphecyID)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, nonVoters)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryReceiveSequence(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryReceiveSequenceParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	sequence := k.ScKeeper.GetReceiveSequence(ctx, params.ChainId, types.RelayPackagesChannelId)
+	bz, err := cdc.MarshalJSON(sequence)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryProphecy(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryProphecyParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.ProphecyID == "" {
+		return nil, types.ErrInvalidIdentifier()
+	}
+
+	prophecy, found := k.GetProphecyInfo(ctx, params.ProphecyID)
+	if !found {
+		return nil, types.ErrProphecyNotFound()
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, prophecy)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryClaimTypes(cdc *codec.Codec, k Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, k.GetClaimTypes())
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func querySequences(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QuerySequencesParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	bz, err := codec.MarshalJSONIndent(cdc, k.GetClaimSequences(ctx, params.ChainId))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryPendingClaims(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryPendingClaimsParams
+	if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if len(params.ValidatorAddr) != sdk.AddrLen {
+		return nil, sdk.ErrInvalidAddress("invalid validator address")
+	}
+
+	bz, err := codec.MarshalJSONIndent(cdc, k.GetPendingClaims(ctx, params.ValidatorAddr))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
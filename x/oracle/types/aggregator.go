@@ -0,0 +1,127 @@
+package types
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WeightedClaim is a validator's claim payload together with the validator's current voting power
+type WeightedClaim struct {
+	ValidatorAddress sdk.ValAddress
+	Payload          string
+	Power            int64
+}
+
+// ClaimAggregator decides the outcome of a prophecy from the claims made so far.
+// totalPower is the power of the whole bonded validator set, claims carry only the
+// power of validators that are still bonded.
+type ClaimAggregator interface {
+	Aggregate(claims []WeightedClaim, totalPower int64, consensusNeeded sdk.Dec) Status
+}
+
+// ExactMatchAggregator requires validators to submit byte-identical payloads. The payload
+// with the highest power wins once its share of total power reaches consensus.
+type ExactMatchAggregator struct{}
+
+var _ ClaimAggregator = ExactMatchAggregator{}
+
+func (ExactMatchAggregator) Aggregate(claims []WeightedClaim, totalPower int64, consensusNeeded sdk.Dec) Status {
+	claimPowers := make(map[string]int64)
+	totalClaimsPower := int64(0)
+	for _, claim := range claims {
+		claimPowers[claim.Payload] += claim.Power
+		totalClaimsPower += claim.Power
+	}
+
+	highestClaimPower := int64(-1)
+	highestClaim := ""
+	for claim, power := range claimPowers {
+		// break ties by payload so that the result does not depend on map order
+		if power > highestClaimPower || (power == highestClaimPower && claim < highestClaim) {
+			highestClaimPower = power
+			highestClaim = claim
+		}
+	}
+
+	highestConsensusRatio := sdk.NewDec(highestClaimPower).Quo(sdk.NewDec(totalPower))
+	remainingPossibleClaimPower := totalPower - totalClaimsPower
+	highestPossibleClaimPower := highestClaimPower + remainingPossibleClaimPower
+	highestPossibleConsensusRatio := sdk.NewDec(highestPossibleClaimPower).Quo(sdk.NewDec(totalPower))
+
+	if highestConsensusRatio.GTE(consensusNeeded) {
+		return NewStatus(SuccessStatusText, highestClaim)
+	} else if highestPossibleConsensusRatio.LT(consensusNeeded) {
+		return NewStatus(FailedStatusText, "")
+	}
+	return NewStatus(PendingStatusText, "")
+}
+
+// ClaimItemCodec splits a payload made of a list of items and joins accepted items back
+// into a payload. Join receives the items in ascending byte order, so item encodings
+// that must stay ordered should start with their position, e.g. a package sequence.
+type ClaimItemCodec interface {
+	Split(payload string) ([]string, error)
+	Join(items []string) string
+}
+
+// ItemMatchAggregator computes consensus per item instead of per payload, so that
+// validators who disagree on part of a batch still agree on the rest of it. An item is
+// accepted once the power of the claims containing it reaches consensus. The prophecy is
+// decided when no other item can reach consensus any more, and succeeds with the
+// accepted items or fails if there are none.
+type ItemMatchAggregator struct {
+	Codec ClaimItemCodec
+}
+
+var _ ClaimAggregator = ItemMatchAggregator{}
+
+func NewItemMatchAggregator(codec ClaimItemCodec) ItemMatchAggregator {
+	return ItemMatchAggregator{Codec: codec}
+}
+
+func (agg ItemMatchAggregator) Aggregate(claims []WeightedClaim, totalPower int64, consensusNeeded sdk.Dec) Status {
+	itemPowers := make(map[string]int64)
+	totalClaimsPower := int64(0)
+	for _, claim := range claims {
+		totalClaimsPower += claim.Power
+		items, err := agg.Codec.Split(claim.Payload)
+		if err != nil {
+			// a malformed claim still counts as claimed power, it just supports no item
+			continue
+		}
+		seen := make(map[string]bool, len(items))
+		for _, item := range items {
+			if seen[item] {
+				continue
+			}
+			seen[item] = true
+			itemPowers[item] += claim.Power
+		}
+	}
+
+	ratio := func(power int64) sdk.Dec {
+		return sdk.NewDec(power).Quo(sdk.NewDec(totalPower))
+	}
+	remainingPossibleClaimPower := totalPower - totalClaimsPower
+
+	// an item nobody has claimed yet could still be accepted with the remaining power
+	undecided := ratio(remainingPossibleClaimPower).GTE(consensusNeeded)
+	accepted := make([]string, 0, len(itemPowers))
+	for item, power := range itemPowers {
+		if ratio(power).GTE(consensusNeeded) {
+			accepted = append(accepted, item)
+		} else if ratio(power + remainingPossibleClaimPower).GTE(consensusNeeded) {
+			undecided = true
+		}
+	}
+
+	if undecided {
+		return NewStatus(PendingStatusText, "")
+	}
+	if len(accepted) == 0 {
+		return NewStatus(FailedStatusText, "")
+	}
+	sort.Strings(accepted)
+	return NewStatus(SuccessStatusText, agg.Codec.Join(accepted))
+}
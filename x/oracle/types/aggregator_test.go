@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type commaItemCodec struct{}
+
+func (commaItemCodec) Split(payload string) ([]string, error) {
+	if strings.HasPrefix(payload, "!") {
+		return nil, fmt.Errorf("malformed payload")
+	}
+	return strings.Split(payload, ","), nil
+}
+
+func (commaItemCodec) Join(items []string) string {
+	return strings.Join(items, ",")
+}
+
+func weightedClaims(payloads ...string) []WeightedClaim {
+	claims := make([]WeightedClaim, 0, len(payloads))
+	for i, payload := range payloads {
+		claims = append(claims, WeightedClaim{
+			ValidatorAddress: sdk.ValAddress([]byte{byte(i)}),
+			Payload:          payload,
+			Power:            10,
+		})
+	}
+	return claims
+}
+
+func TestExactMatchAggregator(t *testing.T) {
+	agg := ExactMatchAggregator{}
+	consensusNeeded := sdk.NewDecWithPrec(7, 1)
+
+	status := agg.Aggregate(weightedClaims("a", "a"), 40, consensusNeeded)
+	require.Equal(t, PendingStatusText, status.Text)
+
+	status = agg.Aggregate(weightedClaims("a", "a", "a"), 40, consensusNeeded)
+	require.Equal(t, SuccessStatusText, status.Text)
+	require.Equal(t, "a", status.FinalClaim)
+
+	status = agg.Aggregate(weightedClaims("a", "b"), 30, consensusNeeded)
+	require.Equal(t, FailedStatusText, status.Text)
+
+	// ties are broken by payload
+	status = agg.Aggregate(weightedClaims("b", "a"), 20, sdk.NewDecWithPrec(5, 1))
+	require.Equal(t, SuccessStatusText, status.Text)
+	require.Equal(t, "a", status.FinalClaim)
+}
+
+func TestItemMatchAggregator(t *testing.T) {
+	agg := NewItemMatchAggregator(commaItemCodec{})
+	consensusNeeded := sdk.NewDecWithPrec(7, 1)
+
+	// exact match would fail here, but items 1 and 2 are agreed on by everyone
+	status := agg.Aggregate(weightedClaims("1,2,3", "1,2", "2,1,4", "1,2,3"), 40, consensusNeeded)
+	require.Equal(t, SuccessStatusText, status.Text)
+	require.Equal(t, "1,2", status.FinalClaim)
+
+	// item 3 could still be accepted by the last validator
+	status = agg.Aggregate(weightedClaims("1,2,3", "1,2", "1,2,3"), 40, consensusNeeded)
+	require.Equal(t, PendingStatusText, status.Text)
+
+	status = agg.Aggregate(weightedClaims("1,2,3", "1,2,3", "1,2,3"), 40, consensusNeeded)
+	require.Equal(t, SuccessStatusText, status.Text)
+	require.Equal(t, "1,2,3", status.FinalClaim)
+
+	// malformed claims count as claimed power but support no item
+	status = agg.Aggregate(weightedClaims("1", "!1", "2", "!2"), 40, consensusNeeded)
+	require.Equal(t, FailedStatusText, status.Text)
+}
@@ -24,6 +24,9 @@ const (
 	CodeInvalidLengthOfPayload        sdk.CodeType = 1011
 	CodeFeeOverflow                   sdk.CodeType = 1012
 	CodeInvalidPayload                sdk.CodeType = 1013
+	CodeRelayerNotAllowed             sdk.CodeType = 1014
+	CodeInvalidSequenceRepair         sdk.CodeType = 1015
+	CodeClaimTooLarge                 sdk.CodeType = 1016
 )
 
 func ErrProphecyNotFound() sdk.Error {
@@ -54,10 +57,18 @@ func ErrInvalidClaim() sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidClaim, fmt.Sprintf("claim cannot be empty string"))
 }
 
+func ErrClaimTooLarge(size int, maxSize int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeClaimTooLarge, fmt.Sprintf("claim has %d bytes, exceeds the limit %d", size, maxSize))
+}
+
 func ErrInvalidPackageType() sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidClaim, fmt.Sprintf("package type is invalid"))
 }
 
+func ErrRelayerNotAllowed(claimType ClaimType) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeRelayerNotAllowed, fmt.Sprintf("validator is not allowed to relay claims of type %d", claimType))
+}
+
 func ErrInvalidValidator() sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidValidator, fmt.Sprintf("claim must be made by actively bonded validator"))
 }
@@ -85,3 +96,7 @@ func ErrFeeOverflow(msg string) sdk.Error {
 func ErrInvalidPayload(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidPayload, msg)
 }
+
+func ErrInvalidSequenceRepair(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidSequenceRepair, msg)
+}
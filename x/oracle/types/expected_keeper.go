@@ -12,3 +12,16 @@ type StakingKeeper interface {
 	GetLastTotalPower(ctx sdk.Context) (power int64)
 	GetBondedValidatorsByPower(ctx sdk.Context) []stake.Validator
 }
+
+// SlashingKeeper punishes the validators misbehaving on the oracle
+type SlashingKeeper interface {
+	// SlashOracleNonVoter punishes a bonded validator which did not claim on an expired prophecy
+	SlashOracleNonVoter(ctx sdk.Context, validator sdk.Validator)
+	// SlashOracleContradictingClaim punishes a validator which claimed a payload contradicting a successful prophecy
+	SlashOracleContradictingClaim(ctx sdk.Context, validator sdk.Validator)
+}
+
+// DistributionKeeper pays the oracle claim rewards out of the community pool
+type DistributionKeeper interface {
+	AllocateCommunityPoolToValidator(ctx sdk.Context, valAddr sdk.ValAddress, amt sdk.Coins) bool
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	param "github.com/cosmos/cosmos-sdk/x/paramHub/types"
+)
+
+const (
+	// ClaimSizeFeeType is the fee param of every ClaimFeeUnitSize bytes of claim content, it is charged
+	// on top of the oracleClaim fee after sdk.ClaimSizeFee even if the oracleClaim fee is free
+	ClaimSizeFeeType = "oracleClaimSize"
+
+	// ClaimFeeUnitSize is the claim content bytes charged the oracleClaimSize fee once
+	ClaimFeeUnitSize = 1024
+)
+
+// ClaimFeeCalculatorGen charges the oracleClaim fee plus the oracleClaimSize fee once for every started
+// ClaimFeeUnitSize bytes of the claim content, only the oracleClaim fee before sdk.ClaimSizeFee
+var ClaimFeeCalculatorGen = fees.FeeCalculatorGenerator(func(params param.FeeParam) fees.FeeCalculator {
+	fixed := fees.FixedFeeCalculatorGen(params)
+
+	return fees.FeeCalculator(func(msg sdk.Msg) sdk.Fee {
+		fee := fixed(msg)
+		if !sdk.IsUpgrade(sdk.ClaimSizeFee) {
+			return fee
+		}
+		claimMsg, ok := msg.(ClaimMsg)
+		if !ok {
+			panic("unexpected msg for ClaimFeeCalculator")
+		}
+
+		sizeFee := claimSizeFee(claimMsg)
+		if sizeFee.IsEmpty() {
+			return fee
+		}
+		if fee.IsEmpty() {
+			return sizeFee
+		}
+		fee.AddFee(sizeFee)
+		return fee
+	})
+})
+
+// claimSizeFee is the oracleClaimSize fee of the claim content
+func claimSizeFee(msg ClaimMsg) sdk.Fee {
+	calculator := fees.GetCalculator(ClaimSizeFeeType)
+	if calculator == nil {
+		return sdk.Fee{}
+	}
+	unitFee := calculator(msg)
+	if unitFee.IsEmpty() {
+		return unitFee
+	}
+
+	units := int64(ClaimContentSize(msg.Payload)+ClaimFeeUnitSize-1) / ClaimFeeUnitSize
+	if units < 1 {
+		units = 1
+	}
+	tokens := make(sdk.Coins, 0, len(unitFee.Tokens))
+	for _, coin := range unitFee.Tokens {
+		amount := sdk.TokenMaxTotalSupply
+		if units <= sdk.TokenMaxTotalSupply/coin.Amount {
+			amount = coin.Amount * units
+		}
+		tokens = append(tokens, sdk.NewCoin(coin.Denom, amount))
+	}
+	return sdk.NewFee(tokens, unitFee.Type)
+}
+
+// ClaimContentSize is the size of the claim content kept in a prophecy for a payload, which is hex encoded
+func ClaimContentSize(payload []byte) int {
+	return hex.EncodedLen(len(payload))
+}
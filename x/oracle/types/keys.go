@@ -1,13 +1,33 @@
 package types
 
 const (
-	EventTypeClaim = "claim"
+	EventTypeClaim                = "claim"
+	EventTypeClaimExecutionFailed = "claim_execution_failed"
+	EventTypeProphecyExpired      = "prophecy_expired"
+	EventTypeClaimReward          = "claim_reward"
+	EventTypeClaimContradicted    = "claim_contradicted"
+	EventTypeSequenceSkipped      = "sequence_skipped"
+	EventTypeProphecyCreated      = "prophecy_created"
+	EventTypeClaimAdded           = "claim_added"
+	EventTypeProphecySucceeded    = "prophecy_succeeded"
+	EventTypeProphecyFailed       = "prophecy_failed"
+	EventTypeClaimExecuted        = "claim_executed"
 
-	ClaimResultCode      = "ClaimResultCode"
-	ClaimResultMsg       = "ClaimResultMsg"
-	ClaimChannel         = "ClaimChannel"
-	ClaimReceiveSequence = "ClaimReceiveSequence"
-	ClaimSendSequence    = "ClaimSendSequence"
-	ClaimCrash           = "ClaimCrash"
-	ClaimPackageType     = "ClaimPackageType"
+	ClaimResultCode       = "ClaimResultCode"
+	ClaimResultMsg        = "ClaimResultMsg"
+	ClaimChannel          = "ClaimChannel"
+	ClaimReceiveSequence  = "ClaimReceiveSequence"
+	ClaimSendSequence     = "ClaimSendSequence"
+	ClaimCrash            = "ClaimCrash"
+	ClaimPackageType      = "ClaimPackageType"
+	ClaimProphecyID       = "ClaimProphecyID"
+	ClaimFailureReason    = "ClaimFailureReason"
+	ProphecyNonVoterCount = "ProphecyNonVoterCount"
+	ClaimValidator        = "ClaimValidator"
+	ClaimRewardAmount     = "ClaimRewardAmount"
+	ClaimChainID          = "ClaimChainID"
+	ClaimPower            = "ClaimPower"
+	ProphecyClaimedPower  = "ProphecyClaimedPower"
+	ProphecyTotalPower    = "ProphecyTotalPower"
+	ClaimPackageCount     = "ClaimPackageCount"
 )
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
@@ -12,6 +13,10 @@ const (
 	RouteOracle = "oracle"
 
 	ClaimMsgType = "oracleClaim"
+
+	// MaxClaimContentSize is the max bytes of the hex encoded claim content after sdk.ClaimSizeLimit,
+	// the MaxClaimSize param can only lower it
+	MaxClaimContentSize = 1 << 20
 )
 
 var _ sdk.Msg = ClaimMsg{}
@@ -24,6 +29,18 @@ type Package struct {
 	Payload   []byte
 }
 
+// Decode decodes the header and the load of a package, the load is decompressed after sdk.IBCLoadCompression
+func (p Package) Decode() (packageType sdk.CrossChainPackageType, relayFee big.Int, load []byte, err error) {
+	if sdk.IsUpgrade(sdk.IBCLoadCompression) {
+		return types.DecodePackage(p.Payload)
+	}
+	packageType, relayFee, err = types.DecodePackageHeader(p.Payload)
+	if err != nil {
+		return
+	}
+	return packageType, relayFee, p.Payload[types.PackageHeaderLength:], nil
+}
+
 type ClaimMsg struct {
 	ChainId          sdk.ChainID    `json:"chain_id"`
 	Sequence         uint64         `json:"sequence"`
@@ -73,5 +90,8 @@ func (msg ClaimMsg) ValidateBasic() sdk.Error {
 	if len(msg.ValidatorAddress) != sdk.AddrLen {
 		return sdk.ErrInvalidAddress(msg.ValidatorAddress.String())
 	}
+	if size := ClaimContentSize(msg.Payload); sdk.IsUpgrade(sdk.ClaimSizeLimit) && size > MaxClaimContentSize {
+		return ErrClaimTooLarge(size, MaxClaimContentSize)
+	}
 	return nil
 }
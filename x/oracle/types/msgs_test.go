@@ -7,7 +7,9 @@ import (
 	"github.com/tendermint/tendermint/libs/common"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	param "github.com/cosmos/cosmos-sdk/x/paramHub/types"
 	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
 )
 
@@ -38,3 +40,61 @@ func TestClaimMsg(t *testing.T) {
 		}
 	}
 }
+
+func TestClaimMsgSizeLimit(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	msg := NewClaimMsg(1, 1, make([]byte, MaxClaimContentSize/2+1), addrs[0])
+	require.Nil(t, msg.ValidateBasic())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ClaimSizeLimit, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ClaimSizeLimit)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// the hex encoded content is limited
+	require.NotNil(t, msg.ValidateBasic())
+	msg.Payload = msg.Payload[:MaxClaimContentSize/2]
+	require.Nil(t, msg.ValidateBasic())
+}
+
+func TestClaimFeeCalculator(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	msg := NewClaimMsg(1, 1, make([]byte, ClaimFeeUnitSize), addrs[0])
+	fee := func(amount int64) sdk.Fee {
+		return sdk.NewFee(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, amount)}, sdk.FeeForProposer)
+	}
+	defer fees.UnsetAllCalculators()
+	fees.RegisterCalculator(ClaimSizeFeeType, fees.FixedFeeCalculator(10, sdk.FeeForProposer))
+
+	calculator := ClaimFeeCalculatorGen(&param.FixedFeeParams{MsgType: ClaimMsgType, Fee: 5, FeeFor: sdk.FeeForProposer})
+	require.Equal(t, fee(5), calculator(msg))
+	free := ClaimFeeCalculatorGen(&param.FixedFeeParams{MsgType: ClaimMsgType, Fee: sdk.ZeroFee, FeeFor: sdk.FeeFree})
+	require.Equal(t, sdk.FeeFree, free(msg).Type)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ClaimSizeFee, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ClaimSizeFee)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	// the hex encoded content of the payload takes 2 units
+	require.Equal(t, fee(25), calculator(msg))
+	msg.Payload = append(msg.Payload, 0x01)
+	require.Equal(t, fee(35), calculator(msg))
+	msg.Payload = nil
+	require.Equal(t, fee(15), calculator(msg))
+
+	// the size is charged even if the claim itself is free
+	msg.Payload = make([]byte, ClaimFeeUnitSize)
+	require.Equal(t, fee(20), free(msg))
+
+	fees.RegisterCalculator(ClaimSizeFeeType, fees.FixedFeeCalculator(sdk.TokenMaxTotalSupply, sdk.FeeForProposer))
+	require.Equal(t, fee(sdk.TokenMaxTotalSupply), free(msg))
+
+	fees.RegisterCalculator(ClaimSizeFeeType, fees.FreeFeeCalculator())
+	require.Equal(t, fee(5), calculator(msg))
+	require.Equal(t, sdk.FeeFree, free(msg).Type)
+}
@@ -1,8 +1,13 @@
 package types
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -13,16 +18,45 @@ var (
 	// prophecy to be finalized
 	DefaultConsensusNeeded      sdk.Dec = sdk.NewDecWithPrec(7, 1)
 	ParamStoreKeyProphecyParams         = []byte("prophecyParams")
+
+	// DefaultProphecyExpiration defines how long a prophecy waits for consensus by default
+	DefaultProphecyExpiration           = 24 * time.Hour
+	ParamStoreKeyProphecyExpiration     = []byte("prophecyExpiration")
+	ParamStoreKeyProphecySlashNonVoters = []byte("prophecySlashNonVoters")
+
+	ParamStoreKeyClaimReward              = []byte("claimReward")
+	ParamStoreKeySlashContradictingClaims = []byte("slashContradictingClaims")
+
+	ParamStoreKeyProphecySnapshotPower = []byte("prophecySnapshotPower")
+	ParamStoreKeyMaxClaimSize          = []byte("maxClaimSize")
 )
 
 type Params struct {
 	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"` //  Minimum deposit for a proposal to enter voting period.
+
+	ProphecyExpiration time.Duration `json:"ProphecyExpiration"` // how long a prophecy waits for consensus, 0 keeps it forever
+	SlashNonVoters     bool          `json:"SlashNonVoters"`     // slash the bonded validators which did not claim on an expired prophecy
+
+	ClaimReward              int64 `json:"ClaimReward"`              // native tokens of the community pool shared by the validators claiming a successful prophecy
+	SlashContradictingClaims bool  `json:"SlashContradictingClaims"` // slash the validators claiming a payload other than the one of a successful prophecy
+
+	SnapshotPower bool  `json:"SnapshotPower"` // weigh the claims of a prophecy by the bonded validator set at its creation
+	MaxClaimSize  int64 `json:"MaxClaimSize"`  // the max bytes of a claim payload, 0 for MaxClaimContentSize
 }
 
 func (p *Params) UpdateCheck() error {
 	if p.ConsensusNeeded.IsNil() || p.ConsensusNeeded.GT(sdk.OneDec()) || p.ConsensusNeeded.LT(sdk.NewDecWithPrec(5, 1)) {
 		return fmt.Errorf("the value should be in range 0.5 to 1")
 	}
+	if p.ProphecyExpiration != 0 && (p.ProphecyExpiration < time.Hour || p.ProphecyExpiration > 30*24*time.Hour) {
+		return fmt.Errorf("the prophecy expiration should be 0 or in range 1 hour to 30 days")
+	}
+	if p.ClaimReward < 0 || p.ClaimReward > sdk.TokenMaxTotalSupply {
+		return fmt.Errorf("the claim reward should be in range 0 to %d", sdk.TokenMaxTotalSupply)
+	}
+	if p.MaxClaimSize < 0 || p.MaxClaimSize > MaxClaimContentSize {
+		return fmt.Errorf("the max claim size should be in range 0 to %d", MaxClaimContentSize)
+	}
 	return nil
 }
 
@@ -33,6 +67,12 @@ func (p *Params) GetParamAttribute() (string, bool) {
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
 	return params.KeyValuePairs{
 		{ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
+		{ParamStoreKeyProphecyExpiration, &p.ProphecyExpiration},
+		{ParamStoreKeyProphecySlashNonVoters, &p.SlashNonVoters},
+		{ParamStoreKeyClaimReward, &p.ClaimReward},
+		{ParamStoreKeySlashContradictingClaims, &p.SlashContradictingClaims},
+		{ParamStoreKeyProphecySnapshotPower, &p.SnapshotPower},
+		{ParamStoreKeyMaxClaimSize, &p.MaxClaimSize},
 	}
 }
 
@@ -53,6 +93,30 @@ type Prophecy struct {
 	ClaimValidators map[string][]sdk.ValAddress `json:"claim_validators"`
 	//This is a mapping from a validator bech32 address to their claim
 	ValidatorClaims map[string]string `json:"validator_claims"`
+
+	// Snapshot is the bonded validator set the claims are weighed by, nil for the current one
+	Snapshot *PowerSnapshot `json:"snapshot,omitempty"`
+}
+
+// PowerSnapshot is the bonded validator set, ordered by address, along with the total power
+// at the creation of a prophecy
+type PowerSnapshot struct {
+	TotalPower int64            `json:"total_power"`
+	Validators []ValidatorPower `json:"validators"`
+}
+
+// Contains returns whether the validator is in the snapshot
+func (snapshot PowerSnapshot) Contains(validator sdk.ValAddress) bool {
+	i := sort.Search(len(snapshot.Validators), func(i int) bool {
+		return bytes.Compare(snapshot.Validators[i].Validator, validator) >= 0
+	})
+	return i < len(snapshot.Validators) && snapshot.Validators[i].Validator.Equals(validator)
+}
+
+// ValidatorPower is the power of a validator in a snapshot
+type ValidatorPower struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Power     int64          `json:"power"`
 }
 
 // DBProphecy is what the prophecy becomes when being saved to the database.
@@ -61,6 +125,17 @@ type DBProphecy struct {
 	ID              string `json:"id"`
 	Status          Status `json:"status"`
 	ValidatorClaims []byte `json:"validator_claims"`
+	// PayloadRefs tells ValidatorClaims maps the validators to the hashes of their claims,
+	// the payloads are stored once apart from the prophecy
+	PayloadRefs bool `json:"payload_refs"`
+
+	Snapshot *PowerSnapshot `json:"snapshot,omitempty"`
+}
+
+// ClaimPayloadHash returns the hash a claim payload is stored by
+func ClaimPayloadHash(payload string) string {
+	hash := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(hash[:])
 }
 
 // SerializeForDB serializes a prophecy into a DBProphecy
@@ -74,15 +149,58 @@ func (prophecy Prophecy) SerializeForDB() (DBProphecy, error) {
 		ID:              prophecy.ID,
 		Status:          prophecy.Status,
 		ValidatorClaims: validatorClaims,
+		Snapshot:        prophecy.Snapshot,
 	}, nil
 }
 
+// SerializeForDBWithPayloadRefs serializes a prophecy into a DBProphecy referring to the claims
+// by their hashes, it also returns the distinct payloads indexed by hash
+func (prophecy Prophecy) SerializeForDBWithPayloadRefs() (DBProphecy, map[string]string, error) {
+	payloads := make(map[string]string, len(prophecy.ClaimValidators))
+	refs := make(map[string]string, len(prophecy.ValidatorClaims))
+	for addr, claim := range prophecy.ValidatorClaims {
+		hash := ClaimPayloadHash(claim)
+		payloads[hash] = claim
+		refs[addr] = hash
+	}
+	validatorClaims, err := json.Marshal(refs)
+	if err != nil {
+		return DBProphecy{}, nil, err
+	}
+
+	return DBProphecy{
+		ID:              prophecy.ID,
+		Status:          prophecy.Status,
+		ValidatorClaims: validatorClaims,
+		PayloadRefs:     true,
+		Snapshot:        prophecy.Snapshot,
+	}, payloads, nil
+}
+
 // DeserializeFromDB deserializes a DBProphecy into a prophecy
 func (dbProphecy DBProphecy) DeserializeFromDB() (Prophecy, error) {
+	if dbProphecy.PayloadRefs {
+		return Prophecy{}, fmt.Errorf("the claims of prophecy %s are stored apart", dbProphecy.ID)
+	}
+	return dbProphecy.DeserializeFromDBWithPayloads(nil)
+}
+
+// DeserializeFromDBWithPayloads deserializes a DBProphecy into a prophecy, getPayload resolves
+// the hashes of the claims if they are stored apart
+func (dbProphecy DBProphecy) DeserializeFromDBWithPayloads(getPayload func(hash string) (string, bool)) (Prophecy, error) {
 	var validatorClaims map[string]string
 	if err := json.Unmarshal(dbProphecy.ValidatorClaims, &validatorClaims); err != nil {
 		return Prophecy{}, err
 	}
+	if dbProphecy.PayloadRefs {
+		for addr, hash := range validatorClaims {
+			payload, ok := getPayload(hash)
+			if !ok {
+				return Prophecy{}, fmt.Errorf("claim payload %s of prophecy %s not found", hash, dbProphecy.ID)
+			}
+			validatorClaims[addr] = payload
+		}
+	}
 
 	var claimValidators = map[string][]sdk.ValAddress{}
 	for addr, claim := range validatorClaims {
@@ -98,18 +216,15 @@ func (dbProphecy DBProphecy) DeserializeFromDB() (Prophecy, error) {
 		Status:          dbProphecy.Status,
 		ClaimValidators: claimValidators,
 		ValidatorClaims: validatorClaims,
+		Snapshot:        dbProphecy.Snapshot,
 	}, nil
 }
 
 // FindHighestClaim looks through all the existing claims on a given prophecy. It adds up the total power across
 // all claims and returns the highest claim, power for that claim, and total power claimed on the prophecy overall.
 func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKeeper) (string, int64, int64) {
-	validators := stakeKeeper.GetBondedValidatorsByPower(ctx)
 	//Index the validators by address for looking when scanning through claims
-	validatorsByAddress := make(map[string]sdk.Validator)
-	for _, validator := range validators {
-		validatorsByAddress[validator.OperatorAddr.String()] = validator
-	}
+	powerByAddress := prophecy.validatorPowers(ctx, stakeKeeper)
 
 	totalClaimsPower := int64(0)
 	highestClaimPower := int64(-1)
@@ -117,11 +232,11 @@ func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKe
 	for claim, validatorAddrs := range prophecy.ClaimValidators {
 		claimPower := int64(0)
 		for _, validatorAddr := range validatorAddrs {
-			validator, found := validatorsByAddress[validatorAddr.String()]
+			power, found := powerByAddress[validatorAddr.String()]
 			if found {
 				// Note: If claim validator is not found in the current validator set, we assume it is no longer
 				// an active validator and so can silently ignore it's claim and no longer count it towards total power.
-				claimPower += validator.GetPower().RawInt()
+				claimPower += power
 			}
 		}
 		totalClaimsPower += claimPower
@@ -133,6 +248,81 @@ func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKe
 	return highestClaim, highestClaimPower, totalClaimsPower
 }
 
+// WeightedClaims returns the claims of this prophecy made by validators in the current bonded set,
+// weighted by their power and ordered by validator address.
+func (prophecy Prophecy) WeightedClaims(ctx sdk.Context, stakeKeeper StakingKeeper) []WeightedClaim {
+	powerByAddress := prophecy.validatorPowers(ctx, stakeKeeper)
+
+	claims := make([]WeightedClaim, 0, len(prophecy.ValidatorClaims))
+	for claim, validatorAddrs := range prophecy.ClaimValidators {
+		for _, validatorAddr := range validatorAddrs {
+			// validators that left the bonded set no longer count towards consensus
+			power, found := powerByAddress[validatorAddr.String()]
+			if !found {
+				continue
+			}
+			claims = append(claims, WeightedClaim{
+				ValidatorAddress: validatorAddr,
+				Payload:          claim,
+				Power:            power,
+			})
+		}
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return bytes.Compare(claims[i].ValidatorAddress, claims[j].ValidatorAddress) < 0
+	})
+	return claims
+}
+
+// TotalPower returns the total power the claims of this prophecy are weighed against
+func (prophecy Prophecy) TotalPower(ctx sdk.Context, stakeKeeper StakingKeeper) int64 {
+	if prophecy.Snapshot != nil {
+		return prophecy.Snapshot.TotalPower
+	}
+	return stakeKeeper.GetLastTotalPower(ctx)
+}
+
+// ValidatorPower returns the power the claim of a validator is weighed by on this prophecy,
+// 0 if the validator is not eligible to it
+func (prophecy Prophecy) ValidatorPower(ctx sdk.Context, stakeKeeper StakingKeeper, validator sdk.ValAddress) int64 {
+	return prophecy.validatorPowers(ctx, stakeKeeper)[validator.String()]
+}
+
+// validatorPowers indexes the power of the validators eligible to the prophecy by their bech32 address,
+// they are the validators of the snapshot if any, the current bonded validators otherwise
+func (prophecy Prophecy) validatorPowers(ctx sdk.Context, stakeKeeper StakingKeeper) map[string]int64 {
+	powerByAddress := make(map[string]int64)
+	if prophecy.Snapshot != nil {
+		for _, validator := range prophecy.Snapshot.Validators {
+			powerByAddress[validator.Validator.String()] = validator.Power
+		}
+		return powerByAddress
+	}
+	for _, validator := range stakeKeeper.GetBondedValidatorsByPower(ctx) {
+		powerByAddress[validator.OperatorAddr.String()] = validator.GetPower().RawInt()
+	}
+	return powerByAddress
+}
+
+// NewPowerSnapshot takes a snapshot of the current bonded validator set
+func NewPowerSnapshot(ctx sdk.Context, stakeKeeper StakingKeeper) *PowerSnapshot {
+	validators := stakeKeeper.GetBondedValidatorsByPower(ctx)
+	snapshot := &PowerSnapshot{
+		TotalPower: stakeKeeper.GetLastTotalPower(ctx),
+		Validators: make([]ValidatorPower, 0, len(validators)),
+	}
+	for _, validator := range validators {
+		snapshot.Validators = append(snapshot.Validators, ValidatorPower{
+			Validator: validator.OperatorAddr,
+			Power:     validator.GetPower().RawInt(),
+		})
+	}
+	sort.Slice(snapshot.Validators, func(i, j int) bool {
+		return bytes.Compare(snapshot.Validators[i].Validator, snapshot.Validators[j].Validator) < 0
+	})
+	return snapshot
+}
+
 // AddClaim adds a given claim to this prophecy
 func (prophecy *Prophecy) AddClaim(validator sdk.ValAddress, claim string) {
 	validatorBech32 := validator.String()
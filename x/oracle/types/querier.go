@@ -0,0 +1,96 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the oracle Querier
+const (
+	QueryProphecyNonVoters = "prophecyNonVoters"
+	QueryReceiveSequence   = "receiveSequence"
+	QueryProphecy          = "prophecy"
+	QueryClaimTypes        = "claimTypes"
+	QuerySequences         = "sequences"
+	QueryPendingClaims     = "pendingClaims"
+)
+
+// QueryReceiveSequenceParams selects the chain whose claim sequence is queried, the result is the
+// sequence of the next claim accepted from the chain
+type QueryReceiveSequenceParams struct {
+	ChainId sdk.ChainID
+}
+
+type QueryProphecyNonVotersParams struct {
+	ProphecyID string
+}
+
+type QueryProphecyParams struct {
+	ProphecyID string
+}
+
+// QuerySequencesParams selects the chain whose receive sequences are queried
+type QuerySequencesParams struct {
+	ChainId sdk.ChainID
+}
+
+// QueryPendingClaimsParams selects the validator whose claims on the pending prophecies are queried
+type QueryPendingClaimsParams struct {
+	ValidatorAddr sdk.ValAddress
+}
+
+// ValidatorClaim is the payload a validator claimed on a prophecy
+type ValidatorClaim struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Payload   string         `json:"payload"`
+}
+
+// ProphecyInfo is a prophecy with its claims ordered by validator address, ExpireAt is only set
+// for a pending prophecy which expires
+type ProphecyInfo struct {
+	ID       string           `json:"id"`
+	Status   Status           `json:"status"`
+	Claims   []ValidatorClaim `json:"claims"`
+	ExpireAt *time.Time       `json:"expire_at,omitempty"`
+	Snapshot *PowerSnapshot   `json:"snapshot,omitempty"`
+}
+
+// ClaimTypeInfo is a registered claim type, see ClaimType
+type ClaimTypeInfo struct {
+	ClaimType ClaimType `json:"claim_type"`
+	Name      string    `json:"name"`
+}
+
+// ClaimSequence is the sequence of the next claim of a type accepted from a chain
+type ClaimSequence struct {
+	ClaimType ClaimType `json:"claim_type"`
+	Name      string    `json:"name"`
+	Sequence  uint64    `json:"sequence"`
+}
+
+// PendingClaim is the claim of a validator on a pending prophecy, PayloadHash is the ClaimPayloadHash
+// of the payload, so that relayers can tell whether the claim they are about to submit is the same
+type PendingClaim struct {
+	ProphecyID  string `json:"prophecy_id"`
+	PayloadHash string `json:"payload_hash"`
+}
+
+// NonVoter is a bonded validator that has not submitted a claim for a prophecy yet
+type NonVoter struct {
+	OperatorAddr sdk.ValAddress `json:"operator_address"`
+	Moniker      string         `json:"moniker"`
+	Power        int64          `json:"power"`
+}
+
+// ProphecyNonVoters describes how far an open prophecy is from reaching consensus.
+// ClaimedPowerRatio is the share of total power that has claimed anything, HighestClaimPowerRatio
+// the share behind the most supported payload, which is what is compared against ConsensusNeeded.
+type ProphecyNonVoters struct {
+	ProphecyID             string     `json:"prophecy_id"`
+	TotalPower             int64      `json:"total_power"`
+	ClaimedPowerRatio      sdk.Dec    `json:"claimed_power_ratio"`
+	HighestClaimPowerRatio sdk.Dec    `json:"highest_claim_power_ratio"`
+	ConsensusNeeded        sdk.Dec    `json:"consensus_needed"`
+	NonVoters              []NonVoter `json:"non_voters"`
+}
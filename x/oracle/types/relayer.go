@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ClaimType is the channel a claim is made for, see GetClaimId
+type ClaimType = sdk.ChannelID
+
+// RelayerAllowList restricts the validator operators allowed to submit claims of a type.
+// It is also the description of a RelayerAllowList proposal, an empty list of relayers
+// lifts the restriction.
+type RelayerAllowList struct {
+	ClaimType ClaimType        `json:"claim_type"`
+	Relayers  []sdk.ValAddress `json:"relayers"`
+}
+
+func (l RelayerAllowList) Check() error {
+	seen := make(map[string]bool, len(l.Relayers))
+	for _, relayer := range l.Relayers {
+		if len(relayer) != sdk.AddrLen {
+			return fmt.Errorf("invalid relayer address %s", relayer)
+		}
+		if seen[string(relayer)] {
+			return fmt.Errorf("duplicated relayer address %s", relayer)
+		}
+		seen[string(relayer)] = true
+	}
+	return nil
+}
+
+// SkipSequence gives up the claims of a sequence the relayers cannot make, e.g. because the side chain lost the
+// packages of the sequence. It is the description of a SkipSequence proposal, the receive sequence of the claim
+// type only moves past Sequence if it is still at Sequence when the proposal is executed.
+type SkipSequence struct {
+	ChainId   sdk.ChainID `json:"chain_id"`
+	ClaimType ClaimType   `json:"claim_type"`
+	Sequence  uint64      `json:"sequence"`
+}
+
+func (s SkipSequence) Check() error {
+	if s.ChainId == 0 {
+		return fmt.Errorf("invalid chain id %d", s.ChainId)
+	}
+	return nil
+}
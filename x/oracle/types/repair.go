@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The kinds of mismatch between a prophecy and the receive sequence of its claim type
+const (
+	// MismatchStale is a prophecy below the receive sequence, its claim was executed already
+	MismatchStale = "stale"
+	// MismatchStuck is a finalized prophecy at the receive sequence, no claim can be made for it anymore
+	MismatchStuck = "stuck"
+	// MismatchAhead is a prophecy above the receive sequence, claims are only accepted at the receive sequence
+	MismatchAhead = "ahead"
+)
+
+// ParseClaimId is the reverse of GetClaimId
+func ParseClaimId(id string) (sdk.ChainID, ClaimType, uint64, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid claim id %q", id)
+	}
+	chainId, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid chain id in claim id %q", id)
+	}
+	claimType, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid claim type in claim id %q", id)
+	}
+	sequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid sequence in claim id %q", id)
+	}
+	return sdk.ChainID(chainId), ClaimType(claimType), sequence, nil
+}
+
+// SequenceMismatch is a prophecy that does not match the receive sequence of its claim type
+type SequenceMismatch struct {
+	ProphecyID      string      `json:"prophecy_id"`
+	ChainID         sdk.ChainID `json:"chain_id"`
+	ClaimType       ClaimType   `json:"claim_type"`
+	Sequence        uint64      `json:"sequence"`
+	ReceiveSequence uint64      `json:"receive_sequence"`
+	Status          string      `json:"status"`
+	Kind            string      `json:"kind"`
+}
+
+func (m SequenceMismatch) String() string {
+	return fmt.Sprintf("%s prophecy %s (%s), receive sequence is %d", m.Kind, m.ProphecyID, m.Status, m.ReceiveSequence)
+}
+
+// SequenceRepair removes the mismatched prophecies found at Height. It is signed by the
+// operator who inspected the state and kept in the state as an audit record once applied.
+type SequenceRepair struct {
+	Height     int64              `json:"height"`
+	Mismatches []SequenceMismatch `json:"mismatches"`
+	Signer     sdk.AccAddress     `json:"signer"`
+	PubKey     []byte             `json:"pub_key"`
+	Signature  []byte             `json:"signature"`
+}
+
+// SignBytes returns the bytes the operator signs, everything but the signature
+func (r SequenceRepair) SignBytes() []byte {
+	r.Signature = nil
+	bz, err := json.Marshal(r)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// Verify checks the repair is signed by its signer
+func (r SequenceRepair) Verify() error {
+	if len(r.Mismatches) == 0 {
+		return fmt.Errorf("nothing to repair")
+	}
+	pubKey, err := cryptoAmino.PubKeyFromBytes(r.PubKey)
+	if err != nil {
+		return fmt.Errorf("invalid pub key: %v", err)
+	}
+	if !sdk.AccAddress(pubKey.Address()).Equals(r.Signer) {
+		return fmt.Errorf("pub key does not match signer %s", r.Signer)
+	}
+	if !pubKey.VerifyBytes(r.SignBytes(), r.Signature) {
+		return fmt.Errorf("invalid signature of %s", r.Signer)
+	}
+	return nil
+}
+
+// SequenceRepairRecord is the audit record of an applied SequenceRepair
+type SequenceRepairRecord struct {
+	AppliedHeight int64          `json:"applied_height"`
+	Repair        SequenceRepair `json:"repair"`
+	Removed       []string       `json:"removed"` // the prophecies still mismatched when applied
+}
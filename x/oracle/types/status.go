@@ -13,13 +13,16 @@ const (
 	PendingStatusText StatusText = iota
 	SuccessStatusText
 	FailedStatusText
+	// ExecutionFailedStatusText is a prophecy that reached consensus on packages that cannot be executed
+	ExecutionFailedStatusText
 )
 
-var StatusTextToString = [...]string{"pending", "success", "failed"}
+var StatusTextToString = [...]string{"pending", "success", "failed", "execution_failed"}
 var StringToStatusText = map[string]StatusText{
-	"pending": PendingStatusText,
-	"success": SuccessStatusText,
-	"failed":  FailedStatusText,
+	"pending":          PendingStatusText,
+	"success":          SuccessStatusText,
+	"failed":           FailedStatusText,
+	"execution_failed": ExecutionFailedStatusText,
 }
 
 func (text StatusText) String() string {
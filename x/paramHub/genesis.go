@@ -66,6 +66,9 @@ const (
 	CrossBindRelayFee        = 2e6
 	CrossUnbindRelayFee      = 2e6
 
+	// charged for every 1KB of the oracle claims
+	OracleClaimSizeFee = 1e4
+
 	//MiniToken fee
 	TinyIssueFee   = 2e8
 	MiniIssueFee   = 3e8
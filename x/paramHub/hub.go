@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	oTypes "github.com/cosmos/cosmos-sdk/x/oracle/types"
 	param "github.com/cosmos/cosmos-sdk/x/paramHub/types"
 )
 
@@ -76,6 +77,12 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 		}
 		paramHub.UpdateFeeParams(ctx, updateFeeParams)
 	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ClaimSizeFee, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: oTypes.ClaimSizeFeeType, Fee: OracleClaimSizeFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
 }
 
 func EndBreatheBlock(ctx sdk.Context, paramHub *ParamHub) {
@@ -130,7 +137,8 @@ func init() {
 		"crossBindRelayFee":        fees.FixedFeeCalculatorGen,
 		"crossUnbindRelayFee":      fees.FixedFeeCalculatorGen,
 		"crossTransferOutRelayFee": fees.FixedFeeCalculatorGen,
-		"oracleClaim":              fees.FixedFeeCalculatorGen,
+		"oracleClaim":              oTypes.ClaimFeeCalculatorGen,
+		"oracleClaimSize":          fees.FixedFeeCalculatorGen,
 		"miniTokensSetURI":         fees.FixedFeeCalculatorGen,
 		"dexListMini":              fees.FixedFeeCalculatorGen,
 		"tinyIssueMsg":             fees.FixedFeeCalculatorGen,
@@ -9,6 +9,7 @@ import (
 const (
 	ChannelName = "params"
 	ChannelId   = sdk.ChannelID(9)
+	ModuleName  = "paramHub" // the sender of the packages of the module, see ibc.Keeper.ForModule
 )
 
 func (keeper *Keeper) SaveParamChangeToIbc(ctx sdk.Context, sideChainId string, paramChange types.CSCParamChange) (seq uint64, sdkErr sdk.Error) {
@@ -19,5 +20,5 @@ func (keeper *Keeper) SaveParamChangeToIbc(ctx sdk.Context, sideChainId string,
 	if err != nil {
 		return 0, sdk.ErrInternal("failed to encode paramChange")
 	}
-	return keeper.ibcKeeper.CreateIBCSyncPackage(ctx, sideChainId, ChannelName, bz)
+	return keeper.ibcKeeper.CreateIBCSyncPackage(ctx, sideChainId, ChannelName, bz, nil)
 }
@@ -81,7 +81,8 @@ func (keeper *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
 
 func (keeper *Keeper) SetupForSideChain(scKeeper *sidechain.Keeper, ibcKeeper *ibc.Keeper) {
 	keeper.ScKeeper = scKeeper
-	keeper.ibcKeeper = ibcKeeper
+	moduleIbcKeeper := ibcKeeper.ForModule(ModuleName)
+	keeper.ibcKeeper = &moduleIbcKeeper
 	keeper.initIbc()
 }
 
@@ -104,7 +105,7 @@ func (keeper *Keeper) EndBreatheBlock(ctx sdk.Context) {
 	log.Info("Sync breath block params proposals.")
 	feeChange := keeper.getLastFeeChangeParam(ctx)
 	if feeChange != nil {
-		keeper.notifyOnUpdate(ctx, feeChange)
+		keeper.notifyOnUpdate(params.WithProposalID(ctx, keeper.getLastFeeChangeProposalId(ctx).ProposalID), feeChange)
 	}
 	if sdk.IsUpgrade(sdk.LaunchBscUpgrade) {
 		_, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
@@ -112,8 +113,9 @@ func (keeper *Keeper) EndBreatheBlock(ctx sdk.Context) {
 			sideChainCtx := ctx.WithSideChainKeyPrefix(storePrefixes[i])
 			scParamChanges := keeper.getLastSCParamChanges(sideChainCtx)
 			if scParamChanges != nil {
+				proposalCtx := params.WithProposalID(sideChainCtx, keeper.GetLastSCParamChangeProposalId(sideChainCtx).ProposalID)
 				for _, change := range scParamChanges.SCParams {
-					keeper.notifyOnUpdate(sideChainCtx, change)
+					keeper.notifyOnUpdate(proposalCtx, change)
 				}
 			}
 		}
@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 func NewQuerier(hub *ParamHub, cdc *codec.Codec) sdk.Querier {
@@ -37,6 +38,8 @@ func NewQuerier(hub *ParamHub, cdc *codec.Codec) sdk.Querier {
 				return nil, sdk.ErrInternal(err.Error())
 			}
 			return res, nil
+		case params.QueryChangeHistory:
+			return params.NewQuerier(hub.Keeper)(ctx, path, req)
 
 		default:
 			return res, sdk.ErrUnknownRequest(req.Path)
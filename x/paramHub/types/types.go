@@ -46,6 +46,7 @@ var (
 		"crossUnbindRelayFee":      {},
 		"crossTransferOutRelayFee": {},
 		"oracleClaim":              {},
+		"oracleClaimSize":          {},
 
 		"HTLT":        {},
 		"depositHTLT": {},
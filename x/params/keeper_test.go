@@ -13,6 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
 )
 
 func defaultContext(key sdk.StoreKey, tkey sdk.StoreKey) sdk.Context {
@@ -215,3 +216,58 @@ func TestSubspace(t *testing.T) {
 		require.Equal(t, kv.param, indirect(kv.ptr), "stored param not equal, tc #%d", i)
 	}
 }
+
+func TestChangeHistory(t *testing.T) {
+	cdc := codec.New()
+	skey := sdk.NewKVStoreKey("test")
+	tkey := sdk.NewTransientStoreKey("transient_test")
+	ctx := defaultContext(skey, tkey)
+	keeper := NewKeeper(cdc, skey, tkey)
+	space := keeper.Subspace("test").WithTypeTable(NewTypeTable([]byte("key"), int64(0)))
+	other := keeper.Subspace("other").WithTypeTable(NewTypeTable([]byte("key"), int64(0)))
+
+	// nothing is recorded before the upgrade
+	space.Set(ctx, []byte("key"), int64(0))
+	require.Len(t, space.GetChangeHistory(ctx), 0)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ParamChangeHistory, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ParamChangeHistory)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	space.Set(ctx.WithBlockHeight(1), []byte("key"), int64(1))
+	// setting the same value is not a change
+	space.Set(ctx.WithBlockHeight(2), []byte("key"), int64(1))
+	space.Set(WithProposalID(ctx.WithBlockHeight(3), 7), []byte("key"), int64(2))
+
+	changes := space.GetChangeHistory(ctx)
+	require.Equal(t, []ParamChange{
+		{Key: "key", OldValue: `"0"`, NewValue: `"1"`, Height: 1},
+		{Key: "key", OldValue: `"1"`, NewValue: `"2"`, Height: 3, ProposalID: 7},
+	}, changes)
+	require.Len(t, other.GetChangeHistory(ctx), 0)
+
+	// the log is pruned
+	for i := int64(0); i < 2*subspace.MaxChangeHistory; i++ {
+		space.Set(ctx.WithBlockHeight(4+i), []byte("key"), 3+i)
+	}
+	changes = space.GetChangeHistory(ctx)
+	require.Len(t, changes, subspace.MaxChangeHistory)
+	require.Equal(t, int64(4+subspace.MaxChangeHistory), changes[0].Height)
+
+	// query
+	querier := NewQuerier(keeper)
+	bz, err := cdc.MarshalJSON(QueryChangeHistoryParams{Subspace: "test"})
+	require.Nil(t, err)
+	res, sdkErr := querier(ctx, []string{QueryChangeHistory}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var queried []ParamChange
+	require.Nil(t, cdc.UnmarshalJSON(res, &queried))
+	require.Equal(t, changes, queried)
+
+	bz, _ = cdc.MarshalJSON(QueryChangeHistoryParams{Subspace: "missing"})
+	_, sdkErr = querier(ctx, []string{QueryChangeHistory}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+}
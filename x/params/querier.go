@@ -0,0 +1,49 @@
+package params
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryChangeHistory = "changeHistory"
+)
+
+type QueryChangeHistoryParams struct {
+	Subspace string `json:"subspace"`
+}
+
+// creates a querier for params REST endpoints
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryChangeHistory:
+			return queryChangeHistory(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown params query endpoint")
+		}
+	}
+}
+
+func queryChangeHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryChangeHistoryParams
+	errRes := k.cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", errRes.Error()))
+	}
+
+	space, ok := k.GetSubspace(params.Subspace)
+	if !ok {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("subspace %s does not exist", params.Subspace))
+	}
+
+	res, errRes = codec.MarshalJSONIndent(k.cdc, space.GetChangeHistory(ctx))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
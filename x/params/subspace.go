@@ -1,6 +1,7 @@
 package params
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params/subspace"
 )
 
@@ -11,9 +12,14 @@ type (
 	ParamSet         = subspace.ParamSet
 	KeyValuePairs    = subspace.KeyValuePairs
 	TypeTable        = subspace.TypeTable
+	ParamChange      = subspace.ParamChange
 )
 
 // re-export functions from subspace
 func NewTypeTable(keytypes ...interface{}) TypeTable {
 	return subspace.NewTypeTable(keytypes...)
 }
+
+func WithProposalID(ctx sdk.Context, proposalID int64) sdk.Context {
+	return subspace.WithProposalID(ctx, proposalID)
+}
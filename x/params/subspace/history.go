@@ -0,0 +1,102 @@
+package subspace
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxChangeHistory is the number of changes kept per subspace, older changes are pruned
+const MaxChangeHistory = 100
+
+// historyPrefix keeps the change log apart from parameters, whose keys start with the
+// printable subspace name
+var historyPrefix = []byte{0x00}
+
+type contextKey int
+
+const contextKeyProposalID contextKey = iota
+
+// WithProposalID marks the parameter changes made with ctx as made by the proposal
+func WithProposalID(ctx sdk.Context, proposalID int64) sdk.Context {
+	return ctx.WithValue(contextKeyProposalID, proposalID)
+}
+
+func proposalIDFromContext(ctx sdk.Context) int64 {
+	if id, ok := ctx.Value(contextKeyProposalID).(int64); ok {
+		return id
+	}
+	return 0
+}
+
+// ParamChange records a change of a parameter value
+type ParamChange struct {
+	Key        string `json:"key"`
+	OldValue   string `json:"old_value"` // json encoded, empty if the parameter was not set before
+	NewValue   string `json:"new_value"` // json encoded
+	Height     int64  `json:"height"`
+	ProposalID int64  `json:"proposal_id"` // 0 if the change was not made by a proposal
+}
+
+// Returns a KVStore holding the change log of the subspace, keyed by sequence
+func (s Subspace) historyStore(ctx sdk.Context) sdk.KVStore {
+	prefix := make([]byte, 0, len(historyPrefix)+len(s.name)+1)
+	prefix = append(prefix, historyPrefix...)
+	prefix = append(prefix, s.name...)
+	prefix = append(prefix, '/')
+	return ctx.KVStore(s.key).Prefix(prefix)
+}
+
+func historyKey(seq uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, seq)
+	return bz
+}
+
+// recordChange appends a change to the log if the value did change, and prunes the
+// oldest change once the log is longer than MaxChangeHistory
+func (s Subspace) recordChange(ctx sdk.Context, key []byte, oldValue, newValue []byte) {
+	if bytes.Equal(oldValue, newValue) {
+		return
+	}
+	store := s.historyStore(ctx)
+
+	var first, next uint64
+	iter := store.Iterator(nil, nil)
+	if iter.Valid() {
+		first = binary.BigEndian.Uint64(iter.Key())
+	}
+	iter.Close()
+	iter = store.ReverseIterator(nil, nil)
+	if iter.Valid() {
+		next = binary.BigEndian.Uint64(iter.Key()) + 1
+	}
+	iter.Close()
+
+	change := ParamChange{
+		Key:        string(key),
+		OldValue:   string(oldValue),
+		NewValue:   string(newValue),
+		Height:     ctx.BlockHeight(),
+		ProposalID: proposalIDFromContext(ctx),
+	}
+	store.Set(historyKey(next), s.cdc.MustMarshalBinaryLengthPrefixed(change))
+
+	for ; next-first+1 > MaxChangeHistory; first++ {
+		store.Delete(historyKey(first))
+	}
+}
+
+// GetChangeHistory returns the recorded changes of the subspace, oldest first
+func (s Subspace) GetChangeHistory(ctx sdk.Context) []ParamChange {
+	changes := make([]ParamChange, 0)
+	iter := s.historyStore(ctx).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var change ParamChange
+		s.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &change)
+		changes = append(changes, change)
+	}
+	return changes
+}
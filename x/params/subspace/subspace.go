@@ -135,6 +135,9 @@ func (s Subspace) Set(ctx sdk.Context, key []byte, param interface{}) {
 	if err != nil {
 		panic(err)
 	}
+	if sdk.IsUpgrade(sdk.ParamChangeHistory) {
+		s.recordChange(ctx, key, store.Get(key), bz)
+	}
 	store.Set(key, bz)
 
 	tstore := s.transientStore(ctx)
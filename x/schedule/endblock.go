@@ -0,0 +1,22 @@
+package schedule
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EndBlocker executes all the scheduled transfers that are due at the current height and block time
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	logger := ctx.Logger().With("module", "x/schedule")
+
+	for _, id := range keeper.dueScheduleIDs(ctx, ctx.BlockHeight(), ctx.BlockHeader().Time) {
+		st, found := keeper.GetScheduledTransfer(ctx, id)
+		if !found {
+			continue
+		}
+		if err := keeper.executeScheduledTransfer(ctx, st); err != nil {
+			logger.Info(fmt.Sprintf("scheduled transfer %d failed and is removed, error=%v", id, err))
+		}
+	}
+}
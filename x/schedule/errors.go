@@ -0,0 +1,27 @@
+package schedule
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 13
+
+	CodeInvalidSchedule  sdk.CodeType = 101
+	CodeUnknownSchedule  sdk.CodeType = 102
+	CodeNotScheduleOwner sdk.CodeType = 103
+)
+
+func ErrInvalidSchedule(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidSchedule, msg)
+}
+
+func ErrUnknownSchedule(codespace sdk.CodespaceType, id int64) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownSchedule, fmt.Sprintf("unknown scheduled transfer %d", id))
+}
+
+func ErrNotScheduleOwner(codespace sdk.CodespaceType, id int64) sdk.Error {
+	return sdk.NewError(codespace, CodeNotScheduleOwner, fmt.Sprintf("scheduled transfer %d is not owned by the sender", id))
+}
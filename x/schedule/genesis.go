@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all scheduled transfer state that must be provided at genesis
+type GenesisState struct {
+	Params             Params              `json:"params"`
+	NextScheduleID     int64               `json:"next_schedule_id"`
+	ScheduledTransfers []ScheduledTransfer `json:"scheduled_transfers"`
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:         DefaultParams(),
+		NextScheduleID: 1,
+	}
+}
+
+// InitGenesis restores the params and the scheduled transfers. The escrow account is
+// restored with the other accounts and must hold the prepaid fees of the transfers.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+	keeper.setNextScheduleID(ctx, data.NextScheduleID)
+
+	prepaidFee := sdk.Coins{}
+	for _, st := range data.ScheduledTransfers {
+		keeper.setScheduledTransfer(ctx, st)
+		keeper.insertQueue(ctx, st)
+		prepaidFee = prepaidFee.Plus(st.PrepaidFee)
+	}
+	if escrow := keeper.ck.GetCoins(ctx, EscrowAccAddr); !escrow.IsGTE(prepaidFee) {
+		panic(fmt.Sprintf("escrow %s does not cover the prepaid fees %s of the scheduled transfers", escrow, prepaidFee))
+	}
+}
+
+// WriteGenesis - output the params and all scheduled transfers
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var transfers []ScheduledTransfer
+	keeper.IterateScheduledTransfers(ctx, func(st ScheduledTransfer) bool {
+		transfers = append(transfers, st)
+		return false
+	})
+	return GenesisState{
+		Params:             keeper.GetParams(ctx),
+		NextScheduleID:     keeper.peekNextScheduleID(ctx),
+		ScheduledTransfers: transfers,
+	}
+}
+
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.UpdateCheck(); err != nil {
+		return err
+	}
+	if data.NextScheduleID <= 0 {
+		return fmt.Errorf("next schedule id should be positive")
+	}
+	ids := make(map[int64]bool, len(data.ScheduledTransfers))
+	for _, st := range data.ScheduledTransfers {
+		if st.ID <= 0 || st.ID >= data.NextScheduleID {
+			return fmt.Errorf("scheduled transfer id %d is out of range", st.ID)
+		}
+		if ids[st.ID] {
+			return fmt.Errorf("duplicated scheduled transfer %d", st.ID)
+		}
+		ids[st.ID] = true
+		if st.Remaining <= 0 {
+			return fmt.Errorf("scheduled transfer %d has no executions left", st.ID)
+		}
+		if !st.PrepaidFee.IsValid() {
+			return fmt.Errorf("invalid prepaid fee %s of scheduled transfer %d", st.PrepaidFee, st.ID)
+		}
+	}
+	return nil
+}
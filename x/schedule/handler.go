@@ -0,0 +1,38 @@
+package schedule
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(keeper Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgScheduleTransfer:
+			return handleMsgScheduleTransfer(ctx, keeper, msg)
+		case MsgCancelScheduledTransfer:
+			return handleMsgCancelScheduledTransfer(ctx, keeper, msg)
+		default:
+			errMsg := "Unrecognized schedule msg type"
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgScheduleTransfer(ctx sdk.Context, keeper Keeper, msg MsgScheduleTransfer) sdk.Result {
+	id, err := keeper.ScheduleTransfer(ctx, msg)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Data: []byte(fmt.Sprintf("%d", id)),
+	}
+}
+
+func handleMsgCancelScheduledTransfer(ctx sdk.Context, keeper Keeper, msg MsgCancelScheduledTransfer) sdk.Result {
+	if err := keeper.CancelScheduledTransfer(ctx, msg.From, msg.ID); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
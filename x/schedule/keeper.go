@@ -0,0 +1,258 @@
+package schedule
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+var (
+	// Holds the prepaid execution fees of all scheduled transfers.
+	EscrowAccAddr = sdk.ModuleAddress("schedule", "escrow")
+)
+
+// Scheduled transfer Keeper
+type Keeper struct {
+	storeKey  sdk.StoreKey
+	cdc       *codec.Codec
+	codespace sdk.CodespaceType
+
+	paramSpace params.Subspace
+	ck         bank.Keeper
+
+	// shared memory for block level state
+	pool *sdk.Pool
+}
+
+func ParamTypeTable() params.TypeTable {
+	return params.NewTypeTable().RegisterParamSet(&Params{})
+}
+
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace params.Subspace, ck bank.Keeper, codespace sdk.CodespaceType, pool *sdk.Pool) Keeper {
+	return Keeper{
+		storeKey:   storeKey,
+		cdc:        cdc,
+		codespace:  codespace,
+		paramSpace: paramSpace.WithTypeTable(ParamTypeTable()),
+		ck:         ck,
+		pool:       pool,
+	}
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) Params {
+	var p Params
+	k.paramSpace.GetParamSet(ctx, &p)
+	return p
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, p Params) {
+	k.paramSpace.SetParamSet(ctx, &p)
+}
+
+// ScheduleTransfer validates the schedule against the params, escrows the
+// execution fees and puts the transfer into the queue. Nothing is written
+// unless all of them succeed.
+func (k Keeper) ScheduleTransfer(ctx sdk.Context, msg MsgScheduleTransfer) (int64, sdk.Error) {
+	if msg.StartTime.IsZero() && msg.StartHeight <= ctx.BlockHeight() {
+		return 0, ErrInvalidSchedule(k.codespace, fmt.Sprintf("start height %d is not in the future", msg.StartHeight))
+	}
+	if !msg.StartTime.IsZero() && !msg.StartTime.After(ctx.BlockHeader().Time) {
+		return 0, ErrInvalidSchedule(k.codespace, fmt.Sprintf("start time %s is not in the future", msg.StartTime))
+	}
+	params := k.GetParams(ctx)
+	if msg.Repeats > params.MaxRepeats {
+		return 0, ErrInvalidSchedule(k.codespace, fmt.Sprintf("repeats %d exceeds the maximum %d", msg.Repeats, params.MaxRepeats))
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	prepaidFee := params.prepaidFee(msg.Repeats)
+	if prepaidFee.IsPositive() {
+		if _, err := k.ck.SendCoins(cacheCtx, msg.From, EscrowAccAddr, prepaidFee); err != nil {
+			return 0, err
+		}
+	}
+
+	st := ScheduledTransfer{
+		ID:            k.getNewScheduleID(cacheCtx),
+		From:          msg.From,
+		To:            msg.To,
+		Amount:        msg.Amount,
+		ExecuteHeight: msg.StartHeight,
+		Interval:      msg.Interval,
+		Remaining:     msg.Repeats,
+		PrepaidFee:    prepaidFee,
+	}
+	if !msg.StartTime.IsZero() {
+		st.ExecuteTime = msg.StartTime.UTC()
+		st.Period = msg.Period
+	}
+	k.setScheduledTransfer(cacheCtx, st)
+	k.insertQueue(cacheCtx, st)
+
+	write()
+	if prepaidFee.IsPositive() {
+		k.addAddrs(ctx, msg.From, EscrowAccAddr)
+	}
+	return st.ID, nil
+}
+
+// CancelScheduledTransfer removes the schedule and refunds the unused prepaid fee
+func (k Keeper) CancelScheduledTransfer(ctx sdk.Context, owner sdk.AccAddress, id int64) sdk.Error {
+	st, found := k.GetScheduledTransfer(ctx, id)
+	if !found {
+		return ErrUnknownSchedule(k.codespace, id)
+	}
+	if !st.From.Equals(owner) {
+		return ErrNotScheduleOwner(k.codespace, id)
+	}
+	return k.removeScheduledTransfer(ctx, st)
+}
+
+func (k Keeper) removeScheduledTransfer(ctx sdk.Context, st ScheduledTransfer) sdk.Error {
+	if st.PrepaidFee.IsPositive() {
+		if _, err := k.ck.SendCoins(ctx, EscrowAccAddr, st.From, st.PrepaidFee); err != nil {
+			return err
+		}
+		k.addAddrs(ctx, st.From, EscrowAccAddr)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(st.queueKey())
+	store.Delete(buildScheduleKey(st.ID))
+	return nil
+}
+
+func (k Keeper) GetScheduledTransfer(ctx sdk.Context, id int64) (ScheduledTransfer, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildScheduleKey(id))
+	if bz == nil {
+		return ScheduledTransfer{}, false
+	}
+	var st ScheduledTransfer
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &st)
+	return st, true
+}
+
+func (k Keeper) setScheduledTransfer(ctx sdk.Context, st ScheduledTransfer) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(st)
+	ctx.KVStore(k.storeKey).Set(buildScheduleKey(st.ID), bz)
+}
+
+// IterateScheduledTransfers iterates over all scheduled transfers in id order
+func (k Keeper) IterateScheduledTransfers(ctx sdk.Context, fn func(st ScheduledTransfer) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixSchedule)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var st ScheduledTransfer
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &st)
+		if fn(st) {
+			break
+		}
+	}
+}
+
+func (k Keeper) getNewScheduleID(ctx sdk.Context) int64 {
+	id := k.peekNextScheduleID(ctx)
+	k.setNextScheduleID(ctx, id+1)
+	return id
+}
+
+func (k Keeper) peekNextScheduleID(ctx sdk.Context) int64 {
+	if bz := ctx.KVStore(k.storeKey).Get(KeyNextScheduleID); bz != nil {
+		return int64(binary.BigEndian.Uint64(bz))
+	}
+	return 1
+}
+
+func (k Keeper) setNextScheduleID(ctx sdk.Context, id int64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(id))
+	ctx.KVStore(k.storeKey).Set(KeyNextScheduleID, bz)
+}
+
+func (k Keeper) insertQueue(ctx sdk.Context, st ScheduledTransfer) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(st.ID))
+	ctx.KVStore(k.storeKey).Set(st.queueKey(), bz)
+}
+
+// dueScheduleIDs returns the ids of the transfers to be executed at or before height,
+// followed by the ids of the time based transfers to be executed at or before blockTime
+func (k Keeper) dueScheduleIDs(ctx sdk.Context, height int64, blockTime time.Time) []int64 {
+	store := ctx.KVStore(k.storeKey)
+
+	var ids []int64
+	iterator := store.Iterator(PrefixQueue, buildQueueEndKey(height))
+	for ; iterator.Valid(); iterator.Next() {
+		ids = append(ids, int64(binary.BigEndian.Uint64(iterator.Value())))
+	}
+	iterator.Close()
+
+	iterator = store.Iterator(PrefixTimeQueue, buildTimeQueueEndKey(blockTime))
+	for ; iterator.Valid(); iterator.Next() {
+		ids = append(ids, int64(binary.BigEndian.Uint64(iterator.Value())))
+	}
+	iterator.Close()
+	return ids
+}
+
+// executeScheduledTransfer runs one execution of the transfer and reschedules
+// it if executions remain. A failed transfer cancels the schedule.
+func (k Keeper) executeScheduledTransfer(ctx sdk.Context, st ScheduledTransfer) sdk.Error {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(st.queueKey())
+
+	// charge the share of the prepaid fee for this execution, param changes
+	// do not affect transfers that are already scheduled
+	fee := sdk.Coins{}
+	for _, coin := range st.PrepaidFee {
+		fee = append(fee, sdk.NewCoin(coin.Denom, coin.Amount/st.Remaining))
+	}
+	if fee.IsPositive() {
+		if _, _, err := k.ck.SubtractCoins(ctx, EscrowAccAddr, fee); err != nil {
+			return err
+		}
+		st.PrepaidFee = st.PrepaidFee.Minus(fee)
+		k.addAddrs(ctx, EscrowAccAddr)
+		if ctx.IsDeliverTx() {
+			fees.Pool.AddAndCommitFee(fmt.Sprintf("scheduled_transfer:%d:%d", st.ID, st.Remaining), sdk.NewFee(fee, sdk.FeeForProposer))
+		}
+	}
+	st.Remaining--
+
+	cacheCtx, write := ctx.CacheContext()
+	_, err := k.ck.SendCoins(cacheCtx, st.From, st.To, st.Amount)
+	if err != nil {
+		// a failed execution ends the schedule, the fee of the remaining executions is refunded
+		st.Remaining = 0
+	} else {
+		write()
+		k.addAddrs(ctx, st.From, st.To)
+	}
+
+	if st.Remaining <= 0 {
+		if rmErr := k.removeScheduledTransfer(ctx, st); rmErr != nil {
+			return rmErr
+		}
+		return err
+	}
+
+	if st.IsTimeBased() {
+		st.ExecuteTime = st.ExecuteTime.Add(st.Period)
+	} else {
+		st.ExecuteHeight += st.Interval
+	}
+	k.setScheduledTransfer(ctx, st)
+	k.insertQueue(ctx, st)
+	return nil
+}
+
+func (k Keeper) addAddrs(ctx sdk.Context, addrs ...sdk.AccAddress) {
+	if ctx.IsDeliverTx() && k.pool != nil {
+		k.pool.AddAddrs(addrs)
+	}
+}
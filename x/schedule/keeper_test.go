@@ -0,0 +1,192 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+var (
+	addrFrom = sdk.AccAddress([]byte("addrFrom____________"))
+	addrTo   = sdk.AccAddress([]byte("addrTo______________"))
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, bank.Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keySchedule := sdk.NewKVStoreKey("schedule")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySchedule, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	RegisterCodec(cdc)
+
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(accountCache)
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(ak)
+	keeper := NewKeeper(cdc, keySchedule, pk.Subspace(DefaultParamspace), ck, DefaultCodespace, new(sdk.Pool))
+	InitGenesis(ctx, keeper, DefaultGenesisState())
+
+	ck.SetCoins(ctx, addrFrom, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 10e8)})
+	return ctx, keeper, ck
+}
+
+func TestRecurringTransfer(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)}
+
+	id, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTransfer(addrFrom, addrTo, amount, 5, 10, 3))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), id)
+	require.Equal(t, int64(3*DefaultExecutionFee), ck.GetCoins(ctx, EscrowAccAddr).AmountOf(sdk.NativeTokenSymbol))
+
+	EndBlocker(ctx.WithBlockHeight(4), keeper)
+	require.True(t, ck.GetCoins(ctx, addrTo).IsZero())
+
+	EndBlocker(ctx.WithBlockHeight(5), keeper)
+	require.Equal(t, int64(1e8), ck.GetCoins(ctx, addrTo).AmountOf(sdk.NativeTokenSymbol))
+
+	st, found := keeper.GetScheduledTransfer(ctx, id)
+	require.True(t, found)
+	require.Equal(t, int64(15), st.ExecuteHeight)
+	require.Equal(t, int64(2), st.Remaining)
+
+	EndBlocker(ctx.WithBlockHeight(15), keeper)
+	EndBlocker(ctx.WithBlockHeight(25), keeper)
+	require.Equal(t, int64(3e8), ck.GetCoins(ctx, addrTo).AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(7e8-3*DefaultExecutionFee), ck.GetCoins(ctx, addrFrom).AmountOf(sdk.NativeTokenSymbol))
+	require.True(t, ck.GetCoins(ctx, EscrowAccAddr).IsZero())
+
+	_, found = keeper.GetScheduledTransfer(ctx, id)
+	require.False(t, found)
+}
+
+func TestCancelScheduledTransfer(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)}
+
+	_, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTransfer(addrFrom, addrTo, amount, 1, 10, 3))
+	require.NotNil(t, err)
+
+	id, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTransfer(addrFrom, addrTo, amount, 5, 10, 3))
+	require.Nil(t, err)
+
+	EndBlocker(ctx.WithBlockHeight(5), keeper)
+
+	err = keeper.CancelScheduledTransfer(ctx, addrTo, id)
+	require.Equal(t, CodeNotScheduleOwner, err.Code())
+
+	err = keeper.CancelScheduledTransfer(ctx, addrFrom, id)
+	require.Nil(t, err)
+	require.True(t, ck.GetCoins(ctx, EscrowAccAddr).IsZero())
+	require.Equal(t, int64(9e8-DefaultExecutionFee), ck.GetCoins(ctx, addrFrom).AmountOf(sdk.NativeTokenSymbol))
+
+	EndBlocker(ctx.WithBlockHeight(15), keeper)
+	require.Equal(t, int64(1e8), ck.GetCoins(ctx, addrTo).AmountOf(sdk.NativeTokenSymbol))
+}
+
+func TestFailedTransferRemovesSchedule(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 6e8)}
+
+	id, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTransfer(addrFrom, addrTo, amount, 5, 10, 3))
+	require.Nil(t, err)
+
+	EndBlocker(ctx.WithBlockHeight(5), keeper)
+	EndBlocker(ctx.WithBlockHeight(15), keeper)
+
+	_, found := keeper.GetScheduledTransfer(ctx, id)
+	require.False(t, found)
+	require.Equal(t, int64(6e8), ck.GetCoins(ctx, addrTo).AmountOf(sdk.NativeTokenSymbol))
+	require.Equal(t, int64(4e8-2*DefaultExecutionFee), ck.GetCoins(ctx, addrFrom).AmountOf(sdk.NativeTokenSymbol))
+	require.True(t, ck.GetCoins(ctx, EscrowAccAddr).IsZero())
+}
+
+func TestTimedTransfer(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)}
+	now := time.Unix(1e9, 0).UTC()
+	ctx = ctx.WithBlockTime(now)
+
+	_, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTimedTransfer(addrFrom, addrTo, amount, now, time.Hour, 2))
+	require.NotNil(t, err)
+
+	id, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTimedTransfer(addrFrom, addrTo, amount, now.Add(time.Hour), time.Hour, 2))
+	require.Nil(t, err)
+
+	// the height queue does not run time based transfers
+	EndBlocker(ctx.WithBlockHeight(1e6).WithBlockTime(now.Add(time.Minute)), keeper)
+	require.True(t, ck.GetCoins(ctx, addrTo).IsZero())
+
+	EndBlocker(ctx.WithBlockTime(now.Add(time.Hour)), keeper)
+	require.Equal(t, int64(1e8), ck.GetCoins(ctx, addrTo).AmountOf(sdk.NativeTokenSymbol))
+	st, found := keeper.GetScheduledTransfer(ctx, id)
+	require.True(t, found)
+	require.Equal(t, now.Add(2*time.Hour), st.ExecuteTime)
+
+	EndBlocker(ctx.WithBlockTime(now.Add(3*time.Hour)), keeper)
+	require.Equal(t, int64(2e8), ck.GetCoins(ctx, addrTo).AmountOf(sdk.NativeTokenSymbol))
+	_, found = keeper.GetScheduledTransfer(ctx, id)
+	require.False(t, found)
+}
+
+func TestScheduleTransferIsAtomic(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)}
+	ck.SetCoins(ctx, addrFrom, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, DefaultExecutionFee)})
+
+	_, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTransfer(addrFrom, addrTo, amount, 5, 10, 3))
+	require.NotNil(t, err)
+	require.Equal(t, int64(1), keeper.peekNextScheduleID(ctx))
+	require.Empty(t, keeper.dueScheduleIDs(ctx, 5, ctx.BlockHeader().Time))
+}
+
+func TestExportImportGenesis(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t)
+	amount := sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 1e8)}
+	now := time.Unix(1e9, 0).UTC()
+	ctx = ctx.WithBlockTime(now)
+
+	_, err := keeper.ScheduleTransfer(ctx, NewMsgScheduleTransfer(addrFrom, addrTo, amount, 5, 10, 3))
+	require.Nil(t, err)
+	_, err = keeper.ScheduleTransfer(ctx, NewMsgScheduleTimedTransfer(addrFrom, addrTo, amount, now.Add(time.Hour), 0, 1))
+	require.Nil(t, err)
+	genesis := WriteGenesis(ctx, keeper)
+	require.Nil(t, ValidateGenesis(genesis))
+	require.Len(t, genesis.ScheduledTransfers, 2)
+	require.Equal(t, int64(3), genesis.NextScheduleID)
+
+	newCtx, newKeeper, newCk := createTestInput(t)
+	newCtx = newCtx.WithBlockTime(now)
+	require.Panics(t, func() { InitGenesis(newCtx, newKeeper, genesis) })
+
+	newCk.SetCoins(newCtx, EscrowAccAddr, ck.GetCoins(ctx, EscrowAccAddr))
+	InitGenesis(newCtx, newKeeper, genesis)
+	require.Equal(t, genesis, WriteGenesis(newCtx, newKeeper))
+
+	EndBlocker(newCtx.WithBlockHeight(5).WithBlockTime(now.Add(time.Hour)), newKeeper)
+	require.Equal(t, int64(2e8), newCk.GetCoins(newCtx, addrTo).AmountOf(sdk.NativeTokenSymbol))
+}
@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"encoding/binary"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	KeyNextScheduleID = []byte{0x00}
+	PrefixSchedule    = []byte{0x01} // id -> ScheduledTransfer
+	PrefixQueue       = []byte{0x02} // execute height | id -> id
+	PrefixTimeQueue   = []byte{0x03} // execute time | id -> id
+)
+
+func buildScheduleKey(id int64) []byte {
+	key := make([]byte, len(PrefixSchedule)+8)
+	copy(key, PrefixSchedule)
+	binary.BigEndian.PutUint64(key[len(PrefixSchedule):], uint64(id))
+	return key
+}
+
+// queue keys sort by execute height first, so transfers of the same height run in id order
+func buildQueueKey(height int64, id int64) []byte {
+	key := make([]byte, len(PrefixQueue)+16)
+	copy(key, PrefixQueue)
+	binary.BigEndian.PutUint64(key[len(PrefixQueue):], uint64(height))
+	binary.BigEndian.PutUint64(key[len(PrefixQueue)+8:], uint64(id))
+	return key
+}
+
+// end key (exclusive) for iterating queue entries due at or before height
+func buildQueueEndKey(height int64) []byte {
+	key := make([]byte, len(PrefixQueue)+8)
+	copy(key, PrefixQueue)
+	binary.BigEndian.PutUint64(key[len(PrefixQueue):], uint64(height+1))
+	return key
+}
+
+// time queue keys sort by execute time first, so transfers of the same time run in id order
+func buildTimeQueueKey(t time.Time, id int64) []byte {
+	key := append(buildTimeQueuePrefix(t), make([]byte, 8)...)
+	binary.BigEndian.PutUint64(key[len(key)-8:], uint64(id))
+	return key
+}
+
+// end key (exclusive) for iterating time queue entries due at or before t
+func buildTimeQueueEndKey(t time.Time) []byte {
+	return sdk.PrefixEndBytes(buildTimeQueuePrefix(t))
+}
+
+func buildTimeQueuePrefix(t time.Time) []byte {
+	timeBz := sdk.FormatTimeBytes(t)
+	key := make([]byte, len(PrefixTimeQueue)+len(timeBz))
+	copy(key, PrefixTimeQueue)
+	copy(key[len(PrefixTimeQueue):], timeBz)
+	return key
+}
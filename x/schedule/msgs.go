@@ -0,0 +1,142 @@
+package schedule
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const MsgRoute = "schedule"
+
+var _, _ sdk.Msg = MsgScheduleTransfer{}, MsgCancelScheduledTransfer{}
+
+// MsgScheduleTransfer schedules a transfer from From to To at StartHeight,
+// repeated Repeats times every Interval blocks. If StartTime is set instead of
+// StartHeight, the transfer is executed at StartTime and repeated every Period.
+type MsgScheduleTransfer struct {
+	From        sdk.AccAddress `json:"from"`
+	To          sdk.AccAddress `json:"to"`
+	Amount      sdk.Coins      `json:"amount"`
+	StartHeight int64          `json:"start_height"`
+	Interval    int64          `json:"interval"`
+	StartTime   time.Time      `json:"start_time"`
+	Period      time.Duration  `json:"period"`
+	Repeats     int64          `json:"repeats"`
+}
+
+func NewMsgScheduleTransfer(from, to sdk.AccAddress, amount sdk.Coins, startHeight, interval, repeats int64) MsgScheduleTransfer {
+	return MsgScheduleTransfer{
+		From:        from,
+		To:          to,
+		Amount:      amount,
+		StartHeight: startHeight,
+		Interval:    interval,
+		Repeats:     repeats,
+	}
+}
+
+func NewMsgScheduleTimedTransfer(from, to sdk.AccAddress, amount sdk.Coins, startTime time.Time, period time.Duration, repeats int64) MsgScheduleTransfer {
+	return MsgScheduleTransfer{
+		From:      from,
+		To:        to,
+		Amount:    amount,
+		StartTime: startTime,
+		Period:    period,
+		Repeats:   repeats,
+	}
+}
+
+func (msg MsgScheduleTransfer) Route() string { return MsgRoute }
+func (msg MsgScheduleTransfer) Type() string  { return "schedule_transfer" }
+
+func (msg MsgScheduleTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgScheduleTransfer) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgScheduleTransfer) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, EscrowAccAddr}
+}
+
+func (msg MsgScheduleTransfer) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if len(msg.To) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.To.String())
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	if msg.Repeats <= 0 {
+		return ErrInvalidSchedule(DefaultCodespace, "repeats should be positive")
+	}
+	if msg.StartTime.IsZero() {
+		if msg.StartHeight <= 0 {
+			return ErrInvalidSchedule(DefaultCodespace, "start height should be positive")
+		}
+		if msg.Period != 0 {
+			return ErrInvalidSchedule(DefaultCodespace, "period is only for time based transfers")
+		}
+		if msg.Interval < 0 || (msg.Repeats > 1 && msg.Interval == 0) {
+			return ErrInvalidSchedule(DefaultCodespace, "interval should be positive for recurring transfers")
+		}
+		return nil
+	}
+	if msg.StartHeight != 0 || msg.Interval != 0 {
+		return ErrInvalidSchedule(DefaultCodespace, "start height and interval should not be set for time based transfers")
+	}
+	if msg.Period < 0 || (msg.Repeats > 1 && msg.Period == 0) {
+		return ErrInvalidSchedule(DefaultCodespace, "period should be positive for recurring transfers")
+	}
+	return nil
+}
+
+// MsgCancelScheduledTransfer cancels a scheduled transfer and refunds its unused prepaid fee
+type MsgCancelScheduledTransfer struct {
+	From sdk.AccAddress `json:"from"`
+	ID   int64          `json:"id"`
+}
+
+func NewMsgCancelScheduledTransfer(from sdk.AccAddress, id int64) MsgCancelScheduledTransfer {
+	return MsgCancelScheduledTransfer{
+		From: from,
+		ID:   id,
+	}
+}
+
+func (msg MsgCancelScheduledTransfer) Route() string { return MsgRoute }
+func (msg MsgCancelScheduledTransfer) Type() string  { return "cancel_scheduled_transfer" }
+
+func (msg MsgCancelScheduledTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgCancelScheduledTransfer) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgCancelScheduledTransfer) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, EscrowAccAddr}
+}
+
+func (msg MsgCancelScheduledTransfer) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if msg.ID <= 0 {
+		return ErrUnknownSchedule(DefaultCodespace, msg.ID)
+	}
+	return nil
+}
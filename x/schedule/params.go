@@ -0,0 +1,57 @@
+package schedule
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+const (
+	DefaultExecutionFee int64 = 1e5 // decimal is 8
+	DefaultMaxRepeats   int64 = 1000
+	// Default parameter namespace
+	DefaultParamspace = "schedule"
+)
+
+var (
+	ParamExecutionFee = []byte("executionFee")
+	ParamMaxRepeats   = []byte("maxRepeats")
+)
+
+type Params struct {
+	ExecutionFee int64 `json:"execution_fee"` // fee in native token charged for every execution, prepaid at scheduling
+	MaxRepeats   int64 `json:"max_repeats"`   // maximum number of executions of a single schedule
+}
+
+func DefaultParams() Params {
+	return Params{
+		ExecutionFee: DefaultExecutionFee,
+		MaxRepeats:   DefaultMaxRepeats,
+	}
+}
+
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{ParamExecutionFee, &p.ExecutionFee},
+		{ParamMaxRepeats, &p.MaxRepeats},
+	}
+}
+
+func (p *Params) UpdateCheck() error {
+	if p.ExecutionFee < 0 {
+		return fmt.Errorf("the execution_fee should not be negative")
+	}
+	if p.MaxRepeats <= 0 {
+		return fmt.Errorf("the max_repeats should be greater than 0")
+	}
+	return nil
+}
+
+// fee to prepay for the given number of executions
+func (p Params) prepaidFee(repeats int64) sdk.Coins {
+	if p.ExecutionFee == 0 {
+		return sdk.Coins{}
+	}
+	return sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, p.ExecutionFee*repeats)}
+}
@@ -0,0 +1,63 @@
+package schedule
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryScheduledTransfer  = "transfer"
+	QueryScheduledTransfers = "transfers"
+)
+
+// Params for query 'custom/schedule/transfer'
+type QueryScheduledTransferParams struct {
+	ID int64
+}
+
+// Params for query 'custom/schedule/transfers'
+type QueryScheduledTransfersParams struct {
+	From sdk.AccAddress
+}
+
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryScheduledTransfer:
+			var params QueryScheduledTransferParams
+			if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err.Error()))
+			}
+			st, found := k.GetScheduledTransfer(ctx, params.ID)
+			if !found {
+				return nil, ErrUnknownSchedule(k.codespace, params.ID)
+			}
+			return marshalResult(k.cdc, st)
+		case QueryScheduledTransfers:
+			var params QueryScheduledTransfersParams
+			if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err.Error()))
+			}
+			transfers := make([]ScheduledTransfer, 0)
+			k.IterateScheduledTransfers(ctx, func(st ScheduledTransfer) bool {
+				if params.From.Empty() || st.From.Equals(params.From) {
+					transfers = append(transfers, st)
+				}
+				return false
+			})
+			return marshalResult(k.cdc, transfers)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown schedule query endpoint")
+		}
+	}
+}
+
+func marshalResult(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ScheduledTransfer is a transfer that will be executed at ExecuteHeight and,
+// if Interval is positive, every Interval blocks after that until no executions remain.
+// A time based transfer has no ExecuteHeight, it is executed at ExecuteTime and every Period after that.
+type ScheduledTransfer struct {
+	ID            int64          `json:"id"`
+	From          sdk.AccAddress `json:"from"`
+	To            sdk.AccAddress `json:"to"`
+	Amount        sdk.Coins      `json:"amount"`
+	ExecuteHeight int64          `json:"execute_height"` // height of the next execution
+	Interval      int64          `json:"interval"`       // blocks between executions, 0 for a one-off transfer
+	ExecuteTime   time.Time      `json:"execute_time"`   // time of the next execution of a time based transfer
+	Period        time.Duration  `json:"period"`         // time between executions of a time based transfer
+	Remaining     int64          `json:"remaining"`      // number of executions left
+	PrepaidFee    sdk.Coins      `json:"prepaid_fee"`    // execution fees escrowed for the remaining executions
+}
+
+func (st ScheduledTransfer) IsTimeBased() bool {
+	return st.ExecuteHeight == 0
+}
+
+func (st ScheduledTransfer) queueKey() []byte {
+	if st.IsTimeBased() {
+		return buildTimeQueueKey(st.ExecuteTime, st.ID)
+	}
+	return buildQueueKey(st.ExecuteHeight, st.ID)
+}
+
+func (st ScheduledTransfer) String() string {
+	return fmt.Sprintf(`ScheduledTransfer %d:
+  From:          %s
+  To:            %s
+  Amount:        %s
+  ExecuteHeight: %d
+  Interval:      %d
+  ExecuteTime:   %s
+  Period:        %s
+  Remaining:     %d
+  PrepaidFee:    %s`, st.ID, st.From, st.To, st.Amount, st.ExecuteHeight, st.Interval, st.ExecuteTime, st.Period, st.Remaining, st.PrepaidFee)
+}
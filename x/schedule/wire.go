@@ -0,0 +1,16 @@
+package schedule
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgScheduleTransfer{}, "cosmos-sdk/MsgScheduleTransfer", nil)
+	cdc.RegisterConcrete(MsgCancelScheduledTransfer{}, "cosmos-sdk/MsgCancelScheduledTransfer", nil)
+}
+
+var msgCdc = codec.New()
+
+func init() {
+	RegisterCodec(msgCdc)
+}
@@ -45,12 +45,12 @@ func (k *Keeper) getLastChanPermissionChanges(ctx sdk.Context) []types.ChanPermi
 					"proposalId", proposal.GetProposalID(), "err", err)
 				return false
 			}
-			if _, ok := k.cfg.destChainNameToID[setting.SideChainId]; !ok {
+			if _, err := k.LookupDestChainID(ctx, setting.SideChainId); err != nil {
 				ctx.Logger().With("module", "side_chain").Error("The SideChainId do not exist, will skip.",
 					"proposalId", proposal.GetProposalID(), "setting", setting)
 				return false
 			}
-			if _, ok := k.cfg.channelIDToName[setting.ChannelId]; !ok {
+			if _, err := k.LookupChannelName(ctx, setting.ChannelId); err != nil {
 				ctx.Logger().With("module", "side_chain").Error("The ChannelId do not exist, will skip.",
 					"proposalId", proposal.GetProposalID(), "setting", setting)
 				return false
@@ -6,6 +6,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
 )
 
 func TestInitCrossChainID(t *testing.T) {
@@ -61,3 +63,78 @@ func TestCrossChainID(t *testing.T) {
 	_, err = sdk.ParseChainID("65537")
 	require.Error(t, err)
 }
+
+func TestRegistryByGovernance(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	require.NoError(t, keeper.RegisterDestChain("bsc", sdk.ChainID(1)))
+	require.NoError(t, keeper.RegisterChannel("transfer", sdk.ChannelID(2), nil))
+
+	// the names and ids fixed by the binary can not be registered again
+	require.Error(t, keeper.AddDestChain(ctx, types.DestChainRegistration{Name: "bsc", ChainId: sdk.ChainID(3)}))
+	require.Error(t, keeper.AddDestChain(ctx, types.DestChainRegistration{Name: "other", ChainId: sdk.ChainID(1)}))
+	require.Error(t, keeper.AddDestChain(ctx, types.DestChainRegistration{Name: "a::b", ChainId: sdk.ChainID(3)}))
+	require.NoError(t, keeper.AddDestChain(ctx, types.DestChainRegistration{Name: "other", ChainId: sdk.ChainID(3)}))
+	require.Error(t, keeper.AddDestChain(ctx, types.DestChainRegistration{Name: "other", ChainId: sdk.ChainID(4)}))
+	require.Equal(t, sdk.ChannelAllow, keeper.GetChannelSendPermission(ctx, sdk.ChainID(3), types.GovChannelId))
+
+	chainID, err := keeper.LookupDestChainID(ctx, "other")
+	require.NoError(t, err)
+	require.Equal(t, sdk.ChainID(3), chainID)
+	name, err := keeper.LookupDestChainName(ctx, sdk.ChainID(1))
+	require.NoError(t, err)
+	require.Equal(t, "bsc", name)
+	_, err = keeper.GetDestChainID("other")
+	require.Error(t, err)
+
+	paused := types.ChannelRegistration{Name: "nft", ChannelId: sdk.ChannelID(10), SideChainId: "other",
+		Scope: types.ChannelScopeInternal, Paused: true}
+	require.NoError(t, keeper.AddChannel(ctx, paused))
+	require.Error(t, keeper.AddChannel(ctx, paused))
+	require.Error(t, keeper.AddChannel(ctx, types.ChannelRegistration{Name: "transfer", ChannelId: sdk.ChannelID(11),
+		SideChainId: "other", Scope: types.ChannelScopeExternal}))
+	require.Error(t, keeper.AddChannel(ctx, types.ChannelRegistration{Name: "token", ChannelId: sdk.ChannelID(11),
+		SideChainId: "unknown", Scope: types.ChannelScopeExternal}))
+	require.Error(t, keeper.AddChannel(ctx, types.ChannelRegistration{Name: "token", ChannelId: sdk.ChannelID(11),
+		SideChainId: "bsc", Scope: "public"}))
+	active := types.ChannelRegistration{Name: "token", ChannelId: sdk.ChannelID(11), SideChainId: "bsc",
+		Scope: types.ChannelScopeExternal}
+	require.NoError(t, keeper.AddChannel(ctx, active))
+
+	require.Equal(t, sdk.ChannelForbidden, keeper.GetChannelSendPermission(ctx, sdk.ChainID(3), sdk.ChannelID(10)))
+	require.Equal(t, sdk.ChannelAllow, keeper.GetChannelSendPermission(ctx, sdk.ChainID(1), sdk.ChannelID(11)))
+	channelID, err := keeper.LookupChannelID(ctx, "nft")
+	require.NoError(t, err)
+	require.Equal(t, sdk.ChannelID(10), channelID)
+	name, err = keeper.LookupChannelName(ctx, sdk.ChannelID(2))
+	require.NoError(t, err)
+	require.Equal(t, "transfer", name)
+
+	registration, found := keeper.GetRegisteredChannel(ctx, sdk.ChannelID(10))
+	require.True(t, found)
+	require.Equal(t, paused, registration)
+	require.Equal(t, []types.ChannelRegistration{paused, active}, keeper.GetRegisteredChannels(ctx))
+	require.Equal(t, []types.DestChainRegistration{{Name: "other", ChainId: sdk.ChainID(3)}}, keeper.GetRegisteredDestChains(ctx))
+}
+
+func TestCrossChainRegistryHooks(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	require.NoError(t, keeper.RegisterDestChain("bsc", sdk.ChainID(1)))
+	hooks := NewCrossChainRegistryHooks(keeper.cdc, &keeper)
+
+	proposal := &gov.TextProposal{ProposalType: gov.ProposalTypeRegisterDestChain,
+		Description: string(keeper.cdc.MustMarshalJSON(types.DestChainRegistration{Name: "other", ChainId: sdk.ChainID(2)}))}
+	require.NoError(t, hooks.OnProposalSubmitted(ctx, proposal))
+	proposal.Description = string(keeper.cdc.MustMarshalJSON(types.DestChainRegistration{Name: "bsc", ChainId: sdk.ChainID(2)}))
+	require.Error(t, hooks.OnProposalSubmitted(ctx, proposal))
+
+	// the destination chain of a channel may be registered by an earlier proposal
+	proposal = &gov.TextProposal{ProposalType: gov.ProposalTypeRegisterChannel,
+		Description: string(keeper.cdc.MustMarshalJSON(types.ChannelRegistration{Name: "nft", ChannelId: sdk.ChannelID(10),
+			SideChainId: "other", Scope: types.ChannelScopeExternal}))}
+	require.NoError(t, hooks.OnProposalSubmitted(ctx, proposal))
+	proposal.Description = string(keeper.cdc.MustMarshalJSON(types.ChannelRegistration{Name: "nft", ChannelId: types.GovChannelId,
+		SideChainId: "other", Scope: types.ChannelScopeExternal}))
+	require.Error(t, hooks.OnProposalSubmitted(ctx, proposal))
+	proposal.Description = "{}"
+	require.Error(t, hooks.OnProposalSubmitted(ctx, proposal))
+}
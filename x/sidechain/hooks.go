@@ -35,11 +35,60 @@ func (hooks ChanPermissionSettingHooks) OnProposalSubmitted(ctx sdk.Context, pro
 	if err := changeParam.Check(); err != nil {
 		return err
 	}
-	if _, ok := hooks.k.cfg.destChainNameToID[changeParam.SideChainId]; !ok {
+	if _, err := hooks.k.LookupDestChainID(ctx, changeParam.SideChainId); err != nil {
 		return fmt.Errorf("the SideChainId do not exist")
 	}
-	if _, ok := hooks.k.cfg.channelIDToName[changeParam.ChannelId]; !ok {
+	if _, err := hooks.k.LookupChannelName(ctx, changeParam.ChannelId); err != nil {
 		return fmt.Errorf("the ChannelId do not exist")
 	}
 	return nil
 }
+
+//---------------------    CrossChainRegistryHooks  -----------------
+type CrossChainRegistryHooks struct {
+	cdc *amino.Codec
+	k   *Keeper
+}
+
+func NewCrossChainRegistryHooks(cdc *amino.Codec, keeper *Keeper) CrossChainRegistryHooks {
+	return CrossChainRegistryHooks{cdc, keeper}
+}
+
+var _ gov.GovHooks = CrossChainRegistryHooks{}
+
+func (hooks CrossChainRegistryHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	switch proposal.GetProposalType() {
+	case gov.ProposalTypeRegisterDestChain:
+		var registration types.DestChainRegistration
+		if err := hooks.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &registration); err != nil {
+			return fmt.Errorf("unmarshal DestChainRegistration failed: %v", err)
+		}
+		if err := registration.Check(); err != nil {
+			return err
+		}
+		if _, err := hooks.k.LookupDestChainID(ctx, registration.Name); err == nil {
+			return fmt.Errorf("the destination chain name is registered already")
+		}
+		if _, err := hooks.k.LookupDestChainName(ctx, registration.ChainId); err == nil {
+			return fmt.Errorf("the destination chain id is registered already")
+		}
+	case gov.ProposalTypeRegisterChannel:
+		var registration types.ChannelRegistration
+		if err := hooks.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &registration); err != nil {
+			return fmt.Errorf("unmarshal ChannelRegistration failed: %v", err)
+		}
+		if err := registration.Check(); err != nil {
+			return err
+		}
+		// the destination chain may be registered by a proposal passing before this one
+		if _, err := hooks.k.LookupChannelID(ctx, registration.Name); err == nil {
+			return fmt.Errorf("the channel name is registered already")
+		}
+		if _, err := hooks.k.LookupChannelName(ctx, registration.ChannelId); err == nil {
+			return fmt.Errorf("the channel id is registered already")
+		}
+	default:
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package sidechain
 import (
 	"encoding/binary"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -43,6 +44,11 @@ func (k *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
 	k.govKeeper = govKeeper
 }
 
+// ModuleName is the sender of the packages of the side chain module, see ibc.Keeper.ForModule
+const ModuleName = "sidechain"
+
+// SetIbcKeeper sets the keeper creating the packages of the side chain module, it should be
+// ibc.Keeper.ForModule(ModuleName) so that the packages pass the sender check of the channels
 func (k *Keeper) SetIbcKeeper(ibcKeeper IbcKeeper) {
 	k.ibcKeeper = ibcKeeper
 }
@@ -156,6 +162,24 @@ func (k *Keeper) GetChannelID(channelName string) (sdk.ChannelID, error) {
 	return id, nil
 }
 
+// GetChannelIDs returns the ids of the registered channels in ascending order
+func (k *Keeper) GetChannelIDs() []sdk.ChannelID {
+	ids := make([]sdk.ChannelID, 0, len(k.cfg.channelIDToName))
+	for id := range k.cfg.channelIDToName {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (k *Keeper) GetChannelName(channelID sdk.ChannelID) (string, error) {
+	name, ok := k.cfg.channelIDToName[channelID]
+	if !ok {
+		return "", fmt.Errorf("non-existing channel")
+	}
+	return name, nil
+}
+
 func (k *Keeper) SetSrcChainID(srcChainID sdk.ChainID) {
 	k.cfg.srcChainID = srcChainID
 }
@@ -231,7 +255,7 @@ func EndBlock(ctx sdk.Context, k Keeper) {
 		for j := len(chanPermissions) - 1; j >= 0; j-- {
 			change := chanPermissions[j]
 			// must exist
-			id, _ := k.cfg.destChainNameToID[change.SideChainId]
+			id, _ := k.LookupDestChainID(ctx, change.SideChainId)
 			k.SetChannelSendPermission(ctx, id, change.ChannelId, change.Permission)
 			_, err := k.SaveChannelSettingChangeToIbc(ctx, id, change.ChannelId, change.Permission)
 			if err != nil {
@@ -240,5 +264,8 @@ func EndBlock(ctx sdk.Context, k Keeper) {
 			}
 		}
 	}
+	if sdk.IsUpgrade(sdk.CrossChainRegistry) && k.govKeeper != nil {
+		k.executeRegistryProposals(ctx)
+	}
 	return
 }
@@ -20,6 +20,11 @@ var (
 	PrefixForReceiveSequenceKey = []byte{0xf1}
 
 	PrefixForChannelPermissionKey = []byte{0xc0}
+
+	PrefixForDestChainByNameKey = []byte{0xd0} // registered destination chain id, by name
+	PrefixForDestChainByIdKey   = []byte{0xd1} // registered destination chain name, by chain id
+	PrefixForChannelByNameKey   = []byte{0xd2} // registered channel id, by name
+	PrefixForChannelByIdKey     = []byte{0xd3} // registered channel, by channel id
 )
 
 func GetSideChainStorePrefixKey(sideChainId string) []byte {
@@ -51,3 +56,23 @@ func buildChannelPermissionsPrefixKey(destChainID sdk.ChainID) []byte {
 	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
 	return key
 }
+
+func buildDestChainByNameKey(name string) []byte {
+	return append(PrefixForDestChainByNameKey, []byte(name)...)
+}
+
+func buildDestChainByIdKey(destChainID sdk.ChainID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength)
+
+	copy(key[:prefixLength], PrefixForDestChainByIdKey)
+	binary.BigEndian.PutUint16(key[prefixLength:], uint16(destChainID))
+	return key
+}
+
+func buildChannelByNameKey(name string) []byte {
+	return append(PrefixForChannelByNameKey, []byte(name)...)
+}
+
+func buildChannelByIdKey(channelID sdk.ChannelID) []byte {
+	return append(PrefixForChannelByIdKey, byte(channelID))
+}
@@ -0,0 +1,45 @@
+package sidechain
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+// CollectPassedProposals marks the proposals of proposalTypes passed since the last block as executed
+// and returns them from the oldest to the newest, for the caller to apply.
+func CollectPassedProposals(ctx sdk.Context, govKeeper *gov.Keeper, proposalTypes ...gov.ProposalKind) []gov.Proposal {
+	passed := make([]gov.Proposal, 0)
+	// It can still find the passed proposal if the block chain stop for SafeToleratePeriod time
+	backPeriod := SafeToleratePeriod + gov.MaxVotingPeriod
+	govKeeper.Iterate(ctx, nil, nil, gov.StatusNil, 0, true, func(proposal gov.Proposal) bool {
+		if !isProposalType(proposal.GetProposalType(), proposalTypes) {
+			return false
+		}
+		if ctx.BlockHeader().Time.Sub(proposal.GetVotingStartTime()) > backPeriod {
+			return true
+		}
+		if proposal.GetStatus() != gov.StatusPassed {
+			return false
+		}
+
+		proposal.SetStatus(gov.StatusExecuted)
+		govKeeper.SetProposal(ctx, proposal)
+		passed = append(passed, proposal)
+		return false
+	})
+
+	// the proposals are iterated in reverse order
+	for i, j := 0, len(passed)-1; i < j; i, j = i+1, j-1 {
+		passed[i], passed[j] = passed[j], passed[i]
+	}
+	return passed
+}
+
+func isProposalType(proposalType gov.ProposalKind, proposalTypes []gov.ProposalKind) bool {
+	for _, t := range proposalTypes {
+		if t == proposalType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package sidechain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// RegisterDestChain and RegisterChannel register the destination chains and channels known when the app
+// starts, AddDestChain and AddChannel register more at runtime by governance and keep them in the store.
+// The Lookup functions look up both.
+
+// AddDestChain registers a destination chain in the store, the gov channel to it is allowed to send
+func (k *Keeper) AddDestChain(ctx sdk.Context, registration types.DestChainRegistration) error {
+	if err := registration.Check(); err != nil {
+		return err
+	}
+	if strings.Contains(registration.Name, separator) {
+		return fmt.Errorf("destination chain name should not contains %s", separator)
+	}
+	if _, err := k.LookupDestChainID(ctx, registration.Name); err == nil {
+		return fmt.Errorf("duplicated destination chain name")
+	}
+	if _, err := k.LookupDestChainName(ctx, registration.ChainId); err == nil {
+		return fmt.Errorf("duplicated destination chain chainID")
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	idBytes := make([]byte, destChainIDLength)
+	binary.BigEndian.PutUint16(idBytes, uint16(registration.ChainId))
+	store.Set(buildDestChainByNameKey(registration.Name), idBytes)
+	store.Set(buildDestChainByIdKey(registration.ChainId), []byte(registration.Name))
+	k.SetChannelSendPermission(ctx, registration.ChainId, types.GovChannelId, sdk.ChannelAllow)
+	return nil
+}
+
+// AddChannel registers a channel in the store, it starts with the send permission given by the registration
+// on its destination chain
+func (k *Keeper) AddChannel(ctx sdk.Context, registration types.ChannelRegistration) error {
+	if err := registration.Check(); err != nil {
+		return err
+	}
+	destChainID, err := k.LookupDestChainID(ctx, registration.SideChainId)
+	if err != nil {
+		return err
+	}
+	if _, err := k.LookupChannelID(ctx, registration.Name); err == nil {
+		return fmt.Errorf("duplicated channel name")
+	}
+	if _, err := k.LookupChannelName(ctx, registration.ChannelId); err == nil {
+		return fmt.Errorf("duplicated channel id")
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(buildChannelByNameKey(registration.Name), []byte{byte(registration.ChannelId)})
+	store.Set(buildChannelByIdKey(registration.ChannelId), k.cdc.MustMarshalBinaryBare(registration))
+	k.SetChannelSendPermission(ctx, destChainID, registration.ChannelId, registration.Permission())
+	return nil
+}
+
+// GetRegisteredChannel returns the registration of a channel registered by governance
+func (k *Keeper) GetRegisteredChannel(ctx sdk.Context, channelID sdk.ChannelID) (types.ChannelRegistration, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildChannelByIdKey(channelID))
+	if bz == nil {
+		return types.ChannelRegistration{}, false
+	}
+	var registration types.ChannelRegistration
+	k.cdc.MustUnmarshalBinaryBare(bz, &registration)
+	return registration, true
+}
+
+// GetRegisteredChannels returns the channels registered by governance in ascending order of their ids
+func (k *Keeper) GetRegisteredChannels(ctx sdk.Context) []types.ChannelRegistration {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixForChannelByIdKey)
+	defer iterator.Close()
+	registrations := make([]types.ChannelRegistration, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var registration types.ChannelRegistration
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &registration)
+		registrations = append(registrations, registration)
+	}
+	return registrations
+}
+
+// GetRegisteredDestChains returns the destination chains registered by governance in ascending order of their ids
+func (k *Keeper) GetRegisteredDestChains(ctx sdk.Context) []types.DestChainRegistration {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixForDestChainByIdKey)
+	defer iterator.Close()
+	registrations := make([]types.DestChainRegistration, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		chainID := binary.BigEndian.Uint16(iterator.Key()[prefixLength:])
+		registrations = append(registrations, types.DestChainRegistration{
+			Name:    string(iterator.Value()),
+			ChainId: sdk.ChainID(chainID),
+		})
+	}
+	return registrations
+}
+
+func (k *Keeper) LookupDestChainID(ctx sdk.Context, name string) (sdk.ChainID, error) {
+	if destChainID, err := k.GetDestChainID(name); err == nil {
+		return destChainID, nil
+	}
+	bz := ctx.KVStore(k.storeKey).Get(buildDestChainByNameKey(name))
+	if bz == nil {
+		return sdk.ChainID(0), fmt.Errorf("non-existing destination chainName ")
+	}
+	return sdk.ChainID(binary.BigEndian.Uint16(bz)), nil
+}
+
+func (k *Keeper) LookupDestChainName(ctx sdk.Context, id sdk.ChainID) (string, error) {
+	if destChainName, err := k.GetDestChainName(id); err == nil {
+		return destChainName, nil
+	}
+	bz := ctx.KVStore(k.storeKey).Get(buildDestChainByIdKey(id))
+	if bz == nil {
+		return "", fmt.Errorf("non-existing destination chainID")
+	}
+	return string(bz), nil
+}
+
+func (k *Keeper) LookupChannelID(ctx sdk.Context, channelName string) (sdk.ChannelID, error) {
+	if id, err := k.GetChannelID(channelName); err == nil {
+		return id, nil
+	}
+	bz := ctx.KVStore(k.storeKey).Get(buildChannelByNameKey(channelName))
+	if bz == nil {
+		return sdk.ChannelID(0), fmt.Errorf("non-existing channel")
+	}
+	return sdk.ChannelID(bz[0]), nil
+}
+
+func (k *Keeper) LookupChannelName(ctx sdk.Context, channelID sdk.ChannelID) (string, error) {
+	if name, err := k.GetChannelName(channelID); err == nil {
+		return name, nil
+	}
+	registration, found := k.GetRegisteredChannel(ctx, channelID)
+	if !found {
+		return "", fmt.Errorf("non-existing channel")
+	}
+	return registration.Name, nil
+}
+
+// executeRegistryProposals applies the RegisterDestChain and RegisterChannel proposals passed since the last block.
+func (k *Keeper) executeRegistryProposals(ctx sdk.Context) {
+	// in the order they passed, a channel can be registered to a destination chain registered in the same block
+	passed := CollectPassedProposals(ctx, k.govKeeper, gov.ProposalTypeRegisterDestChain, gov.ProposalTypeRegisterChannel)
+	for _, proposal := range passed {
+		if proposal.GetProposalType() == gov.ProposalTypeRegisterDestChain {
+			k.executeRegisterDestChain(ctx, proposal)
+		} else {
+			k.executeRegisterChannel(ctx, proposal)
+		}
+	}
+}
+
+func (k *Keeper) executeRegisterDestChain(ctx sdk.Context, proposal gov.Proposal) {
+	logger := ctx.Logger().With("module", "side_chain")
+	var registration types.DestChainRegistration
+	if err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &registration); err != nil {
+		logger.Error("Get broken data when unmarshal DestChainRegistration msg, will skip.",
+			"proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	if err := k.AddDestChain(ctx, registration); err != nil {
+		logger.Error("The RegisterDestChain proposal is invalid, will skip.",
+			"proposalId", proposal.GetProposalID(), "registration", registration, "err", err)
+		return
+	}
+	logger.Info("Registered destination chain", "proposalId", proposal.GetProposalID(),
+		"name", registration.Name, "chainId", registration.ChainId)
+}
+
+func (k *Keeper) executeRegisterChannel(ctx sdk.Context, proposal gov.Proposal) {
+	logger := ctx.Logger().With("module", "side_chain")
+	var registration types.ChannelRegistration
+	if err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &registration); err != nil {
+		logger.Error("Get broken data when unmarshal ChannelRegistration msg, will skip.",
+			"proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	if err := k.AddChannel(ctx, registration); err != nil {
+		logger.Error("The RegisterChannel proposal is invalid, will skip.",
+			"proposalId", proposal.GetProposalID(), "registration", registration, "err", err)
+		return
+	}
+	logger.Info("Registered channel", "proposalId", proposal.GetProposalID(), "name", registration.Name,
+		"channelId", registration.ChannelId, "sideChainId", registration.SideChainId)
+
+	// must exist
+	destChainID, _ := k.LookupDestChainID(ctx, registration.SideChainId)
+	if _, err := k.SaveChannelSettingChangeToIbc(ctx, destChainID, registration.ChannelId, registration.Permission()); err != nil {
+		logger.Error("failed to write cross chain channel permission change message ", "err", err)
+	}
+}
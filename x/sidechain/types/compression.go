@@ -0,0 +1,139 @@
+package types
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/golang/snappy"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CompressionType is the algorithm the load of a package is compressed with. It is flagged in the
+// high bits of the package type byte of the header, the packages of a chain which does not
+// compress have none of these bits set.
+type CompressionType uint8
+
+const (
+	CompressionNone CompressionType = iota
+	CompressionGzip
+	CompressionSnappy
+)
+
+const (
+	compressionShift = 6
+	packageTypeMask  = 1<<compressionShift - 1
+
+	// MaxDecompressedLoadLength bounds the decompressed load of a package
+	MaxDecompressedLoadLength = 1 << 20
+)
+
+var compressionNames = map[CompressionType]string{
+	CompressionNone:   "none",
+	CompressionGzip:   "gzip",
+	CompressionSnappy: "snappy",
+}
+
+func (c CompressionType) IsValid() bool {
+	_, ok := compressionNames[c]
+	return ok
+}
+
+func (c CompressionType) String() string {
+	if name, ok := compressionNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(c))
+}
+
+// EncodePackageHeaderWithCompression encodes a package header flagging the compression of the load
+func EncodePackageHeaderWithCompression(packageType sdk.CrossChainPackageType, relayerFee big.Int, compression CompressionType) []byte {
+	packageHeader := EncodePackageHeader(packageType, relayerFee)
+	packageHeader[0] |= uint8(compression) << compressionShift
+	return packageHeader
+}
+
+// DecodePackageEnvelope decodes a package header along with the compression flagged in it
+func DecodePackageEnvelope(packageHeader []byte) (packageType sdk.CrossChainPackageType, relayFee big.Int, compression CompressionType, err error) {
+	packageType, relayFee, err = DecodePackageHeader(packageHeader)
+	if err != nil {
+		return
+	}
+	compression = CompressionType(uint8(packageType) >> compressionShift)
+	packageType = sdk.CrossChainPackageType(uint8(packageType) & packageTypeMask)
+	if !compression.IsValid() {
+		err = fmt.Errorf("unknown compression type %d", compression)
+	}
+	return
+}
+
+// DecodePackage decodes a package into its header fields and its decompressed load
+func DecodePackage(payload []byte) (packageType sdk.CrossChainPackageType, relayFee big.Int, load []byte, err error) {
+	var compression CompressionType
+	packageType, relayFee, compression, err = DecodePackageEnvelope(payload)
+	if err != nil {
+		return
+	}
+	load, err = DecompressPackageLoad(compression, payload[PackageHeaderLength:])
+	return
+}
+
+// IsStable returns whether the output of the compression is fixed by the version of the codec pinned in
+// go.mod, so the packages compressed with it and saved in the ibc store are the same on every node.
+// The output of compress/gzip depends on the Go version the node is built with, the gzip loads received
+// are decompressed but the loads sent are never compressed with it.
+func (c CompressionType) IsStable() bool {
+	return c == CompressionNone || c == CompressionSnappy
+}
+
+// CompressPackageLoad compresses the load of a package with a stable compression
+func CompressPackageLoad(compression CompressionType, load []byte) ([]byte, error) {
+	if compression.IsValid() && !compression.IsStable() {
+		return nil, fmt.Errorf("compression %s is not stable across builds", compression)
+	}
+	switch compression {
+	case CompressionNone:
+		return load, nil
+	case CompressionSnappy:
+		return snappy.Encode(nil, load), nil
+	default:
+		return nil, fmt.Errorf("unknown compression type %d", compression)
+	}
+}
+
+// DecompressPackageLoad decompresses the load of a package, the decompressed load may not exceed
+// MaxDecompressedLoadLength
+func DecompressPackageLoad(compression CompressionType, load []byte) ([]byte, error) {
+	switch compression {
+	case CompressionNone:
+		return load, nil
+	case CompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(load))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		decompressed, err := io.ReadAll(io.LimitReader(r, MaxDecompressedLoadLength+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(decompressed) > MaxDecompressedLoadLength {
+			return nil, fmt.Errorf("decompressed load exceeds %d bytes", MaxDecompressedLoadLength)
+		}
+		return decompressed, nil
+	case CompressionSnappy:
+		length, err := snappy.DecodedLen(load)
+		if err != nil {
+			return nil, err
+		}
+		if length > MaxDecompressedLoadLength {
+			return nil, fmt.Errorf("decompressed load exceeds %d bytes", MaxDecompressedLoadLength)
+		}
+		return snappy.Decode(nil, load)
+	default:
+		return nil, fmt.Errorf("unknown compression type %d", compression)
+	}
+}
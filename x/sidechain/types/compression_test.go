@@ -0,0 +1,86 @@
+package types
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// gzipLoad compresses a load like a side chain may, the loads sent are never compressed with gzip
+func gzipLoad(t *testing.T, load []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(load)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func compressLoad(t *testing.T, compression CompressionType, load []byte) []byte {
+	if compression == CompressionGzip {
+		return gzipLoad(t, load)
+	}
+	compressed, err := CompressPackageLoad(compression, load)
+	require.NoError(t, err)
+	return compressed
+}
+
+func TestPackageCompression(t *testing.T) {
+	load := bytes.Repeat([]byte("package"), 100)
+	fee := big.NewInt(1000)
+
+	for _, compression := range []CompressionType{CompressionNone, CompressionGzip, CompressionSnappy} {
+		compressed := compressLoad(t, compression, load)
+		if compression != CompressionNone {
+			require.True(t, len(compressed) < len(load), compression.String())
+		}
+
+		payload := append(EncodePackageHeaderWithCompression(sdk.AckCrossChainPackageType, *fee, compression), compressed...)
+		packageType, relayFee, flagged, err := DecodePackageEnvelope(payload)
+		require.NoError(t, err)
+		require.Equal(t, sdk.AckCrossChainPackageType, packageType)
+		require.Equal(t, fee.Int64(), relayFee.Int64())
+		require.Equal(t, compression, flagged)
+
+		packageType, _, decoded, err := DecodePackage(payload)
+		require.NoError(t, err)
+		require.Equal(t, sdk.AckCrossChainPackageType, packageType)
+		require.Equal(t, load, decoded)
+	}
+
+	// the header of an uncompressed package is unchanged
+	require.Equal(t, EncodePackageHeader(sdk.SynCrossChainPackageType, *fee),
+		EncodePackageHeaderWithCompression(sdk.SynCrossChainPackageType, *fee, CompressionNone))
+
+	header := EncodePackageHeaderWithCompression(sdk.SynCrossChainPackageType, *fee, CompressionType(3))
+	_, _, _, err := DecodePackageEnvelope(header)
+	require.Error(t, err)
+
+	_, err = CompressPackageLoad(CompressionGzip, load)
+	require.Error(t, err)
+	require.False(t, CompressionGzip.IsStable())
+
+	_, err = DecompressPackageLoad(CompressionGzip, []byte("not gzip"))
+	require.Error(t, err)
+	_, err = DecompressPackageLoad(CompressionSnappy, []byte{0xff, 0xff, 0xff, 0xff, 0x0f})
+	require.Error(t, err)
+}
+
+func TestDecompressedLoadLimit(t *testing.T) {
+	load := make([]byte, MaxDecompressedLoadLength+1)
+	for _, compression := range []CompressionType{CompressionGzip, CompressionSnappy} {
+		compressed := compressLoad(t, compression, load)
+		_, err := DecompressPackageLoad(compression, compressed)
+		require.Error(t, err, compression.String())
+
+		compressed = compressLoad(t, compression, load[:MaxDecompressedLoadLength])
+		decompressed, err := DecompressPackageLoad(compression, compressed)
+		require.NoError(t, err)
+		require.Len(t, decompressed, MaxDecompressedLoadLength)
+	}
+}
@@ -68,3 +68,63 @@ func (c *ChanPermissionSetting) Check() error {
 	}
 	return nil
 }
+
+// ChannelScope tells which modules may write syn packages to a registered channel
+type ChannelScope string
+
+const (
+	// ChannelScopeInternal channels can only be written by the modules in the sender allow list of the channel
+	ChannelScopeInternal ChannelScope = "internal"
+	// ChannelScopeExternal channels can be written by any module
+	ChannelScopeExternal ChannelScope = "external"
+)
+
+// DestChainRegistration registers a destination chain at runtime, the name is the id of the chain internally
+type DestChainRegistration struct {
+	Name    string      `json:"name"`
+	ChainId sdk.ChainID `json:"chain_id"`
+}
+
+func (r *DestChainRegistration) Check() error {
+	if len(r.Name) == 0 || len(r.Name) > MaxSideChainIdLength {
+		return fmt.Errorf("invalid destination chain name")
+	}
+	if r.ChainId == 0 {
+		return fmt.Errorf("destination chain id should not be 0")
+	}
+	return nil
+}
+
+// ChannelRegistration registers a channel to a destination chain at runtime, packages received on the
+// channel are only handled if a cross chain application is registered for it by the binary
+type ChannelRegistration struct {
+	Name        string        `json:"name"`
+	ChannelId   sdk.ChannelID `json:"channel_id"`
+	SideChainId string        `json:"side_chain_id"`
+	Scope       ChannelScope  `json:"scope"`
+	Paused      bool          `json:"paused"`
+}
+
+func (r *ChannelRegistration) Check() error {
+	if len(r.Name) == 0 || len(r.Name) > MaxSideChainIdLength {
+		return fmt.Errorf("invalid channel name")
+	}
+	if r.ChannelId == GovChannelId {
+		return fmt.Errorf("gov channel id is forbidden to register")
+	}
+	if len(r.SideChainId) == 0 || len(r.SideChainId) > MaxSideChainIdLength {
+		return fmt.Errorf("invalid side chain id")
+	}
+	if r.Scope != ChannelScopeInternal && r.Scope != ChannelScopeExternal {
+		return fmt.Errorf("scope %q is invalid", r.Scope)
+	}
+	return nil
+}
+
+// Permission is the send permission the channel starts with on the destination chain
+func (r *ChannelRegistration) Permission() sdk.ChannelPermission {
+	if r.Paused {
+		return sdk.ChannelForbidden
+	}
+	return sdk.ChannelAllow
+}
@@ -6,4 +6,6 @@ const (
 	FlagInfractionType   = "infraction-type"
 	FlagInfractionHeight = "infraction-height"
 	FlagSideChainId      = "side-chain-id"
+	FlagBefore           = "before"
+	FlagLimit            = "limit"
 )
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -57,3 +58,41 @@ func GetCmdQuerySigningInfo(storeName string, cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQuerySigningPerformance implements the command to query the uptime, missed blocks and jail
+// history of a validator over the current signed blocks window.
+func GetCmdQuerySigningPerformance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signing-performance [validator-pubkey]",
+		Short: "Query a validator's uptime, missed block heights and jail history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pk, err := sdk.GetConsPubKeyBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := slashing.QuerySigningPerformanceParams{
+				ConsAddr: pk.Address(),
+				Before:   viper.GetInt64(FlagBefore),
+				Limit:    viper.GetInt(FlagLimit),
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/slashing/%s", slashing.QuerySigningPerformance), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().Int64(FlagBefore, 0, "list the missed heights below this height, the next cursor of the previous page")
+	cmd.Flags().Int(FlagLimit, slashing.DefaultMissedHeightsPageLimit, "max number of missed heights listed")
+	return cmd
+}
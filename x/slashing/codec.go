@@ -10,6 +10,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSideChainUnjail{}, "cosmos-sdk/MsgSideChainUnjail", nil)
 	cdc.RegisterConcrete(MsgBscSubmitEvidence{}, "cosmos-sdk/MsgBscSubmitEvidence", nil)
 	cdc.RegisterConcrete(&Params{}, "params/SlashParamSet", nil)
+	cdc.RegisterConcrete(&paramBeforeSlashInfractionTypesUpgrade{}, "params/SlashParamSetBeforeInfractionTypes", nil)
 }
 
 // generic sealed codec to be used throughout sdk
@@ -20,6 +20,7 @@ const (
 	CodeMissingSelfDelegation        CodeType = 104
 	CodeSelfDelegationTooLowToUnjail CodeType = 105
 	CodeInvalidClaim                 CodeType = 106
+	CodeValidatorTombstoned          CodeType = 107
 
 	CodeExpiredEvidence        CodeType = 201
 	CodeFailSlash              CodeType = 202
@@ -41,6 +42,10 @@ func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorJailed, "validator still jailed, cannot yet be unjailed")
 }
 
+func ErrValidatorTombstoned(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorTombstoned, "validator was tombstoned for double signing, cannot be unjailed")
+}
+
 func ErrValidatorNotJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorNotJailed, "validator not jailed, cannot be unjailed")
 }
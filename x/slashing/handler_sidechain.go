@@ -95,6 +95,9 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 		panic(fmt.Sprintf("Expected signing info for validator %s but not found", sideConsAddr.Hex()))
 	}
 	signInfo.JailedUntil = jailUntil
+	if sdk.IsUpgrade(sdk.ValidatorTombstone) {
+		signInfo.Tombstoned = true
+	}
 	k.setValidatorSigningInfo(sideCtx, sideConsAddr.Bytes(), signInfo)
 
 	if ctx.IsDeliverTx() && k.PbsbServer != nil {
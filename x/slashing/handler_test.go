@@ -6,6 +6,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestCannotUnjailUnlessJailed(t *testing.T) {
@@ -88,3 +89,43 @@ func TestJailedValidatorDelegations(t *testing.T) {
 	got = NewSlashingHandler(slashingKeeper)(ctx, NewMsgUnjail(valAddr))
 	require.True(t, got.IsOK(), "expected jailed validator to be able to unjail, got: %v", got)
 }
+
+func TestCannotUnjailTombstonedValidator(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ValidatorTombstone, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ValidatorTombstone)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	slashParams := DefaultParams()
+	ctx, _, sk, _, keeper := createTestInput(t, slashParams)
+	slh := NewSlashingHandler(keeper)
+	// validator added pre-genesis
+	ctx = ctx.WithBlockHeight(-1)
+	amtInt := sdk.NewDecWithoutFra(20000).RawInt()
+	addr, val := addrs[0], pks[0]
+	got := stake.NewStakeHandler(sk)(ctx, NewTestMsgCreateValidator(addr, val, amtInt))
+	require.True(t, got.IsOK())
+	validatorUpdates, _ := stake.EndBlocker(ctx, sk)
+	keeper.AddValidators(ctx, validatorUpdates)
+
+	// handle a signature to set signing info, then double sign
+	keeper.handleValidatorSignature(ctx, val.Address(), amtInt, true)
+	keeper.handleDoubleSign(ctx, val.Address(), 0, time.Unix(0, 0), amtInt)
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+	info, found := keeper.getValidatorSigningInfo(ctx, val.Address())
+	require.True(t, found)
+	require.True(t, info.Tombstoned)
+
+	// still cannot unjail after the jail period
+	ctx = ctx.WithBlockTime(ctx.BlockHeader().Time.Add(slashParams.DoubleSignUnbondDuration))
+	got = slh(ctx, NewMsgUnjail(addr))
+	require.False(t, got.IsOK())
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorTombstoned), got.Code)
+
+	// governance lifts the tombstone
+	require.Nil(t, keeper.Untombstone(ctx, addr))
+	require.NotNil(t, keeper.Untombstone(ctx, addr))
+	got = slh(ctx, NewMsgUnjail(addr))
+	require.True(t, got.IsOK(), "expected untombstoned validator to be able to unjail, got: %v", got)
+}
@@ -0,0 +1,72 @@
+package slashing
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Infraction is a kind of misbehavior a validator is slashed for, each with its own slash fraction param
+type Infraction byte
+
+const (
+	InfractionDowntime Infraction = iota + 1
+	InfractionDoubleSign
+	InfractionOracleMisbehavior
+	InfractionBridgeMisbehavior
+)
+
+func (i Infraction) String() string {
+	switch i {
+	case InfractionDowntime:
+		return "Downtime"
+	case InfractionDoubleSign:
+		return "DoubleSign"
+	case InfractionOracleMisbehavior:
+		return "OracleMisbehavior"
+	case InfractionBridgeMisbehavior:
+		return "BridgeMisbehavior"
+	default:
+		return fmt.Sprintf("Infraction(%d)", byte(i))
+	}
+}
+
+// SlashFraction returns the fraction of the stake slashed for the infraction
+func (k Keeper) SlashFraction(ctx sdk.Context, infraction Infraction) sdk.Dec {
+	switch infraction {
+	case InfractionDowntime:
+		return k.SlashFractionDowntime(ctx)
+	case InfractionDoubleSign:
+		return k.SlashFractionDoubleSign(ctx)
+	case InfractionOracleMisbehavior:
+		return k.SlashFractionOracle(ctx)
+	case InfractionBridgeMisbehavior:
+		return k.SlashFractionBridge(ctx)
+	default:
+		panic(fmt.Sprintf("unknown infraction %s", infraction))
+	}
+}
+
+// Slash slashes the validator by the fraction of its infraction. distributionHeight is the height of
+// the stake distribution the infraction was committed with, power the power of the validator at that
+// height. A double sign is capped by the worst infraction within its slashing period.
+func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, distributionHeight, power int64, infraction Infraction) {
+	fraction := k.SlashFraction(ctx, infraction)
+	if infraction == InfractionDoubleSign {
+		revisedFraction := k.capBySlashingPeriod(ctx, consAddr, fraction, distributionHeight)
+		ctx.Logger().With("module", "x/slashing").Info(fmt.Sprintf("Fraction slashed capped by slashing period from %v to %v", fraction, revisedFraction))
+		fraction = revisedFraction
+	}
+	// the fraction is passed in separately to separately slash unbonding and rebonding delegations
+	k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, fraction)
+}
+
+// SlashOracleNonVoter slashes a bonded validator which did not claim on an expired oracle prophecy
+func (k Keeper) SlashOracleNonVoter(ctx sdk.Context, validator sdk.Validator) {
+	k.Slash(ctx, validator.GetConsAddr(), ctx.BlockHeight(), validator.GetPower().RawInt(), InfractionOracleMisbehavior)
+}
+
+// SlashOracleContradictingClaim slashes a validator which claimed a payload contradicting a successful oracle prophecy
+func (k Keeper) SlashOracleContradictingClaim(ctx sdk.Context, validator sdk.Validator) {
+	k.Slash(ctx, validator.GetConsAddr(), ctx.BlockHeight(), validator.GetPower().RawInt(), InfractionOracleMisbehavior)
+}
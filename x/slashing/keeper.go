@@ -15,6 +15,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
 	param "github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
@@ -36,6 +37,8 @@ type Keeper struct {
 	ScKeeper   *sidechain.Keeper
 
 	PbsbServer *pubsub.Server
+
+	govKeeper *gov.Keeper
 }
 
 // NewKeeper creates a slashing keeper
@@ -63,6 +66,10 @@ func (k *Keeper) initIbc() {
 	}
 }
 
+func (k *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
+	k.govKeeper = govKeeper
+}
+
 func (k *Keeper) SetPbsbServer(server *pubsub.Server) {
 	k.PbsbServer = server
 }
@@ -96,19 +103,11 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 	// That's fine since this is just used to filter unbonding delegations & redelegations.
 	distributionHeight := infractionHeight - stake.ValidatorUpdateDelay
 
-	// Cap the amount slashed to the penalty for the worst infraction
-	// within the slashing period when this infraction was committed
-	fraction := k.SlashFractionDoubleSign(ctx)
-	revisedFraction := k.capBySlashingPeriod(ctx, consAddr, fraction, distributionHeight)
-	logger.Info(fmt.Sprintf("Fraction slashed capped by slashing period from %v to %v", fraction, revisedFraction))
-
 	// Slash validator
 	// `power` is the int64 power of the validator as provided to/by
 	// Tendermint. This value is validator.Tokens as sent to Tendermint via
 	// ABCI, and now received as evidence.
-	// The revisedFraction (which is the new fraction to be slashed) is passed
-	// in separately to separately slash unbonding and rebonding delegations.
-	k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, revisedFraction)
+	k.Slash(ctx, consAddr, distributionHeight, power, InfractionDoubleSign)
 
 	// Jail validator if not already jailed
 	validator := k.validatorSet.ValidatorByConsAddr(ctx, consAddr)
@@ -122,6 +121,9 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 		panic(fmt.Sprintf("Expected signing info for validator %s but not found", consAddr))
 	}
 	signInfo.JailedUntil = time.Add(k.DoubleSignUnbondDuration(ctx))
+	if sdk.IsUpgrade(sdk.ValidatorTombstone) {
+		signInfo.Tombstoned = true
+	}
 	k.setValidatorSigningInfo(ctx, consAddr, signInfo)
 }
 
@@ -179,7 +181,7 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 			// i.e. at the end of the pre-genesis block (none) = at the beginning of the genesis block.
 			// That's fine since this is just used to filter unbonding delegations & redelegations.
 			distributionHeight := height - stake.ValidatorUpdateDelay - 1
-			k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, k.SlashFractionDowntime(ctx))
+			k.Slash(ctx, consAddr, distributionHeight, power, InfractionDowntime)
 			k.validatorSet.Jail(ctx, consAddr)
 			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.DowntimeUnbondDuration(ctx))
 			// We need to reset the counter & array so that the validator won't be immediately slashed for downtime upon rebonding.
@@ -227,6 +229,9 @@ func (k *Keeper) SubscribeParamChange(hub types.ParamChangePublisher) {
 			}
 		},
 		&types.ParamSpaceProto{ParamSpace: k.paramspace, Proto: func() types.SCParam {
+			if !sdk.IsUpgrade(sdk.SlashInfractionTypes) {
+				return new(paramBeforeSlashInfractionTypesUpgrade)
+			}
 			return new(Params)
 		}},
 		nil,
@@ -64,6 +64,59 @@ func TestHandleDoubleSign(t *testing.T) {
 	)
 }
 
+// Test that each infraction is slashed by its own fraction
+func TestSlashInfractions(t *testing.T) {
+	params := keeperTestParams()
+	params.SlashFractionOracle = sdk.NewDecWithPrec(1, 1)
+	params.SlashFractionBridge = sdk.NewDecWithPrec(2, 1)
+	ctx, _, sk, _, keeper := createTestInput(t, params)
+	ctx = ctx.WithBlockHeight(-1)
+	amtInt := sdk.NewDecWithoutFra(100).RawInt()
+	operatorAddr, val := addrs[0], pks[0]
+	got := stake.NewStakeHandler(sk)(ctx, NewTestMsgCreateValidator(operatorAddr, val, amtInt))
+	require.True(t, got.IsOK())
+	validatorUpdates, _ := stake.EndBlocker(ctx, sk)
+	keeper.AddValidators(ctx, validatorUpdates)
+	consAddr := sdk.ConsAddress(val.Address())
+
+	require.Equal(t, params.SlashFractionDowntime, keeper.SlashFraction(ctx, InfractionDowntime))
+	require.Equal(t, params.SlashFractionDoubleSign, keeper.SlashFraction(ctx, InfractionDoubleSign))
+
+	// 10% of 100
+	keeper.Slash(ctx, consAddr, -1, amtInt, InfractionOracleMisbehavior)
+	require.Equal(t, sdk.NewDecWithoutFra(90), sk.Validator(ctx, operatorAddr).GetPower())
+	// 20% of 90
+	keeper.Slash(ctx, consAddr, -1, sdk.NewDecWithoutFra(90).RawInt(), InfractionBridgeMisbehavior)
+	require.Equal(t, sdk.NewDecWithoutFra(72), sk.Validator(ctx, operatorAddr).GetPower())
+
+	params.SlashFractionBridge = sdk.NewDecWithoutFra(2)
+	require.Error(t, params.UpdateCheck())
+}
+
+// Test that the infraction params are only set from SlashInfractionTypes on
+func TestSlashInfractionParamsUpgrade(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SlashInfractionTypes, 10)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.SlashInfractionTypes)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	_, sideCtx, _, _, _, keeper := createSideTestInput(t, DefaultParams())
+
+	require.True(t, keeper.SlashFractionOracle(sideCtx).IsZero())
+	require.True(t, keeper.SlashFractionBridge(sideCtx).IsZero())
+	require.NotPanics(t, func() {
+		keeper.paramspace.GetParamSet(sideCtx, new(paramBeforeSlashInfractionTypesUpgrade))
+	})
+
+	sdk.UpgradeMgr.SetHeight(10)
+	keeper.initInfractionParams(sideCtx)
+	require.Equal(t, DefaultParams().SlashFractionOracle, keeper.SlashFractionOracle(sideCtx))
+	require.Equal(t, DefaultParams().SlashFractionBridge, keeper.SlashFractionBridge(sideCtx))
+	require.NotPanics(t, func() {
+		keeper.paramspace.GetParamSet(sideCtx, new(Params))
+	})
+}
+
 // Test that the amount a validator is slashed for multiple double signs
 // is correctly capped by the slashing period in which they were committed
 func TestSlashingPeriodCap(t *testing.T) {
@@ -132,6 +185,12 @@ func TestSlashingPeriodCap(t *testing.T) {
 // Test a validator through uptime, downtime, revocation,
 // unrevocation, starting height reset, and revocation again
 func TestHandleAbsentValidator(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ValidatorTombstone, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ValidatorTombstone)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
 
 	// initial setup
 	ctx, ck, sk, _, keeper := createTestInput(t, keeperTestParams())
@@ -232,9 +291,14 @@ func TestHandleAbsentValidator(t *testing.T) {
 	got = slh(ctx, NewMsgUnjail(addr))
 	require.False(t, got.IsOK())
 
-	// unrevocation should succeed after jail expiration
+	// unrevocation should fail after jail expiration, the double sign tombstoned the validator
 	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(1, 0).Add(keeper.DowntimeUnbondDuration(ctx))})
 	got = slh(ctx, NewMsgUnjail(addr))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeValidatorTombstoned), got.Code)
+
+	// unrevocation should succeed once governance lifts the tombstone
+	require.Nil(t, keeper.Untombstone(ctx, addr))
+	got = slh(ctx, NewMsgUnjail(addr))
 	require.True(t, got.IsOK())
 
 	// end block
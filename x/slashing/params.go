@@ -27,6 +27,8 @@ var (
 	KeyDowntimeSlashAmount      = []byte("DowntimeSlashAmount")
 	KeySubmitterReward          = []byte("SubmitterReward")
 	KeyDowntimeSlashFee         = []byte("DowntimeSlashFee")
+	KeySlashFractionOracle      = []byte("SlashFractionOracle")
+	KeySlashFractionBridge      = []byte("SlashFractionBridge")
 )
 
 // ParamTypeTable for slashing module
@@ -48,6 +50,8 @@ type Params struct {
 	DowntimeSlashAmount      int64         `json:"downtime_slash_amount"`
 	SubmitterReward          int64         `json:"submitter_reward"`
 	DowntimeSlashFee         int64         `json:"downtime_slash_fee"`
+	SlashFractionOracle      sdk.Dec       `json:"slash_fraction_oracle"`
+	SlashFractionBridge      sdk.Dec       `json:"slash_fraction_bridge"`
 }
 
 func (p *Params) GetParamAttribute() (string, bool) {
@@ -80,6 +84,12 @@ func (p *Params) UpdateCheck() error {
 	if p.DowntimeSlashFee < 1e8 || p.DowntimeSlashFee > 1000e8 {
 		return fmt.Errorf("the downtime_slash_fee should be in range 1e8 to 1000e8")
 	}
+	if p.SlashFractionOracle.LT(sdk.ZeroDec()) || p.SlashFractionOracle.GT(sdk.OneDec()) {
+		return fmt.Errorf("the slash_fraction_oracle should be in range 0 to 1")
+	}
+	if p.SlashFractionBridge.LT(sdk.ZeroDec()) || p.SlashFractionBridge.GT(sdk.OneDec()) {
+		return fmt.Errorf("the slash_fraction_bridge should be in range 0 to 1")
+	}
 	return nil
 }
 
@@ -98,6 +108,8 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 		{KeyDowntimeSlashAmount, &p.DowntimeSlashAmount},
 		{KeySubmitterReward, &p.SubmitterReward},
 		{KeyDowntimeSlashFee, &p.DowntimeSlashFee},
+		{KeySlashFractionOracle, &p.SlashFractionOracle},
+		{KeySlashFractionBridge, &p.SlashFractionBridge},
 	}
 }
 
@@ -133,6 +145,10 @@ func DefaultParams() Params {
 		SubmitterReward: 10e8,
 
 		DowntimeSlashFee: 10e8,
+
+		SlashFractionOracle: sdk.OneDec().Quo(sdk.NewDecWithoutFra(100)),
+
+		SlashFractionBridge: sdk.OneDec().Quo(sdk.NewDecWithoutFra(20)),
 	}
 }
 
@@ -206,7 +222,56 @@ func (k Keeper) DowntimeSlashFee(ctx sdk.Context) (downtimeSlashFee int64) {
 	return
 }
 
+// SlashFractionOracle - currently default 1%, zero before SlashInfractionTypes
+func (k Keeper) SlashFractionOracle(ctx sdk.Context) sdk.Dec {
+	res := sdk.ZeroDec()
+	k.paramspace.GetIfExists(ctx, KeySlashFractionOracle, &res)
+	return res
+}
+
+// SlashFractionBridge - currently default 5%, zero before SlashInfractionTypes
+func (k Keeper) SlashFractionBridge(ctx sdk.Context) sdk.Dec {
+	res := sdk.ZeroDec()
+	k.paramspace.GetIfExists(ctx, KeySlashFractionBridge, &res)
+	return res
+}
+
+// in order to be compatible with before
+type paramBeforeSlashInfractionTypesUpgrade Params
+
+func (p *paramBeforeSlashInfractionTypesUpgrade) GetParamAttribute() (string, bool) {
+	return "slash", false
+}
+
+func (p *paramBeforeSlashInfractionTypesUpgrade) UpdateCheck() error {
+	params := Params(*p)
+	return params.UpdateCheck()
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeSlashInfractionTypesUpgrade) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{KeyMaxEvidenceAge, &p.MaxEvidenceAge},
+		{KeySignedBlocksWindow, &p.SignedBlocksWindow},
+		{KeyMinSignedPerWindow, &p.MinSignedPerWindow},
+		{KeyDoubleSignUnbondDuration, &p.DoubleSignUnbondDuration},
+		{KeyDowntimeUnbondDuration, &p.DowntimeUnbondDuration},
+		{KeyTooLowDelUnbondDuration, &p.TooLowDelUnbondDuration},
+		{KeySlashFractionDoubleSign, &p.SlashFractionDoubleSign},
+		{KeySlashFractionDowntime, &p.SlashFractionDowntime},
+		{KeyDoubleSignSlashAmount, &p.DoubleSignSlashAmount},
+		{KeyDowntimeSlashAmount, &p.DowntimeSlashAmount},
+		{KeySubmitterReward, &p.SubmitterReward},
+		{KeyDowntimeSlashFee, &p.DowntimeSlashFee},
+	}
+}
+
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params Params) {
-	k.paramspace.SetParamSet(ctx, &params)
+	sdk.Upgrade(sdk.SlashInfractionTypes, func() {
+		pb := paramBeforeSlashInfractionTypesUpgrade(params)
+		k.paramspace.SetParamSet(ctx, &pb)
+	}, nil, func() {
+		k.paramspace.SetParamSet(ctx, &params)
+	})
 }
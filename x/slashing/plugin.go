@@ -0,0 +1,29 @@
+package slashing
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func RegisterUpgradeBeginBlocker(k Keeper) {
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.SlashInfractionTypes, func(ctx sdk.Context) {
+		k.initInfractionParams(ctx)
+		if k.ScKeeper == nil {
+			return
+		}
+		_, prefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
+		for _, prefix := range prefixes {
+			k.initInfractionParams(ctx.WithSideChainKeyPrefix(prefix))
+		}
+	})
+}
+
+// initInfractionParams sets the default slash fractions of the infractions added by SlashInfractionTypes
+func (k Keeper) initInfractionParams(ctx sdk.Context) {
+	defaults := DefaultParams()
+	if !k.paramspace.Has(ctx, KeySlashFractionOracle) {
+		k.paramspace.Set(ctx, KeySlashFractionOracle, defaults.SlashFractionOracle)
+	}
+	if !k.paramspace.Has(ctx, KeySlashFractionBridge) {
+		k.paramspace.Set(ctx, KeySlashFractionBridge, defaults.SlashFractionBridge)
+	}
+}
@@ -10,14 +10,23 @@ import (
 )
 
 const (
+	QuerySigningInfo              = "signingInfo"
 	QueryConsAddrSlashRecords     = "consAddrSlashHistories"
 	QueryConsAddrTypeSlashRecords = "consAddrTypeSlashHistories"
+	QuerySigningPerformance       = "signingPerformance"
 )
 
 // creates a querier for staking REST endpoints
 func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
+		case QuerySigningInfo:
+			param := new(QueryConsAddrParams)
+			ctx, err = RequestPrepare(ctx, k, req, param)
+			if err != nil {
+				return res, err
+			}
+			return querySigningInfo(ctx, k, param)
 		case QueryConsAddrSlashRecords:
 			param := new(QueryConsAddrParams)
 			ctx, err = RequestPrepare(ctx, k, req, param)
@@ -32,6 +41,13 @@ func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryConsAddrTypeSlashRecords(ctx, k, param)
+		case QuerySigningPerformance:
+			param := new(QuerySigningPerformanceParams)
+			ctx, err = RequestPrepare(ctx, k, req, param)
+			if err != nil {
+				return res, err
+			}
+			return querySigningPerformance(ctx, k, param)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown slashing query endpoint")
 		}
@@ -64,6 +80,15 @@ type QueryConsAddrTypeParams struct {
 	InfractionType byte
 }
 
+// QuerySigningPerformanceParams selects a validator and a page of its missed heights. Before is the
+// cursor, the page starts right below that height, leave it 0 to get the first page.
+type QuerySigningPerformanceParams struct {
+	BaseParams
+	ConsAddr []byte
+	Before   int64
+	Limit    int
+}
+
 func RequestPrepare(ctx sdk.Context, k Keeper, req abci.RequestQuery, p types.SideChainIder) (newCtx sdk.Context, err sdk.Error) {
 	if req.Data == nil || len(req.Data) == 0 {
 		return ctx, nil
@@ -91,6 +116,20 @@ func prepareSideChainCtx(ctx sdk.Context, k Keeper, sideChainId string) (sdk.Con
 	return scCtx, nil
 }
 
+func querySigningInfo(ctx sdk.Context, k Keeper, params *QueryConsAddrParams) (res []byte, err sdk.Error) {
+	signingInfo, found := k.getValidatorSigningInfo(ctx, params.ConsAddr)
+	if !found {
+		return nil, ErrNoValidatorForAddress(k.Codespace)
+	}
+
+	res, resErr := codec.MarshalJSONIndent(k.cdc, signingInfo)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
+
 func queryConsAddrSlashRecords(ctx sdk.Context, k Keeper, params *QueryConsAddrParams) (res []byte, err sdk.Error) {
 	slashRecords := k.getSlashRecordsByConsAddr(ctx, params.ConsAddr)
 	if len(slashRecords) == 0 {
@@ -118,3 +157,24 @@ func queryConsAddrTypeSlashRecords(ctx sdk.Context, k Keeper, params *QueryConsA
 
 	return res, nil
 }
+
+func querySigningPerformance(ctx sdk.Context, k Keeper, params *QuerySigningPerformanceParams) (res []byte, err sdk.Error) {
+	if params.Limit < 0 || params.Limit > MaxMissedHeightsPageLimit {
+		return nil, sdk.ErrUnknownRequest("limit should be between 0 and 1000")
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultMissedHeightsPageLimit
+	}
+
+	performance, found := k.GetSigningPerformance(ctx, params.ConsAddr, params.Before, params.Limit)
+	if !found {
+		return nil, ErrNoValidatorForAddress(k.Codespace)
+	}
+
+	res, resErr := codec.MarshalJSONIndent(k.cdc, performance)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
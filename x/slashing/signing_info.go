@@ -73,10 +73,11 @@ type ValidatorSigningInfo struct {
 	IndexOffset         int64     `json:"index_offset"`          // index offset into signed block bit array
 	JailedUntil         time.Time `json:"jailed_until"`          // timestamp validator cannot be unjailed until
 	MissedBlocksCounter int64     `json:"missed_blocks_counter"` // missed blocks counter (to avoid scanning the array every time)
+	Tombstoned          bool      `json:"tombstoned"`            // whether the validator was slashed for double signing and can never be unjailed
 }
 
 // Return human readable signing info
 func (i ValidatorSigningInfo) HumanReadableString() string {
-	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %v, missed blocks counter: %d",
-		i.StartHeight, i.IndexOffset, i.JailedUntil, i.MissedBlocksCounter)
+	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %v, missed blocks counter: %d, tombstoned: %t",
+		i.StartHeight, i.IndexOffset, i.JailedUntil, i.MissedBlocksCounter, i.Tombstoned)
 }
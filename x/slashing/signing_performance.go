@@ -0,0 +1,104 @@
+package slashing
+
+import (
+	"encoding/binary"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultMissedHeightsPageLimit = 100
+	MaxMissedHeightsPageLimit     = 1000
+)
+
+// SigningPerformance is how a validator signed the blocks of the current signed blocks window
+type SigningPerformance struct {
+	ConsAddr           sdk.ConsAddress `json:"cons_addr"`
+	SignedBlocksWindow int64           `json:"signed_blocks_window"`
+	BlocksCounted      int64           `json:"blocks_counted"` // blocks of the window the validator should have signed so far
+	MissedBlocks       int64           `json:"missed_blocks"`
+	Uptime             sdk.Dec         `json:"uptime"` // ratio of the counted blocks signed, one if none is counted yet
+
+	// MissedHeights is a page of the heights of the missed blocks, the most recent first. The heights
+	// are derived from the index into the window assuming the validator stayed in the validator set
+	// since, Next is the cursor of the following page and is 0 once the last height is returned.
+	MissedHeights []int64 `json:"missed_heights"`
+	Next          int64   `json:"next"`
+
+	JailHistory []SlashRecord `json:"jail_history"`
+}
+
+// GetSigningPerformance computes the signing performance of a validator from its signing info and
+// missed block bit array. The page of missed heights starts right below the height before, leave it
+// 0 to get the first page.
+func (k Keeper) GetSigningPerformance(ctx sdk.Context, consAddr sdk.ConsAddress, before int64, limit int) (SigningPerformance, bool) {
+	signInfo, found := k.getValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return SigningPerformance{}, false
+	}
+
+	window := k.SignedBlocksWindow(ctx)
+	counted := signInfo.IndexOffset
+	if counted > window {
+		counted = window
+	}
+	performance := SigningPerformance{
+		ConsAddr:           consAddr,
+		SignedBlocksWindow: window,
+		BlocksCounted:      counted,
+		MissedBlocks:       signInfo.MissedBlocksCounter,
+		Uptime:             sdk.OneDec(),
+		MissedHeights:      make([]int64, 0),
+		JailHistory:        k.getSlashRecordsByConsAddr(ctx, consAddr),
+	}
+	if counted > 0 {
+		performance.Uptime = sdk.NewDec(counted - signInfo.MissedBlocksCounter).Quo(sdk.NewDec(counted))
+	}
+	if performance.JailHistory == nil {
+		performance.JailHistory = make([]SlashRecord, 0)
+	}
+
+	// the last counted block is the one committed before the current height, it is at index
+	// IndexOffset-1 of the window
+	lastOffset := signInfo.IndexOffset - 1
+	lastHeight := ctx.BlockHeight() - 1
+	var heights []int64
+	store := ctx.KVStore(k.storeKey)
+	prefix := GetValidatorMissedBlockBitArrayPrefixKey(consAddr)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iter.Valid(); iter.Next() {
+		index := int64(binary.LittleEndian.Uint64(iter.Key()[len(prefix):]))
+		if index >= window {
+			// left by a larger window
+			continue
+		}
+		var missed bool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &missed)
+		if !missed {
+			continue
+		}
+		age := (lastOffset - index) % window
+		if age < 0 {
+			age += window
+		}
+		if age >= counted {
+			continue
+		}
+		heights = append(heights, lastHeight-age)
+	}
+	iter.Close()
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+
+	for _, height := range heights {
+		if before > 0 && height >= before {
+			continue
+		}
+		if len(performance.MissedHeights) == limit {
+			performance.Next = performance.MissedHeights[limit-1]
+			break
+		}
+		performance.MissedHeights = append(performance.MissedHeights, height)
+	}
+	return performance, true
+}
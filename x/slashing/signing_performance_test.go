@@ -0,0 +1,73 @@
+package slashing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestSigningPerformance(t *testing.T) {
+	params := keeperTestParams()
+	params.SignedBlocksWindow = 10
+	ctx, _, sk, _, keeper := createTestInput(t, params)
+	stakeParam := stake.DefaultParams()
+	stakeParam.MinSelfDelegation = 10e8
+	sk.SetParams(ctx, stakeParam)
+	amtInt := sdk.NewDecWithoutFra(100).RawInt()
+	addr, val := addrs[0], pks[0]
+	consAddr := sdk.ConsAddress(val.Address())
+	got := stake.NewStakeHandler(sk)(ctx, NewTestMsgCreateValidator(addr, val, amtInt))
+	require.True(t, got.IsOK())
+	validatorUpdates, _ := stake.EndBlocker(ctx, sk)
+	keeper.AddValidators(ctx, validatorUpdates)
+
+	_, found := keeper.GetSigningPerformance(ctx, sdk.ConsAddress(pks[1].Address()), 0, 10)
+	require.False(t, found)
+
+	performance, found := keeper.GetSigningPerformance(ctx, consAddr, 0, 10)
+	require.True(t, found)
+	require.Equal(t, int64(0), performance.BlocksCounted)
+	require.Equal(t, sdk.OneDec(), performance.Uptime)
+	require.Empty(t, performance.MissedHeights)
+
+	// the signatures handled at a height are the ones of the block before
+	missedAt := map[int64]bool{3: true, 10: true, 12: true, 14: true, 16: true}
+	for height := int64(1); height <= 20; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.handleValidatorSignature(ctx, val.Address(), amtInt, !missedAt[height])
+	}
+
+	// the window holds the blocks 10 to 19, the missed blocks 2 and 9 left it
+	performance, found = keeper.GetSigningPerformance(ctx, consAddr, 0, 2)
+	require.True(t, found)
+	require.Equal(t, int64(10), performance.BlocksCounted)
+	require.Equal(t, int64(3), performance.MissedBlocks)
+	require.Equal(t, sdk.NewDecWithPrec(7, 1), performance.Uptime)
+	require.Equal(t, []int64{15, 13}, performance.MissedHeights)
+	require.Equal(t, int64(13), performance.Next)
+	require.Empty(t, performance.JailHistory)
+
+	performance, _ = keeper.GetSigningPerformance(ctx, consAddr, performance.Next, 2)
+	require.Equal(t, []int64{11}, performance.MissedHeights)
+	require.Equal(t, int64(0), performance.Next)
+
+	// query the first page through the querier
+	querier := NewQuerier(keeper, keeper.cdc)
+	bz, err := json.Marshal(QuerySigningPerformanceParams{ConsAddr: consAddr})
+	require.NoError(t, err)
+	res, sdkErr := querier(ctx, []string{QuerySigningPerformance}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var queried SigningPerformance
+	require.NoError(t, keeper.cdc.UnmarshalJSON(res, &queried))
+	require.Equal(t, []int64{15, 13, 11}, queried.MissedHeights)
+
+	bz, err = json.Marshal(QuerySigningPerformanceParams{ConsAddr: consAddr, Limit: MaxMissedHeightsPageLimit + 1})
+	require.NoError(t, err)
+	_, sdkErr = querier(ctx, []string{QuerySigningPerformance}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+}
@@ -38,3 +38,10 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 
 	return
 }
+
+// slashing end block functionality
+func EndBlocker(ctx sdk.Context, sk Keeper) {
+	if sdk.IsUpgrade(sdk.ValidatorTombstone) {
+		sk.executeUntombstoneProposals(ctx)
+	}
+}
@@ -0,0 +1,128 @@
+package slashing
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+// UntombstoneProposal is the description of an UntombstoneValidator proposal. Once the proposal
+// passes, the validator may unjail itself again after its jail period ends.
+type UntombstoneProposal struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	SideChainId   string         `json:"side_chain_id"`
+}
+
+func (p UntombstoneProposal) Check() error {
+	if len(p.ValidatorAddr) != sdk.AddrLen {
+		return fmt.Errorf("invalid validator address %s", p.ValidatorAddr)
+	}
+	return nil
+}
+
+// Untombstone lifts the permanent ban of a validator slashed for double signing.
+func (k Keeper) Untombstone(ctx sdk.Context, validatorAddr sdk.ValAddress) sdk.Error {
+	consAddr, info, err := k.getTombstonedSigningInfo(ctx, validatorAddr)
+	if err != nil {
+		return err
+	}
+	info.Tombstoned = false
+	k.setValidatorSigningInfo(ctx, consAddr, info)
+	return nil
+}
+
+func (k Keeper) getTombstonedSigningInfo(ctx sdk.Context, validatorAddr sdk.ValAddress) ([]byte, ValidatorSigningInfo, sdk.Error) {
+	validator := k.validatorSet.Validator(ctx, validatorAddr)
+	if validator == nil {
+		return nil, ValidatorSigningInfo{}, ErrNoValidatorForAddress(k.Codespace)
+	}
+
+	var consAddr []byte
+	if validator.IsSideChainValidator() {
+		consAddr = validator.GetSideChainConsAddr()
+	} else {
+		consAddr = validator.GetConsAddr().Bytes()
+	}
+
+	info, found := k.getValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return nil, ValidatorSigningInfo{}, ErrNoValidatorForAddress(k.Codespace)
+	}
+	if !info.Tombstoned {
+		return nil, ValidatorSigningInfo{}, ErrInvalidInput(k.Codespace, fmt.Sprintf("validator %s is not tombstoned", validatorAddr))
+	}
+	return consAddr, info, nil
+}
+
+func (k Keeper) prepareUntombstoneCtx(ctx sdk.Context, p UntombstoneProposal) (sdk.Context, sdk.Error) {
+	if len(p.SideChainId) == 0 {
+		return ctx, nil
+	}
+	if k.ScKeeper == nil {
+		return sdk.Context{}, ErrInvalidSideChainId(k.Codespace)
+	}
+	return prepareSideChainCtx(ctx, k, p.SideChainId)
+}
+
+// executeUntombstoneProposals applies the UntombstoneValidator proposals passed since the last block.
+func (k Keeper) executeUntombstoneProposals(ctx sdk.Context) {
+	if k.govKeeper == nil {
+		return
+	}
+	logger := ctx.Logger().With("module", "x/slashing")
+	for _, proposal := range sidechain.CollectPassedProposals(ctx, k.govKeeper, gov.ProposalTypeUntombstoneValidator) {
+		var p UntombstoneProposal
+		if err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &p); err != nil {
+			logger.Error("Get broken data when unmarshal UntombstoneProposal msg, will skip.",
+				"proposalId", proposal.GetProposalID(), "err", err)
+			continue
+		}
+		execCtx, err := k.prepareUntombstoneCtx(ctx, p)
+		if err != nil {
+			logger.Error("The side chain of UntombstoneProposal does not exist, will skip.",
+				"proposalId", proposal.GetProposalID(), "proposal", p)
+			continue
+		}
+		if err := k.Untombstone(execCtx, p.ValidatorAddr); err != nil {
+			logger.Error("Failed to untombstone validator, will skip.",
+				"proposalId", proposal.GetProposalID(), "proposal", p, "err", err.Error())
+			continue
+		}
+		logger.Info("Untombstoned validator", "proposalId", proposal.GetProposalID(), "validator", p.ValidatorAddr.String())
+	}
+}
+
+// ---------------------    UntombstoneHooks  -----------------
+type UntombstoneHooks struct {
+	k Keeper
+}
+
+func NewUntombstoneHooks(keeper Keeper) UntombstoneHooks {
+	return UntombstoneHooks{keeper}
+}
+
+var _ gov.GovHooks = UntombstoneHooks{}
+
+func (hooks UntombstoneHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeUntombstoneValidator {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	var p UntombstoneProposal
+	if err := hooks.k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &p); err != nil {
+		return fmt.Errorf("unmarshal UntombstoneProposal failed: %v", err)
+	}
+	if err := p.Check(); err != nil {
+		return err
+	}
+	checkCtx, err := hooks.k.prepareUntombstoneCtx(ctx, p)
+	if err != nil {
+		return err
+	}
+	if _, _, err := hooks.k.getTombstonedSigningInfo(checkCtx, p.ValidatorAddr); err != nil {
+		return err
+	}
+	return nil
+}
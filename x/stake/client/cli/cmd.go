@@ -18,7 +18,9 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 	stakingCmd.AddCommand(
 		client.PostCommands(
 			GetCmdCreateValidator(cdc),
+			GetCmdCreateValidatorFromFile(cdc),
 			GetCmdRemoveValidator(cdc),
+			GetCmdWithdrawCommissionAndRestake(cdc),
 		)...,
 	)
 	stakingCmd.AddCommand(client.LineBreak)
@@ -27,7 +29,9 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 		client.GetCommands(
 			GetCmdQueryValidator(storeKey, cdc),
 			GetCmdQueryValidators(storeKey, cdc),
+			GetCmdQueryValidatorStatus(storeKey, cdc),
 			GetCmdQueryUnbondingDelegations(storeKey, cdc),
+			GetCmdQueryExchangeRate(cdc),
 		)...,
 	)
 	stakingCmd.AddCommand(client.LineBreak)
@@ -38,6 +38,9 @@ const (
 
 	FlagOutputDocument = "output-document" // inspired by wget -O
 
+	FlagAtHeight = "at-height"
+	FlagHistory  = "history"
+
 	FlagSideChainId  = "side-chain-id"
 	FlagSideConsAddr = "side-cons-addr"
 	FlagSideFeeAddr  = "side-fee-addr"
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -502,3 +503,45 @@ func GetCmdQueryParams(storeName string, cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryExchangeRate implements the validator exchange rate query command.
+func GetCmdQueryExchangeRate(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exchange-rate [operator-addr]",
+		Short: "Query the token/share exchange rate of a validator, currently, at a height or its recent history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			baseParams := stake.NewBaseParams(viper.GetString(FlagSideChainId))
+
+			var path string
+			var params interface{}
+			if viper.GetBool(FlagHistory) {
+				path = "custom/stake/" + stake.QueryValidatorExchangeRateHistory
+				params = stake.QueryValidatorParams{BaseParams: baseParams, ValidatorAddr: valAddr}
+			} else {
+				path = "custom/stake/" + stake.QueryValidatorExchangeRate
+				params = stake.QueryExchangeRateParams{BaseParams: baseParams, ValidatorAddr: valAddr, Height: viper.GetInt64(FlagAtHeight)}
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData(path, bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	cmd.Flags().Int64(FlagAtHeight, 0, "height at which the exchange rate was in effect, 0 for the current one")
+	cmd.Flags().Bool(FlagHistory, false, "show the recent exchange rate changes instead")
+	return cmd
+}
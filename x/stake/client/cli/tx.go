@@ -31,62 +31,16 @@ func GetCmdCreateValidator(cdc *codec.Codec) *cobra.Command {
 				WithCodec(cdc).
 				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
 
-			amount, err := getAmount()
-			if err != nil {
-				return err
-			}
-
+			config := validatorConfigFromFlags()
 			valAddr, err := cliCtx.GetFromAddress()
 			if err != nil {
 				return err
 			}
-
-			pkStr := viper.GetString(FlagPubKey)
-			if len(pkStr) == 0 {
-				return fmt.Errorf("must use --pubkey flag")
-			}
-
-			pk, err := sdk.GetConsPubKeyBech32(pkStr)
+			msg, err := buildCreateValidatorMsg(valAddr, config)
 			if err != nil {
 				return err
 			}
 
-			if viper.GetString(FlagMoniker) == "" {
-				return fmt.Errorf("please enter a moniker for the validator using --moniker")
-			}
-
-			description := stake.Description{
-				Moniker:  viper.GetString(FlagMoniker),
-				Identity: viper.GetString(FlagIdentity),
-				Website:  viper.GetString(FlagWebsite),
-				Details:  viper.GetString(FlagDetails),
-			}
-
-			// get the initial validator commission parameters
-			rateStr := viper.GetString(FlagCommissionRate)
-			maxRateStr := viper.GetString(FlagCommissionMaxRate)
-			maxChangeRateStr := viper.GetString(FlagCommissionMaxChangeRate)
-			commissionMsg, err := buildCommissionMsg(rateStr, maxRateStr, maxChangeRateStr)
-			if err != nil {
-				return err
-			}
-
-			var msg sdk.Msg
-			if viper.GetString(FlagAddressDelegator) != "" {
-				delAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressDelegator))
-				if err != nil {
-					return err
-				}
-
-				msg = stake.NewMsgCreateValidatorOnBehalfOf(
-					delAddr, sdk.ValAddress(valAddr), pk, amount, description, commissionMsg,
-				)
-			} else {
-				msg = stake.NewMsgCreateValidator(
-					sdk.ValAddress(valAddr), pk, amount, description, commissionMsg,
-				)
-			}
-
 			if viper.GetBool(FlagGenesisFormat) {
 				ip := viper.GetString(FlagIP)
 				nodeID := viper.GetString(FlagNodeID)
@@ -95,42 +49,6 @@ func GetCmdCreateValidator(cdc *codec.Codec) *cobra.Command {
 				}
 			}
 
-			proposalId := viper.GetInt64(FlagProposalID)
-			if proposalId == -1 {
-				depositStr := viper.GetString(FlagDeposit)
-				if depositStr == "" {
-					return fmt.Errorf("must specify deposit amount when proposalId is zero using --deposit")
-				}
-				deposit, err := sdk.ParseCoin(depositStr)
-				if err != nil {
-					return err
-				}
-				title := fmt.Sprintf("create validator %s", valAddr.String())
-
-				description, err := json.Marshal(msg)
-				if err != nil {
-					return err
-				}
-				votingPeriodInSeconds := viper.GetInt64(FlagVotingPeriod)
-
-				if votingPeriodInSeconds <= 0 {
-					return errors.New("voting period should be positive")
-				}
-
-				votingPeriod := time.Duration(votingPeriodInSeconds) * time.Second
-				if votingPeriod > gov.MaxVotingPeriod {
-					return errors.New(fmt.Sprintf("voting period should be less than %d seconds", gov.MaxVotingPeriod/time.Second))
-				}
-
-				msg = gov.NewMsgSubmitProposal(title, string(description),
-					gov.ProposalTypeCreateValidator, valAddr, sdk.Coins{deposit}, votingPeriod)
-			} else {
-				msg = stake.MsgCreateValidatorProposal{
-					MsgCreateValidator: msg.(stake.MsgCreateValidator),
-					ProposalId:         proposalId,
-				}
-			}
-
 			if viper.GetBool(FlagGenesisFormat) || cliCtx.GenerateOnly {
 				//Enable offline mode
 				viper.Set(FlagOffline, true)
@@ -0,0 +1,351 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	distrTypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+const (
+	distrStoreKey    = "distr"
+	slashingStoreKey = "slashing"
+)
+
+// ValidatorConfig holds everything needed to create a validator, it is either read
+// from the create-validator flags or from a json file.
+type ValidatorConfig struct {
+	PubKey    string `json:"pubkey"`
+	Amount    string `json:"amount"`
+	Delegator string `json:"delegator,omitempty"`
+
+	Moniker  string `json:"moniker"`
+	Identity string `json:"identity,omitempty"`
+	Website  string `json:"website,omitempty"`
+	Details  string `json:"details,omitempty"`
+
+	CommissionRate          string `json:"commission_rate"`
+	CommissionMaxRate       string `json:"commission_max_rate"`
+	CommissionMaxChangeRate string `json:"commission_max_change_rate"`
+
+	// ProposalID refers to an approved CreateValidator proposal, when it is not set a
+	// proposal is submitted with Deposit and VotingPeriod (in seconds) instead.
+	ProposalID   *int64 `json:"proposal_id,omitempty"`
+	Deposit      string `json:"deposit,omitempty"`
+	VotingPeriod int64  `json:"voting_period,omitempty"`
+}
+
+func validatorConfigFromFlags() ValidatorConfig {
+	config := ValidatorConfig{
+		PubKey:                  viper.GetString(FlagPubKey),
+		Amount:                  viper.GetString(FlagAmount),
+		Delegator:               viper.GetString(FlagAddressDelegator),
+		Moniker:                 viper.GetString(FlagMoniker),
+		Identity:                viper.GetString(FlagIdentity),
+		Website:                 viper.GetString(FlagWebsite),
+		Details:                 viper.GetString(FlagDetails),
+		CommissionRate:          viper.GetString(FlagCommissionRate),
+		CommissionMaxRate:       viper.GetString(FlagCommissionMaxRate),
+		CommissionMaxChangeRate: viper.GetString(FlagCommissionMaxChangeRate),
+		Deposit:                 viper.GetString(FlagDeposit),
+		VotingPeriod:            viper.GetInt64(FlagVotingPeriod),
+	}
+	if proposalId := viper.GetInt64(FlagProposalID); proposalId != -1 {
+		config.ProposalID = &proposalId
+	}
+	return config
+}
+
+func readValidatorConfig(file string) (ValidatorConfig, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return ValidatorConfig{}, err
+	}
+	config := ValidatorConfig{VotingPeriod: 7 * 24 * 60 * 60}
+	if err := json.Unmarshal(bz, &config); err != nil {
+		return ValidatorConfig{}, fmt.Errorf("invalid validator config %s: %v", file, err)
+	}
+	return config, nil
+}
+
+// buildCreateValidatorMsg builds either the proposal to create the validator or, once the
+// proposal is passed, the message creating it.
+func buildCreateValidatorMsg(valAddr sdk.AccAddress, config ValidatorConfig) (sdk.Msg, error) {
+	if config.Amount == "" {
+		return nil, fmt.Errorf("%s is required", FlagAmount)
+	}
+	amount, err := sdk.ParseCoin(config.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.PubKey) == 0 {
+		return nil, fmt.Errorf("must use --pubkey flag")
+	}
+	pk, err := sdk.GetConsPubKeyBech32(config.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Moniker == "" {
+		return nil, fmt.Errorf("please enter a moniker for the validator using --moniker")
+	}
+	description := stake.Description{
+		Moniker:  config.Moniker,
+		Identity: config.Identity,
+		Website:  config.Website,
+		Details:  config.Details,
+	}
+
+	// get the initial validator commission parameters
+	commissionMsg, err := buildCommissionMsg(config.CommissionRate, config.CommissionMaxRate, config.CommissionMaxChangeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg sdk.Msg
+	if config.Delegator != "" {
+		delAddr, err := sdk.AccAddressFromBech32(config.Delegator)
+		if err != nil {
+			return nil, err
+		}
+
+		msg = stake.NewMsgCreateValidatorOnBehalfOf(
+			delAddr, sdk.ValAddress(valAddr), pk, amount, description, commissionMsg,
+		)
+	} else {
+		msg = stake.NewMsgCreateValidator(
+			sdk.ValAddress(valAddr), pk, amount, description, commissionMsg,
+		)
+	}
+
+	if config.ProposalID != nil {
+		return stake.MsgCreateValidatorProposal{
+			MsgCreateValidator: msg.(stake.MsgCreateValidator),
+			ProposalId:         *config.ProposalID,
+		}, nil
+	}
+
+	if config.Deposit == "" {
+		return nil, fmt.Errorf("must specify deposit amount when proposalId is zero using --deposit")
+	}
+	deposit, err := sdk.ParseCoin(config.Deposit)
+	if err != nil {
+		return nil, err
+	}
+	title := fmt.Sprintf("create validator %s", valAddr.String())
+
+	proposalDescription, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.VotingPeriod <= 0 {
+		return nil, errors.New("voting period should be positive")
+	}
+	votingPeriod := time.Duration(config.VotingPeriod) * time.Second
+	if votingPeriod > gov.MaxVotingPeriod {
+		return nil, fmt.Errorf("voting period should be less than %d seconds", gov.MaxVotingPeriod/time.Second)
+	}
+
+	return gov.NewMsgSubmitProposal(title, string(proposalDescription),
+		gov.ProposalTypeCreateValidator, valAddr, sdk.Coins{deposit}, votingPeriod), nil
+}
+
+// GetCmdCreateValidatorFromFile implements the create validator command reading its
+// parameters from a json file instead of flags.
+func GetCmdCreateValidatorFromFile(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-validator-from-file [config-file]",
+		Short: "create new validator with the parameters of a json config file",
+		Long: `create new validator with the parameters of a json config file, e.g.
+{
+  "pubkey": "bcavalconspub1...",
+  "amount": "1000000000000:BNB",
+  "moniker": "my validator",
+  "commission_rate": "10000000",
+  "commission_max_rate": "20000000",
+  "commission_max_change_rate": "1000000",
+  "deposit": "100000000000:BNB",
+  "voting_period": 604800
+}
+Set "proposal_id" instead of "deposit" once the CreateValidator proposal is passed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			config, err := readValidatorConfig(args[0])
+			if err != nil {
+				return err
+			}
+			valAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			msg, err := buildCreateValidatorMsg(valAddr, config)
+			if err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.MarkFlagRequired(client.FlagFrom)
+
+	return cmd
+}
+
+// GetCmdWithdrawCommissionAndRestake withdraws the commission and rewards of the validator
+// and delegates what was credited to the operator back to the validator.
+func GetCmdWithdrawCommissionAndRestake(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-and-restake",
+		Short: "withdraw the commission and rewards of your validator and delegate them back to it",
+		Long: `withdraw the commission and rewards of your validator and delegate them back to it.
+The withdrawal is committed first, then the increase of the operator balance is delegated,
+so rewards paid to a separate withdraw address are not restaked.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+			if cliCtx.Async || cliCtx.GenerateOnly || cliCtx.Dry || cliCtx.DryRun {
+				return errors.New("withdraw-and-restake waits for the withdrawal to be committed, it can not be used with async, dry or generate-only mode")
+			}
+
+			addr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			valAddr := sdk.ValAddress(addr)
+
+			before, err := cliCtx.GetAccount(addr)
+			if err != nil {
+				return err
+			}
+			withdrawMsg := distr.NewMsgWithdrawValidatorRewardsAll(valAddr)
+			if err := utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{withdrawMsg}); err != nil {
+				return err
+			}
+
+			after, err := cliCtx.GetAccount(addr)
+			if err != nil {
+				return err
+			}
+			withdrawn := after.GetCoins().AmountOf(sdk.NativeTokenSymbol) - before.GetCoins().AmountOf(sdk.NativeTokenSymbol)
+			if withdrawn <= 0 {
+				fmt.Println("nothing was credited to the operator, skip restaking")
+				return nil
+			}
+
+			if txBldr.Sequence != 0 {
+				txBldr = txBldr.WithSequence(txBldr.Sequence + 1)
+			}
+			delegateMsg := stake.NewMsgDelegate(addr, valAddr, sdk.NewCoin(sdk.NativeTokenSymbol, withdrawn))
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{delegateMsg})
+		},
+	}
+	cmd.MarkFlagRequired(client.FlagFrom)
+
+	return cmd
+}
+
+// ValidatorStatus aggregates what an operator needs to check the health of a validator.
+type ValidatorStatus struct {
+	OperatorAddr sdk.ValAddress                 `json:"operator_address"`
+	Moniker      string                         `json:"moniker"`
+	Status       sdk.BondStatus                 `json:"status"`
+	Jailed       bool                           `json:"jailed"`
+	Tokens       sdk.Dec                        `json:"tokens"`
+	Commission   types.Commission               `json:"commission"`
+	SigningInfo  *slashing.ValidatorSigningInfo `json:"signing_info"`
+	// commission and delegator rewards accumulated by the validator and not withdrawn yet
+	PendingCommission distrTypes.DecCoins `json:"pending_commission"`
+	PendingRewards    distrTypes.DecCoins `json:"pending_rewards"`
+}
+
+// GetCmdQueryValidatorStatus implements the command aggregating the stake, slashing and
+// distribution state of a validator.
+func GetCmdQueryValidatorStatus(storeName string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-status [operator-addr]",
+		Short: "Query the bond state, jail state, signing info, commission and pending rewards of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryStore(stake.GetValidatorKey(addr), storeName)
+			if err != nil {
+				return err
+			} else if len(res) == 0 {
+				return fmt.Errorf("No validator found with address %s", args[0])
+			}
+			validator, err := types.UnmarshalValidator(cdc, res)
+			if err != nil {
+				return err
+			}
+
+			status := ValidatorStatus{
+				OperatorAddr: validator.OperatorAddr,
+				Moniker:      validator.Description.Moniker,
+				Status:       validator.Status,
+				Jailed:       validator.Jailed,
+				Tokens:       validator.Tokens,
+				Commission:   validator.Commission,
+			}
+
+			res, err = cliCtx.QueryStore(slashing.GetValidatorSigningInfoKey(validator.ConsAddress()), slashingStoreKey)
+			if err != nil {
+				return err
+			} else if len(res) != 0 {
+				signingInfo := new(slashing.ValidatorSigningInfo)
+				cdc.MustUnmarshalBinaryLengthPrefixed(res, signingInfo)
+				status.SigningInfo = signingInfo
+			}
+
+			res, err = cliCtx.QueryStore(distr.GetValidatorDistInfoKey(addr), distrStoreKey)
+			if err != nil {
+				return err
+			} else if len(res) != 0 {
+				var distInfo distr.ValidatorDistInfo
+				cdc.MustUnmarshalBinaryLengthPrefixed(res, &distInfo)
+				status.PendingCommission = distInfo.PoolCommission
+				status.PendingRewards = distInfo.Pool
+			}
+
+			output, err := codec.MarshalJSONIndent(cdc, status)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestBuildCreateValidatorMsgFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validator-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+	file := filepath.Join(dir, "validator.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{
+  "pubkey": "`+sdk.MustBech32ifyConsPub(pubKey)+`",
+  "amount": "1000:BNB",
+  "moniker": "val",
+  "commission_rate": "10000000",
+  "commission_max_rate": "20000000",
+  "commission_max_change_rate": "1000000",
+  "deposit": "100:BNB"
+}`), 0644))
+
+	config, err := readValidatorConfig(file)
+	require.NoError(t, err)
+	require.Equal(t, int64(7*24*60*60), config.VotingPeriod)
+
+	valAddr := sdk.AccAddress([]byte("validator___________"))
+	msg, err := buildCreateValidatorMsg(valAddr, config)
+	require.NoError(t, err)
+	proposal, ok := msg.(gov.MsgSubmitProposal)
+	require.True(t, ok)
+	require.Equal(t, gov.ProposalTypeCreateValidator, proposal.ProposalType)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 100)}, proposal.InitialDeposit)
+
+	proposalId := int64(3)
+	config.ProposalID = &proposalId
+	msg, err = buildCreateValidatorMsg(valAddr, config)
+	require.NoError(t, err)
+	createMsg, ok := msg.(stake.MsgCreateValidatorProposal)
+	require.True(t, ok)
+	require.Equal(t, proposalId, createMsg.ProposalId)
+	require.Equal(t, pubKey, createMsg.PubKey)
+	require.Equal(t, "val", createMsg.Description.Moniker)
+
+	config.Moniker = ""
+	_, err = buildCreateValidatorMsg(valAddr, config)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"amount": 1}`), 0644))
+	_, err = readValidatorConfig(file)
+	require.Error(t, err)
+}
@@ -13,6 +13,9 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.Valid
 	var events sdk.Events
 	_, validatorUpdates, completedUbds, _, events = handleValidatorAndDelegations(ctx, k)
 	ctx.EventManager().EmitEvents(events)
+	if sdk.IsUpgrade(sdk.BondedTokensAverage) {
+		k.UpdateBondedAverage(ctx)
+	}
 	if sdk.IsUpgrade(sdk.BEP128) {
 		sideChainIds, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
 		if len(sideChainIds) == len(storePrefixes) {
@@ -302,6 +302,10 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 		return ErrValidatorJailed(k.Codespace()).Result()
 	}
 
+	if err := k.CheckDelegationCap(ctx, msg.DelegatorAddr, validator, msg.Delegation.Amount); err != nil {
+		return err.Result()
+	}
+
 	_, err := k.Delegate(ctx, msg.DelegatorAddr, msg.Delegation, validator, true)
 	if err != nil {
 		return err.Result()
@@ -334,6 +338,15 @@ func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k kee
 }
 
 func handleMsgBeginRedelegate(ctx sdk.Context, msg types.MsgBeginRedelegate, k keeper.Keeper) sdk.Result {
+	srcValidator, srcFound := k.GetValidator(ctx, msg.ValidatorSrcAddr)
+	dstValidator, dstFound := k.GetValidator(ctx, msg.ValidatorDstAddr)
+	if srcFound && dstFound {
+		amount := srcValidator.TokensFromShares(msg.SharesAmount).RawInt()
+		if err := k.CheckDelegationCap(ctx, msg.DelegatorAddr, dstValidator, amount); err != nil {
+			return err.Result()
+		}
+	}
+
 	red, err := k.BeginRedelegation(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr,
 		msg.ValidatorDstAddr, msg.SharesAmount)
 	if err != nil {
@@ -146,6 +146,10 @@ func handleMsgSideChainDelegate(ctx sdk.Context, msg MsgSideChainDelegate, k kee
 		return ErrValidatorJailed(k.Codespace()).Result()
 	}
 
+	if err := k.CheckDelegationCap(ctx, msg.DelegatorAddr, validator, msg.Delegation.Amount); err != nil {
+		return err.Result()
+	}
+
 	_, err := k.Delegate(ctx, msg.DelegatorAddr, msg.Delegation, validator, true)
 
 	if err != nil {
@@ -198,6 +202,10 @@ func handleMsgSideChainRedelegate(ctx sdk.Context, msg MsgSideChainRedelegate, k
 		return err.Result()
 	}
 
+	if err := k.CheckDelegationCap(ctx, msg.DelegatorAddr, dstValidator, msg.Amount.Amount); err != nil {
+		return err.Result()
+	}
+
 	shares, err := k.ValidateUnbondAmount(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.Amount.Amount)
 	if err != nil {
 		return err.Result()
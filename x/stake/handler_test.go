@@ -1178,3 +1178,68 @@ func TestRemoveValidatorAfterProposal(t *testing.T) {
 	result = handleMsgRemoveValidatorAfterProposal(ctx, msgRemoveValidator, keeper, govKeeper)
 	require.False(t, result.IsOK())
 }
+
+func TestDelegationCap(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := sdk.ValAddress(keep.Addrs[0])
+	validatorAddr2 := sdk.ValAddress(keep.Addrs[1])
+	delegatorAddr := keep.Addrs[2]
+
+	upgrades := []string{sdk.MinRewardPayout, sdk.BondedTokensAverage, sdk.DelegationCap}
+	for _, name := range upgrades {
+		sdk.UpgradeMgr.AddUpgradeHeight(name, 1)
+	}
+	defer func() {
+		for _, name := range upgrades {
+			delete(sdk.UpgradeMgr.Config.HeightMap, name)
+		}
+	}()
+
+	params := setInstantUnbondPeriod(keeper, ctx)
+	require.Equal(t, int64(0), keeper.MaxDelegationPerValidator(ctx))
+	require.True(t, keeper.MaxDelegationFraction(ctx).IsZero())
+
+	msgCreateValidator := NewTestMsgCreateValidator(validatorAddr, keep.PKs[0], 100)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	msgCreateValidator = NewTestMsgCreateValidator(validatorAddr2, keep.PKs[1], 100)
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// the absolute cap counts the tokens already delegated
+	params.MaxDelegationPerValidator = sdk.NewDecWithoutFra(50).RawInt()
+	keeper.SetParams(ctx, params)
+	require.Equal(t, params.MaxDelegationPerValidator, keeper.MaxDelegationPerValidator(ctx))
+
+	got = handleMsgDelegate(ctx, NewTestMsgDelegate(delegatorAddr, validatorAddr, 30), keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	got = handleMsgDelegate(ctx, NewTestMsgDelegate(delegatorAddr, validatorAddr, 30), keeper)
+	require.False(t, got.IsOK())
+	require.Equal(t, types.ErrDelegationCapExceeded(DefaultCodespace, "").ABCICode(), got.Code)
+	got = handleMsgDelegate(ctx, NewTestMsgDelegate(delegatorAddr, validatorAddr, 20), keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// the self-delegation is not capped
+	got = handleMsgDelegate(ctx, NewTestMsgDelegate(sdk.AccAddress(validatorAddr), validatorAddr, 100), keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// the fraction cap counts the tokens of the validator after the delegation
+	params.MaxDelegationPerValidator = 0
+	params.MaxDelegationFraction = sdk.NewDecWithPrec(2, 1)
+	keeper.SetParams(ctx, params)
+
+	got = handleMsgDelegate(ctx, NewTestMsgDelegate(delegatorAddr, validatorAddr2, 30), keeper)
+	require.False(t, got.IsOK())
+	got = handleMsgDelegate(ctx, NewTestMsgDelegate(delegatorAddr, validatorAddr2, 25), keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// the redelegation is capped on the destination validator
+	msgBeginRedelegate := NewMsgBeginRedelegate(delegatorAddr, validatorAddr, validatorAddr2, sdk.NewDecWithoutFra(10))
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.False(t, got.IsOK())
+
+	// no cap before the upgrade
+	delete(sdk.UpgradeMgr.Config.HeightMap, sdk.DelegationCap)
+	got = handleMsgBeginRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+}
@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// GetBondedAverage returns the stored time-weighted average of the bonded tokens
+func (k Keeper) GetBondedAverage(ctx sdk.Context) (average types.BondedAverage, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(BondedAverageKey)
+	if b == nil {
+		return average, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &average)
+	return average, true
+}
+
+// SetBondedAverage stores the time-weighted average of the bonded tokens
+func (k Keeper) SetBondedAverage(ctx sdk.Context, average types.BondedAverage) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(average)
+	store.Set(BondedAverageKey, b)
+}
+
+// UpdateBondedAverage folds the current bonded tokens into the average.
+// The average restarts from the current bonded tokens when the window is zero.
+func (k Keeper) UpdateBondedAverage(ctx sdk.Context) types.BondedAverage {
+	bonded := k.GetPool(ctx).BondedTokens
+	now := ctx.BlockHeader().Time
+	window := k.BondedAverageWindow(ctx)
+
+	average, found := k.GetBondedAverage(ctx)
+	if !found || window == 0 {
+		average = types.NewBondedAverage(bonded, now)
+	} else {
+		average = average.Update(bonded, now, window)
+	}
+	k.SetBondedAverage(ctx, average)
+	return average
+}
+
+// BondedTokensAverage returns the time-weighted average of the bonded tokens,
+// or the current bonded tokens if the average is not maintained.
+func (k Keeper) BondedTokensAverage(ctx sdk.Context) sdk.Dec {
+	average, found := k.GetBondedAverage(ctx)
+	if !found || k.BondedAverageWindow(ctx) == 0 {
+		return k.GetPool(ctx).BondedTokens
+	}
+	return average.Average
+}
+
+// AverageBondedRatio returns the ratio of the bonded tokens average to the token supply
+func (k Keeper) AverageBondedRatio(ctx sdk.Context) sdk.Dec {
+	supply := k.GetPool(ctx).TokenSupply()
+	if !supply.GT(sdk.ZeroDec()) {
+		return sdk.ZeroDec()
+	}
+	ratio := k.BondedTokensAverage(ctx).Quo(supply)
+	if ratio.GT(sdk.OneDec()) {
+		return sdk.OneDec()
+	}
+	return ratio
+}
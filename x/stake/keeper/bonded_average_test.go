@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBondedTokensAverage(t *testing.T) {
+	upgrades := []string{sdk.LaunchBscUpgrade, sdk.BEP128, sdk.MinRewardPayout, sdk.BondedTokensAverage}
+	for _, name := range upgrades {
+		sdk.UpgradeMgr.AddUpgradeHeight(name, 1)
+	}
+	sdk.UpgradeMgr.SetHeight(2)
+	defer func() {
+		for _, name := range upgrades {
+			delete(sdk.UpgradeMgr.Config.HeightMap, name)
+		}
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	start := time.Unix(1600000000, 0).UTC()
+	ctx = ctx.WithBlockTime(start)
+
+	pool := keeper.GetPool(ctx)
+	pool.LooseTokens = sdk.NewDec(1000)
+	pool.BondedTokens = sdk.NewDec(1000)
+	keeper.SetPool(ctx, pool)
+
+	// without a window the average follows the bonded tokens
+	keeper.UpdateBondedAverage(ctx)
+	require.Equal(t, sdk.NewDec(1000), keeper.BondedTokensAverage(ctx))
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), keeper.AverageBondedRatio(ctx))
+
+	params := keeper.GetParams(ctx)
+	params.BondedAverageWindow = 10 * time.Hour
+	keeper.SetParams(ctx, params)
+	require.Equal(t, 10*time.Hour, keeper.BondedAverageWindow(ctx))
+
+	// an unbonding spike barely moves the average
+	pool = keeper.GetPool(ctx)
+	pool.BondedTokens = sdk.NewDec(0)
+	pool.LooseTokens = sdk.NewDec(2000)
+	keeper.SetPool(ctx, pool)
+	ctx = ctx.WithBlockTime(start.Add(time.Hour))
+	keeper.UpdateBondedAverage(ctx)
+	require.Equal(t, sdk.NewDec(1000), keeper.BondedTokensAverage(ctx))
+
+	// the zero bonded tokens held for an hour weigh a tenth of the window
+	ctx = ctx.WithBlockTime(start.Add(2 * time.Hour))
+	keeper.UpdateBondedAverage(ctx)
+	require.Equal(t, sdk.NewDec(900), keeper.BondedTokensAverage(ctx))
+	require.Equal(t, sdk.NewDecWithPrec(45, 2), keeper.AverageBondedRatio(ctx))
+	require.True(t, keeper.BondedRatio(ctx).IsZero())
+
+	// an elapsed time beyond the window catches up with the bonded tokens
+	ctx = ctx.WithBlockTime(start.Add(20 * time.Hour))
+	keeper.UpdateBondedAverage(ctx)
+	require.True(t, keeper.BondedTokensAverage(ctx).IsZero())
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -25,6 +26,36 @@ func (k Keeper) GetDelegation(ctx sdk.Context,
 	return delegation, true
 }
 
+// CheckDelegationCap checks that the tokens the delegator holds with the validator after delegating
+// amount more stay within the delegation caps, the self-delegation of the validator is not capped.
+func (k Keeper) CheckDelegationCap(ctx sdk.Context, delAddr sdk.AccAddress, validator types.Validator, amount int64) sdk.Error {
+	if !sdk.IsUpgrade(sdk.DelegationCap) || bytes.Equal(validator.FeeAddr, delAddr) {
+		return nil
+	}
+
+	maxDelegation := k.MaxDelegationPerValidator(ctx)
+	maxFraction := k.MaxDelegationFraction(ctx)
+	if maxDelegation == 0 && maxFraction.IsZero() {
+		return nil
+	}
+
+	amountDec := sdk.NewDecFromInt(amount)
+	delegated := amountDec
+	if delegation, found := k.GetDelegation(ctx, delAddr, validator.OperatorAddr); found {
+		delegated = delegated.Add(validator.TokensFromShares(delegation.GetShares()))
+	}
+
+	if maxDelegation > 0 && delegated.RawInt() > maxDelegation {
+		return types.ErrDelegationCapExceeded(k.Codespace(),
+			fmt.Sprintf("a delegator can delegate at most %d to a validator", maxDelegation))
+	}
+	if maxFraction.GT(sdk.ZeroDec()) && delegated.GT(validator.Tokens.Add(amountDec).Mul(maxFraction)) {
+		return types.ErrDelegationCapExceeded(k.Codespace(),
+			fmt.Sprintf("a delegator can delegate at most %s of the tokens of a validator", maxFraction))
+	}
+	return nil
+}
+
 // return all delegations used during genesis dump
 func (k Keeper) GetAllDelegations(ctx sdk.Context) (delegations []types.Delegation) {
 	store := ctx.KVStore(k.storeKey)
@@ -111,6 +142,7 @@ func (k Keeper) SetDelegationByVal(ctx sdk.Context, delegation types.Delegation)
 // remove a delegation from store
 func (k Keeper) RemoveDelegation(ctx sdk.Context, delegation types.Delegation) {
 	k.OnDelegationRemoved(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
+	k.BeforeDelegationRemoved(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr))
 
@@ -503,8 +535,10 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 	// call the appropriate hook if present
 	if found {
 		k.OnDelegationSharesModified(ctx, delAddr, validator.OperatorAddr)
+		k.BeforeDelegationSharesModified(ctx, delAddr, validator.OperatorAddr)
 	} else {
 		k.OnDelegationCreated(ctx, delAddr, validator.OperatorAddr)
+		k.BeforeDelegationCreated(ctx, delAddr, validator.OperatorAddr)
 	}
 
 	if subtractAccount {
@@ -523,6 +557,7 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 	delegation.Shares = delegation.Shares.Add(newShares)
 	delegation.Height = ctx.BlockHeight()
 	k.SetDelegation(ctx, delegation)
+	k.AfterDelegationModified(ctx, delAddr, validator.OperatorAddr)
 	return newShares, nil
 }
 
@@ -555,6 +590,7 @@ func (k Keeper) unbond(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValA
 	}
 
 	k.OnDelegationSharesModified(ctx, delAddr, valAddr)
+	k.BeforeDelegationSharesModified(ctx, delAddr, valAddr)
 
 	// retrieve the amount to remove
 	if delegation.Shares.LT(shares) {
@@ -588,6 +624,7 @@ func (k Keeper) unbond(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValA
 		// Update height
 		delegation.Height = ctx.BlockHeight()
 		k.SetDelegation(ctx, delegation)
+		k.AfterDelegationModified(ctx, delAddr, valAddr)
 	}
 
 	// remove the coins from the validator
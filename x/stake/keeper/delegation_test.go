@@ -246,6 +246,61 @@ func TestUnbondDelegation(t *testing.T) {
 	require.Equal(t, sdk.NewDecWithoutFra(4), pool.BondedTokens)
 }
 
+// records the delegation hooks with the shares of the delegation at the time of the call
+type recordDelegationHooks struct {
+	k     *Keeper
+	calls *[]string
+}
+
+func (h recordDelegationHooks) record(ctx sdk.Context, name string, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	shares := "none"
+	if delegation, found := h.k.GetDelegation(ctx, delAddr, valAddr); found {
+		shares = delegation.Shares.String()
+	}
+	*h.calls = append(*h.calls, name+":"+shares)
+}
+
+func (h recordDelegationHooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.record(ctx, "created", delAddr, valAddr)
+}
+func (h recordDelegationHooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.record(ctx, "modifying", delAddr, valAddr)
+}
+func (h recordDelegationHooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.record(ctx, "modified", delAddr, valAddr)
+}
+func (h recordDelegationHooks) BeforeDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.record(ctx, "removed", delAddr, valAddr)
+}
+
+func TestDelegationHooks(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	var first, second []string
+	keeper = keeper.AddDelegationHooks(recordDelegationHooks{&keeper, &first}, recordDelegationHooks{&keeper, &second})
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+
+	bond := sdk.NewCoin(keeper.BondDenom(ctx), sdk.NewDecWithoutFra(10).RawInt())
+	_, err := keeper.Delegate(ctx, addrDels[0], bond, validator, false)
+	require.NoError(t, err)
+	validator = keeper.mustGetValidator(ctx, addrVals[0])
+	_, err = keeper.Delegate(ctx, addrDels[0], bond, validator, false)
+	require.NoError(t, err)
+	_, err = keeper.unbond(ctx, addrDels[0], addrVals[0], sdk.NewDecWithoutFra(5))
+	require.NoError(t, err)
+	_, err = keeper.unbond(ctx, addrDels[0], addrVals[0], sdk.NewDecWithoutFra(15))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"created:none", "modified:1000000000",
+		"modifying:1000000000", "modified:2000000000",
+		"modifying:2000000000", "modified:1500000000",
+		"modifying:1500000000", "removed:1500000000",
+	}, first)
+	require.Equal(t, first, second)
+}
+
 // test removing all self delegation from a validator which should
 // shift it from the bonded to unbonded state
 func TestUndelegateSelfDelegation(t *testing.T) {
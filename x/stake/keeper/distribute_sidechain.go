@@ -27,8 +27,10 @@ func (k Keeper) Distribute(ctx sdk.Context, sideChainId string) {
 	bondDenom := k.BondDenom(ctx)
 	var toPublish []types.DistributionData
 	for _, validator := range validators {
+		minPayout, carried, carryDust := k.shouldCarryDust(ctx, validator.OperatorAddr)
 		distAccCoins := k.bankKeeper.GetCoins(ctx, validator.DistributionAddr)
-		totalReward := distAccCoins.AmountOf(bondDenom)
+		// the rewards carried forward are not rewards of this distribution
+		totalReward := distAccCoins.AmountOf(bondDenom) - carried
 		totalRewardDec := sdk.ZeroDec()
 		commission := sdk.ZeroDec()
 		rewards := make([]types.PreReward, 0)
@@ -40,12 +42,19 @@ func (k Keeper) Distribute(ctx sdk.Context, sideChainId string) {
 			totalRewardDec = sdk.NewDec(totalReward)
 			commission = totalRewardDec.Mul(validator.Commission.Rate)
 			remainReward := totalRewardDec.Sub(commission)
-			// remove all balance of bondDenom from Distribution account
-			distAccCoins = distAccCoins.Minus(sdk.Coins{sdk.NewCoin(bondDenom, totalReward)})
-			if err := k.bankKeeper.SetCoins(ctx, validator.DistributionAddr, distAccCoins); err != nil {
-				panic(err)
-			}
 			rewards = allocate(simDelsToSharers(delegations), remainReward)
+			toRemove := totalReward
+			if carryDust {
+				rewards = k.carryForwardDust(ctx, validator.OperatorAddr, rewards, minPayout)
+				toRemove += carried - k.GetDustRewardTotal(ctx, validator.OperatorAddr)
+			}
+			// remove all balance of bondDenom but the rewards carried forward from Distribution account
+			if toRemove > 0 {
+				distAccCoins = distAccCoins.Minus(sdk.Coins{sdk.NewCoin(bondDenom, toRemove)})
+				if err := k.bankKeeper.SetCoins(ctx, validator.DistributionAddr, distAccCoins); err != nil {
+					panic(err)
+				}
+			}
 			if commission.RawInt() > 0 { // assign rewards to self-delegator
 				if _, _, err := k.bankKeeper.AddCoins(ctx, validator.GetFeeAddr(), sdk.Coins{sdk.NewCoin(bondDenom, commission.RawInt())}); err != nil {
 					panic(err)
@@ -54,10 +63,7 @@ func (k Keeper) Distribute(ctx sdk.Context, sideChainId string) {
 			// assign rewards to delegator
 			changedAddrs := make([]sdk.AccAddress, len(rewards)+1)
 			for i := range rewards {
-				if _, _, err := k.bankKeeper.AddCoins(ctx, rewards[i].AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, rewards[i].Amount)}); err != nil {
-					panic(err)
-				}
-				changedAddrs[i] = rewards[i].AccAddr
+				changedAddrs[i] = k.payReward(ctx, rewards[i].AccAddr, validator.OperatorAddr, sdk.NewCoin(bondDenom, rewards[i].Amount))
 			}
 
 			changedAddrs[len(rewards)] = validator.DistributionAddr
@@ -128,8 +134,10 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) {
 
 	bondDenom := k.BondDenom(ctx)
 	for _, validator := range validators {
+		minPayout, carried, carryDust := k.shouldCarryDust(ctx, validator.OperatorAddr)
 		distAccCoins := k.bankKeeper.GetCoins(ctx, validator.DistributionAddr)
-		totalReward := distAccCoins.AmountOf(bondDenom)
+		// the rewards carried forward are not rewards of this distribution
+		totalReward := distAccCoins.AmountOf(bondDenom) - carried
 		totalRewardDec := sdk.ZeroDec()
 		commission := sdk.ZeroDec()
 		rewards := make([]types.PreReward, 0)
@@ -154,6 +162,10 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) {
 			//calculate rewards for delegators
 			remainReward := totalRewardDec.Sub(commission)
 			rewards = allocate(simDelsToSharers(delegations), remainReward)
+			if carryDust {
+				// the rewards carried forward stay in the distribution address
+				rewards = k.carryForwardDust(ctx, validator.OperatorAddr, rewards, minPayout)
+			}
 			for i := range rewards {
 				// previous tokens calculation is in `node` repo, move it to here
 				tokens, err := sdk.MulQuoDec(validator.GetTokens(), rewards[i].Shares, validator.GetDelegatorShares())
@@ -264,12 +276,10 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) {
 			distAddrBalanceMap[distAddr.String()] = reward.Amount
 		}
 
-		if _, _, err := k.bankKeeper.AddCoins(ctx, reward.AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, reward.Amount)}); err != nil {
-			panic(err)
-		}
+		paidAddr := k.payReward(ctx, reward.AccAddr, reward.ValAddr, sdk.NewCoin(bondDenom, reward.Amount))
 
 		toPublishRewards = append(toPublishRewards, reward)
-		changedAddrs = append(changedAddrs, reward.AccAddr)
+		changedAddrs = append(changedAddrs, paidAddr)
 	}
 
 	for addr, value := range distAddrBalanceMap {
@@ -269,3 +269,60 @@ func TestDistributeInBlock(t *testing.T) {
 	_, found := k.getRewardValDistAddrs(ctx)
 	require.True(t, !found)
 }
+
+func TestDistributeDust(t *testing.T) {
+	ctx, am, k := CreateTestInput(t, false, 0)
+	k.addrPool = new(sdk.Pool)
+	bondDenom := k.BondDenom(ctx)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.MinRewardPayout, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.MinRewardPayout)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	k.paramstore.Set(ctx, types.KeyMinRewardPayout, int64(2e8))
+
+	height := int64(1000)
+	valAddr := sdk.ValAddress(PKs[0].Address().Bytes())
+	validator := types.NewValidator(valAddr, PKs[0], types.Description{})
+	validator.DistributionAddr = Addrs[499]
+	validator.FeeAddr = Addrs[498]
+	small, big := Addrs[497], Addrs[496]
+	simDels := []types.SimplifiedDelegation{
+		{DelegatorAddr: small, Shares: sdk.NewDec(1e8)},
+		{DelegatorAddr: big, Shares: sdk.NewDec(999e8)},
+	}
+	validator.DelegatorShares = sdk.NewDec(1000e8)
+	validator.Tokens = sdk.NewDec(1000e8)
+
+	balanceOf := func(addr sdk.AccAddress) int64 {
+		return am.GetAccount(ctx, addr).GetCoins().AmountOf(bondDenom)
+	}
+	smallBalance, bigBalance := balanceOf(small), balanceOf(big)
+	distribute := func(reward int64) {
+		k.SetSimplifiedDelegations(ctx, height, valAddr, simDels)
+		k.SetValidatorsByHeight(ctx, height, []types.Validator{validator})
+		k.SetValidatorsByHeight(ctx, height+1000, make([]types.Validator, 0))
+		k.SetValidatorsByHeight(ctx, height+2000, make([]types.Validator, 0))
+		_, _, err := k.bankKeeper.AddCoins(ctx, validator.DistributionAddr, sdk.Coins{sdk.NewCoin(bondDenom, reward)})
+		require.NoError(t, err)
+		k.Distribute(ctx, "")
+	}
+
+	// the reward of the small delegator is below the threshold and carried forward
+	distribute(1000e8)
+	require.Equal(t, int64(1e8), k.GetDustReward(ctx, valAddr, small))
+	require.Equal(t, int64(1e8), k.GetDustRewardTotal(ctx, valAddr))
+	require.Equal(t, int64(1e8), balanceOf(validator.DistributionAddr))
+	require.Equal(t, smallBalance, balanceOf(small))
+	require.Equal(t, bigBalance+999e8, balanceOf(big))
+
+	// the carried reward is added to the next one and paid out
+	distribute(1000e8)
+	require.Equal(t, int64(0), k.GetDustReward(ctx, valAddr, small))
+	require.Equal(t, int64(0), k.GetDustRewardTotal(ctx, valAddr))
+	require.Equal(t, int64(0), balanceOf(validator.DistributionAddr))
+	require.Equal(t, smallBalance+2e8, balanceOf(small))
+	require.Equal(t, bigBalance+2*999e8, balanceOf(big))
+}
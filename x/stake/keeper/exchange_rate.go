@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// MaxExchangeRateHistory is the number of exchange rate changes kept per validator
+const MaxExchangeRateHistory = 100
+
+// record the exchange rate of the validator if it changed since the last record
+func (k Keeper) recordExchangeRate(ctx sdk.Context, validator types.Validator) {
+	if !sdk.IsUpgrade(sdk.ExchangeRateHistory) {
+		return
+	}
+	rate := validator.DelegatorShareExRate()
+	if last, found := k.GetLastExchangeRate(ctx, validator.OperatorAddr); found && last.Rate.Equal(rate) {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetValidatorExchangeRateKey(validator.OperatorAddr, ctx.BlockHeight()), k.cdc.MustMarshalBinaryLengthPrefixed(rate))
+
+	// prune the oldest records
+	iterator := sdk.KVStoreReversePrefixIterator(store, GetValidatorExchangeRatesKey(validator.OperatorAddr))
+	defer iterator.Close()
+	var expired [][]byte
+	for count := 0; iterator.Valid(); iterator.Next() {
+		count++
+		if count > MaxExchangeRateHistory {
+			expired = append(expired, iterator.Key())
+		}
+	}
+	for _, key := range expired {
+		store.Delete(key)
+	}
+}
+
+// GetLastExchangeRate returns the latest recorded exchange rate of the validator
+func (k Keeper) GetLastExchangeRate(ctx sdk.Context, operatorAddr sdk.ValAddress) (rate types.ExchangeRate, found bool) {
+	return k.GetExchangeRateAtHeight(ctx, operatorAddr, -1)
+}
+
+// GetExchangeRateAtHeight returns the exchange rate of the validator in effect at height,
+// a negative height returns the latest one. Heights older than the kept history are not found.
+func (k Keeper) GetExchangeRateAtHeight(ctx sdk.Context, operatorAddr sdk.ValAddress, height int64) (rate types.ExchangeRate, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := GetValidatorExchangeRatesKey(operatorAddr)
+	end := sdk.PrefixEndBytes(prefix)
+	if height >= 0 {
+		end = GetValidatorExchangeRateKey(operatorAddr, height+1)
+	}
+	iterator := store.ReverseIterator(prefix, end)
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return rate, false
+	}
+	return k.unmarshalExchangeRate(iterator.Key(), iterator.Value()), true
+}
+
+// GetExchangeRateHistory returns the recorded exchange rates of the validator, oldest first
+func (k Keeper) GetExchangeRateHistory(ctx sdk.Context, operatorAddr sdk.ValAddress) (rates []types.ExchangeRate) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorExchangeRatesKey(operatorAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		rates = append(rates, k.unmarshalExchangeRate(iterator.Key(), iterator.Value()))
+	}
+	return rates
+}
+
+func (k Keeper) deleteExchangeRateHistory(ctx sdk.Context, operatorAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorExchangeRatesKey(operatorAddr))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) unmarshalExchangeRate(key, value []byte) (rate types.ExchangeRate) {
+	rate.Height = int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &rate.Rate)
+	return rate
+}
@@ -70,3 +70,27 @@ func (k Keeper) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, val
 		k.hooks.OnDelegationRemoved(ctx, delAddr, valAddr)
 	}
 }
+
+func (k Keeper) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for _, h := range k.delHooks {
+		h.BeforeDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+
+func (k Keeper) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for _, h := range k.delHooks {
+		h.BeforeDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+
+func (k Keeper) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for _, h := range k.delHooks {
+		h.AfterDelegationModified(ctx, delAddr, valAddr)
+	}
+}
+
+func (k Keeper) BeforeDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	for _, h := range k.delHooks {
+		h.BeforeDelegationRemoved(ctx, delAddr, valAddr)
+	}
+}
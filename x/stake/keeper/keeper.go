@@ -26,6 +26,8 @@ type Keeper struct {
 	bankKeeper     bank.Keeper
 	addrPool       *sdk.Pool
 	hooks          sdk.StakingHooks
+	delHooks       []sdk.DelegationHooks
+	modDelegators  map[string]ModuleDelegator
 	paramstore     params.Subspace
 
 	// codespace
@@ -36,6 +38,9 @@ type Keeper struct {
 	ibcKeeper *ibc.Keeper
 	ScKeeper  *sidechain.Keeper
 
+	// optional, burns the slashed tokens from sdk.SupplyMintBurn on
+	supplyKeeper *bank.SupplyKeeper
+
 	PbsbServer *pubsub.Server
 }
 
@@ -68,10 +73,17 @@ func (k Keeper) initIbc() {
 
 func (k *Keeper) SetupForSideChain(scKeeper *sidechain.Keeper, ibcKeeper *ibc.Keeper) {
 	k.ScKeeper = scKeeper
-	k.ibcKeeper = ibcKeeper
+	moduleIbcKeeper := ibcKeeper.ForModule(types.MsgRoute)
+	k.ibcKeeper = &moduleIbcKeeper
 	k.initIbc()
 }
 
+// SetSupplyKeeper sets the supply keeper burning the slashed tokens, the delegation account has to be
+// registered as the burner module account of types.MsgRoute
+func (k *Keeper) SetSupplyKeeper(supplyKeeper *bank.SupplyKeeper) {
+	k.supplyKeeper = supplyKeeper
+}
+
 func (k *Keeper) SetPbsbServer(server *pubsub.Server) {
 	k.PbsbServer = server
 }
@@ -90,6 +102,12 @@ func (k Keeper) WithHooks(sh sdk.StakingHooks) Keeper {
 	return k
 }
 
+// Add delegation hooks, called in the order they are added
+func (k Keeper) AddDelegationHooks(dh ...sdk.DelegationHooks) Keeper {
+	k.delHooks = append(append([]sdk.DelegationHooks{}, k.delHooks...), dh...)
+	return k
+}
+
 //_________________________________________________________________________
 
 // return the codespace
@@ -17,6 +17,7 @@ var (
 	// ParamKey                         = []byte{0x00} // key for parameters relating to staking
 	PoolKey           = []byte{0x01} // key for the staking pools
 	IntraTxCounterKey = []byte{0x02} // key for intra-block tx index
+	BondedAverageKey  = []byte{0x03} // key for the time-weighted average of the bonded tokens
 
 	// Last* values are const during a block.
 	LastValidatorPowerKey = []byte{0x11} // prefix for each key to a validator index, for bonded validators
@@ -26,6 +27,7 @@ var (
 	ValidatorsByConsAddrKey   = []byte{0x22} // prefix for each key to a validator index, by pubkey
 	ValidatorsByPowerIndexKey = []byte{0x23} // prefix for each key to a validator index, sorted by power
 	ValidatorsByHeightKey     = []byte{0x24} // prefix for each key to a validator index, by height
+	ValidatorExchangeRateKey  = []byte{0x25} // prefix for each key to a validator exchange rate, by operator and height
 
 	DelegationKey                    = []byte{0x31} // key for a delegation
 	UnbondingDelegationKey           = []byte{0x32} // key for an unbonding-delegation
@@ -35,6 +37,8 @@ var (
 	RedelegationByValDstIndexKey     = []byte{0x36} // prefix for each key for an redelegation, by destination validator operator
 	DelegationKeyByVal               = []byte{0x37} // prefix for each key for a delegation, by validator operator and delegator
 	SimplifiedDelegationsKey         = []byte{0x38} // prefix for each key for an simplifiedDelegations, by height and validator operator
+	DustRewardKey                    = []byte{0x39} // prefix for each key for a reward carried forward, by validator operator and delegator
+	DustRewardTotalKey               = []byte{0x3A} // prefix for each key for the total rewards carried forward, by validator operator
 
 	UnbondingQueueKey    = []byte{0x41} // prefix for the timestamps in unbonding queue
 	RedelegationQueueKey = []byte{0x42} // prefix for the timestamps in redelegations queue
@@ -143,6 +147,19 @@ func GetValidatorHeightKey(height int64) []byte {
 	return append(ValidatorsByHeightKey, bz...)
 }
 
+// gets the prefix for the exchange rate history of a validator
+func GetValidatorExchangeRatesKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ValidatorExchangeRateKey, operatorAddr.Bytes()...)
+}
+
+// gets the key for the exchange rate of a validator which took effect at height
+// VALUE: sdk.Dec
+func GetValidatorExchangeRateKey(operatorAddr sdk.ValAddress, height int64) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, uint64(height))
+	return append(GetValidatorExchangeRatesKey(operatorAddr), heightBytes...)
+}
+
 // gets the prefix for all unbonding delegations from a delegator
 func GetValidatorQueueTimeKey(timestamp time.Time) []byte {
 	bz := sdk.FormatTimeBytes(timestamp)
@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// ModuleDelegator describes a module (e.g. liquid staking) that delegates from its own account.
+// Rewards of its delegations are paid to RewardAddr, or to the module account if it is empty,
+// and Hooks, if set, is notified of every payment.
+type ModuleDelegator struct {
+	Module     string
+	RewardAddr sdk.AccAddress
+	Hooks      sdk.ModuleDelegatorHooks
+}
+
+// ModuleDelegatorAddress returns the account a module delegates from.
+func ModuleDelegatorAddress(module string) sdk.AccAddress {
+	return sdk.ModuleAddress(module, "delegator")
+}
+
+// Register a module which is allowed to delegate from its own account
+func (k Keeper) AddModuleDelegator(md ModuleDelegator) Keeper {
+	delAddr := ModuleDelegatorAddress(md.Module)
+	if _, ok := k.modDelegators[string(delAddr)]; ok {
+		panic(fmt.Sprintf("module delegator %s registered twice", md.Module))
+	}
+	modDelegators := make(map[string]ModuleDelegator, len(k.modDelegators)+1)
+	for addr, d := range k.modDelegators {
+		modDelegators[addr] = d
+	}
+	modDelegators[string(delAddr)] = md
+	k.modDelegators = modDelegators
+	return k
+}
+
+// GetModuleDelegator returns the registered module delegating from delAddr, if any
+func (k Keeper) GetModuleDelegator(delAddr sdk.AccAddress) (ModuleDelegator, bool) {
+	md, ok := k.modDelegators[string(delAddr)]
+	return md, ok
+}
+
+// IterateModuleDelegators iterates over the registered module delegators, in no particular order
+func (k Keeper) IterateModuleDelegators(fn func(delAddr sdk.AccAddress, md ModuleDelegator) (stop bool)) {
+	for addr, md := range k.modDelegators {
+		if fn(sdk.AccAddress(addr), md) {
+			return
+		}
+	}
+}
+
+func (k Keeper) moduleDelegatorAddress(module string) (sdk.AccAddress, sdk.Error) {
+	delAddr := ModuleDelegatorAddress(module)
+	if _, ok := k.modDelegators[string(delAddr)]; !ok {
+		return nil, types.ErrModuleDelegatorNotRegistered(k.Codespace(), module)
+	}
+	return delAddr, nil
+}
+
+// DelegateFromModule delegates amount from the account of a registered module to a validator,
+// with the same checks as a MsgDelegate.
+func (k Keeper) DelegateFromModule(ctx sdk.Context, module string, valAddr sdk.ValAddress, amount sdk.Coin) (sdk.Dec, sdk.Error) {
+	delAddr, err := k.moduleDelegatorAddress(module)
+	if err != nil {
+		return sdk.ZeroDec(), err
+	}
+
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return sdk.ZeroDec(), types.ErrNoValidatorFound(k.Codespace())
+	}
+	if amount.Denom != k.BondDenom(ctx) {
+		return sdk.ZeroDec(), types.ErrBadDenom(k.Codespace())
+	}
+	if amount.Amount <= 0 {
+		return sdk.ZeroDec(), types.ErrBadDelegationAmount(k.Codespace(), "amount must be > 0")
+	}
+	if validator.Jailed {
+		return sdk.ZeroDec(), types.ErrValidatorJailed(k.Codespace())
+	}
+
+	return k.Delegate(ctx, delAddr, amount, validator, true)
+}
+
+// UndelegateFromModule starts unbonding shares of a registered module from a validator,
+// the tokens are returned to the module account once the unbonding completes.
+func (k Keeper) UndelegateFromModule(ctx sdk.Context, module string, valAddr sdk.ValAddress, shares sdk.Dec) (types.UnbondingDelegation, sdk.Error) {
+	delAddr, err := k.moduleDelegatorAddress(module)
+	if err != nil {
+		return types.UnbondingDelegation{}, err
+	}
+	if !shares.GT(sdk.ZeroDec()) {
+		return types.UnbondingDelegation{}, types.ErrBadSharesAmount(k.Codespace())
+	}
+
+	return k.BeginUnbonding(ctx, delAddr, valAddr, shares)
+}
+
+// payReward pays a delegation reward, routing the rewards of module delegations to the reward address
+// of the module. It returns the address which has been paid.
+func (k Keeper) payReward(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, reward sdk.Coin) sdk.AccAddress {
+	md, isModule := k.modDelegators[string(delAddr)]
+	rewardAddr := delAddr
+	if isModule && !md.RewardAddr.Empty() {
+		rewardAddr = md.RewardAddr
+	}
+	if _, _, err := k.bankKeeper.AddCoins(ctx, rewardAddr, sdk.Coins{reward}); err != nil {
+		panic(err)
+	}
+	if isModule && md.Hooks != nil {
+		md.Hooks.AfterModuleRewardPaid(ctx, md.Module, valAddr, rewardAddr, reward.Amount)
+	}
+	return rewardAddr
+}
+
+// CheckModuleDelegations checks that every delegation of a registered module is to an existing
+// validator, has positive shares, and does not exceed the shares of the validator.
+func (k Keeper) CheckModuleDelegations(ctx sdk.Context) error {
+	var err error
+	k.IterateModuleDelegators(func(delAddr sdk.AccAddress, md ModuleDelegator) bool {
+		for _, delegation := range k.GetAllDelegatorDelegations(ctx, delAddr) {
+			validator, found := k.GetValidator(ctx, delegation.ValidatorAddr)
+			if !found {
+				err = fmt.Errorf("delegation of module %s to unknown validator %s", md.Module, delegation.ValidatorAddr)
+				return true
+			}
+			if !delegation.Shares.GT(sdk.ZeroDec()) {
+				err = fmt.Errorf("delegation of module %s to validator %s has non-positive shares %v",
+					md.Module, delegation.ValidatorAddr, delegation.Shares)
+				return true
+			}
+			if delegation.Shares.GT(validator.DelegatorShares) {
+				err = fmt.Errorf("delegation of module %s to validator %s has more shares (%v) than the validator (%v)",
+					md.Module, delegation.ValidatorAddr, delegation.Shares, validator.DelegatorShares)
+				return true
+			}
+		}
+		return false
+	})
+	return err
+}
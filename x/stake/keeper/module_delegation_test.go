@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+type recordRewardHooks struct {
+	paid *[]int64
+}
+
+func (h recordRewardHooks) AfterModuleRewardPaid(ctx sdk.Context, module string, valAddr sdk.ValAddress, rewardAddr sdk.AccAddress, amount int64) {
+	*h.paid = append(*h.paid, amount)
+}
+
+func TestModuleDelegation(t *testing.T) {
+	ctx, am, k := CreateTestInput(t, false, 0)
+	bondDenom := k.BondDenom(ctx)
+	balanceOf := func(addr sdk.AccAddress) int64 {
+		acc := am.GetAccount(ctx, addr)
+		if acc == nil {
+			return 0
+		}
+		return acc.GetCoins().AmountOf(bondDenom)
+	}
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator = TestingUpdateValidator(k, ctx, validator)
+	bond := sdk.NewCoin(bondDenom, 10e8)
+
+	// unregistered modules can not delegate
+	_, err := k.DelegateFromModule(ctx, "liquid", addrVals[0], bond)
+	require.Error(t, err)
+
+	var paid []int64
+	rewardAddr := Addrs[499]
+	k = k.AddModuleDelegator(ModuleDelegator{Module: "liquid", RewardAddr: rewardAddr, Hooks: recordRewardHooks{&paid}})
+	delAddr := ModuleDelegatorAddress("liquid")
+	require.Panics(t, func() { k.AddModuleDelegator(ModuleDelegator{Module: "liquid"}) })
+
+	_, err = k.DelegateFromModule(ctx, "liquid", addrVals[0], bond)
+	require.Error(t, err, "the module account has no balance")
+	_, _, err = k.bankKeeper.AddCoins(ctx, delAddr, sdk.Coins{sdk.NewCoin(bondDenom, 20e8)})
+	require.NoError(t, err)
+	_, err = k.DelegateFromModule(ctx, "liquid", addrVals[0], sdk.NewCoin("foo", 10e8))
+	require.Error(t, err)
+	_, err = k.DelegateFromModule(ctx, "liquid", addrVals[1], bond)
+	require.Error(t, err)
+
+	shares, err := k.DelegateFromModule(ctx, "liquid", addrVals[0], bond)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(10e8), shares)
+	require.Equal(t, int64(10e8), balanceOf(delAddr))
+	delegation, found := k.GetDelegation(ctx, delAddr, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, shares, delegation.Shares)
+	require.NoError(t, k.CheckModuleDelegations(ctx))
+
+	// rewards of the module are routed to its reward address
+	height := int64(1000)
+	validator = k.mustGetValidator(ctx, addrVals[0])
+	validator.DistributionAddr = Addrs[498]
+	k.SetSimplifiedDelegations(ctx, height, addrVals[0], []types.SimplifiedDelegation{{DelegatorAddr: delAddr, Shares: shares}})
+	k.SetValidatorsByHeight(ctx, height, []types.Validator{validator})
+	k.SetValidatorsByHeight(ctx, height+1000, make([]types.Validator, 0))
+	k.SetValidatorsByHeight(ctx, height+2000, make([]types.Validator, 0))
+	_, _, err = k.bankKeeper.AddCoins(ctx, validator.DistributionAddr, sdk.Coins{sdk.NewCoin(bondDenom, 5e8)})
+	require.NoError(t, err)
+	rewardBalance := balanceOf(rewardAddr)
+	k.Distribute(ctx, "")
+	require.Equal(t, []int64{5e8}, paid)
+	require.Equal(t, rewardBalance+5e8, balanceOf(rewardAddr))
+	require.Equal(t, int64(10e8), balanceOf(delAddr))
+
+	// the tokens are returned to the module account once the unbonding completes
+	_, err = k.UndelegateFromModule(ctx, "liquid", addrVals[0], sdk.ZeroDec())
+	require.Error(t, err)
+	ubd, err := k.UndelegateFromModule(ctx, "liquid", addrVals[0], shares)
+	require.NoError(t, err)
+	require.Equal(t, bond, ubd.Balance)
+	_, found = k.GetDelegation(ctx, delAddr, addrVals[0])
+	require.False(t, found)
+	_, err = k.CompleteUnbonding(ctx, delAddr, addrVals[0])
+	require.NoError(t, err)
+	require.Equal(t, int64(20e8), balanceOf(delAddr))
+	require.NoError(t, k.CheckModuleDelegations(ctx))
+}
@@ -56,6 +56,29 @@ func (k Keeper) RewardDistributionBatchSize(ctx sdk.Context) (res int64) {
 	return
 }
 
+func (k Keeper) MinRewardPayout(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyMinRewardPayout, &res)
+	return
+}
+
+// BondedAverageWindow - the window of the time-weighted average of the bonded tokens
+func (k Keeper) BondedAverageWindow(ctx sdk.Context) (res time.Duration) {
+	k.paramstore.GetIfExists(ctx, types.KeyBondedAverageWindow, &res)
+	return
+}
+
+// MaxDelegationPerValidator - the max tokens a delegator may delegate to a validator, 0 for no cap
+func (k Keeper) MaxDelegationPerValidator(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyMaxDelegationPerValidator, &res)
+	return
+}
+
+// MaxDelegationFraction - the max fraction of the tokens of a validator delegated by a delegator, 0 for no cap
+func (k Keeper) MaxDelegationFraction(ctx sdk.Context) (res sdk.Dec) {
+	k.paramstore.GetIfExists(ctx, types.KeyMaxDelegationFraction, &res)
+	return
+}
+
 // Get all parameters as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.UnbondingTime = k.UnbondingTime(ctx)
@@ -64,6 +87,10 @@ func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.MinSelfDelegation = k.MinSelfDelegation(ctx)
 	res.MinDelegationChange = k.MinDelegationChange(ctx)
 	res.RewardDistributionBatchSize = k.RewardDistributionBatchSize(ctx)
+	res.MinRewardPayout = k.MinRewardPayout(ctx)
+	res.BondedAverageWindow = k.BondedAverageWindow(ctx)
+	res.MaxDelegationPerValidator = k.MaxDelegationPerValidator(ctx)
+	res.MaxDelegationFraction = k.MaxDelegationFraction(ctx)
 	return
 }
 
@@ -105,6 +132,82 @@ func (p *paramBeforeBEP128Upgrade) KeyValuePairs() params.KeyValuePairs {
 	}
 }
 
+// in order to be compatible with before
+type paramBeforeMinRewardPayoutUpgrade struct {
+	UnbondingTime time.Duration `json:"unbonding_time"`
+
+	MaxValidators               uint16 `json:"max_validators"`                 // maximum number of validators
+	BondDenom                   string `json:"bond_denom"`                     // bondable coin denomination
+	MinSelfDelegation           int64  `json:"min_self_delegation"`            // the minimal self-delegation amount
+	MinDelegationChange         int64  `json:"min_delegation_change"`          // the minimal delegation amount changed
+	RewardDistributionBatchSize int64  `json:"reward_distribution_batch_size"` // the batch size for distributing rewards in blocks
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeMinRewardPayoutUpgrade) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{types.KeyUnbondingTime, &p.UnbondingTime},
+		{types.KeyMaxValidators, &p.MaxValidators},
+		{types.KeyBondDenom, &p.BondDenom},
+		{types.KeyMinSelfDelegation, &p.MinSelfDelegation},
+		{types.KeyMinDelegationChange, &p.MinDelegationChange},
+		{types.KeyRewardDistributionBatchSize, &p.RewardDistributionBatchSize},
+	}
+}
+
+// in order to be compatible with before
+type paramBeforeBondedTokensAverageUpgrade struct {
+	UnbondingTime time.Duration `json:"unbonding_time"`
+
+	MaxValidators               uint16 `json:"max_validators"`                 // maximum number of validators
+	BondDenom                   string `json:"bond_denom"`                     // bondable coin denomination
+	MinSelfDelegation           int64  `json:"min_self_delegation"`            // the minimal self-delegation amount
+	MinDelegationChange         int64  `json:"min_delegation_change"`          // the minimal delegation amount changed
+	RewardDistributionBatchSize int64  `json:"reward_distribution_batch_size"` // the batch size for distributing rewards in blocks
+	MinRewardPayout             int64  `json:"min_reward_payout"`              // the rewards below are carried forward to the next distribution
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeBondedTokensAverageUpgrade) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{types.KeyUnbondingTime, &p.UnbondingTime},
+		{types.KeyMaxValidators, &p.MaxValidators},
+		{types.KeyBondDenom, &p.BondDenom},
+		{types.KeyMinSelfDelegation, &p.MinSelfDelegation},
+		{types.KeyMinDelegationChange, &p.MinDelegationChange},
+		{types.KeyRewardDistributionBatchSize, &p.RewardDistributionBatchSize},
+		{types.KeyMinRewardPayout, &p.MinRewardPayout},
+	}
+}
+
+// in order to be compatible with before
+type paramBeforeDelegationCapUpgrade struct {
+	UnbondingTime time.Duration `json:"unbonding_time"`
+
+	MaxValidators               uint16 `json:"max_validators"`                 // maximum number of validators
+	BondDenom                   string `json:"bond_denom"`                     // bondable coin denomination
+	MinSelfDelegation           int64  `json:"min_self_delegation"`            // the minimal self-delegation amount
+	MinDelegationChange         int64  `json:"min_delegation_change"`          // the minimal delegation amount changed
+	RewardDistributionBatchSize int64  `json:"reward_distribution_batch_size"` // the batch size for distributing rewards in blocks
+	MinRewardPayout             int64  `json:"min_reward_payout"`              // the rewards below are carried forward to the next distribution
+
+	BondedAverageWindow time.Duration `json:"bonded_average_window"` // the window of the time-weighted average of the bonded tokens
+}
+
+// Implements params.ParamSet
+func (p *paramBeforeDelegationCapUpgrade) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{types.KeyUnbondingTime, &p.UnbondingTime},
+		{types.KeyMaxValidators, &p.MaxValidators},
+		{types.KeyBondDenom, &p.BondDenom},
+		{types.KeyMinSelfDelegation, &p.MinSelfDelegation},
+		{types.KeyMinDelegationChange, &p.MinDelegationChange},
+		{types.KeyRewardDistributionBatchSize, &p.RewardDistributionBatchSize},
+		{types.KeyMinRewardPayout, &p.MinRewardPayout},
+		{types.KeyBondedAverageWindow, &p.BondedAverageWindow},
+	}
+}
+
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	sdk.Upgrade(sdk.LaunchBscUpgrade, func() {
@@ -125,7 +228,46 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 
 			k.paramstore.SetParamSet(ctx, &pb)
 		}, nil, func() {
-			k.paramstore.SetParamSet(ctx, &params)
+			sdk.Upgrade(sdk.MinRewardPayout, func() {
+				var pb paramBeforeMinRewardPayoutUpgrade
+				pb.UnbondingTime = params.UnbondingTime
+				pb.MaxValidators = params.MaxValidators
+				pb.BondDenom = params.BondDenom
+				pb.MinSelfDelegation = params.MinSelfDelegation
+				pb.MinDelegationChange = params.MinDelegationChange
+				pb.RewardDistributionBatchSize = params.RewardDistributionBatchSize
+
+				k.paramstore.SetParamSet(ctx, &pb)
+			}, nil, func() {
+				sdk.Upgrade(sdk.BondedTokensAverage, func() {
+					var pb paramBeforeBondedTokensAverageUpgrade
+					pb.UnbondingTime = params.UnbondingTime
+					pb.MaxValidators = params.MaxValidators
+					pb.BondDenom = params.BondDenom
+					pb.MinSelfDelegation = params.MinSelfDelegation
+					pb.MinDelegationChange = params.MinDelegationChange
+					pb.RewardDistributionBatchSize = params.RewardDistributionBatchSize
+					pb.MinRewardPayout = params.MinRewardPayout
+
+					k.paramstore.SetParamSet(ctx, &pb)
+				}, nil, func() {
+					sdk.Upgrade(sdk.DelegationCap, func() {
+						var pb paramBeforeDelegationCapUpgrade
+						pb.UnbondingTime = params.UnbondingTime
+						pb.MaxValidators = params.MaxValidators
+						pb.BondDenom = params.BondDenom
+						pb.MinSelfDelegation = params.MinSelfDelegation
+						pb.MinDelegationChange = params.MinDelegationChange
+						pb.RewardDistributionBatchSize = params.RewardDistributionBatchSize
+						pb.MinRewardPayout = params.MinRewardPayout
+						pb.BondedAverageWindow = params.BondedAverageWindow
+
+						k.paramstore.SetParamSet(ctx, &pb)
+					}, nil, func() {
+						k.paramstore.SetParamSet(ctx, &params)
+					})
+				})
+			})
 		})
 	})
 }
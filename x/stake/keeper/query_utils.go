@@ -137,3 +137,25 @@ func (k Keeper) GetAllUnJailValidatorsCount(ctx sdk.Context) (count int64) {
 	}
 	return count
 }
+
+// return the total balance of unbonding delegations in denom, across the main chain and all side chains
+func (k Keeper) GetTotalUnbondingBalance(ctx sdk.Context, denom string) int64 {
+	total := k.getUnbondingBalance(ctx, denom)
+	if k.ScKeeper != nil {
+		_, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
+		for i := range storePrefixes {
+			total += k.getUnbondingBalance(ctx.WithSideChainKeyPrefix(storePrefixes[i]), denom)
+		}
+	}
+	return total
+}
+
+func (k Keeper) getUnbondingBalance(ctx sdk.Context, denom string) (total int64) {
+	k.IterateUnbondingDelegations(ctx, func(_ int64, ubd types.UnbondingDelegation) (stop bool) {
+		if ubd.Balance.Denom == denom {
+			total += ubd.Balance.Amount
+		}
+		return false
+	})
+	return total
+}
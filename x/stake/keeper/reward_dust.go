@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// The rewards below MinRewardPayout are not paid but carried forward: they stay in the distribution
+// address of the validator and are added to the next rewards of the delegator.
+
+// gets the prefix for the rewards carried forward of a validator
+func GetDustRewardsKey(valAddr sdk.ValAddress) []byte {
+	return append(DustRewardKey, valAddr.Bytes()...)
+}
+
+// gets the key for the reward carried forward of a delegator of a validator
+// VALUE: int64
+func GetDustRewardKey(valAddr sdk.ValAddress, delAddr sdk.AccAddress) []byte {
+	return append(GetDustRewardsKey(valAddr), delAddr.Bytes()...)
+}
+
+// gets the key for the total rewards carried forward of a validator
+// VALUE: int64
+func GetDustRewardTotalKey(valAddr sdk.ValAddress) []byte {
+	return append(DustRewardTotalKey, valAddr.Bytes()...)
+}
+
+func getInt64(store sdk.KVStore, key []byte) int64 {
+	bz := store.Get(key)
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+func setInt64(store sdk.KVStore, key []byte, value int64) {
+	if value == 0 {
+		store.Delete(key)
+		return
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(value))
+	store.Set(key, bz)
+}
+
+// GetDustReward returns the reward carried forward for the delegator of the validator
+func (k Keeper) GetDustReward(ctx sdk.Context, valAddr sdk.ValAddress, delAddr sdk.AccAddress) int64 {
+	return getInt64(ctx.KVStore(k.storeKey), GetDustRewardKey(valAddr, delAddr))
+}
+
+// GetDustRewardTotal returns the rewards carried forward for all the delegators of the validator,
+// they are part of the balance of its distribution address
+func (k Keeper) GetDustRewardTotal(ctx sdk.Context, valAddr sdk.ValAddress) int64 {
+	return getInt64(ctx.KVStore(k.storeKey), GetDustRewardTotalKey(valAddr))
+}
+
+// shouldCarryDust returns the rewards carried forward of the validator, and whether the distribution
+// needs carryForwardDust: either the threshold is set or some rewards are still carried forward
+func (k Keeper) shouldCarryDust(ctx sdk.Context, valAddr sdk.ValAddress) (minPayout, carried int64, carry bool) {
+	if !sdk.IsUpgrade(sdk.MinRewardPayout) {
+		return 0, 0, false
+	}
+	minPayout = k.MinRewardPayout(ctx)
+	carried = k.GetDustRewardTotal(ctx, valAddr)
+	return minPayout, carried, minPayout > 0 || carried > 0
+}
+
+// carryForwardDust adds the rewards carried forward to the new rewards of the delegators, and carries
+// forward again the ones still below minPayout. The delegators with a reward carried forward but no
+// new reward, e.g. after undelegating, are paid off. Returns the rewards to pay.
+func (k Keeper) carryForwardDust(ctx sdk.Context, valAddr sdk.ValAddress, rewards []types.PreReward, minPayout int64) []types.PreReward {
+	store := ctx.KVStore(k.storeKey)
+
+	var carriedAddrs []sdk.AccAddress
+	carried := make(map[string]int64)
+	prefix := GetDustRewardsKey(valAddr)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		delAddr := sdk.AccAddress(iterator.Key()[len(prefix):])
+		carriedAddrs = append(carriedAddrs, delAddr)
+		carried[string(delAddr)] = int64(binary.BigEndian.Uint64(iterator.Value()))
+	}
+	iterator.Close()
+
+	var total int64
+	payouts := make([]types.PreReward, 0, len(rewards))
+	for _, reward := range rewards {
+		amount := reward.Amount + carried[string(reward.AccAddr)]
+		delete(carried, string(reward.AccAddr))
+		if amount >= minPayout {
+			reward.Amount = amount
+			payouts = append(payouts, reward)
+			setInt64(store, GetDustRewardKey(valAddr, reward.AccAddr), 0)
+		} else {
+			setInt64(store, GetDustRewardKey(valAddr, reward.AccAddr), amount)
+			total += amount
+		}
+	}
+	for _, delAddr := range carriedAddrs {
+		if amount, ok := carried[string(delAddr)]; ok {
+			payouts = append(payouts, types.PreReward{AccAddr: delAddr, Shares: sdk.ZeroDec(), Amount: amount})
+			setInt64(store, GetDustRewardKey(valAddr, delAddr), 0)
+		}
+	}
+	setInt64(store, GetDustRewardTotalKey(valAddr), total)
+	return payouts
+}
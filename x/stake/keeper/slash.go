@@ -106,6 +106,7 @@ func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeigh
 	// Burn the slashed tokens, which are now loose.
 	pool.LooseTokens = pool.LooseTokens.Sub(tokensToBurn)
 	k.SetPool(ctx, pool)
+	k.burnSlashedCoins(ctx, tokensToBurn.RawInt())
 
 	// remove validator if it has no more tokens
 	if validator.DelegatorShares.IsZero() && validator.Status == sdk.Unbonded {
@@ -180,6 +181,7 @@ func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, unbondingDelegation ty
 		// Ref https://github.com/cosmos/cosmos-sdk/pull/1278#discussion_r198657760
 		pool.LooseTokens = pool.LooseTokens.Sub(slashAmount)
 		k.SetPool(ctx, pool)
+		k.burnSlashedCoins(ctx, unbondingSlashAmount)
 	}
 
 	return
@@ -242,7 +244,24 @@ func (k Keeper) slashRedelegation(ctx sdk.Context, validator types.Validator, re
 		pool := k.GetPool(ctx)
 		pool.LooseTokens = pool.LooseTokens.Sub(tokensToBurn)
 		k.SetPool(ctx, pool)
+		k.burnSlashedCoins(ctx, tokensToBurn.RawInt())
 	}
 
 	return slashAmount
 }
+
+// burnSlashedCoins burns the coins of the slashed tokens held by the delegation account, they
+// were left there before sdk.SupplyMintBurn
+func (k Keeper) burnSlashedCoins(ctx sdk.Context, amount int64) {
+	if amount <= 0 || k.supplyKeeper == nil || !sdk.IsUpgrade(sdk.SupplyMintBurn) {
+		return
+	}
+	coins := sdk.Coins{sdk.NewCoin(k.BondDenom(ctx), amount)}
+	if err := k.supplyKeeper.BurnCoins(ctx, types.MsgRoute, coins); err != nil {
+		k.Logger(ctx).Error("failed to burn the slashed tokens", "amount", coins.String(), "err", err.Error())
+		return
+	}
+	if ctx.IsDeliverTx() && k.addrPool != nil {
+		k.addrPool.AddAddrs([]sdk.AccAddress{DelegationAccAddr})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -246,6 +247,38 @@ func TestSlashValidatorAtCurrentHeight(t *testing.T) {
 	require.Equal(t, sdk.NewDecWithoutFra(5), oldPool.BondedTokens.Sub(newPool.BondedTokens))
 }
 
+// tests that the coins of the slashed tokens are burned from the delegation account
+func TestSlashBurnCoins(t *testing.T) {
+	ctx, am, keeper := CreateTestInput(t, false, 10)
+	pool := keeper.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewDecWithoutFra(10).RawInt())
+	pool.BondedTokens = pool.BondedTokens.Add(sdk.NewDecWithoutFra(10))
+	keeper.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+	keeper.SetValidatorByConsAddr(ctx, validator)
+
+	delegated := sdk.Coins{sdk.NewCoin(keeper.BondDenom(ctx), sdk.NewDecWithoutFra(10).RawInt())}
+	_, _, err := keeper.bankKeeper.AddCoins(ctx, DelegationAccAddr, delegated)
+	require.Nil(t, err)
+	supplyKeeper := bank.NewSupplyKeeper(am)
+	supplyKeeper.AddModuleAccount(types.MsgRoute, DelegationAccAddr, bank.PermBurner)
+	keeper.SetSupplyKeeper(&supplyKeeper)
+
+	// the coins of the slashed tokens are left in the delegation account before the upgrade
+	consAddr := sdk.ConsAddress(PKs[0].Address())
+	fraction := sdk.NewDecWithPrec(5, 1)
+	keeper.Slash(ctx, consAddr, ctx.BlockHeight(), sdk.NewDecWithoutFra(10).RawInt(), fraction)
+	require.Equal(t, delegated, keeper.bankKeeper.GetCoins(ctx, DelegationAccAddr))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyMintBurn, 1)
+	defer delete(sdk.UpgradeMgr.Config.HeightMap, sdk.SupplyMintBurn)
+	keeper.Slash(ctx, consAddr, ctx.BlockHeight(), sdk.NewDecWithoutFra(10).RawInt(), fraction)
+	validator = keeper.mustGetValidator(ctx, addrVals[0])
+	require.True(t, validator.GetTokens().IsZero())
+	require.Equal(t, sdk.NewDecWithoutFra(5).RawInt(), keeper.bankKeeper.GetCoins(ctx, DelegationAccAddr).AmountOf(keeper.BondDenom(ctx)))
+}
+
 // tests Slash at a previous height with an unbonding delegation
 func TestSlashWithUnbondingDelegation(t *testing.T) {
 	ctx, keeper, params := setupHelper(t, 10)
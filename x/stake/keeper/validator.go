@@ -123,6 +123,7 @@ func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
 	bz := types.MustMarshalValidator(k.cdc, validator)
 	store.Set(GetValidatorKey(validator.OperatorAddr), bz)
+	k.recordExchangeRate(ctx, validator)
 	// publish validator update
 	if k.PbsbServer != nil && ctx.IsDeliverTx() {
 		k.PbsbServer.Publish(types.ValidatorUpdateEvent{
@@ -252,6 +253,7 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 		store.Delete(GetValidatorByConsAddrKey(sdk.ConsAddress(validator.ConsPubKey.Address())))
 	}
 	store.Delete(GetValidatorsByPowerIndexKey(validator))
+	k.deleteExchangeRateHistory(ctx, address)
 
 	// publish validator update
 	if k.PbsbServer != nil && ctx.IsDeliverTx() {
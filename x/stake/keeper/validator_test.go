@@ -1119,3 +1119,66 @@ func TestUpdateValidatorCommission(t *testing.T) {
 		}
 	}
 }
+
+func TestExchangeRateHistory(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 10)
+	pool := keeper.GetPool(ctx)
+
+	valPubKey := PKs[0]
+	valAddr := sdk.ValAddress(valPubKey.Address().Bytes())
+
+	validator := types.NewValidator(valAddr, valPubKey, types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewDecWithoutFra(10).RawInt())
+	keeper.SetPool(ctx, pool)
+
+	// nothing is recorded before the upgrade
+	keeper.SetValidator(ctx, validator)
+	require.Empty(t, keeper.GetExchangeRateHistory(ctx, valAddr))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ExchangeRateHistory, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.ExchangeRateHistory)
+		sdk.UpgradeMgr.SetHeight(0)
+	}()
+	keeper.SetValidator(ctx, validator)
+
+	// delegating more keeps the rate, so nothing new is recorded
+	ctx = ctx.WithBlockHeight(5)
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewDecWithoutFra(10).RawInt())
+	keeper.SetValidator(ctx, validator)
+
+	// slashing half of the tokens halves the rate
+	ctx = ctx.WithBlockHeight(10)
+	validator, _ = validator.RemoveTokens(pool, sdk.NewDecWithoutFra(10))
+	keeper.SetValidator(ctx, validator)
+
+	history := keeper.GetExchangeRateHistory(ctx, valAddr)
+	require.Equal(t, 2, len(history))
+	require.Equal(t, int64(0), history[0].Height)
+	require.True(sdk.DecEq(t, sdk.OneDec(), history[0].Rate))
+	require.Equal(t, int64(10), history[1].Height)
+	require.True(sdk.DecEq(t, sdk.NewDecWithPrec(5, 1), history[1].Rate))
+
+	rate, found := keeper.GetExchangeRateAtHeight(ctx, valAddr, 9)
+	require.True(t, found)
+	require.Equal(t, int64(0), rate.Height)
+	rate, found = keeper.GetLastExchangeRate(ctx, valAddr)
+	require.True(t, found)
+	require.Equal(t, int64(10), rate.Height)
+
+	// only the most recent changes are kept
+	for i := int64(1); i <= MaxExchangeRateHistory; i++ {
+		ctx = ctx.WithBlockHeight(10 + i)
+		validator, _ = validator.RemoveTokens(pool, sdk.NewDecWithPrec(1, 2))
+		keeper.SetValidator(ctx, validator)
+	}
+	history = keeper.GetExchangeRateHistory(ctx, valAddr)
+	require.Equal(t, MaxExchangeRateHistory, len(history))
+	require.Equal(t, int64(11), history[0].Height)
+	_, found = keeper.GetExchangeRateAtHeight(ctx, valAddr, 10)
+	require.False(t, found)
+
+	keeper.RemoveValidator(ctx, valAddr)
+	require.Empty(t, keeper.GetExchangeRateHistory(ctx, valAddr))
+}
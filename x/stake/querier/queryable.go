@@ -2,6 +2,7 @@ package querier
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -30,6 +31,9 @@ const (
 	QueryTopValidators                 = "topValidators"
 	QueryAllValidatorsCount            = "allValidatorsCount"
 	QueryAllUnJailValidatorsCount      = "allUnJailValidatorsCount"
+	QueryValidatorExchangeRate         = "validatorExchangeRate"
+	QueryValidatorExchangeRateHistory  = "validatorExchangeRateHistory"
+	QueryBondedAverage                 = "bondedAverage"
 )
 
 // creates a querier for staking REST endpoints
@@ -120,6 +124,13 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryPool(ctx, cdc, k)
+		case QueryBondedAverage:
+			p := new(BaseParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryBondedAverage(ctx, cdc, k)
 		case QueryParameters:
 			p := new(BaseParams)
 			ctx, err = RequestPrepare(ctx, k, req, p)
@@ -148,6 +159,20 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryAllUnJailValidatorsCount(ctx, cdc, k)
+		case QueryValidatorExchangeRate:
+			p := new(QueryExchangeRateParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryValidatorExchangeRate(ctx, cdc, p, k)
+		case QueryValidatorExchangeRateHistory:
+			p := new(QueryValidatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryValidatorExchangeRateHistory(ctx, cdc, p, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown stake query endpoint")
 		}
@@ -223,6 +248,14 @@ type QueryTopValidatorsParams struct {
 	Top int
 }
 
+// defines the params for 'custom/stake/validatorExchangeRate'
+// a zero Height queries the current exchange rate
+type QueryExchangeRateParams struct {
+	BaseParams
+	ValidatorAddr sdk.ValAddress
+	Height        int64
+}
+
 func queryValidators(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
 	stakeParams := k.GetParams(ctx)
 	validators := k.GetValidators(ctx, stakeParams.MaxValidators)
@@ -269,6 +302,39 @@ func queryValidatorRedelegations(ctx sdk.Context, cdc *codec.Codec, params *Quer
 	return res, nil
 }
 
+func queryValidatorExchangeRate(ctx sdk.Context, cdc *codec.Codec, params *QueryExchangeRateParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	var rate types.ExchangeRate
+	if params.Height == 0 {
+		validator, found := k.GetValidator(ctx, params.ValidatorAddr)
+		if !found {
+			return nil, types.ErrNoValidatorFound(types.DefaultCodespace)
+		}
+		rate = types.ExchangeRate{Height: ctx.BlockHeight(), Rate: validator.DelegatorShareExRate()}
+	} else {
+		var found bool
+		rate, found = k.GetExchangeRateAtHeight(ctx, params.ValidatorAddr, params.Height)
+		if !found {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("no exchange rate of validator %s recorded at height %d", params.ValidatorAddr, params.Height))
+		}
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, rate)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryValidatorExchangeRateHistory(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	rates := k.GetExchangeRateHistory(ctx, params.ValidatorAddr)
+
+	res, errRes := codec.MarshalJSONIndent(cdc, rates)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func queryDelegatorDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
 	delegations := k.GetAllDelegatorDelegations(ctx, params.DelegatorAddr)
 	delResponses, err := delegationsToDelegationResponses(ctx, k, delegations)
@@ -370,6 +436,19 @@ func queryPool(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, er
 	return res, nil
 }
 
+func queryBondedAverage(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
+	average, found := k.GetBondedAverage(ctx)
+	if !found || k.BondedAverageWindow(ctx) == 0 {
+		average = types.NewBondedAverage(k.GetPool(ctx).BondedTokens, ctx.BlockHeader().Time)
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, average)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func queryParameters(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
 
 	params := k.GetParams(ctx)
@@ -14,7 +14,7 @@ import (
 )
 
 // AllInvariants runs all invariants of the stake module.
-// Currently: total supply, positive power
+// Currently: total supply, positive power, module delegations
 func AllInvariants(ck bank.Keeper, k stake.Keeper, d distribution.Keeper, am auth.AccountKeeper) simulation.Invariant {
 	return func(app *baseapp.BaseApp) error {
 		err := SupplyInvariants(ck, k, d, am)(app)
@@ -26,6 +26,10 @@ func AllInvariants(ck bank.Keeper, k stake.Keeper, d distribution.Keeper, am aut
 			return err
 		}
 		err = ValidatorSetInvariant(k)(app)
+		if err != nil {
+			return err
+		}
+		err = ModuleDelegationInvariant(k)(app)
 		return err
 	}
 }
@@ -111,3 +115,11 @@ func ValidatorSetInvariant(k stake.Keeper) simulation.Invariant {
 		return nil
 	}
 }
+
+// ModuleDelegationInvariant checks that the delegations of the registered module delegators are consistent
+func ModuleDelegationInvariant(k stake.Keeper) simulation.Invariant {
+	return func(app *baseapp.BaseApp) error {
+		ctx := app.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		return k.CheckModuleDelegations(ctx)
+	}
+}
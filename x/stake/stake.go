@@ -9,10 +9,12 @@ import (
 
 type (
 	Keeper                     = keeper.Keeper
+	ModuleDelegator            = keeper.ModuleDelegator
 	Validator                  = types.Validator
 	Description                = types.Description
 	Commission                 = types.Commission
 	Delegation                 = types.Delegation
+	ExchangeRate               = types.ExchangeRate
 	UnbondingDelegation        = types.UnbondingDelegation
 	Redelegation               = types.Redelegation
 	Params                     = types.Params
@@ -30,6 +32,7 @@ type (
 	QueryBondsParams           = querier.QueryBondsParams
 	CreateValidatorJsonMsg     = types.CreateValidatorJsonMsg
 	QueryTopValidatorsParams   = querier.QueryTopValidatorsParams
+	QueryExchangeRateParams    = querier.QueryExchangeRateParams
 	BaseParams                 = querier.BaseParams
 
 	MsgCreateSideChainValidator = types.MsgCreateSideChainValidator
@@ -129,7 +132,8 @@ var (
 	NewQuerier    = querier.NewQuerier
 	NewBaseParams = querier.NewBaseParams
 
-	DelegationAccAddr = keeper.DelegationAccAddr
+	DelegationAccAddr      = keeper.DelegationAccAddr
+	ModuleDelegatorAddress = keeper.ModuleDelegatorAddress
 )
 
 const (
@@ -146,6 +150,8 @@ const (
 	QueryDelegatorValidator            = querier.QueryDelegatorValidator
 	QueryPool                          = querier.QueryPool
 	QueryParameters                    = querier.QueryParameters
+	QueryValidatorExchangeRate         = querier.QueryValidatorExchangeRate
+	QueryValidatorExchangeRateHistory  = querier.QueryValidatorExchangeRateHistory
 
 	Topic = types.Topic
 )
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BondedAverage is the time-weighted moving average of the bonded tokens,
+// it smooths out the short-term bonding and unbonding spikes.
+type BondedAverage struct {
+	Average    sdk.Dec   `json:"average"`     // the time-weighted average of the bonded tokens
+	LastBonded sdk.Dec   `json:"last_bonded"` // the bonded tokens observed at the last update
+	LastTime   time.Time `json:"last_time"`   // the block time of the last update
+}
+
+// NewBondedAverage starts an average at the bonded tokens of the given time
+func NewBondedAverage(bonded sdk.Dec, now time.Time) BondedAverage {
+	return BondedAverage{
+		Average:    bonded,
+		LastBonded: bonded,
+		LastTime:   now,
+	}
+}
+
+// Update folds the bonded tokens held since the last update into the average.
+// The weight of the elapsed time is relative to the window and capped at one,
+// so the average follows the bonded tokens within about a window.
+func (a BondedAverage) Update(bonded sdk.Dec, now time.Time, window time.Duration) BondedAverage {
+	elapsed := now.Sub(a.LastTime)
+	if elapsed > 0 {
+		weight := sdk.OneDec()
+		if elapsed < window {
+			weight = sdk.NewDec(int64(elapsed)).Quo(sdk.NewDec(int64(window)))
+		}
+		a.Average = a.Average.Add(a.LastBonded.Sub(a.Average).Mul(weight))
+		a.LastTime = now
+	}
+	a.LastBonded = bonded
+	return a
+}
+
+// HumanReadableString returns a human readable string representation of the average
+func (a BondedAverage) HumanReadableString() string {
+	resp := "Bonded Tokens Average \n"
+	resp += fmt.Sprintf("Average: %s\n", a.Average)
+	resp += fmt.Sprintf("Last Bonded Tokens: %s\n", a.LastBonded)
+	resp += fmt.Sprintf("Last Update Time: %s\n", a.LastTime)
+	return resp
+}
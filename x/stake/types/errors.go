@@ -152,6 +152,14 @@ func ErrNotEnoughDelegationShares(codespace sdk.CodespaceType, shares string) sd
 	return sdk.NewError(codespace, CodeInvalidDelegation, fmt.Sprintf("not enough shares only have %v", shares))
 }
 
+func ErrModuleDelegatorNotRegistered(codespace sdk.CodespaceType, module string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, fmt.Sprintf("module %s is not registered as a delegator", module))
+}
+
+func ErrDelegationCapExceeded(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, "delegation cap exceeded: "+msg)
+}
+
 func ErrBadSharesAmount(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidDelegation, "shares must be > 0")
 }
@@ -0,0 +1,12 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExchangeRate is the token/share exchange rate of a validator which took effect at Height.
+// A drop of the rate between two records means the validator was slashed in between.
+type ExchangeRate struct {
+	Height int64   `json:"height"`
+	Rate   sdk.Dec `json:"rate"`
+}
@@ -30,6 +30,15 @@ const (
 
 	// defaultRewardDistributionBatchSize represents the default batch size for distributing delegators' staking rewards in blocks
 	defaultRewardDistributionBatchSize = 1000
+
+	// defaultMinRewardPayout represents the default minimal reward paid to a delegator, every reward is paid by default
+	defaultMinRewardPayout int64 = 0
+
+	// defaultBondedAverageWindow represents the default window of the bonded tokens average, the average is disabled by default
+	defaultBondedAverageWindow time.Duration = 0
+
+	// defaultMaxDelegationPerValidator represents the default cap of the tokens a delegator may delegate to a validator, no cap by default
+	defaultMaxDelegationPerValidator int64 = 0
 )
 
 // nolint - Keys for parameter access
@@ -40,6 +49,10 @@ var (
 	KeyMinSelfDelegation           = []byte("MinSelfDelegation")
 	KeyMinDelegationChange         = []byte("MinDelegationChanged")
 	KeyRewardDistributionBatchSize = []byte("RewardDistributionBatchSize")
+	KeyMinRewardPayout             = []byte("MinRewardPayout")
+	KeyBondedAverageWindow         = []byte("BondedAverageWindow")
+	KeyMaxDelegationPerValidator   = []byte("MaxDelegationPerValidator")
+	KeyMaxDelegationFraction       = []byte("MaxDelegationFraction")
 )
 
 var _ params.ParamSet = (*Params)(nil)
@@ -53,6 +66,12 @@ type Params struct {
 	MinSelfDelegation           int64  `json:"min_self_delegation"`            // the minimal self-delegation amount
 	MinDelegationChange         int64  `json:"min_delegation_change"`          // the minimal delegation amount changed
 	RewardDistributionBatchSize int64  `json:"reward_distribution_batch_size"` // the batch size for distributing rewards in blocks
+	MinRewardPayout             int64  `json:"min_reward_payout"`              // the rewards below are carried forward to the next distribution
+
+	BondedAverageWindow time.Duration `json:"bonded_average_window"` // the window of the time-weighted average of the bonded tokens
+
+	MaxDelegationPerValidator int64     `json:"max_delegation_per_validator"` // the max tokens a delegator may delegate to a validator, 0 for no cap
+	MaxDelegationFraction     types.Dec `json:"max_delegation_fraction"`      // the max fraction of the tokens of a validator delegated by a delegator, 0 for no cap
 }
 
 func (p *Params) GetParamAttribute() (string, bool) {
@@ -84,6 +103,23 @@ func (p *Params) UpdateCheck() error {
 		return fmt.Errorf("the reward_distribution_batch_size should be in range 1000 to 5000")
 	}
 
+	if p.MinRewardPayout < 0 || p.MinRewardPayout > 1e8 {
+		return fmt.Errorf("the min_reward_payout should be in range 0 to 1e8")
+	}
+
+	// zero disables the average, otherwise the valid range is 1 hour to 30 days.
+	if p.BondedAverageWindow != 0 && (p.BondedAverageWindow < time.Hour || p.BondedAverageWindow > 30*24*time.Hour) {
+		return fmt.Errorf("the bonded_average_window should be 0 or in range 1 hour to 30 days")
+	}
+
+	// zero disables the caps.
+	if p.MaxDelegationPerValidator < 0 {
+		return fmt.Errorf("the max_delegation_per_validator should not be negative")
+	}
+	if p.MaxDelegationFraction.LT(types.ZeroDec()) || p.MaxDelegationFraction.GT(types.OneDec()) {
+		return fmt.Errorf("the max_delegation_fraction should be in range 0 to 1")
+	}
+
 	return nil
 }
 
@@ -96,6 +132,10 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 		{KeyMinSelfDelegation, &p.MinSelfDelegation},
 		{KeyMinDelegationChange, &p.MinDelegationChange},
 		{KeyRewardDistributionBatchSize, &p.RewardDistributionBatchSize},
+		{KeyMinRewardPayout, &p.MinRewardPayout},
+		{KeyBondedAverageWindow, &p.BondedAverageWindow},
+		{KeyMaxDelegationPerValidator, &p.MaxDelegationPerValidator},
+		{KeyMaxDelegationFraction, &p.MaxDelegationFraction},
 	}
 }
 
@@ -115,6 +155,10 @@ func DefaultParams() Params {
 		MinSelfDelegation:           defaultMinSelfDelegation,
 		MinDelegationChange:         defaultMinDelegationChange,
 		RewardDistributionBatchSize: defaultRewardDistributionBatchSize,
+		MinRewardPayout:             defaultMinRewardPayout,
+		BondedAverageWindow:         defaultBondedAverageWindow,
+		MaxDelegationPerValidator:   defaultMaxDelegationPerValidator,
+		MaxDelegationFraction:       types.ZeroDec(),
 	}
 }
 
@@ -129,6 +173,10 @@ func (p Params) HumanReadableString() string {
 	resp += fmt.Sprintf("Minimal self-delegation amount: %d\n", p.MinSelfDelegation)
 	resp += fmt.Sprintf("The minimum value allowed to change the delegation amount: %d\n", p.MinDelegationChange)
 	resp += fmt.Sprintf("The batch size to distribute staking rewards: %d\n", p.RewardDistributionBatchSize)
+	resp += fmt.Sprintf("The minimal staking reward paid out: %d\n", p.MinRewardPayout)
+	resp += fmt.Sprintf("The window of the bonded tokens average: %s\n", p.BondedAverageWindow)
+	resp += fmt.Sprintf("The max tokens delegated to a validator by a delegator: %d\n", p.MaxDelegationPerValidator)
+	resp += fmt.Sprintf("The max fraction of the tokens of a validator delegated by a delegator: %s\n", p.MaxDelegationFraction)
 	return resp
 }
 